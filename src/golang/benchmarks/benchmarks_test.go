@@ -1,6 +1,7 @@
 package benchmarks
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -17,19 +18,18 @@ const benchConnString = "syndrdb://localhost:1776:primary:root:root;"
 func BenchmarkConnectionEstablishment(b *testing.B) {
 	b.ReportAllocs()
 
+	opts := benchOptions(b)
+	ctx := context.Background()
+
 	for i := 0; i < b.N; i++ {
-		c := client.NewClient(&client.ClientOptions{
-			DefaultTimeoutMs: 10000,
-			DebugMode:        false,
-			MaxRetries:       3,
-		})
+		c := client.NewClient(opts)
 
-		err := c.Connect(benchConnString)
+		err := c.Connect(ctx, benchConnString)
 		if err != nil {
 			b.Fatalf("Failed to connect: %v", err)
 		}
 
-		err = c.Disconnect()
+		err = c.Disconnect(ctx)
 		if err != nil {
 			b.Fatalf("Failed to disconnect: %v", err)
 		}
@@ -38,17 +38,7 @@ func BenchmarkConnectionEstablishment(b *testing.B) {
 
 // BenchmarkSimpleQuery measures query execution time
 func BenchmarkSimpleQuery(b *testing.B) {
-	c := client.NewClient(&client.ClientOptions{
-		DefaultTimeoutMs: 10000,
-		DebugMode:        false,
-		MaxRetries:       3,
-	})
-
-	err := c.Connect(benchConnString)
-	if err != nil {
-		b.Fatalf("Failed to connect: %v", err)
-	}
-	defer c.Disconnect()
+	c := newBenchClient(b)
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -63,17 +53,7 @@ func BenchmarkSimpleQuery(b *testing.B) {
 
 // BenchmarkMutation measures mutation execution time
 func BenchmarkMutation(b *testing.B) {
-	c := client.NewClient(&client.ClientOptions{
-		DefaultTimeoutMs: 10000,
-		DebugMode:        false,
-		MaxRetries:       3,
-	})
-
-	err := c.Connect(benchConnString)
-	if err != nil {
-		b.Fatalf("Failed to connect: %v", err)
-	}
-	defer c.Disconnect()
+	c := newBenchClient(b)
 
 	// Setup: Create a test bundle
 	createCmd := `CREATE BUNDLE "bench_test" WITH FIELDS (
@@ -81,7 +61,7 @@ func BenchmarkMutation(b *testing.B) {
 		name STRING REQUIRED,
 		value INT
 	);`
-	_, err = c.Mutate(createCmd, 10000)
+	_, err := c.Mutate(createCmd, 10000)
 	if err != nil {
 		b.Fatalf("Failed to create bundle: %v", err)
 	}
@@ -0,0 +1,151 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// BenchmarkPooledQuery measures query throughput from parallel goroutines by pool size
+func BenchmarkPooledQuery(b *testing.B) {
+	for _, size := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("Pool%d", size), func(b *testing.B) {
+			opts := benchOptions(b)
+			opts.PoolMinSize = size
+			opts.PoolMaxSize = size
+			c := connectBench(b, opts)
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := c.Query("SHOW BUNDLES;", 10000); err != nil {
+						b.Errorf("Query failed: %v", err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkQueryBuilderExecute measures building and executing a typical SELECT
+func BenchmarkQueryBuilderExecute(b *testing.B) {
+	c := newBenchClient(b)
+	ensureBenchBundle(c)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := c.QueryBuilder().
+			Select("bench_test", "id", "name", "value").
+			Where("value", client.GreaterThan, i%100).
+			And("name", client.Like, "test_%").
+			OrderBy("id", client.Descending).
+			Limit(10).
+			Execute(ctx)
+		if err != nil {
+			b.Fatalf("Execute failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkParameters compares inlined builder parameters with prepared execution
+func BenchmarkParameters(b *testing.B) {
+	const query = `SELECT * FROM BUNDLE "bench_test" WHERE "id" == $1;`
+
+	b.Run("Inline", func(b *testing.B) {
+		c := newBenchClient(b)
+		ensureBenchBundle(c)
+		ctx := context.Background()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, err := c.QueryBuilder().Select("bench_test").Where("id", client.Equals, i).Execute(ctx)
+			if err != nil {
+				b.Fatalf("Execute failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("Prepared", func(b *testing.B) {
+		c := newBenchClient(b)
+		ensureBenchBundle(c)
+		stmt, err := c.Prepare(context.Background(), "bench_by_id", query)
+		if err != nil {
+			b.Fatalf("Prepare failed: %v", err)
+		}
+		defer stmt.Close()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := stmt.Execute(i); err != nil {
+				b.Fatalf("Execute failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("PreparePerCall", func(b *testing.B) {
+		c := newBenchClient(b)
+		ensureBenchBundle(c)
+		ctx := context.Background()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := c.QueryWithParams(ctx, query, i); err != nil {
+				b.Fatalf("QueryWithParams failed: %v", err)
+			}
+		}
+	})
+}
+
+// BenchmarkHookOverhead measures end-to-end query cost with the built-in hooks registered
+func BenchmarkHookOverhead(b *testing.B) {
+	cases := []struct {
+		name  string
+		hooks func() []client.Hook
+	}{
+		{"None", func() []client.Hook { return nil }},
+		{"Logging", func() []client.Hook {
+			return []client.Hook{client.NewLoggingHook(client.NewNoopLogger(), true, true, true)}
+		}},
+		{"Metrics", func() []client.Hook {
+			return []client.Hook{client.NewMetricsHook()}
+		}},
+		{"LoggingAndMetrics", func() []client.Hook {
+			return []client.Hook{
+				client.NewLoggingHook(client.NewNoopLogger(), true, true, true),
+				client.NewMetricsHook(),
+			}
+		}},
+	}
+
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			c := newBenchClient(b)
+			for _, hook := range tc.hooks() {
+				c.RegisterHook(hook)
+			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := c.Query("SHOW BUNDLES;", 10000); err != nil {
+					b.Fatalf("Query failed: %v", err)
+				}
+			}
+		})
+	}
+}
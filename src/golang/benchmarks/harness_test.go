@@ -0,0 +1,67 @@
+package benchmarks
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client/clienttest"
+)
+
+// mock runs the client benchmarks against an in-memory server instead of a live
+// one at benchConnString, so they can run in CI:
+//
+//	go test -bench . ./benchmarks -mock
+var mock = flag.Bool("mock", false, "run client benchmarks against an in-memory server")
+
+// benchRows is the result set the mock server returns for every command.
+var benchRows = []map[string]interface{}{
+	{"id": 1, "name": "test_1", "value": 10},
+	{"id": 2, "name": "test_2", "value": 20},
+	{"id": 3, "name": "test_3", "value": 30},
+}
+
+// benchOptions returns the options for a benchmark client, starting from the
+// defaults. With -mock the client dials a fresh in-memory server.
+func benchOptions(b *testing.B) *client.ClientOptions {
+	b.Helper()
+
+	opts := client.DefaultOptions()
+	opts.Logger = client.NewNoopLogger()
+	if *mock {
+		server := clienttest.NewServer()
+		server.On("*").Return(benchRows)
+		b.Cleanup(func() { server.Close() })
+		opts.Dialer = server.Dial
+	}
+	return &opts
+}
+
+// connectBench connects a client with opts, disconnecting it when the benchmark ends.
+func connectBench(b *testing.B, opts *client.ClientOptions) *client.Client {
+	b.Helper()
+
+	c := client.NewClient(opts)
+	if err := c.Connect(context.Background(), benchConnString); err != nil {
+		b.Fatalf("Failed to connect: %v", err)
+	}
+	b.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c
+}
+
+// newBenchClient returns a connected client with default options.
+func newBenchClient(b *testing.B) *client.Client {
+	b.Helper()
+	return connectBench(b, benchOptions(b))
+}
+
+// ensureBenchBundle creates the bundle the builder benchmarks read from.
+// An existing bundle is fine, so the error is ignored.
+func ensureBenchBundle(c *client.Client) {
+	_, _ = c.Mutate(`CREATE BUNDLE "bench_test" WITH FIELDS (
+		id INT REQUIRED UNIQUE,
+		name STRING REQUIRED,
+		value INT
+	);`, 10000)
+}
@@ -0,0 +1,270 @@
+package client
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Operation is a kind of command an AccessPolicy can allow or deny.
+type Operation string
+
+const (
+	OperationRead   Operation = "read"   // SELECT, SHOW, EXPLAIN
+	OperationInsert Operation = "insert" // ADD DOCUMENT, INSERT
+	OperationUpdate Operation = "update" // UPDATE DOCUMENTS
+	OperationDelete Operation = "delete" // DELETE DOCUMENTS
+	OperationSchema Operation = "schema" // CREATE, DROP, ALTER
+)
+
+// BundlePolicy restricts the commands allowed on a bundle.
+type BundlePolicy struct {
+	// ReadOnly allows only OperationRead.
+	ReadOnly bool
+
+	// Operations lists the operations allowed; empty allows every operation.
+	Operations []Operation
+
+	// ForbiddenFields are case-insensitive field-name patterns using
+	// path.Match syntax, e.g. "password_hash" or "internal_*", that commands on
+	// the bundle may not read, write or filter on.
+	ForbiddenFields []string
+}
+
+// allows reports whether op is allowed on the bundle.
+func (p *BundlePolicy) allows(op Operation) bool {
+	if p.ReadOnly && op != OperationRead {
+		return false
+	}
+	if len(p.Operations) == 0 {
+		return true
+	}
+	for _, allowed := range p.Operations {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// forbiddenField returns the first of fields the policy forbids, or "".
+func (p *BundlePolicy) forbiddenField(fields []string) string {
+	for _, field := range fields {
+		for _, pattern := range p.ForbiddenFields {
+			if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(field)); ok {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+// AccessPolicy enforces per-bundle restrictions in the client, rejecting
+// violating commands with E_POLICY_VIOLATION (errors.Is(err,
+// ErrPolicyViolation)) before they are sent. It is meant for sandboxing
+// plugins or extensions that embed the driver, not as a substitute for
+// server-side permissions: commands are checked by their text, so a policy
+// is only as precise as SyndrQL parsing allows.
+//
+// Commands that are not reads, writes or schema changes, such as BEGIN or
+// USE DATABASE, are always allowed. It is safe to change a policy while
+// commands run.
+type AccessPolicy struct {
+	mu      sync.RWMutex
+	bundles map[string]*BundlePolicy // Lower-cased bundle name -> policy
+	def     *BundlePolicy
+}
+
+// NewAccessPolicy returns a policy that allows everything until bundles are
+// restricted with SetBundle or SetDefault.
+func NewAccessPolicy() *AccessPolicy {
+	return &AccessPolicy{bundles: make(map[string]*BundlePolicy)}
+}
+
+// SetBundle restricts the commands allowed on bundle, matched
+// case-insensitively. A nil policy removes the bundle's restrictions, so the
+// default policy applies to it again.
+func (p *AccessPolicy) SetBundle(bundle string, policy *BundlePolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if policy == nil {
+		delete(p.bundles, strings.ToLower(bundle))
+		return
+	}
+	p.bundles[strings.ToLower(bundle)] = policy
+}
+
+// SetDefault sets the policy for bundles without one of their own, and for
+// commands whose bundle cannot be determined. Nil allows them.
+func (p *AccessPolicy) SetDefault(policy *BundlePolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.def = policy
+}
+
+// policyFor returns the policy governing bundle, or nil if it is unrestricted.
+func (p *AccessPolicy) policyFor(bundle string) *BundlePolicy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if policy, ok := p.bundles[strings.ToLower(bundle)]; ok {
+		return policy
+	}
+	return p.def
+}
+
+// Check returns an E_POLICY_VIOLATION error if the policy forbids command.
+// The bundle a command targets is checked for the command's operation, and
+// the bundles it joins for OperationRead.
+func (p *AccessPolicy) Check(command string) error {
+	if p == nil {
+		return nil
+	}
+	op := commandOperation(command)
+	if op == "" {
+		return nil
+	}
+
+	bundles := policyBundles(command)
+	if len(bundles) == 0 {
+		bundles = []string{""}
+	}
+	fields := commandFields(command)
+	for i, bundle := range bundles {
+		policy := p.policyFor(bundle)
+		if policy == nil {
+			continue
+		}
+		bundleOp := op
+		if i > 0 {
+			bundleOp = OperationRead
+		}
+		if !policy.allows(bundleOp) {
+			return policyViolation(command, bundle, bundleOp, "",
+				fmt.Sprintf("%s is not allowed on bundle %q", bundleOp, bundle))
+		}
+		if field := policy.forbiddenField(fields); field != "" {
+			return policyViolation(command, bundle, bundleOp, field,
+				fmt.Sprintf("field %q of bundle %q is forbidden", field, bundle))
+		}
+	}
+	return nil
+}
+
+// policyViolation returns the E_POLICY_VIOLATION error for command.
+func policyViolation(command, bundle string, op Operation, field, message string) error {
+	details := map[string]interface{}{
+		"bundle":    bundle,
+		"operation": string(op),
+	}
+	if field != "" {
+		details["field"] = field
+	}
+	return &QueryError{
+		Code:    "E_POLICY_VIOLATION",
+		Type:    "QueryError",
+		Message: "access policy violation: " + message,
+		Query:   command,
+		Details: details,
+	}
+}
+
+// commandOperation classifies command, or returns "" for commands an
+// AccessPolicy does not restrict.
+func commandOperation(command string) Operation {
+	upper := strings.ToUpper(strings.TrimSpace(command))
+	switch {
+	case strings.HasPrefix(upper, "SELECT"), strings.HasPrefix(upper, "SHOW"), strings.HasPrefix(upper, "EXPLAIN"):
+		return OperationRead
+	case strings.HasPrefix(upper, "ADD "), strings.HasPrefix(upper, "INSERT"):
+		return OperationInsert
+	case strings.HasPrefix(upper, "UPDATE"):
+		return OperationUpdate
+	case strings.HasPrefix(upper, "DELETE"):
+		return OperationDelete
+	case strings.HasPrefix(upper, "CREATE"), strings.HasPrefix(upper, "DROP"), strings.HasPrefix(upper, "ALTER"):
+		return OperationSchema
+	default:
+		return ""
+	}
+}
+
+// policyTargetPattern extends bundlePattern with ADD DOCUMENT TO "users",
+// the form the InsertBuilder renders.
+var policyTargetPattern = regexp.MustCompile(`(?i)\b(?:FROM|BUNDLE|INTO|TO)\s+(?:BUNDLE\s+)?"?([A-Za-z_][A-Za-z0-9_.\-]*)"?`)
+
+// policyBundles returns the bundle command targets followed by the bundles
+// it joins, without duplicates.
+func policyBundles(command string) []string {
+	var bundles []string
+	seen := make(map[string]bool)
+	add := func(bundle string) {
+		if !seen[strings.ToLower(bundle)] {
+			seen[strings.ToLower(bundle)] = true
+			bundles = append(bundles, bundle)
+		}
+	}
+	if match := policyTargetPattern.FindStringSubmatch(command); match != nil {
+		add(match[1])
+	}
+	for _, match := range joinTargetPattern.FindAllStringSubmatch(command, -1) {
+		add(match[1])
+	}
+	return bundles
+}
+
+// commandFields returns the field names command may reference: bare
+// identifiers outside string literals, and quoted names followed by an
+// assignment, comparison or operator keyword, as in {"name" = ...} or
+// "age" >= 18. Other quoted strings are taken to be values or bundle names.
+func commandFields(command string) []string {
+	var fields []string
+	for i := 0; i < len(command); {
+		ch := command[i]
+		switch {
+		case ch == '"' || ch == '\'':
+			end := i + 1
+			for end < len(command) && command[end] != ch {
+				if command[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if ch == '"' && end < len(command) && followedByOperator(command[end+1:]) {
+				fields = append(fields, command[i+1:end])
+			}
+			i = end + 1
+		case ch == '_' || ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z':
+			end := i + 1
+			for end < len(command) && isIdentifierByte(command[end]) {
+				end++
+			}
+			fields = append(fields, command[i:end])
+			if dot := strings.LastIndexByte(command[i:end], '.'); dot >= 0 {
+				fields = append(fields, command[i+dot+1:end]) // users.email
+			}
+			i = end
+		case ch == '$' || ch >= '0' && ch <= '9':
+			// Placeholders and numbers
+			for i++; i < len(command) && isIdentifierByte(command[i]); i++ {
+			}
+		default:
+			i++
+		}
+	}
+	return fields
+}
+
+// isIdentifierByte reports whether b may continue an identifier.
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b == '.' || b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9'
+}
+
+// fieldOperatorPattern matches what follows a quoted field name.
+var fieldOperatorPattern = regexp.MustCompile(`(?i)^\s*(?:[=!<>:]|(?:IN|NOT|LIKE|CONTAINS|IS|BETWEEN|ASC|DESC)\b)`)
+
+// followedByOperator reports whether rest starts with an operator.
+func followedByOperator(rest string) bool {
+	return fieldOperatorPattern.MatchString(rest)
+}
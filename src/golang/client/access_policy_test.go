@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestAccessPolicyCheck verifies operations, bundles and fields are enforced.
+func TestAccessPolicyCheck(t *testing.T) {
+	policy := NewAccessPolicy()
+	policy.SetBundle("audit_log", &BundlePolicy{ReadOnly: true})
+	policy.SetBundle("Users", &BundlePolicy{
+		Operations:      []Operation{OperationRead, OperationUpdate},
+		ForbiddenFields: []string{"password_hash", "internal_*"},
+	})
+
+	tests := []struct {
+		command string
+		allowed bool
+	}{
+		{`SELECT * FROM audit_log WHERE level = $1;`, true},
+		{`ADD DOCUMENT TO BUNDLE "audit_log" WITH ({"level"="info"});`, false},
+		{`DROP BUNDLE "audit_log";`, false},
+		{`SELECT id, name FROM users WHERE name = $1;`, true},
+		{`SELECT id, password_hash FROM users;`, false},
+		{`SELECT * FROM users ORDER BY "internal_score" DESC;`, false},
+		{`UPDATE DOCUMENTS IN BUNDLE "users" ("name" = "password_hash") WHERE "id" == 1;`, true},
+		{`UPDATE DOCUMENTS IN BUNDLE "users" ("password_hash" = "x") WHERE "id" == 1;`, false},
+		{`ADD DOCUMENT TO "users" ("name" = "Ada");`, false},
+		{`DELETE DOCUMENTS FROM "users" WHERE "id" == 1;`, false},
+		{`SELECT * FROM orders LEFT JOIN "users" ON orders.user_id = users.id;`, true},
+		{`SELECT orders.id, users.password_hash FROM orders LEFT JOIN "users" ON orders.user_id = users.id;`, false},
+		{`DELETE DOCUMENTS FROM "orders" WHERE "id" == 1;`, true},
+		{`BEGIN TRANSACTION;`, true},
+	}
+	for _, tt := range tests {
+		err := policy.Check(tt.command)
+		if tt.allowed && err != nil {
+			t.Errorf("%s: expected allowed, got %v", tt.command, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrPolicyViolation) {
+			t.Errorf("%s: expected a policy violation, got %v", tt.command, err)
+		}
+	}
+
+	// The default applies to bundles without a policy of their own
+	policy.SetDefault(&BundlePolicy{ReadOnly: true})
+	if err := policy.Check(`DELETE DOCUMENTS FROM "orders" WHERE "id" == 1;`); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("expected the default policy to apply, got %v", err)
+	}
+	policy.SetBundle("audit_log", nil)
+	if err := policy.Check(`SHOW BUNDLES;`); err != nil {
+		t.Errorf("expected reads allowed by the default policy, got %v", err)
+	}
+}
+
+// TestAccessPolicyRejectsLocally verifies violating commands are not sent.
+func TestAccessPolicyRejectsLocally(t *testing.T) {
+	c, server := newPipeClient(t, func(string) string { return `{"success":true,"data":{}}` })
+	policy := NewAccessPolicy()
+	policy.SetBundle("users", &BundlePolicy{ReadOnly: true})
+	c.opts.AccessPolicy = policy
+
+	_, err := c.Mutate(`DELETE DOCUMENTS FROM BUNDLE "users" WHERE ("id" == 1);`, 1000)
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) || queryErr.Code != "E_POLICY_VIOLATION" {
+		t.Fatalf("expected E_POLICY_VIOLATION, got %v", err)
+	}
+	if queryErr.Details["bundle"] != "users" || queryErr.Details["operation"] != "delete" {
+		t.Errorf("unexpected details: %v", queryErr.Details)
+	}
+
+	p := c.Pipeline()
+	p.Add(`SELECT * FROM users;`)
+	p.Add(`UPDATE DOCUMENTS IN BUNDLE "users" ("name" = "Ada") WHERE ("id" == 1);`)
+	results, err := p.Execute(context.Background())
+	if !errors.Is(err, ErrPolicyViolation) || results[0].Error != nil {
+		t.Errorf("expected only the pipelined write to be rejected, got %v", err)
+	}
+
+	if _, err := c.Query(`SELECT * FROM users;`, 1000); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if got := server.received(); len(got) != 2 {
+		t.Errorf("expected only the two reads sent, got %q", got)
+	}
+}
+
+// TestAccessPolicyCachedQuery verifies a cached result is not served once a
+// policy forbids the query.
+func TestAccessPolicyCachedQuery(t *testing.T) {
+	c, server := newPipeClient(t, func(string) string {
+		return `{"success":true,"data":{"Result":[{"email":"ada@example.com"}]}}`
+	})
+	policy := NewAccessPolicy()
+	c.opts.AccessPolicy = policy
+
+	query := c.QueryBuilder().Select("users").Where("email", Equals, "ada@example.com").Cached(time.Minute)
+	if _, err := query.Execute(context.Background()); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+
+	policy.SetBundle("users", &BundlePolicy{ForbiddenFields: []string{"email"}})
+	if _, err := query.Execute(context.Background()); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected E_POLICY_VIOLATION for a cached query, got %v", err)
+	}
+
+	policy.SetBundle("users", &BundlePolicy{Operations: []Operation{OperationInsert}})
+	if _, err := query.Execute(context.Background()); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected E_POLICY_VIOLATION for a disallowed read, got %v", err)
+	}
+	if got := server.received(); len(got) != 1 {
+		t.Errorf("expected only the first query sent, got %q", got)
+	}
+}
@@ -0,0 +1,311 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AuditRecord describes one audited mutation.
+type AuditRecord struct {
+	CommandType string
+	Bundle      string
+	User        string
+	TraceID     string
+	Timestamp   time.Time
+
+	// AffectedCount is the number of documents changed, 0 if the mutation
+	// failed, or -1 if the server did not report it.
+	AffectedCount int64
+
+	// Error is the mutation's error message, empty if it succeeded.
+	Error string
+}
+
+// AuditStats counts the records handled by an AuditHook.
+type AuditStats struct {
+	Recorded uint64 // Records queued for writing
+	Written  uint64 // Records written to the audit bundle
+	Dropped  uint64 // Records discarded because the buffer was full or the hook closed
+	Failed   uint64 // Records lost to failed writes
+}
+
+type auditUserKey struct{}
+
+// WithAuditUser returns a context whose mutations AuditHook attributes to user.
+func WithAuditUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, auditUserKey{}, user)
+}
+
+// AuditUserFromContext returns the user set on ctx with WithAuditUser, or "".
+func AuditUserFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(auditUserKey{}).(string)
+	return user
+}
+
+const (
+	defaultAuditBatchSize     = 100
+	defaultAuditFlushInterval = time.Second
+	defaultAuditBufferSize    = 10000
+	defaultAuditWriteTimeout  = 5 * time.Second
+)
+
+// AuditHook records every mutation into an audit bundle. Records are buffered
+// and written in batches by a background goroutine through target, which may
+// be the audited client itself or a separate client, e.g. for an audit
+// database. Audit failures never fail the audited command: when the buffer is
+// full records are dropped, and failed writes are reported to the OnError
+// callback and counted in Stats.
+type AuditHook struct {
+	target        *Client
+	bundle        string
+	batchSize     int
+	flushInterval time.Duration
+	bufferSize    int
+	writeTimeout  time.Duration
+	defaultUser   string
+	onError       func(err error, records []AuditRecord)
+
+	startOnce sync.Once
+	records   chan AuditRecord
+	stop      chan struct{}
+	done      chan struct{}
+	closed    atomic.Bool
+
+	recorded atomic.Uint64
+	written  atomic.Uint64
+	dropped  atomic.Uint64
+	failed   atomic.Uint64
+}
+
+// NewAuditHook creates an audit hook writing to bundle through target.
+// The bundle must exist with string fields command_type, bundle, user,
+// trace_id, timestamp and error, and an integer field affected_count.
+func NewAuditHook(target *Client, bundle string) *AuditHook {
+	return &AuditHook{
+		target:        target,
+		bundle:        bundle,
+		batchSize:     defaultAuditBatchSize,
+		flushInterval: defaultAuditFlushInterval,
+		bufferSize:    defaultAuditBufferSize,
+		writeTimeout:  defaultAuditWriteTimeout,
+	}
+}
+
+// WithBatchSize sets the number of records written per batch (default 100).
+func (h *AuditHook) WithBatchSize(size int) *AuditHook {
+	if size < 1 {
+		size = 1
+	}
+	h.batchSize = size
+	return h
+}
+
+// WithFlushInterval sets how often a partial batch is written (default 1s).
+func (h *AuditHook) WithFlushInterval(interval time.Duration) *AuditHook {
+	h.flushInterval = interval
+	return h
+}
+
+// WithBufferSize sets the number of records held while waiting to be written
+// (default 10000). Records beyond it are dropped.
+func (h *AuditHook) WithBufferSize(size int) *AuditHook {
+	if size < 1 {
+		size = 1
+	}
+	h.bufferSize = size
+	return h
+}
+
+// WithDefaultUser sets the user recorded for mutations whose context has no WithAuditUser.
+func (h *AuditHook) WithDefaultUser(user string) *AuditHook {
+	h.defaultUser = user
+	return h
+}
+
+// OnError sets a callback invoked from the background writer with each
+// batch that could not be written.
+func (h *AuditHook) OnError(fn func(err error, records []AuditRecord)) *AuditHook {
+	h.onError = fn
+	return h
+}
+
+func (h *AuditHook) Name() string {
+	return "audit"
+}
+
+func (h *AuditHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	return nil
+}
+
+func (h *AuditHook) After(ctx context.Context, hookCtx *HookContext) error {
+	if hookCtx.CommandType != "mutation" {
+		return nil
+	}
+	bundle := CommandBundle(hookCtx.Command)
+	if strings.EqualFold(bundle, h.bundle) {
+		// The hook's own writes, when target is the audited client
+		return nil
+	}
+
+	record := AuditRecord{
+		CommandType: hookCtx.CommandType,
+		Bundle:      bundle,
+		User:        AuditUserFromContext(ctx),
+		TraceID:     hookCtx.TraceID,
+		Timestamp:   time.Now().UTC(),
+	}
+	if record.User == "" {
+		record.User = h.defaultUser
+	}
+	if hookCtx.Error != nil {
+		record.Error = hookCtx.Error.Error()
+	} else {
+		record.AffectedCount = parseMutationResult(hookCtx.Result).AffectedCount
+	}
+
+	if h.closed.Load() {
+		h.dropped.Add(1)
+		return nil
+	}
+	h.startOnce.Do(h.start)
+	select {
+	case h.records <- record:
+		h.recorded.Add(1)
+	default:
+		h.dropped.Add(1)
+	}
+	return nil
+}
+
+// Stats returns the hook's record counts.
+func (h *AuditHook) Stats() AuditStats {
+	return AuditStats{
+		Recorded: h.recorded.Load(),
+		Written:  h.written.Load(),
+		Dropped:  h.dropped.Load(),
+		Failed:   h.failed.Load(),
+	}
+}
+
+// Close writes the buffered records and stops the background writer.
+// Mutations after Close are not recorded.
+func (h *AuditHook) Close(ctx context.Context) error {
+	if h.closed.Swap(true) {
+		return nil
+	}
+	h.startOnce.Do(func() {}) // a writer that has not started never will
+	if h.stop == nil {
+		return nil
+	}
+	close(h.stop)
+
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// start launches the background writer.
+func (h *AuditHook) start() {
+	h.records = make(chan AuditRecord, h.bufferSize)
+	h.stop = make(chan struct{})
+	h.done = make(chan struct{})
+	go h.run()
+}
+
+// run batches records until stop is closed, then writes what remains.
+func (h *AuditHook) run() {
+	defer close(h.done)
+
+	ticker := time.NewTicker(h.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]AuditRecord, 0, h.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			h.write(batch)
+			batch = make([]AuditRecord, 0, h.batchSize)
+		}
+	}
+
+	for {
+		select {
+		case record := <-h.records:
+			batch = append(batch, record)
+			if len(batch) >= h.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-h.stop:
+			for {
+				select {
+				case record := <-h.records:
+					batch = append(batch, record)
+					if len(batch) >= h.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// write sends records to the audit bundle as one pipeline.
+func (h *AuditHook) write(records []AuditRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.writeTimeout)
+	defer cancel()
+
+	pipeline := h.target.Pipeline()
+	for _, record := range records {
+		pipeline.Add(auditInsertCommand(h.bundle, record))
+	}
+	results, err := pipeline.Execute(ctx)
+
+	failed := 0
+	if results == nil && err != nil {
+		failed = len(records)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+		}
+	}
+	h.written.Add(uint64(len(records) - failed))
+	if failed == 0 {
+		return
+	}
+
+	h.failed.Add(uint64(failed))
+	h.target.logger.Warn("audit write failed",
+		String("bundle", h.bundle),
+		Int("records", len(records)),
+		Int("failed", failed),
+		Error("error", err))
+	if h.onError != nil {
+		h.onError(err, records)
+	}
+}
+
+// auditInsertCommand returns the command adding record to bundle.
+func auditInsertCommand(bundle string, record AuditRecord) string {
+	return fmt.Sprintf(`ADD DOCUMENT TO BUNDLE %q WITH ({"command_type" = %s}, {"bundle" = %s}, {"user" = %s}, {"trace_id" = %s}, {"timestamp" = %s}, {"affected_count" = %d}, {"error" = %s});`,
+		bundle,
+		strconv.Quote(record.CommandType),
+		strconv.Quote(record.Bundle),
+		strconv.Quote(record.User),
+		strconv.Quote(record.TraceID),
+		strconv.Quote(record.Timestamp.Format(time.RFC3339Nano)),
+		record.AffectedCount,
+		strconv.Quote(record.Error))
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAuditHookRecordsMutations verifies mutations are written to the audit bundle in batches.
+func TestAuditHookRecordsMutations(t *testing.T) {
+	target, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+	hook := NewAuditHook(target, "audit_log").
+		WithFlushInterval(time.Hour).
+		WithDefaultUser("service")
+
+	if hook.Name() != "audit" {
+		t.Errorf("expected name 'audit', got %s", hook.Name())
+	}
+
+	ctx := WithAuditUser(context.Background(), "alice")
+	hook.After(ctx, &HookContext{
+		Command:     `UPDATE DOCUMENTS IN BUNDLE "users" (name = "a") WHERE "id" == 1;`,
+		CommandType: "mutation",
+		TraceID:     "trace-1",
+		Result:      map[string]interface{}{"affected_count": float64(3)},
+	})
+	hook.After(context.Background(), &HookContext{
+		Command:     `DELETE DOCUMENTS FROM BUNDLE "orders" WHERE "id" == 2;`,
+		CommandType: "mutation",
+		TraceID:     "trace-2",
+		Error:       errors.New("permission denied"),
+	})
+	hook.After(ctx, &HookContext{Command: `SELECT * FROM "users";`, CommandType: "query"})
+	hook.After(ctx, &HookContext{Command: auditInsertCommand("audit_log", AuditRecord{}), CommandType: "mutation"})
+
+	if err := hook.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	commands := server.received()
+	if len(commands) != 2 {
+		t.Fatalf("expected 2 audit writes, got %d: %v", len(commands), commands)
+	}
+	for _, want := range []string{`ADD DOCUMENT TO BUNDLE "audit_log"`, `{"bundle" = "users"}`, `{"user" = "alice"}`, `{"trace_id" = "trace-1"}`, `{"affected_count" = 3}`, `{"error" = ""}`} {
+		if !strings.Contains(commands[0], want) {
+			t.Errorf("expected first write to contain %s, got %s", want, commands[0])
+		}
+	}
+	for _, want := range []string{`{"bundle" = "orders"}`, `{"user" = "service"}`, `{"affected_count" = 0}`, `{"error" = "permission denied"}`} {
+		if !strings.Contains(commands[1], want) {
+			t.Errorf("expected second write to contain %s, got %s", want, commands[1])
+		}
+	}
+
+	stats := hook.Stats()
+	if stats.Recorded != 2 || stats.Written != 2 || stats.Failed != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// Mutations after Close are dropped
+	hook.After(ctx, &HookContext{Command: `DELETE DOCUMENTS FROM BUNDLE "users";`, CommandType: "mutation"})
+	if hook.Stats().Dropped != 1 {
+		t.Errorf("expected record after Close to be dropped, got %+v", hook.Stats())
+	}
+}
+
+// TestAuditHookWriteFailure verifies failed audit writes are reported but never fail the command.
+func TestAuditHookWriteFailure(t *testing.T) {
+	target, _ := newPipeClient(t, func(command string) string {
+		return `{"status":"error","message":"bundle not found"}`
+	})
+
+	var mu sync.Mutex
+	var failedRecords []AuditRecord
+	hook := NewAuditHook(target, "audit_log").
+		WithBatchSize(2).
+		WithFlushInterval(time.Hour).
+		OnError(func(err error, records []AuditRecord) {
+			mu.Lock()
+			failedRecords = append(failedRecords, records...)
+			mu.Unlock()
+		})
+
+	for i := 0; i < 2; i++ {
+		err := hook.After(context.Background(), &HookContext{
+			Command:     `DELETE DOCUMENTS FROM BUNDLE "users";`,
+			CommandType: "mutation",
+		})
+		if err != nil {
+			t.Fatalf("expected After never to fail, got %v", err)
+		}
+	}
+	hook.Close(context.Background())
+
+	stats := hook.Stats()
+	if stats.Failed != 2 || stats.Written != 0 {
+		t.Errorf("expected 2 failed records, got %+v", stats)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(failedRecords) != 2 {
+		t.Errorf("expected OnError with 2 records, got %d", len(failedRecords))
+	}
+}
+
+// TestAuditHookSameClient verifies the hook does not audit its own writes when registered on its target.
+func TestAuditHookSameClient(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok","affected_count":1}`
+	})
+	hook := NewAuditHook(c, "audit_log").WithFlushInterval(time.Hour)
+	c.RegisterHook(hook)
+
+	if _, err := c.sendCommand(context.Background(), `DELETE DOCUMENTS FROM BUNDLE "users" WHERE "id" == 1;`); err != nil {
+		t.Fatalf("sendCommand failed: %v", err)
+	}
+	hook.Close(context.Background())
+
+	commands := server.received()
+	if len(commands) != 2 || !strings.Contains(commands[1], `{"affected_count" = 1}`) {
+		t.Errorf("expected the mutation and one audit write, got %v", commands)
+	}
+	if stats := hook.Stats(); stats.Recorded != 1 {
+		t.Errorf("expected only the audited mutation to be recorded, got %+v", stats)
+	}
+}
@@ -0,0 +1,657 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// A dump is NDJSON: a header line describing the dump, one line per
+// document, and a trailer line with the document count and the SHA-256 of
+// the document lines. It can be read without the driver, and the trailer
+// reveals truncated or altered dumps.
+const (
+	dumpFormat              = "syndrdb-dump"
+	dumpVersion             = 1
+	dumpChecksumPrefix      = "sha256:"
+	defaultDumpBatchSize    = 1000
+	defaultRestoreBatchSize = 500
+)
+
+// DumpManifest describes a bundle dump.
+type DumpManifest struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	Bundle    string    `json:"bundle"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Schema is the bundle's definition, if the dump was taken with
+	// WithSchemaDDL.
+	Schema *schema.BundleDefinition `json:"schema,omitempty"`
+
+	// Documents and Checksum are read from the trailer.
+	Documents int    `json:"documents"`
+	Checksum  string `json:"checksum"` // "sha256:" and the hex digest of the document lines
+}
+
+// dumpHeader is the first line of a dump.
+type dumpHeader struct {
+	Format    string                   `json:"format"`
+	Version   int                      `json:"version"`
+	Bundle    string                   `json:"bundle"`
+	CreatedAt time.Time                `json:"createdAt"`
+	Schema    *schema.BundleDefinition `json:"schema,omitempty"`
+}
+
+// dumpTrailer is the last line of a dump.
+type dumpTrailer struct {
+	End *dumpSummary `json:"end"`
+}
+
+type dumpSummary struct {
+	Documents int    `json:"documents"`
+	Checksum  string `json:"checksum"`
+}
+
+// BackupOption configures DumpBundle and RestoreBundle.
+type BackupOption func(*backupOptions)
+
+type backupOptions struct {
+	schemaDDL    bool
+	createBundle bool
+	batchSize    int
+	progress     func(documents int)
+}
+
+// WithSchemaDDL includes the bundle's definition in a dump, so RestoreBundle
+// can recreate the bundle with WithCreateBundle.
+func WithSchemaDDL() BackupOption {
+	return func(o *backupOptions) {
+		o.schemaDDL = true
+	}
+}
+
+// WithCreateBundle creates the bundle and its indexes from the dump's schema
+// before restoring documents. The dump must have been taken with
+// WithSchemaDDL. Partial index filters are run as written in the dump, so
+// only create bundles from dumps you trust.
+func WithCreateBundle() BackupOption {
+	return func(o *backupOptions) {
+		o.createBundle = true
+	}
+}
+
+// WithBackupBatchSize sets the documents fetched per query by DumpBundle
+// (default 1000) and sent per pipeline by RestoreBundle (default 500).
+func WithBackupBatchSize(n int) BackupOption {
+	return func(o *backupOptions) {
+		o.batchSize = n
+	}
+}
+
+// WithBackupProgress calls fn with the number of documents dumped or
+// restored so far, after each batch.
+func WithBackupProgress(fn func(documents int)) BackupOption {
+	return func(o *backupOptions) {
+		o.progress = fn
+	}
+}
+
+func newBackupOptions(opts []BackupOption, batchSize int) backupOptions {
+	options := backupOptions{batchSize: batchSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.batchSize <= 0 {
+		options.batchSize = batchSize
+	}
+	return options
+}
+
+// DumpBundle writes every document of bundle to w as a dump that
+// RestoreBundle reads back, paging through the bundle in DocumentID order so
+// it is never held in memory. Documents are written as stored, including
+// encrypted fields' ciphertext:
+//
+//	f, err := os.Create("users.dump")
+//	...
+//	manifest, err := c.DumpBundle(ctx, "users", f, client.WithSchemaDDL())
+//
+// The returned manifest has the document count and checksum. Each page
+// starts after the last DocumentID of the one before, so no document is
+// dumped twice, but documents added or deleted while the bundle is dumped
+// may or may not be included.
+func (c *Client) DumpBundle(ctx context.Context, bundle string, w io.Writer, opts ...BackupOption) (*DumpManifest, error) {
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("DumpBundle", CONNECTED, c.stateMgr.GetState())
+	}
+	if bundle == "" {
+		return nil, &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: "bundle name is required",
+		}
+	}
+	if err := checkBackupBundle(bundle); err != nil {
+		return nil, err
+	}
+	options := newBackupOptions(opts, defaultDumpBatchSize)
+
+	manifest := &DumpManifest{
+		Format:    dumpFormat,
+		Version:   dumpVersion,
+		Bundle:    bundle,
+		CreatedAt: time.Now().UTC(),
+	}
+	if options.schemaDDL {
+		bundleDef, err := c.DescribeBundle(ctx, bundle)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Schema = bundleDef
+	}
+
+	out := bufio.NewWriter(w)
+	if err := writeDumpLine(out, dumpHeader{
+		Format:    manifest.Format,
+		Version:   manifest.Version,
+		Bundle:    manifest.Bundle,
+		CreatedAt: manifest.CreatedAt,
+		Schema:    manifest.Schema,
+	}); err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.New()
+	lastID := ""
+	for {
+		query := dumpPageQuery(bundle, lastID, options.batchSize)
+		result, err := c.executeWithTimeout(ctx, query, 0)
+		if err != nil {
+			return nil, err
+		}
+		rows := resultRows(result)
+		for _, row := range rows {
+			doc, ok := row.(map[string]interface{})
+			if !ok {
+				return nil, &QueryError{
+					Code:    "E_DUMP_FAILED",
+					Type:    "QueryError",
+					Message: fmt.Sprintf("unexpected %T row in bundle %s", row, bundle),
+					Query:   query,
+				}
+			}
+			if lastID = documentID(doc); lastID == "" {
+				return nil, &QueryError{
+					Code:    "E_DUMP_FAILED",
+					Type:    "QueryError",
+					Message: fmt.Sprintf("document in bundle %s has no %s to page by", bundle, documentIDField),
+					Query:   query,
+				}
+			}
+			line, err := json.Marshal(row)
+			if err != nil {
+				return nil, err
+			}
+			line = append(line, '\n')
+			checksum.Write(line)
+			if _, err := out.Write(line); err != nil {
+				return nil, err
+			}
+			manifest.Documents++
+		}
+		if options.progress != nil {
+			options.progress(manifest.Documents)
+		}
+		if len(rows) < options.batchSize {
+			break
+		}
+	}
+
+	manifest.Checksum = dumpChecksumPrefix + hex.EncodeToString(checksum.Sum(nil))
+	trailer := dumpTrailer{End: &dumpSummary{Documents: manifest.Documents, Checksum: manifest.Checksum}}
+	if err := writeDumpLine(out, trailer); err != nil {
+		return nil, err
+	}
+	if err := out.Flush(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// dumpPageQuery selects the page of bundle after the document afterID, or
+// the first page if afterID is "". Paging by key rather than OFFSET keeps
+// pages from overlapping when the server returns documents in no set order.
+func dumpPageQuery(bundle, afterID string, limit int) string {
+	if afterID == "" {
+		return fmt.Sprintf(`SELECT * FROM "%s" ORDER BY "%s" ASC LIMIT %d;`, bundle, documentIDField, limit)
+	}
+	return fmt.Sprintf(`SELECT * FROM "%s" WHERE "%s" > %s ORDER BY "%s" ASC LIMIT %d;`,
+		bundle, documentIDField, formatParameterValue(afterID), documentIDField, limit)
+}
+
+// checkBackupBundle rejects bundle names that cannot be written into a
+// command as they are, using the identifier rules of the query builder.
+func checkBackupBundle(bundle string) error {
+	if aliasPattern.MatchString(bundle) {
+		return nil
+	}
+	return &QueryError{
+		Code:    "E_INVALID_QUERY",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("invalid bundle name %q", bundle),
+		Details: map[string]interface{}{"bundle": bundle},
+	}
+}
+
+// writeDumpLine writes v as one line of JSON.
+func writeDumpLine(w io.Writer, v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
+
+// RestoreResult reports the outcome of RestoreBundle.
+type RestoreResult struct {
+	Manifest *DumpManifest
+	Restored int
+	Failed   int
+}
+
+// RestoreBundle adds the documents of a dump written by DumpBundle to
+// bundle, or to the bundle the dump was taken from if bundle is "". The
+// documents are sent in pipelined batches of ADD DOCUMENT commands; a
+// document the server rejects is counted in Failed and the restore goes on,
+// ending with an E_RESTORE_INCOMPLETE error whose cause is the first
+// rejection.
+//
+// The dump is verified as it is read: a malformed line, or a bundle or
+// field name that is not a plain identifier, stops the restore with
+// E_DUMP_INVALID, but a missing trailer or a count or checksum mismatch
+// (E_DUMP_CHECKSUM_MISMATCH) is only detected after the documents before it
+// were sent. Both match ErrDumpCorrupt. Check dumps of doubtful integrity
+// with VerifyDump first.
+func (c *Client) RestoreBundle(ctx context.Context, bundle string, r io.Reader, opts ...BackupOption) (*RestoreResult, error) {
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("RestoreBundle", CONNECTED, c.stateMgr.GetState())
+	}
+	if bundle != "" {
+		if err := checkBackupBundle(bundle); err != nil {
+			return nil, err
+		}
+	}
+	options := newBackupOptions(opts, defaultRestoreBatchSize)
+
+	reader, err := newDumpReader(r)
+	if err != nil {
+		return nil, err
+	}
+	result := &RestoreResult{Manifest: reader.manifest}
+	if bundle == "" {
+		bundle = reader.manifest.Bundle
+	}
+
+	if options.createBundle {
+		if err := c.createDumpedBundle(ctx, bundle, reader.manifest); err != nil {
+			return result, err
+		}
+	}
+
+	var firstFailure error
+	batch := make([]string, 0, options.batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		results, err := c.Pipeline().Add(batch...).Execute(ctx)
+		if results == nil {
+			return err
+		}
+		for _, r := range results {
+			if r.Error == nil {
+				result.Restored++
+				continue
+			}
+			result.Failed++
+			if firstFailure == nil {
+				firstFailure = r.Error
+			}
+		}
+		batch = batch[:0]
+		if options.progress != nil {
+			options.progress(result.Restored)
+		}
+		return nil
+	}
+
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if flushErr := flush(); flushErr != nil {
+				return result, flushErr
+			}
+			return result, err
+		}
+		batch = append(batch, addDocumentCommand(bundle, doc))
+		if len(batch) == options.batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	if result.Failed > 0 {
+		return result, &QueryError{
+			Code:    "E_RESTORE_INCOMPLETE",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("%d of %d documents could not be restored to %s", result.Failed, result.Failed+result.Restored, bundle),
+			Details: map[string]interface{}{
+				"bundle":   bundle,
+				"restored": result.Restored,
+				"failed":   result.Failed,
+			},
+			Cause: firstFailure,
+		}
+	}
+	return result, nil
+}
+
+// createDumpedBundle creates bundle and its indexes from the dump's schema.
+func (c *Client) createDumpedBundle(ctx context.Context, bundle string, manifest *DumpManifest) error {
+	if manifest.Schema == nil {
+		return &QueryError{
+			Code:    "E_DUMP_INVALID",
+			Type:    "QueryError",
+			Message: "cannot create the bundle: the dump has no schema (dump with WithSchemaDDL)",
+			Details: map[string]interface{}{"bundle": manifest.Bundle},
+		}
+	}
+	bundleDef := *manifest.Schema
+	bundleDef.Name = bundle
+	commands := []string{schema.SerializeCreateBundle(&bundleDef)}
+	for i := range bundleDef.Indexes {
+		if command := schema.SerializeCreateIndex(&bundleDef.Indexes[i], bundle); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	for _, command := range commands {
+		if _, err := c.executeWithTimeout(ctx, command, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyDump reads a dump written by DumpBundle to the end and returns its
+// manifest, or an error matching ErrDumpCorrupt if it is malformed,
+// truncated or fails its checksum. It needs no connection.
+func VerifyDump(r io.Reader) (*DumpManifest, error) {
+	reader, err := newDumpReader(r)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, err := reader.Read(); err == io.EOF {
+			return reader.manifest, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// dumpReader reads the documents of a dump, verifying the trailer.
+type dumpReader struct {
+	r        *bufio.Reader
+	manifest *DumpManifest
+	checksum hash.Hash
+	next     []byte // The line after the last one returned
+	line     int    // Number of the next line
+	count    int
+	done     bool
+}
+
+// newDumpReader reads the header of a dump.
+func newDumpReader(r io.Reader) (*dumpReader, error) {
+	d := &dumpReader{r: bufio.NewReader(r), checksum: sha256.New()}
+	line, err := d.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if line == nil {
+		return nil, dumpInvalid("the dump is empty", 1)
+	}
+	var header dumpHeader
+	if err := json.Unmarshal(line, &header); err != nil || header.Format != dumpFormat {
+		return nil, dumpInvalid("not a SyndrDB dump", 1)
+	}
+	if header.Version != dumpVersion {
+		return nil, dumpInvalid(fmt.Sprintf("unsupported dump version %d", header.Version), 1)
+	}
+	if !aliasPattern.MatchString(header.Bundle) {
+		return nil, dumpInvalid(fmt.Sprintf("invalid bundle name %q", header.Bundle), 1)
+	}
+	if name := invalidSchemaName(header.Schema); name != "" {
+		return nil, dumpInvalid(fmt.Sprintf("invalid name %q in the bundle definition", name), 1)
+	}
+	d.manifest = &DumpManifest{
+		Format:    header.Format,
+		Version:   header.Version,
+		Bundle:    header.Bundle,
+		CreatedAt: header.CreatedAt,
+		Schema:    header.Schema,
+	}
+	if d.next, err = d.readLine(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// readLine returns the next line without its newline, or nil at the end.
+func (d *dumpReader) readLine() ([]byte, error) {
+	line, err := d.r.ReadBytes('\n')
+	if err == io.EOF {
+		if len(line) == 0 {
+			return nil, nil
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.line++
+	return bytes.TrimSuffix(line, []byte("\n")), nil
+}
+
+// Read returns the next document, or io.EOF once the trailer has been read
+// and verified.
+func (d *dumpReader) Read() (map[string]interface{}, error) {
+	if d.done {
+		return nil, io.EOF
+	}
+	if d.next == nil {
+		return nil, dumpInvalid("the dump is truncated: its trailer is missing", d.line+1)
+	}
+	line := d.next
+	following, err := d.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if following == nil {
+		// The last line is the trailer
+		d.done = true
+		if err := d.verify(line); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	d.next = following
+
+	d.checksum.Write(line)
+	d.checksum.Write([]byte("\n"))
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil || doc == nil {
+		return nil, dumpInvalid("invalid document", d.line-1)
+	}
+	for field := range doc {
+		// Field names are written into ADD DOCUMENT commands as they are
+		if !aliasPattern.MatchString(field) {
+			return nil, dumpInvalid(fmt.Sprintf("invalid field name %q", field), d.line-1)
+		}
+	}
+	d.count++
+	return doc, nil
+}
+
+// invalidSchemaName returns the first field or index name in bundleDef that
+// is not a valid identifier, or "" if there is none.
+func invalidSchemaName(bundleDef *schema.BundleDefinition) string {
+	if bundleDef == nil {
+		return ""
+	}
+	for _, field := range bundleDef.Fields {
+		if !aliasPattern.MatchString(field.Name) {
+			return field.Name
+		}
+	}
+	for _, index := range bundleDef.Indexes {
+		if !aliasPattern.MatchString(index.Name) {
+			return index.Name
+		}
+		for _, field := range index.Fields {
+			if !aliasPattern.MatchString(field) {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+// verify checks the trailer against the documents read.
+func (d *dumpReader) verify(line []byte) error {
+	var trailer dumpTrailer
+	if err := json.Unmarshal(line, &trailer); err != nil || trailer.End == nil {
+		return dumpInvalid("the dump is truncated: its trailer is missing", d.line)
+	}
+	checksum := dumpChecksumPrefix + hex.EncodeToString(d.checksum.Sum(nil))
+	if trailer.End.Documents != d.count || trailer.End.Checksum != checksum {
+		return &QueryError{
+			Code: "E_DUMP_CHECKSUM_MISMATCH",
+			Type: "QueryError",
+			Message: fmt.Sprintf("dump of %s fails verification: read %d documents with checksum %s, the trailer records %d with %s",
+				d.manifest.Bundle, d.count, checksum, trailer.End.Documents, trailer.End.Checksum),
+			Details: map[string]interface{}{
+				"bundle":            d.manifest.Bundle,
+				"documents":         d.count,
+				"expectedDocuments": trailer.End.Documents,
+			},
+		}
+	}
+	d.manifest.Documents = trailer.End.Documents
+	d.manifest.Checksum = trailer.End.Checksum
+	return nil
+}
+
+// dumpInvalid returns the E_DUMP_INVALID error for a malformed dump line.
+func dumpInvalid(message string, line int) error {
+	return &QueryError{
+		Code:    "E_DUMP_INVALID",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("invalid dump (line %d): %s", line, message),
+		Details: map[string]interface{}{"line": line},
+	}
+}
+
+// AddDocumentCommand renders an ADD DOCUMENT command adding doc to bundle,
+// with fields in sorted order, as RestoreBundle does. Nested objects and
+// arrays are stored as JSON strings. Bundle and field names must be
+// identifiers; any other name fails with E_INVALID_QUERY rather than being
+// written into the command.
+func AddDocumentCommand(bundle string, doc map[string]interface{}) (string, error) {
+	if err := checkBackupBundle(bundle); err != nil {
+		return "", err
+	}
+	for field := range doc {
+		if !aliasPattern.MatchString(field) {
+			return "", &QueryError{
+				Code:    "E_INVALID_QUERY",
+				Type:    "QueryError",
+				Message: fmt.Sprintf("invalid field name %q", field),
+				Details: map[string]interface{}{"bundle": bundle, "field": field},
+			}
+		}
+	}
+	return addDocumentCommand(bundle, doc), nil
+}
+
+// addDocumentCommand renders an ADD DOCUMENT command restoring doc, with
+// fields in sorted order. Nested objects and arrays are stored as JSON
+// strings. The names must have been validated.
+func addDocumentCommand(bundle string, doc map[string]interface{}) string {
+	fields := make([]string, 0, len(doc))
+	for field := range doc {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var command strings.Builder
+	command.WriteString(`ADD DOCUMENT TO BUNDLE "` + bundle + `" WITH (`)
+	for i, field := range fields {
+		if i > 0 {
+			command.WriteString(", ")
+		}
+		command.WriteString(`{"` + field + `" = ` + dumpLiteral(doc[field]) + `}`)
+	}
+	command.WriteString(");")
+	return command.String()
+}
+
+// dumpLiteral renders a JSON value as a SyndrQL literal.
+func dumpLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case string:
+		quoted, _ := json.Marshal(v)
+		return string(quoted)
+	default:
+		encoded, _ := json.Marshal(v)
+		quoted, _ := json.Marshal(string(encoded))
+		return string(quoted)
+	}
+}
@@ -0,0 +1,253 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// dumpTestServer serves SHOW BUNDLES and pages of three users, keyed by DocumentID.
+func dumpTestServer(command string) string {
+	switch {
+	case strings.HasPrefix(command, "SHOW BUNDLES"):
+		return showBundlesResponse
+	case !strings.Contains(command, "WHERE"):
+		return `{"success": true, "data": [{"DocumentID": "d1", "name": "ann", "age": 31}, {"DocumentID": "d2", "name": "bo", "tags": ["a", "b"]}]}`
+	case strings.Contains(command, `"DocumentID" > 'd2'`):
+		return `{"success": true, "data": [{"DocumentID": "d3", "name": "cy \"the quick\"", "active": true, "score": 12345678901234}]}`
+	default:
+		return `{"success": true, "data": []}`
+	}
+}
+
+func TestDumpAndRestoreBundle(t *testing.T) {
+	c, server := newPipeClient(t, dumpTestServer)
+	ctx := context.Background()
+
+	var dump bytes.Buffer
+	var progress []int
+	manifest, err := c.DumpBundle(ctx, "users", &dump, WithSchemaDDL(), WithBackupBatchSize(2),
+		WithBackupProgress(func(n int) { progress = append(progress, n) }))
+	if err != nil {
+		t.Fatalf("DumpBundle: %v", err)
+	}
+	if manifest.Documents != 3 || !strings.HasPrefix(manifest.Checksum, "sha256:") {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	if manifest.Schema == nil || manifest.Schema.Name != "users" || len(manifest.Schema.Indexes) != 1 {
+		t.Errorf("manifest schema = %+v", manifest.Schema)
+	}
+	want := []string{
+		"SHOW BUNDLES;",
+		`SELECT * FROM "users" ORDER BY "DocumentID" ASC LIMIT 2;`,
+		`SELECT * FROM "users" WHERE "DocumentID" > 'd2' ORDER BY "DocumentID" ASC LIMIT 2;`,
+	}
+	if got := server.received(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+	if len(progress) != 2 || progress[1] != 3 {
+		t.Errorf("progress = %v", progress)
+	}
+	if lines := strings.Count(dump.String(), "\n"); lines != 5 {
+		t.Errorf("dump has %d lines, want header, 3 documents and trailer:\n%s", lines, dump.String())
+	}
+
+	verified, err := VerifyDump(bytes.NewReader(dump.Bytes()))
+	if err != nil {
+		t.Fatalf("VerifyDump: %v", err)
+	}
+	if verified.Checksum != manifest.Checksum || verified.Documents != 3 || verified.Bundle != "users" {
+		t.Errorf("verified manifest = %+v", verified)
+	}
+
+	target, targetServer := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": "ok"}`
+	})
+	result, err := target.RestoreBundle(ctx, "users_copy", bytes.NewReader(dump.Bytes()), WithCreateBundle())
+	if err != nil {
+		t.Fatalf("RestoreBundle: %v", err)
+	}
+	if result.Restored != 3 || result.Failed != 0 || result.Manifest.Checksum != manifest.Checksum {
+		t.Errorf("result = %+v", result)
+	}
+	got := targetServer.received()
+	if len(got) != 5 {
+		t.Fatalf("restore sent %d commands: %q", len(got), got)
+	}
+	if !strings.HasPrefix(got[0], `CREATE BUNDLE "users_copy"`) {
+		t.Errorf("first command = %q, want CREATE BUNDLE", got[0])
+	}
+	if got[1] != `CREATE HASH INDEX "idx_email" ON BUNDLE "users_copy" WITH FIELDS ("email");` {
+		t.Errorf("second command = %q, want the index", got[1])
+	}
+	wantDocs := []string{
+		`ADD DOCUMENT TO BUNDLE "users_copy" WITH ({"DocumentID" = "d1"}, {"age" = 31}, {"name" = "ann"});`,
+		`ADD DOCUMENT TO BUNDLE "users_copy" WITH ({"DocumentID" = "d2"}, {"name" = "bo"}, {"tags" = "[\"a\",\"b\"]"});`,
+		`ADD DOCUMENT TO BUNDLE "users_copy" WITH ({"DocumentID" = "d3"}, {"active" = TRUE}, {"name" = "cy \"the quick\""}, {"score" = 12345678901234});`,
+	}
+	for i, doc := range wantDocs {
+		if got[2+i] != doc {
+			t.Errorf("document %d: %q, want %q", i, got[2+i], doc)
+		}
+	}
+}
+
+// TestDumpBundleWithoutDocumentID verifies a dump fails rather than paging
+// through documents it cannot key.
+func TestDumpBundleWithoutDocumentID(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": [{"name": "ann"}]}`
+	})
+	var dump bytes.Buffer
+	_, err := c.DumpBundle(context.Background(), "users", &dump)
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) || queryErr.Code != "E_DUMP_FAILED" {
+		t.Fatalf("err = %v, want E_DUMP_FAILED", err)
+	}
+}
+
+func TestRestoreBundleIntoDumpedBundle(t *testing.T) {
+	source, _ := newPipeClient(t, dumpTestServer)
+	var dump bytes.Buffer
+	if _, err := source.DumpBundle(context.Background(), "users", &dump, WithBackupBatchSize(2)); err != nil {
+		t.Fatalf("DumpBundle: %v", err)
+	}
+
+	target, server := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, `"bo"`) {
+			return `{"success": false, "error": "unique constraint violated"}`
+		}
+		return `{"success": true, "data": "ok"}`
+	})
+	result, err := target.RestoreBundle(context.Background(), "", bytes.NewReader(dump.Bytes()))
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) || queryErr.Code != "E_RESTORE_INCOMPLETE" {
+		t.Fatalf("err = %v, want E_RESTORE_INCOMPLETE", err)
+	}
+	if cause := errors.Unwrap(err); cause == nil || !strings.Contains(cause.Error(), "unique constraint") {
+		t.Errorf("cause = %v, want the rejection", cause)
+	}
+	if result.Restored != 2 || result.Failed != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	for _, command := range server.received() {
+		if !strings.HasPrefix(command, `ADD DOCUMENT TO BUNDLE "users"`) {
+			t.Errorf("unexpected command %q", command)
+		}
+	}
+
+	// A dump without a schema cannot create the bundle
+	if _, err := target.RestoreBundle(context.Background(), "", bytes.NewReader(dump.Bytes()), WithCreateBundle()); !errors.Is(err, ErrDumpCorrupt) {
+		t.Errorf("err = %v, want E_DUMP_INVALID", err)
+	}
+}
+
+func TestVerifyDumpCorruption(t *testing.T) {
+	c, _ := newPipeClient(t, dumpTestServer)
+	var dump bytes.Buffer
+	if _, err := c.DumpBundle(context.Background(), "users", &dump); err != nil {
+		t.Fatalf("DumpBundle: %v", err)
+	}
+	lines := strings.SplitAfter(dump.String(), "\n")
+
+	tests := []struct {
+		name string
+		dump string
+		code string
+	}{
+		{"altered", strings.Replace(dump.String(), `"ann"`, `"anne"`, 1), "E_DUMP_CHECKSUM_MISMATCH"},
+		{"document removed", lines[0] + strings.Join(lines[2:], ""), "E_DUMP_CHECKSUM_MISMATCH"},
+		{"truncated", strings.Join(lines[:3], ""), "E_DUMP_INVALID"},
+		{"header only", lines[0], "E_DUMP_INVALID"},
+		{"malformed document", lines[0] + "{not json\n" + strings.Join(lines[1:], ""), "E_DUMP_INVALID"},
+		{"not a dump", `{"name": "ann"}` + "\n", "E_DUMP_INVALID"},
+		{"empty", "", "E_DUMP_INVALID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyDump(strings.NewReader(tt.dump))
+			if ErrorCode(err) != tt.code {
+				t.Fatalf("err = %v, want %s", err, tt.code)
+			}
+			if !errors.Is(err, ErrDumpCorrupt) {
+				t.Errorf("errors.Is(%v, ErrDumpCorrupt) = false", err)
+			}
+		})
+	}
+}
+
+// TestBackupRejectsInvalidIdentifiers verifies bundle and field names are
+// never written into commands unless they are plain identifiers.
+func TestBackupRejectsInvalidIdentifiers(t *testing.T) {
+	c, server := newPipeClient(t, dumpTestServer)
+	ctx := context.Background()
+
+	var queryErr *QueryError
+	if _, err := c.DumpBundle(ctx, `users" WHERE 1 == 1; --`, &bytes.Buffer{}); !errors.As(err, &queryErr) || queryErr.Code != "E_INVALID_QUERY" {
+		t.Errorf("DumpBundle err = %v, want E_INVALID_QUERY", err)
+	}
+
+	var dump bytes.Buffer
+	if _, err := c.DumpBundle(ctx, "users", &dump); err != nil {
+		t.Fatalf("DumpBundle: %v", err)
+	}
+	sent := len(server.received())
+
+	if _, err := c.RestoreBundle(ctx, "users; DROP BUNDLE users", bytes.NewReader(dump.Bytes())); !errors.As(err, &queryErr) || queryErr.Code != "E_INVALID_QUERY" {
+		t.Errorf("RestoreBundle err = %v, want E_INVALID_QUERY", err)
+	}
+
+	hostile := strings.Replace(dump.String(), `"name":`, `"name\" = 1}); DROP BUNDLE \"users\"; --":`, 1)
+	if _, err := c.RestoreBundle(ctx, "", strings.NewReader(hostile)); ErrorCode(err) != "E_DUMP_INVALID" {
+		t.Errorf("RestoreBundle err = %v, want E_DUMP_INVALID for a hostile field name", err)
+	}
+	if _, err := VerifyDump(strings.NewReader(hostile)); ErrorCode(err) != "E_DUMP_INVALID" {
+		t.Errorf("VerifyDump err = %v, want E_DUMP_INVALID", err)
+	}
+
+	renamed := strings.Replace(dump.String(), `"bundle":"users"`, `"bundle":"users\" WITH ({\"x\" = 1}); --"`, 1)
+	if _, err := VerifyDump(strings.NewReader(renamed)); ErrorCode(err) != "E_DUMP_INVALID" {
+		t.Errorf("VerifyDump err = %v, want E_DUMP_INVALID for a hostile bundle name", err)
+	}
+
+	if got := server.received(); len(got) != sent {
+		t.Errorf("commands sent for invalid identifiers: %q", got[sent:])
+	}
+}
+
+func TestAddDocumentCommand(t *testing.T) {
+	doc := map[string]interface{}{
+		"name":   `Ann "A"`,
+		"age":    int64(30),
+		"rank":   2,
+		"active": true,
+		"tags":   []interface{}{"a"},
+		"note":   nil,
+	}
+	got, err := AddDocumentCommand("users", doc)
+	if err != nil {
+		t.Fatalf("AddDocumentCommand: %v", err)
+	}
+	want := `ADD DOCUMENT TO BUNDLE "users" WITH ({"active" = TRUE}, {"age" = 30}, {"name" = "Ann \"A\""}, {"note" = NULL}, {"rank" = 2}, {"tags" = "[\"a\"]"});`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	hostile := []struct {
+		bundle string
+		doc    map[string]interface{}
+	}{
+		{"users", map[string]interface{}{`a" = 1}); DROP BUNDLE "x`: 1}},
+		{"users", map[string]interface{}{"": 1}},
+		{`users" WITH ({"a" = 1}); --`, map[string]interface{}{"a": 1}},
+	}
+	for _, tt := range hostile {
+		command, err := AddDocumentCommand(tt.bundle, tt.doc)
+		var queryErr *QueryError
+		if !errors.As(err, &queryErr) || queryErr.Code != "E_INVALID_QUERY" || command != "" {
+			t.Errorf("AddDocumentCommand(%q, %v) = %q, %v; want E_INVALID_QUERY", tt.bundle, tt.doc, command, err)
+		}
+	}
+}
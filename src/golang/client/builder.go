@@ -3,8 +3,10 @@ package client
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cespare/xxhash"
 )
@@ -39,6 +41,21 @@ const (
 	And
 	Or
 	Not
+
+	// Geospatial operators, with a GeoJSON operand (see WhereNear and WhereWithin)
+	Near
+	Within
+
+	// Array operators (see WhereContains and WhereAny)
+	Contains
+	ContainsAny
+
+	// Full-text search against a fulltext index (see Search)
+	Match
+
+	// Subquery operators, with a *QueryBuilder operand (see WhereExists)
+	Exists
+	NotExists
 )
 
 // String returns the SyndrQL representation of the operator.
@@ -78,6 +95,20 @@ func (o Operator) String() string {
 		return "OR"
 	case Not:
 		return "NOT"
+	case Near:
+		return "NEAR"
+	case Within:
+		return "WITHIN"
+	case Contains:
+		return "CONTAINS"
+	case ContainsAny:
+		return "CONTAINS ANY"
+	case Match:
+		return "MATCH"
+	case Exists:
+		return "EXISTS"
+	case NotExists:
+		return "NOT EXISTS"
 	default:
 		return "="
 	}
@@ -119,12 +150,14 @@ type whereClause struct {
 	operator  Operator
 	value     interface{}
 	connector Operator // And or Or
+	raw       *rawExpr // Set by WhereRaw; replaces field, operator and value
 }
 
 // orderByClause represents an ORDER BY clause.
 type orderByClause struct {
 	field     string
 	direction Direction
+	raw       *rawExpr // Set by OrderByRaw; replaces field and direction
 }
 
 // joinClause represents a JOIN clause with ON conditions.
@@ -139,47 +172,62 @@ type joinClause struct {
 
 // QueryBuilder provides a fluent API for building type-safe SELECT queries.
 type QueryBuilder struct {
+	queryOptions
 	client           *Client
 	bundle           string
 	fields           []string
+	aliases          map[int]string // Output names by index into fields, set by SelectAs
+	distinct         bool
+	rawSelects       []rawExpr // SelectRaw expressions, after fields
+	searchSelects    []rawExpr // Search score and highlight expressions, after everything else
 	whereClauses     []whereClause
 	orderBys         []orderByClause
 	joinClauses      []joinClause // Explicit JOIN clauses
 	limitVal         *int
 	offsetVal        *int
+	limit, offset    int      // Storage for limitVal and offsetVal, so setting them does not allocate
 	includes         []string // For relationship eager loading
 	params           []interface{}
 	paramCount       int
 	schemaValidation bool
 	queryType        queryType
+	cacheTTL         time.Duration // Result cache TTL; zero disables caching
 }
 
 // InsertBuilder provides a fluent API for building INSERT queries.
 type InsertBuilder struct {
+	queryOptions
 	client           *Client
 	bundle           string
 	values           map[string]interface{}
 	params           []interface{}
 	paramCount       int
 	schemaValidation bool
+	skipTimestamps   bool
 }
 
 // UpdateBuilder provides a fluent API for building UPDATE queries.
 type UpdateBuilder struct {
+	queryOptions
 	client           *Client
 	bundle           string
 	setFields        map[string]interface{}
 	whereClauses     []whereClause
+	limitVal         *int
+	version          *versionCheck
 	params           []interface{}
 	paramCount       int
 	schemaValidation bool
+	skipTimestamps   bool
 }
 
 // DeleteBuilder provides a fluent API for building DELETE queries.
 type DeleteBuilder struct {
+	queryOptions
 	client           *Client
 	bundle           string
 	whereClauses     []whereClause
+	limitVal         *int
 	params           []interface{}
 	paramCount       int
 	schemaValidation bool
@@ -197,10 +245,28 @@ type DeleteBuilder struct {
 func (qb *QueryBuilder) Select(bundle string, fields ...string) *QueryBuilder {
 	qb.bundle = bundle
 	qb.fields = fields
+	qb.aliases = nil
 	qb.queryType = selectQuery
 	return qb
 }
 
+// SelectAs adds field to the selected fields, returned under alias:
+// SelectAs("status", "s") renders "status AS s". Call it after Select.
+func (qb *QueryBuilder) SelectAs(field, alias string) *QueryBuilder {
+	if qb.aliases == nil {
+		qb.aliases = make(map[int]string)
+	}
+	qb.aliases[len(qb.fields)] = alias
+	qb.fields = append(qb.fields, field)
+	return qb
+}
+
+// Distinct removes duplicate rows from the results (SELECT DISTINCT).
+func (qb *QueryBuilder) Distinct() *QueryBuilder {
+	qb.distinct = true
+	return qb
+}
+
 // Where adds a WHERE condition with implicit AND connector.
 // Subsequent calls to Where() are combined with AND.
 func (qb *QueryBuilder) Where(field string, op Operator, value interface{}) *QueryBuilder {
@@ -236,6 +302,18 @@ func (qb *QueryBuilder) Or(field string, op Operator, value interface{}) *QueryB
 	return qb
 }
 
+// WhereContains adds a condition, with an implicit AND connector, matching
+// documents whose ARRAY field has value as an element.
+func (qb *QueryBuilder) WhereContains(field string, value interface{}) *QueryBuilder {
+	return qb.Where(field, Contains, value)
+}
+
+// WhereAny adds a condition, with an implicit AND connector, matching
+// documents whose ARRAY field has at least one of values as an element.
+func (qb *QueryBuilder) WhereAny(field string, values ...interface{}) *QueryBuilder {
+	return qb.Where(field, ContainsAny, values)
+}
+
 // OrderBy adds an ORDER BY clause.
 func (qb *QueryBuilder) OrderBy(field string, dir Direction) *QueryBuilder {
 	qb.orderBys = append(qb.orderBys, orderByClause{
@@ -247,13 +325,15 @@ func (qb *QueryBuilder) OrderBy(field string, dir Direction) *QueryBuilder {
 
 // Limit sets the maximum number of results to return.
 func (qb *QueryBuilder) Limit(n int) *QueryBuilder {
-	qb.limitVal = &n
+	qb.limit = n
+	qb.limitVal = &qb.limit
 	return qb
 }
 
 // Offset sets the number of results to skip.
 func (qb *QueryBuilder) Offset(n int) *QueryBuilder {
-	qb.offsetVal = &n
+	qb.offset = n
+	qb.offsetVal = &qb.offset
 	return qb
 }
 
@@ -264,6 +344,40 @@ func (qb *QueryBuilder) WithValidation(enabled bool) *QueryBuilder {
 	return qb
 }
 
+// WithTimeout bounds this query to d, overriding ClientOptions.DefaultQueryTimeout.
+// The caller's context deadline still applies if it is earlier.
+func (qb *QueryBuilder) WithTimeout(d time.Duration) *QueryBuilder {
+	qb.timeout = d
+	return qb
+}
+
+// WithTag attaches a key/value tag to this query. Tags are exposed to hooks as
+// HookContext.Metadata["tags"] and included in debug logs.
+func (qb *QueryBuilder) WithTag(key, value string) *QueryBuilder {
+	qb.setTag(key, value)
+	return qb
+}
+
+// WithPriority sets the priority reported to hooks for this query.
+func (qb *QueryBuilder) WithPriority(p QueryPriority) *QueryBuilder {
+	qb.priority = &p
+	return qb
+}
+
+// Database runs this query against database name instead of the client's current database.
+func (qb *QueryBuilder) Database(name string) *QueryBuilder {
+	qb.database = name
+	return qb
+}
+
+// Cached enables result caching for this query for up to ttl.
+// Cached results are dropped early when a mutation or DDL command targets
+// any bundle the query reads. A non-positive ttl disables caching.
+func (qb *QueryBuilder) Cached(ttl time.Duration) *QueryBuilder {
+	qb.cacheTTL = ttl
+	return qb
+}
+
 // Include adds a relationship for eager loading via JOIN.
 // The relationship name should match the relationship defined in the schema.
 func (qb *QueryBuilder) Include(relationship string) *QueryBuilder {
@@ -326,6 +440,32 @@ func (ib *InsertBuilder) WithValidation(enabled bool) *InsertBuilder {
 	return ib
 }
 
+// WithTimeout bounds this query to d, overriding ClientOptions.DefaultQueryTimeout.
+// The caller's context deadline still applies if it is earlier.
+func (ib *InsertBuilder) WithTimeout(d time.Duration) *InsertBuilder {
+	ib.timeout = d
+	return ib
+}
+
+// WithTag attaches a key/value tag to this query. Tags are exposed to hooks as
+// HookContext.Metadata["tags"] and included in debug logs.
+func (ib *InsertBuilder) WithTag(key, value string) *InsertBuilder {
+	ib.setTag(key, value)
+	return ib
+}
+
+// WithPriority sets the priority reported to hooks for this query.
+func (ib *InsertBuilder) WithPriority(p QueryPriority) *InsertBuilder {
+	ib.priority = &p
+	return ib
+}
+
+// Database runs this query against database name instead of the client's current database.
+func (ib *InsertBuilder) Database(name string) *InsertBuilder {
+	ib.database = name
+	return ib
+}
+
 // ============================================================================
 // UpdateBuilder Methods
 // ============================================================================
@@ -372,12 +512,44 @@ func (ub *UpdateBuilder) Or(field string, op Operator, value interface{}) *Updat
 	return ub
 }
 
+// Limit caps the number of documents the update may modify.
+func (ub *UpdateBuilder) Limit(n int) *UpdateBuilder {
+	ub.limitVal = &n
+	return ub
+}
+
 // WithValidation enables or disables schema validation for this update.
 func (ub *UpdateBuilder) WithValidation(enabled bool) *UpdateBuilder {
 	ub.schemaValidation = enabled
 	return ub
 }
 
+// WithTimeout bounds this query to d, overriding ClientOptions.DefaultQueryTimeout.
+// The caller's context deadline still applies if it is earlier.
+func (ub *UpdateBuilder) WithTimeout(d time.Duration) *UpdateBuilder {
+	ub.timeout = d
+	return ub
+}
+
+// WithTag attaches a key/value tag to this query. Tags are exposed to hooks as
+// HookContext.Metadata["tags"] and included in debug logs.
+func (ub *UpdateBuilder) WithTag(key, value string) *UpdateBuilder {
+	ub.setTag(key, value)
+	return ub
+}
+
+// WithPriority sets the priority reported to hooks for this query.
+func (ub *UpdateBuilder) WithPriority(p QueryPriority) *UpdateBuilder {
+	ub.priority = &p
+	return ub
+}
+
+// Database runs this query against database name instead of the client's current database.
+func (ub *UpdateBuilder) Database(name string) *UpdateBuilder {
+	ub.database = name
+	return ub
+}
+
 // ============================================================================
 // DeleteBuilder Methods
 // ============================================================================
@@ -415,12 +587,44 @@ func (db *DeleteBuilder) Or(field string, op Operator, value interface{}) *Delet
 	return db
 }
 
+// Limit caps the number of documents the delete may remove.
+func (db *DeleteBuilder) Limit(n int) *DeleteBuilder {
+	db.limitVal = &n
+	return db
+}
+
 // WithValidation enables or disables schema validation for this delete.
 func (db *DeleteBuilder) WithValidation(enabled bool) *DeleteBuilder {
 	db.schemaValidation = enabled
 	return db
 }
 
+// WithTimeout bounds this query to d, overriding ClientOptions.DefaultQueryTimeout.
+// The caller's context deadline still applies if it is earlier.
+func (db *DeleteBuilder) WithTimeout(d time.Duration) *DeleteBuilder {
+	db.timeout = d
+	return db
+}
+
+// WithTag attaches a key/value tag to this query. Tags are exposed to hooks as
+// HookContext.Metadata["tags"] and included in debug logs.
+func (db *DeleteBuilder) WithTag(key, value string) *DeleteBuilder {
+	db.setTag(key, value)
+	return db
+}
+
+// WithPriority sets the priority reported to hooks for this query.
+func (db *DeleteBuilder) WithPriority(p QueryPriority) *DeleteBuilder {
+	db.priority = &p
+	return db
+}
+
+// Database runs this query against database name instead of the client's current database.
+func (db *DeleteBuilder) Database(name string) *DeleteBuilder {
+	db.database = name
+	return db
+}
+
 // ============================================================================
 // Execute Methods
 // ============================================================================
@@ -435,11 +639,21 @@ func (qb *QueryBuilder) Execute(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	// Build the query string
-	query, params, err := qb.buildQuery()
+	// Build the query string, scoped to the tenant and with conditions on
+	// encrypted fields rewritten
+	scoped, err := qb.withTenant(ctx)
 	if err != nil {
 		return nil, err
 	}
+	encrypted, err := scoped.withEncryption()
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := encrypted.buildQuery()
+	if err != nil {
+		return nil, err
+	}
+	params = newValueCodec(qb.client.opts).encodeParams(params)
 
 	// TODO: Validate schema if enabled
 	if qb.schemaValidation && qb.client.schemaValidator != nil {
@@ -451,12 +665,65 @@ func (qb *QueryBuilder) Execute(ctx context.Context) (interface{}, error) {
 	// For now, inline parameters into query (prepared statements not yet fully supported)
 	inlineQuery := inlineParameters(query, params)
 
-	// Execute query using Query method
-	return qb.client.Query(inlineQuery, 10000)
+	ctx, cancel := qb.queryOptions.apply(ctx, qb.client)
+	defer cancel()
+
+	cache := qb.client.queryCache
+	if qb.cacheTTL <= 0 || cache == nil {
+		return qb.decrypt(qb.client.executeWithTimeout(ctx, inlineQuery, 0))
+	}
+
+	// Cache hits never reach the command path, so the policy is checked here:
+	// a result cached before the policy changed must not be served after it
+	if err := qb.client.opts.AccessPolicy.Check(inlineQuery); err != nil {
+		return nil, err
+	}
+
+	key := qb.cacheKey(inlineQuery)
+	if database := qb.client.targetDatabase(ctx); database != "" {
+		key = database + "/" + key
+	}
+	if result, ok := cache.Get(key); ok {
+		return result, nil
+	}
+
+	generation := cache.generation.Load()
+	result, err := qb.decrypt(qb.client.executeWithTimeout(ctx, inlineQuery, 0))
+	if err == nil {
+		cache.setIfCurrent(key, qb.cacheBundles(inlineQuery), result, qb.cacheTTL, generation)
+	}
+	return result, err
+}
+
+// cacheKey combines the query fingerprint with a hash of the rendered query,
+// so queries sharing a shape but differing in parameters are cached separately.
+func (qb *QueryBuilder) cacheKey(renderedQuery string) string {
+	return fmt.Sprintf("%s:%016x", qb.Fingerprint(), xxhash.Sum64String(renderedQuery))
 }
 
-// Execute builds and executes the INSERT query, returning the result.
-func (ib *InsertBuilder) Execute(ctx context.Context) (interface{}, error) {
+// aliasPattern matches the output names accepted by SelectAs.
+var aliasPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// joinTargetPattern matches the bundle named in a JOIN clause.
+var joinTargetPattern = regexp.MustCompile(`(?i)\bJOIN\s+"?([A-Za-z_][A-Za-z0-9_.\-]*)"?`)
+
+// cacheBundles returns the bundles this query reads, used for cache invalidation.
+// Joined bundles are taken from the rendered query so relationship includes are covered.
+func (qb *QueryBuilder) cacheBundles(renderedQuery string) []string {
+	bundles := []string{qb.bundle}
+	for _, match := range joinTargetPattern.FindAllStringSubmatch(renderedQuery, -1) {
+		bundles = append(bundles, match[1])
+	}
+	for _, clause := range qb.whereClauses {
+		if sub, ok := clause.value.(*QueryBuilder); ok && sub != nil {
+			bundles = append(bundles, sub.cacheBundles("")...)
+		}
+	}
+	return bundles
+}
+
+// Execute builds and executes the INSERT query, returning the parsed result.
+func (ib *InsertBuilder) Execute(ctx context.Context) (*MutationResult, error) {
 	if ib.bundle == "" {
 		return nil, &QueryError{
 			Code:    "E_INVALID_QUERY",
@@ -472,8 +739,14 @@ func (ib *InsertBuilder) Execute(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	// Build the query string
-	query, params := ib.buildInsertQuery()
+	ib = ib.withTimestamps()
+	ib, generatedID, err := ib.withGeneratedID()
+	if err != nil {
+		return nil, err
+	}
+	if ib, err = ib.withTenant(ctx); err != nil {
+		return nil, err
+	}
 
 	// TODO: Validate schema if enabled
 	if ib.schemaValidation && ib.client.schemaValidator != nil {
@@ -482,15 +755,32 @@ func (ib *InsertBuilder) Execute(ctx context.Context) (interface{}, error) {
 		}
 	}
 
+	// Build the query string, with encrypted fields replaced by their ciphertext
+	encrypted, err := ib.withEncryption()
+	if err != nil {
+		return nil, err
+	}
+	encrypted.values = newValueCodec(ib.client.opts).encodeValues(encrypted.values)
+	query, params := encrypted.buildInsertQuery()
+
 	// For now, inline parameters into query (prepared statements not yet fully supported)
 	inlineQuery := inlineParameters(query, params)
 
 	// Execute mutation using Mutate method
-	return ib.client.Mutate(inlineQuery, 10000)
+	ctx, cancel := ib.queryOptions.apply(ctx, ib.client)
+	defer cancel()
+	result, err := ib.client.mutate(ctx, inlineQuery, 0)
+	if err == nil && generatedID != "" {
+		result.GeneratedID = generatedID
+		if len(result.InsertedIDs) == 0 {
+			result.InsertedIDs = []string{generatedID}
+		}
+	}
+	return result, err
 }
 
-// Execute builds and executes the UPDATE query, returning the result.
-func (ub *UpdateBuilder) Execute(ctx context.Context) (interface{}, error) {
+// Execute builds and executes the UPDATE query, returning the parsed result.
+func (ub *UpdateBuilder) Execute(ctx context.Context) (*MutationResult, error) {
 	if ub.bundle == "" {
 		return nil, &QueryError{
 			Code:    "E_INVALID_QUERY",
@@ -513,25 +803,50 @@ func (ub *UpdateBuilder) Execute(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	// Build the query string
-	query, params := ub.buildUpdateQuery()
+	if err := ub.validateVersion(); err != nil {
+		return nil, err
+	}
+
+	ub = ub.withTimestamps()
 
 	// TODO: Validate schema if enabled
 	if ub.schemaValidation && ub.client.schemaValidator != nil {
-		if err := ub.client.schemaValidator.ValidateUpdate(ub.bundle, ub.setFields, ub.whereClauses); err != nil {
+		setFields, whereClauses := ub.versionedClauses()
+		if err := ub.client.schemaValidator.ValidateUpdate(ub.bundle, setFields, whereClauses); err != nil {
 			return nil, err
 		}
 	}
 
+	// Build the query string, scoped to the tenant and with encrypted fields
+	// replaced by their ciphertext
+	scoped, err := ub.withTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := scoped.withEncryption()
+	if err != nil {
+		return nil, err
+	}
+	codec := newValueCodec(ub.client.opts)
+	encrypted.setFields = codec.encodeValues(encrypted.setFields)
+	encrypted.whereClauses = codec.encodeWhere(encrypted.whereClauses)
+	query, params := encrypted.buildUpdateQuery()
+
 	// For now, inline parameters into query (prepared statements not yet fully supported)
 	inlineQuery := inlineParameters(query, params)
 
 	// Execute mutation
-	return ub.client.Mutate(inlineQuery, 10000)
+	ctx, cancel := ub.queryOptions.apply(ctx, ub.client)
+	defer cancel()
+	result, err := ub.client.mutate(ctx, inlineQuery, 0)
+	if err == nil && ub.version != nil && result.AffectedCount == 0 {
+		return result, ErrStaleVersion(ub.bundle, ub.version.field, ub.version.current)
+	}
+	return result, err
 }
 
-// Execute builds and executes the DELETE query, returning the result.
-func (db *DeleteBuilder) Execute(ctx context.Context) (interface{}, error) {
+// Execute builds and executes the DELETE query, returning the parsed result.
+func (db *DeleteBuilder) Execute(ctx context.Context) (*MutationResult, error) {
 	if db.bundle == "" {
 		return nil, &QueryError{
 			Code:    "E_INVALID_QUERY",
@@ -547,9 +862,6 @@ func (db *DeleteBuilder) Execute(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	// Build the query string
-	query, params := db.buildDeleteQuery()
-
 	// TODO: Validate schema if enabled
 	if db.schemaValidation && db.client.schemaValidator != nil {
 		if err := db.client.schemaValidator.ValidateDelete(db.bundle, db.whereClauses); err != nil {
@@ -557,11 +869,26 @@ func (db *DeleteBuilder) Execute(ctx context.Context) (interface{}, error) {
 		}
 	}
 
+	// Build the query string, scoped to the tenant and with conditions on
+	// encrypted fields rewritten
+	scoped, err := db.withTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := scoped.withEncryption()
+	if err != nil {
+		return nil, err
+	}
+	encrypted.whereClauses = newValueCodec(db.client.opts).encodeWhere(encrypted.whereClauses)
+	query, params := encrypted.buildDeleteQuery()
+
 	// For now, inline parameters into query (prepared statements not yet fully supported)
 	inlineQuery := inlineParameters(query, params)
 
 	// Execute mutation
-	return db.client.Mutate(inlineQuery, 10000)
+	ctx, cancel := db.queryOptions.apply(ctx, db.client)
+	defer cancel()
+	return db.client.mutate(ctx, inlineQuery, 0)
 }
 
 // ============================================================================
@@ -569,14 +896,33 @@ func (db *DeleteBuilder) Execute(ctx context.Context) (interface{}, error) {
 // ============================================================================
 
 // buildQuery constructs the SELECT query string with parameterized values.
+// It renders into a pooled buffer, so the returned string is the only allocation
+// beyond params.
 func (qb *QueryBuilder) buildQuery() (string, []interface{}, error) {
-	var query strings.Builder
-	var params []interface{}
-	paramCount := 0
+	buf := getQueryBuffer()
+	defer putQueryBuffer(buf)
+
+	rendered, params, err := qb.AppendQuery((*buf)[:0], nil)
+	*buf = rendered
+	if err != nil {
+		return "", nil, err
+	}
+	return string(rendered), params, nil
+}
+
+// AppendQuery renders the SELECT query with $n placeholders onto dst and its
+// parameter values onto params, returning the extended slices. Reusing dst and
+// params across calls, e.g. with a builder from PooledQueryBuilder, renders
+// without allocating.
+func (qb *QueryBuilder) AppendQuery(dst []byte, params []interface{}) ([]byte, []interface{}, error) {
+	query := queryWriter{buf: dst, params: params}
 
 	// SELECT clause
 	query.WriteString("SELECT ")
-	if len(qb.fields) == 0 {
+	if qb.distinct {
+		query.WriteString("DISTINCT ")
+	}
+	if len(qb.fields) == 0 && len(qb.rawSelects) == 0 {
 		query.WriteString("*")
 	} else {
 		for i, field := range qb.fields {
@@ -584,6 +930,31 @@ func (qb *QueryBuilder) buildQuery() (string, []interface{}, error) {
 				query.WriteString(", ")
 			}
 			query.WriteString(field)
+			if alias, ok := qb.aliases[i]; ok {
+				if !aliasPattern.MatchString(alias) {
+					return dst, params, &QueryError{
+						Code:    "E_INVALID_QUERY",
+						Type:    "QueryError",
+						Message: fmt.Sprintf("invalid alias %q for field %s", alias, field),
+					}
+				}
+				query.WriteString(" AS ")
+				query.WriteString(alias)
+			}
+		}
+		for i := range qb.rawSelects {
+			if i > 0 || len(qb.fields) > 0 {
+				query.WriteString(", ")
+			}
+			if err := qb.rawSelects[i].bind(&query); err != nil {
+				return dst, params, err
+			}
+		}
+	}
+	for i := range qb.searchSelects {
+		query.WriteString(", ")
+		if err := qb.searchSelects[i].bind(&query); err != nil {
+			return dst, params, err
 		}
 	}
 
@@ -647,6 +1018,45 @@ func (qb *QueryBuilder) buildQuery() (string, []interface{}, error) {
 				query.WriteString(" ")
 			}
 
+			if clause.raw != nil {
+				query.WriteString("(")
+				if err := clause.raw.bind(&query); err != nil {
+					return dst, params, err
+				}
+				query.WriteString(")")
+				continue
+			}
+
+			if sub, ok := clause.value.(*QueryBuilder); ok || clause.operator == Exists || clause.operator == NotExists {
+				if err := validateSubquery(clause, sub); err != nil {
+					return dst, params, err
+				}
+				if clause.field != "" {
+					query.WriteString(clause.field)
+					query.WriteString(" ")
+				}
+				query.WriteString(clause.operator.String())
+				query.WriteString(" ")
+				if err := query.subquery(sub); err != nil {
+					return dst, params, err
+				}
+				continue
+			}
+
+			if clause.operator == Near || clause.operator == Within {
+				if err := validateGeoClause(clause); err != nil {
+					return dst, params, err
+				}
+			}
+			if clause.operator == Match {
+				if err := validateSearchClause(clause); err != nil {
+					return dst, params, err
+				}
+			}
+			if err := validateColumns(clause.value); err != nil {
+				return dst, params, err
+			}
+
 			// Handle dot-notation for relationship traversal (e.g., "Author.Name")
 			// Dot-notation allows querying related bundle fields directly
 			query.WriteString(clause.field)
@@ -657,10 +1067,8 @@ func (qb *QueryBuilder) buildQuery() (string, []interface{}, error) {
 			if clause.operator == IsNull || clause.operator == IsNotNull {
 				// No parameter needed
 			} else {
-				paramCount++
-				query.WriteString(" $")
-				query.WriteString(strconv.Itoa(paramCount))
-				params = append(params, clause.value)
+				query.WriteString(" ")
+				query.placeholder(clause.value)
 			}
 		}
 	}
@@ -672,6 +1080,12 @@ func (qb *QueryBuilder) buildQuery() (string, []interface{}, error) {
 			if i > 0 {
 				query.WriteString(", ")
 			}
+			if orderBy.raw != nil {
+				if err := orderBy.raw.bind(&query); err != nil {
+					return dst, params, err
+				}
+				continue
+			}
 			query.WriteString(orderBy.field)
 			query.WriteString(" ")
 			query.WriteString(orderBy.direction.String())
@@ -681,18 +1095,18 @@ func (qb *QueryBuilder) buildQuery() (string, []interface{}, error) {
 	// LIMIT clause
 	if qb.limitVal != nil {
 		query.WriteString(" LIMIT ")
-		query.WriteString(strconv.Itoa(*qb.limitVal))
+		query.WriteInt(*qb.limitVal)
 	}
 
 	// OFFSET clause
 	if qb.offsetVal != nil {
 		query.WriteString(" OFFSET ")
-		query.WriteString(strconv.Itoa(*qb.offsetVal))
+		query.WriteInt(*qb.offsetVal)
 	}
 
 	query.WriteString(";")
 
-	return query.String(), params, nil
+	return query.buf, query.params, nil
 }
 
 // buildInsertQuery constructs the INSERT query string with parameterized values.
@@ -771,17 +1185,18 @@ func (ub *UpdateBuilder) buildUpdateQuery() (string, []interface{}) {
 
 	var query strings.Builder
 	var params []interface{}
-	paramCount := 0
 
 	// UPDATE clause
 	query.WriteString("UPDATE DOCUMENTS IN BUNDLE \"")
 	query.WriteString(ub.bundle)
 	query.WriteString("\" ( ")
 
+	setFields, whereClauses := ub.versionedClauses()
+
 	// SET clause
 	first := true
 	fieldCount := 1
-	for field, value := range ub.setFields {
+	for field, value := range setFields {
 		if !first {
 			query.WriteString(", ")
 		}
@@ -797,7 +1212,7 @@ func (ub *UpdateBuilder) buildUpdateQuery() (string, []interface{}) {
 		//query.WriteString(strconv.Itoa(paramCount))
 		//params = append(params, value)
 		//first = false
-		if fieldCount < len(ub.setFields) {
+		if fieldCount < len(setFields) {
 			query.WriteString(", ")
 		}
 		fieldCount++
@@ -807,34 +1222,8 @@ func (ub *UpdateBuilder) buildUpdateQuery() (string, []interface{}) {
 	query.WriteString(")")
 
 	// WHERE clause
-	query.WriteString(" WHERE ")
-	for i, clause := range ub.whereClauses {
-		if i > 0 {
-			query.WriteString(" ")
-			query.WriteString(clause.connector.String())
-			query.WriteString(" ")
-		}
-
-		query.WriteString("\"" + clause.field + "\"")
-		query.WriteString(" ")
-		query.WriteString(clause.operator.String())
-
-		if clause.operator == IsNull || clause.operator == IsNotNull {
-			// No parameter needed
-		} else {
-			paramCount++
-			//query.WriteString(" $")
-			switch clause.value.(type) {
-			case string:
-				query.WriteString(" \"" + fmt.Sprintf("%v", clause.value) + "\"")
-			default:
-				query.WriteString(" " + fmt.Sprintf("%v", clause.value))
-			}
-
-			//query.WriteString(strconv.Itoa(paramCount))
-			//params = append(params, clause.value)
-		}
-	}
+	writeMutationWhere(&query, whereClauses)
+	writeLimit(&query, ub.limitVal)
 
 	query.WriteString(";")
 
@@ -845,7 +1234,6 @@ func (ub *UpdateBuilder) buildUpdateQuery() (string, []interface{}) {
 func (db *DeleteBuilder) buildDeleteQuery() (string, []interface{}) {
 	var query strings.Builder
 	var params []interface{}
-	paramCount := 0
 
 	// DELETE DOCUMENTS FROM clause
 	query.WriteString("DELETE DOCUMENTS FROM \"")
@@ -853,8 +1241,19 @@ func (db *DeleteBuilder) buildDeleteQuery() (string, []interface{}) {
 	query.WriteString("\"")
 
 	// WHERE clause
+	writeMutationWhere(&query, db.whereClauses)
+	writeLimit(&query, db.limitVal)
+
+	query.WriteString(";")
+
+	return query.String(), params
+}
+
+// writeMutationWhere writes the WHERE clause of an UPDATE or DELETE with
+// quoted field names and inlined values.
+func writeMutationWhere(query *strings.Builder, clauses []whereClause) {
 	query.WriteString(" WHERE ")
-	for i, clause := range db.whereClauses {
+	for i, clause := range clauses {
 		if i > 0 {
 			query.WriteString(" ")
 			query.WriteString(clause.connector.String())
@@ -869,11 +1268,6 @@ func (db *DeleteBuilder) buildDeleteQuery() (string, []interface{}) {
 		if clause.operator == IsNull || clause.operator == IsNotNull {
 			// No parameter needed
 		} else {
-			paramCount++
-			//query.WriteString(" $")
-			//query.WriteString(strconv.Itoa(paramCount))
-			//params = append(params, clause.value)
-			//query.WriteString(" $")
 			switch clause.value.(type) {
 			case string:
 				query.WriteString(" \"" + fmt.Sprintf("%v", clause.value) + "\"")
@@ -882,10 +1276,14 @@ func (db *DeleteBuilder) buildDeleteQuery() (string, []interface{}) {
 			}
 		}
 	}
+}
 
-	query.WriteString(";")
-
-	return query.String(), params
+// writeLimit writes a LIMIT clause if limit is set.
+func writeLimit(query *strings.Builder, limit *int) {
+	if limit != nil {
+		query.WriteString(" LIMIT ")
+		query.WriteString(strconv.Itoa(*limit))
+	}
 }
 
 // ============================================================================
@@ -913,11 +1311,28 @@ func (qb *QueryBuilder) Fingerprint() string {
 
 	// Fields
 	pattern.WriteString(":")
-	if len(qb.fields) > 0 {
-		pattern.WriteString(strings.Join(qb.fields, ","))
+	if qb.distinct {
+		pattern.WriteString("DISTINCT:")
+	}
+	if len(qb.fields) > 0 || len(qb.rawSelects) > 0 {
+		for i, field := range qb.fields {
+			if i > 0 {
+				pattern.WriteString(",")
+			}
+			pattern.WriteString(field)
+			if alias, ok := qb.aliases[i]; ok {
+				pattern.WriteString(" AS " + alias)
+			}
+		}
+		for _, raw := range qb.rawSelects {
+			pattern.WriteString(",RAW(" + raw.sql + ")")
+		}
 	} else {
 		pattern.WriteString("*")
 	}
+	for _, raw := range qb.searchSelects {
+		pattern.WriteString(",RAW(" + raw.sql + ")")
+	}
 
 	// WHERE operators (not values, just structure)
 	if len(qb.whereClauses) > 0 {
@@ -926,8 +1341,15 @@ func (qb *QueryBuilder) Fingerprint() string {
 			if i > 0 {
 				pattern.WriteString(",")
 			}
+			if clause.raw != nil {
+				pattern.WriteString("RAW(" + clause.raw.sql + ")")
+				continue
+			}
 			pattern.WriteString(clause.field)
 			pattern.WriteString(clause.operator.String())
+			if sub, ok := clause.value.(*QueryBuilder); ok && sub != nil {
+				pattern.WriteString("(" + sub.Fingerprint() + ")")
+			}
 		}
 	}
 
@@ -938,6 +1360,10 @@ func (qb *QueryBuilder) Fingerprint() string {
 			if i > 0 {
 				pattern.WriteString(",")
 			}
+			if orderBy.raw != nil {
+				pattern.WriteString("RAW(" + orderBy.raw.sql + ")")
+				continue
+			}
 			pattern.WriteString(orderBy.field)
 			pattern.WriteString(orderBy.direction.String())
 		}
@@ -964,14 +1390,29 @@ func (qb *QueryBuilder) Fingerprint() string {
 
 // inlineParameters replaces parameter placeholders ($1, $2, etc.) with actual values.
 // This is a temporary solution until full prepared statement support is available.
+// Placeholders are replaced in a single pass, so $1 does not match the start
+// of $10 and values containing $n are not substituted again.
 func inlineParameters(query string, params []interface{}) string {
-	result := query
-	for i, param := range params {
-		placeholder := fmt.Sprintf("$%d", i+1)
-		value := formatParameterValue(param)
-		result = strings.ReplaceAll(result, placeholder, value)
+	if len(params) == 0 {
+		return query
+	}
+	var result strings.Builder
+	result.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		if query[i] == '$' {
+			end := i + 1
+			for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+			if n, err := strconv.Atoi(query[i+1 : end]); err == nil && n >= 1 && n <= len(params) {
+				result.WriteString(formatParameterValue(params[n-1]))
+				i = end - 1
+				continue
+			}
+		}
+		result.WriteByte(query[i])
 	}
-	return result
+	return result.String()
 }
 
 // formatParameterValue converts a parameter value to its string representation for inline SQL.
@@ -991,6 +1432,15 @@ func formatParameterValue(param interface{}) string {
 		return fmt.Sprintf("%d", v)
 	case float32, float64:
 		return fmt.Sprintf("%v", v)
+	case wireLiteral:
+		return string(v)
+	case Column:
+		// Columns are validated when conditions are built; any other
+		// invalid one is quoted as a string rather than written raw
+		if columnPattern.MatchString(string(v)) {
+			return string(v)
+		}
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(string(v), "'", "''"))
 	case bool:
 		if v {
 			return "TRUE"
@@ -0,0 +1,100 @@
+package client
+
+import "testing"
+
+// BenchmarkQueryBuilder_Build measures rendering a typical SELECT without executing it.
+func BenchmarkQueryBuilder_Build(b *testing.B) {
+	c := NewClient(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		qb := c.QueryBuilder().
+			Select("users", "id", "name", "email").
+			Where("age", GreaterThan, 21).
+			And("status", Equals, "active").
+			OrderBy("name", Ascending).
+			Limit(50)
+		if _, _, err := qb.buildQuery(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkQueryBuilder_BuildWithJoin measures rendering a SELECT with a join and IN list.
+func BenchmarkQueryBuilder_BuildWithJoin(b *testing.B) {
+	c := NewClient(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		qb := c.QueryBuilder().
+			Select("orders", "id", "total").
+			InnerJoin("users", "user_id", "id").
+			Where("status", In, []interface{}{"paid", "shipped", "delivered"}).
+			OrderBy("total", Descending)
+		if _, _, err := qb.buildQuery(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkInlineParameters measures substituting parameters into a rendered query.
+func BenchmarkInlineParameters(b *testing.B) {
+	query := `SELECT * FROM BUNDLE "users" WHERE "age" > $1 AND "status" == $2 AND "name" LIKE $3;`
+	params := []interface{}{21, "active", "O'Brien%"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = inlineParameters(query, params)
+	}
+}
+
+// BenchmarkQueryBuilder_BuildPooled measures rendering with a pooled builder into a reused buffer.
+func BenchmarkQueryBuilder_BuildPooled(b *testing.B) {
+	c := NewClient(nil)
+	var age, status interface{} = 21, "active"
+	fields := []string{"id", "name", "email"}
+	buf := make([]byte, 0, 256)
+	params := make([]interface{}, 0, 4)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		qb := c.PooledQueryBuilder().
+			Select("users", fields...).
+			Where("age", GreaterThan, age).
+			And("status", Equals, status).
+			OrderBy("name", Ascending).
+			Limit(50)
+		var err error
+		if buf, params, err = qb.AppendQuery(buf[:0], params[:0]); err != nil {
+			b.Fatal(err)
+		}
+		qb.Release()
+	}
+}
+
+// BenchmarkQueryBuilder_BuildParallel measures buildQuery from many goroutines, exercising the buffer pool.
+func BenchmarkQueryBuilder_BuildParallel(b *testing.B) {
+	c := NewClient(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			qb := c.PooledQueryBuilder().Select("users").Where("status", Equals, "active").Limit(50)
+			if _, _, err := qb.buildQuery(); err != nil {
+				b.Error(err)
+				return
+			}
+			qb.Release()
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxPooledQueryBuffer is the largest render buffer returned to the pool;
+// larger ones are left to the GC so one huge query does not pin memory.
+const maxPooledQueryBuffer = 64 << 10
+
+// queryBufferPool holds render buffers for buildQuery.
+var queryBufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 256)
+		return &buf
+	},
+}
+
+func getQueryBuffer() *[]byte {
+	return queryBufferPool.Get().(*[]byte)
+}
+
+func putQueryBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledQueryBuffer {
+		return
+	}
+	*buf = (*buf)[:0]
+	queryBufferPool.Put(buf)
+}
+
+// queryWriter renders a query onto a byte slice, numbering $n placeholders
+// as their values are appended to params.
+type queryWriter struct {
+	buf    []byte
+	params []interface{}
+}
+
+func (w *queryWriter) WriteString(s string) {
+	w.buf = append(w.buf, s...)
+}
+
+func (w *queryWriter) WriteRune(r rune) {
+	w.buf = utf8.AppendRune(w.buf, r)
+}
+
+func (w *queryWriter) WriteInt(n int) {
+	w.buf = strconv.AppendInt(w.buf, int64(n), 10)
+}
+
+// placeholder writes the next $n placeholder and records value as its parameter.
+func (w *queryWriter) placeholder(value interface{}) {
+	w.params = append(w.params, value)
+	w.buf = append(w.buf, '$')
+	w.buf = strconv.AppendInt(w.buf, int64(len(w.params)), 10)
+}
+
+// queryBuilderPool holds released builders with their clause slices, so a
+// builder from PooledQueryBuilder reuses their capacity.
+var queryBuilderPool = sync.Pool{
+	New: func() interface{} { return new(QueryBuilder) },
+}
+
+// PooledQueryBuilder returns a QueryBuilder from a pool. It behaves like one
+// from QueryBuilder; call Release when done with it so its clause slices are
+// reused by the next build. Combined with AppendQuery, building thousands of
+// queries per second creates no garbage beyond the values passed in:
+//
+//	qb := c.PooledQueryBuilder().Select("users").Where("age", client.GreaterThan, 21)
+//	buf, params, err = qb.AppendQuery(buf[:0], params[:0])
+//	qb.Release()
+//
+// The builder must not be used after Release.
+func (c *Client) PooledQueryBuilder() *QueryBuilder {
+	qb := queryBuilderPool.Get().(*QueryBuilder)
+	qb.client = c
+	qb.queryType = selectQuery
+	return qb
+}
+
+// Release resets a builder from PooledQueryBuilder and returns it to the pool.
+// Calling it on a builder from QueryBuilder is allowed and pools it too.
+func (qb *QueryBuilder) Release() {
+	// Clear clause slices before truncating so pooled builders do not keep
+	// caller values alive.
+	clear(qb.rawSelects)
+	clear(qb.whereClauses)
+	clear(qb.orderBys)
+	clear(qb.joinClauses)
+	clear(qb.includes)
+	clear(qb.params)
+
+	*qb = QueryBuilder{
+		rawSelects:   qb.rawSelects[:0],
+		whereClauses: qb.whereClauses[:0],
+		orderBys:     qb.orderBys[:0],
+		joinClauses:  qb.joinClauses[:0],
+		includes:     qb.includes[:0],
+		params:       qb.params[:0],
+	}
+	queryBuilderPool.Put(qb)
+}
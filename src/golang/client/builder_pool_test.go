@@ -0,0 +1,91 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestAppendQueryMatchesBuildQuery verifies byte rendering produces the same query and params.
+func TestAppendQueryMatchesBuildQuery(t *testing.T) {
+	c := NewClient(nil)
+	qb := c.QueryBuilder().
+		Select("users", "id", "name").
+		WhereRaw("lower(name) == ?", "alice").
+		Where("age", GreaterThan, 21).
+		OrderBy("name", Descending).
+		Limit(10).
+		Offset(20)
+
+	query, params, err := qb.buildQuery()
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	buf, appended, err := qb.AppendQuery([]byte("prefix:"), nil)
+	if err != nil {
+		t.Fatalf("AppendQuery failed: %v", err)
+	}
+	if string(buf) != "prefix:"+query {
+		t.Errorf("expected %q, got %q", "prefix:"+query, buf)
+	}
+	if !reflect.DeepEqual(appended, params) {
+		t.Errorf("expected params %v, got %v", params, appended)
+	}
+	expected := "SELECT id, name FROM users WHERE (lower(name) == $1) AND age > $2 ORDER BY name DESC LIMIT 10 OFFSET 20;"
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+}
+
+// TestPooledQueryBuilderReleaseResets verifies a released builder comes back empty.
+func TestPooledQueryBuilderReleaseResets(t *testing.T) {
+	c := NewClient(nil)
+	for i := 0; i < 3; i++ {
+		qb := c.PooledQueryBuilder().Select("users").Where("id", Equals, i).Limit(1)
+		query, params, err := qb.buildQuery()
+		if err != nil {
+			t.Fatalf("buildQuery failed: %v", err)
+		}
+		if query != "SELECT * FROM users WHERE id == $1 LIMIT 1;" || len(params) != 1 || params[0] != i {
+			t.Fatalf("iteration %d: unexpected %q %v", i, query, params)
+		}
+		qb.Release()
+	}
+
+	qb := c.PooledQueryBuilder().Select("orders")
+	defer qb.Release()
+	if query, _, _ := qb.buildQuery(); query != "SELECT * FROM orders;" {
+		t.Errorf("expected clean builder, got %q", query)
+	}
+}
+
+// TestPooledQueryBuilderDoesNotAllocate verifies the pooled path renders without allocating.
+func TestPooledQueryBuilderDoesNotAllocate(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items under the race detector")
+	}
+	c := NewClient(nil)
+	var age, status interface{} = 21, "active"
+	fields := []string{"id", "name"}
+	buf := make([]byte, 0, 256)
+	params := make([]interface{}, 0, 4)
+
+	build := func() {
+		qb := c.PooledQueryBuilder().
+			Select("users", fields...).
+			Where("age", GreaterThan, age).
+			And("status", Equals, status).
+			OrderBy("name", Ascending).
+			Limit(50)
+		var err error
+		buf, params, err = qb.AppendQuery(buf[:0], params[:0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		qb.Release()
+	}
+	build() // warm the pool
+
+	if allocs := testing.AllocsPerRun(100, build); allocs != 0 {
+		t.Errorf("expected 0 allocations per build, got %v", allocs)
+	}
+}
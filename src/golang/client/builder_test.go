@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -277,6 +279,25 @@ func TestQueryBuilder_InOperator(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_ArrayOperators(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+
+	_, err := c.QueryBuilder().Select("posts").
+		WhereContains("tags", "go").
+		WhereAny("authors", "ada", "grace").
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	expected := `SELECT * FROM posts WHERE tags CONTAINS 'go' AND authors CONTAINS ANY ["ada","grace"];`
+	if got := server.received()[0]; got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
 func TestQueryBuilder_OrderBy(t *testing.T) {
 	client := &Client{}
 	qb := &QueryBuilder{client: client}
@@ -682,6 +703,36 @@ func TestQueryBuilder_Fingerprint(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_DistinctAndAliases(t *testing.T) {
+	client := &Client{}
+	qb := &QueryBuilder{client: client}
+	qb.Select("Users").Distinct().SelectAs("status", "s").SelectAs("status", "state")
+
+	query, _, err := qb.buildQuery()
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	if query != "SELECT DISTINCT status AS s, status AS state FROM Users;" {
+		t.Errorf("unexpected query: %s", query)
+	}
+
+	plain := &QueryBuilder{client: client}
+	plain.Select("Users", "status")
+	distinct := &QueryBuilder{client: client}
+	distinct.Select("Users", "status").Distinct()
+	aliased := &QueryBuilder{client: client}
+	aliased.Select("Users").SelectAs("status", "s")
+	if plain.Fingerprint() == distinct.Fingerprint() || plain.Fingerprint() == aliased.Fingerprint() {
+		t.Error("Expected DISTINCT and aliases to change the fingerprint")
+	}
+
+	invalid := &QueryBuilder{client: client}
+	invalid.Select("Users").SelectAs("status", "s; DROP")
+	if _, _, err := invalid.buildQuery(); ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("Expected E_INVALID_QUERY for an invalid alias, got %v", err)
+	}
+}
+
 func TestQueryBuilder_FingerprintFormat(t *testing.T) {
 	client := &Client{}
 	qb := &QueryBuilder{client: client, queryType: selectQuery}
@@ -930,6 +981,31 @@ func TestQueryBuilder_ZeroLimitOffset(t *testing.T) {
 	}
 }
 
+func TestInlineParameters(t *testing.T) {
+	params := []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, "ten", "$1 and $2", nil}
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"single digit", "SELECT * FROM users WHERE a == $1;", "SELECT * FROM users WHERE a == 1;"},
+		{"two digits", "SELECT * FROM users WHERE a == $1 AND b == $10;", "SELECT * FROM users WHERE a == 1 AND b == 'ten';"},
+		{"adjacent", "$10$1", "'ten'1"},
+		{"value containing placeholders", "SELECT * FROM users WHERE a == $11;", "SELECT * FROM users WHERE a == '$1 and $2';"},
+		{"null", "SELECT * FROM users WHERE a == $12;", "SELECT * FROM users WHERE a == NULL;"},
+		{"out of range", "SELECT * FROM users WHERE a == $13 AND b == $0;", "SELECT * FROM users WHERE a == $13 AND b == $0;"},
+		{"bare dollar", "SELECT * FROM users WHERE a == '$' AND b == $2;", "SELECT * FROM users WHERE a == '$' AND b == 2;"},
+	}
+	for _, tt := range tests {
+		if got := inlineParameters(tt.query, params); got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, got)
+		}
+	}
+	if got := inlineParameters("SELECT $1;", nil); got != "SELECT $1;" {
+		t.Errorf("expected a query without params unchanged, got %s", got)
+	}
+}
+
 // ============================================================================
 // Integration Tests (require running SyndrDB server)
 // ============================================================================
@@ -939,13 +1015,39 @@ const (
 	integrationTestTimeout = 10000
 )
 
-// skipIfNoServer skips the test if SyndrDB server is not available
+// skipIfNoServer connects to a SyndrDB server for an integration test.
+// With SYNDRDB_RECORD set, traffic with the live server is recorded to
+// testdata/fixtures/<test>.json. Otherwise a recorded fixture is replayed if one
+// exists, and the test is skipped if neither a fixture nor a server is available.
 func skipIfNoServer(t *testing.T) *Client {
 	opts := DefaultOptions()
+	connStr := integrationTestConnStr
+	fixturePath := filepath.Join("testdata", "fixtures", t.Name()+".json")
+
+	if os.Getenv("SYNDRDB_RECORD") != "" {
+		recorder := NewTrafficRecorder()
+		opts.Dialer = recorder.Dial
+		t.Cleanup(func() {
+			if t.Skipped() {
+				return
+			}
+			if err := recorder.Save(fixturePath); err != nil {
+				t.Errorf("failed to save fixture: %v", err)
+			}
+		})
+	} else if fixture, err := LoadFixture(fixturePath); err == nil {
+		server, err := NewFixtureServer(fixture)
+		if err != nil {
+			t.Fatalf("failed to start fixture server: %v", err)
+		}
+		t.Cleanup(func() { server.Close() })
+		connStr = server.ConnectionString(connStr)
+	}
+
 	c := NewClient(&opts)
 
 	ctx := context.Background()
-	err := c.Connect(ctx, integrationTestConnStr)
+	err := c.Connect(ctx, connStr)
 	if err != nil {
 		t.Skipf("Skipping integration test: SyndrDB server not available: %v", err)
 		return nil
@@ -1275,7 +1377,7 @@ func TestIntegration_UpdateBuilder(t *testing.T) {
 	t.Logf("Update result: %+v", result)
 
 	// Check if result contains an error from server
-	if resultMap, ok := result.(map[string]interface{}); ok {
+	if resultMap, ok := result.Raw.(map[string]interface{}); ok {
 		if status, hasStatus := resultMap["status"]; hasStatus && status == "error" {
 			t.Fatalf("Server returned error: %v", resultMap["message"])
 		}
@@ -1331,7 +1433,7 @@ func TestIntegration_DeleteBuilder(t *testing.T) {
 	t.Logf("Delete result: %+v", result)
 
 	// Check if result contains an error from server
-	if resultMap, ok := result.(map[string]interface{}); ok {
+	if resultMap, ok := result.Raw.(map[string]interface{}); ok {
 		if status, hasStatus := resultMap["status"]; hasStatus && status == "error" {
 			t.Fatalf("Server returned error: %v", resultMap["message"])
 		}
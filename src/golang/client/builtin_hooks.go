@@ -3,8 +3,12 @@ package client
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/cespare/xxhash"
 )
 
 // ============================================================================
@@ -77,6 +81,16 @@ type MetricsHook struct {
 	TotalMutations  atomic.Uint64
 	TotalErrors     atomic.Uint64
 	TotalDurationNs atomic.Uint64
+
+	// Commands rejected by the client's limiter with E_RATE_LIMITED
+	TotalRateLimited atomic.Uint64
+
+	// Low-priority commands shed by the client with E_OVERLOADED
+	TotalOverloaded atomic.Uint64
+
+	// Response sizes in bytes, as reported in HookContext.ResponseBytes
+	TotalResponseBytes atomic.Uint64
+	MaxResponseBytes   atomic.Uint64
 }
 
 // NewMetricsHook creates a new metrics collection hook.
@@ -105,6 +119,23 @@ func (h *MetricsHook) After(ctx context.Context, hookCtx *HookContext) error {
 
 	if hookCtx.Error != nil {
 		h.TotalErrors.Add(1)
+		switch ErrorCode(hookCtx.Error) {
+		case "E_RATE_LIMITED":
+			h.TotalRateLimited.Add(1)
+		case "E_OVERLOADED":
+			h.TotalOverloaded.Add(1)
+		}
+	}
+
+	if hookCtx.ResponseBytes > 0 {
+		size := uint64(hookCtx.ResponseBytes)
+		h.TotalResponseBytes.Add(size)
+		for {
+			peak := h.MaxResponseBytes.Load()
+			if size <= peak || h.MaxResponseBytes.CompareAndSwap(peak, size) {
+				break
+			}
+		}
 	}
 
 	return nil
@@ -115,20 +146,29 @@ func (h *MetricsHook) GetStats() map[string]interface{} {
 	totalCmds := h.TotalCommands.Load()
 	totalDur := h.TotalDurationNs.Load()
 
+	totalBytes := h.TotalResponseBytes.Load()
+
 	avgDuration := int64(0)
+	avgBytes := uint64(0)
 	if totalCmds > 0 {
 		avgDuration = int64(totalDur / totalCmds)
+		avgBytes = totalBytes / totalCmds
 	}
 
 	return map[string]interface{}{
-		"total_commands":    totalCmds,
-		"total_queries":     h.TotalQueries.Load(),
-		"total_mutations":   h.TotalMutations.Load(),
-		"total_errors":      h.TotalErrors.Load(),
-		"total_duration_ns": totalDur,
-		"avg_duration_ns":   avgDuration,
-		"avg_duration_ms":   float64(avgDuration) / 1_000_000,
-		"total_duration_ms": float64(totalDur) / 1_000_000,
+		"total_commands":       totalCmds,
+		"total_queries":        h.TotalQueries.Load(),
+		"total_mutations":      h.TotalMutations.Load(),
+		"total_errors":         h.TotalErrors.Load(),
+		"total_rate_limited":   h.TotalRateLimited.Load(),
+		"total_overloaded":     h.TotalOverloaded.Load(),
+		"total_duration_ns":    totalDur,
+		"avg_duration_ns":      avgDuration,
+		"avg_duration_ms":      float64(avgDuration) / 1_000_000,
+		"total_duration_ms":    float64(totalDur) / 1_000_000,
+		"total_response_bytes": totalBytes,
+		"avg_response_bytes":   avgBytes,
+		"max_response_bytes":   h.MaxResponseBytes.Load(),
 	}
 }
 
@@ -138,15 +178,19 @@ func (h *MetricsHook) Reset() {
 	h.TotalQueries.Store(0)
 	h.TotalMutations.Store(0)
 	h.TotalErrors.Store(0)
+	h.TotalRateLimited.Store(0)
+	h.TotalOverloaded.Store(0)
 	h.TotalDurationNs.Store(0)
+	h.TotalResponseBytes.Store(0)
+	h.MaxResponseBytes.Store(0)
 }
 
 // ============================================================================
 // TracingHook - Distributed tracing support
 // ============================================================================
 
-// TracingHook provides distributed tracing integration.
-// TODO: Add OpenTelemetry integration when dependency is approved.
+// TracingHook records trace timing in hook metadata without external dependencies.
+// For OpenTelemetry spans use the client/tracing package instead.
 type TracingHook struct {
 	serviceName string
 }
@@ -163,32 +207,14 @@ func (h *TracingHook) Name() string {
 }
 
 func (h *TracingHook) Before(ctx context.Context, hookCtx *HookContext) error {
-	// TODO: Start OpenTelemetry span
-	// span, ctx := otel.Tracer(h.serviceName).Start(ctx, hookCtx.CommandType)
-	// hookCtx.Metadata["trace_span"] = span
-
-	// For now, just record start time
+	// Record start time
 	hookCtx.Metadata["trace_start"] = time.Now()
 	hookCtx.Metadata["trace_service"] = h.serviceName
 	return nil
 }
 
 func (h *TracingHook) After(ctx context.Context, hookCtx *HookContext) error {
-	// TODO: End OpenTelemetry span with attributes
-	// if span, ok := hookCtx.Metadata["trace_span"].(trace.Span); ok {
-	//     span.SetAttributes(
-	//         attribute.String("db.system", "syndrdb"),
-	//         attribute.String("db.statement", hookCtx.Command),
-	//         attribute.String("db.operation", hookCtx.CommandType),
-	//     )
-	//     if hookCtx.Error != nil {
-	//         span.RecordError(hookCtx.Error)
-	//         span.SetStatus(codes.Error, hookCtx.Error.Error())
-	//     }
-	//     span.End()
-	// }
-
-	// For now, calculate duration manually
+	// Calculate duration
 	if start, ok := hookCtx.Metadata["trace_start"].(time.Time); ok {
 		duration := time.Since(start)
 		hookCtx.Metadata["trace_duration"] = duration
@@ -200,7 +226,9 @@ func (h *TracingHook) After(ctx context.Context, hookCtx *HookContext) error {
 // RetryHook - Automatic retry with exponential backoff
 // ============================================================================
 
-// RetryHook automatically retries failed commands with exponential backoff.
+// RetryHook waits with exponential backoff after retryable failures.
+// Hooks cannot re-execute a command; use ClientOptions.RetryPolicy or a
+// Middleware for real retries.
 type RetryHook struct {
 	maxRetries      int
 	initialBackoff  time.Duration
@@ -275,8 +303,7 @@ func (h *RetryHook) After(ctx context.Context, hookCtx *HookContext) error {
 	case <-timer.C:
 		// Increment retry count for next attempt
 		hookCtx.Metadata["retry_count"] = retryCount + 1
-		// TODO: Implement actual retry logic - needs access to Client.sendCommand
-		// For now, just log that we would retry
+		// Re-executing needs a Middleware; a hook can only delay
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
@@ -288,6 +315,8 @@ func (h *RetryHook) After(ctx context.Context, hookCtx *HookContext) error {
 // ============================================================================
 
 // CacheHook caches query results for read operations.
+// It never invalidates entries; prefer QueryBuilder.Cached, which uses the
+// client QueryCache with LRU limits and invalidation on writes.
 type CacheHook struct {
 	cache   map[string]interface{}
 	mu      atomic.Value // stores *sync.RWMutex
@@ -345,6 +374,198 @@ func (h *CacheHook) ClearCache() {
 	h.cache = make(map[string]interface{})
 }
 
+// ============================================================================
+// SlowQueryHook - Records commands exceeding a duration threshold
+// ============================================================================
+
+// defaultSlowQueryCapacity is the default number of slow queries retained.
+const defaultSlowQueryCapacity = 100
+
+// SlowQuery describes a command that exceeded the slow query threshold.
+type SlowQuery struct {
+	Command     string
+	CommandType string
+	Fingerprint string
+	Duration    time.Duration
+	Params      []interface{}
+	TraceID     string
+	Tags        map[string]string // Tags set with WithTag or WithQueryTags
+	Error       error
+	Timestamp   time.Time
+}
+
+// SlowQueryHook records commands slower than a threshold in a bounded ring buffer.
+// Each slow command is logged to the sink (if set) and passed to the optional callback.
+type SlowQueryHook struct {
+	threshold time.Duration
+	sink      Logger
+	redact    bool
+	onSlow    func(SlowQuery)
+
+	mu      sync.Mutex
+	entries []SlowQuery
+	next    int
+	full    bool
+}
+
+// NewSlowQueryHook creates a slow query hook. sink may be nil to disable logging.
+func NewSlowQueryHook(threshold time.Duration, sink Logger) *SlowQueryHook {
+	return &SlowQueryHook{
+		threshold: threshold,
+		sink:      sink,
+		entries:   make([]SlowQuery, defaultSlowQueryCapacity),
+	}
+}
+
+// WithCapacity sets the number of slow queries retained (default 100).
+// Existing entries are discarded.
+func (h *SlowQueryHook) WithCapacity(capacity int) *SlowQueryHook {
+	if capacity < 1 {
+		capacity = 1
+	}
+	h.mu.Lock()
+	h.entries = make([]SlowQuery, capacity)
+	h.next = 0
+	h.full = false
+	h.mu.Unlock()
+	return h
+}
+
+// WithRedaction masks parameters and literal values in recorded commands.
+func (h *SlowQueryHook) WithRedaction(enabled bool) *SlowQueryHook {
+	h.redact = enabled
+	return h
+}
+
+// OnSlowQuery sets a callback invoked synchronously for every slow query, e.g. for alerting.
+func (h *SlowQueryHook) OnSlowQuery(fn func(SlowQuery)) *SlowQueryHook {
+	h.onSlow = fn
+	return h
+}
+
+func (h *SlowQueryHook) Name() string {
+	return "slow-query"
+}
+
+func (h *SlowQueryHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	return nil
+}
+
+func (h *SlowQueryHook) After(ctx context.Context, hookCtx *HookContext) error {
+	if hookCtx.Duration < h.threshold {
+		return nil
+	}
+
+	entry := SlowQuery{
+		Command:     hookCtx.Command,
+		CommandType: hookCtx.CommandType,
+		Fingerprint: CommandFingerprint(hookCtx.Command),
+		Duration:    hookCtx.Duration,
+		Params:      hookCtx.Params,
+		TraceID:     hookCtx.TraceID,
+		Error:       hookCtx.Error,
+		Timestamp:   time.Now(),
+	}
+	if tags, ok := hookCtx.Metadata[MetadataTags].(map[string]string); ok {
+		entry.Tags = tags
+	}
+
+	if h.redact {
+		entry.Command = normalizeCommand(entry.Command)
+		if len(entry.Params) > 0 {
+			redacted := make([]interface{}, len(entry.Params))
+			for i := range redacted {
+				redacted[i] = "[REDACTED]"
+			}
+			entry.Params = redacted
+		}
+	}
+
+	h.mu.Lock()
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+	h.mu.Unlock()
+
+	if h.sink != nil {
+		fields := []Field{
+			String("command", entry.Command),
+			String("fingerprint", entry.Fingerprint),
+			Duration("duration", entry.Duration),
+			Duration("threshold", h.threshold),
+			String("trace_id", entry.TraceID),
+		}
+		if len(entry.Params) > 0 {
+			fields = append(fields, String("params", fmt.Sprintf("%v", entry.Params)))
+		}
+		if len(entry.Tags) > 0 {
+			fields = append(fields, String("tags", formatQueryTags(entry.Tags)))
+		}
+		if entry.Error != nil {
+			fields = append(fields, Error("error", entry.Error))
+		}
+		h.sink.Warn("slow query", fields...)
+	}
+
+	if h.onSlow != nil {
+		h.onSlow(entry)
+	}
+
+	return nil
+}
+
+// GetSlowQueries returns the recorded slow queries, oldest first.
+func (h *SlowQueryHook) GetSlowQueries() []SlowQuery {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.full {
+		result := make([]SlowQuery, h.next)
+		copy(result, h.entries[:h.next])
+		return result
+	}
+
+	result := make([]SlowQuery, 0, len(h.entries))
+	result = append(result, h.entries[h.next:]...)
+	result = append(result, h.entries[:h.next]...)
+	return result
+}
+
+// Reset clears all recorded slow queries.
+func (h *SlowQueryHook) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = make([]SlowQuery, len(h.entries))
+	h.next = 0
+	h.full = false
+}
+
+// Literal patterns replaced by normalizeCommand.
+var (
+	singleQuotedLiteral   = regexp.MustCompile(`'(?:[^']|'')*'`)
+	comparedDoubleQuoted  = regexp.MustCompile(`((?:==|!=|>=|<=|=|>|<)\s*)"[^"]*"`)
+	numericLiteral        = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	comparedBooleanOrNull = regexp.MustCompile(`(?i)((?:==|!=|=)\s*)(?:TRUE|FALSE|NULL)\b`)
+)
+
+// normalizeCommand replaces literal values in a SyndrQL command with "?",
+// leaving identifiers and structure intact.
+func normalizeCommand(command string) string {
+	normalized := singleQuotedLiteral.ReplaceAllString(command, "?")
+	normalized = comparedDoubleQuoted.ReplaceAllString(normalized, "${1}?")
+	normalized = comparedBooleanOrNull.ReplaceAllString(normalized, "${1}?")
+	return numericLiteral.ReplaceAllString(normalized, "?")
+}
+
+// CommandFingerprint returns a stable identifier for the shape of a command.
+// Commands differing only in literal values share a fingerprint.
+func CommandFingerprint(command string) string {
+	hash := xxhash.Sum64String(normalizeCommand(command))
+	return fmt.Sprintf("cmd_%016x", hash)
+}
+
 // Helper function to check if error string contains error code.
 // Checks for exact substring match anywhere in the string.
 func containsErrorCode(s, substr string) bool {
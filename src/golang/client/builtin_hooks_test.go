@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -456,3 +457,94 @@ func TestMetricsHookAverageDuration(t *testing.T) {
 		t.Errorf("expected avg duration %d ns, got %d ns", expectedAvg, avgNs)
 	}
 }
+
+// TestSlowQueryHook verifies only commands over the threshold are recorded.
+func TestSlowQueryHook(t *testing.T) {
+	var alerted []SlowQuery
+	hook := NewSlowQueryHook(50*time.Millisecond, NewLogger("DEBUG", nil)).
+		OnSlowQuery(func(q SlowQuery) { alerted = append(alerted, q) })
+
+	if hook.Name() != "slow-query" {
+		t.Errorf("expected name 'slow-query', got %s", hook.Name())
+	}
+
+	ctx := context.Background()
+	fast := &HookContext{Command: `SELECT * FROM "users";`, Duration: 10 * time.Millisecond, Metadata: map[string]interface{}{}}
+	slow := &HookContext{
+		Command:  `SELECT * FROM "users" WHERE "age" > 30;`,
+		Duration: 80 * time.Millisecond,
+		TraceID:  "trace-1",
+		Params:   []interface{}{30},
+		Metadata: map[string]interface{}{},
+	}
+
+	hook.After(ctx, fast)
+	hook.After(ctx, slow)
+
+	queries := hook.GetSlowQueries()
+	if len(queries) != 1 {
+		t.Fatalf("expected 1 slow query, got %d", len(queries))
+	}
+	q := queries[0]
+	if q.TraceID != "trace-1" || q.Duration != 80*time.Millisecond || q.Command != slow.Command {
+		t.Errorf("unexpected slow query entry: %+v", q)
+	}
+	if q.Fingerprint != CommandFingerprint(`SELECT * FROM "users" WHERE "age" > 99;`) {
+		t.Error("expected fingerprint to ignore literal values")
+	}
+	if len(q.Params) != 1 || q.Params[0] != 30 {
+		t.Errorf("expected params [30], got %v", q.Params)
+	}
+	if len(alerted) != 1 {
+		t.Errorf("expected callback once, got %d", len(alerted))
+	}
+
+	hook.Reset()
+	if len(hook.GetSlowQueries()) != 0 {
+		t.Error("expected no slow queries after Reset")
+	}
+}
+
+// TestSlowQueryHookRingBuffer verifies the buffer keeps only the newest entries.
+func TestSlowQueryHookRingBuffer(t *testing.T) {
+	hook := NewSlowQueryHook(0, nil).WithCapacity(3)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		hook.After(ctx, &HookContext{
+			Command:  fmt.Sprintf("cmd-%d", i),
+			TraceID:  fmt.Sprintf("trace-%d", i),
+			Metadata: map[string]interface{}{},
+		})
+	}
+
+	queries := hook.GetSlowQueries()
+	if len(queries) != 3 {
+		t.Fatalf("expected 3 slow queries, got %d", len(queries))
+	}
+	for i, q := range queries {
+		if expected := fmt.Sprintf("trace-%d", i+2); q.TraceID != expected {
+			t.Errorf("entry %d: expected %s, got %s", i, expected, q.TraceID)
+		}
+	}
+}
+
+// TestSlowQueryHookRedaction verifies literals and params are masked.
+func TestSlowQueryHookRedaction(t *testing.T) {
+	hook := NewSlowQueryHook(0, nil).WithRedaction(true)
+
+	hook.After(context.Background(), &HookContext{
+		Command:  `SELECT * FROM "users" WHERE "email" == "bob@example.com" AND "age" > 21 AND "name" == 'bob';`,
+		Params:   []interface{}{"secret"},
+		Metadata: map[string]interface{}{},
+	})
+
+	q := hook.GetSlowQueries()[0]
+	expected := `SELECT * FROM "users" WHERE "email" == ? AND "age" > ? AND "name" == ?;`
+	if q.Command != expected {
+		t.Errorf("expected %q, got %q", expected, q.Command)
+	}
+	if q.Params[0] != "[REDACTED]" {
+		t.Errorf("expected redacted param, got %v", q.Params[0])
+	}
+}
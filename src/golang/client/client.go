@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -24,10 +25,36 @@ type Client struct {
 	debugMode          atomic.Bool
 	activeTransactions sync.Map // map[string]*transactionContext
 	stmtCache          *StatementCache
+	queryCache         *QueryCache
+	limiter            *commandLimiter // nil when no command limits are configured
+	shedder            *loadShedder    // nil when load shedding is disabled
+	hedges             hedgeCounters
 	schemaValidator    *SchemaValidator // Schema validation for QueryBuilder
 	txMonitorDone      chan struct{}
 	hooks              []hookEntry  // Registered hooks in execution order
+	recentErrors       errorLog     // Last failed commands, for DiagnosticsSnapshot
 	hooksMu            sync.RWMutex // Protects hooks slice
+	nextHookOrder      int          // Registration order of the next new hook
+	middleware         []Middleware
+	middlewareMu       sync.Mutex                  // Protects middleware
+	commandChain       atomic.Pointer[CommandFunc] // middleware wrapped around sendWithRetries; nil without middleware
+	schemaHandlers     []SchemaChangeHandler
+	schemaHandlersMu   sync.RWMutex                         // Protects schemaHandlers
+	serverInfo         *ServerInfo                          // From the latest handshake; nil before connecting
+	serverInfoMu       sync.RWMutex                         // Protects serverInfo
+	redaction          *RedactionPolicy                     // Masks sensitive values; nil disables
+	database           string                               // Set by UseDatabase; "" keeps the connection string's
+	databaseMu         sync.RWMutex                         // Protects database
+	encryptors         map[string]map[string]FieldEncryptor // bundle -> field -> encryptor
+	encryptorsMu       sync.RWMutex                         // Protects encryptors
+	codecs             codecRegistry                        // Field and type codecs
+	tenancy            tenantRegistry                       // Tenant-scoped bundles
+	offline            *offlineQueue                        // nil unless EnableOfflineQueue was called
+	offlineMu          sync.RWMutex                         // Protects offline
+	offlineReplayOnce  sync.Once                            // Registers the reconnect replay handler
+	members            []string                             // Cluster members from opts.Discoverer
+	membersMu          sync.RWMutex                         // Protects members
+	nextMember         atomic.Uint32                        // Member the next connection starts with
 }
 
 // NewClient creates a new SyndrDB client with the given options.
@@ -43,6 +70,7 @@ func NewClient(opts *ClientOptions) *Client {
 	if logger == nil {
 		logger = NewLogger(opts.LogLevel, nil)
 	}
+	logger = opts.Redaction.wrapLogger(logger)
 
 	// Initialize statement cache
 	cacheSize := opts.PreparedStatementCacheSize
@@ -50,19 +78,34 @@ func NewClient(opts *ClientOptions) *Client {
 		cacheSize = 100 // Default cache size
 	}
 
+	queryCacheSize := opts.QueryCacheSize
+	if queryCacheSize == 0 {
+		queryCacheSize = 1000 // Default cache size
+	}
+
 	client := &Client{
 		opts:          *opts,
 		stateMgr:      NewStateManager(),
 		logger:        logger,
+		redaction:     opts.Redaction,
 		poolEnabled:   opts.PoolMaxSize > 1,
 		stmtCache:     NewStatementCache(cacheSize),
+		queryCache:    NewQueryCache(queryCacheSize),
+		limiter:       newCommandLimiter(opts),
+		shedder:       newLoadShedder(opts),
 		txMonitorDone: make(chan struct{}),
 	}
 
 	client.debugMode.Store(opts.DebugMode)
+	if opts.StateHistorySize != 0 {
+		client.stateMgr.SetHistorySize(opts.StateHistorySize)
+	}
 
 	// Initialize schema validator
 	client.schemaValidator = NewSchemaValidator(client, opts.SchemaCacheTTL, opts.PreloadSchema)
+	if opts.SchemaCache != nil {
+		client.schemaValidator.cache = opts.SchemaCache
+	}
 
 	// Wire up lifecycle callbacks if provided
 	if opts.OnConnected != nil || opts.OnDisconnected != nil || opts.OnReconnecting != nil {
@@ -88,8 +131,14 @@ func NewClient(opts *ClientOptions) *Client {
 }
 
 // Connect establishes a connection to the SyndrDB server.
-// Connection string format: syndrdb://host:port/database
+// Connection string format: syndrdb://host:port/database, or
+// syndrdb+unix:///path/to/socket:database:user:password; for a server on the
+// same machine listening on a Unix domain socket.
+// An empty connStr uses ClientOptions.ConnString.
 func (c *Client) Connect(ctx context.Context, connStr string) error {
+	if connStr == "" {
+		connStr = c.opts.ConnString
+	}
 	c.logger.Info("connecting to database", String("connStr", connStr), Bool("poolEnabled", c.poolEnabled))
 
 	// Transition to CONNECTING state
@@ -102,6 +151,20 @@ func (c *Client) Connect(ctx context.Context, connStr string) error {
 		return err
 	}
 
+	// A Unix domain socket connection dials the socket and sends the usual
+	// syndrdb:// handshake
+	socketPath := ""
+	if strings.HasPrefix(connStr, unixScheme) {
+		path, handshake, err := parseUnixConnString(connStr)
+		if err != nil {
+			c.stateMgr.TransitionTo(DISCONNECTED, nil, map[string]interface{}{
+				"reason": "error",
+			})
+			return err
+		}
+		socketPath, connStr = path, handshake
+	}
+
 	// Validate connection string format
 	if !strings.HasPrefix(connStr, "syndrdb://") {
 		c.stateMgr.TransitionTo(DISCONNECTED, nil, map[string]interface{}{
@@ -110,10 +173,10 @@ func (c *Client) Connect(ctx context.Context, connStr string) error {
 		return &ConnectionError{
 			Code:    "INVALID_SCHEME",
 			Type:    "CONNECTION_ERROR",
-			Message: "connection string must use 'syndrdb://' scheme",
+			Message: "connection string must use 'syndrdb://' or '" + unixScheme + "' scheme",
 			Details: map[string]interface{}{
 				"connectionString": connStr,
-				"expected":         "syndrdb://",
+				"expected":         "syndrdb:// or " + unixScheme,
 			},
 		}
 	}
@@ -138,6 +201,9 @@ func (c *Client) Connect(ctx context.Context, connStr string) error {
 	}
 
 	address := parts[0] + ":" + parts[1] // HOST:PORT
+	if socketPath != "" {
+		address = UnixAddress(socketPath)
+	}
 	c.connStr = connStr
 
 	// Parse TLS options from connection string query parameters
@@ -165,13 +231,32 @@ func (c *Client) Connect(ctx context.Context, connStr string) error {
 		return c.createAndAuthenticateConnection(ctx, address, connStr)
 	}
 
-	// Use pool mode if configured
-	if c.poolEnabled {
-		return c.connectWithPool(ctx)
+	// Connect to discovered cluster members instead of the connection string's host
+	if c.opts.Discoverer != nil {
+		if err := c.discoverMembers(ctx); err != nil {
+			c.stateMgr.TransitionTo(DISCONNECTED, err, map[string]interface{}{
+				"reason": "discovery_failed",
+			})
+			return err
+		}
+		c.connFactory = func(ctx context.Context) (ConnectionInterface, error) {
+			return c.connectMember(ctx, connStr)
+		}
 	}
 
-	// Use single connection mode
-	return c.connectSingle(ctx)
+	// Use pool mode if configured, otherwise single connection mode
+	if c.poolEnabled {
+		err = c.connectWithPool(ctx)
+	} else {
+		err = c.connectSingle(ctx)
+	}
+	if err == nil && c.opts.Discoverer != nil {
+		c.startDiscoveryRefresh(c.txMonitorDone)
+	}
+	if err == nil && c.opts.SchemaNotifications {
+		c.startSchemaSubscription(c.txMonitorDone)
+	}
+	return err
 }
 
 // createAndAuthenticateConnection creates a new connection and performs authentication.
@@ -247,6 +332,21 @@ func (c *Client) createAndAuthenticateConnection(ctx context.Context, address, c
 		}
 	}
 
+	if err := c.negotiateCompression(ctx, conn, authData); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	info := parseServerInfo(authData, conn.tlsState != nil)
+	conn.serverInfo = info
+	conn.database = connStrDatabase(connStr)
+	c.setServerInfo(info)
+	c.logger.Debug("server capabilities",
+		String("version", info.Version),
+		String("features", strings.Join(info.Features, ",")))
+	if !info.TLS && info.Features != nil && info.Supports(FeatureTLS) {
+		c.logger.Warn("server supports TLS but the connection is unencrypted; set TLSEnabled to encrypt traffic")
+	}
+
 	return conn, nil
 }
 
@@ -263,6 +363,7 @@ func (c *Client) connectWithPool(ctx context.Context) error {
 		c.opts.PoolIdleTimeout,
 		c.opts.HealthCheckInterval,
 	)
+	c.pool.configure(c.poolSettings())
 
 	if err := c.pool.Initialize(ctx); err != nil {
 		c.logger.Error("failed to initialize connection pool", Error("error", err))
@@ -287,6 +388,16 @@ func (c *Client) connectWithPool(ctx context.Context) error {
 	return nil
 }
 
+// poolSettings returns the pool options NewConnectionPool does not take.
+func (c *Client) poolSettings() poolSettings {
+	return poolSettings{
+		refillJitter: c.opts.PoolRefillJitter,
+		maxLifetime:  c.opts.PoolMaxConnLifetime,
+		maxUses:      c.opts.PoolMaxConnUses,
+		idleProbe:    c.opts.IdleProbeInterval,
+	}
+}
+
 // connectSingle establishes a single persistent connection with retries.
 func (c *Client) connectSingle(ctx context.Context) error {
 	var lastErr error
@@ -316,6 +427,7 @@ func (c *Client) connectSingle(ctx context.Context) error {
 
 			// Start transaction timeout monitor
 			go c.transactionTimeoutMonitor()
+			c.startIdleProbe(c.txMonitorDone)
 
 			c.stateMgr.TransitionTo(CONNECTED, nil, map[string]interface{}{
 				"reason":     "user_initiated",
@@ -381,6 +493,7 @@ func (c *Client) Disconnect(ctx context.Context) error {
 				String("tx_id", txCtx.tx.id),
 				Error("error", err))
 		}
+		c.releaseTransaction(key.(string))
 		return true
 	})
 
@@ -446,34 +559,56 @@ func (c *Client) GetLastTransition() StateTransition {
 	return c.stateMgr.GetLastTransition()
 }
 
+// GetStateHistory returns up to limit of the most recent state transitions,
+// oldest first. A limit of zero or less returns every recorded transition;
+// StateHistorySize sets how many are kept.
+func (c *Client) GetStateHistory(limit int) []StateTransition {
+	return c.stateMgr.GetStateHistory(limit)
+}
+
+// QueryStateHistory returns the recorded state transitions matching filter,
+// oldest first, e.g. only the errors or reconnects of a flapping connection.
+func (c *Client) QueryStateHistory(filter TransitionFilter) []StateTransition {
+	return c.stateMgr.QueryStateHistory(filter)
+}
+
 // OnStateChange registers a handler to be called on state transitions.
 func (c *Client) OnStateChange(handler StateChangeHandler) {
 	c.stateMgr.OnStateChange(handler)
 }
 
+// SchemaChangeHandler is called with the DDL command after it succeeds. The
+// command is "" for a schema notification that does not name it.
+type SchemaChangeHandler func(command string)
+
+// OnSchemaChange registers a handler for bundle DDL commands (see DetectDDL)
+// that succeed on this client. Handlers run in their own goroutine.
+// Changes made by other clients are observed only with SchemaNotifications.
+func (c *Client) OnSchemaChange(handler SchemaChangeHandler) {
+	c.schemaHandlersMu.Lock()
+	defer c.schemaHandlersMu.Unlock()
+	c.schemaHandlers = append(c.schemaHandlers, handler)
+}
+
 // GetVersion returns the build version of the client.
 func (c *Client) GetVersion() string {
 	return Version
 }
 
-// sendCommand sends a command and validates connection state.
-func (c *Client) sendCommand(ctx context.Context, command string) (interface{}, error) {
+// sendCommandOnce sends a command and validates connection state.
+// Callers should use sendCommand, which adds middleware and retries.
+func (c *Client) sendCommandOnce(ctx context.Context, command string) (interface{}, error) {
 	if c.stateMgr.GetState() != CONNECTED {
 		return nil, ErrInvalidState("sendCommand", CONNECTED, c.stateMgr.GetState())
 	}
 
-	start := time.Now()
-	traceID := uuid.New().String()
-	debugMode := c.IsDebugMode()
-
 	// Initialize hook context
-	hookCtx := &HookContext{
-		Command:     command,
-		CommandType: inferCommandType(command),
-		Params:      nil,
-		StartTime:   start,
-		Metadata:    make(map[string]interface{}),
-		TraceID:     traceID,
+	hookCtx := newHookContext(ctx, command)
+	start := hookCtx.StartTime
+	traceID := hookCtx.TraceID
+	debugMode := c.IsDebugMode()
+	if c.opts.WireCapture != nil {
+		ctx = context.WithValue(ctx, wireTraceKey{}, traceID)
 	}
 
 	// Execute before hooks
@@ -484,12 +619,60 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 	// Use potentially modified command from hooks
 	command = hookCtx.Command
 
+	if err := c.opts.AccessPolicy.Check(command); err != nil {
+		c.logger.Warn("command rejected by access policy",
+			String("trace_id", traceID),
+			Error("error", err))
+
+		hookCtx.Error = err
+		hookCtx.Duration = time.Since(start)
+		c.executeAfterHooks(ctx, hookCtx)
+
+		return nil, err
+	}
+
+	if c.shedder != nil {
+		if err := c.shedder.admit(QueryPriorityFromContext(ctx)); err != nil {
+			c.logger.Warn("low-priority command shed",
+				String("trace_id", traceID),
+				Error("error", err))
+
+			hookCtx.Error = err
+			hookCtx.Duration = time.Since(start)
+			c.executeAfterHooks(ctx, hookCtx)
+
+			return nil, err
+		}
+	}
+
+	if c.limiter != nil {
+		release, err := c.limiter.acquire(ctx)
+		if err != nil {
+			c.logger.Warn("command rejected by limiter",
+				String("trace_id", traceID),
+				Error("error", err))
+
+			hookCtx.Error = err
+			hookCtx.Duration = time.Since(start)
+			c.executeAfterHooks(ctx, hookCtx)
+
+			return nil, err
+		}
+		defer release()
+	}
+
+	if c.shedder != nil {
+		admitted := time.Now()
+		defer func() { c.shedder.record(time.Since(admitted)) }()
+	}
+
 	// Debug logging: log raw command before sending
 	if debugMode {
-		c.logger.Debug("sending raw command",
+		c.logger.Debug("sending raw command", append([]Field{
 			String("command", command),
 			String("trace_id", traceID),
-			String("timestamp", start.Format(time.RFC3339Nano)))
+			String("timestamp", start.Format(time.RFC3339Nano))},
+			queryMetadataFields(ctx)...)...)
 	}
 
 	// Use pool mode if enabled
@@ -503,7 +686,20 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 				Int("idle_connections", int(poolStats.IdleConnections.Load())))
 		}
 
-		conn, err := c.pool.Get(ctx)
+		session := SessionFromContext(ctx)
+		if session != nil && session.client != c {
+			session = nil
+		}
+
+		waitStart := time.Now()
+		var conn ConnectionInterface
+		var err error
+		if session != nil {
+			conn, err = session.acquire(ctx)
+		} else {
+			conn, err = c.pool.Get(ctx)
+		}
+		hookCtx.PoolWaitDuration = time.Since(waitStart)
 		if err != nil {
 			c.logger.Error("failed to acquire connection from pool", Error("error", err))
 
@@ -514,7 +710,12 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 
 			return nil, err
 		}
+		var wrote bool
 		defer func() {
+			if session != nil {
+				session.release(conn, wrote)
+				return
+			}
 			c.pool.Put(conn)
 			// Debug logging: returning connection to pool
 			if debugMode {
@@ -523,8 +724,9 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 					String("remote_addr", conn.RemoteAddr()))
 			}
 		}()
+		hookCtx.setConnection(conn)
 
-		if err := conn.SendCommand(ctx, command); err != nil {
+		if err := c.sendOnConn(ctx, conn, command); err != nil {
 			c.logger.Error("failed to send command", Error("error", err))
 
 			// Execute after hooks with error
@@ -535,13 +737,19 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 			return nil, err
 		}
 
-		result, err := conn.ReceiveResponse(ctx)
+		result, err := receiveResponse(ctx, conn)
+		result, err = checkServerStatus(result, err, command)
+		if err == nil {
+			trackUseCommand(conn, command)
+			wrote = IsWriteCommand(command)
+		}
 		duration := time.Since(start)
 
 		// Update hook context with result
 		hookCtx.Result = result
 		hookCtx.Error = err
 		hookCtx.Duration = duration
+		hookCtx.ResponseBytes = responseSize(conn)
 
 		// Debug logging: log raw response
 		if debugMode {
@@ -558,6 +766,11 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 			err = hookErr
 		}
 
+		// Invalidate caches affected by DDL/DML
+		if err == nil {
+			c.invalidateCaches(command, traceID)
+		}
+
 		if err != nil {
 			c.logger.Error("failed to receive response",
 				Error("error", err),
@@ -565,10 +778,11 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 			return nil, err
 		}
 
-		c.logger.Debug("command executed",
+		c.logger.Debug("command executed", append([]Field{
 			String("command", command),
 			String("trace_id", traceID),
-			Duration("duration", duration))
+			Duration("duration", duration)},
+			queryMetadataFields(ctx)...)...)
 		return result, nil
 	}
 
@@ -588,7 +802,8 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 		return nil, err
 	}
 
-	err := c.conn.SendCommand(ctx, command)
+	hookCtx.setConnection(c.conn)
+	err := c.sendOnConn(ctx, c.conn, command)
 	if err != nil {
 		c.logger.Error("failed to send command", Error("error", err))
 
@@ -600,13 +815,18 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 		return nil, err
 	}
 
-	result, err := c.conn.ReceiveResponse(ctx)
+	result, err := receiveResponse(ctx, c.conn)
+	result, err = checkServerStatus(result, err, command)
+	if err == nil {
+		trackUseCommand(c.conn, command)
+	}
 	duration := time.Since(start)
 
 	// Update hook context with result
 	hookCtx.Result = result
 	hookCtx.Error = err
 	hookCtx.Duration = duration
+	hookCtx.ResponseBytes = responseSize(c.conn)
 
 	// Debug logging: log raw response
 	if debugMode {
@@ -623,8 +843,39 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 		err = hookErr
 	}
 
-	// Detect DDL operations and invalidate schema cache
-	if err == nil && c.schemaValidator != nil && DetectDDL(command) {
+	// Invalidate caches affected by DDL/DML
+	if err == nil {
+		c.invalidateCaches(command, traceID)
+	}
+
+	if err != nil {
+		c.logger.Error("failed to receive response",
+			Error("error", err),
+			Duration("duration", duration))
+		return nil, err
+	}
+
+	c.logger.Debug("command executed", append([]Field{
+		String("command", command),
+		String("trace_id", traceID),
+		Duration("duration", duration)},
+		queryMetadataFields(ctx)...)...)
+	return result, nil
+}
+
+// invalidateCaches drops schema and query cache entries made stale by a successful command.
+func (c *Client) invalidateCaches(command, traceID string) {
+	if DetectDDL(command) {
+		c.schemaChanged(command, traceID)
+	}
+	c.invalidateQueryCache(command)
+}
+
+// schemaChanged invalidates the schema cache after a DDL command, made by this
+// client or reported by a schema notification, and runs the OnSchemaChange
+// handlers.
+func (c *Client) schemaChanged(command, traceID string) {
+	if c.schemaValidator != nil {
 		c.logger.Debug("DDL operation detected, invalidating schema cache",
 			String("command", command),
 			String("trace_id", traceID))
@@ -647,18 +898,11 @@ func (c *Client) sendCommand(ctx context.Context, command string) (interface{},
 		}
 	}
 
-	if err != nil {
-		c.logger.Error("failed to receive response",
-			Error("error", err),
-			Duration("duration", duration))
-		return nil, err
+	c.schemaHandlersMu.RLock()
+	for _, handler := range c.schemaHandlers {
+		go handler(command)
 	}
-
-	c.logger.Debug("command executed",
-		String("command", command),
-		String("trace_id", traceID),
-		Duration("duration", duration))
-	return result, nil
+	c.schemaHandlersMu.RUnlock()
 }
 
 // Query executes a query command.
@@ -667,30 +911,28 @@ func (c *Client) Query(query string, timeoutMs int) (interface{}, error) {
 		return nil, ErrInvalidState("Query", CONNECTED, c.stateMgr.GetState())
 	}
 
-	ctx := context.Background()
-	if timeoutMs > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
-		defer cancel()
-	}
-
-	return c.sendCommand(ctx, query)
+	return c.executeWithTimeout(context.Background(), query, timeoutMs)
 }
 
 // Mutate executes a mutation command.
-func (c *Client) Mutate(mutation string, timeoutMs int) (interface{}, error) {
-	if c.stateMgr.GetState() != CONNECTED {
+func (c *Client) Mutate(mutation string, timeoutMs int) (*MutationResult, error) {
+	if c.stateMgr.GetState() != CONNECTED && c.offlineQueue() == nil {
 		return nil, ErrInvalidState("Mutate", CONNECTED, c.stateMgr.GetState())
 	}
 
-	ctx := context.Background()
+	return c.mutate(context.Background(), mutation, timeoutMs)
+}
+
+// executeWithTimeout sends command with ctx, bounded by timeoutMs when positive.
+// Values carried by ctx (e.g. trace spans) are visible to hooks.
+func (c *Client) executeWithTimeout(ctx context.Context, command string, timeoutMs int) (interface{}, error) {
 	if timeoutMs > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
 		defer cancel()
 	}
 
-	return c.sendCommand(ctx, mutation)
+	return c.sendCommand(ctx, command)
 }
 
 // Ping performs a health check on the connection.
@@ -731,8 +973,8 @@ func (c *Client) SetLogLevel(level string) {
 	c.opts.LogLevel = level
 
 	// If using default logger, update its level via recreating
-	if _, ok := c.logger.(*defaultLogger); ok {
-		c.logger = NewLogger(parsedLevel.String(), nil)
+	if _, ok := unwrapLogger(c.logger).(*defaultLogger); ok {
+		c.logger = c.redaction.wrapLogger(NewLogger(parsedLevel.String(), nil))
 		c.logger.Info("log level changed", String("newLevel", level))
 	}
 }
@@ -750,14 +992,37 @@ func (c *Client) Prepare(ctx context.Context, name, query string) (*Statement, e
 		return nil, err
 	}
 
+	if err := c.requireFeature(FeaturePreparedStatements); err != nil {
+		return nil, err
+	}
+
+	// Named placeholders are sent to the server as $n
+	prepared, paramNames, err := rewriteNamedParams(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.opts.AccessPolicy.Check(prepared); err != nil {
+		return nil, err
+	}
+
 	// Count expected parameters
-	paramCount := countPlaceholders(query)
+	paramCount := countPlaceholders(prepared)
 
-	command := fmt.Sprintf("PREPARE %s AS %s", name, query)
+	command := fmt.Sprintf("PREPARE %s AS %s", name, prepared)
+
+	// Deallocate a cached statement of the same name first: after PREPARE,
+	// DEALLOCATE on a shared connection would drop the new statement instead
+	if old, ok := c.stmtCache.statements.Load(name); ok {
+		if err := old.(*Statement).Close(); err != nil {
+			c.logger.Warn("failed to deallocate replaced statement",
+				String("stmt_name", name),
+				Error("error", err))
+		}
+	}
 
 	// Get connection
 	var conn ConnectionInterface
-	var err error
 	returnConn := false
 
 	if c.poolEnabled && c.pool != nil {
@@ -771,7 +1036,7 @@ func (c *Client) Prepare(ctx context.Context, name, query string) (*Statement, e
 	}
 
 	// Send PREPARE command
-	if err := conn.SendCommand(ctx, command); err != nil {
+	if err := c.sendOnConn(ctx, conn, command); err != nil {
 		if returnConn {
 			c.pool.Put(conn)
 		}
@@ -809,9 +1074,20 @@ func (c *Client) Prepare(ctx context.Context, name, query string) (*Statement, e
 		name:       name,
 		query:      query,
 		paramCount: paramCount,
+		paramNames: paramNames,
+		paramTypes: parseParamTypes(response, paramCount),
 		conn:       conn,
 		closed:     false,
 		createdAt:  time.Now(),
+		redaction:  c.redaction,
+		codec:      newValueCodec(c.opts),
+		codecs:     &c.codecs,
+		timeout:    c.opts.StatementTimeout,
+	}
+
+	// The statement owns the pooled connection until it is closed or evicted
+	if returnConn {
+		stmt.release = func() { c.pool.Put(conn) }
 	}
 
 	// Add to cache
@@ -826,8 +1102,6 @@ func (c *Client) Prepare(ctx context.Context, name, query string) (*Statement, e
 		Int("param_count", paramCount),
 		String("query", query))
 
-	_ = response // TODO: Parse server response for validation
-
 	// Don't return connection yet - statement needs it for Execute
 	return stmt, nil
 }
@@ -839,8 +1113,8 @@ func (c *Client) QueryWithParams(ctx context.Context, query string, params ...in
 		return nil, ErrInvalidState("QueryWithParams", CONNECTED, c.stateMgr.GetState())
 	}
 
-	// Generate unique statement name
-	stmtName := fmt.Sprintf("stmt_%s", uuid.New().String())
+	// Generate unique statement name; names allow only alphanumerics and underscores
+	stmtName := "stmt_" + strings.ReplaceAll(uuid.New().String(), "-", "_")
 
 	// Prepare statement
 	stmt, err := c.Prepare(ctx, stmtName, query)
@@ -855,7 +1129,7 @@ func (c *Client) QueryWithParams(ctx context.Context, query string, params ...in
 		Int("param_count", len(params)))
 
 	// Execute with parameters
-	return stmt.Execute(params...)
+	return stmt.ExecuteContext(ctx, params...)
 }
 
 // ============================================================================
@@ -922,17 +1196,30 @@ func (c *Client) PreloadSchema(ctx context.Context) error {
 
 // Begin starts a new transaction, reserving a connection until commit/rollback.
 // Sends BEGIN TRANSACTION command to server and parses the returned TX_ID.
+// The transaction uses the server's default READ COMMITTED isolation.
 func (c *Client) Begin(ctx context.Context) (*Transaction, error) {
 	if c.stateMgr.GetState() != CONNECTED {
 		return nil, ErrInvalidState("Begin", CONNECTED, c.stateMgr.GetState())
 	}
+	return c.begin(ctx, ReadCommitted, false)
+}
+
+// begin reserves a connection and starts a transaction at level. When explicit
+// is true the isolation clause is sent if the connection's server supports it.
+func (c *Client) begin(ctx context.Context, level IsolationLevel, explicit bool) (*Transaction, error) {
+	if err := c.requireFeature(FeatureTransactions); err != nil {
+		return nil, err
+	}
 
 	// Get connection from pool or use single connection
 	var conn ConnectionInterface
+	var poolWait time.Duration
 	var err error
 
 	if c.poolEnabled && c.pool != nil {
+		waitStart := time.Now()
 		conn, err = c.pool.Get(ctx)
+		poolWait = time.Since(waitStart)
 		if err != nil {
 			return nil, err
 		}
@@ -940,56 +1227,71 @@ func (c *Client) Begin(ctx context.Context) (*Transaction, error) {
 		conn = c.conn
 	}
 
-	// Send BEGIN TRANSACTION command
-	if err := conn.SendCommand(ctx, "BEGIN TRANSACTION;"); err != nil {
-		if c.poolEnabled && c.pool != nil {
-			c.pool.Put(conn)
-		}
-		return nil, &TransactionError{
-			Code:    "E_BEGIN_FAILED",
-			Type:    "TransactionError",
-			Message: "failed to begin transaction",
-			Cause:   err,
+	// Plain BEGIN runs at READ COMMITTED, so only other levels need server support
+	command := "BEGIN TRANSACTION;"
+	if explicit {
+		switch {
+		case connSupportsIsolation(conn, level):
+			command = fmt.Sprintf("BEGIN TRANSACTION ISOLATION LEVEL %s;", level)
+		case level != ReadCommitted:
+			if c.poolEnabled && c.pool != nil {
+				c.pool.Put(conn)
+			}
+			return nil, ErrIsolationUnsupported(level)
 		}
 	}
 
-	// Receive response with TX_ID
-	response, err := conn.ReceiveResponse(ctx)
-	if err != nil {
+	if err := c.ensureDatabase(ctx, conn); err != nil {
 		if c.poolEnabled && c.pool != nil {
 			c.pool.Put(conn)
 		}
-		return nil, &TransactionError{
-			Code:    "E_BEGIN_RESPONSE_FAILED",
-			Type:    "TransactionError",
-			Message: "failed to receive begin response",
-			Cause:   err,
-		}
+		return nil, err
 	}
 
-	// Parse TX_ID from response
-	// Expected format: "Transaction started with ID: TX_<timestamp>_<random>"
+	// Send BEGIN TRANSACTION command and parse TX_ID from the response
 	var txID string
-	if respStr, ok := response.(string); ok {
-		// Extract TX_ID using simple parsing
-		if strings.Contains(respStr, "Transaction started with ID:") {
-			parts := strings.Split(respStr, "ID:")
-			if len(parts) == 2 {
-				txID = strings.TrimSpace(parts[1])
+	hookCtx := newHookContext(ctx, command)
+	hookCtx.PoolWaitDuration = poolWait
+	hookCtx.setConnection(conn)
+	_, err = c.runWithHooks(ctx, hookCtx, func(command string) (interface{}, error) {
+		if err := conn.SendCommand(ctx, command); err != nil {
+			return nil, &TransactionError{
+				Code:    "E_BEGIN_FAILED",
+				Type:    "TransactionError",
+				Message: "failed to begin transaction",
+				Cause:   err,
+			}
+		}
+
+		// Receive response with TX_ID
+		response, err := conn.ReceiveResponse(ctx)
+		if err != nil {
+			return nil, &TransactionError{
+				Code:    "E_BEGIN_RESPONSE_FAILED",
+				Type:    "TransactionError",
+				Message: "failed to receive begin response",
+				Cause:   err,
 			}
 		}
-	}
 
-	if txID == "" {
+		txID = parseTransactionID(response)
+		if txID == "" {
+			return response, &TransactionError{
+				Code:    "E_BEGIN_PARSE_FAILED",
+				Type:    "TransactionError",
+				Message: fmt.Sprintf("failed to parse transaction ID from response: %v", response),
+				Details: map[string]interface{}{"response": response},
+			}
+		}
+
+		hookCtx.TransactionID = txID
+		return response, nil
+	})
+	if err != nil {
 		if c.poolEnabled && c.pool != nil {
 			c.pool.Put(conn)
 		}
-		return nil, &TransactionError{
-			Code:    "E_BEGIN_PARSE_FAILED",
-			Type:    "TransactionError",
-			Message: fmt.Sprintf("failed to parse transaction ID from response: %v", response),
-			Details: map[string]interface{}{"response": response},
-		}
+		return nil, err
 	}
 
 	tx := &Transaction{
@@ -997,8 +1299,13 @@ func (c *Client) Begin(ctx context.Context) (*Transaction, error) {
 		connID:    conn.RemoteAddr(), // Track connection for affinity
 		conn:      conn,
 		client:    c,
-		isolation: ReadCommitted, // Default isolation level
+		isolation: level,
 		startedAt: time.Now(),
+		traceID:   hookCtx.TraceID,
+	}
+	if c.IsDebugMode() {
+		// Reported if the transaction is abandoned
+		tx.beginStack = string(debug.Stack())
 	}
 
 	// Register active transaction
@@ -1007,22 +1314,42 @@ func (c *Client) Begin(ctx context.Context) (*Transaction, error) {
 		conn:      conn,
 		startedAt: time.Now(),
 	})
+	tx.watch(ctx)
 
 	c.logger.Info("transaction started",
-		String("tx_id", txID))
+		String("tx_id", txID),
+		String("isolation", level.String()))
 
 	return tx, nil
 }
 
+// parseTransactionID extracts the TX_ID from a BEGIN response.
+// Expected format: "Transaction started with ID: TX_<timestamp>_<random>"
+func parseTransactionID(response interface{}) string {
+	respStr, ok := response.(string)
+	if !ok || !strings.Contains(respStr, "Transaction started with ID:") {
+		return ""
+	}
+	parts := strings.Split(respStr, "ID:")
+	if len(parts) != 2 {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
 // BeginWithIsolation starts a transaction with a specific isolation level.
-// Note: Server currently only supports READ COMMITTED isolation (not configurable).
-// The isolation parameter is accepted but ignored; all transactions use READ COMMITTED.
+// The isolation clause is sent only to servers that advertised the level during
+// authentication. READ COMMITTED, the server default, is always available;
+// other unsupported levels fail with E_ISOLATION_UNSUPPORTED rather than
+// silently running at a weaker level.
 func (c *Client) BeginWithIsolation(ctx context.Context, level IsolationLevel) (*Transaction, error) {
-	c.logger.Warn("transaction isolation levels not yet configurable, using READ COMMITTED",
-		String("requested_level", level.String()))
-
-	// Begin transaction with default isolation (server will use READ COMMITTED)
-	return c.Begin(ctx)
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("BeginWithIsolation", CONNECTED, c.stateMgr.GetState())
+	}
+	if level.String() == "UNKNOWN" {
+		return nil, ErrIsolationUnsupported(level)
+	}
+	return c.begin(ctx, level, true)
 }
 
 // transactionTimeoutMonitor runs in the background checking for abandoned transactions.
@@ -1054,10 +1381,16 @@ func (c *Client) checkAbandonedTransactions() {
 
 		age := time.Since(txCtx.startedAt)
 		if age > timeout {
-			c.logger.Error("transaction exceeded timeout, forcing rollback",
+			fields := []Field{
 				String("tx_id", txID),
+				String("trace_id", txCtx.tx.traceID),
 				Duration("age", age),
-				Duration("timeout", timeout))
+				Duration("timeout", timeout),
+			}
+			if txCtx.tx.beginStack != "" {
+				fields = append(fields, String("begin_stack", txCtx.tx.beginStack))
+			}
+			c.logger.Warn("transaction leaked: not committed or rolled back before timeout, forcing rollback", fields...)
 
 			// Force rollback
 			if err := txCtx.tx.Rollback(); err != nil {
@@ -1067,7 +1400,7 @@ func (c *Client) checkAbandonedTransactions() {
 			}
 
 			// Remove from active transactions (Rollback already does this, but double-check)
-			c.activeTransactions.Delete(txID)
+			c.releaseTransaction(txID)
 		}
 
 		return true // Continue iteration
@@ -0,0 +1,125 @@
+package clienttest
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// conn is the client side of an in-memory connection. Writes are parsed into
+// EOT-terminated commands and answered immediately, so writes never block and
+// pipelined commands work as they do over TCP.
+type conn struct {
+	server   *Server
+	address  string
+	pending  bytes.Buffer // partial command awaiting its EOT terminator
+	output   bytes.Buffer // responses not yet read by the client
+	closed   bool
+	deadline time.Time
+	cond     *sync.Cond
+	mu       sync.Mutex
+}
+
+func newConn(server *Server, address string) *conn {
+	c := &conn{server: server, address: address}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Write accepts command bytes and queues a response for each complete command.
+func (c *conn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return 0, net.ErrClosed
+	}
+	c.pending.Write(p)
+
+	var commands []string
+	for {
+		data := c.pending.Bytes()
+		idx := bytes.IndexByte(data, '\x04')
+		if idx < 0 {
+			break
+		}
+		commands = append(commands, string(data[:idx]))
+		c.pending.Next(idx + 1)
+	}
+	c.mu.Unlock()
+
+	// Answer outside the lock so RespondWith callbacks may use the server freely
+	for _, command := range commands {
+		response := c.server.handle(command)
+		c.mu.Lock()
+		c.output.WriteString(response + "\n")
+		c.mu.Unlock()
+		c.cond.Broadcast()
+	}
+
+	return len(p), nil
+}
+
+// Read returns queued responses, blocking until one is available,
+// the connection is closed or the read deadline passes.
+func (c *conn) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.output.Len() == 0 {
+		if c.closed {
+			return 0, io.EOF
+		}
+		if !c.deadline.IsZero() && !time.Now().Before(c.deadline) {
+			return 0, os.ErrDeadlineExceeded
+		}
+
+		if !c.deadline.IsZero() {
+			// Wake up when the deadline passes
+			timer := time.AfterFunc(time.Until(c.deadline), c.cond.Broadcast)
+			c.cond.Wait()
+			timer.Stop()
+		} else {
+			c.cond.Wait()
+		}
+	}
+
+	return c.output.Read(p)
+}
+
+// Close closes the connection, unblocking pending reads.
+func (c *conn) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.cond.Broadcast()
+	return nil
+}
+
+func (c *conn) LocalAddr() net.Addr  { return addr("clienttest") }
+func (c *conn) RemoteAddr() net.Addr { return addr(c.address) }
+
+func (c *conn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.deadline = t
+	c.mu.Unlock()
+	c.cond.Broadcast()
+	return nil
+}
+
+// SetWriteDeadline is a no-op; writes never block.
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// addr is the net.Addr of an in-memory connection.
+type addr string
+
+func (a addr) Network() string { return "clienttest" }
+func (a addr) String() string  { return string(a) }
@@ -0,0 +1,286 @@
+// Package clienttest provides an in-memory SyndrDB server for unit tests.
+//
+// A Server answers commands from a scriptable table of rules matched against
+// SyndrQL patterns. Clients connect to it through ClientOptions.Dialer, so the
+// real client, connection and protocol code runs without a live server:
+//
+//	server := clienttest.NewServer()
+//	server.On(`SELECT * FROM BUNDLE "users"*`).Return([]map[string]interface{}{
+//		{"name": "Alice"},
+//	})
+//	server.On(`DELETE DOCUMENTS FROM BUNDLE "users"*`).ReturnError("permission denied")
+//
+//	c := clienttest.NewClient(t, server, nil)
+//	rows, err := c.Query(`SELECT * FROM BUNDLE "users";`, 1000)
+//
+// Server also implements migration.MigrationExecutor for testing migrations
+// without a client.
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// ConnectionString is the connection string NewClient uses. The server accepts any
+// connection string, so tests may also connect with their own.
+const ConnectionString = "syndrdb://clienttest:1776:test:test:test;"
+
+// Rule maps commands matching a pattern to a scripted response.
+type Rule struct {
+	pattern *regexp.Regexp
+	source  string
+	respond func(command string) string
+	limit   int // 0 = unlimited
+	calls   int
+}
+
+// Return responds with a successful result. The result is JSON-encoded, so the
+// client sees it as it would a real server response (maps, []interface{}, float64).
+func (r *Rule) Return(result interface{}) *Rule {
+	line, err := json.Marshal(map[string]interface{}{
+		"success": true,
+		"data":    result,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("clienttest: cannot encode result for %q: %v", r.source, err))
+	}
+	return r.ReturnRaw(string(line))
+}
+
+// ReturnError responds with a server error, surfaced by the client as a
+// ProtocolError with code SERVER_ERROR.
+func (r *Rule) ReturnError(message string) *Rule {
+	line, _ := json.Marshal(map[string]interface{}{
+		"success": false,
+		"error":   message,
+	})
+	return r.ReturnRaw(string(line))
+}
+
+// ReturnRaw responds with line exactly as written, e.g. a non-JSON status message.
+func (r *Rule) ReturnRaw(line string) *Rule {
+	return r.RespondWith(func(string) string { return line })
+}
+
+// RespondWith computes the raw response line from the command.
+func (r *Rule) RespondWith(fn func(command string) string) *Rule {
+	r.respond = fn
+	return r
+}
+
+// Times limits the rule to n matches, after which later rules are consulted.
+func (r *Rule) Times(n int) *Rule {
+	r.limit = n
+	return r
+}
+
+// Once limits the rule to a single match.
+func (r *Rule) Once() *Rule {
+	return r.Times(1)
+}
+
+// Server is an in-memory SyndrDB server answering commands from a rule table.
+// Rules are consulted in the order they were added; the first match wins.
+// Commands without a matching rule receive a server error naming the command.
+type Server struct {
+	rules []*Rule
+	calls []string
+	conns []*conn
+	mu    sync.Mutex
+}
+
+// NewServer creates a server with no rules.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// On adds a rule for commands matching a SyndrQL pattern. Matching ignores case,
+// treats any run of whitespace as equivalent and ignores a trailing semicolon.
+// '*' matches any sequence of characters.
+// Without a response the rule returns a successful empty result.
+func (s *Server) On(pattern string) *Rule {
+	return s.addRule(compilePattern(pattern), pattern)
+}
+
+// OnRegexp adds a rule for commands matching a regular expression.
+func (s *Server) OnRegexp(expr string) *Rule {
+	return s.addRule(regexp.MustCompile(expr), expr)
+}
+
+func (s *Server) addRule(pattern *regexp.Regexp, source string) *Rule {
+	rule := &Rule{pattern: pattern, source: source}
+	rule.Return(nil)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = append(s.rules, rule)
+	return rule
+}
+
+// Calls returns the commands received so far, excluding connection handshakes.
+func (s *Server) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+// CallCount returns how many received commands match a SyndrQL pattern (see On).
+func (s *Server) CallCount(pattern string) int {
+	re := compilePattern(pattern)
+	count := 0
+	for _, call := range s.Calls() {
+		if re.MatchString(call) {
+			count++
+		}
+	}
+	return count
+}
+
+// Reset removes all rules and recorded calls.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = nil
+	s.calls = nil
+}
+
+// Close closes all connections to the server.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	conns := s.conns
+	s.conns = nil
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.Close()
+	}
+	return nil
+}
+
+// Dial opens an in-memory connection to the server. Its signature matches
+// ClientOptions.Dialer.
+func (s *Server) Dial(ctx context.Context, network, address string) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := newConn(s, address)
+	s.mu.Lock()
+	s.conns = append(s.conns, c)
+	s.mu.Unlock()
+	return c, nil
+}
+
+// Execute answers command directly, without a client or connection.
+// It implements migration.MigrationExecutor.
+func (s *Server) Execute(command string) (interface{}, error) {
+	line := s.handle(command)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &response); err != nil {
+		return line, nil
+	}
+	if success, ok := response["success"].(bool); ok && !success {
+		return nil, fmt.Errorf("%v", response["error"])
+	}
+	if data, ok := response["data"]; ok {
+		return data, nil
+	}
+	return response, nil
+}
+
+// handle records command and returns the raw response line.
+func (s *Server) handle(command string) string {
+	command = strings.TrimSpace(command)
+
+	// Connection string handshake: welcome message followed by auth result
+	if strings.HasPrefix(command, "syndrdb://") {
+		return "S0001 Welcome to SyndrDB\n" + `{"status": "success", "message": "authenticated"}`
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, command)
+	var rule *Rule
+	for _, candidate := range s.rules {
+		if candidate.limit > 0 && candidate.calls >= candidate.limit {
+			continue
+		}
+		if candidate.pattern.MatchString(command) {
+			candidate.calls++
+			rule = candidate
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if rule != nil {
+		return rule.respond(command)
+	}
+
+	// Health checks succeed unless scripted otherwise
+	if strings.EqualFold(command, "STATUS") {
+		return `{"success": true, "data": "OK"}`
+	}
+
+	line, _ := json.Marshal(map[string]interface{}{
+		"success": false,
+		"error":   fmt.Sprintf("clienttest: no response registered for command: %s", command),
+	})
+	return string(line)
+}
+
+// compilePattern converts a SyndrQL pattern to an anchored, case-insensitive regexp.
+func compilePattern(pattern string) *regexp.Regexp {
+	pattern = strings.TrimSuffix(strings.TrimSpace(pattern), ";")
+
+	var expr strings.Builder
+	expr.WriteString(`(?is)^\s*`)
+	for i, part := range strings.Split(pattern, "*") {
+		if i > 0 {
+			expr.WriteString(`.*`)
+		}
+		for j, word := range strings.Fields(part) {
+			if j > 0 {
+				expr.WriteString(`\s+`)
+			}
+			expr.WriteString(regexp.QuoteMeta(word))
+		}
+		// Whitespace at the edges of a segment is optional next to a wildcard
+		if strings.TrimSpace(part) != part {
+			expr.WriteString(`\s*`)
+		}
+	}
+	expr.WriteString(`\s*;?\s*$`)
+	return regexp.MustCompile(expr.String())
+}
+
+// NewClient returns a client connected to server, disconnected when the test ends.
+// opts may be nil; its Dialer is replaced with server.Dial.
+func NewClient(t testing.TB, server *Server, opts *client.ClientOptions) *client.Client {
+	t.Helper()
+
+	if opts == nil {
+		defaultOpts := client.DefaultOptions()
+		defaultOpts.Logger = client.NewNoopLogger()
+		opts = &defaultOpts
+	}
+	opts.Dialer = server.Dial
+
+	c := client.NewClient(opts)
+	if err := c.Connect(context.Background(), ConnectionString); err != nil {
+		t.Fatalf("clienttest: connect failed: %v", err)
+	}
+	t.Cleanup(func() {
+		c.Disconnect(context.Background())
+	})
+	return c
+}
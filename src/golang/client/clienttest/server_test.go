@@ -0,0 +1,150 @@
+package clienttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/migration"
+)
+
+// TestClientQuery verifies scripted results reach the client through the real protocol code.
+func TestClientQuery(t *testing.T) {
+	server := NewServer()
+	server.On(`SELECT * FROM BUNDLE "users"*`).Return([]map[string]interface{}{
+		{"name": "Alice"},
+		{"name": "Bob"},
+	})
+
+	c := NewClient(t, server, nil)
+	result, err := c.Query(`select *  from bundle "users" where "age" > 21;`, 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	rows, ok := result.([]interface{})
+	if !ok || len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %v", result)
+	}
+	if name := rows[0].(map[string]interface{})["name"]; name != "Alice" {
+		t.Errorf("expected Alice, got %v", name)
+	}
+	if got := server.CallCount(`SELECT * FROM BUNDLE "users"*`); got != 1 {
+		t.Errorf("expected 1 matching call, got %d", got)
+	}
+}
+
+// TestClientErrors verifies scripted and unmatched commands fail with SERVER_ERROR.
+func TestClientErrors(t *testing.T) {
+	server := NewServer()
+	server.On(`DELETE DOCUMENTS FROM BUNDLE "users"*`).ReturnError("permission denied")
+
+	c := NewClient(t, server, nil)
+
+	_, err := c.Mutate(`DELETE DOCUMENTS FROM BUNDLE "users" WHERE "id" == 1;`, 1000)
+	if client.ErrorCode(err) != "SERVER_ERROR" {
+		t.Errorf("expected SERVER_ERROR, got %v", err)
+	}
+
+	_, err = c.Query(`SHOW BUNDLES;`, 1000)
+	if client.ErrorCode(err) != "SERVER_ERROR" {
+		t.Errorf("expected SERVER_ERROR for unmatched command, got %v", err)
+	}
+}
+
+// TestRuleTimes verifies limited rules fall through to later rules.
+func TestRuleTimes(t *testing.T) {
+	server := NewServer()
+	server.On("SHOW BUNDLES").Once().Return("first")
+	server.On("SHOW BUNDLES").Return("later")
+
+	c := NewClient(t, server, nil)
+	for _, expected := range []string{"first", "later", "later"} {
+		result, err := c.Query("SHOW BUNDLES;", 1000)
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if result != expected {
+			t.Errorf("expected %q, got %v", expected, result)
+		}
+	}
+}
+
+// TestPipelineAndPool verifies pipelined commands and pooled connections.
+func TestPipelineAndPool(t *testing.T) {
+	server := NewServer()
+	server.OnRegexp(`^ADD DOCUMENT`).RespondWith(func(command string) string {
+		return `{"success": true, "data": "added"}`
+	})
+
+	opts := client.DefaultOptions()
+	opts.Logger = client.NewNoopLogger()
+	opts.PoolMinSize = 2
+	opts.PoolMaxSize = 4
+	c := NewClient(t, server, &opts)
+
+	results, err := c.Pipeline().
+		Add(`ADD DOCUMENT TO BUNDLE "events" WITH ({"n" = 1});`).
+		Add(`ADD DOCUMENT TO BUNDLE "events" WITH ({"n" = 2});`).
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	for i, result := range results {
+		if result.Result != "added" {
+			t.Errorf("result %d: expected 'added', got %v", i, result.Result)
+		}
+	}
+	if got := len(server.Calls()); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+// TestServerAsMigrationExecutor verifies Server runs migrations without a client.
+func TestServerAsMigrationExecutor(t *testing.T) {
+	server := NewServer()
+	server.On(`CREATE BUNDLE "users"*`)
+
+	migrations := migration.NewClient(server)
+	plan, err := migrations.Plan([]*migration.Migration{{
+		ID:   "001_users",
+		Name: "create users",
+		Up:   []string{`CREATE BUNDLE "users" WITH FIELDS ({"name", "STRING", TRUE, FALSE, ""});`},
+		Down: []string{`DROP BUNDLE "users";`},
+	}})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if err := migrations.Apply(plan); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if got := server.CallCount(`CREATE BUNDLE "users"*`); got != 1 {
+		t.Errorf("expected CREATE BUNDLE to run once, got %d", got)
+	}
+
+	server.Reset()
+	if _, err := server.Execute(`DROP BUNDLE "users";`); err == nil {
+		t.Error("expected unmatched command to fail after Reset")
+	}
+}
+
+// TestCompilePattern verifies SyndrQL pattern matching rules.
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		command string
+		match   bool
+	}{
+		{`SHOW BUNDLES`, `show   bundles;`, true},
+		{`SHOW BUNDLES`, `SHOW BUNDLES FOR "db";`, false},
+		{`SELECT * FROM "users"*`, `SELECT name FROM "users" WHERE x == 1;`, true},
+		{`SELECT * FROM "users"`, `SELECT * FROM "orders";`, false},
+		{`USE "db";`, "USE\n\"db\"", true},
+	}
+
+	for _, tt := range tests {
+		if got := compilePattern(tt.pattern).MatchString(tt.command); got != tt.match {
+			t.Errorf("pattern %q vs %q: expected %v, got %v", tt.pattern, tt.command, tt.match, got)
+		}
+	}
+}
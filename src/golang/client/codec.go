@@ -0,0 +1,296 @@
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec converts between a Go value and the form stored in a document, e.g.
+// a protobuf message and its serialized bytes, or a date and a custom string
+// format. Register codecs with Client.RegisterFieldCodec and
+// Client.RegisterTypeCodec.
+type Codec interface {
+	// Marshal returns the stored form of value.
+	Marshal(value interface{}) (interface{}, error)
+
+	// Unmarshal restores a value returned by Marshal, as decoded from the
+	// server's JSON response.
+	Unmarshal(stored interface{}) (interface{}, error)
+}
+
+// CodecFuncs adapts a pair of functions to a Codec.
+type CodecFuncs struct {
+	MarshalFunc   func(value interface{}) (interface{}, error)
+	UnmarshalFunc func(stored interface{}) (interface{}, error)
+}
+
+// Marshal implements Codec.
+func (f CodecFuncs) Marshal(value interface{}) (interface{}, error) {
+	return f.MarshalFunc(value)
+}
+
+// Unmarshal implements Codec.
+func (f CodecFuncs) Unmarshal(stored interface{}) (interface{}, error) {
+	return f.UnmarshalFunc(stored)
+}
+
+// codecRegistry holds the codecs registered with a client.
+type codecRegistry struct {
+	mu     sync.RWMutex
+	fields map[string]map[string]Codec // bundle -> field -> codec
+	types  map[reflect.Type]Codec
+}
+
+// RegisterFieldCodec encodes field of bundle with codec. Values are marshaled
+// by InsertBuilder and UpdateBuilder, condition values on the field in builder
+// WHERE clauses, and the field is unmarshaled in QueryBuilder results.
+// Marshaling happens before encryption and unmarshaling after decryption, so
+// a field may have both. Raw commands sent with Query or Mutate are not
+// modified. Pass nil to remove the codec.
+func (c *Client) RegisterFieldCodec(bundle, field string, codec Codec) {
+	c.codecs.mu.Lock()
+	defer c.codecs.mu.Unlock()
+
+	if codec == nil {
+		delete(c.codecs.fields[bundle], field)
+		return
+	}
+	if c.codecs.fields == nil {
+		c.codecs.fields = make(map[string]map[string]Codec)
+	}
+	if c.codecs.fields[bundle] == nil {
+		c.codecs.fields[bundle] = make(map[string]Codec)
+	}
+	c.codecs.fields[bundle][field] = codec
+}
+
+// RegisterTypeCodec encodes every value of sample's type with codec: in
+// builder values and conditions on fields without a field codec, in prepared
+// statement parameters, and when GenericRepository decodes struct fields of
+// the type. A nil pointer of the type is left as null. Plain QueryBuilder
+// results carry no Go types and are not unmarshaled; use a field codec for
+// those. The value Unmarshal returns must encode to JSON and back to the
+// struct field's type. Pass a nil codec to remove it.
+//
+//	c.RegisterTypeCodec(Money{}, moneyCodec)
+func (c *Client) RegisterTypeCodec(sample interface{}, codec Codec) {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		return
+	}
+
+	c.codecs.mu.Lock()
+	defer c.codecs.mu.Unlock()
+
+	if codec == nil {
+		delete(c.codecs.types, t)
+		return
+	}
+	if c.codecs.types == nil {
+		c.codecs.types = make(map[reflect.Type]Codec)
+	}
+	c.codecs.types[t] = codec
+}
+
+// bundleCodecs returns a snapshot of the field codecs registered for bundle.
+func (r *codecRegistry) bundleCodecs(bundle string) map[string]Codec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.fields[bundle]) == 0 {
+		return nil
+	}
+	snapshot := make(map[string]Codec, len(r.fields[bundle]))
+	for field, codec := range r.fields[bundle] {
+		snapshot[field] = codec
+	}
+	return snapshot
+}
+
+// typeCodec returns the codec registered for t, if any.
+func (r *codecRegistry) typeCodec(t reflect.Type) (Codec, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	codec, ok := r.types[t]
+	return codec, ok
+}
+
+// hasTypeCodecs reports whether any type codec is registered.
+func (r *codecRegistry) hasTypeCodecs() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.types) > 0
+}
+
+// marshalTyped marshals value with the codec of its type, or of the type it
+// points to. Values without a type codec are returned unchanged.
+func (r *codecRegistry) marshalTyped(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	t := reflect.TypeOf(value)
+	if codec, ok := r.typeCodec(t); ok {
+		return codec.Marshal(value)
+	}
+	if t.Kind() == reflect.Pointer {
+		if codec, ok := r.typeCodec(t.Elem()); ok {
+			v := reflect.ValueOf(value)
+			if v.IsNil() {
+				return nil, nil
+			}
+			return codec.Marshal(v.Elem().Interface())
+		}
+	}
+	return value, nil
+}
+
+// marshal returns the stored form of a value of field, using its field codec
+// if it has one and otherwise the codec of the value's type.
+func (r *codecRegistry) marshal(fieldCodecs map[string]Codec, field string, value interface{}) (interface{}, error) {
+	if codec, ok := fieldCodecs[field]; ok {
+		if value == nil {
+			return nil, nil
+		}
+		return codec.Marshal(value)
+	}
+	return r.marshalTyped(value)
+}
+
+// marshalValues returns values with each field marshaled by its codec.
+func (r *codecRegistry) marshalValues(bundle string, values map[string]interface{}) (map[string]interface{}, error) {
+	fieldCodecs := r.bundleCodecs(bundle)
+	if fieldCodecs == nil && !r.hasTypeCodecs() {
+		return values, nil
+	}
+
+	marshaled := make(map[string]interface{}, len(values))
+	for field, value := range values {
+		stored, err := r.marshal(fieldCodecs, field, value)
+		if err != nil {
+			return nil, errCodec("E_CODEC_MARSHAL_FAILED", bundle, field, err)
+		}
+		marshaled[field] = stored
+	}
+	return marshaled, nil
+}
+
+// marshalWhere returns clauses with condition values marshaled by the codec
+// of their field or type.
+func (r *codecRegistry) marshalWhere(bundle string, clauses []whereClause) ([]whereClause, error) {
+	fieldCodecs := r.bundleCodecs(bundle)
+	if fieldCodecs == nil && !r.hasTypeCodecs() {
+		return clauses, nil
+	}
+
+	marshaled := make([]whereClause, len(clauses))
+	copy(marshaled, clauses)
+	for i, clause := range marshaled {
+		if clause.operator == IsNull || clause.operator == IsNotNull {
+			continue
+		}
+		switch clause.value.(type) {
+		case *QueryBuilder, Column:
+			continue
+		}
+
+		value, err := r.marshalOperand(fieldCodecs, clause)
+		if err != nil {
+			return nil, errCodec("E_CODEC_MARSHAL_FAILED", bundle, clause.field, err)
+		}
+		marshaled[i].value = value
+	}
+	return marshaled, nil
+}
+
+// marshalOperand marshals a condition value, element-wise for the operands
+// of In and NotIn.
+func (r *codecRegistry) marshalOperand(fieldCodecs map[string]Codec, clause whereClause) (interface{}, error) {
+	v := reflect.ValueOf(clause.value)
+	if (clause.operator != In && clause.operator != NotIn) || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return r.marshal(fieldCodecs, clause.field, clause.value)
+	}
+
+	marshaled := make([]interface{}, v.Len())
+	for i := range marshaled {
+		stored, err := r.marshal(fieldCodecs, clause.field, v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		marshaled[i] = stored
+	}
+	return marshaled, nil
+}
+
+// marshalParams returns statement parameters marshaled by their type codecs.
+func (r *codecRegistry) marshalParams(params []interface{}) ([]interface{}, error) {
+	if r == nil || !r.hasTypeCodecs() {
+		return params, nil
+	}
+
+	marshaled := make([]interface{}, len(params))
+	for i, param := range params {
+		stored, err := r.marshalTyped(param)
+		if err != nil {
+			return nil, &QueryError{
+				Code:    "E_CODEC_MARSHAL_FAILED",
+				Type:    "QueryError",
+				Message: fmt.Sprintf("failed to marshal parameter %d", i+1),
+				Details: map[string]interface{}{
+					"index": i,
+					"type":  fmt.Sprintf("%T", param),
+				},
+				Cause: err,
+			}
+		}
+		marshaled[i] = stored
+	}
+	return marshaled, nil
+}
+
+// unmarshalResult unmarshals fields with a field codec in the documents of a
+// query result, in place. Null values are left as they are.
+func (r *codecRegistry) unmarshalResult(bundle string, result interface{}) error {
+	fieldCodecs := r.bundleCodecs(bundle)
+	if fieldCodecs == nil {
+		return nil
+	}
+
+	for _, row := range resultRows(result) {
+		doc, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for field, codec := range fieldCodecs {
+			stored, ok := doc[field]
+			if !ok || stored == nil {
+				continue
+			}
+			value, err := codec.Unmarshal(stored)
+			if err != nil {
+				return errCodec("E_CODEC_UNMARSHAL_FAILED", bundle, field, err)
+			}
+			doc[field] = value
+		}
+	}
+	return nil
+}
+
+// errCodec creates an error for a failed marshal or unmarshal of field.
+func errCodec(code, bundle, field string, cause error) *QueryError {
+	action := "marshal"
+	if code == "E_CODEC_UNMARSHAL_FAILED" {
+		action = "unmarshal"
+	}
+	return &QueryError{
+		Code:    code,
+		Type:    "QueryError",
+		Message: fmt.Sprintf("failed to %s %s.%s", action, bundle, field),
+		Details: map[string]interface{}{
+			"bundle": bundle,
+			"field":  field,
+		},
+		Cause: cause,
+	}
+}
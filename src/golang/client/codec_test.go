@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rgb is stored as a "#rrggbb" string by rgbCodec.
+type rgb struct{ R, G, B uint8 }
+
+var rgbCodec = CodecFuncs{
+	MarshalFunc: func(value interface{}) (interface{}, error) {
+		c := value.(rgb)
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B), nil
+	},
+	UnmarshalFunc: func(stored interface{}) (interface{}, error) {
+		var c rgb
+		s, _ := stored.(string)
+		if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+			return nil, err
+		}
+		return c, nil
+	},
+}
+
+// tagsCodec stores a []string as a comma-separated string.
+var tagsCodec = CodecFuncs{
+	MarshalFunc: func(value interface{}) (interface{}, error) {
+		tags, ok := value.([]string)
+		if !ok {
+			return nil, fmt.Errorf("expected []string, got %T", value)
+		}
+		return strings.Join(tags, ","), nil
+	},
+	UnmarshalFunc: func(stored interface{}) (interface{}, error) {
+		return strings.Split(stored.(string), ","), nil
+	},
+}
+
+// TestCodecBuilders verifies builder values and conditions are marshaled by
+// their field or type codec before sending.
+func TestCodecBuilders(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true,"data":{"affected_count":1}}` })
+	c.RegisterFieldCodec("items", "tags", tagsCodec)
+	c.RegisterTypeCodec(rgb{}, rgbCodec)
+	ctx := context.Background()
+
+	red := rgb{R: 255}
+	if _, err := c.InsertBuilder("items").Values(map[string]interface{}{"tags": []string{"a", "b"}, "color": red, "name": "x"}).Execute(ctx); err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+	if _, err := c.UpdateBuilder("items").Set("color", &red).Where("tags", Equals, []string{"a", "b"}).Execute(ctx); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := c.DeleteBuilder("items").Where("color", In, []rgb{red, {B: 255}}).Execute(ctx); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	commands := server.received()
+	for _, want := range []string{`"a,b"`, `"#ff0000"`, `"x"`} {
+		if !strings.Contains(commands[0], want) {
+			t.Errorf("expected %s in %s", want, commands[0])
+		}
+	}
+	if !strings.Contains(commands[1], `"#ff0000"`) || !strings.Contains(commands[1], `"a,b"`) {
+		t.Errorf("expected marshaled value and condition in %s", commands[1])
+	}
+	if !strings.Contains(commands[2], `"#ff0000"`) || !strings.Contains(commands[2], `"#0000ff"`) {
+		t.Errorf("expected In operands marshaled element-wise in %s", commands[2])
+	}
+
+	_, err := c.InsertBuilder("items").Values(map[string]interface{}{"tags": 42}).Execute(ctx)
+	var qe *QueryError
+	if !errors.As(err, &qe) || qe.Code != "E_CODEC_MARSHAL_FAILED" || qe.Details["field"] != "tags" {
+		t.Errorf("expected E_CODEC_MARSHAL_FAILED for tags, got %v", err)
+	}
+}
+
+// TestCodecQueryResult verifies field codecs unmarshal query results after
+// decryption, once even when the result is cached.
+func TestCodecQueryResult(t *testing.T) {
+	enc := NewAESGCMEncryptor(testKeys, "k1")
+	ciphertext, _ := enc.Encrypt("a,b")
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success":true,"data":{"Result":[{"tags":"` + ciphertext + `"},{"tags":null}]}}`
+	})
+	c.RegisterEncryptor("items", "tags", enc)
+	c.RegisterFieldCodec("items", "tags", tagsCodec)
+
+	for i := 0; i < 2; i++ {
+		result, err := c.QueryBuilder().Select("items").Cached(time.Minute).Execute(context.Background())
+		if err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		rows := result.(map[string]interface{})["Result"].([]interface{})
+		if got := rows[0].(map[string]interface{})["tags"]; !reflect.DeepEqual(got, []string{"a", "b"}) {
+			t.Errorf("query %d: expected unmarshaled tags, got %v", i, got)
+		}
+		if got := rows[1].(map[string]interface{})["tags"]; got != nil {
+			t.Errorf("query %d: expected null to stay null, got %v", i, got)
+		}
+	}
+	if n := len(server.received()); n != 1 {
+		t.Errorf("expected the second query to be served from cache, sent %d commands", n)
+	}
+
+	c.RegisterFieldCodec("items", "tags", nil)
+	if len(c.codecs.bundleCodecs("items")) != 0 {
+		t.Error("expected RegisterFieldCodec(nil) to remove the codec")
+	}
+}
+
+// TestCodecStatementParams verifies prepared statement parameters are
+// marshaled by their type codecs.
+func TestCodecStatementParams(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"status":"ok"}` })
+	c.RegisterTypeCodec(rgb{}, rgbCodec)
+
+	stmt, err := c.Prepare(context.Background(), "by_color", `SELECT * FROM BUNDLE "items" WHERE "color" == $1;`)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if _, err := stmt.Execute(rgb{G: 255}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got := server.received()[1]; got != "EXECUTE by_color\x05#00ff00" {
+		t.Errorf("expected marshaled parameter, got %q", got)
+	}
+}
+
+// TestCodecRepository verifies repositories decode fields whose type has a codec.
+func TestCodecRepository(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success":true,"data":{"Result":[{"name":"x","color":"#00ff00","accent":"#0000ff"},{"name":"y","color":"#ff0000","accent":null}]}}`
+	})
+	c.RegisterTypeCodec(rgb{}, rgbCodec)
+
+	type item struct {
+		Name   string `json:"name"`
+		Color  rgb    `json:"color"`
+		Accent *rgb   `json:"accent"`
+	}
+	items, err := NewGenericRepository[item](c, "items").Find(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	want := []item{
+		{Name: "x", Color: rgb{G: 255}, Accent: &rgb{B: 255}},
+		{Name: "y", Color: rgb{R: 255}},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("got %+v, want %+v", items, want)
+	}
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Compression selects the response compression requested during the handshake.
+type Compression string
+
+const (
+	// CompressionNone disables response compression.
+	CompressionNone Compression = "none"
+
+	// CompressionGzip requests gzip-compressed responses.
+	CompressionGzip Compression = "gzip"
+)
+
+// compressedFramePrefix marks a compressed response line. The rest of the line
+// is the base64-encoded compressed payload, which keeps compressed responses
+// compatible with the line-based framing used for plain JSON.
+const compressedFramePrefix = "Z1:"
+
+// negotiateCompression asks the server to compress responses if it advertised
+// support for the configured algorithm in its authentication response.
+// Servers that don't advertise it are used uncompressed.
+func (c *Client) negotiateCompression(ctx context.Context, conn *Connection, authData map[string]interface{}) error {
+	mode := c.opts.Compression
+	if mode == "" || mode == CompressionNone {
+		return nil
+	}
+
+	if !serverSupportsCompression(authData, mode) {
+		c.logger.Info("server does not support requested compression, continuing uncompressed",
+			String("compression", string(mode)))
+		return nil
+	}
+
+	if err := conn.SendCommand(ctx, fmt.Sprintf("SET COMPRESSION \"%s\";", mode)); err != nil {
+		return err
+	}
+	if _, err := conn.ReceiveResponse(ctx); err != nil {
+		return &ConnectionError{
+			Code:    "COMPRESSION_NEGOTIATION_FAILED",
+			Type:    "CONNECTION_ERROR",
+			Message: fmt.Sprintf("server rejected %s compression", mode),
+			Details: map[string]interface{}{
+				"compression": string(mode),
+			},
+			Cause: err,
+		}
+	}
+
+	conn.setCompression(mode)
+	c.logger.Debug("response compression enabled", String("compression", string(mode)))
+	return nil
+}
+
+// serverSupportsCompression reports whether the authentication response lists mode
+// in its "compression" capabilities.
+func serverSupportsCompression(authData map[string]interface{}, mode Compression) bool {
+	var supported []interface{}
+	switch v := authData["compression"].(type) {
+	case []interface{}:
+		supported = v
+	case string:
+		supported = []interface{}{v}
+	}
+
+	for _, algorithm := range supported {
+		if name, ok := algorithm.(string); ok && strings.EqualFold(name, string(mode)) {
+			return true
+		}
+	}
+	return false
+}
+
+// decompressFrame decodes a compressed response line into its plain text.
+// maxBytes bounds the decompressed size; zero means unlimited.
+func decompressFrame(mode Compression, frame string, maxBytes int) ([]byte, error) {
+	compressed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(frame, compressedFramePrefix))
+	if err != nil {
+		return nil, compressionError(mode, err)
+	}
+
+	var reader io.ReadCloser
+	switch mode {
+	case CompressionGzip:
+		reader, err = gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, compressionError(mode, err)
+		}
+	default:
+		return nil, compressionError(mode, fmt.Errorf("unsupported compression %q", mode))
+	}
+	defer reader.Close()
+
+	// Read one byte past the limit to detect oversized payloads
+	limited := io.Reader(reader)
+	if maxBytes > 0 {
+		limited = io.LimitReader(reader, int64(maxBytes)+1)
+	}
+
+	data, err := io.ReadAll(limited)
+	if err != nil {
+		return nil, compressionError(mode, err)
+	}
+	if maxBytes > 0 && len(data) > maxBytes {
+		return nil, responseTooLargeError("MaxResponseBytes", maxBytes)
+	}
+	return data, nil
+}
+
+// compressionError reports a compressed response that could not be decoded.
+func compressionError(mode Compression, cause error) *ProtocolError {
+	return &ProtocolError{
+		Code:    "DECOMPRESSION_FAILED",
+		Type:    "PROTOCOL_ERROR",
+		Message: "failed to decompress server response",
+		Details: map[string]interface{}{
+			"compression": string(mode),
+		},
+		Cause: cause,
+	}
+}
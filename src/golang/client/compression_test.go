@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+)
+
+// compressionServer is a mock server that performs the connection handshake,
+// advertises the given compression algorithms and gzip-compresses responses
+// once SET COMPRESSION succeeds.
+type compressionServer struct {
+	addr     string
+	commands chan string
+}
+
+func newCompressionServer(t *testing.T, advertise []string, response string) *compressionServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	server := &compressionServer{addr: ln.Addr().String(), commands: make(chan string, 16)}
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		if _, err := reader.ReadString('\x04'); err != nil {
+			return
+		}
+
+		auth, _ := json.Marshal(map[string]interface{}{
+			"status":      "success",
+			"compression": advertise,
+		})
+		conn.Write([]byte("S0001 Welcome to SyndrDB\n" + string(auth) + "\n"))
+
+		compressed := false
+		for {
+			command, err := reader.ReadString('\x04')
+			if err != nil {
+				return
+			}
+			command = strings.TrimSuffix(command, "\x04")
+			server.commands <- command
+
+			if strings.HasPrefix(command, "SET COMPRESSION") {
+				compressed = true
+				conn.Write([]byte(`{"success": true}` + "\n"))
+				continue
+			}
+
+			line := response
+			if compressed {
+				var buf bytes.Buffer
+				zw := gzip.NewWriter(&buf)
+				zw.Write([]byte(response))
+				zw.Close()
+				line = compressedFramePrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+			}
+			conn.Write([]byte(line + "\n"))
+		}
+	}()
+
+	return server
+}
+
+func connectCompressionClient(t *testing.T, server *compressionServer, mode Compression) *Client {
+	t.Helper()
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.Compression = mode
+	c := NewClient(&opts)
+
+	if err := c.Connect(context.Background(), "syndrdb://"+server.addr+":db:user:pass;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c
+}
+
+// TestCompressionNegotiated verifies gzip is negotiated and responses are decompressed.
+func TestCompressionNegotiated(t *testing.T) {
+	payload := `{"success": true, "data": "` + strings.Repeat("row ", 1000) + `"}`
+	server := newCompressionServer(t, []string{"gzip"}, payload)
+	c := connectCompressionClient(t, server, CompressionGzip)
+
+	if got := <-server.commands; got != `SET COMPRESSION "gzip";` {
+		t.Fatalf("expected SET COMPRESSION during handshake, got %q", got)
+	}
+	if mode := c.conn.Compression(); mode != CompressionGzip {
+		t.Fatalf("expected gzip compression, got %q", mode)
+	}
+
+	result, err := c.Query(`SELECT * FROM BUNDLE "rows";`, 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if result != strings.Repeat("row ", 1000) {
+		t.Errorf("unexpected decompressed result: %.40v", result)
+	}
+	if size := c.conn.LastResponseSize(); size >= len(payload) {
+		t.Errorf("expected compressed wire size below %d, got %d", len(payload), size)
+	}
+}
+
+// TestCompressionUnsupportedByServer verifies the client falls back to plain responses.
+func TestCompressionUnsupportedByServer(t *testing.T) {
+	server := newCompressionServer(t, nil, `{"success": true, "data": "plain"}`)
+	c := connectCompressionClient(t, server, CompressionGzip)
+
+	if mode := c.conn.Compression(); mode != CompressionNone {
+		t.Fatalf("expected no compression, got %q", mode)
+	}
+
+	result, err := c.Query(`SELECT * FROM BUNDLE "rows";`, 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if result != "plain" {
+		t.Errorf("expected 'plain', got %v", result)
+	}
+	if got := <-server.commands; strings.HasPrefix(got, "SET COMPRESSION") {
+		t.Errorf("expected no compression negotiation, got %q", got)
+	}
+}
+
+// TestDecompressFrameLimit verifies MaxResponseBytes applies to the decompressed size.
+func TestDecompressFrameLimit(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(bytes.Repeat([]byte("a"), 10000))
+	zw.Close()
+	frame := compressedFramePrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	if _, err := decompressFrame(CompressionGzip, frame, 1000); ErrorCode(err) != "E_RESPONSE_TOO_LARGE" {
+		t.Errorf("expected E_RESPONSE_TOO_LARGE, got %v", err)
+	}
+	if data, err := decompressFrame(CompressionGzip, frame, 0); err != nil || len(data) != 10000 {
+		t.Errorf("expected 10000 bytes, got %d (%v)", len(data), err)
+	}
+	if _, err := decompressFrame(CompressionGzip, compressedFramePrefix+"not base64!", 0); ErrorCode(err) != "DECOMPRESSION_FAILED" {
+		t.Errorf("expected DECOMPRESSION_FAILED, got %v", err)
+	}
+}
+
+// TestDecompressedResponseLimit verifies MaxResponseBytes applies to the
+// decompressed size of all partial frames together.
+func TestDecompressedResponseLimit(t *testing.T) {
+	compress := func(payload string) string {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(payload))
+		zw.Close()
+		return compressedFramePrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	frame := partialFramePrefix + compress(`["`+strings.Repeat("a", 400)+`"]`) + "\n"
+	c, _ := newPipeClient(t, func(command string) string {
+		return strings.Repeat(frame, 4) + compress(`{"Result":[],"ResultCount":4}`)
+	})
+	c.conn.setCompression(CompressionGzip)
+
+	c.conn.setResponseLimits(2000, 0)
+	result, err := c.Query(`SELECT * FROM BUNDLE "big";`, 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if rows := resultRows(result); len(rows) != 4 {
+		t.Errorf("expected 4 rows, got %d", len(rows))
+	}
+
+	// Each frame decompresses to less than the limit, but together they exceed it
+	c.conn.setResponseLimits(1000, 0)
+	if len(frame) > 1000/4 {
+		t.Fatalf("compressed frames of %d bytes exceed the limit on the wire", len(frame))
+	}
+	if _, err := c.Query(`SELECT * FROM BUNDLE "big";`, 1000); ErrorCode(err) != "E_RESPONSE_TOO_LARGE" {
+		t.Fatalf("expected E_RESPONSE_TOO_LARGE, got %v", err)
+	}
+	if !c.conn.IsAlive() {
+		t.Error("expected the connection to stay usable once the response was read")
+	}
+}
@@ -0,0 +1,283 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// configEnvPrefix prefixes the environment variable for each config key,
+// e.g. pool_max_size is read from SYNDRDB_POOL_MAX_SIZE.
+const configEnvPrefix = "SYNDRDB_"
+
+// ConfigFileNames are the files OptionsFromFile searches, in order, when
+// called with an empty path: first in the working directory, then in the
+// user's home directory.
+var ConfigFileNames = []string{"syndrdb.yaml", "syndrdb.yml", ".syndrdbrc"}
+
+// configSetters maps config keys to the ClientOptions field they set.
+// The same keys are used in config files and, upper-cased with the
+// SYNDRDB_ prefix, in the environment.
+var configSetters = map[string]func(opts *ClientOptions, value string) error{
+	"conn": func(opts *ClientOptions, value string) error {
+		opts.ConnString = value
+		return nil
+	},
+	"pool_min_size": func(opts *ClientOptions, value string) error {
+		return setInt(&opts.PoolMinSize, value)
+	},
+	"pool_max_size": func(opts *ClientOptions, value string) error {
+		return setInt(&opts.PoolMaxSize, value)
+	},
+	"pool_idle_timeout": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.PoolIdleTimeout, value)
+	},
+	"pool_refill_jitter": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.PoolRefillJitter, value)
+	},
+	"pool_max_conn_lifetime": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.PoolMaxConnLifetime, value)
+	},
+	"pool_max_conn_uses": func(opts *ClientOptions, value string) error {
+		return setInt(&opts.PoolMaxConnUses, value)
+	},
+	"dial_address_timeout": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.DialAddressTimeout, value)
+	},
+	"discovery_srv": func(opts *ClientOptions, value string) error {
+		opts.Discoverer = NewSRVDiscoverer(value)
+		return nil
+	},
+	"discovery_refresh_interval": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.DiscoveryRefreshInterval, value)
+	},
+	"idle_probe_interval": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.IdleProbeInterval, value)
+	},
+	"tcp_keepalive": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.TCPKeepAlive, value)
+	},
+	"timeout": func(opts *ClientOptions, value string) error {
+		var d time.Duration
+		if err := setDuration(&d, value); err != nil {
+			return err
+		}
+		opts.DefaultTimeoutMs = int(d.Milliseconds())
+		return nil
+	},
+	"query_timeout": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.DefaultQueryTimeout, value)
+	},
+	"statement_timeout": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.StatementTimeout, value)
+	},
+	"transaction_timeout": func(opts *ClientOptions, value string) error {
+		return setDuration(&opts.TransactionTimeout, value)
+	},
+	"max_retries": func(opts *ClientOptions, value string) error {
+		return setInt(&opts.MaxRetries, value)
+	},
+	"tls": func(opts *ClientOptions, value string) error {
+		return setBool(&opts.TLSEnabled, value)
+	},
+	"tls_insecure_skip_verify": func(opts *ClientOptions, value string) error {
+		return setBool(&opts.TLSInsecureSkipVerify, value)
+	},
+	"tls_ca_file": func(opts *ClientOptions, value string) error {
+		opts.TLSCAFile = value
+		return nil
+	},
+	"tls_cert_file": func(opts *ClientOptions, value string) error {
+		opts.TLSCertFile = value
+		return nil
+	},
+	"tls_key_file": func(opts *ClientOptions, value string) error {
+		opts.TLSKeyFile = value
+		return nil
+	},
+	"log_level": func(opts *ClientOptions, value string) error {
+		opts.LogLevel = strings.ToUpper(value)
+		return nil
+	},
+	"debug": func(opts *ClientOptions, value string) error {
+		return setBool(&opts.DebugMode, value)
+	},
+}
+
+// OptionsFromEnv returns DefaultOptions overridden by SYNDRDB_* environment
+// variables: SYNDRDB_CONN, SYNDRDB_POOL_MIN_SIZE, SYNDRDB_POOL_MAX_SIZE,
+// SYNDRDB_POOL_IDLE_TIMEOUT, SYNDRDB_POOL_REFILL_JITTER,
+// SYNDRDB_POOL_MAX_CONN_LIFETIME, SYNDRDB_POOL_MAX_CONN_USES,
+// SYNDRDB_IDLE_PROBE_INTERVAL, SYNDRDB_TCP_KEEPALIVE,
+// SYNDRDB_DIAL_ADDRESS_TIMEOUT, SYNDRDB_DISCOVERY_SRV,
+// SYNDRDB_DISCOVERY_REFRESH_INTERVAL, SYNDRDB_TIMEOUT,
+// SYNDRDB_QUERY_TIMEOUT, SYNDRDB_STATEMENT_TIMEOUT,
+// SYNDRDB_TRANSACTION_TIMEOUT, SYNDRDB_MAX_RETRIES, SYNDRDB_TLS,
+// SYNDRDB_TLS_INSECURE_SKIP_VERIFY, SYNDRDB_TLS_CA_FILE, SYNDRDB_TLS_CERT_FILE,
+// SYNDRDB_TLS_KEY_FILE, SYNDRDB_LOG_LEVEL and SYNDRDB_DEBUG.
+// Durations accept Go syntax ("30s") or plain milliseconds.
+func OptionsFromEnv() (*ClientOptions, error) {
+	opts := DefaultOptions()
+	if err := applyEnv(&opts); err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+// OptionsFromFile returns DefaultOptions overridden by a config file of
+// "key: value" (YAML) or "key = value" lines, using the keys of
+// OptionsFromEnv in lower case without the prefix, e.g. "pool_max_size: 10".
+// Only flat keys are supported. If path is empty, ConfigFileNames are
+// searched and defaults are returned if none exists.
+func OptionsFromFile(path string) (*ClientOptions, error) {
+	opts := DefaultOptions()
+	if err := applyFile(&opts, path); err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+// LoadOptions returns DefaultOptions overridden by the config file at path
+// (searched for if empty) and then by the environment, so SYNDRDB_*
+// variables take precedence over the file.
+func LoadOptions(path string) (*ClientOptions, error) {
+	opts := DefaultOptions()
+	if err := applyFile(&opts, path); err != nil {
+		return nil, err
+	}
+	if err := applyEnv(&opts); err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+// applyEnv sets opts from the SYNDRDB_* environment variables that are set.
+func applyEnv(opts *ClientOptions) error {
+	for key, set := range configSetters {
+		name := configEnvPrefix + strings.ToUpper(key)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := set(opts, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// applyFile sets opts from the config file at path, or the first of
+// ConfigFileNames found if path is empty.
+func applyFile(opts *ClientOptions, path string) error {
+	if path == "" {
+		path = findConfigFile()
+		if path == "" {
+			return nil
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || text == "---" {
+			continue
+		}
+
+		key, value, ok := cutConfigLine(text)
+		if !ok {
+			return fmt.Errorf("%s:%d: expected \"key: value\", got %q", path, line, text)
+		}
+		set, ok := configSetters[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("%s:%d: unknown key %q", path, line, key)
+		}
+		if err := set(opts, value); err != nil {
+			return fmt.Errorf("%s:%d: invalid %s: %w", path, line, key, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	return nil
+}
+
+// cutConfigLine splits a "key: value" or "key = value" line, dropping
+// trailing comments and surrounding quotes from the value.
+func cutConfigLine(line string) (string, string, bool) {
+	sep := strings.IndexAny(line, ":=")
+	if sep <= 0 {
+		return "", "", false
+	}
+	key := strings.TrimSpace(line[:sep])
+	value := strings.TrimSpace(line[sep+1:])
+
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return key, value[1 : end+1], true
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return key, value, true
+}
+
+// findConfigFile returns the first of ConfigFileNames in the working
+// directory or home directory, or "" if there is none.
+func findConfigFile() string {
+	dirs := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	}
+	for _, dir := range dirs {
+		for _, name := range ConfigFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+func setInt(dst *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	*dst = n
+	return nil
+}
+
+func setBool(dst *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*dst = b
+	return nil
+}
+
+// setDuration parses Go duration syntax or a plain number of milliseconds.
+func setDuration(dst *time.Duration, value string) error {
+	if ms, err := strconv.Atoi(value); err == nil {
+		*dst = time.Duration(ms) * time.Millisecond
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*dst = d
+	return nil
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOptionsFromEnv verifies SYNDRDB_* variables override the defaults.
+func TestOptionsFromEnv(t *testing.T) {
+	t.Setenv("SYNDRDB_CONN", "syndrdb://db:1776:primary:root:secret;")
+	t.Setenv("SYNDRDB_POOL_MAX_SIZE", "8")
+	t.Setenv("SYNDRDB_TIMEOUT", "2s")
+	t.Setenv("SYNDRDB_QUERY_TIMEOUT", "1500")
+	t.Setenv("SYNDRDB_TLS", "true")
+	t.Setenv("SYNDRDB_LOG_LEVEL", "debug")
+
+	opts, err := OptionsFromEnv()
+	if err != nil {
+		t.Fatalf("OptionsFromEnv failed: %v", err)
+	}
+	if opts.ConnString != "syndrdb://db:1776:primary:root:secret;" || opts.PoolMaxSize != 8 || !opts.TLSEnabled {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+	if opts.DefaultTimeoutMs != 2000 || opts.DefaultQueryTimeout != 1500*time.Millisecond || opts.LogLevel != "DEBUG" {
+		t.Errorf("unexpected timeouts or level: %d %v %s", opts.DefaultTimeoutMs, opts.DefaultQueryTimeout, opts.LogLevel)
+	}
+	if opts.PoolMinSize != DefaultOptions().PoolMinSize {
+		t.Errorf("expected unset keys to keep defaults, got PoolMinSize %d", opts.PoolMinSize)
+	}
+
+	t.Setenv("SYNDRDB_POOL_MAX_SIZE", "many")
+	if _, err := OptionsFromEnv(); err == nil || !strings.Contains(err.Error(), "SYNDRDB_POOL_MAX_SIZE") {
+		t.Errorf("expected an error naming the variable, got %v", err)
+	}
+}
+
+// TestOptionsFromFile verifies YAML and rc-style config files.
+func TestOptionsFromFile(t *testing.T) {
+	dir := t.TempDir()
+	yaml := filepath.Join(dir, "syndrdb.yaml")
+	os.WriteFile(yaml, []byte("# local dev\nconn: \"syndrdb://localhost:1776:primary:root:root;\"\npool_max_size: 4 # per process\ntls_insecure_skip_verify: true\n"), 0644)
+
+	opts, err := OptionsFromFile(yaml)
+	if err != nil {
+		t.Fatalf("OptionsFromFile failed: %v", err)
+	}
+	if opts.ConnString != "syndrdb://localhost:1776:primary:root:root;" || opts.PoolMaxSize != 4 || !opts.TLSInsecureSkipVerify {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	rc := filepath.Join(dir, ".syndrdbrc")
+	os.WriteFile(rc, []byte("pool_idle_timeout = 1m\nbogus = 1\n"), 0644)
+	if _, err := OptionsFromFile(rc); err == nil || !strings.Contains(err.Error(), ".syndrdbrc:2") {
+		t.Errorf("expected an unknown key error with line number, got %v", err)
+	}
+}
+
+// TestLoadOptions verifies the file is found automatically and the environment overrides it.
+func TestLoadOptions(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ".syndrdbrc"), []byte("conn=syndrdb://file:1776:db:u:p;\npool_max_size=4\n"), 0644)
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("SYNDRDB_POOL_MAX_SIZE", "6")
+
+	opts, err := LoadOptions("")
+	if err != nil {
+		t.Fatalf("LoadOptions failed: %v", err)
+	}
+	if opts.ConnString != "syndrdb://file:1776:db:u:p;" || opts.PoolMaxSize != 6 {
+		t.Errorf("expected file conn and env pool size, got %q %d", opts.ConnString, opts.PoolMaxSize)
+	}
+}
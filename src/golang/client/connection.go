@@ -2,13 +2,17 @@ package client
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -39,37 +43,85 @@ type ConnectionInterface interface {
 
 // Connection represents a single TCP connection to SyndrDB server.
 type Connection struct {
+	id           uint64
 	conn         net.Conn
-	scanner      *bufio.Scanner
+	reader       *bufio.Reader
 	remoteAddr   string
 	lastActivity time.Time
 	mu           sync.RWMutex
 	alive        bool
 	tlsState     *tls.ConnectionState
+
+	// Response limits from ClientOptions; zero means unlimited
+	maxResponseBytes int
+	maxRows          int
+
+	// lastResponseBytes is the size of the most recently received response
+	lastResponseBytes int
+
+	// lineBuf accumulates responses larger than the read buffer and is reused
+	// across reads; see readLine
+	lineBuf []byte
+
+	// compression is the negotiated response compression, empty if none
+	compression Compression
+
+	// serverInfo is what the server advertised during authentication
+	serverInfo *ServerInfo
+
+	// database is the database the connection is using, tracked across USE commands
+	database string
+
+	// healthCheckDue is set when a command was cancelled, so the pool pings
+	// the connection before reusing it
+	healthCheckDue bool
+
+	// capture records the frames sent and received, nil when not capturing
+	capture *WireCapture
 }
 
+const (
+	// defaultReadBufferSize is the read buffer used when ClientOptions.ReadBufferSize is unset.
+	// Responses that fit in it are parsed without being copied.
+	defaultReadBufferSize = 64 << 10
+
+	// maxRetainedLineBuffer is the largest buffer kept between reads for
+	// responses that do not fit in the read buffer.
+	maxRetainedLineBuffer = 1 << 20
+)
+
+// errResponseTooLong reports a response exceeding MaxResponseBytes.
+var errResponseTooLong = errors.New("response exceeds MaxResponseBytes")
+
+// nextConnectionID numbers connections in the order they are opened.
+var nextConnectionID atomic.Uint64
+
 // NewConnection creates a new connection to the specified address with optional TLS.
+// The address is a host:port, or a Unix domain socket made by UnixAddress.
 func NewConnection(ctx context.Context, address string, opts ClientOptions) (*Connection, error) {
-	timeout := time.Duration(opts.DefaultTimeoutMs) * time.Millisecond
-
-	// Create TCP connection with timeout
-	conn, err := net.DialTimeout("tcp", address, timeout)
+	// Create TCP connection with timeout, trying each address of the host
+	conn, tried, err := dialServer(ctx, address, opts)
 	if err != nil {
 		return nil, &ConnectionError{
 			Code:    "CONNECTION_FAILED",
 			Type:    "CONNECTION_ERROR",
 			Message: fmt.Sprintf("failed to connect to %s", address),
 			Details: map[string]interface{}{
-				"address": address,
-				"timeout": opts.DefaultTimeoutMs,
+				"address":   address,
+				"addresses": tried,
+				"timeout":   opts.DefaultTimeoutMs,
 			},
 			Cause: err,
 		}
 	}
 
+	setKeepAlive(conn, opts.TCPKeepAlive)
+
 	// Extract server name from address for TLS
 	serverName := address
-	if idx := strings.Index(address, ":"); idx >= 0 {
+	if _, ok := unixSocketPath(address); ok {
+		serverName = "localhost"
+	} else if idx := strings.Index(address, ":"); idx >= 0 {
 		serverName = address[:idx]
 	}
 
@@ -100,29 +152,53 @@ func NewConnection(ctx context.Context, address string, opts ClientOptions) (*Co
 			}
 		}
 
-		conn = tlsConn
-		scanner := bufio.NewScanner(conn)
-
-		return &Connection{
-			conn:         conn,
-			scanner:      scanner,
-			remoteAddr:   conn.RemoteAddr().String(),
-			lastActivity: time.Now(),
-			alive:        true,
-			tlsState:     &state,
-		}, nil
+		c := newConnection(tlsConn, opts)
+		c.tlsState = &state
+		return c, nil
 	}
 
 	// Plain TCP connection
-	scanner := bufio.NewScanner(conn)
+	return newConnection(conn, opts), nil
+}
 
-	return &Connection{
+// setKeepAlive applies ClientOptions.TCPKeepAlive to a TCP connection.
+// Other connections, e.g. in-memory ones from a test Dialer, are left alone.
+func setKeepAlive(conn net.Conn, period time.Duration) {
+	tcp, ok := conn.(*net.TCPConn)
+	if !ok || period == 0 {
+		return
+	}
+	if period < 0 {
+		tcp.SetKeepAlive(false)
+		return
+	}
+	tcp.SetKeepAlive(true)
+	tcp.SetKeepAlivePeriod(period)
+}
+
+// newConnection wraps an established network connection.
+func newConnection(conn net.Conn, opts ClientOptions) *Connection {
+	size := opts.ReadBufferSize
+	if size <= 0 {
+		size = defaultReadBufferSize
+	}
+	c := &Connection{
+		id:           nextConnectionID.Add(1),
 		conn:         conn,
-		scanner:      scanner,
+		reader:       bufio.NewReaderSize(conn, size),
 		remoteAddr:   conn.RemoteAddr().String(),
 		lastActivity: time.Now(),
 		alive:        true,
-	}, nil
+		capture:      opts.WireCapture,
+	}
+	c.setResponseLimits(opts.MaxResponseBytes, opts.MaxRowsInMemory)
+	return c
+}
+
+// setResponseLimits configures the maximum response size and row count.
+func (c *Connection) setResponseLimits(maxBytes, maxRows int) {
+	c.maxResponseBytes = maxBytes
+	c.maxRows = maxRows
 }
 
 // SendCommand sends a command to the server with EOT terminator.
@@ -146,6 +222,10 @@ func (c *Connection) SendCommand(ctx context.Context, command string) error {
 		}
 	}
 
+	if c.capture != nil {
+		c.capture.sent(ctx, c.id, command)
+	}
+
 	// Append EOT terminator
 	fullCmd := command + "\x04"
 	_, err := c.conn.Write([]byte(fullCmd))
@@ -166,8 +246,44 @@ func (c *Connection) SendCommand(ctx context.Context, command string) error {
 	return nil
 }
 
-// ReceiveResponse reads and parses a response from the server.
+// ReceiveResponse reads and parses a response from the server. The rows of
+// a response sent in several frames are combined into one result, unless ctx
+// comes from QueryStream, which receives them as each frame arrives.
 func (c *Connection) ReceiveResponse(ctx context.Context) (interface{}, error) {
+	deliver, _ := ctx.Value(partialRowsKey{}).(func([]interface{}) error)
+	var rows []interface{}
+	line, err := c.readResponse(ctx, func(payload []byte) error {
+		part, err := c.parseResponse(payload)
+		if err != nil {
+			return err
+		}
+		if deliver != nil {
+			return deliver(resultRows(part))
+		}
+		rows = append(rows, resultRows(part)...)
+		return c.checkRowLimit(rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.parseResponse(line)
+	if err != nil || rows == nil {
+		return result, err
+	}
+	result = mergeRows(rows, result)
+	if err := c.checkRowLimit(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readResponse reads the next response, decompressing it if needed, and
+// returns its final frame. The payload of each partial frame before it is
+// passed to partial. If partial fails, the rest of the response is still
+// read, so the connection stays usable, and its error is returned.
+// The returned slice is only valid until the next read.
+func (c *Connection) readResponse(ctx context.Context, partial func(payload []byte) error) ([]byte, error) {
 	// Check context cancellation before operation
 	select {
 	case <-ctx.Done():
@@ -187,9 +303,31 @@ func (c *Connection) ReceiveResponse(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	if !c.scanner.Scan() {
-		if err := c.scanner.Err(); err != nil {
+	// Streamed responses are handed out frame by frame, so only their frames
+	// are limited; other responses are held whole and limited in total, both
+	// as read from the wire and once decompressed
+	streamed := ctx.Value(partialRowsKey{}) != nil
+	total, held := 0, 0
+	var partialErr error
+	for {
+		raw, err := c.readFrame()
+		if err != nil {
+			if errors.Is(err, errResponseTooLong) {
+				// The rest of the oversized response is still unread, so the
+				// connection cannot be resynchronized and must be discarded
+				c.markDead()
+				c.conn.Close()
+				return nil, responseTooLargeError("MaxResponseBytes", c.maxResponseBytes)
+			}
 			c.markDead()
+			if errors.Is(err, io.EOF) {
+				return nil, &ProtocolError{
+					Code:    "NO_RESPONSE",
+					Type:    "PROTOCOL_ERROR",
+					Message: "no response from server",
+					Details: map[string]interface{}{},
+				}
+			}
 			return nil, &ProtocolError{
 				Code:    "RECEIVE_FAILED",
 				Type:    "PROTOCOL_ERROR",
@@ -198,56 +336,229 @@ func (c *Connection) ReceiveResponse(ctx context.Context) (interface{}, error) {
 				Cause:   err,
 			}
 		}
-		c.markDead()
-		return nil, &ProtocolError{
-			Code:    "NO_RESPONSE",
-			Type:    "PROTOCOL_ERROR",
-			Message: "no response from server",
-			Details: map[string]interface{}{},
+		total += len(raw)
+		if c.maxResponseBytes > 0 && total > c.maxResponseBytes && !streamed {
+			// Each partial frame fits, but together they exceed the limit
+			c.markDead()
+			c.conn.Close()
+			return nil, responseTooLargeError("MaxResponseBytes", c.maxResponseBytes)
+		}
+		if c.capture != nil {
+			c.capture.received(ctx, c.id, raw)
 		}
-	}
 
-	line := strings.TrimSpace(c.scanner.Text())
+		budget := c.maxResponseBytes
+		if budget > 0 && !streamed {
+			// At least one byte, as a budget of zero would disable the limit
+			budget = max(budget-held, 1)
+		}
+		line, isPartial, err := c.decodeFrame(raw, budget)
+		if err == nil && !streamed {
+			held += len(line)
+			if c.maxResponseBytes > 0 && held > c.maxResponseBytes {
+				err = errResponseTooLong
+			}
+		}
+		if errors.Is(err, errResponseTooLong) {
+			// The frame was read in full, so the connection stays usable
+			err = responseTooLargeError("MaxResponseBytes", c.maxResponseBytes)
+		}
+		if !isPartial {
+			c.mu.Lock()
+			c.lastResponseBytes = total
+			c.mu.Unlock()
+			if err != nil {
+				return nil, err
+			}
+			if partialErr != nil {
+				return nil, partialErr
+			}
+			return line, nil
+		}
+		switch {
+		case partialErr != nil:
+			// Drain the rest of a response that has already failed
+		case err != nil:
+			partialErr = err
+		default:
+			partialErr = partial(line)
+		}
+	}
+}
 
+// parseResponse decodes a response line: the welcome message and non-JSON
+// lines as strings, JSON as interface{} values with the "data" envelope removed.
+func (c *Connection) parseResponse(line []byte) (interface{}, error) {
 	// Check for welcome message (S0001)
-	if strings.Contains(line, "S0001") {
-		return line, nil
+	if bytes.Contains(line, []byte("S0001")) {
+		return string(line), nil
 	}
 
 	// Try to parse as JSON
 	var result interface{}
-	if err := json.Unmarshal([]byte(line), &result); err != nil {
+	if err := json.Unmarshal(line, &result); err != nil {
 		// Not JSON, return raw string
-		return line, nil
+		return string(line), nil
 	}
 
 	// Check for error in JSON response
 	if respMap, ok := result.(map[string]interface{}); ok {
-		if success, hasSuccess := respMap["success"].(bool); hasSuccess && !success {
-			// Error response
-			errMsg := "unknown error"
-			if errData, ok := respMap["error"]; ok {
-				errMsg = fmt.Sprintf("%v", errData)
-			}
-			return nil, &ProtocolError{
-				Code:    "SERVER_ERROR",
-				Type:    "PROTOCOL_ERROR",
-				Message: errMsg,
-				Details: respMap,
-			}
+		if err := envelopeError(respMap); err != nil {
+			return nil, err
 		}
 
 		// Return data field if present
 		if data, ok := respMap["data"]; ok {
 			c.updateActivity()
+			if err := c.checkRowLimit(data); err != nil {
+				return nil, err
+			}
 			return data, nil
 		}
 	}
 
 	c.updateActivity()
+	if err := c.checkRowLimit(result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
+// envelopeError returns the error carried by a {"success": false, ...} response.
+func envelopeError(respMap map[string]interface{}) error {
+	success, hasSuccess := respMap["success"].(bool)
+	if !hasSuccess || success {
+		return nil
+	}
+	errMsg := "unknown error"
+	if errData, ok := respMap["error"]; ok {
+		errMsg = fmt.Sprintf("%v", errData)
+	}
+	return &ProtocolError{
+		Code:    "SERVER_ERROR",
+		Type:    "PROTOCOL_ERROR",
+		Message: errMsg,
+		Details: respMap,
+	}
+}
+
+// readLine reads one newline-terminated response without its terminator.
+// A line that fits in the read buffer is returned in place, without copying;
+// longer lines are assembled in lineBuf, which is kept for the next read.
+// The returned slice is only valid until the next read.
+func (c *Connection) readLine() ([]byte, error) {
+	chunk, err := c.reader.ReadSlice('\n')
+	if err == nil {
+		if c.tooLong(len(chunk)) {
+			return nil, errResponseTooLong
+		}
+		return dropLineEnding(chunk), nil
+	}
+
+	// Size the buffer from the previous large response so it is filled
+	// without repeated growth
+	buf := c.lineBuf[:0]
+	if hint := c.LastResponseSize(); cap(buf) < hint {
+		buf = make([]byte, 0, hint+hint/4)
+	}
+	for err == bufio.ErrBufferFull {
+		buf = append(buf, chunk...)
+		if c.tooLong(len(buf)) {
+			c.lineBuf = nil
+			return nil, errResponseTooLong
+		}
+		chunk, err = c.reader.ReadSlice('\n')
+	}
+	buf = append(buf, chunk...)
+	if cap(buf) <= maxRetainedLineBuffer {
+		c.lineBuf = buf
+	} else {
+		c.lineBuf = nil
+	}
+
+	if err != nil && !(errors.Is(err, io.EOF) && len(buf) > 0) {
+		return nil, err
+	}
+	if c.tooLong(len(buf)) {
+		return nil, errResponseTooLong
+	}
+	return dropLineEnding(buf), nil
+}
+
+// tooLong reports whether a line of n bytes, including its terminator, exceeds MaxResponseBytes.
+func (c *Connection) tooLong(n int) bool {
+	return c.maxResponseBytes > 0 && n > c.maxResponseBytes+1
+}
+
+// dropLineEnding removes a trailing "\n" or "\r\n".
+func dropLineEnding(line []byte) []byte {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r"))
+}
+
+// checkRowLimit returns E_RESPONSE_TOO_LARGE if result holds more rows than MaxRowsInMemory.
+// The response has been fully read, so the connection remains usable.
+func (c *Connection) checkRowLimit(result interface{}) error {
+	if c.maxRows <= 0 {
+		return nil
+	}
+	if rows := responseRowCount(result); rows > c.maxRows {
+		err := responseTooLargeError("MaxRowsInMemory", c.maxRows)
+		err.Details["rows"] = rows
+		return err
+	}
+	return nil
+}
+
+// responseRowCount returns the number of rows in a parsed response:
+// the length of a top-level array or of a "Result" array.
+func responseRowCount(result interface{}) int {
+	switch v := result.(type) {
+	case []interface{}:
+		return len(v)
+	case map[string]interface{}:
+		if rows, ok := v["Result"].([]interface{}); ok {
+			return len(rows)
+		}
+	}
+	return 0
+}
+
+// responseTooLargeError reports a response exceeding a configured limit.
+func responseTooLargeError(option string, limit int) *ProtocolError {
+	return &ProtocolError{
+		Code: "E_RESPONSE_TOO_LARGE",
+		Type: "PROTOCOL_ERROR",
+		Message: fmt.Sprintf("response exceeds %s (%d); narrow the query or read the results with QueryStream instead of loading them at once",
+			option, limit),
+		Details: map[string]interface{}{
+			"option": option,
+			"limit":  limit,
+		},
+	}
+}
+
+// setCompression enables decoding of compressed responses after a successful negotiation.
+func (c *Connection) setCompression(mode Compression) {
+	c.compression = mode
+}
+
+// Compression returns the negotiated response compression, or CompressionNone.
+func (c *Connection) Compression() Compression {
+	if c.compression == "" {
+		return CompressionNone
+	}
+	return c.compression
+}
+
+// LastResponseSize returns the size in bytes of the most recently received response
+// as read from the wire, i.e. before decompression.
+func (c *Connection) LastResponseSize() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastResponseBytes
+}
+
 // Ping sends a minimal status check command to verify connection health.
 func (c *Connection) Ping(ctx context.Context) error {
 	if !c.IsAlive() {
@@ -272,6 +583,28 @@ func (c *Connection) Ping(ctx context.Context) error {
 	return nil
 }
 
+// idleProbeTimeout bounds the ping of an idle probe.
+const idleProbeTimeout = 5 * time.Second
+
+// probeConnection pings an idle connection; see ClientOptions.IdleProbeInterval.
+func probeConnection(conn ConnectionInterface) error {
+	ctx, cancel := context.WithTimeout(context.Background(), idleProbeTimeout)
+	defer cancel()
+	if err := conn.Ping(ctx); err != nil {
+		return err
+	}
+	if !conn.IsAlive() {
+		return errors.New("connection closed after ping")
+	}
+	return nil
+}
+
+// clearDeadline removes the deadline left by the last command, for a
+// connection that waits indefinitely for the server to push a response.
+func (c *Connection) clearDeadline() error {
+	return c.conn.SetDeadline(time.Time{})
+}
+
 // Close closes the connection gracefully.
 func (c *Connection) Close() error {
 	c.mu.Lock()
@@ -289,6 +622,11 @@ func (c *Connection) RemoteAddr() string {
 	return c.remoteAddr
 }
 
+// ID returns a number identifying the connection, unique within the process.
+func (c *Connection) ID() uint64 {
+	return c.id
+}
+
 // IsAlive checks if the connection is still valid.
 func (c *Connection) IsAlive() bool {
 	c.mu.RLock()
@@ -310,6 +648,23 @@ func (c *Connection) updateActivity() {
 	c.mu.Unlock()
 }
 
+// flagHealthCheck asks for the connection to be health-checked before reuse.
+func (c *Connection) flagHealthCheck() {
+	c.mu.Lock()
+	c.healthCheckDue = true
+	c.mu.Unlock()
+}
+
+// takeHealthCheckFlag reports whether a health check was requested, and
+// clears the request.
+func (c *Connection) takeHealthCheckFlag() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	due := c.healthCheckDue
+	c.healthCheckDue = false
+	return due
+}
+
 // markDead marks the connection as dead.
 func (c *Connection) markDead() {
 	c.mu.Lock()
@@ -317,6 +672,20 @@ func (c *Connection) markDead() {
 	c.mu.Unlock()
 }
 
+// currentDatabase returns the database the connection is using.
+func (c *Connection) currentDatabase() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.database
+}
+
+// setDatabase records the database the connection switched to.
+func (c *Connection) setDatabase(name string) {
+	c.mu.Lock()
+	c.database = name
+	c.mu.Unlock()
+}
+
 // GetTLSConnectionState returns the TLS connection state if TLS is enabled.
 func (c *Connection) GetTLSConnectionState() *tls.ConnectionState {
 	c.mu.RLock()
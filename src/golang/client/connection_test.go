@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMaxResponseBytes verifies oversized responses fail and discard the connection.
+func TestMaxResponseBytes(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `"` + strings.Repeat("x", 500) + `"`
+	})
+	c.conn.setResponseLimits(100, 0)
+
+	_, err := c.Query("SELECT * FROM BUNDLE \"big\";", 1000)
+	if ErrorCode(err) != "E_RESPONSE_TOO_LARGE" {
+		t.Fatalf("expected E_RESPONSE_TOO_LARGE, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "QueryStream") {
+		t.Errorf("expected the error to suggest QueryStream, got %v", err)
+	}
+	if c.conn.IsAlive() {
+		t.Error("expected connection to be discarded after an oversized response")
+	}
+}
+
+// TestMaxResponseBytesPartialFrames verifies the limit applies to the whole
+// response, not just to each of its partial frames.
+func TestMaxResponseBytesPartialFrames(t *testing.T) {
+	frame := `P1:["` + strings.Repeat("x", 50) + `"]` + "\n"
+	c, _ := newPipeClient(t, func(command string) string {
+		return strings.Repeat(frame, 4) + `{"Result":[],"ResultCount":4}`
+	})
+	c.conn.setResponseLimits(100, 0)
+
+	_, err := c.Query("SELECT * FROM BUNDLE \"big\";", 1000)
+	if ErrorCode(err) != "E_RESPONSE_TOO_LARGE" {
+		t.Fatalf("expected E_RESPONSE_TOO_LARGE, got %v", err)
+	}
+	if c.conn.IsAlive() {
+		t.Error("expected connection to be discarded after an oversized response")
+	}
+}
+
+// TestMaxResponseBytesStreamed verifies a streamed response is only limited
+// per frame, since its frames are not held together.
+func TestMaxResponseBytesStreamed(t *testing.T) {
+	frame := `P1:["` + strings.Repeat("x", 50) + `"]` + "\n"
+	c, _ := newPipeClient(t, func(command string) string {
+		return strings.Repeat(frame, 4) + `{"Result":[],"ResultCount":4}`
+	})
+	c.conn.setResponseLimits(100, 0)
+
+	stream, err := c.QueryStream(context.Background(), "SELECT * FROM BUNDLE \"big\";", 10)
+	if err != nil {
+		t.Fatalf("QueryStream failed: %v", err)
+	}
+	defer stream.Close()
+	rows := 0
+	for batch := stream.NextBatch(); batch != nil; batch = stream.NextBatch() {
+		rows += len(batch)
+	}
+	if err := stream.Err(); err != nil || rows != 4 {
+		t.Errorf("expected 4 streamed rows, got %d (err=%v)", rows, err)
+	}
+}
+
+// TestMaxResponseBytesWithinLimit verifies responses up to the limit are read in full,
+// including responses beyond bufio.Scanner's default 64KB line size.
+func TestMaxResponseBytesWithinLimit(t *testing.T) {
+	payload := `"` + strings.Repeat("x", 100*1024) + `"`
+	c, _ := newPipeClient(t, func(command string) string { return payload })
+	c.conn.setResponseLimits(len(payload), 0)
+
+	result, err := c.Query("SELECT * FROM BUNDLE \"big\";", 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if s, ok := result.(string); !ok || len(s) != 100*1024 {
+		t.Errorf("expected 100KB string result, got %T", result)
+	}
+	if got := c.conn.LastResponseSize(); got != len(payload) {
+		t.Errorf("expected LastResponseSize %d, got %d", len(payload), got)
+	}
+}
+
+// TestMaxRowsInMemory verifies row limits fail the command but keep the connection usable.
+func TestMaxRowsInMemory(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, "small") {
+			return `{"success": true, "data": [1, 2]}`
+		}
+		return `{"success": true, "Result": [1, 2, 3, 4]}`
+	})
+	c.conn.setResponseLimits(0, 3)
+
+	_, err := c.Query("SELECT * FROM BUNDLE \"large\";", 1000)
+	if ErrorCode(err) != "E_RESPONSE_TOO_LARGE" {
+		t.Fatalf("expected E_RESPONSE_TOO_LARGE, got %v", err)
+	}
+	if !c.conn.IsAlive() {
+		t.Fatal("expected connection to remain alive after a row limit error")
+	}
+
+	result, err := c.Query("SELECT * FROM BUNDLE \"small\";", 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if rows, ok := result.([]interface{}); !ok || len(rows) != 2 {
+		t.Errorf("expected 2 rows, got %v", result)
+	}
+}
+
+// TestMetricsHookResponseBytes verifies response sizes reach the metrics hook.
+func TestMetricsHookResponseBytes(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, "one") {
+			return `"a"`
+		}
+		return `"abcdefg"`
+	})
+	metrics := NewMetricsHook()
+	c.RegisterHook(metrics)
+
+	if _, err := c.Query("SELECT * FROM BUNDLE \"one\";", 1000); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if _, err := c.Query("SELECT * FROM BUNDLE \"two\";", 1000); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	stats := metrics.GetStats()
+	if stats["total_response_bytes"] != uint64(12) {
+		t.Errorf("expected 12 total response bytes, got %v", stats["total_response_bytes"])
+	}
+	if stats["max_response_bytes"] != uint64(9) {
+		t.Errorf("expected max response of 9 bytes, got %v", stats["max_response_bytes"])
+	}
+	if stats["avg_response_bytes"] != uint64(6) {
+		t.Errorf("expected average response of 6 bytes, got %v", stats["avg_response_bytes"])
+	}
+}
+
+// TestSingleConnectionIdleProbe verifies the single connection is pinged only
+// once it has been idle for IdleProbeInterval.
+func TestSingleConnectionIdleProbe(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+	c.opts.IdleProbeInterval = 80 * time.Millisecond
+	done := make(chan struct{})
+	defer close(done)
+	c.startIdleProbe(done)
+
+	probes := func() int {
+		n := 0
+		for _, command := range server.received() {
+			if command == "STATUS" {
+				n++
+			}
+		}
+		return n
+	}
+
+	for end := time.Now().Add(200 * time.Millisecond); time.Now().Before(end); {
+		if _, err := c.Query(`SELECT * FROM BUNDLE "users";`, 1000); err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := probes(); n != 0 {
+		t.Errorf("expected no probes while the connection is busy, got %d", n)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for probes() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if probes() == 0 {
+		t.Error("expected an idle probe")
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// databaseNamePattern matches database names accepted by UseDatabase.
+var databaseNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// useCommandPattern matches a USE statement and captures the database name.
+var useCommandPattern = regexp.MustCompile(`(?i)^\s*USE\s+"?([A-Za-z0-9_-]+)"?\s*;?\s*$`)
+
+type databaseKey struct{}
+
+// WithDatabase returns a context whose commands run against database name,
+// switching the connection with USE first if needed. It takes precedence
+// over the client's database set by UseDatabase.
+func WithDatabase(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, databaseKey{}, name)
+}
+
+// DatabaseFromContext returns the database set on ctx with WithDatabase, or "".
+func DatabaseFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(databaseKey{}).(string)
+	return name
+}
+
+// UseDatabase switches the client to database name. In pooled mode every
+// connection is switched lazily, before its next command, so all commands
+// use the new database. Transactions and prepared statements use the
+// database current when they are started.
+func (c *Client) UseDatabase(ctx context.Context, name string) error {
+	if err := validateDatabaseName(name); err != nil {
+		return err
+	}
+
+	// Switch one connection now so an unknown database fails here
+	if _, err := c.sendCommand(ctx, useDatabaseCommand(name)); err != nil {
+		return err
+	}
+
+	c.databaseMu.Lock()
+	c.database = name
+	c.databaseMu.Unlock()
+	c.logger.Info("switched database", String("database", name))
+	return nil
+}
+
+// Database returns the database set by UseDatabase, or "" if the client uses
+// the database from its connection string.
+func (c *Client) Database() string {
+	c.databaseMu.RLock()
+	defer c.databaseMu.RUnlock()
+	return c.database
+}
+
+// targetDatabase returns the database commands on ctx should run against, or
+// "" to leave the connection's database unchanged.
+func (c *Client) targetDatabase(ctx context.Context) string {
+	if name := DatabaseFromContext(ctx); name != "" {
+		return name
+	}
+	return c.Database()
+}
+
+// databaseTracker is implemented by connections that remember their current database.
+type databaseTracker interface {
+	currentDatabase() string
+	setDatabase(name string)
+}
+
+// ensureDatabase switches conn to the database ctx targets, if it is not
+// already using it. Connections that do not track their database are
+// switched on every call.
+func (c *Client) ensureDatabase(ctx context.Context, conn ConnectionInterface) error {
+	target := c.targetDatabase(ctx)
+	if target == "" {
+		return nil
+	}
+	tracker, tracked := conn.(databaseTracker)
+	if tracked && tracker.currentDatabase() == target {
+		return nil
+	}
+	if err := validateDatabaseName(target); err != nil {
+		return err
+	}
+
+	command := useDatabaseCommand(target)
+	c.logger.Debug("switching connection database",
+		String("database", target),
+		String("remote_addr", conn.RemoteAddr()))
+	if err := conn.SendCommand(ctx, command); err != nil {
+		return err
+	}
+	result, err := conn.ReceiveResponse(ctx)
+	if _, err := checkServerStatus(result, err, command); err != nil {
+		return err
+	}
+	if tracked {
+		tracker.setDatabase(target)
+	}
+	return nil
+}
+
+// sendOnConn sends command on conn after switching it to the database ctx targets.
+func (c *Client) sendOnConn(ctx context.Context, conn ConnectionInterface, command string) error {
+	if !isUseCommand(command) {
+		if err := c.ensureDatabase(ctx, conn); err != nil {
+			return err
+		}
+	}
+	return conn.SendCommand(ctx, c.annotateTrace(ctx, command))
+}
+
+// trackUseCommand records the database selected by a successful USE command on conn.
+func trackUseCommand(conn ConnectionInterface, command string) {
+	tracker, ok := conn.(databaseTracker)
+	if !ok {
+		return
+	}
+	if m := useCommandPattern.FindStringSubmatch(command); m != nil {
+		tracker.setDatabase(m[1])
+	}
+}
+
+// isUseCommand reports whether command is a USE statement.
+func isUseCommand(command string) bool {
+	return useCommandPattern.MatchString(command)
+}
+
+// useDatabaseCommand returns the SyndrQL statement switching to database name.
+func useDatabaseCommand(name string) string {
+	return fmt.Sprintf("USE %q;", name)
+}
+
+// validateDatabaseName rejects names that cannot be used in a USE statement.
+func validateDatabaseName(name string) error {
+	if !databaseNamePattern.MatchString(name) {
+		return &QueryError{
+			Code:    "E_INVALID_DATABASE_NAME",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("invalid database name %q: use letters, digits, underscores and hyphens", name),
+			Details: map[string]interface{}{
+				"database": name,
+			},
+		}
+	}
+	return nil
+}
+
+// connStrDatabase returns the database in a syndrdb://HOST:PORT:DATABASE:... connection string.
+func connStrDatabase(connStr string) string {
+	parts := strings.Split(strings.TrimPrefix(connStr, "syndrdb://"), ":")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.TrimSuffix(parts[2], ";")
+}
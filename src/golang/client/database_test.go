@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestUseDatabase verifies the client switches once and later commands are not re-prefixed.
+func TestUseDatabase(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, "missing") {
+			return `{"status":"error","message":"database not found: missing"}`
+		}
+		return `{"success":true}`
+	})
+	ctx := context.Background()
+
+	if err := c.UseDatabase(ctx, "tenant_a"); err != nil {
+		t.Fatalf("UseDatabase failed: %v", err)
+	}
+	if _, err := c.Query(`SELECT * FROM "users";`, 0); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	want := []string{`USE "tenant_a";`, `SELECT * FROM "users";`}
+	if got := server.received(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if err := c.UseDatabase(ctx, "missing"); err == nil {
+		t.Error("expected an error for an unknown database")
+	}
+	if c.Database() != "tenant_a" {
+		t.Errorf("expected the database to stay tenant_a, got %q", c.Database())
+	}
+
+	if err := c.UseDatabase(ctx, `x"; DROP`); ErrorCode(err) != "E_INVALID_DATABASE_NAME" {
+		t.Errorf("expected E_INVALID_DATABASE_NAME, got %v", err)
+	}
+}
+
+// TestBuilderDatabase verifies a builder's database is switched to and then switched back.
+func TestBuilderDatabase(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	ctx := context.Background()
+	c.conn.setDatabase("primary")
+
+	if _, err := c.QueryBuilder().Select("users").Database("tenant_b").Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if _, err := c.QueryBuilder().Select("users").Database("tenant_b").Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if err := c.UseDatabase(ctx, "primary"); err != nil {
+		t.Fatalf("UseDatabase failed: %v", err)
+	}
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "a"}).Execute(WithDatabase(ctx, "tenant_c")); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	got := server.received()
+	want := []string{`USE "tenant_b";`, "SELECT", "SELECT", `USE "primary";`, `USE "tenant_c";`, "ADD DOCUMENT"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d commands, got %v", len(want), got)
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("command %d: expected prefix %q, got %q", i, want[i], got[i])
+		}
+	}
+}
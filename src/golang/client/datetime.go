@@ -0,0 +1,47 @@
+package client
+
+import (
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/mapper"
+)
+
+func (vc valueCodec) location() *time.Location {
+	if vc.zone == nil {
+		return time.UTC
+	}
+	return vc.zone
+}
+
+// format renders t in the session time zone with the wire layout.
+func (vc valueCodec) format(t time.Time) string {
+	layout := vc.layout
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+	return t.In(vc.location()).Format(layout)
+}
+
+// parse reads a DATETIME value: a string in the wire layout or another
+// common format, or a Unix timestamp in seconds. Strings without an offset
+// are read in the session time zone.
+func (vc valueCodec) parse(value interface{}) (time.Time, error) {
+	m := mapper.ResponseMapper{Location: vc.location()}
+	if vc.layout != "" {
+		m.Layouts = []string{vc.layout}
+	}
+	return m.ToDateTime(value)
+}
+
+// FormatDateTime renders t as the client sends DATETIME values: in TimeZone,
+// using DateTimeLayout.
+func (c *Client) FormatDateTime(t time.Time) string {
+	return newValueCodec(c.opts).format(t)
+}
+
+// ParseDateTime converts a DATETIME value from a result to a time.Time in the
+// client's TimeZone. Strings in DateTimeLayout, RFC 3339 and other common
+// formats are accepted, as are Unix timestamps in seconds.
+func (c *Client) ParseDateTime(value interface{}) (time.Time, error) {
+	return newValueCodec(c.opts).parse(value)
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	berlin    = time.FixedZone("CET", 3600)
+	newYork   = time.FixedZone("EST", -5*3600)
+	dateValue = time.Date(2026, 3, 1, 23, 30, 15, 123456789, berlin)
+)
+
+func TestDateTimeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		zone   *time.Location
+		want   string
+		trunc  time.Duration
+	}{
+		{"default", "", nil, "2026-03-01T22:30:15.123456789Z", 0},
+		{"rfc3339 in zone", time.RFC3339, newYork, "2026-03-01T17:30:15-05:00", time.Second},
+		{"no offset", time.DateTime, newYork, "2026-03-01 17:30:15", time.Second},
+		{"millis", "2006-01-02T15:04:05.000", berlin, "2026-03-01T23:30:15.123", time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := valueCodec{layout: tt.layout, zone: tt.zone}
+			wire := codec.format(dateValue)
+			if wire != tt.want {
+				t.Fatalf("format: expected %s, got %s", tt.want, wire)
+			}
+			parsed, err := codec.parse(wire)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			if !parsed.Equal(dateValue.Truncate(tt.trunc)) {
+				t.Errorf("round trip: expected %v, got %v", dateValue.Truncate(tt.trunc), parsed)
+			}
+			if parsed.Location() != codec.location() {
+				t.Errorf("expected the session zone, got %v", parsed.Location())
+			}
+		})
+	}
+}
+
+func TestParseDateTimeFormats(t *testing.T) {
+	c := NewClient(nil)
+	c.opts.TimeZone = newYork
+
+	parsed, err := c.ParseDateTime("2026-03-01T12:00:00Z")
+	if err != nil || !parsed.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected an RFC 3339 string to parse, got %v, %v", parsed, err)
+	}
+	parsed, err = c.ParseDateTime("2026-03-01 07:00:00")
+	if err != nil || !parsed.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected a string without offset to be read in the session zone, got %v, %v", parsed, err)
+	}
+	parsed, err = c.ParseDateTime(float64(1772366400.5))
+	if err != nil || !parsed.Equal(time.Unix(1772366400, 5e8)) {
+		t.Errorf("expected a JSON number to be a Unix timestamp, got %v, %v", parsed, err)
+	}
+	if _, err := c.ParseDateTime("yesterday"); err == nil {
+		t.Error("expected an error for an unparseable value")
+	}
+}
+
+func TestBuildersEncodeDateTimes(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	c.opts.DateTimeLayout = time.DateTime
+	c.opts.TimeZone = newYork
+	ctx := context.Background()
+	wire := "2026-03-01 17:30:15"
+
+	if _, err := c.InsertBuilder("events").Values(map[string]interface{}{"at": dateValue}).Execute(ctx); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if _, err := c.QueryBuilder().Select("events").Where("at", GreaterThan, dateValue).Execute(ctx); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if _, err := c.UpdateBuilder("events").Set("at", &dateValue).Where("at", LessThan, dateValue).Execute(ctx); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := c.DeleteBuilder("events").Where("at", In, []time.Time{dateValue}).Execute(ctx); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	want := []string{
+		`"at" =  "` + wire + `"`,
+		`at > '` + wire + `'`,
+		`"at" = "` + wire + `"`,
+		`"at" IN ["` + wire + `"]`,
+	}
+	for i, command := range server.received() {
+		if !strings.Contains(command, want[i]) {
+			t.Errorf("command %d: expected %s in %s", i, want[i], command)
+		}
+		if strings.Contains(command, "+0100") || strings.Contains(command, "CET") {
+			t.Errorf("command %d: time rendered with fmt: %s", i, command)
+		}
+	}
+	if command := server.received()[2]; !strings.Contains(command, `WHERE "at" < "`+wire+`"`) {
+		t.Errorf("expected the update condition to be formatted: %s", command)
+	}
+}
+
+func TestStatementEncodesDateTimes(t *testing.T) {
+	stmt, transport := newCachedTestStatement("stmt_1")
+	stmt.paramCount = 1
+	stmt.codec = valueCodec{layout: time.RFC3339, zone: newYork}
+
+	if _, err := stmt.Execute(dateValue); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if sent := sentCommands(transport); len(sent) != 1 || sent[0] != "EXECUTE stmt_1\x052026-03-01T17:30:15-05:00" {
+		t.Errorf("unexpected EXECUTE command %q", sent)
+	}
+}
+
+func TestRepositoryDecodesDateTimes(t *testing.T) {
+	type event struct {
+		ID string     `json:"DocumentID"`
+		At time.Time  `json:"at"`
+		Ok *time.Time `json:"ok"`
+	}
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": [{"DocumentID": "e_1", "at": "2026-03-01 17:30:15", "ok": null}]}}`
+	})
+	c.opts.DateTimeLayout = time.DateTime
+	c.opts.TimeZone = newYork
+
+	events, err := NewGenericRepository[event](c, "events").Find(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(events) != 1 || !events[0].At.Equal(dateValue.Truncate(time.Second)) || events[0].Ok != nil {
+		t.Errorf("unexpected events: %+v", events)
+	}
+}
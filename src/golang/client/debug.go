@@ -45,6 +45,9 @@ func (c *Client) GetDebugInfo() map[string]interface{} {
 			"misses":            stats.Misses.Load(),
 			"timeouts":          stats.Timeouts.Load(),
 			"errors":            stats.Errors.Load(),
+			"refills":           stats.Refills.Load(),
+			"recycled":          stats.Recycled.Load(),
+			"probeFailures":     stats.ProbeFailures.Load(),
 		}
 	} else if c.conn != nil {
 		info["connection"] = map[string]interface{}{
@@ -63,6 +66,56 @@ func (c *Client) GetDebugInfo() map[string]interface{} {
 		}
 	}
 
+	if server := c.ServerInfo(); server != nil {
+		isolation := make([]string, len(server.IsolationLevels))
+		for i, level := range server.IsolationLevels {
+			isolation[i] = level.String()
+		}
+		info["server"] = map[string]interface{}{
+			"version":         server.Version,
+			"features":        server.Features,
+			"isolationLevels": isolation,
+			"compression":     server.Compression,
+			"tls":             server.TLS,
+		}
+	}
+
+	if c.limiter != nil {
+		stats := c.limiter.stats()
+		info["limiter"] = map[string]interface{}{
+			"inFlight":     stats.InFlight,
+			"maxInFlight":  stats.MaxInFlight,
+			"utilization":  stats.Utilization,
+			"tokens":       stats.Tokens,
+			"admitted":     stats.Admitted,
+			"rejected":     stats.Rejected,
+			"waitDuration": stats.WaitDuration.String(),
+		}
+	}
+
+	if c.shedder != nil {
+		stats := c.shedder.stats()
+		info["loadShedding"] = map[string]interface{}{
+			"threshold": stats.Threshold.String(),
+			"p95":       stats.P95.String(),
+			"samples":   stats.Samples,
+			"shedding":  stats.Shedding,
+			"shed":      stats.Shed,
+		}
+	}
+
+	if hedges := c.HedgeStats(); hedges.Eligible > 0 {
+		info["hedging"] = map[string]interface{}{
+			"delay":     hedges.Delay.String(),
+			"eligible":  hedges.Eligible,
+			"hedged":    hedges.Hedged,
+			"wins":      hedges.Wins,
+			"denied":    hedges.Denied,
+			"hedgeRate": hedges.HedgeRate(),
+			"winRate":   hedges.WinRate(),
+		}
+	}
+
 	// Options
 	info["options"] = map[string]interface{}{
 		"defaultTimeoutMs":     c.opts.DefaultTimeoutMs,
@@ -73,6 +126,7 @@ func (c *Client) GetDebugInfo() map[string]interface{} {
 		"healthCheckInterval":  c.opts.HealthCheckInterval.String(),
 		"maxReconnectAttempts": c.opts.MaxReconnectAttempts,
 		"tlsEnabled":           c.opts.TLSEnabled,
+		"wireCapture":          c.opts.WireCapture != nil,
 	}
 
 	// Last transition
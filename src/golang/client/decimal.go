@@ -0,0 +1,86 @@
+package client
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/mapper"
+)
+
+// DecimalValue is an arbitrary-precision decimal equal to
+// Coefficient × 10^Exponent. Decimal types such as shopspring/decimal's
+// Decimal implement it, so they can be passed to the builders and Statement
+// parameters without this package depending on them.
+type DecimalValue interface {
+	Coefficient() *big.Int
+	Exponent() int32
+}
+
+// maxDecimalDigits is the number of fractional digits sent for a *big.Rat
+// without a finite decimal expansion, such as 1/3: the precision of IEEE
+// 754 decimal128.
+const maxDecimalDigits = 34
+
+// encodeDecimal returns the decimal literal of a *big.Rat, big.Rat,
+// *big.Float, *big.Int or DecimalValue, or nil for a nil pointer.
+func encodeDecimal(value interface{}) interface{} {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	switch v := value.(type) {
+	case *big.Rat:
+		return wireLiteral(formatRat(v))
+	case big.Rat:
+		return wireLiteral(formatRat(&v))
+	case *big.Float:
+		return wireLiteral(v.Text('f', -1))
+	case *big.Int:
+		return wireLiteral(v.String())
+	case DecimalValue:
+		return wireLiteral(formatCoefficient(v.Coefficient(), v.Exponent()))
+	}
+	return value
+}
+
+// formatRat renders r in decimal notation, exactly if it has a finite
+// expansion and rounded to maxDecimalDigits fractional digits otherwise.
+func formatRat(r *big.Rat) string {
+	digits, exact := r.FloatPrec()
+	if !exact || digits > maxDecimalDigits {
+		digits = maxDecimalDigits
+	}
+	return r.FloatString(digits)
+}
+
+// formatCoefficient renders coefficient × 10^exponent in decimal notation,
+// keeping trailing fractional zeros so that a scale such as 12.50 survives.
+func formatCoefficient(coefficient *big.Int, exponent int32) string {
+	if coefficient == nil || coefficient.Sign() == 0 && exponent >= 0 {
+		return "0"
+	}
+	digits := new(big.Int).Abs(coefficient).String()
+	sign := ""
+	if coefficient.Sign() < 0 {
+		sign = "-"
+	}
+	if exponent >= 0 {
+		return sign + digits + strings.Repeat("0", int(exponent))
+	}
+
+	scale := int(-exponent)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	point := len(digits) - scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// ParseDecimal converts a DECIMAL value from a result to a *big.Rat. Strings
+// and json.Number values are converted exactly. JSON numbers decoded as
+// float64 are read by their shortest representation, so 0.1 becomes 1/10,
+// but digits beyond float64 precision are already lost; servers should
+// return DECIMAL values as strings.
+func (c *Client) ParseDecimal(value interface{}) (*big.Rat, error) {
+	return mapper.NewResponseMapper().ToDecimal(value)
+}
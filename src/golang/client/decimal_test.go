@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// testDecimal implements DecimalValue as shopspring/decimal's Decimal does.
+type testDecimal struct {
+	coefficient int64
+	exponent    int32
+}
+
+func (d testDecimal) Coefficient() *big.Int { return big.NewInt(d.coefficient) }
+func (d testDecimal) Exponent() int32       { return d.exponent }
+
+func TestEncodeDecimal(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	var nilRat *big.Rat
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{"terminating rat", big.NewRat(1999, 100), wireLiteral("19.99")},
+		{"rat value", *big.NewRat(-1, 8), wireLiteral("-0.125")},
+		{"integral rat", big.NewRat(10, 2), wireLiteral("5")},
+		{"repeating rat", big.NewRat(1, 3), wireLiteral("0." + strings.Repeat("3", maxDecimalDigits))},
+		{"float", big.NewFloat(0.5), wireLiteral("0.5")},
+		{"int", huge, wireLiteral("123456789012345678901234567890")},
+		{"scaled decimal", testDecimal{1250, -2}, wireLiteral("12.50")},
+		{"small decimal", testDecimal{-5, -3}, wireLiteral("-0.005")},
+		{"positive exponent", testDecimal{12, 3}, wireLiteral("12000")},
+		{"nil", nilRat, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeDecimal(tt.value); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildersEncodeDecimals(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	ctx := context.Background()
+	price, _ := new(big.Rat).SetString("1234567890.123456789")
+
+	if _, err := c.InsertBuilder("orders").Values(map[string]interface{}{"total": price}).Execute(ctx); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if _, err := c.QueryBuilder().Select("orders").Where("total", GreaterThan, testDecimal{1250, -2}).Execute(ctx); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if _, err := c.UpdateBuilder("orders").Set("total", price).Where("total", In, []interface{}{price}).Execute(ctx); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	want := []string{
+		`"total" =  1234567890.123456789}`,
+		`total > 12.50`,
+		`"total" = 1234567890.123456789`,
+	}
+	for i, command := range server.received() {
+		if !strings.Contains(command, want[i]) {
+			t.Errorf("command %d: expected %s in %s", i, want[i], command)
+		}
+	}
+}
+
+func TestStatementEncodesDecimals(t *testing.T) {
+	stmt, transport := newCachedTestStatement("stmt_1")
+	stmt.paramCount = 2
+
+	if _, err := stmt.Execute(big.NewRat(1999, 100), 0.1); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if sent := sentCommands(transport); len(sent) != 1 || sent[0] != "EXECUTE stmt_1\x0519.99\x050.1" {
+		t.Errorf("unexpected EXECUTE command %q", sent)
+	}
+}
+
+func TestRepositoryDecodesDecimals(t *testing.T) {
+	type order struct {
+		ID    string   `json:"DocumentID"`
+		Total *big.Rat `json:"total"`
+		Tax   big.Rat  `json:"tax"`
+	}
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": [{"DocumentID": "o_1", "total": "1234567890.123456789", "tax": 0.1}]}}`
+	})
+
+	orders, err := NewGenericRepository[order](c, "orders").Find(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("expected one order, got %d", len(orders))
+	}
+	if got := orders[0].Total.FloatString(9); got != "1234567890.123456789" {
+		t.Errorf("expected the total to be exact, got %s", got)
+	}
+	if orders[0].Tax.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Errorf("expected a tax of 1/10, got %s", orders[0].Tax.String())
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	d, err := NewClient(nil).ParseDecimal("-0.005")
+	if err != nil || d.Cmp(big.NewRat(-1, 200)) != 0 {
+		t.Errorf("expected -1/200, got %v, %v", d, err)
+	}
+	if _, err := NewClient(nil).ParseDecimal(true); err == nil {
+		t.Error("expected an error for a boolean")
+	}
+}
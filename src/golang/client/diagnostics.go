@@ -0,0 +1,279 @@
+package client
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// recentErrorsSize is how many failed commands DiagnosticsSnapshot reports.
+const recentErrorsSize = 20
+
+// DiagnosticsSnapshot is a point-in-time view of a client's internals,
+// returned by Client.DiagnosticsSnapshot. It marshals to JSON for attaching
+// to bug reports; commands and connection strings in it are redacted.
+type DiagnosticsSnapshot struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Version   string      `json:"version"`
+	State     string      `json:"state"`
+	DebugMode bool        `json:"debugMode"`
+	Server    *ServerInfo `json:"server,omitempty"`
+
+	// Pool is set in pooled mode, Connection in single-connection mode
+	Pool       *PoolDiagnostics       `json:"pool,omitempty"`
+	Connection *ConnectionDiagnostics `json:"connection,omitempty"`
+
+	Limiter      *LimiterStats      `json:"limiter,omitempty"`
+	LoadShedding *LoadSheddingStats `json:"loadShedding,omitempty"`
+	Hedging      *HedgeStats        `json:"hedging,omitempty"`
+
+	// StateHistory lists the recorded state transitions, oldest first
+	StateHistory []TransitionDiagnostics `json:"stateHistory"`
+
+	Transactions []TransactionInfo       `json:"transactions"`
+	Statements   StatementDiagnostics    `json:"statements"`
+	Hooks        []HookDiagnostics       `json:"hooks"`
+	Middleware   int                     `json:"middleware"` // Number installed with Use
+	SchemaCache  *SchemaCacheDiagnostics `json:"schemaCache,omitempty"`
+
+	// RecentErrors lists the most recent failed commands, oldest first
+	RecentErrors []ErrorDiagnostics `json:"recentErrors"`
+}
+
+// PoolDiagnostics is a snapshot of the connection pool counters.
+type PoolDiagnostics struct {
+	ActiveConnections int32         `json:"activeConnections"`
+	IdleConnections   int32         `json:"idleConnections"`
+	TotalConnections  int32         `json:"totalConnections"`
+	WaitCount         int64         `json:"waitCount"`
+	WaitDuration      time.Duration `json:"waitDuration"`
+	Hits              int64         `json:"hits"`
+	Misses            int64         `json:"misses"`
+	Timeouts          int64         `json:"timeouts"`
+	Errors            int64         `json:"errors"`
+	Refills           int64         `json:"refills"`
+	Recycled          int64         `json:"recycled"`
+	ProbeFailures     int64         `json:"probeFailures"`
+}
+
+// ConnectionDiagnostics describes the connection of a single-connection client.
+type ConnectionDiagnostics struct {
+	RemoteAddr   string    `json:"remoteAddr"`
+	Alive        bool      `json:"alive"`
+	LastActivity time.Time `json:"lastActivity"`
+	TLS          bool      `json:"tls"`
+	Compression  string    `json:"compression,omitempty"`
+	Database     string    `json:"database,omitempty"`
+}
+
+// TransitionDiagnostics is a StateTransition with its error as text.
+type TransitionDiagnostics struct {
+	From      string                 `json:"from"`
+	To        string                 `json:"to"`
+	Timestamp time.Time              `json:"timestamp"`
+	Duration  time.Duration          `json:"duration"`
+	Error     string                 `json:"error,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// StatementDiagnostics lists the prepared statement cache.
+type StatementDiagnostics struct {
+	Stats   CacheStats          `json:"stats"`
+	Entries []StatementSnapshot `json:"entries"` // Least recently used first
+}
+
+// StatementSnapshot describes one cached prepared statement.
+type StatementSnapshot struct {
+	Name       string    `json:"name"`
+	Query      string    `json:"query"`
+	ParamCount int       `json:"paramCount"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// HookDiagnostics describes a registered hook, in execution order.
+type HookDiagnostics struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Enabled  bool   `json:"enabled"`
+	Filtered bool   `json:"filtered"` // Whether a HookFilter scopes the hook
+}
+
+// SchemaCacheDiagnostics describes the schema cached for validation.
+type SchemaCacheDiagnostics struct {
+	Cached bool          `json:"cached"`
+	Age    time.Duration `json:"age"`
+	TTL    time.Duration `json:"ttl"`
+}
+
+// ErrorDiagnostics describes a failed command.
+type ErrorDiagnostics struct {
+	Timestamp time.Time     `json:"timestamp"`
+	TraceID   string        `json:"traceId"`
+	Command   string        `json:"command"`
+	Code      string        `json:"code,omitempty"`
+	Error     string        `json:"error"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// errorLog keeps the most recent failed commands in a ring buffer.
+type errorLog struct {
+	mu      sync.Mutex
+	entries []ErrorDiagnostics
+	next    int
+}
+
+// record adds the failed command described by hookCtx.
+func (l *errorLog) record(hookCtx *HookContext) {
+	entry := ErrorDiagnostics{
+		Timestamp: time.Now(),
+		TraceID:   hookCtx.TraceID,
+		Command:   redactHandshake(hookCtx.Command),
+		Code:      ErrorCode(hookCtx.Error),
+		Error:     hookCtx.Error.Error(),
+		Duration:  hookCtx.Duration,
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) < recentErrorsSize {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % recentErrorsSize
+}
+
+// snapshot returns the recorded failures, oldest first.
+func (l *errorLog) snapshot() []ErrorDiagnostics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]ErrorDiagnostics, 0, len(l.entries))
+	entries = append(entries, l.entries[l.next:]...)
+	return append(entries, l.entries[:l.next]...)
+}
+
+// DiagnosticsSnapshot collects pool statistics, recent state transitions,
+// open transactions, cached statements, registered hooks, the schema cache
+// and recent errors into one value. It is a fuller, typed form of
+// GetDebugInfo; use its JSON method to attach it to a bug report.
+func (c *Client) DiagnosticsSnapshot() *DiagnosticsSnapshot {
+	snapshot := &DiagnosticsSnapshot{
+		Timestamp:    time.Now(),
+		Version:      Version,
+		State:        c.GetState().String(),
+		DebugMode:    c.IsDebugMode(),
+		Server:       c.ServerInfo(),
+		Transactions: c.ActiveTransactions(),
+		RecentErrors: c.recentErrors.snapshot(),
+	}
+
+	if c.poolEnabled && c.pool != nil {
+		stats := c.pool.Stats()
+		snapshot.Pool = &PoolDiagnostics{
+			ActiveConnections: stats.ActiveConnections.Load(),
+			IdleConnections:   stats.IdleConnections.Load(),
+			TotalConnections:  stats.TotalConnections.Load(),
+			WaitCount:         stats.WaitCount.Load(),
+			WaitDuration:      time.Duration(stats.WaitDuration.Load()),
+			Hits:              stats.Hits.Load(),
+			Misses:            stats.Misses.Load(),
+			Timeouts:          stats.Timeouts.Load(),
+			Errors:            stats.Errors.Load(),
+			Refills:           stats.Refills.Load(),
+			Recycled:          stats.Recycled.Load(),
+			ProbeFailures:     stats.ProbeFailures.Load(),
+		}
+	} else if c.conn != nil {
+		snapshot.Connection = &ConnectionDiagnostics{
+			RemoteAddr:   c.conn.RemoteAddr(),
+			Alive:        c.conn.IsAlive(),
+			LastActivity: c.conn.LastActivity(),
+			TLS:          c.conn.GetTLSConnectionState() != nil,
+			Compression:  string(c.conn.Compression()),
+			Database:     c.conn.currentDatabase(),
+		}
+	}
+
+	if c.limiter != nil {
+		stats := c.limiter.stats()
+		snapshot.Limiter = &stats
+	}
+	if c.shedder != nil {
+		stats := c.shedder.stats()
+		snapshot.LoadShedding = &stats
+	}
+	if hedges := c.HedgeStats(); hedges.Eligible > 0 {
+		snapshot.Hedging = &hedges
+	}
+
+	for _, transition := range c.stateMgr.GetStateHistory(0) {
+		entry := TransitionDiagnostics{
+			From:      transition.From.String(),
+			To:        transition.To.String(),
+			Timestamp: transition.Timestamp,
+			Duration:  transition.Duration,
+			Metadata:  redactTransitionMetadata(transition.Metadata),
+		}
+		if transition.Error != nil {
+			entry.Error = c.redaction.redactError(transition.Error).Error()
+		}
+		snapshot.StateHistory = append(snapshot.StateHistory, entry)
+	}
+
+	if c.stmtCache != nil {
+		snapshot.Statements.Stats = c.stmtCache.Stats()
+		for _, stmt := range c.stmtCache.Statements() {
+			snapshot.Statements.Entries = append(snapshot.Statements.Entries, StatementSnapshot{
+				Name:       stmt.name,
+				Query:      c.redaction.RedactCommand(stmt.query),
+				ParamCount: stmt.paramCount,
+				CreatedAt:  stmt.createdAt,
+			})
+		}
+	}
+
+	c.hooksMu.RLock()
+	for _, entry := range c.hooks {
+		snapshot.Hooks = append(snapshot.Hooks, HookDiagnostics{
+			Name:     entry.hook.Name(),
+			Priority: entry.priority,
+			Enabled:  !entry.disabled,
+			Filtered: entry.filter != nil,
+		})
+	}
+	c.hooksMu.RUnlock()
+	snapshot.Middleware = c.MiddlewareCount()
+
+	if c.schemaValidator != nil {
+		age, cached := c.schemaValidator.cacheAge()
+		snapshot.SchemaCache = &SchemaCacheDiagnostics{
+			Cached: cached,
+			Age:    age,
+			TTL:    c.schemaValidator.cacheTTL,
+		}
+	}
+
+	return snapshot
+}
+
+// redactTransitionMetadata returns a copy of metadata with the password in
+// any connection string masked.
+func redactTransitionMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		if connStr, ok := value.(string); ok && key == "connectionString" {
+			value = redactHandshake(connStr)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// JSON returns the snapshot as indented JSON.
+func (s *DiagnosticsSnapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestDiagnosticsSnapshot verifies the snapshot reports the connection, state
+// history, hooks and failed commands, and marshals to JSON.
+func TestDiagnosticsSnapshot(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		if strings.HasPrefix(command, "SELECT") {
+			return `{"status":"ok"}`
+		}
+		return `{"status":"error","message":"bundle not found"}`
+	})
+	c.RegisterHookWithPriority(NewMetricsHook(), 5)
+	c.DisableHook("metrics")
+
+	ctx := context.Background()
+	if _, err := c.sendCommand(ctx, `SELECT * FROM BUNDLE "users";`); err != nil {
+		t.Fatalf("sendCommand failed: %v", err)
+	}
+	if _, err := c.sendCommand(WithTraceID(ctx, "trace-9"), `DELETE DOCUMENTS FROM BUNDLE "missing" CONFIRMED;`); err == nil {
+		t.Fatal("expected the delete to fail")
+	}
+
+	snapshot := c.DiagnosticsSnapshot()
+	if snapshot.State != "CONNECTED" || snapshot.Connection == nil || !snapshot.Connection.Alive {
+		t.Errorf("unexpected connection state: %+v", snapshot)
+	}
+	if len(snapshot.StateHistory) != 2 || snapshot.StateHistory[0].To != "CONNECTING" || snapshot.StateHistory[1].To != "CONNECTED" {
+		t.Errorf("unexpected state history: %+v", snapshot.StateHistory)
+	}
+	if len(snapshot.Hooks) != 1 || snapshot.Hooks[0] != (HookDiagnostics{Name: "metrics", Priority: 5}) {
+		t.Errorf("unexpected hooks: %+v", snapshot.Hooks)
+	}
+	if len(snapshot.RecentErrors) != 1 {
+		t.Fatalf("expected 1 recent error, got %+v", snapshot.RecentErrors)
+	}
+	if failure := snapshot.RecentErrors[0]; failure.TraceID != "trace-9" || !strings.HasPrefix(failure.Command, "DELETE") || !strings.Contains(failure.Error, "bundle not found") {
+		t.Errorf("unexpected recent error: %+v", failure)
+	}
+
+	data, err := snapshot.JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, key := range []string{"stateHistory", "transactions", "statements", "hooks", "recentErrors", "connection"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("expected %q in the JSON, got %s", key, data)
+		}
+	}
+}
+
+// TestDiagnosticsRedactsConnectionString verifies passwords in transition
+// metadata are masked.
+func TestDiagnosticsRedactsConnectionString(t *testing.T) {
+	c := NewClient(&ClientOptions{Logger: NewNoopLogger()})
+	c.stateMgr.TransitionTo(CONNECTING, nil, map[string]interface{}{
+		"connectionString": "syndrdb://localhost:1776:primary:root:secret;",
+	})
+
+	data, err := c.DiagnosticsSnapshot().JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("expected the password to be masked, got %s", data)
+	}
+}
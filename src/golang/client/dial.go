@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// lookupIPAddr resolves server hostnames. It is a variable so tests can
+// substitute DNS answers.
+var lookupIPAddr = net.DefaultResolver.LookupIPAddr
+
+// dialServer opens a TCP connection to address, a host:port. A hostname is
+// resolved on every call, so reconnects follow DNS changes such as a moved
+// Kubernetes service or a DNS-based failover, and each resolved address is
+// tried in turn until one accepts. Every address gets DialAddressTimeout, or
+// an even share of what remains of the connect timeout. It returns the
+// addresses tried. With a custom Dialer, resolution is left to the Dialer.
+// An address made by UnixAddress dials the socket, with network "unix".
+func dialServer(ctx context.Context, address string, opts ClientOptions) (net.Conn, []string, error) {
+	timeout := time.Duration(opts.DefaultTimeoutMs) * time.Millisecond
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if path, ok := unixSocketPath(address); ok {
+		if opts.Dialer != nil {
+			conn, err := opts.Dialer(ctx, "unix", path)
+			return conn, []string{path}, err
+		}
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "unix", path)
+		return conn, []string{path}, err
+	}
+	if opts.Dialer != nil {
+		conn, err := opts.Dialer(ctx, "tcp", address)
+		return conn, []string{address}, err
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", address)
+		return conn, []string{address}, err
+	}
+
+	ips, err := lookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ips) == 0 {
+		return nil, nil, fmt.Errorf("no addresses found for %s", host)
+	}
+
+	tried := make([]string, 0, len(ips))
+	var errs []error
+	for i, ip := range ips {
+		target := net.JoinHostPort(ip.String(), port)
+		tried = append(tried, target)
+
+		d := net.Dialer{Timeout: opts.DialAddressTimeout}
+		if d.Timeout <= 0 {
+			if deadline, ok := ctx.Deadline(); ok {
+				d.Timeout = time.Until(deadline) / time.Duration(len(ips)-i)
+			}
+		}
+		conn, err := d.DialContext(ctx, "tcp", target)
+		if err == nil {
+			return conn, tried, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", target, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, tried, errors.Join(errs...)
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stubDNS answers lookups for the test's hostnames from answers until the
+// test ends.
+func stubDNS(t *testing.T, answers func(host string) []string) {
+	t.Helper()
+	original := lookupIPAddr
+	lookupIPAddr = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		var addrs []net.IPAddr
+		for _, ip := range answers(host) {
+			addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+		}
+		if addrs == nil {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return addrs, nil
+	}
+	t.Cleanup(func() { lookupIPAddr = original })
+}
+
+// listenOn accepts connections on ip and returns the port.
+func listenOn(t *testing.T, ip, port string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", net.JoinHostPort(ip, port))
+	if err != nil {
+		t.Skipf("cannot listen on %s: %v", ip, err)
+	}
+	var mu sync.Mutex
+	var accepted []net.Conn
+	t.Cleanup(func() {
+		ln.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range accepted {
+			conn.Close()
+		}
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			accepted = append(accepted, conn)
+			mu.Unlock()
+		}
+	}()
+	_, port, _ = net.SplitHostPort(ln.Addr().String())
+	return port
+}
+
+// TestDialServerTriesEachAddress verifies an unreachable address is skipped.
+func TestDialServerTriesEachAddress(t *testing.T) {
+	port := listenOn(t, "127.0.0.1", "0")
+	stubDNS(t, func(host string) []string { return []string{"127.0.0.2", "127.0.0.1"} })
+
+	conn, tried, err := dialServer(context.Background(), "db.test:"+port, DefaultOptions())
+	if err != nil {
+		t.Fatalf("dialServer failed: %v", err)
+	}
+	defer conn.Close()
+	if want := []string{"127.0.0.2:" + port, "127.0.0.1:" + port}; !reflect.DeepEqual(tried, want) {
+		t.Errorf("tried %v, want %v", tried, want)
+	}
+	if got := conn.RemoteAddr().String(); got != "127.0.0.1:"+port {
+		t.Errorf("connected to %s", got)
+	}
+}
+
+// TestDialServerReresolves verifies every connection resolves the hostname
+// again, so a reconnect follows the service to its new address.
+func TestDialServerReresolves(t *testing.T) {
+	port := listenOn(t, "127.0.0.1", "0")
+	listenOn(t, "127.0.0.3", port)
+	current := "127.0.0.1"
+	stubDNS(t, func(host string) []string { return []string{current} })
+
+	for _, ip := range []string{"127.0.0.1", "127.0.0.3"} {
+		current = ip
+		conn, _, err := dialServer(context.Background(), "db.test:"+port, DefaultOptions())
+		if err != nil {
+			t.Fatalf("dialServer failed: %v", err)
+		}
+		if got := conn.RemoteAddr().String(); got != net.JoinHostPort(ip, port) {
+			t.Errorf("connected to %s, want %s", got, ip)
+		}
+		conn.Close()
+	}
+}
+
+// TestNewConnectionAllAddressesFail verifies the error lists every address tried.
+func TestNewConnectionAllAddressesFail(t *testing.T) {
+	stubDNS(t, func(host string) []string {
+		if host == "db.test" {
+			return []string{"127.0.0.2", "127.0.0.4"}
+		}
+		return nil
+	})
+
+	_, err := NewConnection(context.Background(), "db.test:1", DefaultOptions())
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) || !errors.Is(err, ErrConnectionFailed) {
+		t.Fatalf("expected a connection error, got %v", err)
+	}
+	if want := []string{"127.0.0.2:1", "127.0.0.4:1"}; !reflect.DeepEqual(connErr.Details["addresses"], want) {
+		t.Errorf("expected addresses %v, got %v", want, connErr.Details["addresses"])
+	}
+	if cause := connErr.Cause.Error(); !strings.Contains(cause, "127.0.0.2:1") || !strings.Contains(cause, "127.0.0.4:1") {
+		t.Errorf("expected each address's failure in the cause, got %q", cause)
+	}
+
+	_, err = NewConnection(context.Background(), "missing.test:1", DefaultOptions())
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		t.Errorf("expected the DNS error as the cause, got %v", err)
+	}
+}
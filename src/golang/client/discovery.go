@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Discoverer finds the members of a SyndrDB cluster. Set it as
+// ClientOptions.Discoverer to connect to whichever members it returns
+// instead of the connection string's host.
+type Discoverer interface {
+	// Discover returns the members' host:port addresses in order of preference.
+	Discover(ctx context.Context) ([]string, error)
+}
+
+// DiscovererFunc adapts a function to the Discoverer interface, e.g. to
+// read members from a service registry.
+type DiscovererFunc func(ctx context.Context) ([]string, error)
+
+// Discover calls f.
+func (f DiscovererFunc) Discover(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// lookupSRV resolves SRV records. It is a variable so tests can substitute
+// DNS answers.
+var lookupSRV = net.DefaultResolver.LookupSRV
+
+// SRVDiscoverer finds cluster members from the _syndrdb._tcp SRV records of
+// Domain, ordered by priority and, within a priority, randomly by weight.
+type SRVDiscoverer struct {
+	Domain string
+
+	// Service and Proto name the records to look up.
+	// Default: "syndrdb" and "tcp"
+	Service string
+	Proto   string
+}
+
+// NewSRVDiscoverer returns a discoverer for the _syndrdb._tcp records of domain.
+func NewSRVDiscoverer(domain string) *SRVDiscoverer {
+	return &SRVDiscoverer{Domain: domain}
+}
+
+// Discover looks up the SRV records.
+func (d *SRVDiscoverer) Discover(ctx context.Context) ([]string, error) {
+	service, proto := d.Service, d.Proto
+	if service == "" {
+		service = "syndrdb"
+	}
+	if proto == "" {
+		proto = "tcp"
+	}
+
+	_, records, err := lookupSRV(ctx, service, proto, d.Domain)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(records))
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		members = append(members, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+	return members, nil
+}
+
+// ClusterMembers returns the members last found by ClientOptions.Discoverer,
+// or nil when discovery is not used.
+func (c *Client) ClusterMembers() []string {
+	c.membersMu.RLock()
+	defer c.membersMu.RUnlock()
+	return slices.Clone(c.members)
+}
+
+// discoverMembers asks the discoverer for the cluster members and keeps them
+// if it found any, logging changes.
+func (c *Client) discoverMembers(ctx context.Context) error {
+	members, err := c.opts.Discoverer.Discover(ctx)
+	if err == nil && len(members) == 0 {
+		err = errors.New("no cluster members found")
+	}
+	if err != nil {
+		return &ConnectionError{
+			Code:    "DISCOVERY_FAILED",
+			Type:    "CONNECTION_ERROR",
+			Message: "failed to discover cluster members",
+			Cause:   err,
+		}
+	}
+
+	c.membersMu.Lock()
+	changed := !slices.Equal(c.members, members)
+	previous := c.members
+	c.members = members
+	c.membersMu.Unlock()
+
+	if changed && previous != nil {
+		c.logger.Info("cluster members changed",
+			String("members", strings.Join(members, ",")),
+			String("previous", strings.Join(previous, ",")))
+	}
+	return nil
+}
+
+// connectMember opens and authenticates a connection to one of the cluster
+// members. Successive connections start at successive members, so a pool is
+// spread across the cluster, and an unreachable member is skipped.
+func (c *Client) connectMember(ctx context.Context, connStr string) (ConnectionInterface, error) {
+	members := c.ClusterMembers()
+	start := int(c.nextMember.Add(1) - 1)
+
+	var errs []error
+	for i := range members {
+		member := members[(start+i)%len(members)]
+		conn, err := c.createAndAuthenticateConnection(ctx, member, connStr)
+		if err == nil {
+			return conn, nil
+		}
+		if errors.Is(err, ErrAuthFailed) {
+			// Every member would reject the same credentials
+			return nil, err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", member, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, &ConnectionError{
+		Code:    "CONNECTION_FAILED",
+		Type:    "CONNECTION_ERROR",
+		Message: "failed to connect to any cluster member",
+		Details: map[string]interface{}{"members": members},
+		Cause:   errors.Join(errs...),
+	}
+}
+
+// startDiscoveryRefresh refreshes the cluster members every
+// DiscoveryRefreshInterval until done is closed. When a refresh fails, the
+// members found last are kept.
+func (c *Client) startDiscoveryRefresh(done <-chan struct{}) {
+	interval := c.opts.DiscoveryRefreshInterval
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				err := c.discoverMembers(ctx)
+				cancel()
+				if err != nil {
+					c.logger.Warn("cluster member refresh failed, keeping previous members",
+						Error("error", err))
+				}
+			}
+		}
+	}()
+}
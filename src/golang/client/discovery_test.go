@@ -0,0 +1,156 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// TestSRVDiscoverer verifies SRV records become host:port members in order.
+func TestSRVDiscoverer(t *testing.T) {
+	original := lookupSRV
+	t.Cleanup(func() { lookupSRV = original })
+	lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		if service != "syndrdb" || proto != "tcp" || name != "cluster.example.com" {
+			return "", nil, fmt.Errorf("unexpected lookup _%s._%s.%s", service, proto, name)
+		}
+		return "", []*net.SRV{
+			{Target: "db1.cluster.example.com.", Port: 1776, Priority: 10},
+			{Target: "db2.cluster.example.com.", Port: 1777, Priority: 20},
+		}, nil
+	}
+
+	members, err := NewSRVDiscoverer("cluster.example.com").Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	want := []string{"db1.cluster.example.com:1776", "db2.cluster.example.com:1777"}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("got %v, want %v", members, want)
+	}
+}
+
+// clusterDialer simulates cluster members that accept any credentials and
+// answer every command with their own address. Addresses in down refuse
+// connections.
+type clusterDialer struct {
+	mu     sync.Mutex
+	dialed []string
+	down   map[string]bool
+}
+
+func (d *clusterDialer) dial(ctx context.Context, network, address string) (net.Conn, error) {
+	d.mu.Lock()
+	d.dialed = append(d.dialed, address)
+	d.mu.Unlock()
+	if d.down[address] {
+		return nil, errors.New("connection refused")
+	}
+
+	clientSide, serverSide := net.Pipe()
+	go func() {
+		defer serverSide.Close()
+		reader := bufio.NewReader(serverSide)
+		for first := true; ; first = false {
+			if _, err := reader.ReadString('\x04'); err != nil {
+				return
+			}
+			response := fmt.Sprintf(`{"member":%q}`, address) + "\n"
+			if first {
+				response = "S0001::Welcome\n" + `{"status":"success"}` + "\n"
+			}
+			if _, err := serverSide.Write([]byte(response)); err != nil {
+				return
+			}
+		}
+	}()
+	return clientSide, nil
+}
+
+func (d *clusterDialer) attempts() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.dialed...)
+}
+
+// TestDiscoveredMembers verifies connections go to discovered members in
+// turn, skipping unreachable ones, and that refreshes replace the members.
+func TestDiscoveredMembers(t *testing.T) {
+	dialer := &clusterDialer{down: map[string]bool{"db2:1776": true}}
+	members := []string{"db1:1776", "db2:1776", "db3:1776"}
+	var membersMu sync.Mutex
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.Dialer = dialer.dial
+	opts.DiscoveryRefreshInterval = 0
+	opts.Discoverer = DiscovererFunc(func(ctx context.Context) ([]string, error) {
+		membersMu.Lock()
+		defer membersMu.Unlock()
+		return members, nil
+	})
+	c := NewClient(&opts)
+	if err := c.Connect(context.Background(), "syndrdb://ignored:1776:primary:root:root;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer c.Disconnect(context.Background())
+
+	result, err := c.Query(`SELECT * FROM BUNDLE "users";`, 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if member := result.(map[string]interface{})["member"]; member != "db1:1776" {
+		t.Errorf("expected the first member, got %v", member)
+	}
+
+	// The next connections start at db2, which is down, and then at db3
+	for _, want := range []string{"db3:1776", "db3:1776", "db1:1776"} {
+		conn, err := c.connectMember(context.Background(), c.connStr)
+		if err != nil {
+			t.Fatalf("connectMember failed: %v", err)
+		}
+		attempts := dialer.attempts()
+		if got := attempts[len(attempts)-1]; got != want {
+			t.Errorf("expected a connection to %s, got %s", want, got)
+		}
+		conn.Close()
+	}
+
+	membersMu.Lock()
+	members = []string{"db2:1776"}
+	membersMu.Unlock()
+	if err := c.discoverMembers(context.Background()); err != nil {
+		t.Fatalf("discoverMembers failed: %v", err)
+	}
+	if got := c.ClusterMembers(); !reflect.DeepEqual(got, []string{"db2:1776"}) {
+		t.Errorf("expected refreshed members, got %v", got)
+	}
+	_, err = c.connectMember(context.Background(), c.connStr)
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) || !reflect.DeepEqual(connErr.Details["members"], []string{"db2:1776"}) {
+		t.Errorf("expected a failure naming the members, got %v", err)
+	}
+}
+
+// TestDiscoveryFailure verifies Connect fails when no members are found.
+func TestDiscoveryFailure(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.Discoverer = DiscovererFunc(func(ctx context.Context) ([]string, error) {
+		return nil, nil
+	})
+	c := NewClient(&opts)
+
+	err := c.Connect(context.Background(), "syndrdb://ignored:1776:primary:root:root;")
+	if ErrorCode(err) != "DISCOVERY_FAILED" || !errors.Is(err, ErrConnectionFailed) {
+		t.Errorf("expected DISCOVERY_FAILED, got %v", err)
+	}
+	if c.GetState() != DISCONNECTED {
+		t.Errorf("expected DISCONNECTED, got %v", c.GetState())
+	}
+}
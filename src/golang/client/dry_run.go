@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DryRun reports how many documents Execute would modify, without modifying
+// any. It issues the equivalent SELECT COUNT(*) and caps the count at Limit.
+func (ub *UpdateBuilder) DryRun(ctx context.Context) (int, error) {
+	if err := validateMutationTarget(ub.bundle, ub.whereClauses, "UPDATE"); err != nil {
+		return 0, err
+	}
+	if err := ub.validateVersion(); err != nil {
+		return 0, err
+	}
+	scoped, err := ub.withTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	_, whereClauses := scoped.versionedClauses()
+	return ub.client.countMatching(ctx, ub.queryOptions, ub.bundle, whereClauses, ub.limitVal)
+}
+
+// DryRun reports how many documents Execute would remove, without removing
+// any. It issues the equivalent SELECT COUNT(*) and caps the count at Limit.
+func (db *DeleteBuilder) DryRun(ctx context.Context) (int, error) {
+	if err := validateMutationTarget(db.bundle, db.whereClauses, "DELETE"); err != nil {
+		return 0, err
+	}
+	scoped, err := db.withTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return db.client.countMatching(ctx, db.queryOptions, db.bundle, scoped.whereClauses, db.limitVal)
+}
+
+// validateMutationTarget checks the bundle and WHERE clause required by UPDATE and DELETE.
+func validateMutationTarget(bundle string, clauses []whereClause, verb string) error {
+	if bundle == "" {
+		return &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: "bundle name is required",
+		}
+	}
+	if len(clauses) == 0 {
+		return &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("WHERE clause required for %s (use Where() to specify conditions)", verb),
+		}
+	}
+	return nil
+}
+
+// countMatching counts the documents in bundle matching clauses, capped at limit.
+func (c *Client) countMatching(ctx context.Context, opts queryOptions, bundle string, clauses []whereClause, limit *int) (int, error) {
+	clauses, err := c.codecs.marshalWhere(bundle, clauses)
+	if err != nil {
+		return 0, err
+	}
+	clauses, err = c.encryptWhere(bundle, clauses)
+	if err != nil {
+		return 0, err
+	}
+	query := buildCountQuery(bundle, clauses)
+
+	ctx, cancel := opts.apply(ctx, c)
+	defer cancel()
+	result, err := c.executeWithTimeout(ctx, query, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	count, ok := parseCount(result)
+	if !ok {
+		return 0, &QueryError{
+			Code:    "E_COUNT_PARSE_FAILED",
+			Type:    "QueryError",
+			Message: "could not read a count from the server response",
+			Details: map[string]interface{}{
+				"response": result,
+			},
+			Query: query,
+		}
+	}
+
+	if limit != nil && *limit >= 0 && count > *limit {
+		count = *limit
+	}
+	return count, nil
+}
+
+// buildCountQuery constructs the SELECT COUNT(*) matching an UPDATE or DELETE.
+func buildCountQuery(bundle string, clauses []whereClause) string {
+	var query strings.Builder
+	query.WriteString("SELECT COUNT(*) FROM \"")
+	query.WriteString(bundle)
+	query.WriteString("\"")
+	writeMutationWhere(&query, clauses)
+	query.WriteString(";")
+	return query.String()
+}
+
+// parseCount extracts a count from a COUNT response: a bare number, a
+// {"count": n} object, or a single-row result containing either.
+func parseCount(result interface{}) (int, bool) {
+	switch v := result.(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	case map[string]interface{}:
+		for key, value := range v {
+			if strings.EqualFold(key, "count") || strings.EqualFold(key, "COUNT(*)") {
+				return parseCount(value)
+			}
+		}
+	case []interface{}:
+		if len(v) == 1 {
+			return parseCount(v[0])
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestMutationLimit verifies Limit is rendered for UPDATE and DELETE.
+func TestMutationLimit(t *testing.T) {
+	c := NewClient(nil)
+
+	query, _ := c.UpdateBuilder("users").Set("status", "inactive").Where("age", GreaterThan, 90).Limit(100).buildUpdateQuery()
+	if !strings.HasSuffix(query, `WHERE "age" > 90 LIMIT 100;`) {
+		t.Errorf("unexpected update query: %s", query)
+	}
+
+	query, _ = c.DeleteBuilder("users").Where("status", Equals, "inactive").Limit(10).buildDeleteQuery()
+	if query != `DELETE DOCUMENTS FROM "users" WHERE "status" == "inactive" LIMIT 10;` {
+		t.Errorf("unexpected delete query: %s", query)
+	}
+}
+
+// TestDryRun verifies DryRun issues a COUNT, caps it at Limit and modifies nothing.
+func TestDryRun(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"success","data":[{"count":250}]}`
+	})
+	ctx := context.Background()
+
+	count, err := c.DeleteBuilder("users").Where("status", Equals, "inactive").DryRun(ctx)
+	if err != nil || count != 250 {
+		t.Fatalf("expected 250, got %d (%v)", count, err)
+	}
+
+	count, err = c.UpdateBuilder("users").Set("status", "archived").
+		Where("status", Equals, "inactive").And("age", GreaterThan, 90).
+		Limit(100).DryRun(ctx)
+	if err != nil || count != 100 {
+		t.Fatalf("expected count capped at 100, got %d (%v)", count, err)
+	}
+
+	want := []string{
+		`SELECT COUNT(*) FROM "users" WHERE "status" == "inactive";`,
+		`SELECT COUNT(*) FROM "users" WHERE "status" == "inactive" AND "age" > 90;`,
+	}
+	got := server.received()
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("unexpected commands: %v", got)
+	}
+
+	if _, err := c.DeleteBuilder("users").DryRun(ctx); ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("expected E_INVALID_QUERY without WHERE, got %v", err)
+	}
+}
+
+// TestParseCount verifies the supported COUNT response shapes.
+func TestParseCount(t *testing.T) {
+	for _, result := range []interface{}{
+		float64(7),
+		map[string]interface{}{"COUNT(*)": float64(7)},
+		[]interface{}{map[string]interface{}{"Count": float64(7)}},
+	} {
+		if count, ok := parseCount(result); !ok || count != 7 {
+			t.Errorf("parseCount(%v) = %d, %v", result, count, ok)
+		}
+	}
+	if _, ok := parseCount([]interface{}{float64(1), float64(2)}); ok {
+		t.Error("expected multi-row results to be rejected")
+	}
+}
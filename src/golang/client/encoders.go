@@ -0,0 +1,322 @@
+package client
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ResultSet is a source of result rows delivered in batches, such as a
+// *RowStream from QueryStream. NextBatch returns nil once the rows are
+// exhausted, after which Err reports any error that ended them early.
+type ResultSet interface {
+	NextBatch() []interface{}
+	Err() error
+}
+
+// NewResultSet returns a ResultSet over the rows of a query response already
+// in memory, e.g. the result of Query or QueryBuilder.Execute.
+func NewResultSet(result interface{}) *RowStream {
+	return NewRowStream(resultRows(result), 0)
+}
+
+// EncodeOption configures EncodeCSV, EncodeNDJSON and the row encoders.
+type EncodeOption func(*encodeOptions)
+
+type encodeOptions struct {
+	columns        []string
+	noHeader       bool
+	timeFormat     string
+	nullString     string
+	floatPrecision int
+	delimiter      rune
+	onSkipped      func(field string)
+}
+
+// WithColumns sets the fields encoded and their order. For CSV they are the
+// columns; for NDJSON other fields are dropped. By default CSV uses the
+// sorted fields of the first row and NDJSON encodes every field.
+func WithColumns(columns ...string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.columns = columns
+	}
+}
+
+// WithoutHeader omits the CSV header row.
+func WithoutHeader() EncodeOption {
+	return func(o *encodeOptions) {
+		o.noHeader = true
+	}
+}
+
+// WithTimeFormat sets the layout time.Time values are formatted with.
+// The default is time.RFC3339Nano.
+func WithTimeFormat(layout string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.timeFormat = layout
+	}
+}
+
+// WithNullString sets the CSV cell written for missing and null values.
+// The default is an empty cell.
+func WithNullString(s string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.nullString = s
+	}
+}
+
+// WithFloatPrecision sets the number of decimals of floating-point CSV cells.
+// The default, -1, uses the fewest digits that represent the value exactly.
+func WithFloatPrecision(decimals int) EncodeOption {
+	return func(o *encodeOptions) {
+		o.floatPrecision = decimals
+	}
+}
+
+// WithDelimiter sets the CSV field delimiter. The default is a comma.
+func WithDelimiter(delimiter rune) EncodeOption {
+	return func(o *encodeOptions) {
+		o.delimiter = delimiter
+	}
+}
+
+// OnSkippedField calls fn once for each field that is not encoded because
+// it is not among the CSV columns: fields first seen after the header was
+// written, or missing from WithColumns.
+func OnSkippedField(fn func(field string)) EncodeOption {
+	return func(o *encodeOptions) {
+		o.onSkipped = fn
+	}
+}
+
+func newEncodeOptions(opts []EncodeOption) encodeOptions {
+	options := encodeOptions{
+		timeFormat:     time.RFC3339Nano,
+		floatPrecision: -1,
+		delimiter:      ',',
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// rowDocument returns row as a document; rows that are not documents are
+// encoded as a document with a single "value" field.
+func rowDocument(row interface{}) map[string]interface{} {
+	if doc, ok := row.(map[string]interface{}); ok {
+		return doc
+	}
+	return map[string]interface{}{"value": row}
+}
+
+// CSVEncoder writes rows as CSV, one row at a time.
+type CSVEncoder struct {
+	writer  *csv.Writer
+	opts    encodeOptions
+	columns []string
+	known   map[string]bool
+	skipped map[string]bool
+}
+
+// NewCSVEncoder returns an encoder writing CSV to w. Call Flush after the
+// last row.
+func NewCSVEncoder(w io.Writer, opts ...EncodeOption) *CSVEncoder {
+	e := &CSVEncoder{
+		writer:  csv.NewWriter(w),
+		opts:    newEncodeOptions(opts),
+		skipped: make(map[string]bool),
+	}
+	e.writer.Comma = e.opts.delimiter
+	return e
+}
+
+// Encode writes row, preceded by the header row if it is the first.
+func (e *CSVEncoder) Encode(row interface{}) error {
+	doc := rowDocument(row)
+	if e.columns == nil {
+		columns := e.opts.columns
+		if columns == nil {
+			columns = make([]string, 0, len(doc))
+			for field := range doc {
+				columns = append(columns, field)
+			}
+			sort.Strings(columns)
+		}
+		if err := e.writeHeader(columns); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		record[i] = e.cell(doc[column])
+	}
+	for field := range doc {
+		if !e.known[field] && !e.skipped[field] {
+			e.skipped[field] = true
+			if e.opts.onSkipped != nil {
+				e.opts.onSkipped(field)
+			}
+		}
+	}
+	return e.writer.Write(record)
+}
+
+// Flush writes buffered rows to the underlying writer. With WithColumns,
+// the header is written even if no row was.
+func (e *CSVEncoder) Flush() error {
+	if e.columns == nil && e.opts.columns != nil {
+		if err := e.writeHeader(e.opts.columns); err != nil {
+			return err
+		}
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// writeHeader fixes the columns and writes the header row, unless disabled.
+func (e *CSVEncoder) writeHeader(columns []string) error {
+	e.columns = columns
+	e.known = make(map[string]bool, len(columns))
+	for _, column := range columns {
+		e.known[column] = true
+	}
+	if e.opts.noHeader {
+		return nil
+	}
+	return e.writer.Write(columns)
+}
+
+// cell renders a value for a CSV cell; nested values are JSON-encoded.
+func (e *CSVEncoder) cell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return e.opts.nullString
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', e.opts.floatPrecision, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', e.opts.floatPrecision, 32)
+	case int, int64, int32, uint, uint64, uint32, json.Number:
+		return fmt.Sprint(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(e.opts.timeFormat)
+	case *time.Time:
+		if v == nil {
+			return e.opts.nullString
+		}
+		return v.Format(e.opts.timeFormat)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// NDJSONEncoder writes rows as newline-delimited JSON, one row at a time.
+type NDJSONEncoder struct {
+	encoder *json.Encoder
+	opts    encodeOptions
+}
+
+// NewNDJSONEncoder returns an encoder writing one JSON document per line to w.
+func NewNDJSONEncoder(w io.Writer, opts ...EncodeOption) *NDJSONEncoder {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &NDJSONEncoder{encoder: encoder, opts: newEncodeOptions(opts)}
+}
+
+// Encode writes row as one line.
+func (e *NDJSONEncoder) Encode(row interface{}) error {
+	doc, ok := row.(map[string]interface{})
+	if !ok {
+		return e.encoder.Encode(e.value(row))
+	}
+	out := make(map[string]interface{}, len(doc))
+	if e.opts.columns != nil {
+		for _, column := range e.opts.columns {
+			if value, ok := doc[column]; ok {
+				out[column] = e.value(value)
+			}
+		}
+	} else {
+		for field, value := range doc {
+			out[field] = e.value(value)
+		}
+	}
+	return e.encoder.Encode(out)
+}
+
+// Flush is a no-op: every row is written as it is encoded. It makes
+// NDJSONEncoder interchangeable with CSVEncoder.
+func (e *NDJSONEncoder) Flush() error {
+	return nil
+}
+
+// value applies the time format to a field value.
+func (e *NDJSONEncoder) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(e.opts.timeFormat)
+	case *time.Time:
+		if v != nil {
+			return v.Format(e.opts.timeFormat)
+		}
+	}
+	return value
+}
+
+// rowEncoder is implemented by CSVEncoder and NDJSONEncoder.
+type rowEncoder interface {
+	Encode(row interface{}) error
+	Flush() error
+}
+
+// EncodeCSV writes the rows of rs to w as CSV and returns the number of rows
+// written, streaming batch by batch:
+//
+//	stream, err := c.QueryStream(ctx, `SELECT * FROM BUNDLE "orders";`, 0)
+//	...
+//	n, err := client.EncodeCSV(w, stream, client.WithColumns("id", "total", "createdAt"))
+//
+// Nested values are written as JSON.
+func EncodeCSV(w io.Writer, rs ResultSet, opts ...EncodeOption) (int, error) {
+	return encodeResultSet(NewCSVEncoder(w, opts...), rs)
+}
+
+// EncodeNDJSON writes the rows of rs to w as newline-delimited JSON and
+// returns the number of rows written, streaming batch by batch.
+func EncodeNDJSON(w io.Writer, rs ResultSet, opts ...EncodeOption) (int, error) {
+	return encodeResultSet(NewNDJSONEncoder(w, opts...), rs)
+}
+
+// encodeResultSet writes every row of rs with encoder.
+func encodeResultSet(encoder rowEncoder, rs ResultSet) (int, error) {
+	written := 0
+	for batch := rs.NextBatch(); batch != nil; batch = rs.NextBatch() {
+		for _, row := range batch {
+			if err := encoder.Encode(row); err != nil {
+				return written, err
+			}
+			written++
+		}
+		// Hand each batch to the writer, so slow streams are not held back
+		if err := encoder.Flush(); err != nil {
+			return written, err
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		return written, err
+	}
+	return written, rs.Err()
+}
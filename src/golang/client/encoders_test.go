@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEncodeCSV verifies the header, column order and cell formatting.
+func TestEncodeCSV(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{"id": "a", "total": 12.5, "paid": true, "tags": []interface{}{"x", "y"}},
+		map[string]interface{}{"id": "b", "total": nil, "paid": false, "extra": 1.0},
+	}
+	var skipped []string
+
+	var buf bytes.Buffer
+	n, err := EncodeCSV(&buf, NewRowStream(rows, 1), OnSkippedField(func(field string) {
+		skipped = append(skipped, field)
+	}))
+	if err != nil || n != 2 {
+		t.Fatalf("EncodeCSV returned %d, %v", n, err)
+	}
+	want := "id,paid,tags,total\na,true,\"[\"\"x\"\",\"\"y\"\"]\",12.5\nb,false,,\n"
+	if buf.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+	if len(skipped) != 1 || skipped[0] != "extra" {
+		t.Errorf("expected the extra field to be skipped, got %v", skipped)
+	}
+
+	buf.Reset()
+	created := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	_, err = EncodeCSV(&buf, NewResultSet(map[string]interface{}{"Result": []interface{}{
+		map[string]interface{}{"id": "a", "total": 2.0 / 3, "created": created},
+	}}), WithColumns("total", "id", "created", "missing"), WithoutHeader(),
+		WithFloatPrecision(2), WithTimeFormat("2006-01-02"), WithNullString("NULL"), WithDelimiter(';'))
+	if err != nil {
+		t.Fatalf("EncodeCSV failed: %v", err)
+	}
+	if want := "0.67;a;2026-03-04;NULL\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	if _, err := EncodeCSV(&buf, NewRowStream(nil, 0), WithColumns("id", "total")); err != nil {
+		t.Fatalf("EncodeCSV failed: %v", err)
+	}
+	if buf.String() != "id,total\n" {
+		t.Errorf("expected a header for an empty result, got %q", buf.String())
+	}
+}
+
+// TestEncodeNDJSON verifies one document per line, projection and time format.
+func TestEncodeNDJSON(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{"id": "a", "note": "<b>", "created": time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)},
+		map[string]interface{}{"id": "b", "note": nil},
+	}
+
+	var buf bytes.Buffer
+	n, err := EncodeNDJSON(&buf, NewRowStream(rows, 0), WithColumns("id", "created"), WithTimeFormat(time.DateOnly))
+	if err != nil || n != 2 {
+		t.Fatalf("EncodeNDJSON returned %d, %v", n, err)
+	}
+	want := `{"created":"2026-03-04","id":"a"}` + "\n" + `{"id":"b"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	if _, err := EncodeNDJSON(&buf, NewRowStream(rows[1:], 0)); err != nil {
+		t.Fatalf("EncodeNDJSON failed: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != `{"id":"b","note":null}` {
+		t.Errorf("unexpected line %s", got)
+	}
+}
+
+// failedResultSet ends with an error after its rows.
+type failedResultSet struct {
+	rows []interface{}
+	err  error
+}
+
+func (rs *failedResultSet) NextBatch() []interface{} {
+	rows := rs.rows
+	rs.rows = nil
+	return rows
+}
+
+func (rs *failedResultSet) Err() error { return rs.err }
+
+// TestEncodeStreamError verifies the rows before a stream error are written
+// and the error is returned.
+func TestEncodeStreamError(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	var buf bytes.Buffer
+	n, err := EncodeNDJSON(&buf, &failedResultSet{
+		rows: []interface{}{map[string]interface{}{"id": "a"}},
+		err:  streamErr,
+	})
+	if n != 1 || !errors.Is(err, streamErr) {
+		t.Errorf("expected 1 row and the stream error, got %d, %v", n, err)
+	}
+	if buf.Len() == 0 {
+		t.Error("expected the rows before the error to be written")
+	}
+}
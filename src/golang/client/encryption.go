@@ -0,0 +1,442 @@
+package client
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// encryptedPrefix marks encrypted field values. The key ID follows, so values
+// written under a previous key remain readable after key rotation.
+const encryptedPrefix = "enc:v1:"
+
+// KeyProvider supplies encryption keys by ID.
+type KeyProvider interface {
+	// Key returns the AES key (16, 24 or 32 bytes) for keyID.
+	Key(keyID string) ([]byte, error)
+}
+
+// StaticKeys is a KeyProvider backed by an in-memory map of key IDs to keys.
+type StaticKeys map[string][]byte
+
+// Key implements KeyProvider.
+func (k StaticKeys) Key(keyID string) ([]byte, error) {
+	key, ok := k[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %q", keyID)
+	}
+	return key, nil
+}
+
+// FieldEncryptor encrypts and decrypts the values of one field.
+type FieldEncryptor interface {
+	// Encrypt returns the stored form of value.
+	Encrypt(value interface{}) (string, error)
+
+	// Decrypt restores a value returned by Encrypt.
+	Decrypt(ciphertext string) (interface{}, error)
+
+	// Deterministic reports whether equal values always encrypt to the same
+	// ciphertext, which allows equality conditions on the field.
+	Deterministic() bool
+}
+
+// AESGCMEncryptor encrypts field values with AES-GCM. Values are JSON-encoded
+// before encryption, so numbers, booleans and objects round-trip with the
+// types encoding/json decodes them to.
+type AESGCMEncryptor struct {
+	keys          KeyProvider
+	keyID         string
+	deterministic bool
+}
+
+// NewAESGCMEncryptor creates an encryptor using a random nonce per value.
+// New values are encrypted with keyID; existing values are decrypted with the
+// key they were written with.
+func NewAESGCMEncryptor(keys KeyProvider, keyID string) *AESGCMEncryptor {
+	return &AESGCMEncryptor{keys: keys, keyID: keyID}
+}
+
+// NewDeterministicEncryptor creates an encryptor deriving the nonce from the
+// value, so equal values produce equal ciphertexts and can be matched with
+// Equals, NotEquals, In and NotIn. This reveals which documents share a value;
+// use it only for fields that must be searchable.
+func NewDeterministicEncryptor(keys KeyProvider, keyID string) *AESGCMEncryptor {
+	return &AESGCMEncryptor{keys: keys, keyID: keyID, deterministic: true}
+}
+
+// Deterministic implements FieldEncryptor.
+func (e *AESGCMEncryptor) Deterministic() bool {
+	return e.deterministic
+}
+
+// Encrypt implements FieldEncryptor.
+func (e *AESGCMEncryptor) Encrypt(value interface{}) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	key, aead, err := e.cipher(e.keyID)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if e.deterministic {
+		mac := hmac.New(sha256.New, append([]byte("syndrdb-nonce:"), key...))
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(e.keyID))
+	return encryptedPrefix + e.keyID + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt implements FieldEncryptor.
+func (e *AESGCMEncryptor) Decrypt(ciphertext string) (interface{}, error) {
+	if !strings.HasPrefix(ciphertext, encryptedPrefix) {
+		return nil, fmt.Errorf("value is not encrypted")
+	}
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(ciphertext, encryptedPrefix), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	_, aead, err := e.cipher(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// cipher returns the key and AEAD for keyID.
+func (e *AESGCMEncryptor) cipher(keyID string) ([]byte, cipher.AEAD, error) {
+	key, err := e.keys.Key(keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, aead, nil
+}
+
+// RegisterEncryptor encrypts field of bundle with enc. Values are encrypted
+// by InsertBuilder and UpdateBuilder, conditions on the field are encrypted in
+// builder WHERE clauses, and QueryBuilder results are decrypted. Raw commands
+// sent with Query or Mutate are not modified. Pass nil to remove the encryptor.
+func (c *Client) RegisterEncryptor(bundle, field string, enc FieldEncryptor) {
+	c.encryptorsMu.Lock()
+	defer c.encryptorsMu.Unlock()
+
+	if enc == nil {
+		delete(c.encryptors[bundle], field)
+		return
+	}
+	if c.encryptors == nil {
+		c.encryptors = make(map[string]map[string]FieldEncryptor)
+	}
+	if c.encryptors[bundle] == nil {
+		c.encryptors[bundle] = make(map[string]FieldEncryptor)
+	}
+	c.encryptors[bundle][field] = enc
+}
+
+// bundleEncryptors returns a snapshot of the encryptors registered for bundle.
+func (c *Client) bundleEncryptors(bundle string) map[string]FieldEncryptor {
+	c.encryptorsMu.RLock()
+	defer c.encryptorsMu.RUnlock()
+
+	if len(c.encryptors[bundle]) == 0 {
+		return nil
+	}
+	snapshot := make(map[string]FieldEncryptor, len(c.encryptors[bundle]))
+	for field, enc := range c.encryptors[bundle] {
+		snapshot[field] = enc
+	}
+	return snapshot
+}
+
+// encryptValues returns values with encrypted fields replaced by their ciphertext.
+func (c *Client) encryptValues(bundle string, values map[string]interface{}) (map[string]interface{}, error) {
+	encryptors := c.bundleEncryptors(bundle)
+	if encryptors == nil {
+		return values, nil
+	}
+
+	encrypted := make(map[string]interface{}, len(values))
+	for field, value := range values {
+		enc, ok := encryptors[field]
+		if !ok || value == nil {
+			encrypted[field] = value
+			continue
+		}
+		ciphertext, err := enc.Encrypt(value)
+		if err != nil {
+			return nil, errEncryption("E_ENCRYPTION_FAILED", bundle, field, err)
+		}
+		encrypted[field] = ciphertext
+	}
+	return encrypted, nil
+}
+
+// encryptWhere returns clauses with conditions on encrypted fields rewritten
+// to compare ciphertexts. Only equality and set conditions on deterministic
+// fields can be rewritten; others fail with E_ENCRYPTED_FIELD_NOT_SEARCHABLE.
+func (c *Client) encryptWhere(bundle string, clauses []whereClause) ([]whereClause, error) {
+	encryptors := c.bundleEncryptors(bundle)
+	if encryptors == nil {
+		return clauses, nil
+	}
+
+	rewritten := make([]whereClause, len(clauses))
+	copy(rewritten, clauses)
+	for i, clause := range rewritten {
+		enc, ok := encryptors[clause.field]
+		if !ok || clause.operator == IsNull || clause.operator == IsNotNull {
+			continue
+		}
+
+		searchable := enc.Deterministic() &&
+			(clause.operator == Equals || clause.operator == NotEquals || clause.operator == In || clause.operator == NotIn)
+		if !searchable {
+			return nil, &QueryError{
+				Code:    "E_ENCRYPTED_FIELD_NOT_SEARCHABLE",
+				Type:    "QueryError",
+				Message: fmt.Sprintf("cannot use %s on encrypted field %s.%s", clause.operator, bundle, clause.field),
+				Details: map[string]interface{}{
+					"bundle":        bundle,
+					"field":         clause.field,
+					"operator":      clause.operator.String(),
+					"deterministic": enc.Deterministic(),
+				},
+			}
+		}
+
+		// Subqueries and column references compare with stored ciphertexts
+		switch clause.value.(type) {
+		case *QueryBuilder, Column:
+			continue
+		}
+
+		value, err := encryptOperand(enc, clause.value)
+		if err != nil {
+			return nil, errEncryption("E_ENCRYPTION_FAILED", bundle, clause.field, err)
+		}
+		rewritten[i].value = value
+	}
+	return rewritten, nil
+}
+
+// encryptOperand encrypts a condition value, element-wise for slices.
+func encryptOperand(enc FieldEncryptor, value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return enc.Encrypt(value)
+	}
+
+	encrypted := make([]interface{}, v.Len())
+	for i := range encrypted {
+		ciphertext, err := enc.Encrypt(v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		encrypted[i] = ciphertext
+	}
+	return encrypted, nil
+}
+
+// decryptResult decrypts encrypted fields in the documents of a query result,
+// in place. Values without the encrypted prefix are left as they are.
+func (c *Client) decryptResult(bundle string, result interface{}) error {
+	encryptors := c.bundleEncryptors(bundle)
+	if encryptors == nil {
+		return nil
+	}
+
+	var rows []interface{}
+	switch v := result.(type) {
+	case []interface{}:
+		rows = v
+	case map[string]interface{}:
+		if list, ok := v["Result"].([]interface{}); ok {
+			rows = list
+		} else {
+			rows = []interface{}{v}
+		}
+	}
+
+	for _, row := range rows {
+		doc, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for field, enc := range encryptors {
+			ciphertext, ok := doc[field].(string)
+			if !ok || !strings.HasPrefix(ciphertext, encryptedPrefix) {
+				continue
+			}
+			value, err := enc.Decrypt(ciphertext)
+			if err != nil {
+				return errEncryption("E_DECRYPTION_FAILED", bundle, field, err)
+			}
+			doc[field] = value
+		}
+	}
+	return nil
+}
+
+// withEncryption returns a copy of the builder with condition values marshaled
+// by their codecs and conditions on encrypted fields rewritten, in its
+// subqueries too.
+func (qb *QueryBuilder) withEncryption() (*QueryBuilder, error) {
+	whereClauses, err := qb.client.codecs.marshalWhere(qb.bundle, qb.whereClauses)
+	if err != nil {
+		return nil, err
+	}
+	whereClauses, err = qb.client.encryptWhere(qb.bundle, whereClauses)
+	if err != nil {
+		return nil, err
+	}
+	copied := false
+	for i, clause := range whereClauses {
+		sub, ok := clause.value.(*QueryBuilder)
+		if !ok || sub == nil {
+			continue
+		}
+		encryptedSub, err := sub.withEncryption()
+		if err != nil {
+			return nil, err
+		}
+		if !copied {
+			whereClauses = append([]whereClause(nil), whereClauses...)
+			copied = true
+		}
+		whereClauses[i].value = encryptedSub
+	}
+	encrypted := *qb
+	encrypted.whereClauses = whereClauses
+	return &encrypted, nil
+}
+
+// decrypt decrypts encrypted fields in a successful result and unmarshals
+// fields with a codec.
+func (qb *QueryBuilder) decrypt(result interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return result, err
+	}
+	if err := qb.client.decryptResult(qb.bundle, result); err != nil {
+		return nil, err
+	}
+	if err := qb.client.codecs.unmarshalResult(qb.bundle, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// withEncryption returns a copy of the builder with values marshaled by their
+// codecs and encrypted.
+func (ib *InsertBuilder) withEncryption() (*InsertBuilder, error) {
+	values, err := ib.client.codecs.marshalValues(ib.bundle, ib.values)
+	if err != nil {
+		return nil, err
+	}
+	values, err = ib.client.encryptValues(ib.bundle, values)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *ib
+	encrypted.values = values
+	return &encrypted, nil
+}
+
+// withEncryption returns a copy of the builder with values and conditions
+// marshaled by their codecs and encrypted.
+func (ub *UpdateBuilder) withEncryption() (*UpdateBuilder, error) {
+	setFields, err := ub.client.codecs.marshalValues(ub.bundle, ub.setFields)
+	if err != nil {
+		return nil, err
+	}
+	setFields, err = ub.client.encryptValues(ub.bundle, setFields)
+	if err != nil {
+		return nil, err
+	}
+	whereClauses, err := ub.client.codecs.marshalWhere(ub.bundle, ub.whereClauses)
+	if err != nil {
+		return nil, err
+	}
+	whereClauses, err = ub.client.encryptWhere(ub.bundle, whereClauses)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *ub
+	encrypted.setFields = setFields
+	encrypted.whereClauses = whereClauses
+	return &encrypted, nil
+}
+
+// withEncryption returns a copy of the builder with condition values marshaled
+// by their codecs and conditions on encrypted fields rewritten.
+func (db *DeleteBuilder) withEncryption() (*DeleteBuilder, error) {
+	whereClauses, err := db.client.codecs.marshalWhere(db.bundle, db.whereClauses)
+	if err != nil {
+		return nil, err
+	}
+	whereClauses, err = db.client.encryptWhere(db.bundle, whereClauses)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *db
+	encrypted.whereClauses = whereClauses
+	return &encrypted, nil
+}
+
+// errEncryption creates an error for a failed encryption or decryption.
+func errEncryption(code, bundle, field string, cause error) *QueryError {
+	action := "encrypt"
+	if code == "E_DECRYPTION_FAILED" {
+		action = "decrypt"
+	}
+	return &QueryError{
+		Code:    code,
+		Type:    "QueryError",
+		Message: fmt.Sprintf("failed to %s %s.%s", action, bundle, field),
+		Details: map[string]interface{}{
+			"bundle": bundle,
+			"field":  field,
+		},
+		Cause: cause,
+	}
+}
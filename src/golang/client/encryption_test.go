@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testKeys = StaticKeys{
+	"k1": []byte("0123456789abcdef0123456789abcdef"),
+	"k2": []byte("fedcba9876543210fedcba9876543210"),
+}
+
+// TestAESGCMEncryptorRoundTrip verifies values decrypt to their JSON-decoded form.
+func TestAESGCMEncryptorRoundTrip(t *testing.T) {
+	enc := NewAESGCMEncryptor(testKeys, "k1")
+	for _, value := range []interface{}{"secret", float64(42), true, map[string]interface{}{"a": "b"}} {
+		ciphertext, err := enc.Encrypt(value)
+		if err != nil {
+			t.Fatalf("Encrypt(%v) failed: %v", value, err)
+		}
+		if !strings.HasPrefix(ciphertext, "enc:v1:k1:") {
+			t.Errorf("expected key-tagged ciphertext, got %s", ciphertext)
+		}
+		got, err := enc.Decrypt(ciphertext)
+		if err != nil || !reflect.DeepEqual(got, value) {
+			t.Errorf("expected %v, got %v (%v)", value, got, err)
+		}
+	}
+
+	a, _ := enc.Encrypt("secret")
+	b, _ := enc.Encrypt("secret")
+	if a == b {
+		t.Error("expected randomized ciphertexts to differ")
+	}
+}
+
+// TestDeterministicEncryptor verifies equal values encrypt identically and old keys still decrypt.
+func TestDeterministicEncryptor(t *testing.T) {
+	enc := NewDeterministicEncryptor(testKeys, "k1")
+	a, _ := enc.Encrypt("alice@example.com")
+	b, _ := enc.Encrypt("alice@example.com")
+	c, _ := enc.Encrypt("bob@example.com")
+	if a != b || a == c {
+		t.Errorf("expected equal values to match and distinct values to differ: %s %s %s", a, b, c)
+	}
+
+	rotated := NewDeterministicEncryptor(testKeys, "k2")
+	if got, err := rotated.Decrypt(a); err != nil || got != "alice@example.com" {
+		t.Errorf("expected value written under k1 to decrypt after rotation, got %v (%v)", got, err)
+	}
+	if _, err := NewAESGCMEncryptor(StaticKeys{"k1": testKeys["k2"]}, "k1").Decrypt(a); err == nil {
+		t.Error("expected decryption with the wrong key to fail")
+	}
+}
+
+// TestEncryptedBuilders verifies values and conditions are encrypted before sending.
+func TestEncryptedBuilders(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true,"data":{"affected_count":1}}` })
+	email := NewDeterministicEncryptor(testKeys, "k1")
+	c.RegisterEncryptor("users", "email", email)
+	c.RegisterEncryptor("users", "ssn", NewAESGCMEncryptor(testKeys, "k1"))
+	ctx := context.Background()
+
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{"email": "alice@example.com", "ssn": "123-45-6789", "name": "Alice"}).Execute(ctx); err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+	if _, err := c.UpdateBuilder("users").Set("ssn", "987-65-4321").Where("email", Equals, "alice@example.com").Execute(ctx); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := c.DeleteBuilder("users").Where("email", In, []string{"alice@example.com"}).Execute(ctx); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	ciphertext, _ := email.Encrypt("alice@example.com")
+	for _, command := range server.received() {
+		if strings.Contains(command, "alice@example.com") || strings.Contains(command, "-45-") || strings.Contains(command, "-65-") {
+			t.Errorf("expected no plaintext in %s", command)
+		}
+		if !strings.Contains(command, ciphertext) {
+			t.Errorf("expected deterministic ciphertext in %s", command)
+		}
+	}
+	if !strings.Contains(server.received()[0], `"Alice"`) {
+		t.Error("expected unregistered fields to be sent as-is")
+	}
+
+	_, err := c.QueryBuilder().Select("users").Where("ssn", Equals, "123-45-6789").Execute(ctx)
+	if ErrorCode(err) != "E_ENCRYPTED_FIELD_NOT_SEARCHABLE" {
+		t.Errorf("expected E_ENCRYPTED_FIELD_NOT_SEARCHABLE, got %v", err)
+	}
+	_, err = c.QueryBuilder().Select("users").Where("email", GreaterThan, "a").Execute(ctx)
+	if ErrorCode(err) != "E_ENCRYPTED_FIELD_NOT_SEARCHABLE" {
+		t.Errorf("expected E_ENCRYPTED_FIELD_NOT_SEARCHABLE for a range condition, got %v", err)
+	}
+}
+
+// TestEncryptedQueryResult verifies query results are decrypted.
+func TestEncryptedQueryResult(t *testing.T) {
+	enc := NewAESGCMEncryptor(testKeys, "k1")
+	ciphertext, _ := enc.Encrypt("123-45-6789")
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success":true,"data":{"Result":[{"name":"Alice","ssn":"` + ciphertext + `"},{"name":"Bob","ssn":null}]}}`
+	})
+	c.RegisterEncryptor("users", "ssn", enc)
+
+	result, err := c.QueryBuilder().Select("users").Execute(context.Background())
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	rows := result.(map[string]interface{})["Result"].([]interface{})
+	if got := rows[0].(map[string]interface{})["ssn"]; got != "123-45-6789" {
+		t.Errorf("expected decrypted ssn, got %v", got)
+	}
+	if got := rows[1].(map[string]interface{})["ssn"]; got != nil {
+		t.Errorf("expected null to stay null, got %v", got)
+	}
+
+	c.RegisterEncryptor("users", "ssn", nil)
+	if len(c.bundleEncryptors("users")) != 0 {
+		t.Error("expected RegisterEncryptor(nil) to remove the encryptor")
+	}
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+)
+
+// sentinelError is a category of driver errors for use with errors.Is.
+// A typed driver error matches a sentinel when its code is registered to it in
+// errorCatalog, so callers need not compare code strings.
+type sentinelError struct {
+	message string
+}
+
+func (e *sentinelError) Error() string {
+	return e.message
+}
+
+// errorCatalog maps error codes to their sentinel.
+var errorCatalog = map[string]*sentinelError{}
+
+func newSentinel(message string, codes ...string) *sentinelError {
+	e := &sentinelError{message: message}
+	for _, code := range codes {
+		errorCatalog[code] = e
+	}
+	return e
+}
+
+// Sentinel errors. Every driver error type implements Is, so for example
+//
+//	if errors.Is(err, client.ErrBundleNotFound) { ... }
+//
+// matches a *QueryError with code E_BUNDLE_NOT_FOUND as well as a server error
+// payload classified as a missing bundle.
+var (
+	ErrAuthFailed = newSentinel("authentication failed", "AUTH_FAILED")
+
+	ErrConnectionFailed = newSentinel("connection failed",
+		"CONNECTION_FAILED", "NO_CONNECTION", "NETWORK_ERROR", "SEND_FAILED",
+		"RECEIVE_FAILED", "NO_RESPONSE", "CONNECTION_DEAD", "CONNECTION_UNHEALTHY",
+		"DISCOVERY_FAILED")
+
+	ErrTLS = newSentinel("TLS failure",
+		"TLS_HANDSHAKE_FAILED", "TLS_HANDSHAKE_INCOMPLETE", "TLS_CERT_EXPIRED",
+		"TLS_CERT_UNTRUSTED", "TLS_UNKNOWN_CA", "TLS_HOSTNAME_MISMATCH",
+		"TLS_CLIENT_CERT_FAILED", "TLS_CA_LOAD_FAILED", "TLS_CA_INVALID")
+
+	// ErrTimeout also matches driver errors caused by a context deadline or
+	// network timeout.
+	ErrTimeout = newSentinel("operation timed out", "E_TX_TIMEOUT", "DEADLINE_ERROR", "E_TIMEOUT", "E_STATEMENT_TIMEOUT")
+
+	ErrInvalidQuery = newSentinel("invalid query",
+		"E_INVALID_QUERY", "E_PARAM_COUNT_MISMATCH", "E_PARAM_MISSING", "E_PARAM_UNKNOWN",
+		"E_PARAM_TYPE_MISMATCH", "E_SYNTAX_ERROR")
+
+	ErrBundleNotFound   = newSentinel("bundle not found", "E_BUNDLE_NOT_FOUND")
+	ErrFieldNotFound    = newSentinel("field not found", "E_FIELD_NOT_FOUND")
+	ErrDocumentNotFound = newSentinel("document not found", "E_DOCUMENT_NOT_FOUND")
+	ErrAlreadyExists    = newSentinel("already exists", "E_ALREADY_EXISTS", "E_DUPLICATE_KEY")
+
+	ErrPermissionDenied = newSentinel("permission denied", "E_PERMISSION_DENIED")
+
+	ErrTransactionClosed = newSentinel("transaction is not active",
+		"E_TX_ALREADY_COMMITTED", "E_TX_ALREADY_ROLLEDBACK", "E_TX_CONTEXT_CANCELLED", "E_NO_ACTIVE_TX")
+
+	ErrUnsupported = newSentinel("not supported by the server",
+		"E_FEATURE_UNSUPPORTED", "E_ISOLATION_UNSUPPORTED")
+
+	// ErrTxConflict matches serialization failures, deadlocks, lock conflicts
+	// and stale optimistic-locking versions, after which the whole
+	// transaction may be retried.
+	ErrTxConflict = newSentinel("transaction conflict",
+		"E_TX_CONFLICT", "E_SERIALIZATION_FAILURE", "E_DEADLOCK", "E_LOCK_CONFLICT", "E_STALE_VERSION")
+
+	ErrRateLimited      = newSentinel("rate limited", "E_RATE_LIMITED")
+	ErrOverloaded       = newSentinel("overloaded", "E_OVERLOADED")
+	ErrResponseTooLarge = newSentinel("response too large", "E_RESPONSE_TOO_LARGE")
+	ErrOfflineQueueFull = newSentinel("offline queue full", "E_OFFLINE_QUEUE_FULL")
+	ErrPolicyViolation  = newSentinel("access policy violation", "E_POLICY_VIOLATION")
+	ErrDumpCorrupt      = newSentinel("dump is corrupt", "E_DUMP_INVALID", "E_DUMP_CHECKSUM_MISMATCH")
+)
+
+// serverErrorPatterns classify server error messages that carry no code.
+var serverErrorPatterns = []struct {
+	pattern *regexp.Regexp
+	code    string
+}{
+	{regexp.MustCompile(`(?i)\bbundle\b.*\b(not found|does not exist)`), "E_BUNDLE_NOT_FOUND"},
+	{regexp.MustCompile(`(?i)\bfield\b.*\b(not found|does not exist)`), "E_FIELD_NOT_FOUND"},
+	{regexp.MustCompile(`(?i)\bdocument\b.*\b(not found|does not exist)`), "E_DOCUMENT_NOT_FOUND"},
+	{regexp.MustCompile(`(?i)already exists|duplicate|unique constraint`), "E_ALREADY_EXISTS"},
+	{regexp.MustCompile(`(?i)permission denied|not authori[sz]ed|access denied`), "E_PERMISSION_DENIED"},
+	{regexp.MustCompile(`(?i)authentication failed|invalid (credentials|password)`), "AUTH_FAILED"},
+	{regexp.MustCompile(`(?i)syntax error|parse error|unexpected token`), "E_SYNTAX_ERROR"},
+	{regexp.MustCompile(`(?i)deadlock|serialization failure|could not serialize|(lock|write) conflict`), "E_TX_CONFLICT"},
+	{regexp.MustCompile(`(?i)timed? ?out`), "E_TIMEOUT"},
+}
+
+// ClassifyServerError maps a server error payload, such as
+// {"success": false, "error": "bundle not found: users"}, to an error code
+// from the catalog. An explicit "code" in the payload takes precedence over
+// the message. Returns "" if the payload is not recognized.
+func ClassifyServerError(payload map[string]interface{}) string {
+	if code, ok := payload["code"].(string); ok {
+		if _, known := errorCatalog[code]; known {
+			return code
+		}
+	}
+
+	for _, key := range []string{"error", "message"} {
+		value, ok := payload[key]
+		if !ok {
+			continue
+		}
+		message := fmt.Sprintf("%v", value)
+		for _, p := range serverErrorPatterns {
+			if p.pattern.MatchString(message) {
+				return p.code
+			}
+		}
+	}
+	return ""
+}
+
+// ClassifyError returns the sentinel matching err, or nil if err is not a
+// recognized driver error.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if sentinel, ok := errorCatalog[ErrorCode(err)]; ok {
+		return sentinel
+	}
+
+	var protoErr *ProtocolError
+	if errors.As(err, &protoErr) {
+		if sentinel := protoErr.classified(); sentinel != nil {
+			return sentinel
+		}
+	}
+	if errors.Is(err, ErrTimeout) {
+		return ErrTimeout
+	}
+	return nil
+}
+
+// matchesSentinel reports whether an error with code and cause matches target.
+func matchesSentinel(code string, cause error, target error) bool {
+	sentinel, ok := target.(*sentinelError)
+	if !ok {
+		return false
+	}
+	if errorCatalog[code] == sentinel {
+		return true
+	}
+	return sentinel == ErrTimeout && isTimeout(cause)
+}
+
+// isTimeout reports whether err is a context deadline or network timeout.
+func isTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// classified returns the sentinel for a SERVER_ERROR payload, if recognized.
+func (e *ProtocolError) classified() *sentinelError {
+	if e.Code != "SERVER_ERROR" {
+		return nil
+	}
+	payload := e.Details
+	if payload == nil {
+		payload = map[string]interface{}{"error": e.Message}
+	}
+	return errorCatalog[ClassifyServerError(payload)]
+}
+
+// Is reports whether target is the sentinel for this error's code.
+func (e *ConnectionError) Is(target error) bool {
+	return matchesSentinel(e.Code, e.Cause, target)
+}
+
+// Is reports whether target is the sentinel for this error's code, or for the
+// server error payload it carries.
+func (e *ProtocolError) Is(target error) bool {
+	if matchesSentinel(e.Code, e.Cause, target) {
+		return true
+	}
+	sentinel := e.classified()
+	return sentinel != nil && sentinel == target
+}
+
+// Is reports whether target is the sentinel for this error's code.
+func (e *StateError) Is(target error) bool {
+	return matchesSentinel(e.Code, nil, target)
+}
+
+// Is reports whether target is the sentinel for this error's code.
+func (e *QueryError) Is(target error) bool {
+	return matchesSentinel(e.Code, e.Cause, target)
+}
+
+// Is reports whether target is the sentinel for this error's code.
+func (e *StatementError) Is(target error) bool {
+	return matchesSentinel(e.Code, nil, target)
+}
+
+// Is reports whether target is the sentinel for this error's code.
+func (e *TransactionError) Is(target error) bool {
+	return matchesSentinel(e.Code, e.Cause, target)
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestSentinelErrors verifies typed errors match the sentinel for their code.
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+	}{
+		{"auth", &ConnectionError{Code: "AUTH_FAILED"}, ErrAuthFailed},
+		{"connection", &ProtocolError{Code: "SEND_FAILED"}, ErrConnectionFailed},
+		{"tls", &ConnectionError{Code: "TLS_CERT_EXPIRED"}, ErrTLS},
+		{"bundle", &QueryError{Code: "E_BUNDLE_NOT_FOUND"}, ErrBundleNotFound},
+		{"tx timeout", ErrTransactionTimeout("TX_1", 10), ErrTimeout},
+		{"tx closed", ErrTransactionAlreadyCommitted("TX_1"), ErrTransactionClosed},
+		{"unsupported", ErrIsolationUnsupported(Serializable), ErrUnsupported},
+		{"rate limited", rateLimitedError("MaxInFlightCommands", 1), ErrRateLimited},
+		{"wrapped", fmt.Errorf("loading users: %w", &QueryError{Code: "E_INVALID_QUERY"}), ErrInvalidQuery},
+		{"deadline cause", &ProtocolError{Code: "RECEIVE_FAILED", Cause: context.DeadlineExceeded}, ErrTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.sentinel) {
+				t.Errorf("expected %v to match %v", tt.err, tt.sentinel)
+			}
+			if errors.Is(tt.err, ErrPermissionDenied) {
+				t.Errorf("expected %v not to match ErrPermissionDenied", tt.err)
+			}
+		})
+	}
+}
+
+// TestClassifyServerError verifies server payloads map to catalog codes.
+func TestClassifyServerError(t *testing.T) {
+	tests := []struct {
+		payload map[string]interface{}
+		want    string
+	}{
+		{map[string]interface{}{"error": "bundle not found: users"}, "E_BUNDLE_NOT_FOUND"},
+		{map[string]interface{}{"error": "WHERE field not found in bundle: age"}, "E_FIELD_NOT_FOUND"},
+		{map[string]interface{}{"message": "Bundle \"users\" already exists"}, "E_ALREADY_EXISTS"},
+		{map[string]interface{}{"error": "permission denied"}, "E_PERMISSION_DENIED"},
+		{map[string]interface{}{"code": "E_DOCUMENT_NOT_FOUND", "error": "no such document"}, "E_DOCUMENT_NOT_FOUND"},
+		{map[string]interface{}{"code": "E_CUSTOM", "error": "syntax error near WHERE"}, "E_SYNTAX_ERROR"},
+		{map[string]interface{}{"error": "disk full"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyServerError(tt.payload); got != tt.want {
+			t.Errorf("ClassifyServerError(%v) = %q, want %q", tt.payload, got, tt.want)
+		}
+	}
+}
+
+// TestClassifyError verifies server errors are classified through errors.Is and ClassifyError.
+func TestClassifyError(t *testing.T) {
+	serverErr := &ProtocolError{
+		Code:    "SERVER_ERROR",
+		Message: "bundle not found: users",
+		Details: map[string]interface{}{"success": false, "error": "bundle not found: users"},
+	}
+	if !errors.Is(serverErr, ErrBundleNotFound) {
+		t.Error("expected server error to match ErrBundleNotFound")
+	}
+	if got := ClassifyError(serverErr); got != ErrBundleNotFound {
+		t.Errorf("expected ErrBundleNotFound, got %v", got)
+	}
+	if got := ClassifyError(&StateError{Code: "INVALID_STATE"}); got != nil {
+		t.Errorf("expected no classification, got %v", got)
+	}
+	if ClassifyError(nil) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
@@ -434,6 +434,23 @@ func ErrInvalidParameterCount(expected, actual int) *QueryError {
 	}
 }
 
+// ErrParamTypeMismatch creates an error for a parameter value that does not
+// match the type the server reported for its placeholder.
+func ErrParamTypeMismatch(position int, expected string, value interface{}) *QueryError {
+	return &QueryError{
+		Code:    "E_PARAM_TYPE_MISMATCH",
+		Type:    "QUERY_ERROR",
+		Message: fmt.Sprintf("parameter $%d expects %s, got %T", position, expected, value),
+		Details: map[string]interface{}{
+			"position": position,
+			"expected": expected,
+			"actual":   fmt.Sprintf("%T", value),
+		},
+		StackTrace: captureStackTrace(),
+		Timestamp:  time.Now(),
+	}
+}
+
 // ErrStatementNotFound creates an error when a prepared statement doesn't exist.
 func ErrStatementNotFound(name string) *StatementError {
 	return &StatementError{
@@ -451,6 +468,25 @@ func ErrStatementNotFound(name string) *StatementError {
 	}
 }
 
+// ErrStatementTimeout creates an error for a statement cancelled after
+// running longer than its timeout.
+func ErrStatementTimeout(name string, timeout time.Duration) *StatementError {
+	return &StatementError{
+		QueryError: QueryError{
+			Code:    "E_STATEMENT_TIMEOUT",
+			Type:    "STATEMENT_ERROR",
+			Message: fmt.Sprintf("prepared statement '%s' exceeded its %s timeout and was cancelled", name, timeout),
+			Details: map[string]interface{}{
+				"statement_name": name,
+				"timeout_ms":     timeout.Milliseconds(),
+			},
+			StackTrace: captureStackTrace(),
+			Timestamp:  time.Now(),
+		},
+		StatementName: name,
+	}
+}
+
 // ErrTransactionAlreadyActive creates an error when trying to begin a transaction while one is already active.
 func ErrTransactionAlreadyActive(id string) *TransactionError {
 	return &TransactionError{
@@ -504,6 +540,21 @@ func ErrTransactionAlreadyRolledBack(id string) *TransactionError {
 	}
 }
 
+// ErrTransactionContextCancelled creates an error for operations on a
+// transaction that was rolled back because its context ended.
+func ErrTransactionContextCancelled(id string, cause error) *TransactionError {
+	return &TransactionError{
+		Code:          "E_TX_CONTEXT_CANCELLED",
+		Type:          "TRANSACTION_ERROR",
+		Message:       "transaction was rolled back because its context ended",
+		TransactionID: id,
+		State:         "rolledback",
+		Cause:         cause,
+		StackTrace:    captureStackTrace(),
+		Timestamp:     time.Now(),
+	}
+}
+
 // ErrTransactionTimeout creates an error for abandoned transactions.
 func ErrTransactionTimeout(id string, duration int64) *TransactionError {
 	return &TransactionError{
@@ -520,6 +571,20 @@ func ErrTransactionTimeout(id string, duration int64) *TransactionError {
 	}
 }
 
+// ErrIsolationUnsupported creates an error for an isolation level the server
+// did not advertise support for.
+func ErrIsolationUnsupported(level IsolationLevel) *TransactionError {
+	return &TransactionError{
+		Code:    "E_ISOLATION_UNSUPPORTED",
+		Type:    "TRANSACTION_ERROR",
+		Message: fmt.Sprintf("server does not support %s isolation", level),
+		Details: map[string]interface{}{
+			"isolation": level.String(),
+		},
+		Timestamp: time.Now(),
+	}
+}
+
 // Helper functions
 
 // captureStackTrace captures the current stack trace for error reporting.
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PlanNode is one operation in a query plan.
+type PlanNode struct {
+	// Type is the operation, e.g. "IndexScan", "FullScan", "Filter", "Sort".
+	Type string `json:"type"`
+
+	// Bundle is the bundle the operation reads, if any.
+	Bundle string `json:"bundle,omitempty"`
+
+	// Index is the index used, or "" for operations that use none.
+	Index string `json:"index,omitempty"`
+
+	// EstimatedRows is the planner's row estimate, or -1 if not reported.
+	EstimatedRows int64 `json:"estimatedRows"`
+
+	// Detail holds any other description the server gave, e.g. a filter condition.
+	Detail string `json:"detail,omitempty"`
+
+	Children []*PlanNode `json:"children,omitempty"`
+}
+
+// QueryPlan is the parsed EXPLAIN output for a query.
+type QueryPlan struct {
+	Query string    `json:"query"`
+	Root  *PlanNode `json:"root"`
+
+	// Raw is the unparsed server response.
+	Raw interface{} `json:"-"`
+}
+
+// Explain asks the server how it would execute query, without executing it.
+// Servers that advertise features but not "explain" fail with E_FEATURE_UNSUPPORTED.
+func (c *Client) Explain(ctx context.Context, query string) (*QueryPlan, error) {
+	if err := c.requireFeature(FeatureExplain); err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	command := "EXPLAIN " + query
+	if !strings.HasSuffix(command, ";") {
+		command += ";"
+	}
+
+	result, err := c.executeWithTimeout(ctx, command, c.opts.DefaultTimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	return parseQueryPlan(query, result)
+}
+
+// Explain returns the server's plan for the query this builder would execute.
+func (qb *QueryBuilder) Explain(ctx context.Context) (*QueryPlan, error) {
+	if qb.bundle == "" {
+		return nil, &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: "bundle name is required",
+		}
+	}
+
+	scoped, err := qb.withTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := scoped.withEncryption()
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := encrypted.buildQuery()
+	if err != nil {
+		return nil, err
+	}
+	params = newValueCodec(qb.client.opts).encodeParams(params)
+
+	ctx, cancel := qb.queryOptions.apply(ctx, qb.client)
+	defer cancel()
+	return qb.client.Explain(ctx, inlineParameters(query, params))
+}
+
+// parseQueryPlan converts an EXPLAIN response into a QueryPlan. The plan may
+// be the response itself, under a "plan" key, or a list of top-level steps.
+func parseQueryPlan(query string, result interface{}) (*QueryPlan, error) {
+	plan := &QueryPlan{Query: query, Raw: result}
+
+	value := result
+	if m, ok := value.(map[string]interface{}); ok {
+		if inner, ok := m["plan"]; ok {
+			value = inner
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		plan.Root = parsePlanNode(v)
+	case []interface{}:
+		plan.Root = &PlanNode{Type: "Plan", EstimatedRows: -1}
+		for _, step := range v {
+			if m, ok := step.(map[string]interface{}); ok {
+				plan.Root.Children = append(plan.Root.Children, parsePlanNode(m))
+			}
+		}
+	case string:
+		plan.Root = &PlanNode{Type: "Plan", EstimatedRows: -1, Detail: v}
+	}
+
+	if plan.Root == nil || plan.Root.Type == "" {
+		return nil, &QueryError{
+			Code:    "E_PLAN_PARSE_FAILED",
+			Type:    "QueryError",
+			Message: "could not parse the EXPLAIN response",
+			Details: map[string]interface{}{
+				"response": result,
+			},
+			Query: query,
+		}
+	}
+	return plan, nil
+}
+
+// parsePlanNode reads a plan node, accepting the key spellings servers use.
+func parsePlanNode(m map[string]interface{}) *PlanNode {
+	node := &PlanNode{
+		Type:          firstString(m, "type", "node_type", "nodeType", "operation", "op"),
+		Bundle:        firstString(m, "bundle", "bundle_name", "source"),
+		Index:         firstString(m, "index", "index_used", "indexUsed", "index_name"),
+		Detail:        firstString(m, "detail", "details", "condition", "filter"),
+		EstimatedRows: -1,
+	}
+	for _, key := range []string{"estimated_rows", "estimatedRows", "rows"} {
+		if rows, ok := parseCount(m[key]); ok {
+			node.EstimatedRows = int64(rows)
+			break
+		}
+	}
+	for _, key := range []string{"children", "plans", "inputs"} {
+		children, ok := m[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, child := range children {
+			if cm, ok := child.(map[string]interface{}); ok {
+				node.Children = append(node.Children, parsePlanNode(cm))
+			}
+		}
+		break
+	}
+	return node
+}
+
+// firstString returns the first non-empty string value among keys.
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := m[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// String renders the plan as an indented tree, one operation per line:
+//
+//	Filter ("age" > 30) rows=120
+//	  IndexScan on users using idx_age rows=480
+func (p *QueryPlan) String() string {
+	var b strings.Builder
+	if p.Root != nil {
+		p.Root.render(&b, 0)
+	}
+	return b.String()
+}
+
+func (n *PlanNode) render(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+	b.WriteString(n.Type)
+	if n.Bundle != "" {
+		b.WriteString(" on " + n.Bundle)
+	}
+	if n.Index != "" {
+		b.WriteString(" using " + n.Index)
+	}
+	if n.Detail != "" {
+		b.WriteString(" (" + n.Detail + ")")
+	}
+	if n.EstimatedRows >= 0 {
+		fmt.Fprintf(b, " rows=%d", n.EstimatedRows)
+	}
+	b.WriteString("\n")
+	for _, child := range n.Children {
+		child.render(b, depth+1)
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+// TestExplain verifies the EXPLAIN command and plan tree parsing.
+func TestExplain(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"success","data":{"plan":{"node_type":"Filter","condition":"\"age\" > $1","rows":120,` +
+			`"children":[{"type":"IndexScan","bundle":"users","index_used":"idx_age","estimated_rows":480}]}}}`
+	})
+
+	plan, err := c.QueryBuilder().Select("users").Where("age", GreaterThan, 30).Explain(context.Background())
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if got := server.received(); len(got) != 1 || got[0] != "EXPLAIN SELECT * FROM users WHERE age > 30;" {
+		t.Errorf("unexpected commands: %v", got)
+	}
+
+	root := plan.Root
+	if root.Type != "Filter" || root.EstimatedRows != 120 || len(root.Children) != 1 {
+		t.Fatalf("unexpected root: %+v", root)
+	}
+	scan := root.Children[0]
+	if scan.Type != "IndexScan" || scan.Bundle != "users" || scan.Index != "idx_age" || scan.EstimatedRows != 480 {
+		t.Errorf("unexpected child: %+v", scan)
+	}
+
+	want := "Filter (\"age\" > $1) rows=120\n  IndexScan on users using idx_age rows=480\n"
+	if plan.String() != want {
+		t.Errorf("unexpected rendering:\n%s", plan.String())
+	}
+}
+
+// TestExplainUnsupported verifies servers without the feature fail before sending.
+func TestExplainUnsupported(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	c.setServerInfo(&ServerInfo{Features: []string{FeatureTransactions}})
+
+	if _, err := c.Explain(context.Background(), `SELECT * FROM "users";`); ErrorCode(err) != "E_FEATURE_UNSUPPORTED" {
+		t.Errorf("expected E_FEATURE_UNSUPPORTED, got %v", err)
+	}
+	if got := server.received(); len(got) != 0 {
+		t.Errorf("expected no commands, got %v", got)
+	}
+}
+
+// TestParseQueryPlan verifies step lists are accepted and unknown shapes rejected.
+func TestParseQueryPlan(t *testing.T) {
+	plan, err := parseQueryPlan("q", []interface{}{
+		map[string]interface{}{"operation": "FullScan", "bundle": "orders"},
+		map[string]interface{}{"operation": "Sort", "detail": "created_at DESC"},
+	})
+	if err != nil || len(plan.Root.Children) != 2 || plan.Root.Children[1].Detail != "created_at DESC" {
+		t.Fatalf("unexpected plan: %+v, %v", plan, err)
+	}
+	if plan.Root.Children[0].EstimatedRows != -1 {
+		t.Error("expected unknown row estimates to be -1")
+	}
+
+	if _, err := parseQueryPlan("q", map[string]interface{}{"status": "success"}); ErrorCode(err) != "E_PLAN_PARSE_FAILED" {
+		t.Errorf("expected E_PLAN_PARSE_FAILED, got %v", err)
+	}
+}
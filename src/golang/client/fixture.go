@@ -0,0 +1,355 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Record/replay fixtures let integration tests run without a live server.
+// A TrafficRecorder captures the raw commands and response lines exchanged with
+// a real server; a FixtureServer later replays them over TCP so the same tests
+// run deterministically in CI.
+//
+// Recording:
+//
+//	recorder := client.NewTrafficRecorder()
+//	opts.Dialer = recorder.Dial
+//	// ... run commands against a live server ...
+//	recorder.Save("testdata/fixtures/users.json")
+//
+// Replay:
+//
+//	fixture, _ := client.LoadFixture("testdata/fixtures/users.json")
+//	server, _ := client.NewFixtureServer(fixture)
+//	defer server.Close()
+//	c.Connect(ctx, server.ConnectionString(connStr))
+
+// fixtureVersion is the current fixture file format version.
+const fixtureVersion = 1
+
+// Fixture is a recording of client/server traffic.
+type Fixture struct {
+	Version   int               `json:"version"`
+	Exchanges []FixtureExchange `json:"exchanges"`
+}
+
+// FixtureExchange is one command and the raw response lines the server sent for it.
+// Connection handshakes are recorded with credentials redacted.
+type FixtureExchange struct {
+	Request  string   `json:"request"`
+	Response []string `json:"response"`
+}
+
+// LoadFixture reads a fixture file written by TrafficRecorder.Save.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	if fixture.Version != fixtureVersion {
+		return nil, fmt.Errorf("unsupported fixture version %d in %s", fixture.Version, path)
+	}
+	return &fixture, nil
+}
+
+// ============================================================================
+// Recording
+// ============================================================================
+
+// TrafficRecorder records raw traffic on connections it dials.
+// Use its Dial method as ClientOptions.Dialer.
+type TrafficRecorder struct {
+	exchanges []*FixtureExchange
+	mu        sync.Mutex
+}
+
+// NewTrafficRecorder creates an empty recorder.
+func NewTrafficRecorder() *TrafficRecorder {
+	return &TrafficRecorder{}
+}
+
+// Dial opens a TCP connection whose traffic is recorded.
+func (r *TrafficRecorder) Dial(ctx context.Context, network, address string) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingConn{Conn: conn, recorder: r}, nil
+}
+
+// Fixture returns a snapshot of the traffic recorded so far.
+func (r *TrafficRecorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fixture := &Fixture{
+		Version:   fixtureVersion,
+		Exchanges: make([]FixtureExchange, len(r.exchanges)),
+	}
+	for i, exchange := range r.exchanges {
+		fixture.Exchanges[i] = FixtureExchange{
+			Request:  exchange.Request,
+			Response: append([]string{}, exchange.Response...),
+		}
+	}
+	return fixture
+}
+
+// Save writes the recorded traffic to path, creating parent directories.
+func (r *TrafficRecorder) Save(path string) error {
+	data, err := json.MarshalIndent(r.Fixture(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// recordingConn splits written bytes into commands and read bytes into response
+// lines. Lines are attributed to the oldest command awaiting a response; a command
+// keeps receiving lines (e.g. the two-line handshake) until a later command has
+// been sent, which keeps pipelined commands paired with their responses.
+type recordingConn struct {
+	net.Conn
+	recorder *TrafficRecorder
+	written  bytes.Buffer
+	read     bytes.Buffer
+	pending  []*FixtureExchange
+}
+
+func (c *recordingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.written.Write(p[:n])
+
+	for {
+		idx := bytes.IndexByte(c.written.Bytes(), '\x04')
+		if idx < 0 {
+			break
+		}
+		command := string(c.written.Next(idx + 1)[:idx])
+
+		exchange := &FixtureExchange{Request: redactHandshake(command), Response: []string{}}
+		c.recorder.mu.Lock()
+		c.recorder.exchanges = append(c.recorder.exchanges, exchange)
+		c.pending = append(c.pending, exchange)
+		c.recorder.mu.Unlock()
+	}
+
+	return n, err
+}
+
+func (c *recordingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.read.Write(p[:n])
+
+	for {
+		idx := bytes.IndexByte(c.read.Bytes(), '\n')
+		if idx < 0 {
+			break
+		}
+		line := strings.TrimSuffix(string(c.read.Next(idx + 1)[:idx]), "\r")
+
+		c.recorder.mu.Lock()
+		// Advance past commands that already have a response
+		for len(c.pending) > 1 && len(c.pending[0].Response) > 0 {
+			c.pending = c.pending[1:]
+		}
+		if len(c.pending) > 0 {
+			c.pending[0].Response = append(c.pending[0].Response, line)
+		}
+		c.recorder.mu.Unlock()
+	}
+
+	return n, err
+}
+
+// redactHandshake replaces the password in a connection string command.
+// Format: syndrdb://HOST:PORT:DATABASE:USERNAME:PASSWORD;
+func redactHandshake(command string) string {
+	if !strings.HasPrefix(command, "syndrdb://") {
+		return command
+	}
+	parts := strings.SplitN(command, ":", 6)
+	if len(parts) < 6 {
+		return command
+	}
+	suffix := ""
+	if idx := strings.IndexAny(parts[5], ";?"); idx >= 0 {
+		suffix = parts[5][idx:]
+	}
+	parts[5] = "****" + suffix
+	return strings.Join(parts, ":")
+}
+
+// ============================================================================
+// Replay
+// ============================================================================
+
+// FixtureServer is a TCP server that replays a Fixture. Each command receives
+// the response recorded for the same command text; repeated commands replay
+// their recordings in order, and the last recording is reused once exhausted.
+// Any connection string is accepted as a handshake.
+type FixtureServer struct {
+	listener  net.Listener
+	responses map[string][][]string
+	next      map[string]int
+	misses    []string
+	conns     map[net.Conn]struct{}
+	mu        sync.Mutex
+	wg        sync.WaitGroup
+}
+
+// handshakeKey groups all recorded connection handshakes.
+const handshakeKey = "syndrdb://"
+
+// NewFixtureServer starts a server replaying fixture on a loopback port.
+func NewFixtureServer(fixture *Fixture) (*FixtureServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FixtureServer{
+		listener:  listener,
+		responses: make(map[string][][]string),
+		next:      make(map[string]int),
+		conns:     make(map[net.Conn]struct{}),
+	}
+	for _, exchange := range fixture.Exchanges {
+		key := fixtureKey(exchange.Request)
+		s.responses[key] = append(s.responses[key], exchange.Response)
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the server's host:port.
+func (s *FixtureServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// ConnectionString rewrites the host and port of connStr to point at the server.
+func (s *FixtureServer) ConnectionString(connStr string) string {
+	parts := strings.SplitN(strings.TrimPrefix(connStr, "syndrdb://"), ":", 3)
+	rest := ""
+	if len(parts) == 3 {
+		rest = ":" + parts[2]
+	}
+	return "syndrdb://" + s.Addr() + rest
+}
+
+// Misses returns commands for which no recording existed.
+func (s *FixtureServer) Misses() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.misses...)
+}
+
+// Close stops the server and closes open connections.
+func (s *FixtureServer) Close() error {
+	err := s.listener.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *FixtureServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *FixtureServer) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	reader := bufio.NewReader(conn)
+	for {
+		command, err := reader.ReadString('\x04')
+		if err != nil {
+			return
+		}
+		command = strings.TrimSuffix(command, "\x04")
+
+		var response bytes.Buffer
+		for _, line := range s.replay(command) {
+			response.WriteString(line)
+			response.WriteByte('\n')
+		}
+		if _, err := conn.Write(response.Bytes()); err != nil {
+			return
+		}
+	}
+}
+
+// replay returns the recorded response lines for command.
+func (s *FixtureServer) replay(command string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fixtureKey(command)
+	recordings := s.responses[key]
+	if len(recordings) == 0 {
+		s.misses = append(s.misses, command)
+		line, _ := json.Marshal(map[string]interface{}{
+			"success": false,
+			"error":   fmt.Sprintf("fixture: no recorded response for command: %s", command),
+		})
+		return []string{string(line)}
+	}
+
+	idx := s.next[key]
+	if idx < len(recordings)-1 {
+		s.next[key] = idx + 1
+	}
+	return recordings[idx]
+}
+
+// fixtureKey normalizes a command for lookup.
+func fixtureKey(command string) string {
+	if strings.HasPrefix(command, handshakeKey) {
+		return handshakeKey
+	}
+	return strings.TrimSpace(command)
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newEchoServer starts a server that completes the handshake and answers each
+// command with its text and a per-server sequence number.
+func newEchoServer(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var seq atomic.Int64
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					command, err := reader.ReadString('\x04')
+					if err != nil {
+						return
+					}
+					command = strings.TrimSuffix(command, "\x04")
+					if strings.HasPrefix(command, "syndrdb://") {
+						conn.Write([]byte("S0001 Welcome to SyndrDB\n{\"status\": \"success\"}\n"))
+						continue
+					}
+					n := seq.Add(1)
+					conn.Write([]byte(`{"success": true, "data": "` + command + ` #` + strconv.FormatInt(n, 10) + `"}` + "\n"))
+				}
+			}()
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+// TestFixtureRecordAndReplay verifies recorded traffic replays identically.
+func TestFixtureRecordAndReplay(t *testing.T) {
+	addr := newEchoServer(t)
+	connStr := "syndrdb://" + addr + ":primary:root:secret;"
+	commands := []string{"SHOW BUNDLES;", "SHOW DATABASES;", "SHOW BUNDLES;"}
+
+	// Record against the live server
+	recorder := NewTrafficRecorder()
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.Dialer = recorder.Dial
+	live := NewClient(&opts)
+	if err := live.Connect(context.Background(), connStr); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	var recorded []interface{}
+	for _, command := range commands {
+		result, err := live.Query(command, 1000)
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		recorded = append(recorded, result)
+	}
+	live.Disconnect(context.Background())
+
+	path := filepath.Join(t.TempDir(), "fixtures", "echo.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "secret") {
+		t.Error("expected password to be redacted from the fixture")
+	}
+
+	// Replay without the live server
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		t.Fatalf("LoadFixture failed: %v", err)
+	}
+	server, err := NewFixtureServer(fixture)
+	if err != nil {
+		t.Fatalf("NewFixtureServer failed: %v", err)
+	}
+	defer server.Close()
+
+	opts = DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	replay := NewClient(&opts)
+	if err := replay.Connect(context.Background(), server.ConnectionString(connStr)); err != nil {
+		t.Fatalf("Connect to fixture server failed: %v", err)
+	}
+	defer replay.Disconnect(context.Background())
+
+	for i, command := range commands {
+		result, err := replay.Query(command, 1000)
+		if err != nil {
+			t.Fatalf("replayed Query failed: %v", err)
+		}
+		if result != recorded[i] {
+			t.Errorf("command %d: expected %v, got %v", i, recorded[i], result)
+		}
+	}
+
+	// Unrecorded commands fail and are reported
+	if _, err := replay.Query("SHOW USERS;", 1000); ErrorCode(err) != "SERVER_ERROR" {
+		t.Errorf("expected SERVER_ERROR for unrecorded command, got %v", err)
+	}
+	if misses := server.Misses(); len(misses) != 1 || misses[0] != "SHOW USERS;" {
+		t.Errorf("expected one miss, got %v", misses)
+	}
+}
+
+// TestFixtureRecordsPipelinedResponses verifies pipelined responses pair with their commands.
+func TestFixtureRecordsPipelinedResponses(t *testing.T) {
+	addr := newEchoServer(t)
+
+	recorder := NewTrafficRecorder()
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.Dialer = recorder.Dial
+	c := NewClient(&opts)
+	if err := c.Connect(context.Background(), "syndrdb://"+addr+":primary:root:root;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer c.Disconnect(context.Background())
+
+	if _, err := c.Pipeline().Add("A;", "B;").Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	exchanges := recorder.Fixture().Exchanges
+	if len(exchanges) != 3 {
+		t.Fatalf("expected handshake and 2 commands, got %+v", exchanges)
+	}
+	if len(exchanges[0].Response) != 2 {
+		t.Errorf("expected 2-line handshake response, got %v", exchanges[0].Response)
+	}
+	for i, command := range []string{"A;", "B;"} {
+		exchange := exchanges[i+1]
+		if exchange.Request != command || len(exchange.Response) != 1 || !strings.Contains(exchange.Response[0], command) {
+			t.Errorf("exchange %d not paired correctly: %+v", i+1, exchange)
+		}
+	}
+}
+
+// TestRedactHandshake verifies the password is removed from connection strings.
+func TestRedactHandshake(t *testing.T) {
+	got := redactHandshake("syndrdb://localhost:1776:primary:root:secret;")
+	if got != "syndrdb://localhost:1776:primary:root:****;" {
+		t.Errorf("unexpected redaction: %s", got)
+	}
+	if got := redactHandshake("SHOW BUNDLES;"); got != "SHOW BUNDLES;" {
+		t.Errorf("expected commands to be unchanged, got %s", got)
+	}
+}
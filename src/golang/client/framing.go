@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+// Responses are made of frames. A frame is either a newline-terminated line
+// or a length-prefixed block: a "#<n>" line followed by n bytes, which may
+// contain newlines, and a newline. Most responses are a single frame. A
+// server streaming a large result sends its rows in partial frames, each
+// starting with partialFramePrefix, followed by an ordinary final frame that
+// ends the response. Both kinds of frame may be compressed.
+const partialFramePrefix = "P1:"
+
+// partialRowsKey marks a context whose partial frames are delivered to a
+// func([]interface{}) error as they arrive instead of being combined into
+// the result; see QueryStream.
+type partialRowsKey struct{}
+
+// readFrame reads one frame without its terminator. The returned slice is
+// only valid until the next read.
+func (c *Connection) readFrame() ([]byte, error) {
+	line, err := c.readLine()
+	if err != nil || len(line) < 2 || line[0] != '#' {
+		return line, err
+	}
+	n, convErr := strconv.Atoi(string(line[1:]))
+	if convErr != nil || n < 0 {
+		// Not a length prefix, just a line starting with '#'
+		return line, nil
+	}
+	if c.tooLong(n + 1) {
+		return nil, errResponseTooLong
+	}
+
+	buf := c.lineBuf[:0]
+	if cap(buf) < n {
+		buf = make([]byte, n)
+	}
+	buf = buf[:n]
+	if _, err := io.ReadFull(c.reader, buf); err != nil {
+		return nil, err
+	}
+	if cap(buf) <= maxRetainedLineBuffer {
+		c.lineBuf = buf
+	} else {
+		c.lineBuf = nil
+	}
+
+	// Consume the newline ending the block
+	if end, err := c.reader.ReadSlice('\n'); err != nil && !(err == io.EOF && len(bytes.TrimSpace(end)) == 0) {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// decodeFrame strips the partial frame marker from frame, reporting whether
+// it was present, and decompresses the payload if needed. A payload
+// decompressing to more than maxBytes fails with errResponseTooLong.
+func (c *Connection) decodeFrame(frame []byte, maxBytes int) ([]byte, bool, error) {
+	line := bytes.TrimSpace(frame)
+	partial := bytes.HasPrefix(line, []byte(partialFramePrefix))
+	if partial {
+		line = bytes.TrimSpace(line[len(partialFramePrefix):])
+	}
+
+	// Decompress negotiated compressed frames
+	if c.compression != "" && bytes.HasPrefix(line, []byte(compressedFramePrefix)) {
+		data, err := decompressFrame(c.compression, string(line), maxBytes)
+		if ErrorCode(err) == "E_RESPONSE_TOO_LARGE" {
+			return nil, partial, errResponseTooLong
+		}
+		if err != nil {
+			return nil, partial, err
+		}
+		line = bytes.TrimSpace(data)
+	}
+	return line, partial, nil
+}
+
+// mergeRows combines the rows of a response's partial frames with its final
+// frame. A final object keeps its other members, such as "ResultCount", and
+// gets the combined rows under "Result".
+func mergeRows(rows []interface{}, final interface{}) interface{} {
+	respMap, ok := final.(map[string]interface{})
+	if !ok {
+		return append(rows, resultRows(final)...)
+	}
+	merged := make(map[string]interface{}, len(respMap)+1)
+	for key, value := range respMap {
+		merged[key] = value
+	}
+	if own, ok := respMap["Result"].([]interface{}); ok {
+		rows = append(rows, own...)
+	}
+	merged["Result"] = rows
+	return merged
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestReceiveMultiFrameResponse verifies the rows of partial frames are
+// combined with the final frame, which keeps its other members.
+func TestReceiveMultiFrameResponse(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, "next") {
+			return `{"Result":["next"]}`
+		}
+		return "P1:[1,2]\nP1:{\"success\":true,\"data\":{\"Result\":[3]}}\n" + `{"ResultCount":4,"Result":[4]}`
+	})
+
+	result, err := c.Query(`SELECT * FROM BUNDLE "big";`, 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	want := map[string]interface{}{"ResultCount": 4.0, "Result": []interface{}{1.0, 2.0, 3.0, 4.0}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("got %v, want %v", result, want)
+	}
+
+	result, err = c.Query(`SELECT * FROM BUNDLE "next";`, 1000)
+	if err != nil || !reflect.DeepEqual(resultRows(result), []interface{}{"next"}) {
+		t.Errorf("expected the next response to be read in sync, got %v, %v", result, err)
+	}
+}
+
+// TestLengthPrefixedFrame verifies a length-prefixed frame may contain newlines.
+func TestLengthPrefixedFrame(t *testing.T) {
+	payload := "[1,\n2,\n3]"
+	c, _ := newPipeClient(t, func(command string) string {
+		return "#" + strconv.Itoa(len(payload)) + "\n" + payload
+	})
+
+	for i := 0; i < 2; i++ {
+		result, err := c.Query(`SELECT * FROM BUNDLE "lines";`, 1000)
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if !reflect.DeepEqual(result, []interface{}{1.0, 2.0, 3.0}) {
+			t.Errorf("got %v", result)
+		}
+	}
+}
+
+// TestMultiFrameFailureDrainsResponse verifies a failed partial frame fails
+// the command, and the rest of its response is read so the connection stays
+// usable.
+func TestMultiFrameFailureDrainsResponse(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		switch {
+		case strings.Contains(command, "broken"):
+			return "P1:[1]\nP1:{\"success\":false,\"error\":\"scan aborted\"}\nP1:[3]\n[4]"
+		case strings.Contains(command, "large"):
+			return "P1:[1,2]\nP1:[3,4]\n[5]"
+		}
+		return `["ok"]`
+	})
+	c.conn.setResponseLimits(0, 3)
+
+	if _, err := c.Query(`SELECT * FROM BUNDLE "broken";`, 1000); err == nil || !strings.Contains(err.Error(), "scan aborted") {
+		t.Errorf("expected the partial frame error, got %v", err)
+	}
+	if _, err := c.Query(`SELECT * FROM BUNDLE "large";`, 1000); ErrorCode(err) != "E_RESPONSE_TOO_LARGE" {
+		t.Errorf("expected E_RESPONSE_TOO_LARGE, got %v", err)
+	}
+	if !c.conn.IsAlive() {
+		t.Fatal("expected connection to remain alive")
+	}
+	result, err := c.Query(`SELECT * FROM BUNDLE "small";`, 1000)
+	if err != nil || !reflect.DeepEqual(result, []interface{}{"ok"}) {
+		t.Errorf("expected the next response to be read in sync, got %v, %v", result, err)
+	}
+}
+
+// TestQueryRawMultiFrame verifies QueryRaw combines the raw rows of each frame.
+func TestQueryRawMultiFrame(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return "P1:[{\"id\":1}]\nP1:{\"Result\":[{\"id\":2}]}\n" + `{"Result":[{"id":3}],"ResultCount":3}`
+	})
+
+	result, err := c.QueryRaw(context.Background(), `SELECT * FROM BUNDLE "users";`)
+	if err != nil {
+		t.Fatalf("QueryRaw failed: %v", err)
+	}
+	if result.Len() != 3 || result.Fields["ResultCount"] != 3.0 {
+		t.Fatalf("expected 3 rows and ResultCount, got %d rows and %v", result.Len(), result.Fields)
+	}
+	for i := 0; i < 3; i++ {
+		var row struct{ ID int }
+		if err := result.Decode(i, &row); err != nil || row.ID != i+1 {
+			t.Errorf("row %d: got %+v, %v", i, row, err)
+		}
+	}
+}
+
+// TestQueryStreamPartialFrames verifies QueryStream delivers the rows of each
+// partial frame before the response is complete.
+func TestQueryStreamPartialFrames(t *testing.T) {
+	clientSide, serverSide := net.Pipe()
+	t.Cleanup(func() {
+		clientSide.Close()
+		serverSide.Close()
+	})
+	release := make(chan struct{})
+	go func() {
+		if _, err := bufio.NewReader(serverSide).ReadString('\x04'); err != nil {
+			return
+		}
+		serverSide.Write([]byte("P1:[1,2]\n"))
+		<-release
+		serverSide.Write([]byte("P1:[3]\n{\"ResultCount\":3}\n"))
+	}()
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	c := NewClient(&opts)
+	c.conn = newConnection(clientSide, opts)
+	c.stateMgr.TransitionTo(CONNECTING, nil, nil)
+	c.stateMgr.TransitionTo(CONNECTED, nil, nil)
+
+	stream, err := c.QueryStream(context.Background(), `SELECT * FROM BUNDLE "events";`, 10)
+	if err != nil {
+		t.Fatalf("QueryStream failed: %v", err)
+	}
+	if batch := stream.NextBatch(); !reflect.DeepEqual(batch, []interface{}{1.0, 2.0}) {
+		t.Fatalf("expected the first frame's rows, got %v", batch)
+	}
+
+	close(release)
+	if batch := stream.NextBatch(); !reflect.DeepEqual(batch, []interface{}{3.0}) {
+		t.Errorf("expected the second frame's rows, got %v", batch)
+	}
+	if batch := stream.NextBatch(); batch != nil {
+		t.Errorf("expected the stream to end, got %v", batch)
+	}
+	if stream.Total() != 3 || stream.Delivered() != 3 || stream.Err() != nil {
+		t.Errorf("expected 3 rows delivered without error, got %d, %d, %v", stream.Total(), stream.Delivered(), stream.Err())
+	}
+}
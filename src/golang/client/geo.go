@@ -0,0 +1,163 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// GeoPoint is a location in WGS 84 degrees, stored in GEOPOINT fields and
+// sent as a GeoJSON Point. Its JSON form is the same GeoJSON object, so
+// repositories and json.Unmarshal read GEOPOINT values into it directly.
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// GeoPolygon is a closed area given by the points of its boundary, sent as a
+// GeoJSON Polygon. The ring is closed automatically if the last point does
+// not repeat the first.
+type GeoPolygon []GeoPoint
+
+// geoCircle is the operand of NEAR: a center and a radius in meters.
+type geoCircle struct {
+	center GeoPoint
+	radius float64
+}
+
+// validate reports coordinates outside the WGS 84 range.
+func (p GeoPoint) validate() error {
+	if math.IsNaN(p.Lat) || p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("latitude %v is outside [-90, 90]", p.Lat)
+	}
+	if math.IsNaN(p.Lon) || p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("longitude %v is outside [-180, 180]", p.Lon)
+	}
+	return nil
+}
+
+func (p GeoPolygon) validate() error {
+	if len(p) < 3 {
+		return fmt.Errorf("a polygon needs at least 3 points, got %d", len(p))
+	}
+	for _, point := range p {
+		if err := point.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c geoCircle) validate() error {
+	if math.IsNaN(c.radius) || c.radius <= 0 {
+		return fmt.Errorf("radius %v must be positive", c.radius)
+	}
+	return c.center.validate()
+}
+
+// appendCoordinates appends p as a GeoJSON position, longitude first.
+func (p GeoPoint) appendCoordinates(dst []byte) []byte {
+	dst = append(dst, '[')
+	dst = strconv.AppendFloat(dst, p.Lon, 'f', -1, 64)
+	dst = append(dst, ',')
+	dst = strconv.AppendFloat(dst, p.Lat, 'f', -1, 64)
+	return append(dst, ']')
+}
+
+// geoJSON renders p as a GeoJSON Point.
+func (p GeoPoint) geoJSON() string {
+	dst := []byte(`{"type":"Point","coordinates":`)
+	dst = p.appendCoordinates(dst)
+	return string(append(dst, '}'))
+}
+
+// geoJSON renders p as a GeoJSON Polygon with a single, closed ring.
+func (p GeoPolygon) geoJSON() string {
+	dst := []byte(`{"type":"Polygon","coordinates":[[`)
+	for i, point := range p {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = point.appendCoordinates(dst)
+	}
+	if len(p) > 0 && p[0] != p[len(p)-1] {
+		dst = append(dst, ',')
+		dst = p[0].appendCoordinates(dst)
+	}
+	return string(append(dst, "]]}"...))
+}
+
+// MarshalJSON encodes p as a GeoJSON Point.
+func (p GeoPoint) MarshalJSON() ([]byte, error) {
+	return []byte(p.geoJSON()), nil
+}
+
+// UnmarshalJSON decodes a GeoJSON Point.
+func (p *GeoPoint) UnmarshalJSON(data []byte) error {
+	var point struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &point); err != nil {
+		return err
+	}
+	if point.Type != "Point" || len(point.Coordinates) < 2 {
+		return fmt.Errorf("client: invalid GeoJSON point %s", data)
+	}
+	p.Lon, p.Lat = point.Coordinates[0], point.Coordinates[1]
+	return nil
+}
+
+// encodeGeo returns the GeoJSON literal of a geospatial value.
+func encodeGeo(value interface{}) interface{} {
+	switch v := value.(type) {
+	case GeoPoint:
+		return wireLiteral(v.geoJSON())
+	case *GeoPoint:
+		if v == nil {
+			return nil
+		}
+		return wireLiteral(v.geoJSON())
+	case GeoPolygon:
+		return wireLiteral(v.geoJSON())
+	case geoCircle:
+		return wireLiteral("(" + v.center.geoJSON() + ", " + strconv.FormatFloat(v.radius, 'f', -1, 64) + ")")
+	}
+	return value
+}
+
+// validateGeoClause checks the operand of a NEAR or WITHIN condition.
+func validateGeoClause(clause whereClause) error {
+	var err error
+	switch v := clause.value.(type) {
+	case geoCircle:
+		err = v.validate()
+	case GeoPolygon:
+		err = v.validate()
+	case GeoPoint:
+		err = v.validate()
+	default:
+		err = fmt.Errorf("unsupported operand %T", clause.value)
+	}
+	if err != nil {
+		return &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("invalid %s condition on %s: %v", clause.operator, clause.field, err),
+		}
+	}
+	return nil
+}
+
+// WhereNear adds a condition, with an implicit AND connector, matching
+// documents whose GEOPOINT field lies within radius meters of lat, lon.
+func (qb *QueryBuilder) WhereNear(field string, lat, lon, radius float64) *QueryBuilder {
+	return qb.Where(field, Near, geoCircle{center: GeoPoint{Lat: lat, Lon: lon}, radius: radius})
+}
+
+// WhereWithin adds a condition, with an implicit AND connector, matching
+// documents whose geospatial field lies inside polygon.
+func (qb *QueryBuilder) WhereWithin(field string, polygon GeoPolygon) *QueryBuilder {
+	return qb.Where(field, Within, polygon)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var (
+	ferryBuilding = GeoPoint{Lat: 37.7955, Lon: -122.3937}
+	downtownSF    = GeoPolygon{
+		{Lat: 37.80, Lon: -122.42},
+		{Lat: 37.80, Lon: -122.38},
+		{Lat: 37.77, Lon: -122.38},
+	}
+)
+
+func TestGeoJSONEncoding(t *testing.T) {
+	if got := encodeGeo(ferryBuilding); got != wireLiteral(`{"type":"Point","coordinates":[-122.3937,37.7955]}`) {
+		t.Errorf("unexpected point literal %v", got)
+	}
+	want := `{"type":"Polygon","coordinates":[[[-122.42,37.8],[-122.38,37.8],[-122.38,37.77],[-122.42,37.8]]]}`
+	if got := encodeGeo(downtownSF); got != wireLiteral(want) {
+		t.Errorf("expected the ring to be closed, got %v", got)
+	}
+
+	var decoded GeoPoint
+	data, _ := json.Marshal(ferryBuilding)
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != ferryBuilding {
+		t.Errorf("round trip: expected %v, got %v (%v)", ferryBuilding, decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`), &decoded); err == nil {
+		t.Error("expected an error decoding a non-point geometry")
+	}
+}
+
+func TestWhereNearAndWithin(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	ctx := context.Background()
+
+	if _, err := c.QueryBuilder().Select("stores").WhereNear("location", 37.7955, -122.3937, 500).Execute(ctx); err != nil {
+		t.Fatalf("WhereNear failed: %v", err)
+	}
+	if _, err := c.QueryBuilder().Select("stores").WhereWithin("location", downtownSF).Execute(ctx); err != nil {
+		t.Fatalf("WhereWithin failed: %v", err)
+	}
+	if _, err := c.InsertBuilder("stores").Values(map[string]interface{}{"location": ferryBuilding}).Execute(ctx); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	want := []string{
+		`WHERE location NEAR ({"type":"Point","coordinates":[-122.3937,37.7955]}, 500)`,
+		`WHERE location WITHIN {"type":"Polygon","coordinates":[[[-122.42,37.8],`,
+		`"location" =  {"type":"Point","coordinates":[-122.3937,37.7955]}`,
+	}
+	for i, command := range server.received() {
+		if !strings.Contains(command, want[i]) {
+			t.Errorf("command %d: expected %s in %s", i, want[i], command)
+		}
+	}
+}
+
+func TestGeoConditionValidation(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		qb   *QueryBuilder
+	}{
+		{"latitude", c.QueryBuilder().Select("stores").WhereNear("location", 91, 0, 10)},
+		{"radius", c.QueryBuilder().Select("stores").WhereNear("location", 0, 0, 0)},
+		{"polygon", c.QueryBuilder().Select("stores").WhereWithin("location", downtownSF[:2])},
+		{"operand", c.QueryBuilder().Select("stores").Where("location", Within, "downtown")},
+	}
+	for _, tt := range tests {
+		if _, err := tt.qb.Execute(ctx); ErrorCode(err) != "E_INVALID_QUERY" {
+			t.Errorf("%s: expected E_INVALID_QUERY, got %v", tt.name, err)
+		}
+	}
+	if len(server.received()) != 0 {
+		t.Error("expected nothing to be sent")
+	}
+}
+
+func TestRepositoryDecodesGeoPoints(t *testing.T) {
+	type store struct {
+		ID       string   `json:"DocumentID"`
+		Location GeoPoint `json:"location"`
+	}
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": [{"DocumentID": "s_1", "location": {"type": "Point", "coordinates": [-122.3937, 37.7955]}}]}}`
+	})
+
+	stores, err := NewGenericRepository[store](c, "stores").Find(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(stores) != 1 || stores[0].Location != ferryBuilding {
+		t.Errorf("unexpected stores: %+v", stores)
+	}
+}
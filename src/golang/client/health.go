@@ -208,6 +208,7 @@ func (c *Client) attemptReconnect(ctx context.Context) error {
 				c.opts.PoolIdleTimeout,
 				c.opts.HealthCheckInterval,
 			)
+			c.pool.configure(c.poolSettings())
 
 			if err := c.pool.Initialize(ctx); err == nil {
 				c.logger.Info("reconnection successful via pool")
@@ -254,3 +255,36 @@ func (c *Client) attemptReconnect(ctx context.Context) error {
 
 	return errors.New("reconnection failed after maximum attempts")
 }
+
+// startIdleProbe starts probing the single connection when it has been idle
+// for IdleProbeInterval, until done is closed. A connection that fails the
+// probe is replaced through automatic reconnection.
+func (c *Client) startIdleProbe(done <-chan struct{}) {
+	interval := c.opts.IdleProbeInterval
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+
+			case <-ticker.C:
+				conn := c.conn
+				if c.GetState() != CONNECTED || conn == nil || time.Since(conn.LastActivity()) < interval {
+					continue
+				}
+				if err := probeConnection(conn); err != nil {
+					c.logger.Warn("idle probe failed, reconnecting",
+						String("remoteAddr", conn.RemoteAddr()),
+						Error("error", err))
+					c.attemptReconnect(context.Background())
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,272 @@
+//go:build !wasm
+// +build !wasm
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HealthCheckOptions configures a HealthChecker.
+type HealthCheckOptions struct {
+	// Interval is how often checks run after Start (default: 10s).
+	Interval time.Duration
+
+	// Timeout bounds each check (default: 5s).
+	Timeout time.Duration
+
+	// Query is the command run by each check. Empty uses Ping.
+	Query string
+
+	// FailureThreshold is the number of consecutive failed checks after which
+	// the client is reported unhealthy (default: 3).
+	FailureThreshold int
+
+	// LatencySamples is the number of recent check latencies used for
+	// percentiles (default: 100).
+	LatencySamples int
+}
+
+// HealthLatency summarizes recent successful check latencies.
+type HealthLatency struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// HealthStatus is a snapshot of a HealthChecker's view of the client.
+type HealthStatus struct {
+	// Healthy is true once a check has succeeded, the client is connected and
+	// fewer than FailureThreshold consecutive checks have failed.
+	Healthy bool
+
+	State               ConnectionState
+	ConsecutiveFailures int
+	TotalChecks         int64
+	TotalFailures       int64
+	LastCheck           time.Time
+	LastSuccess         time.Time
+	LastError           error
+	Latency             HealthLatency
+}
+
+// HealthChecker runs periodic checks against a client and reports its health,
+// e.g. for readiness probes. Unlike HealthMonitor it never reconnects; it only
+// observes.
+type HealthChecker struct {
+	client *Client
+	opts   HealthCheckOptions
+
+	mu          sync.RWMutex
+	failures    int
+	checks      int64
+	failed      int64
+	lastCheck   time.Time
+	lastSuccess time.Time
+	lastErr     error
+	latencies   []time.Duration // ring buffer of recent latencies
+	next        int
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewHealthChecker creates a health checker for client. Call Start to run
+// checks periodically, or Check to run one on demand.
+func NewHealthChecker(client *Client, opts HealthCheckOptions) *HealthChecker {
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 3
+	}
+	if opts.LatencySamples <= 0 {
+		opts.LatencySamples = 100
+	}
+	return &HealthChecker{
+		client:    client,
+		opts:      opts,
+		latencies: make([]time.Duration, 0, opts.LatencySamples),
+	}
+}
+
+// Start runs a check immediately and then every Interval until Stop is called.
+func (h *HealthChecker) Start() {
+	h.mu.Lock()
+	if h.stopCh != nil {
+		h.mu.Unlock()
+		return
+	}
+	h.stopCh = make(chan struct{})
+	stopCh := h.stopCh
+	h.mu.Unlock()
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		ticker := time.NewTicker(h.opts.Interval)
+		defer ticker.Stop()
+
+		for {
+			h.Check(context.Background())
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops periodic checks and waits for a running check to finish.
+func (h *HealthChecker) Stop() {
+	h.mu.Lock()
+	stopCh := h.stopCh
+	h.stopCh = nil
+	h.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		h.wg.Wait()
+	}
+}
+
+// Check runs a single check, records its outcome and returns its error.
+func (h *HealthChecker) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	if h.opts.Query != "" {
+		_, err = h.client.executeWithTimeout(ctx, h.opts.Query, 0)
+	} else {
+		err = h.client.Ping(ctx)
+	}
+	latency := time.Since(start)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks++
+	h.lastCheck = start
+	if err != nil {
+		h.failed++
+		h.failures++
+		h.lastErr = err
+		return err
+	}
+
+	h.failures = 0
+	h.lastSuccess = start
+	h.lastErr = nil
+	if len(h.latencies) < cap(h.latencies) {
+		h.latencies = append(h.latencies, latency)
+	} else {
+		h.latencies[h.next] = latency
+		h.next = (h.next + 1) % len(h.latencies)
+	}
+	return nil
+}
+
+// GetHealth returns the current health status.
+func (h *HealthChecker) GetHealth() HealthStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	state := h.client.GetState()
+	return HealthStatus{
+		Healthy: state == CONNECTED && !h.lastSuccess.IsZero() &&
+			h.failures < h.opts.FailureThreshold,
+		State:               state,
+		ConsecutiveFailures: h.failures,
+		TotalChecks:         h.checks,
+		TotalFailures:       h.failed,
+		LastCheck:           h.lastCheck,
+		LastSuccess:         h.lastSuccess,
+		LastError:           h.lastErr,
+		Latency:             latencyPercentiles(h.latencies),
+	}
+}
+
+// latencyPercentiles computes nearest-rank percentiles of samples.
+func latencyPercentiles(samples []time.Duration) HealthLatency {
+	if len(samples) == 0 {
+		return HealthLatency{}
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) time.Duration {
+		i := int(p*float64(len(sorted))+0.5) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return HealthLatency{
+		P50: rank(0.50),
+		P90: rank(0.90),
+		P99: rank(0.99),
+		Max: sorted[len(sorted)-1],
+	}
+}
+
+// Handler returns an http.Handler for readiness endpoints such as /healthz.
+// It responds 200 when healthy and 503 otherwise, with the status as JSON:
+//
+//	http.Handle("/healthz", checker.Handler())
+func (h *HealthChecker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := h.GetHealth()
+
+		status := "ok"
+		code := http.StatusOK
+		if !health.Healthy {
+			status = "unavailable"
+			code = http.StatusServiceUnavailable
+		}
+
+		body := map[string]interface{}{
+			"status":               status,
+			"state":                health.State.String(),
+			"consecutive_failures": health.ConsecutiveFailures,
+			"total_checks":         health.TotalChecks,
+			"total_failures":       health.TotalFailures,
+			"latency_ms": map[string]float64{
+				"p50": durationMs(health.Latency.P50),
+				"p90": durationMs(health.Latency.P90),
+				"p99": durationMs(health.Latency.P99),
+				"max": durationMs(health.Latency.Max),
+			},
+		}
+		if !health.LastCheck.IsZero() {
+			body["last_check"] = health.LastCheck.Format(time.RFC3339Nano)
+		}
+		if !health.LastSuccess.IsZero() {
+			body["last_success"] = health.LastSuccess.Format(time.RFC3339Nano)
+		}
+		if health.LastError != nil {
+			body["last_error"] = health.LastError.Error()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(body)
+	})
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
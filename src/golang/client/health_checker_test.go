@@ -0,0 +1,130 @@
+//go:build !wasm
+// +build !wasm
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestHealthCheckerThreshold verifies health flips only after consecutive failures.
+func TestHealthCheckerThreshold(t *testing.T) {
+	var failing atomic.Bool
+	c, server := newPipeClient(t, func(command string) string {
+		if failing.Load() {
+			return `{"success":false,"message":"overloaded"}`
+		}
+		return `{"success":true}`
+	})
+
+	checker := NewHealthChecker(c, HealthCheckOptions{Query: "SHOW BUNDLES;", FailureThreshold: 2})
+	ctx := context.Background()
+
+	if checker.GetHealth().Healthy {
+		t.Error("expected unhealthy before the first check")
+	}
+	if err := checker.Check(ctx); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !checker.GetHealth().Healthy {
+		t.Error("expected healthy after a successful check")
+	}
+	if got := server.received(); len(got) != 1 || got[0] != "SHOW BUNDLES;" {
+		t.Errorf("expected the configured query, got %v", got)
+	}
+
+	failing.Store(true)
+	if err := checker.Check(ctx); err == nil {
+		t.Fatal("expected check to fail")
+	}
+	if health := checker.GetHealth(); !health.Healthy || health.ConsecutiveFailures != 1 {
+		t.Errorf("expected healthy below the threshold, got %+v", health)
+	}
+	checker.Check(ctx)
+	health := checker.GetHealth()
+	if health.Healthy || health.ConsecutiveFailures != 2 || health.LastError == nil {
+		t.Errorf("expected unhealthy at the threshold, got %+v", health)
+	}
+
+	failing.Store(false)
+	checker.Check(ctx)
+	health = checker.GetHealth()
+	if !health.Healthy || health.TotalChecks != 4 || health.TotalFailures != 2 || health.LastError != nil {
+		t.Errorf("expected recovery, got %+v", health)
+	}
+}
+
+// TestHealthCheckerStart verifies periodic checks run until Stop.
+func TestHealthCheckerStart(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string { return `{"success":true}` })
+
+	checker := NewHealthChecker(c, HealthCheckOptions{Interval: 5 * time.Millisecond})
+	checker.Start()
+	deadline := time.Now().Add(2 * time.Second)
+	for checker.GetHealth().TotalChecks < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	checker.Stop()
+
+	checks := checker.GetHealth().TotalChecks
+	if checks < 3 {
+		t.Fatalf("expected periodic checks, got %d", checks)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := checker.GetHealth().TotalChecks; got != checks {
+		t.Errorf("checks continued after Stop: %d -> %d", checks, got)
+	}
+}
+
+// TestLatencyPercentiles verifies nearest-rank percentiles.
+func TestLatencyPercentiles(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[len(samples)-1-i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	got := latencyPercentiles(samples)
+	want := HealthLatency{P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if (latencyPercentiles(nil) != HealthLatency{}) {
+		t.Error("expected zero percentiles without samples")
+	}
+}
+
+// TestHealthCheckerHandler verifies the HTTP status code and JSON body.
+func TestHealthCheckerHandler(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	checker := NewHealthChecker(c, HealthCheckOptions{})
+
+	serve := func() (*httptest.ResponseRecorder, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		checker.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid JSON body %q: %v", rec.Body.String(), err)
+		}
+		return rec, body
+	}
+
+	rec, body := serve()
+	if rec.Code != http.StatusServiceUnavailable || body["status"] != "unavailable" {
+		t.Errorf("expected 503 before the first check, got %d %v", rec.Code, body)
+	}
+
+	checker.Check(context.Background())
+	rec, body = serve()
+	if rec.Code != http.StatusOK || body["status"] != "ok" || body["state"] != "CONNECTED" {
+		t.Errorf("expected 200 when healthy, got %d %v", rec.Code, body)
+	}
+	if _, ok := body["latency_ms"].(map[string]interface{}); !ok {
+		t.Errorf("expected latency percentiles, got %v", body)
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// HedgeStats counts hedged reads. See ClientOptions.HedgeDelay.
+type HedgeStats struct {
+	Delay time.Duration // The client's HedgeDelay; zero if hedging is off by default
+
+	Eligible int64 // Reads sent with hedging enabled
+	Hedged   int64 // Reads that sent a hedge after the delay
+	Wins     int64 // Hedges that answered first
+	Denied   int64 // Hedges not sent because HedgeBudget was exhausted
+}
+
+// HedgeRate returns the fraction of eligible reads that were hedged.
+func (s HedgeStats) HedgeRate() float64 {
+	if s.Eligible == 0 {
+		return 0
+	}
+	return float64(s.Hedged) / float64(s.Eligible)
+}
+
+// WinRate returns the fraction of hedges that answered first.
+func (s HedgeStats) WinRate() float64 {
+	if s.Hedged == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Hedged)
+}
+
+// hedgeCounters accumulates HedgeStats.
+type hedgeCounters struct {
+	eligible atomic.Int64
+	hedged   atomic.Int64
+	wins     atomic.Int64
+	denied   atomic.Int64
+}
+
+type hedgeDelayKey struct{}
+
+// WithHedgeDelay overrides ClientOptions.HedgeDelay for reads executed with
+// ctx. Zero disables hedging for the call.
+func WithHedgeDelay(ctx context.Context, delay time.Duration) context.Context {
+	return context.WithValue(ctx, hedgeDelayKey{}, delay)
+}
+
+// hedgeDelayFor returns the hedge delay for command on ctx, or zero if it
+// must not be hedged: hedging needs a pool to take a second connection from,
+// applies only to SELECT and SHOW, and is skipped for streamed results and
+// while a session is pinned to the connection of its last write.
+func (c *Client) hedgeDelayFor(ctx context.Context, command string) time.Duration {
+	delay := c.opts.HedgeDelay
+	if d, ok := ctx.Value(hedgeDelayKey{}).(time.Duration); ok {
+		delay = d
+	}
+	if delay <= 0 || !c.poolEnabled || c.pool == nil || !isHedgeableCommand(command) {
+		return 0
+	}
+	if ctx.Value(partialRowsKey{}) != nil {
+		return 0
+	}
+	if session := SessionFromContext(ctx); session != nil && session.Pinned() {
+		return 0
+	}
+	return delay
+}
+
+// isHedgeableCommand reports whether command is a read that may be sent twice.
+// Unlike retries, WithIdempotent does not make writes hedgeable.
+func isHedgeableCommand(command string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(command))
+	return strings.HasPrefix(upper, "SELECT") || strings.HasPrefix(upper, "SHOW")
+}
+
+// sendAttempt sends one attempt of command, hedged if hedgeDelayFor allows.
+func (c *Client) sendAttempt(ctx context.Context, command string) (interface{}, error) {
+	if delay := c.hedgeDelayFor(ctx, command); delay > 0 {
+		return c.sendHedged(ctx, command, delay)
+	}
+	return c.sendCommandOnce(ctx, command)
+}
+
+// hedgeOutcome is the response to one copy of a hedged command.
+type hedgeOutcome struct {
+	result interface{}
+	err    error
+	hedge  bool
+}
+
+// sendHedged sends command and, if it has not been answered within delay,
+// sends it again on another pooled connection, returning whichever answers
+// first. The loser's context is cancelled: a copy still waiting for a
+// connection is abandoned, and one already sent finishes in the background,
+// its response discarded, so that its connection stays usable.
+func (c *Client) sendHedged(ctx context.Context, command string, delay time.Duration) (interface{}, error) {
+	c.hedges.eligible.Add(1)
+	if budget := c.opts.HedgeBudget; budget != nil {
+		budget.deposit()
+	}
+
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make(chan hedgeOutcome, 2)
+	send := func(hedge bool) {
+		result, err := c.sendCommandOnce(hedgeCtx, command)
+		outcomes <- hedgeOutcome{result: result, err: err, hedge: hedge}
+	}
+	go send(false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	pending := 1
+	for {
+		select {
+		case outcome := <-outcomes:
+			pending--
+			// A copy that fails first waits for the other, if one was sent
+			if outcome.err != nil && pending > 0 {
+				continue
+			}
+			if outcome.hedge && outcome.err == nil {
+				c.hedges.wins.Add(1)
+			}
+			return outcome.result, outcome.err
+
+		case <-timer.C:
+			if budget := c.opts.HedgeBudget; budget != nil && !budget.withdraw() {
+				c.hedges.denied.Add(1)
+				continue
+			}
+			c.hedges.hedged.Add(1)
+			c.logger.Debug("hedging slow read",
+				String("command", command),
+				Duration("delay", delay))
+			pending++
+			go send(true)
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// HedgeStats returns counters of the reads hedged under HedgeDelay or
+// WithHedgeDelay.
+func (c *Client) HedgeStats() HedgeStats {
+	return HedgeStats{
+		Delay:    c.opts.HedgeDelay,
+		Eligible: c.hedges.eligible.Load(),
+		Hedged:   c.hedges.hedged.Load(),
+		Wins:     c.hedges.wins.Load(),
+		Denied:   c.hedges.denied.Load(),
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newHedgePoolClient connects a pooled client to a server that answers the
+// first SELECT slowly and reports which connection served each command.
+func newHedgePoolClient(t *testing.T, opts ClientOptions) *Client {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var accepted, selects atomic.Int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			id := accepted.Add(1)
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for first := true; ; first = false {
+					command, err := reader.ReadString('\x04')
+					if err != nil {
+						return
+					}
+					response := fmt.Sprintf(`{"success":true,"data":{"conn":%d}}`, id) + "\n"
+					if first {
+						response = "S0001::Welcome\n" + `{"status":"success"}` + "\n"
+					} else if strings.HasPrefix(command, "SELECT") && selects.Add(1) == 1 {
+						time.Sleep(300 * time.Millisecond)
+					}
+					if _, err := conn.Write([]byte(response)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	opts.Logger = NewNoopLogger()
+	opts.PoolMinSize = 2
+	opts.PoolMaxSize = 2
+	c := NewClient(&opts)
+	if err := c.Connect(context.Background(), "syndrdb://"+ln.Addr().String()+":primary:root:root;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c
+}
+
+// TestHedgedRead verifies a slow read is sent again on another connection
+// and the faster response is returned.
+func TestHedgedRead(t *testing.T) {
+	opts := DefaultOptions()
+	opts.HedgeDelay = 20 * time.Millisecond
+	c := newHedgePoolClient(t, opts)
+	ctx := context.Background()
+
+	start := time.Now()
+	servedBy(t, c, ctx, `SELECT * FROM BUNDLE "orders";`)
+	if elapsed := time.Since(start); elapsed >= 300*time.Millisecond {
+		t.Errorf("expected the hedge to answer before the slow read, took %v", elapsed)
+	}
+	stats := c.HedgeStats()
+	if stats.Eligible != 1 || stats.Hedged != 1 || stats.Wins != 1 {
+		t.Errorf("unexpected stats after a hedged read: %+v", stats)
+	}
+
+	// Writes and calls with hedging disabled are sent once
+	servedBy(t, c, ctx, `ADD DOCUMENT TO BUNDLE "orders" WITH ({"id" = 1});`)
+	servedBy(t, c, WithHedgeDelay(ctx, 0), `SELECT * FROM BUNDLE "orders";`)
+	if got := c.HedgeStats(); got.Eligible != 1 {
+		t.Errorf("expected writes and disabled calls not to be hedged: %+v", got)
+	}
+	if rate := c.HedgeStats().HedgeRate(); rate != 1 {
+		t.Errorf("expected a hedge rate of 1, got %v", rate)
+	}
+
+	// The slow connection is returned to the pool once its response arrives
+	deadline := time.Now().Add(2 * time.Second)
+	for idleConnections(c) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the losing connection to return to the pool")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestHedgeBudget verifies hedges are not sent once HedgeBudget is exhausted.
+func TestHedgeBudget(t *testing.T) {
+	opts := DefaultOptions()
+	opts.HedgeDelay = 20 * time.Millisecond
+	opts.HedgeBudget = NewRetryBudget(0, 0)
+	c := newHedgePoolClient(t, opts)
+
+	start := time.Now()
+	servedBy(t, c, context.Background(), `SELECT * FROM BUNDLE "orders";`)
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("expected the read to wait for the slow response, took %v", elapsed)
+	}
+	stats := c.HedgeStats()
+	if stats.Eligible != 1 || stats.Hedged != 0 || stats.Denied != 1 {
+		t.Errorf("unexpected stats with an exhausted budget: %+v", stats)
+	}
+}
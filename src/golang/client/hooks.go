@@ -2,7 +2,12 @@ package client
 
 import (
 	"context"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // HookContext contains information about the command being executed.
@@ -23,9 +28,15 @@ type HookContext struct {
 	// Metadata allows hooks to store arbitrary data for passing between Before/After
 	Metadata map[string]interface{}
 
-	// TraceID is the unique identifier for this command execution
+	// TraceID identifies this command execution: the ID set with WithTraceID,
+	// shared by every command of the request, or else a fresh UUID
 	TraceID string
 
+	// TransactionID identifies the transaction the command belongs to.
+	// Empty outside transactions. For BEGIN it is set once the server returns the ID
+	// and is therefore only available in the After hook.
+	TransactionID string
+
 	// Result stores the command result (set after execution, available in After hook)
 	Result interface{}
 
@@ -34,10 +45,37 @@ type HookContext struct {
 
 	// Duration is the execution time (available in After hook)
 	Duration time.Duration
+
+	// ResponseBytes is the size of the raw server response (available in After hook).
+	// Zero if no response was read or the connection does not report sizes.
+	ResponseBytes int
+
+	// ConnectionID identifies the connection that served the command, for
+	// connections that report one (see Connection.ID); zero otherwise.
+	// Set once a connection is acquired; commands that acquire one after
+	// the Before hooks, such as pooled queries, report it only in After.
+	ConnectionID uint64
+
+	// RemoteAddr is the server address of the connection that served the
+	// command. Set alongside ConnectionID.
+	RemoteAddr string
+
+	// PoolWaitDuration is how long the command waited to acquire a pooled
+	// connection. Zero when the pool is disabled or no connection was acquired.
+	PoolWaitDuration time.Duration
+
+	// Attempt is the 1-based attempt number of the command under the client's
+	// retry policy. Retries run the hooks again with Attempt incremented.
+	Attempt int
+
+	// hooks are the hooks selected for Before, so After runs the same set
+	// even if a hook rewrote Command
+	hooks []Hook
 }
 
 // Hook is the interface that all hooks must implement.
-// Hooks can inspect, modify, or abort command execution.
+// Hooks can inspect, modify, or abort command execution. To wrap execution,
+// e.g. to retry or short-circuit it, use a Middleware.
 type Hook interface {
 	// Name returns the unique name of this hook
 	Name() string
@@ -53,33 +91,114 @@ type Hook interface {
 	After(ctx context.Context, hookCtx *HookContext) error
 }
 
-// hookEntry wraps a Hook with its registration order for stable iteration.
+// TransactionEndHook is implemented by hooks that keep per-transaction state.
+// TransactionEnded is called once a transaction is released for any reason,
+// including when the timeout monitor or Disconnect gives up on a rollback.
+// It is called for every registered hook, whatever its filter or enabled state.
+type TransactionEndHook interface {
+	TransactionEnded(txID string)
+}
+
+// hookEntry wraps a Hook with its priority, registration order, and scoping.
 type hookEntry struct {
-	hook  Hook
-	order int
+	hook     Hook
+	order    int
+	priority int
+	disabled bool
+	filter   *HookFilter
 }
 
-// RegisterHook adds a hook to the client's hook chain.
-// Hooks are executed in FIFO order (first registered, first executed).
+// HookFilter scopes a hook to a subset of commands, so expensive hooks such
+// as tracing can be targeted narrowly. Empty fields match every command; a
+// command must match every non-empty field.
+type HookFilter struct {
+	// CommandTypes limits the hook to these HookContext.CommandType values,
+	// e.g. "query" or "mutation".
+	CommandTypes []string
+
+	// Bundles limits the hook to commands on these bundles.
+	Bundles []string
+
+	// Pattern limits the hook to commands it matches.
+	Pattern *regexp.Regexp
+}
+
+// Matches reports whether a command of commandType passes the filter.
+func (f *HookFilter) Matches(commandType, command string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.CommandTypes) > 0 && !containsFold(f.CommandTypes, commandType) {
+		return false
+	}
+	if len(f.Bundles) > 0 && !containsFold(f.Bundles, CommandBundle(command)) {
+		return false
+	}
+	if f.Pattern != nil && !f.Pattern.MatchString(command) {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterHook adds a hook to the client's hook chain with priority 0.
+// Hooks of equal priority are executed in FIFO order (first registered, first executed).
 // If a hook with the same name already exists, it is replaced.
 func (c *Client) RegisterHook(hook Hook) {
+	c.registerHook(hook, 0, false)
+}
+
+// RegisterHookWithPriority adds a hook that runs before hooks of lower priority,
+// in both Before and After. If a hook with the same name already exists, it is
+// replaced and takes the new priority.
+func (c *Client) RegisterHookWithPriority(hook Hook, priority int) {
+	c.registerHook(hook, priority, true)
+}
+
+func (c *Client) registerHook(hook Hook, priority int, setPriority bool) {
 	c.hooksMu.Lock()
 	defer c.hooksMu.Unlock()
 
 	// Check if hook already exists
 	for i, entry := range c.hooks {
 		if entry.hook.Name() == hook.Name() {
-			// Replace existing hook, preserve order
+			// Replace existing hook, preserve order, filter, and enabled state
 			c.hooks[i].hook = hook
+			if setPriority {
+				c.hooks[i].priority = priority
+				c.sortHooks()
+			}
 			c.logger.Info("hook replaced", String("hook", hook.Name()))
 			return
 		}
 	}
 
 	// Add new hook
-	order := len(c.hooks)
-	c.hooks = append(c.hooks, hookEntry{hook: hook, order: order})
-	c.logger.Info("hook registered", String("hook", hook.Name()), Int("order", order))
+	order := c.nextHookOrder
+	c.nextHookOrder++
+	c.hooks = append(c.hooks, hookEntry{hook: hook, order: order, priority: priority})
+	c.sortHooks()
+	c.logger.Info("hook registered", String("hook", hook.Name()), Int("order", order), Int("priority", priority))
+}
+
+// sortHooks orders hooks by descending priority, then registration order.
+// Callers must hold hooksMu.
+func (c *Client) sortHooks() {
+	sort.SliceStable(c.hooks, func(i, j int) bool {
+		if c.hooks[i].priority != c.hooks[j].priority {
+			return c.hooks[i].priority > c.hooks[j].priority
+		}
+		return c.hooks[i].order < c.hooks[j].order
+	})
 }
 
 // UnregisterHook removes a hook by name.
@@ -100,7 +219,53 @@ func (c *Client) UnregisterHook(name string) bool {
 	return false
 }
 
-// GetHooks returns the names of all registered hooks in execution order.
+// EnableHook resumes a hook paused with DisableHook.
+// Returns true if the hook was found, false otherwise.
+func (c *Client) EnableHook(name string) bool {
+	return c.updateHook(name, func(entry *hookEntry) { entry.disabled = false })
+}
+
+// DisableHook pauses a hook without unregistering it, keeping its position
+// and filter. Returns true if the hook was found, false otherwise.
+func (c *Client) DisableHook(name string) bool {
+	return c.updateHook(name, func(entry *hookEntry) { entry.disabled = true })
+}
+
+// IsHookEnabled reports whether a hook is registered and enabled.
+func (c *Client) IsHookEnabled(name string) bool {
+	c.hooksMu.RLock()
+	defer c.hooksMu.RUnlock()
+
+	for _, entry := range c.hooks {
+		if entry.hook.Name() == name {
+			return !entry.disabled
+		}
+	}
+	return false
+}
+
+// SetHookFilter scopes a hook to the commands matching filter; nil removes
+// the filter. Returns true if the hook was found, false otherwise.
+func (c *Client) SetHookFilter(name string, filter *HookFilter) bool {
+	return c.updateHook(name, func(entry *hookEntry) { entry.filter = filter })
+}
+
+// updateHook applies update to the hook registered as name.
+func (c *Client) updateHook(name string, update func(entry *hookEntry)) bool {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+
+	for i := range c.hooks {
+		if c.hooks[i].hook.Name() == name {
+			update(&c.hooks[i])
+			return true
+		}
+	}
+	return false
+}
+
+// GetHooks returns the names of all registered hooks, including disabled
+// ones, in execution order.
 func (c *Client) GetHooks() []string {
 	c.hooksMu.RLock()
 	defer c.hooksMu.RUnlock()
@@ -112,15 +277,80 @@ func (c *Client) GetHooks() []string {
 	return names
 }
 
-// executeBeforeHooks runs all Before hooks in order.
+// activeHooks returns the enabled hooks whose filters match command, in execution order.
+func (c *Client) activeHooks(commandType, command string) []Hook {
+	c.hooksMu.RLock()
+	defer c.hooksMu.RUnlock()
+
+	hooks := make([]Hook, 0, len(c.hooks))
+	for _, entry := range c.hooks {
+		if entry.disabled || !entry.filter.Matches(commandType, command) {
+			continue
+		}
+		hooks = append(hooks, entry.hook)
+	}
+	return hooks
+}
+
+// newHookContext creates a HookContext for command with the trace ID set on
+// ctx, or a fresh one. Query tags and priority set on ctx are copied into Metadata.
+func newHookContext(ctx context.Context, command string) *HookContext {
+	traceID := TraceIDFromContext(ctx)
+	if traceID == "" {
+		traceID = uuid.New().String()
+	}
+	hookCtx := &HookContext{
+		Command:     command,
+		CommandType: inferCommandType(command),
+		StartTime:   time.Now(),
+		Metadata:    make(map[string]interface{}),
+		TraceID:     traceID,
+		Attempt:     commandAttempt(ctx),
+	}
+	applyQueryMetadata(ctx, hookCtx)
+	return hookCtx
+}
+
+// runWithHooks executes fn wrapped in the Before/After hook chain.
+// fn receives the (possibly hook-modified) command. Used for commands bound to a
+// specific connection, such as transaction commands, that bypass sendCommand.
+func (c *Client) runWithHooks(ctx context.Context, hookCtx *HookContext, fn func(command string) (interface{}, error)) (interface{}, error) {
+	if err := c.executeBeforeHooks(ctx, hookCtx); err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	err := c.opts.AccessPolicy.Check(hookCtx.Command)
+	if err == nil {
+		result, err = fn(hookCtx.Command)
+	}
+
+	hookCtx.Result = result
+	hookCtx.Error = err
+	hookCtx.Duration = time.Since(hookCtx.StartTime)
+
+	if hookErr := c.executeAfterHooks(ctx, hookCtx); hookErr != nil {
+		// Hook error replaces original error
+		err = hookErr
+	}
+
+	return result, err
+}
+
+// executeBeforeHooks runs the Before hooks of enabled hooks whose filters match, in order.
+// Filters see the original command, before redaction.
 // If any hook returns an error, execution stops and the error is returned.
+// With a redaction policy, hooks see the redacted Command and Params; if no
+// hook changed Command, the original command is restored for sending.
 func (c *Client) executeBeforeHooks(ctx context.Context, hookCtx *HookContext) error {
-	c.hooksMu.RLock()
-	hooks := make([]Hook, len(c.hooks))
-	for i, entry := range c.hooks {
-		hooks[i] = entry.hook
+	command := hookCtx.Command
+	hooks := c.activeHooks(hookCtx.CommandType, command)
+	hookCtx.hooks = hooks
+	if c.redaction != nil {
+		hookCtx.Params = c.redaction.redactParams(command, hookCtx.Params)
+		hookCtx.Command = c.redaction.RedactCommand(command)
 	}
-	c.hooksMu.RUnlock()
+	redacted := hookCtx.Command
 
 	for _, hook := range hooks {
 		if err := hook.Before(ctx, hookCtx); err != nil {
@@ -132,19 +362,29 @@ func (c *Client) executeBeforeHooks(ctx context.Context, hookCtx *HookContext) e
 		}
 	}
 
+	if hookCtx.Command == redacted {
+		hookCtx.Command = command
+	}
 	return nil
 }
 
-// executeAfterHooks runs all After hooks in order.
+// executeAfterHooks runs the After hooks of the hooks selected by executeBeforeHooks, in order.
 // All hooks are executed even if one returns an error.
 // The last error returned (if any) is returned.
 func (c *Client) executeAfterHooks(ctx context.Context, hookCtx *HookContext) error {
-	c.hooksMu.RLock()
-	hooks := make([]Hook, len(c.hooks))
-	for i, entry := range c.hooks {
-		hooks[i] = entry.hook
+	hooks := hookCtx.hooks
+	if hooks == nil {
+		hooks = c.activeHooks(hookCtx.CommandType, hookCtx.Command)
+	}
+
+	if c.redaction != nil {
+		hookCtx.Params = c.redaction.redactParams(hookCtx.Command, hookCtx.Params)
+		hookCtx.Command = c.redaction.RedactCommand(hookCtx.Command)
+		hookCtx.Error = c.redaction.redactError(hookCtx.Error)
+	}
+	if hookCtx.Error != nil {
+		c.recentErrors.record(hookCtx)
 	}
-	c.hooksMu.RUnlock()
 
 	var lastErr error
 	for _, hook := range hooks {
@@ -183,6 +423,8 @@ func inferCommandType(command string) string {
 		return "mutation"
 	case len(command) >= 6 && (command[:6] == "DELETE" || command[:6] == "delete"):
 		return "mutation"
+	case len(command) >= 4 && (command[:4] == "ADD " || command[:4] == "add "):
+		return "mutation"
 	case len(command) >= 5 && (command[:5] == "BEGIN" || command[:5] == "begin"):
 		return "transaction"
 	case len(command) >= 6 && (command[:6] == "COMMIT" || command[:6] == "commit"):
@@ -199,3 +441,32 @@ func inferCommandType(command string) string {
 		return "unknown"
 	}
 }
+
+// setConnection records conn as the connection serving the command.
+func (h *HookContext) setConnection(conn ConnectionInterface) {
+	if conn == nil {
+		return
+	}
+	if identified, ok := conn.(connectionIdentifier); ok {
+		h.ConnectionID = identified.ID()
+	}
+	h.RemoteAddr = conn.RemoteAddr()
+}
+
+// connectionIdentifier is implemented by connections that report an ID.
+type connectionIdentifier interface {
+	ID() uint64
+}
+
+// responseSizer is implemented by connections that report response sizes.
+type responseSizer interface {
+	LastResponseSize() int
+}
+
+// responseSize returns the size of the last response read on conn, or 0 if unknown.
+func responseSize(conn ConnectionInterface) int {
+	if sizer, ok := conn.(responseSizer); ok {
+		return sizer.LastResponseSize()
+	}
+	return 0
+}
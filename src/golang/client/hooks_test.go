@@ -3,8 +3,12 @@ package client
 import (
 	"context"
 	"errors"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/transport/mock"
 )
 
 // TestHook is a simple hook for testing.
@@ -148,6 +152,130 @@ func TestHookExecutionOrder(t *testing.T) {
 	}
 }
 
+// TestHookPriorityOrder verifies higher-priority hooks run first and ties keep FIFO order.
+func TestHookPriorityOrder(t *testing.T) {
+	opts := DefaultOptions()
+	client := NewClient(&opts)
+
+	var order []string
+	client.RegisterHook(&OrderTrackingHook{name: "default", order: &order})
+	client.RegisterHookWithPriority(&OrderTrackingHook{name: "low", order: &order}, -10)
+	client.RegisterHookWithPriority(&OrderTrackingHook{name: "high", order: &order}, 10)
+	client.RegisterHookWithPriority(&OrderTrackingHook{name: "high2", order: &order}, 10)
+
+	want := []string{"high", "high2", "default", "low"}
+	if got := client.GetHooks(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected GetHooks %v, got %v", want, got)
+	}
+
+	client.executeBeforeHooks(context.Background(), &HookContext{Command: "test", Metadata: make(map[string]interface{})})
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("expected execution order %v, got %v", want, order)
+	}
+
+	// Replacing with a new priority moves the hook
+	client.RegisterHookWithPriority(&OrderTrackingHook{name: "low", order: &order}, 20)
+	if got := client.GetHooks(); got[0] != "low" {
+		t.Errorf("expected replaced hook to move first, got %v", got)
+	}
+}
+
+// TestHookEnableDisable verifies disabled hooks are skipped but stay registered.
+func TestHookEnableDisable(t *testing.T) {
+	opts := DefaultOptions()
+	client := NewClient(&opts)
+
+	hook := &TestHook{name: "tracing"}
+	client.RegisterHook(hook)
+
+	if !client.DisableHook("tracing") {
+		t.Fatal("expected DisableHook to find the hook")
+	}
+	if client.IsHookEnabled("tracing") {
+		t.Error("expected hook to be disabled")
+	}
+
+	ctx := context.Background()
+	hookCtx := &HookContext{Command: "test", Metadata: make(map[string]interface{})}
+	client.executeBeforeHooks(ctx, hookCtx)
+	client.executeAfterHooks(ctx, hookCtx)
+	if hook.beforeCalled || hook.afterCalled {
+		t.Error("expected disabled hook not to run")
+	}
+	if len(client.GetHooks()) != 1 {
+		t.Error("expected disabled hook to stay registered")
+	}
+
+	client.EnableHook("tracing")
+	hookCtx = &HookContext{Command: "test", Metadata: make(map[string]interface{})}
+	client.executeBeforeHooks(ctx, hookCtx)
+	if !hook.beforeCalled {
+		t.Error("expected re-enabled hook to run")
+	}
+
+	if client.DisableHook("missing") || client.EnableHook("missing") {
+		t.Error("expected unknown hook names to return false")
+	}
+}
+
+// TestHookFilter verifies filters scope hooks by command type, bundle, and pattern.
+func TestHookFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *HookFilter
+		command string
+		want    bool
+	}{
+		{"nil filter", nil, `DELETE DOCUMENTS FROM "users";`, true},
+		{"type match", &HookFilter{CommandTypes: []string{"query"}}, `SELECT * FROM "users";`, true},
+		{"type mismatch", &HookFilter{CommandTypes: []string{"query"}}, `DELETE DOCUMENTS FROM "users";`, false},
+		{"bundle match", &HookFilter{Bundles: []string{"orders"}}, `SELECT * FROM "orders" WHERE "id" == 1;`, true},
+		{"bundle mismatch", &HookFilter{Bundles: []string{"orders"}}, `SELECT * FROM "users";`, false},
+		{"pattern match", &HookFilter{Pattern: regexp.MustCompile(`(?i)WHERE`)}, `SELECT * FROM "users" WHERE "id" == 1;`, true},
+		{"pattern mismatch", &HookFilter{Pattern: regexp.MustCompile(`(?i)WHERE`)}, `SELECT * FROM "users";`, false},
+		{"all fields", &HookFilter{CommandTypes: []string{"mutation"}, Bundles: []string{"users"}}, `SELECT * FROM "users";`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			client := NewClient(&opts)
+
+			hook := &TestHook{name: "scoped"}
+			client.RegisterHook(hook)
+			client.SetHookFilter("scoped", tt.filter)
+
+			ctx := context.Background()
+			hookCtx := &HookContext{Command: tt.command, CommandType: inferCommandType(tt.command), Metadata: make(map[string]interface{})}
+			client.executeBeforeHooks(ctx, hookCtx)
+			client.executeAfterHooks(ctx, hookCtx)
+			if hook.beforeCalled != tt.want || hook.afterCalled != tt.want {
+				t.Errorf("expected hook called = %v, got Before %v After %v", tt.want, hook.beforeCalled, hook.afterCalled)
+			}
+		})
+	}
+}
+
+// TestHookFilterAfterCommandRewrite verifies After runs for the hooks selected
+// in Before even if an earlier hook rewrote the command.
+func TestHookFilterAfterCommandRewrite(t *testing.T) {
+	opts := DefaultOptions()
+	client := NewClient(&opts)
+
+	client.RegisterHookWithPriority(&TestHook{name: "rewriter", modifyCmd: `SELECT * FROM "archive";`}, 10)
+	scoped := &TestHook{name: "scoped"}
+	client.RegisterHook(scoped)
+	client.SetHookFilter("scoped", &HookFilter{Bundles: []string{"users"}})
+
+	ctx := context.Background()
+	hookCtx := newHookContext(ctx, `SELECT * FROM "users";`)
+	client.executeBeforeHooks(ctx, hookCtx)
+	client.executeAfterHooks(ctx, hookCtx)
+	if !scoped.beforeCalled || !scoped.afterCalled {
+		t.Errorf("expected scoped hook in Before and After, got %v %v", scoped.beforeCalled, scoped.afterCalled)
+	}
+}
+
 // TestBeforeHookAbort verifies a Before hook can abort command execution.
 func TestBeforeHookAbort(t *testing.T) {
 	opts := DefaultOptions()
@@ -300,6 +428,7 @@ func TestInferCommandType(t *testing.T) {
 		{"INSERT INTO users VALUES (1, 'test')", "mutation"},
 		{"UPDATE users SET name='test'", "mutation"},
 		{"DELETE FROM users WHERE id=1", "mutation"},
+		{`ADD DOCUMENT TO BUNDLE "users" WITH ({"name" = "test"})`, "mutation"},
 		{"BEGIN TRANSACTION", "transaction"},
 		{"COMMIT", "transaction"},
 		{"ROLLBACK", "transaction"},
@@ -410,3 +539,117 @@ func TestMultipleHooksModifyCommand(t *testing.T) {
 		t.Errorf("expected %q, got %q", expected, hookCtx.Command)
 	}
 }
+
+// txRecordingHook records the commands and transaction IDs seen in After.
+type txRecordingHook struct {
+	commands []string
+	txIDs    []string
+}
+
+func (h *txRecordingHook) Name() string { return "tx-recorder" }
+func (h *txRecordingHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	return nil
+}
+func (h *txRecordingHook) After(ctx context.Context, hookCtx *HookContext) error {
+	h.commands = append(h.commands, hookCtx.Command)
+	h.txIDs = append(h.txIDs, hookCtx.TransactionID)
+	return nil
+}
+
+// TestTransactionCommandsRunHooks verifies transaction commands pass through the hook chain.
+func TestTransactionCommandsRunHooks(t *testing.T) {
+	opts := DefaultOptions()
+	client := NewClient(&opts)
+
+	hook := &txRecordingHook{}
+	client.RegisterHook(hook)
+
+	mockTransport := mock.NewMockTransport().WithReceiveData([]byte("OK\x04"))
+	tx := &Transaction{
+		id:     "TX_1",
+		conn:   NewTransportConnection(mockTransport, "localhost:1776"),
+		client: client,
+	}
+
+	if _, err := tx.Query(`SELECT * FROM "users";`, 0); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	expected := []string{`SELECT * FROM "users";`, "COMMIT;"}
+	if len(hook.commands) != len(expected) {
+		t.Fatalf("expected %d hooked commands, got %v", len(expected), hook.commands)
+	}
+	for i, command := range expected {
+		if hook.commands[i] != command {
+			t.Errorf("command %d: expected %q, got %q", i, command, hook.commands[i])
+		}
+		if hook.txIDs[i] != "TX_1" {
+			t.Errorf("command %d: expected transaction ID TX_1, got %q", i, hook.txIDs[i])
+		}
+	}
+}
+
+// TestParseTransactionID verifies TX_ID extraction from BEGIN responses.
+func TestParseTransactionID(t *testing.T) {
+	if id := parseTransactionID("Transaction started with ID: TX_123_abc"); id != "TX_123_abc" {
+		t.Errorf("expected TX_123_abc, got %q", id)
+	}
+	if id := parseTransactionID("unexpected"); id != "" {
+		t.Errorf("expected empty ID, got %q", id)
+	}
+	if id := parseTransactionID(42); id != "" {
+		t.Errorf("expected empty ID for non-string response, got %q", id)
+	}
+}
+
+// TestHookContextConnectionFields verifies hooks see the serving connection and attempt.
+func TestHookContextConnectionFields(t *testing.T) {
+	calls := 0
+	c, _ := newPipeClient(t, func(command string) string {
+		calls++
+		if calls == 1 {
+			return `{"status":"error","code":"E_UNAVAILABLE","message":"try again"}`
+		}
+		return `{"status":"ok"}`
+	})
+	c.conn.id = 7
+
+	var attempts []*HookContext
+	c.RegisterHook(&funcHook{
+		before: func(*HookContext) {},
+		after:  func(hookCtx *HookContext) { attempts = append(attempts, hookCtx) },
+	})
+
+	policy := &RetryPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     2,
+		RetryableCodes: []string{"E_UNAVAILABLE"},
+	}
+	ctx := WithRetryPolicy(context.Background(), policy)
+	if _, err := c.sendCommand(ctx, `SELECT * FROM "users";`); err != nil {
+		t.Fatalf("sendCommand failed: %v", err)
+	}
+
+	if len(attempts) != 2 {
+		t.Fatalf("expected hooks to run for 2 attempts, got %d", len(attempts))
+	}
+	for i, hookCtx := range attempts {
+		if hookCtx.Attempt != i+1 {
+			t.Errorf("expected Attempt %d, got %d", i+1, hookCtx.Attempt)
+		}
+		if hookCtx.ConnectionID != 7 {
+			t.Errorf("expected ConnectionID 7, got %d", hookCtx.ConnectionID)
+		}
+		if hookCtx.RemoteAddr != c.conn.RemoteAddr() {
+			t.Errorf("expected RemoteAddr %q, got %q", c.conn.RemoteAddr(), hookCtx.RemoteAddr)
+		}
+		if hookCtx.PoolWaitDuration != 0 {
+			t.Errorf("expected no pool wait without a pool, got %v", hookCtx.PoolWaitDuration)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator creates document IDs for inserts. When ClientOptions.IDGenerator
+// is set, InsertBuilder fills ClientOptions.IDField with a new ID unless the
+// caller set it, and reports the ID in MutationResult.
+type IDGenerator interface {
+	// GenerateID returns a new, unique ID. An empty ID leaves the field unset,
+	// so the server assigns one.
+	GenerateID() (string, error)
+}
+
+// IDGeneratorFunc adapts a function to IDGenerator.
+type IDGeneratorFunc func() (string, error)
+
+// GenerateID implements IDGenerator.
+func (f IDGeneratorFunc) GenerateID() (string, error) {
+	return f()
+}
+
+// ServerAssignedIDs leaves IDs to the server. It is equivalent to no
+// generator, and documents the choice when set explicitly.
+var ServerAssignedIDs IDGenerator = IDGeneratorFunc(func() (string, error) {
+	return "", nil
+})
+
+// UUIDv7Generator generates RFC 9562 version 7 UUIDs, which sort by creation
+// time and so keep index inserts local.
+type UUIDv7Generator struct{}
+
+// GenerateID implements IDGenerator.
+func (UUIDv7Generator) GenerateID() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator generates ULIDs: 26-character, lexicographically sortable IDs
+// of a millisecond timestamp and 80 random bits. IDs from one generator within
+// the same millisecond increment the random part, so they stay ordered.
+// The zero value is ready to use.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	lastMs  uint64
+	entropy [10]byte
+}
+
+// GenerateID implements IDGenerator.
+func (g *ULIDGenerator) GenerateID() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(time.Now().UnixMilli())
+	if ms <= g.lastMs {
+		// Same millisecond (or a clock step back): increment the entropy
+		ms = g.lastMs
+		i := len(g.entropy) - 1
+		for ; i >= 0; i-- {
+			g.entropy[i]++
+			if g.entropy[i] != 0 {
+				break
+			}
+		}
+		if i < 0 {
+			return "", fmt.Errorf("ULID entropy exhausted within one millisecond")
+		}
+	} else if _, err := rand.Read(g.entropy[:]); err != nil {
+		return "", err
+	}
+	g.lastMs = ms
+
+	var data [16]byte
+	for i := 0; i < 6; i++ {
+		data[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(data[6:], g.entropy[:])
+	return encodeULID(data), nil
+}
+
+// encodeULID encodes 128 bits as 26 base32 characters, 5 bits each,
+// with the first character carrying the top 3 bits.
+func encodeULID(data [16]byte) string {
+	var out [26]byte
+	var acc uint64
+	bits := 2 // 130 output bits; pad with two leading zero bits
+	pos := 0
+	for _, b := range data {
+		acc = acc<<8 | uint64(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockford[(acc>>uint(bits))&0x1f]
+			pos++
+		}
+	}
+	return string(out[:])
+}
+
+// snowflakeEpoch is the epoch of snowflake timestamps, 2024-01-01T00:00:00Z,
+// giving 41 bits of milliseconds about 69 years of range.
+var snowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	maxSnowflakeNode      = 1<<snowflakeNodeBits - 1
+	maxSnowflakeSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+// SnowflakeGenerator generates 64-bit, time-ordered integer IDs from a
+// millisecond timestamp, a node number and a per-millisecond sequence, written
+// as decimal strings. Each process generating IDs concurrently needs its own
+// node number.
+type SnowflakeGenerator struct {
+	mu       sync.Mutex
+	node     int64
+	lastMs   int64
+	sequence int64
+	now      func() time.Time
+}
+
+// NewSnowflakeGenerator returns a generator for node, which must be in [0, 1023].
+func NewSnowflakeGenerator(node int64) (*SnowflakeGenerator, error) {
+	if node < 0 || node > maxSnowflakeNode {
+		return nil, fmt.Errorf("snowflake node %d out of range [0, %d]", node, maxSnowflakeNode)
+	}
+	return &SnowflakeGenerator{node: node, now: time.Now}, nil
+}
+
+// GenerateID implements IDGenerator.
+func (g *SnowflakeGenerator) GenerateID() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := g.now().UnixMilli() - snowflakeEpoch
+	if ms < g.lastMs {
+		// The clock stepped back; keep issuing from the last millisecond
+		ms = g.lastMs
+	}
+	if ms == g.lastMs {
+		g.sequence = (g.sequence + 1) & maxSnowflakeSequence
+		if g.sequence == 0 {
+			// Sequence exhausted: wait for the next millisecond
+			for ms <= g.lastMs {
+				time.Sleep(100 * time.Microsecond)
+				ms = g.now().UnixMilli() - snowflakeEpoch
+			}
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastMs = ms
+
+	id := ms<<(snowflakeNodeBits+snowflakeSequenceBits) | g.node<<snowflakeSequenceBits | g.sequence
+	return strconv.FormatInt(id, 10), nil
+}
+
+// withGeneratedID returns a copy of the builder with a generated ID in the
+// client's IDField, and the ID. The builder is returned unchanged, with an
+// empty ID, when there is no generator or the field is already set.
+func (ib *InsertBuilder) withGeneratedID() (*InsertBuilder, string, error) {
+	generator := ib.client.opts.IDGenerator
+	if generator == nil {
+		return ib, "", nil
+	}
+	field := ib.client.opts.IDField
+	if field == "" {
+		field = "id" // Default field
+	}
+	if _, set := ib.values[field]; set {
+		return ib, "", nil
+	}
+
+	id, err := generator.GenerateID()
+	if err != nil {
+		return nil, "", &QueryError{
+			Code:    "E_ID_GENERATION_FAILED",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("failed to generate %s for insert into %s", field, ib.bundle),
+			Details: map[string]interface{}{"bundle": ib.bundle, "field": field},
+			Cause:   err,
+		}
+	}
+	if id == "" {
+		return ib, "", nil
+	}
+
+	values := make(map[string]interface{}, len(ib.values)+1)
+	for k, v := range ib.values {
+		values[k] = v
+	}
+	values[field] = id
+	withID := *ib
+	withID.values = values
+	return &withID, id, nil
+}
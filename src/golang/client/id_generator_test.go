@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUUIDv7Generator(t *testing.T) {
+	id, err := UUIDv7Generator{}.GenerateID()
+	if err != nil {
+		t.Fatalf("GenerateID failed: %v", err)
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil || parsed.Version() != 7 {
+		t.Errorf("expected a version 7 UUID, got %q (%v)", id, err)
+	}
+}
+
+func TestULIDGeneratorOrdered(t *testing.T) {
+	var g ULIDGenerator
+	prev := ""
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id, err := g.GenerateID()
+		if err != nil {
+			t.Fatalf("GenerateID failed: %v", err)
+		}
+		if len(id) != 26 || strings.Trim(id, crockford) != "" {
+			t.Fatalf("invalid ULID %q", id)
+		}
+		if id <= prev || seen[id] {
+			t.Fatalf("expected increasing unique IDs, got %q after %q", id, prev)
+		}
+		seen[id] = true
+		prev = id
+	}
+}
+
+func TestEncodeULID(t *testing.T) {
+	var max [16]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	if got := encodeULID(max); got != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("unexpected encoding of the largest ULID: %s", got)
+	}
+	if got := encodeULID([16]byte{15: 1}); got != "00000000000000000000000001" {
+		t.Errorf("unexpected encoding of 1: %s", got)
+	}
+}
+
+func TestSnowflakeGenerator(t *testing.T) {
+	if _, err := NewSnowflakeGenerator(1024); err == nil {
+		t.Error("expected an error for an out-of-range node")
+	}
+
+	g, err := NewSnowflakeGenerator(7)
+	if err != nil {
+		t.Fatalf("NewSnowflakeGenerator failed: %v", err)
+	}
+	fixed := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return fixed }
+
+	var prev int64
+	for i := 0; i < 100; i++ {
+		id, err := g.GenerateID()
+		if err != nil {
+			t.Fatalf("GenerateID failed: %v", err)
+		}
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || n <= prev {
+			t.Fatalf("expected increasing integer IDs, got %q after %d", id, prev)
+		}
+		if node := n >> snowflakeSequenceBits & maxSnowflakeNode; node != 7 {
+			t.Fatalf("expected node 7 in %d, got %d", n, node)
+		}
+		prev = n
+	}
+	if ms := prev >> (snowflakeNodeBits + snowflakeSequenceBits); ms != fixed.UnixMilli()-snowflakeEpoch {
+		t.Errorf("unexpected timestamp %d", ms)
+	}
+}
+
+func TestInsertGeneratesID(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	c.opts.IDGenerator = IDGeneratorFunc(func() (string, error) { return "gen_1", nil })
+	ctx := context.Background()
+
+	result, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "Ada"}).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.GeneratedID != "gen_1" || len(result.InsertedIDs) != 1 || result.InsertedIDs[0] != "gen_1" {
+		t.Errorf("expected the generated ID in the result, got %+v", result)
+	}
+	if command := server.received()[0]; !strings.Contains(command, `"id" =  "gen_1"`) {
+		t.Errorf("expected the generated ID to be sent: %s", command)
+	}
+
+	// A caller-set ID is kept
+	result, err = c.InsertBuilder("users").Values(map[string]interface{}{"id": "mine"}).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result.GeneratedID != "" || strings.Contains(server.received()[1], "gen_1") {
+		t.Errorf("expected no generated ID when the field is set, got %+v", result)
+	}
+
+	c.opts.IDGenerator = ServerAssignedIDs
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "Ada"}).Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if command := server.received()[2]; strings.Contains(command, `"id"`) {
+		t.Errorf("expected server-assigned IDs to leave the field unset: %s", command)
+	}
+}
+
+func TestInsertIDGenerationFailure(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	cause := errors.New("entropy unavailable")
+	c.opts.IDGenerator = IDGeneratorFunc(func() (string, error) { return "", cause })
+	c.opts.IDField = "key"
+
+	_, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "Ada"}).Execute(context.Background())
+	if ErrorCode(err) != "E_ID_GENERATION_FAILED" || !errors.Is(err, cause) {
+		t.Errorf("expected E_ID_GENERATION_FAILED wrapping the cause, got %v", err)
+	}
+	if len(server.received()) != 0 {
+		t.Error("expected nothing to be sent")
+	}
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// showBundlesCommand lists every bundle with its metadata.
+const showBundlesCommand = "SHOW BUNDLES;"
+
+// GetSchema fetches the database schema with SHOW BUNDLES and parses it.
+// Both the server's BundleMetadata response and the simplified
+// {"bundles": [...]} format accepted by schema.ParseServerSchema are supported.
+// When schema validation is enabled the validator's cached schema is refreshed.
+func (c *Client) GetSchema(ctx context.Context) (*schema.SchemaDefinition, error) {
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("GetSchema", CONNECTED, c.stateMgr.GetState())
+	}
+
+	result, err := c.executeWithTimeout(ctx, showBundlesCommand, 0)
+	if err != nil {
+		return nil, &QueryError{
+			Code:    "E_SCHEMA_FETCH_FAILED",
+			Type:    "QueryError",
+			Message: "failed to fetch schema",
+			Query:   showBundlesCommand,
+			Cause:   err,
+		}
+	}
+
+	schemaDef, err := parseSchemaResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.schemaValidator != nil {
+		c.schemaValidator.setSchema(schemaDef)
+	}
+	return schemaDef, nil
+}
+
+// DescribeBundle returns the definition of a single bundle.
+// Returns a QueryError with code E_BUNDLE_NOT_FOUND if it does not exist.
+func (c *Client) DescribeBundle(ctx context.Context, name string) (*schema.BundleDefinition, error) {
+	schemaDef, err := c.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range schemaDef.Bundles {
+		if schemaDef.Bundles[i].Name == name {
+			return &schemaDef.Bundles[i], nil
+		}
+	}
+
+	return nil, &QueryError{
+		Code:    "E_BUNDLE_NOT_FOUND",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("bundle not found: %s", name),
+		Details: map[string]interface{}{"bundle": name},
+	}
+}
+
+// ListIndexes returns the indexes defined on a bundle.
+func (c *Client) ListIndexes(ctx context.Context, bundle string) ([]schema.IndexDefinition, error) {
+	bundleDef, err := c.DescribeBundle(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	return bundleDef.Indexes, nil
+}
+
+// parseSchemaResult converts a SHOW BUNDLES result into a schema definition.
+func parseSchemaResult(result interface{}) (*schema.SchemaDefinition, error) {
+	// Raw JSON responses are decoded first so both formats are handled below
+	var raw []byte
+	switch v := result.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	}
+	if raw != nil {
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, schemaParseError("schema response is not valid JSON", err, result)
+		}
+	}
+
+	if response, ok := result.(map[string]interface{}); ok {
+		if items, ok := response["Result"].([]interface{}); ok {
+			return parseBundleMetadata(items), nil
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, schemaParseError("failed to marshal schema response", err, result)
+	}
+	schemaDef, err := schema.ParseServerSchema(data)
+	if err != nil {
+		return nil, schemaParseError("failed to parse schema response", err, result)
+	}
+	return schemaDef, nil
+}
+
+// parseBundleMetadata converts the server's SHOW BUNDLES result entries, each
+// holding a BundleMetadata object with DocumentStructure.FieldDefinitions and
+// Indexes maps. Fields and indexes are sorted by name for stable output.
+func parseBundleMetadata(items []interface{}) *schema.SchemaDefinition {
+	schemaDef := &schema.SchemaDefinition{
+		Bundles: make([]schema.BundleDefinition, 0, len(items)),
+	}
+
+	for _, item := range items {
+		entry, _ := item.(map[string]interface{})
+		metadata, ok := entry["BundleMetadata"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		bundle := schema.BundleDefinition{
+			Name:          stringValue(metadata["Name"]),
+			Fields:        make([]schema.FieldDefinition, 0),
+			Indexes:       make([]schema.IndexDefinition, 0),
+			Relationships: make([]schema.RelationshipDefinition, 0),
+		}
+
+		structure, _ := metadata["DocumentStructure"].(map[string]interface{})
+		fieldDefs, _ := structure["FieldDefinitions"].(map[string]interface{})
+		for key, value := range fieldDefs {
+			field, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name := stringValue(field["Name"])
+			if name == "" {
+				name = key
+			}
+			bundle.Fields = append(bundle.Fields, schema.FieldDefinition{
+				Name:         name,
+				Type:         schema.FieldType(stringValue(field["Type"])),
+				Required:     field["Required"] == true,
+				Unique:       field["Unique"] == true,
+				DefaultValue: field["DefaultValue"],
+			})
+		}
+		sort.Slice(bundle.Fields, func(i, j int) bool {
+			return bundle.Fields[i].Name < bundle.Fields[j].Name
+		})
+
+		indexes, _ := metadata["Indexes"].(map[string]interface{})
+		for name, value := range indexes {
+			index, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			fields := make([]string, 0, 1)
+			if hashField, ok := index["HashIndexField"].(map[string]interface{}); ok {
+				if fieldName := stringValue(hashField["FieldName"]); fieldName != "" {
+					fields = append(fields, fieldName)
+				}
+			}
+			bundle.Indexes = append(bundle.Indexes, schema.IndexDefinition{
+				Name:   name,
+				Type:   schema.IndexType(stringValue(index["IndexType"])),
+				Fields: fields,
+			})
+		}
+		sort.Slice(bundle.Indexes, func(i, j int) bool {
+			return bundle.Indexes[i].Name < bundle.Indexes[j].Name
+		})
+
+		schemaDef.Bundles = append(schemaDef.Bundles, bundle)
+	}
+
+	return schemaDef
+}
+
+// schemaParseError reports a SHOW BUNDLES response that could not be parsed.
+func schemaParseError(message string, cause error, result interface{}) *QueryError {
+	return &QueryError{
+		Code:    "E_SCHEMA_PARSE_FAILED",
+		Type:    "QueryError",
+		Message: message,
+		Details: map[string]interface{}{"response_type": fmt.Sprintf("%T", result)},
+		Query:   showBundlesCommand,
+		Cause:   cause,
+	}
+}
+
+func stringValue(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
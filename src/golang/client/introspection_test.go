@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// showBundlesResponse is a SHOW BUNDLES response in the server's BundleMetadata format.
+var showBundlesResponse = strings.NewReplacer("\n", "", "\t", "").Replace(`{"success": true, "data": {"Result": [
+	{"BundleMetadata": {
+		"Name": "users",
+		"DocumentStructure": {"FieldDefinitions": {
+			"name": {"Name": "name", "Type": "STRING", "Required": true, "Unique": false, "DefaultValue": null},
+			"email": {"Name": "email", "Type": "STRING", "Required": true, "Unique": true, "DefaultValue": null}
+		}},
+		"Indexes": {
+			"idx_email": {"IndexType": "hash", "HashIndexField": {"FieldName": "email"}}
+		}
+	}},
+	{"BundleMetadata": {"Name": "posts", "DocumentStructure": {"FieldDefinitions": {}}}}
+]}}`)
+
+func TestGetSchema(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return showBundlesResponse
+	})
+
+	schemaDef, err := c.GetSchema(context.Background())
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	if got := server.received(); len(got) != 1 || got[0] != "SHOW BUNDLES;" {
+		t.Errorf("expected SHOW BUNDLES, got %v", got)
+	}
+
+	if len(schemaDef.Bundles) != 2 {
+		t.Fatalf("expected 2 bundles, got %d", len(schemaDef.Bundles))
+	}
+	users := schemaDef.Bundles[0]
+	if users.Name != "users" || len(users.Fields) != 2 {
+		t.Fatalf("unexpected users bundle: %+v", users)
+	}
+	// Fields are sorted by name
+	email := users.Fields[0]
+	if email.Name != "email" || email.Type != schema.STRING || !email.Required || !email.Unique {
+		t.Errorf("unexpected email field: %+v", email)
+	}
+}
+
+func TestDescribeBundleAndListIndexes(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return showBundlesResponse
+	})
+	ctx := context.Background()
+
+	bundle, err := c.DescribeBundle(ctx, "posts")
+	if err != nil {
+		t.Fatalf("DescribeBundle failed: %v", err)
+	}
+	if bundle.Name != "posts" || len(bundle.Fields) != 0 {
+		t.Errorf("unexpected posts bundle: %+v", bundle)
+	}
+
+	indexes, err := c.ListIndexes(ctx, "users")
+	if err != nil {
+		t.Fatalf("ListIndexes failed: %v", err)
+	}
+	if len(indexes) != 1 || indexes[0].Name != "idx_email" || indexes[0].Type != schema.HASH || indexes[0].Fields[0] != "email" {
+		t.Errorf("unexpected indexes: %+v", indexes)
+	}
+
+	if _, err := c.DescribeBundle(ctx, "missing"); ErrorCode(err) != "E_BUNDLE_NOT_FOUND" {
+		t.Errorf("expected E_BUNDLE_NOT_FOUND, got %v", err)
+	}
+}
+
+func TestGetSchemaErrors(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success": false, "error": "permission denied"}`
+	})
+	_, err := c.GetSchema(context.Background())
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) || queryErr.Code != "E_SCHEMA_FETCH_FAILED" {
+		t.Errorf("expected E_SCHEMA_FETCH_FAILED, got %v", err)
+	}
+	if ErrorCode(err) != "SERVER_ERROR" {
+		t.Errorf("expected the server error to remain visible, got %s", ErrorCode(err))
+	}
+
+	if _, err := parseSchemaResult("not json"); ErrorCode(err) != "E_SCHEMA_PARSE_FAILED" {
+		t.Errorf("expected E_SCHEMA_PARSE_FAILED, got %v", err)
+	}
+}
+
+func TestParseSchemaResultSimplifiedFormat(t *testing.T) {
+	schemaDef, err := parseSchemaResult(map[string]interface{}{
+		"bundles": []interface{}{
+			map[string]interface{}{
+				"name":    "users",
+				"fields":  []interface{}{map[string]interface{}{"name": "age", "type": "INT"}},
+				"indexes": map[string]interface{}{"btree": []interface{}{map[string]interface{}{"name": "idx_age", "fields": []interface{}{"age"}}}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("parseSchemaResult failed: %v", err)
+	}
+	if len(schemaDef.Bundles) != 1 || schemaDef.Bundles[0].Indexes[0].Type != schema.BTREE {
+		t.Errorf("unexpected schema: %+v", schemaDef)
+	}
+}
+
+func TestOnSchemaChange(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": "ok"}`
+	})
+
+	changes := make(chan string, 2)
+	c.OnSchemaChange(func(command string) {
+		changes <- command
+	})
+
+	if _, err := c.Query(`SELECT * FROM "users";`, 0); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	ddl := `CREATE BUNDLE "accounts" WITH FIELDS ({"id", "int"});`
+	if _, err := c.Mutate(ddl, 0); err != nil {
+		t.Fatalf("Mutate failed: %v", err)
+	}
+
+	select {
+	case command := <-changes:
+		if command != ddl {
+			t.Errorf("expected handler to receive %q, got %q", ddl, command)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("schema change handler was not called")
+	}
+
+	select {
+	case command := <-changes:
+		t.Errorf("unexpected schema change for %q", command)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
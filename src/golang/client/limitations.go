@@ -54,9 +54,10 @@ package client
 // Cannot implement partial rollback within transaction.
 // Limits error recovery strategies in complex transaction workflows.
 
-// TODO: Isolation levels not configurable. Server provides READ COMMITTED isolation only.
-// SET TRANSACTION ISOLATION LEVEL command not available.
-// Transactions see only committed data from other transactions.
+// Isolation levels are negotiated: BeginWithIsolation sends
+// BEGIN TRANSACTION ISOLATION LEVEL <level> only to servers that list the level in the
+// "isolation_levels" capability of their authentication response. Servers that don't
+// advertise it provide READ COMMITTED only; other levels fail with E_ISOLATION_UNSUPPORTED.
 
 // TODO: Two-phase commit (2PC) protocol not available for distributed transactions.
 // Cannot coordinate transactions across multiple SyndrDB instances.
@@ -76,9 +77,9 @@ package client
 // invalidate prepared statement cache automatically.
 // Risk: Cached statements become invalid after schema migration without notification.
 
-// TODO: Query execution plans (EXPLAIN output) not available for optimization.
-// Cannot analyze slow queries or verify index usage from client.
-// Limits performance tuning capabilities.
+// Query execution plans are available through Client.Explain and
+// QueryBuilder.Explain on servers that support EXPLAIN. Servers that advertise
+// features without "explain" fail fast with E_FEATURE_UNSUPPORTED.
 
 // Performance and Resource Limitations
 
@@ -93,10 +94,16 @@ package client
 // TODO: Streaming result sets not supported. All query results loaded into memory.
 // Cannot process large result sets incrementally with cursor/iterator pattern.
 // Blocks processing of multi-GB result sets that exceed memory limits.
+// ClientOptions.MaxResponseBytes and MaxRowsInMemory bound what a single
+// response may allocate, failing with E_RESPONSE_TOO_LARGE instead.
+// Client.QueryStream hands a received result to consumers in batches, which
+// keeps WASM callers responsive but does not reduce what the driver holds.
 
 // TODO: Compression not available for protocol messages.
 // Large parameter values or result sets consume significant bandwidth.
 // Consider: Client-side compression before sending if protocol adds support.
+// ClientOptions.Compression negotiates gzip-compressed responses with servers
+// that advertise it during authentication; commands are still sent uncompressed.
 
 // Security Limitations
 
@@ -125,7 +132,7 @@ package client
 // | COMMIT                     | ✅ Available | Current        | Implemented    |
 // | ROLLBACK                   | ✅ Available | Current        | Implemented    |
 // | Nested transactions        | ❌ Blocked   | Planned        | TODO           |
-// | Isolation levels           | ❌ Blocked   | Planned        | Stubbed        |
+// | Isolation levels           | ✅ Available | Negotiated     | Implemented    |
 // | Savepoints                 | ❌ Blocked   | Planned        | TODO           |
 // | Query streaming            | ❌ Blocked   | Not Started    | TODO           |
 // | Schema introspection       | ❌ Blocked   | Not Started    | TODO           |
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LimiterStats is a snapshot of the client's command limiter.
+type LimiterStats struct {
+	Enabled     bool
+	InFlight    int
+	MaxInFlight int // Zero when concurrency is unlimited
+
+	// Utilization is InFlight/MaxInFlight, or 0 without a concurrency limit.
+	Utilization float64
+
+	// Tokens is the number of commands that may start now under CommandsPerSecond.
+	Tokens float64
+
+	Admitted     int64
+	Rejected     int64
+	WaitDuration time.Duration // Total time commands spent waiting for capacity
+}
+
+// commandLimiter bounds the commands a client executes, both concurrently
+// (MaxInFlightCommands) and per second (CommandsPerSecond with CommandBurst).
+type commandLimiter struct {
+	slots   chan struct{} // nil when concurrency is unlimited
+	rate    float64
+	burst   float64
+	maxWait time.Duration
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	admitted atomic.Int64
+	rejected atomic.Int64
+	waitNs   atomic.Int64
+}
+
+// newCommandLimiter returns a limiter for opts, or nil if no limit is configured.
+func newCommandLimiter(opts *ClientOptions) *commandLimiter {
+	if opts.MaxInFlightCommands <= 0 && opts.CommandsPerSecond <= 0 {
+		return nil
+	}
+
+	l := &commandLimiter{maxWait: opts.LimiterMaxWait}
+	if opts.MaxInFlightCommands > 0 {
+		l.slots = make(chan struct{}, opts.MaxInFlightCommands)
+	}
+	if opts.CommandsPerSecond > 0 {
+		l.rate = opts.CommandsPerSecond
+		l.burst = math.Max(1, float64(opts.CommandBurst))
+		l.tokens = l.burst
+		l.last = time.Now()
+	}
+	return l
+}
+
+// acquire reserves capacity for one command, waiting up to maxWait (bounded by
+// ctx) before failing with E_RATE_LIMITED. The returned func releases it.
+func (l *commandLimiter) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	deadline := start.Add(l.maxWait)
+
+	if l.rate > 0 {
+		for {
+			wait := l.takeToken()
+			if wait == 0 {
+				break
+			}
+			if time.Now().Add(wait).After(deadline) {
+				l.rejected.Add(1)
+				return nil, rateLimitedError("CommandsPerSecond", l.rate)
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				l.rejected.Add(1)
+				return nil, rateLimitedError("MaxInFlightCommands", float64(cap(l.slots)))
+			}
+			timer := time.NewTimer(remaining)
+			defer timer.Stop()
+			select {
+			case l.slots <- struct{}{}:
+			case <-timer.C:
+				l.rejected.Add(1)
+				return nil, rateLimitedError("MaxInFlightCommands", float64(cap(l.slots)))
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	l.admitted.Add(1)
+	l.waitNs.Add(int64(time.Since(start)))
+	return l.release, nil
+}
+
+// takeToken consumes a token if one is available and returns zero; otherwise
+// it returns how long until the next token.
+func (l *commandLimiter) takeToken() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// refill adds the tokens accrued since the last refill. Must be called with l.mu locked.
+func (l *commandLimiter) refill(now time.Time) {
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+func (l *commandLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// stats returns a snapshot of the limiter.
+func (l *commandLimiter) stats() LimiterStats {
+	stats := LimiterStats{
+		Enabled:      true,
+		Admitted:     l.admitted.Load(),
+		Rejected:     l.rejected.Load(),
+		WaitDuration: time.Duration(l.waitNs.Load()),
+	}
+	if l.slots != nil {
+		stats.InFlight = len(l.slots)
+		stats.MaxInFlight = cap(l.slots)
+		stats.Utilization = float64(stats.InFlight) / float64(stats.MaxInFlight)
+	}
+	if l.rate > 0 {
+		l.mu.Lock()
+		l.refill(time.Now())
+		stats.Tokens = l.tokens
+		l.mu.Unlock()
+	}
+	return stats
+}
+
+// rateLimitedError reports that the limiter named by option is saturated.
+func rateLimitedError(option string, limit float64) *ConnectionError {
+	return &ConnectionError{
+		Code:    "E_RATE_LIMITED",
+		Type:    "CONNECTION_ERROR",
+		Message: fmt.Sprintf("client command limit exceeded (%s = %v); retry later or raise the limit", option, limit),
+		Details: map[string]interface{}{
+			"option": option,
+			"limit":  limit,
+		},
+	}
+}
+
+// LimiterStats returns a snapshot of the command limiter configured with
+// MaxInFlightCommands and CommandsPerSecond. Enabled is false without limits.
+func (c *Client) LimiterStats() LimiterStats {
+	if c.limiter == nil {
+		return LimiterStats{}
+	}
+	return c.limiter.stats()
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestLimiterMaxInFlight verifies commands beyond MaxInFlightCommands are rejected.
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := newCommandLimiter(&ClientOptions{MaxInFlightCommands: 2})
+	ctx := context.Background()
+
+	release1, err := l.acquire(ctx)
+	if err != nil {
+		t.Fatalf("first acquire failed: %v", err)
+	}
+	if _, err := l.acquire(ctx); err != nil {
+		t.Fatalf("second acquire failed: %v", err)
+	}
+
+	_, err = l.acquire(ctx)
+	if ErrorCode(err) != "E_RATE_LIMITED" {
+		t.Fatalf("expected E_RATE_LIMITED, got %v", err)
+	}
+
+	stats := l.stats()
+	if stats.InFlight != 2 || stats.Utilization != 1 || stats.Admitted != 2 || stats.Rejected != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	release1()
+	if _, err := l.acquire(ctx); err != nil {
+		t.Errorf("acquire after release failed: %v", err)
+	}
+}
+
+// TestLimiterMaxWait verifies commands wait for a free slot up to LimiterMaxWait.
+func TestLimiterMaxWait(t *testing.T) {
+	l := newCommandLimiter(&ClientOptions{MaxInFlightCommands: 1, LimiterMaxWait: time.Second})
+	ctx := context.Background()
+
+	release, err := l.acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	time.AfterFunc(20*time.Millisecond, release)
+
+	if _, err := l.acquire(ctx); err != nil {
+		t.Fatalf("expected to acquire after waiting, got %v", err)
+	}
+	if l.stats().WaitDuration < 10*time.Millisecond {
+		t.Errorf("expected wait time to be recorded, got %v", l.stats().WaitDuration)
+	}
+
+	// Context cancellation ends the wait early
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := l.acquire(cancelled); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// TestLimiterRate verifies CommandsPerSecond admits a burst and then throttles.
+func TestLimiterRate(t *testing.T) {
+	l := newCommandLimiter(&ClientOptions{CommandsPerSecond: 50, CommandBurst: 2})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := l.acquire(ctx); err != nil {
+			t.Fatalf("burst acquire %d failed: %v", i, err)
+		}
+	}
+	if _, err := l.acquire(ctx); ErrorCode(err) != "E_RATE_LIMITED" {
+		t.Fatalf("expected E_RATE_LIMITED, got %v", err)
+	}
+
+	// With a wait allowance the next token (every 20ms) is awaited
+	l.maxWait = time.Second
+	start := time.Now()
+	if _, err := l.acquire(ctx); err != nil {
+		t.Fatalf("expected to acquire after waiting, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("expected to wait for a token, took %v", elapsed)
+	}
+}
+
+// TestClientLimiter verifies the limiter applies to commands and is reported in stats and metrics.
+func TestClientLimiter(t *testing.T) {
+	block := make(chan struct{})
+	c, _ := newPipeClient(t, func(command string) string {
+		<-block
+		return `{"success":true}`
+	})
+	c.limiter = newCommandLimiter(&ClientOptions{MaxInFlightCommands: 1})
+	metrics := NewMetricsHook()
+	c.RegisterHook(metrics)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.Query("SELECT * FROM \"users\";", 5000)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for c.LimiterStats().InFlight == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err := c.Query("SELECT * FROM \"posts\";", 5000)
+	if ErrorCode(err) != "E_RATE_LIMITED" {
+		t.Errorf("expected E_RATE_LIMITED, got %v", err)
+	}
+	close(block)
+	wg.Wait()
+
+	if got := metrics.TotalRateLimited.Load(); got != 1 {
+		t.Errorf("expected 1 rate limited command in metrics, got %d", got)
+	}
+	if stats := c.LimiterStats(); !stats.Enabled || stats.InFlight != 0 || stats.Rejected != 1 {
+		t.Errorf("unexpected limiter stats: %+v", stats)
+	}
+
+	if NewClient(nil).LimiterStats().Enabled {
+		t.Error("expected the limiter to be disabled by default")
+	}
+}
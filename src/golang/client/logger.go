@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -214,8 +215,13 @@ type contextKey string
 
 const requestIDKey contextKey = "requestID"
 
-// RequestIDField extracts request ID from context and returns it as a Field.
+// RequestIDField extracts the trace ID set with WithTraceID from a
+// context.Context and returns it as a Field.
 func RequestIDField(ctx interface{}) Field {
-	// TODO: implement context value extraction when request ID tracking is added
+	if c, ok := ctx.(context.Context); ok {
+		if id := TraceIDFromContext(c); id != "" {
+			return Field{Key: "requestID", Value: id}
+		}
+	}
 	return Field{Key: "requestID", Value: "unknown"}
 }
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// CommandFunc executes a command and returns its decoded result.
+type CommandFunc func(ctx context.Context, command string) (interface{}, error)
+
+// Middleware wraps command execution. Unlike a Hook, which observes a command
+// before and after it runs, a middleware decides whether and how next is
+// called: it can short-circuit with a cached result, call next again to
+// retry, or bound it with a deadline.
+//
+//	logSlow := func(next client.CommandFunc) client.CommandFunc {
+//		return func(ctx context.Context, command string) (interface{}, error) {
+//			start := time.Now()
+//			result, err := next(ctx, command)
+//			if time.Since(start) > time.Second {
+//				log.Printf("slow command: %s", command)
+//			}
+//			return result, err
+//		}
+//	}
+//	c.Use(logSlow)
+//
+// Middleware runs once per command, outside the retry policy, hedging and
+// hooks, which run inside next for every attempt. Commands the AccessPolicy
+// forbids are rejected before middleware runs. Commands bound to a
+// connection, such as those of transactions and pipelines, do not pass
+// through middleware.
+type Middleware func(next CommandFunc) CommandFunc
+
+// Use installs middleware around command execution. Middleware installed
+// first is outermost. It is safe to call Use while commands run; commands
+// already started keep the chain they began with.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middlewareMu.Lock()
+	defer c.middlewareMu.Unlock()
+
+	c.middleware = append(c.middleware, middleware...)
+	var chain CommandFunc = c.sendWithRetries
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		chain = c.middleware[i](chain)
+	}
+	c.commandChain.Store(&chain)
+	c.logger.Info("middleware installed", Int("count", len(c.middleware)))
+}
+
+// MiddlewareCount returns the number of middleware installed with Use.
+func (c *Client) MiddlewareCount() int {
+	c.middlewareMu.Lock()
+	defer c.middlewareMu.Unlock()
+	return len(c.middleware)
+}
+
+// sendCommand executes a command through the installed middleware. The
+// access policy is checked first, so no middleware sees, or can answer, a
+// command the policy forbids; it is checked again once hooks have run.
+func (c *Client) sendCommand(ctx context.Context, command string) (interface{}, error) {
+	if err := c.opts.AccessPolicy.Check(command); err != nil {
+		c.logger.Warn("command rejected by access policy", Error("error", err))
+		return nil, err
+	}
+	if chain := c.commandChain.Load(); chain != nil {
+		return (*chain)(ctx, command)
+	}
+	return c.sendWithRetries(ctx, command)
+}
+
+// TimeoutMiddleware bounds every command by timeout, unless its context
+// already has an earlier deadline.
+func TimeoutMiddleware(timeout time.Duration) Middleware {
+	return func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, command string) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, command)
+		}
+	}
+}
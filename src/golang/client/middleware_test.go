@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMiddlewareOrder verifies middleware installed first runs outermost.
+func TestMiddlewareOrder(t *testing.T) {
+	c, server := newPipeClient(t, func(string) string { return `{"success":true,"data":{}}` })
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next CommandFunc) CommandFunc {
+			return func(ctx context.Context, command string) (interface{}, error) {
+				calls = append(calls, name+" before")
+				result, err := next(ctx, command)
+				calls = append(calls, name+" after")
+				return result, err
+			}
+		}
+	}
+	c.Use(trace("outer"))
+	c.Use(trace("inner"))
+
+	if _, err := c.sendCommand(context.Background(), `SELECT * FROM BUNDLE "users";`); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	want := "outer before,inner before,inner after,outer after"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if n := len(server.received()); n != 1 {
+		t.Errorf("expected one command sent, got %d", n)
+	}
+	if n := c.MiddlewareCount(); n != 2 {
+		t.Errorf("expected 2 middleware, got %d", n)
+	}
+}
+
+// TestMiddlewareShortCircuit verifies middleware can answer without sending
+// the command, in which case hooks do not run.
+func TestMiddlewareShortCircuit(t *testing.T) {
+	c, server := newPipeClient(t, func(string) string { return `{"success":true,"data":{}}` })
+	hook := &countingHook{name: "counter"}
+	c.RegisterHook(hook)
+
+	cached := map[string]interface{}{"cached": true}
+	c.Use(func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, command string) (interface{}, error) {
+			if strings.HasPrefix(command, "SELECT") {
+				return cached, nil
+			}
+			return next(ctx, command)
+		}
+	})
+
+	result, err := c.sendCommand(context.Background(), `SELECT * FROM BUNDLE "users";`)
+	if err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if result.(map[string]interface{})["cached"] != true {
+		t.Errorf("expected the middleware's result, got %v", result)
+	}
+	if n := len(server.received()); n != 0 {
+		t.Errorf("expected nothing sent, got %d commands", n)
+	}
+	if hook.before != 0 {
+		t.Errorf("expected hooks not to run, ran %d times", hook.before)
+	}
+}
+
+// TestMiddlewareAccessPolicy verifies commands the access policy forbids are
+// rejected before any middleware sees them.
+func TestMiddlewareAccessPolicy(t *testing.T) {
+	c, server := newPipeClient(t, func(string) string { return `{"success":true,"data":{}}` })
+	policy := NewAccessPolicy()
+	policy.SetBundle("secrets", &BundlePolicy{Operations: []Operation{OperationInsert}})
+	c.opts.AccessPolicy = policy
+
+	var seen []string
+	c.Use(func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, command string) (interface{}, error) {
+			seen = append(seen, command)
+			return map[string]interface{}{"cached": true}, nil
+		}
+	})
+
+	if _, err := c.sendCommand(context.Background(), `SELECT * FROM BUNDLE "secrets";`); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected E_POLICY_VIOLATION, got %v", err)
+	}
+	if _, err := c.sendCommand(context.Background(), `SELECT * FROM BUNDLE "users";`); err != nil {
+		t.Fatalf("allowed command failed: %v", err)
+	}
+	if len(seen) != 1 || !strings.Contains(seen[0], "users") {
+		t.Errorf("expected middleware to see only the allowed command, saw %q", seen)
+	}
+	if n := len(server.received()); n != 0 {
+		t.Errorf("expected nothing sent, got %d commands", n)
+	}
+}
+
+// TestMiddlewareRetry verifies middleware can call next again, with hooks
+// running for each call.
+func TestMiddlewareRetry(t *testing.T) {
+	failures := 1
+	c, server := newPipeClient(t, func(string) string {
+		if failures > 0 {
+			failures--
+			return `{"success":false,"error":"temporarily unavailable"}`
+		}
+		return `{"success":true,"data":{}}`
+	})
+	hook := &countingHook{name: "counter"}
+	c.RegisterHook(hook)
+
+	c.Use(func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, command string) (interface{}, error) {
+			result, err := next(ctx, command)
+			if err != nil {
+				return next(ctx, command)
+			}
+			return result, err
+		}
+	})
+
+	if _, err := c.sendCommand(context.Background(), `SELECT * FROM BUNDLE "users";`); err != nil {
+		t.Fatalf("expected the retry to succeed: %v", err)
+	}
+	if n := len(server.received()); n != 2 {
+		t.Errorf("expected two sends, got %d", n)
+	}
+	if hook.before != 2 {
+		t.Errorf("expected hooks to run for each call, ran %d times", hook.before)
+	}
+}
+
+// TestTimeoutMiddleware verifies commands get the middleware's deadline.
+func TestTimeoutMiddleware(t *testing.T) {
+	c, _ := newPipeClient(t, func(string) string { return `{"success":true,"data":{}}` })
+
+	var deadline time.Time
+	c.Use(TimeoutMiddleware(time.Minute), func(next CommandFunc) CommandFunc {
+		return func(ctx context.Context, command string) (interface{}, error) {
+			deadline, _ = ctx.Deadline()
+			return next(ctx, command)
+		}
+	})
+
+	if _, err := c.sendCommand(context.Background(), `SELECT * FROM BUNDLE "users";`); err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("expected a deadline within a minute, got %v", remaining)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.sendCommand(ctx, `SELECT * FROM BUNDLE "users";`); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the caller's cancellation to be kept, got %v", err)
+	}
+}
+
+// countingHook counts the commands its Before hook sees.
+type countingHook struct {
+	name   string
+	before int
+}
+
+func (h *countingHook) Name() string { return h.name }
+
+func (h *countingHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	h.before++
+	return nil
+}
+
+func (h *countingHook) After(ctx context.Context, hookCtx *HookContext) error { return nil }
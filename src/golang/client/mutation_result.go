@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MutationResult describes the outcome of an INSERT, UPDATE or DELETE.
+type MutationResult struct {
+	// AffectedCount is the number of documents added, modified or removed,
+	// or -1 if the server did not report it.
+	AffectedCount int64
+
+	// InsertedIDs lists the DocumentIDs of added documents.
+	InsertedIDs []string
+
+	// GeneratedID is the ID the client's IDGenerator assigned to an inserted
+	// document, or "" if none was generated.
+	GeneratedID string
+
+	// Warnings lists any warnings the server attached to the response.
+	Warnings []string
+
+	// Raw is the unparsed server response.
+	Raw interface{}
+
+	// Queued is true when offline mode queued the mutation instead of sending
+	// it (see EnableOfflineQueue); QueueID identifies the entry.
+	Queued  bool
+	QueueID uint64
+}
+
+// Keys servers use for mutation metadata, in order of preference.
+var (
+	affectedCountKeys = []string{"affected_count", "affectedCount", "AffectedCount", "affected", "affected_rows", "rows_affected", "ResultCount", "count"}
+	insertedIDKeys    = []string{"inserted_ids", "insertedIds", "InsertedIDs", "document_ids", "DocumentIDs", "ids"}
+	documentIDKeys    = []string{"DocumentID", "document_id", "documentId"}
+	warningKeys       = []string{"warnings", "Warnings", "warning"}
+)
+
+var (
+	affectedCountPattern = regexp.MustCompile(`(?i)\b(\d+)\s+(?:documents?|rows?)\b`)
+	insertedIDPattern    = regexp.MustCompile(`(?i)\bID:\s*"?([\w-]+)`)
+)
+
+// parseMutationResult extracts mutation metadata from a server response: an
+// object with count, ID and warning fields, the added document(s), or a
+// status message such as "3 documents updated".
+func parseMutationResult(raw interface{}) *MutationResult {
+	result := &MutationResult{AffectedCount: -1, Raw: raw}
+
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		for _, key := range affectedCountKeys {
+			if count, ok := parseCount(v[key]); ok {
+				result.AffectedCount = int64(count)
+				break
+			}
+		}
+		for _, key := range insertedIDKeys {
+			if ids := stringList(v[key]); ids != nil {
+				result.InsertedIDs = ids
+				break
+			}
+		}
+		for _, key := range warningKeys {
+			if warnings := stringList(v[key]); warnings != nil {
+				result.Warnings = warnings
+				break
+			}
+		}
+		if result.InsertedIDs == nil {
+			if docs, ok := v["Result"].([]interface{}); ok {
+				result.InsertedIDs = documentIDs(docs)
+			} else if id := documentID(v); id != "" {
+				result.InsertedIDs = []string{id}
+			}
+		}
+		if message, ok := v["message"].(string); ok {
+			result.parseMessage(message)
+		}
+	case []interface{}:
+		result.InsertedIDs = documentIDs(v)
+		result.AffectedCount = int64(len(v))
+	case string:
+		result.parseMessage(v)
+	}
+
+	if result.AffectedCount < 0 && len(result.InsertedIDs) > 0 {
+		result.AffectedCount = int64(len(result.InsertedIDs))
+	}
+	return result
+}
+
+// parseMessage fills in fields missing from the structured response using a
+// status message.
+func (r *MutationResult) parseMessage(message string) {
+	if r.AffectedCount < 0 {
+		if m := affectedCountPattern.FindStringSubmatch(message); m != nil {
+			if count, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+				r.AffectedCount = count
+			}
+		}
+	}
+	if r.InsertedIDs == nil && strings.Contains(strings.ToLower(message), "add") {
+		if m := insertedIDPattern.FindStringSubmatch(message); m != nil {
+			r.InsertedIDs = []string{m[1]}
+		}
+	}
+}
+
+// documentIDs returns the DocumentIDs of the documents in rows.
+func documentIDs(rows []interface{}) []string {
+	var ids []string
+	for _, row := range rows {
+		if doc, ok := row.(map[string]interface{}); ok {
+			if id := documentID(doc); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// documentID returns a document's ID, or "" if it has none.
+func documentID(doc map[string]interface{}) string {
+	return firstString(doc, documentIDKeys...)
+}
+
+// mutate executes a mutation and parses its result.
+func (c *Client) mutate(ctx context.Context, command string, timeoutMs int) (*MutationResult, error) {
+	if result, err := c.queueOffline(command); result != nil || err != nil {
+		return result, err
+	}
+
+	raw, err := c.executeWithTimeout(ctx, command, timeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	return parseMutationResult(raw), nil
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// TestParseMutationResult verifies counts, IDs and warnings are read from the response shapes servers use.
+func TestParseMutationResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      interface{}
+		affected int64
+		ids      []string
+		warnings []string
+	}{
+		{
+			name:     "explicit fields",
+			raw:      map[string]interface{}{"affected_rows": float64(3), "document_ids": []interface{}{"a", "b", "c"}, "warnings": "field ignored: age"},
+			affected: 3,
+			ids:      []string{"a", "b", "c"},
+			warnings: []string{"field ignored: age"},
+		},
+		{
+			name:     "added document",
+			raw:      map[string]interface{}{"DocumentID": "187320fc", "name": "Alice"},
+			affected: 1,
+			ids:      []string{"187320fc"},
+		},
+		{
+			name:     "result list",
+			raw:      map[string]interface{}{"Result": []interface{}{map[string]interface{}{"DocumentID": "x"}, map[string]interface{}{"DocumentID": "y"}}, "ResultCount": float64(2)},
+			affected: 2,
+			ids:      []string{"x", "y"},
+		},
+		{
+			name:     "status message",
+			raw:      map[string]interface{}{"status": "success", "message": "5 documents updated"},
+			affected: 5,
+		},
+		{
+			name:     "text response",
+			raw:      "Document added with ID: 9f1c-22",
+			affected: 1,
+			ids:      []string{"9f1c-22"},
+		},
+		{
+			name:     "unknown",
+			raw:      map[string]interface{}{"status": "success"},
+			affected: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseMutationResult(tt.raw)
+			if result.AffectedCount != tt.affected {
+				t.Errorf("expected AffectedCount %d, got %d", tt.affected, result.AffectedCount)
+			}
+			if !reflect.DeepEqual(result.InsertedIDs, tt.ids) {
+				t.Errorf("expected InsertedIDs %v, got %v", tt.ids, result.InsertedIDs)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.warnings) {
+				t.Errorf("expected Warnings %v, got %v", tt.warnings, result.Warnings)
+			}
+		})
+	}
+}
+
+// TestMutationResultFromBuilders verifies builders and Mutate return parsed results.
+func TestMutationResultFromBuilders(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"success":true,"data":{"affected_count":2,"inserted_ids":["d1"]}}`
+	})
+	ctx := context.Background()
+
+	result, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "Alice"}).Execute(ctx)
+	if err != nil || result.AffectedCount != 2 || len(result.InsertedIDs) != 1 || result.InsertedIDs[0] != "d1" {
+		t.Errorf("unexpected insert result: %+v, %v", result, err)
+	}
+	if _, ok := result.Raw.(map[string]interface{}); !ok {
+		t.Errorf("expected the raw response to be kept, got %T", result.Raw)
+	}
+
+	if result, err := c.DeleteBuilder("users").Where("id", Equals, 1).Execute(ctx); err != nil || result.AffectedCount != 2 {
+		t.Errorf("unexpected delete result: %+v, %v", result, err)
+	}
+	if result, err := c.Mutate(`DELETE DOCUMENTS FROM "users" WHERE "id" == 1;`, 1000); err != nil || result.AffectedCount != 2 {
+		t.Errorf("unexpected Mutate result: %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NamedArgs binds :name placeholders by name. Pass it as the only argument to
+// Statement.Execute, QueryWithParams or a raw builder expression:
+//
+//	c.QueryWithParams(ctx, `SELECT * FROM BUNDLE "users" WHERE "email" == :email;`,
+//		client.NamedArgs{"email": email})
+type NamedArgs map[string]interface{}
+
+// namedArgsFrom returns the NamedArgs passed as the only argument, if any.
+func namedArgsFrom(args []interface{}) (NamedArgs, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+	named, ok := args[0].(NamedArgs)
+	return named, ok
+}
+
+// scanNamedParams splits query into literal text and :name placeholders,
+// calling text and param in order. A colon starts a placeholder only when it
+// is followed by a letter or underscore and does not follow an identifier
+// character, a colon or a quote, so "::" casts and "key":value pairs are left
+// alone. Quoted strings are never scanned.
+func scanNamedParams(query string, text func(s string), param func(name string)) {
+	var quote byte
+	start := 0
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		case ch == '"' || ch == '\'':
+			quote = ch
+			continue
+		case ch != ':' || i+1 == len(query) || !isNameStart(query[i+1]):
+			continue
+		case i > 0 && (isNamePart(query[i-1]) || strings.IndexByte(":\"'", query[i-1]) >= 0):
+			continue
+		}
+
+		end := i + 1
+		for end < len(query) && isNamePart(query[end]) {
+			end++
+		}
+		text(query[start:i])
+		param(query[i+1 : end])
+		start = end
+		i = end - 1
+	}
+	text(query[start:])
+}
+
+func isNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isNamePart(ch byte) bool {
+	return isNameStart(ch) || (ch >= '0' && ch <= '9')
+}
+
+// rewriteNamedParams replaces :name placeholders with $n, numbering names in
+// order of first appearance, and returns the rewritten query and the names by
+// position. A query without named placeholders is returned unchanged with no
+// names; one that mixes them with $n placeholders is rejected.
+func rewriteNamedParams(query string) (string, []string, error) {
+	var sb strings.Builder
+	var names []string
+	index := make(map[string]int)
+	scanNamedParams(query, func(s string) {
+		sb.WriteString(s)
+	}, func(name string) {
+		n, ok := index[name]
+		if !ok {
+			names = append(names, name)
+			n = len(names)
+			index[name] = n
+		}
+		sb.WriteByte('$')
+		sb.WriteString(strconv.Itoa(n))
+	})
+
+	if len(names) == 0 {
+		return query, nil, nil
+	}
+	if countPlaceholders(query) > 0 {
+		return "", nil, &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: "query mixes named (:name) and positional ($n) placeholders",
+			Query:   query,
+		}
+	}
+	return sb.String(), names, nil
+}
+
+// uniqueNames returns names without repeats, in order of first appearance.
+func uniqueNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	unique := names[:0:0]
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// bindNamedArgs orders args by names, failing with E_PARAM_MISSING if a name
+// has no value and E_PARAM_UNKNOWN if a value matches no name.
+func bindNamedArgs(names []string, args NamedArgs) ([]interface{}, error) {
+	params := make([]interface{}, len(names))
+	for i, name := range names {
+		value, ok := args[name]
+		if !ok {
+			return nil, &QueryError{
+				Code:    "E_PARAM_MISSING",
+				Type:    "QueryError",
+				Message: fmt.Sprintf("no value for named parameter :%s", name),
+				Details: map[string]interface{}{"parameter": name},
+			}
+		}
+		params[i] = value
+	}
+
+	if len(args) > len(names) {
+		known := make(map[string]bool, len(names))
+		for _, name := range names {
+			known[name] = true
+		}
+		for name := range args {
+			if !known[name] {
+				return nil, &QueryError{
+					Code:    "E_PARAM_UNKNOWN",
+					Type:    "QueryError",
+					Message: fmt.Sprintf("value for unknown named parameter :%s", name),
+					Details: map[string]interface{}{"parameter": name},
+				}
+			}
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRewriteNamedParams verifies :name placeholders are numbered by first appearance.
+func TestRewriteNamedParams(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+		names []string
+	}{
+		{`WHERE "email" == :email AND "age" >= :minAge;`, `WHERE "email" == $1 AND "age" >= $2;`, []string{"email", "minAge"}},
+		{`WHERE a == :v OR b == :v AND c == :w_2`, `WHERE a == $1 OR b == $1 AND c == $2`, []string{"v", "w_2"}},
+		{`WHERE (a==:a)`, `WHERE (a==$1)`, []string{"a"}},
+		// Not placeholders: quoted strings, casts, object keys, times and bare colons
+		{`WHERE t == '12:30' AND n == ":name" AND x::int == 1`, `WHERE t == '12:30' AND n == ":name" AND x::int == 1`, nil},
+		{`SET {"key":value} WHERE a: 1 AND b == :1`, `SET {"key":value} WHERE a: 1 AND b == :1`, nil},
+		{`WHERE a == $1`, `WHERE a == $1`, nil},
+	}
+	for _, tt := range tests {
+		got, names, err := rewriteNamedParams(tt.query)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+		if got != tt.want || !reflect.DeepEqual(names, tt.names) {
+			t.Errorf("%s: got %q %v, want %q %v", tt.query, got, names, tt.want, tt.names)
+		}
+	}
+
+	if _, _, err := rewriteNamedParams(`WHERE a == :a AND b == $1`); ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("expected mixed placeholders to fail with E_INVALID_QUERY, got %v", err)
+	}
+}
+
+// TestBindNamedArgs verifies missing and unknown names are reported.
+func TestBindNamedArgs(t *testing.T) {
+	names := []string{"email", "minAge"}
+	params, err := bindNamedArgs(names, NamedArgs{"minAge": 21, "email": "a@example.com"})
+	if err != nil || !reflect.DeepEqual(params, []interface{}{"a@example.com", 21}) {
+		t.Errorf("unexpected binding %v, %v", params, err)
+	}
+
+	_, err = bindNamedArgs(names, NamedArgs{"email": "a@example.com"})
+	if ErrorCode(err) != "E_PARAM_MISSING" || !errors.Is(err, ErrInvalidQuery) || !strings.Contains(err.Error(), ":minAge") {
+		t.Errorf("expected E_PARAM_MISSING for :minAge, got %v", err)
+	}
+	_, err = bindNamedArgs(names, NamedArgs{"email": "a@example.com", "minAge": 21, "maxAge": 65})
+	if ErrorCode(err) != "E_PARAM_UNKNOWN" || !strings.Contains(err.Error(), ":maxAge") {
+		t.Errorf("expected E_PARAM_UNKNOWN for :maxAge, got %v", err)
+	}
+}
+
+// TestStatementExecuteNamed verifies named statements are prepared with $n
+// placeholders and executed with values in positional order.
+func TestStatementExecuteNamed(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+
+	stmt, err := c.Prepare(context.Background(), "find_user", `SELECT * FROM BUNDLE "users" WHERE "email" == :email AND "age" >= :minAge;`)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if !reflect.DeepEqual(stmt.ParamNames(), []string{"email", "minAge"}) || stmt.ParamCount() != 2 {
+		t.Errorf("unexpected parameters %v (%d)", stmt.ParamNames(), stmt.ParamCount())
+	}
+	if _, err := stmt.ExecuteNamed(map[string]interface{}{"minAge": 21, "email": "a@example.com"}); err != nil {
+		t.Fatalf("ExecuteNamed failed: %v", err)
+	}
+	if _, err := stmt.ExecuteNamed(map[string]interface{}{"email": "a@example.com"}); ErrorCode(err) != "E_PARAM_MISSING" {
+		t.Errorf("expected E_PARAM_MISSING, got %v", err)
+	}
+
+	got := server.received()
+	want := []string{
+		`PREPARE find_user AS SELECT * FROM BUNDLE "users" WHERE "email" == $1 AND "age" >= $2;`,
+		"EXECUTE find_user\x05a@example.com\x0521",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	positional, err := c.Prepare(context.Background(), "by_id", `SELECT * FROM BUNDLE "users" WHERE "id" == $1;`)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if _, err := positional.ExecuteNamed(map[string]interface{}{"id": 1}); ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("expected named arguments for a positional statement to fail, got %v", err)
+	}
+}
+
+// TestQueryWithNamedArgs verifies QueryWithParams binds a NamedArgs argument.
+func TestQueryWithNamedArgs(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+
+	_, err := c.QueryWithParams(context.Background(), `SELECT * FROM BUNDLE "users" WHERE "email" == :email;`,
+		NamedArgs{"email": "a@example.com"})
+	if err != nil {
+		t.Fatalf("QueryWithParams failed: %v", err)
+	}
+	commands := server.received()
+	if len(commands) < 2 || !strings.HasSuffix(commands[0], `"email" == $1;`) || !strings.HasSuffix(commands[1], "\x05a@example.com") {
+		t.Errorf("unexpected commands %q", commands)
+	}
+}
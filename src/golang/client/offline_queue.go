@@ -0,0 +1,351 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultOfflineQueueSize is the number of entries kept when OfflineQueueOptions.MaxEntries is unset.
+const defaultOfflineQueueSize = 1000
+
+// OfflineEntry is a mutation queued while the client was disconnected.
+type OfflineEntry struct {
+	ID        uint64    `json:"id"`
+	Command   string    `json:"command"`
+	QueuedAt  time.Time `json:"queuedAt"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+// OfflineStore persists the offline queue so entries survive a restart or page reload.
+type OfflineStore interface {
+	// Load returns the stored entries in any order.
+	Load() ([]OfflineEntry, error)
+	// Save adds or replaces the entry with entry.ID.
+	Save(entry OfflineEntry) error
+	// Remove deletes the entry with id; removing a missing entry is not an error.
+	Remove(id uint64) error
+}
+
+// ConflictResolution tells ReplayOfflineQueue what to do with an entry the server rejected.
+type ConflictResolution int
+
+const (
+	// ConflictSkip drops the entry and continues with the next one.
+	ConflictSkip ConflictResolution = iota
+	// ConflictStop keeps the entry at the head of the queue and stops replaying.
+	ConflictStop
+)
+
+// ConflictHandler decides how to handle a queued mutation that failed on replay
+// for a reason other than the connection, e.g. a duplicate key.
+type ConflictHandler func(entry OfflineEntry, err error) ConflictResolution
+
+// OfflineQueueOptions configures EnableOfflineQueue.
+type OfflineQueueOptions struct {
+	// MaxEntries bounds the queue; mutations beyond it fail with
+	// E_OFFLINE_QUEUE_FULL. Default: 1000.
+	MaxEntries int
+
+	// Store persists entries. Default: in memory, lost when the process exits.
+	Store OfflineStore
+
+	// OnConflict handles entries the server rejects on replay. Default: skip.
+	OnConflict ConflictHandler
+
+	// DisableAutoReplay stops the queue from replaying when the client
+	// reconnects; call ReplayOfflineQueue instead.
+	DisableAutoReplay bool
+}
+
+// ReplayResult summarizes a ReplayOfflineQueue run.
+type ReplayResult struct {
+	Replayed  int // Entries the server accepted
+	Skipped   int // Entries rejected and dropped by the conflict handler
+	Remaining int // Entries still queued
+}
+
+// offlineQueue holds mutations issued while disconnected, in issue order.
+type offlineQueue struct {
+	mu       sync.Mutex
+	opts     OfflineQueueOptions
+	entries  []OfflineEntry
+	nextID   uint64
+	replayMu sync.Mutex // Serializes replays
+}
+
+// EnableOfflineQueue turns on offline mode. While the client is not connected,
+// Mutate and the insert, update and delete builders queue their commands and
+// return a MutationResult with Queued set instead of failing. Queued commands
+// are replayed in order when the client reconnects. While entries are pending,
+// new mutations queue behind them so the server sees them in issue order.
+// Entries already in opts.Store are loaded and kept ahead of new ones.
+func (c *Client) EnableOfflineQueue(opts OfflineQueueOptions) error {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = defaultOfflineQueueSize
+	}
+	if opts.Store == nil {
+		opts.Store = &memoryOfflineStore{entries: make(map[uint64]OfflineEntry)}
+	}
+
+	entries, err := opts.Store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load offline queue: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	q := &offlineQueue{opts: opts, entries: entries, nextID: 1}
+	if len(entries) > 0 {
+		q.nextID = entries[len(entries)-1].ID + 1
+	}
+
+	c.offlineMu.Lock()
+	c.offline = q
+	c.offlineMu.Unlock()
+
+	c.offlineReplayOnce.Do(func() {
+		c.OnStateChange(func(transition StateTransition) {
+			if transition.To != CONNECTED {
+				return
+			}
+			if q := c.offlineQueue(); q != nil && !q.opts.DisableAutoReplay && q.len() > 0 {
+				go c.ReplayOfflineQueue(context.Background())
+			}
+		})
+	})
+
+	if len(entries) > 0 && c.stateMgr.GetState() == CONNECTED && !opts.DisableAutoReplay {
+		go c.ReplayOfflineQueue(context.Background())
+	}
+	return nil
+}
+
+// DisableOfflineQueue turns off offline mode. Pending entries stay in the
+// store and are loaded again by the next EnableOfflineQueue.
+func (c *Client) DisableOfflineQueue() {
+	c.offlineMu.Lock()
+	c.offline = nil
+	c.offlineMu.Unlock()
+}
+
+// OfflineQueue returns a snapshot of the pending entries in replay order.
+// It is empty when offline mode is disabled.
+func (c *Client) OfflineQueue() []OfflineEntry {
+	q := c.offlineQueue()
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]OfflineEntry(nil), q.entries...)
+}
+
+// RemoveOfflineEntry drops a pending entry so it is never replayed.
+func (c *Client) RemoveOfflineEntry(id uint64) error {
+	q := c.offlineQueue()
+	if q == nil {
+		return nil
+	}
+	return q.remove(id)
+}
+
+// ClearOfflineQueue drops every pending entry.
+func (c *Client) ClearOfflineQueue() error {
+	q := c.offlineQueue()
+	if q == nil {
+		return nil
+	}
+	for _, entry := range c.OfflineQueue() {
+		if err := q.remove(entry.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayOfflineQueue sends pending entries in order until the queue is empty,
+// the connection is lost, or the conflict handler returns ConflictStop.
+// Replays are serialized; a replay started while another runs waits for it.
+func (c *Client) ReplayOfflineQueue(ctx context.Context) (result ReplayResult, err error) {
+	q := c.offlineQueue()
+	if q == nil {
+		return ReplayResult{}, nil
+	}
+
+	q.replayMu.Lock()
+	defer q.replayMu.Unlock()
+	defer func() { result.Remaining = q.len() }()
+
+	for {
+		entry, ok := q.head()
+		if !ok {
+			return result, nil
+		}
+		if state := c.stateMgr.GetState(); state != CONNECTED {
+			return result, ErrInvalidState("ReplayOfflineQueue", CONNECTED, state)
+		}
+
+		_, err = c.executeWithTimeout(ctx, entry.Command, 0)
+		if err == nil {
+			if err := q.remove(entry.ID); err != nil {
+				return result, err
+			}
+			result.Replayed++
+			continue
+		}
+
+		entry.Attempts++
+		entry.LastError = err.Error()
+		if saveErr := q.update(entry); saveErr != nil {
+			return result, saveErr
+		}
+
+		var connErr *ConnectionError
+		if errors.As(err, &connErr) || ctx.Err() != nil || c.stateMgr.GetState() != CONNECTED {
+			return result, err
+		}
+		if q.opts.OnConflict != nil && q.opts.OnConflict(entry, err) == ConflictStop {
+			return result, err
+		}
+		if err := q.remove(entry.ID); err != nil {
+			return result, err
+		}
+		result.Skipped++
+	}
+}
+
+// offlineQueue returns the active queue, or nil when offline mode is disabled.
+func (c *Client) offlineQueue() *offlineQueue {
+	c.offlineMu.RLock()
+	defer c.offlineMu.RUnlock()
+	return c.offline
+}
+
+// queueOffline queues command when offline mode is enabled and the client is
+// disconnected or earlier entries are still pending. Returns nil, nil when
+// the command should be sent now.
+func (c *Client) queueOffline(command string) (*MutationResult, error) {
+	q := c.offlineQueue()
+	if q == nil {
+		return nil, nil
+	}
+
+	connected := c.stateMgr.GetState() == CONNECTED
+	if connected && q.len() == 0 {
+		return nil, nil
+	}
+
+	entry, err := q.push(command)
+	if err != nil {
+		return nil, err
+	}
+	if connected && !q.opts.DisableAutoReplay {
+		go c.ReplayOfflineQueue(context.Background())
+	}
+	return &MutationResult{AffectedCount: -1, Queued: true, QueueID: entry.ID}, nil
+}
+
+func (q *offlineQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+func (q *offlineQueue) head() (OfflineEntry, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.entries) == 0 {
+		return OfflineEntry{}, false
+	}
+	return q.entries[0], true
+}
+
+// push appends command, persisting it before it is accepted.
+func (q *offlineQueue) push(command string) (OfflineEntry, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.entries) >= q.opts.MaxEntries {
+		return OfflineEntry{}, offlineQueueFullError(q.opts.MaxEntries)
+	}
+	entry := OfflineEntry{ID: q.nextID, Command: command, QueuedAt: time.Now()}
+	if err := q.opts.Store.Save(entry); err != nil {
+		return OfflineEntry{}, fmt.Errorf("failed to persist offline entry: %w", err)
+	}
+	q.nextID++
+	q.entries = append(q.entries, entry)
+	return entry, nil
+}
+
+func (q *offlineQueue) update(entry OfflineEntry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i := range q.entries {
+		if q.entries[i].ID == entry.ID {
+			q.entries[i] = entry
+			return q.opts.Store.Save(entry)
+		}
+	}
+	return nil
+}
+
+func (q *offlineQueue) remove(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.opts.Store.Remove(id); err != nil {
+		return err
+	}
+	for i := range q.entries {
+		if q.entries[i].ID == id {
+			q.entries = append(q.entries[:i], q.entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// offlineQueueFullError reports that the offline queue holds max entries.
+func offlineQueueFullError(max int) *ConnectionError {
+	return &ConnectionError{
+		Code:    "E_OFFLINE_QUEUE_FULL",
+		Type:    "CONNECTION_ERROR",
+		Message: fmt.Sprintf("offline queue is full (%d entries); reconnect or raise MaxEntries", max),
+		Details: map[string]interface{}{
+			"maxEntries": max,
+		},
+	}
+}
+
+// memoryOfflineStore is the default OfflineStore; entries last as long as the process.
+type memoryOfflineStore struct {
+	mu      sync.Mutex
+	entries map[uint64]OfflineEntry
+}
+
+func (s *memoryOfflineStore) Load() ([]OfflineEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]OfflineEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *memoryOfflineStore) Save(entry OfflineEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.ID] = entry
+	return nil
+}
+
+func (s *memoryOfflineStore) Remove(id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
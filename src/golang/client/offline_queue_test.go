@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// goOffline moves a pipe client to DISCONNECTED without closing its connection.
+func goOffline(t *testing.T, c *Client) {
+	t.Helper()
+	c.stateMgr.TransitionTo(DISCONNECTING, nil, nil)
+	c.stateMgr.TransitionTo(DISCONNECTED, nil, nil)
+}
+
+// goOnline moves a pipe client back to CONNECTED, triggering reconnect handlers.
+func goOnline(t *testing.T, c *Client) {
+	t.Helper()
+	c.stateMgr.TransitionTo(CONNECTING, nil, nil)
+	c.stateMgr.TransitionTo(CONNECTED, nil, nil)
+}
+
+// waitForQueue waits until the offline queue holds n entries.
+func waitForQueue(t *testing.T, c *Client, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(c.OfflineQueue()) != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d queued entries, got %d", n, len(c.OfflineQueue()))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// recordingStore is an OfflineStore that keeps entries in memory for inspection.
+type recordingStore struct {
+	mu      sync.Mutex
+	entries map[uint64]OfflineEntry
+}
+
+func (s *recordingStore) Load() ([]OfflineEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []OfflineEntry
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (s *recordingStore) Save(entry OfflineEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.ID] = entry
+	return nil
+}
+
+func (s *recordingStore) Remove(id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+func (s *recordingStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// TestOfflineQueueReplaysOnReconnect verifies mutations queued while disconnected are replayed in order.
+func TestOfflineQueueReplaysOnReconnect(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok","affected_count":1}`
+	})
+	store := &recordingStore{entries: make(map[uint64]OfflineEntry)}
+	if err := c.EnableOfflineQueue(OfflineQueueOptions{Store: store}); err != nil {
+		t.Fatalf("EnableOfflineQueue failed: %v", err)
+	}
+
+	goOffline(t, c)
+	result, err := c.Mutate(`DELETE DOCUMENTS FROM BUNDLE "a";`, 0)
+	if err != nil {
+		t.Fatalf("expected mutation to be queued, got %v", err)
+	}
+	if !result.Queued || result.QueueID != 1 || result.AffectedCount != -1 {
+		t.Errorf("unexpected queued result: %+v", result)
+	}
+	if _, err := c.InsertBuilder("b").Values(map[string]interface{}{"n": 1}).Execute(context.Background()); err != nil {
+		t.Fatalf("expected insert to be queued, got %v", err)
+	}
+
+	if len(server.received()) != 0 {
+		t.Fatalf("expected nothing sent while offline, got %v", server.received())
+	}
+	if store.len() != 2 {
+		t.Errorf("expected 2 persisted entries, got %d", store.len())
+	}
+
+	goOnline(t, c)
+	waitForQueue(t, c, 0)
+
+	commands := server.received()
+	if len(commands) != 2 || !strings.Contains(commands[0], `BUNDLE "a"`) || !strings.Contains(commands[1], `"b"`) {
+		t.Errorf("expected queued commands replayed in order, got %v", commands)
+	}
+	if store.len() != 0 {
+		t.Errorf("expected store emptied after replay, got %d", store.len())
+	}
+
+	// Once drained, mutations are sent directly again
+	result, err = c.Mutate(`DELETE DOCUMENTS FROM BUNDLE "c";`, 0)
+	if err != nil || result.Queued || result.AffectedCount != 1 {
+		t.Errorf("expected direct mutation, got %+v, %v", result, err)
+	}
+}
+
+// TestOfflineQueueBoundedAndInspectable verifies the size bound and inspection API.
+func TestOfflineQueueBoundedAndInspectable(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+	c.EnableOfflineQueue(OfflineQueueOptions{MaxEntries: 2, DisableAutoReplay: true})
+	goOffline(t, c)
+
+	c.Mutate("CMD 1", 0)
+	c.Mutate("CMD 2", 0)
+	_, err := c.Mutate("CMD 3", 0)
+	if ErrorCode(err) != "E_OFFLINE_QUEUE_FULL" {
+		t.Fatalf("expected E_OFFLINE_QUEUE_FULL, got %v", err)
+	}
+
+	entries := c.OfflineQueue()
+	if len(entries) != 2 || entries[0].Command != "CMD 1" || entries[1].Command != "CMD 2" {
+		t.Fatalf("unexpected queue: %+v", entries)
+	}
+	if err := c.RemoveOfflineEntry(entries[0].ID); err != nil {
+		t.Fatalf("RemoveOfflineEntry failed: %v", err)
+	}
+	if q := c.OfflineQueue(); len(q) != 1 || q[0].Command != "CMD 2" {
+		t.Errorf("expected CMD 2 left, got %+v", q)
+	}
+	if err := c.ClearOfflineQueue(); err != nil || len(c.OfflineQueue()) != 0 {
+		t.Errorf("expected empty queue after clear, got %+v, %v", c.OfflineQueue(), err)
+	}
+
+	// Replaying while disconnected fails and keeps entries
+	c.Mutate("CMD 4", 0)
+	if _, err := c.ReplayOfflineQueue(context.Background()); err == nil {
+		t.Error("expected replay to fail while disconnected")
+	}
+	if len(c.OfflineQueue()) != 1 {
+		t.Errorf("expected entry kept, got %+v", c.OfflineQueue())
+	}
+
+	// Without offline mode, disconnected mutations fail as before
+	c.DisableOfflineQueue()
+	if _, err := c.Mutate("CMD 5", 0); err == nil {
+		t.Error("expected Mutate to fail while disconnected with offline mode disabled")
+	}
+}
+
+// TestOfflineQueueConflicts verifies server rejections go to the conflict handler.
+func TestOfflineQueueConflicts(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, "DUP") {
+			return `{"status":"error","code":"E_DUPLICATE_KEY","message":"duplicate key"}`
+		}
+		return `{"status":"ok"}`
+	})
+
+	var conflicts []OfflineEntry
+	resolution := ConflictSkip
+	c.EnableOfflineQueue(OfflineQueueOptions{
+		DisableAutoReplay: true,
+		OnConflict: func(entry OfflineEntry, err error) ConflictResolution {
+			conflicts = append(conflicts, entry)
+			return resolution
+		},
+	})
+
+	goOffline(t, c)
+	c.Mutate("CMD DUP", 0)
+	c.Mutate("CMD OK", 0)
+	goOnline(t, c)
+
+	resolution = ConflictStop
+	result, err := c.ReplayOfflineQueue(context.Background())
+	if err == nil || result.Replayed != 0 || result.Remaining != 2 {
+		t.Fatalf("expected replay to stop at the conflict, got %+v, %v", result, err)
+	}
+	if head := c.OfflineQueue()[0]; head.Attempts != 1 || !strings.Contains(head.LastError, "duplicate") {
+		t.Errorf("expected attempt recorded on the entry, got %+v", head)
+	}
+
+	resolution = ConflictSkip
+	result, err = c.ReplayOfflineQueue(context.Background())
+	if err != nil || result.Skipped != 1 || result.Replayed != 1 || result.Remaining != 0 {
+		t.Fatalf("expected conflict skipped and rest replayed, got %+v, %v", result, err)
+	}
+	if len(conflicts) != 2 || conflicts[1].Attempts != 2 {
+		t.Errorf("expected conflict handler called twice, got %+v", conflicts)
+	}
+	if commands := server.received(); len(commands) != 3 || commands[2] != "CMD OK" {
+		t.Errorf("unexpected commands: %v", commands)
+	}
+}
+
+// TestOfflineQueueLoadsStoredEntries verifies entries persisted by an earlier session replay first.
+func TestOfflineQueueLoadsStoredEntries(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+	store := &recordingStore{entries: map[uint64]OfflineEntry{
+		7: {ID: 7, Command: "OLD 2"},
+		3: {ID: 3, Command: "OLD 1"},
+	}}
+
+	goOffline(t, c)
+	c.EnableOfflineQueue(OfflineQueueOptions{Store: store})
+	result, _ := c.Mutate("NEW", 0)
+	if result.QueueID != 8 {
+		t.Errorf("expected new entry after stored IDs, got %d", result.QueueID)
+	}
+
+	goOnline(t, c)
+	waitForQueue(t, c, 0)
+	commands := server.received()
+	if len(commands) != 3 || commands[0] != "OLD 1" || commands[1] != "OLD 2" || commands[2] != "NEW" {
+		t.Errorf("expected stored entries first, got %v", commands)
+	}
+}
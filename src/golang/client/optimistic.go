@@ -0,0 +1,107 @@
+package client
+
+import "fmt"
+
+// versionCheck is the optimistic-locking condition of an UpdateBuilder.
+type versionCheck struct {
+	field   string
+	current interface{}
+}
+
+// WithVersion enables optimistic locking: the update only applies to
+// documents whose field still equals current, and sets field to current+1.
+// If no document matched, Execute returns an E_STALE_VERSION error, meaning
+// another writer modified the document first and the caller should re-read it
+// and retry. The error matches ErrTxConflict, so InTransaction with
+// WithTxRetry re-runs the callback. current must be an integer.
+func (ub *UpdateBuilder) WithVersion(field string, current interface{}) *UpdateBuilder {
+	ub.version = &versionCheck{field: field, current: current}
+	return ub
+}
+
+// versionedClauses returns the SET fields and WHERE clauses including the
+// version check and bump, without modifying the builder.
+func (ub *UpdateBuilder) versionedClauses() (map[string]interface{}, []whereClause) {
+	if ub.version == nil {
+		return ub.setFields, ub.whereClauses
+	}
+
+	next, _ := nextVersion(ub.version.current)
+	setFields := make(map[string]interface{}, len(ub.setFields)+1)
+	for field, value := range ub.setFields {
+		setFields[field] = value
+	}
+	setFields[ub.version.field] = next
+
+	whereClauses := append(ub.whereClauses[:len(ub.whereClauses):len(ub.whereClauses)], whereClause{
+		field:     ub.version.field,
+		operator:  Equals,
+		value:     ub.version.current,
+		connector: And,
+	})
+	return setFields, whereClauses
+}
+
+// validateVersion checks that the version check can be applied safely.
+func (ub *UpdateBuilder) validateVersion() error {
+	if ub.version == nil {
+		return nil
+	}
+	if _, ok := nextVersion(ub.version.current); !ok || ub.version.field == "" {
+		return &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("version field %q requires an integer version, got %T", ub.version.field, ub.version.current),
+		}
+	}
+	// AND binds tighter than OR, so the version check would only guard the last branch
+	for i, clause := range ub.whereClauses {
+		if i > 0 && clause.connector == Or {
+			return &QueryError{
+				Code:    "E_INVALID_QUERY",
+				Type:    "QueryError",
+				Message: "WithVersion cannot be combined with OR conditions",
+			}
+		}
+	}
+	return nil
+}
+
+// nextVersion returns v+1 for integer versions, keeping the input's type.
+// float64 is accepted for versions decoded from JSON.
+func nextVersion(v interface{}) (interface{}, bool) {
+	switch n := v.(type) {
+	case int:
+		return n + 1, true
+	case int32:
+		return n + 1, true
+	case int64:
+		return n + 1, true
+	case uint:
+		return n + 1, true
+	case uint32:
+		return n + 1, true
+	case uint64:
+		return n + 1, true
+	case float64:
+		if n == float64(int64(n)) {
+			return int64(n) + 1, true
+		}
+	}
+	return nil, false
+}
+
+// ErrStaleVersion creates an error for an optimistic-locking update that
+// matched no document because its version changed.
+func ErrStaleVersion(bundle, field string, version interface{}) *QueryError {
+	return &QueryError{
+		Code:    "E_STALE_VERSION",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("document in %s was modified concurrently: %s is no longer %v", bundle, field, version),
+		Details: map[string]interface{}{
+			"bundle":  bundle,
+			"field":   field,
+			"version": version,
+		},
+	}
+}
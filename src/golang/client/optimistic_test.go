@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestWithVersionQuery verifies the version check is added to WHERE and the bump to SET.
+func TestWithVersionQuery(t *testing.T) {
+	c := NewClient(nil)
+	ub := c.UpdateBuilder("users").Set("name", "Bob").Where("DocumentID", Equals, "d1").WithVersion("version", 4)
+
+	query, _ := ub.buildUpdateQuery()
+	if !strings.Contains(query, `"version" = 5`) || !strings.Contains(query, `"name" = "Bob"`) {
+		t.Errorf("expected the version bump in SET, got %s", query)
+	}
+	if !strings.HasSuffix(query, `WHERE "DocumentID" == "d1" AND "version" == 4;`) {
+		t.Errorf("expected the version check in WHERE, got %s", query)
+	}
+	if len(ub.whereClauses) != 1 || len(ub.setFields) != 1 {
+		t.Error("expected the builder's own clauses to be unchanged")
+	}
+}
+
+// TestWithVersionStale verifies zero matched documents yield E_STALE_VERSION.
+func TestWithVersionStale(t *testing.T) {
+	affected := `{"success":true,"data":{"affected_count":1}}`
+	c, _ := newPipeClient(t, func(command string) string { return affected })
+	ctx := context.Background()
+
+	update := func() error {
+		_, err := c.UpdateBuilder("users").Set("name", "Bob").Where("DocumentID", Equals, "d1").WithVersion("version", float64(4)).Execute(ctx)
+		return err
+	}
+	if err := update(); err != nil {
+		t.Fatalf("expected update to succeed, got %v", err)
+	}
+
+	affected = `{"success":true,"data":{"affected_count":0}}`
+	err := update()
+	if ErrorCode(err) != "E_STALE_VERSION" || !errors.Is(err, ErrTxConflict) {
+		t.Fatalf("expected E_STALE_VERSION, got %v", err)
+	}
+
+	// Without WithVersion, zero matches are not an error
+	if _, err := c.UpdateBuilder("users").Set("name", "Bob").Where("DocumentID", Equals, "d1").Execute(ctx); err != nil {
+		t.Errorf("expected unversioned update to succeed, got %v", err)
+	}
+}
+
+// TestWithVersionInvalid verifies non-integer versions and OR conditions are rejected.
+func TestWithVersionInvalid(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	ctx := context.Background()
+
+	_, err := c.UpdateBuilder("users").Set("name", "Bob").Where("DocumentID", Equals, "d1").WithVersion("version", "v4").Execute(ctx)
+	if ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("expected E_INVALID_QUERY for a string version, got %v", err)
+	}
+
+	_, err = c.UpdateBuilder("users").Set("name", "Bob").Where("id", Equals, 1).Or("id", Equals, 2).WithVersion("version", 1).Execute(ctx)
+	if ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("expected E_INVALID_QUERY with OR, got %v", err)
+	}
+	if got := server.received(); len(got) != 0 {
+		t.Errorf("expected no commands, got %v", got)
+	}
+}
@@ -1,16 +1,34 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
 	"time"
 )
 
 // ClientOptions configures the SyndrDB client behavior.
 type ClientOptions struct {
+	// ConnString is the connection string Connect uses when called with "".
+	// Set by OptionsFromEnv and OptionsFromFile from the "conn" key.
+	ConnString string
+
 	// DefaultTimeoutMs is the default timeout in milliseconds for operations.
 	// Default: 10000 (10 seconds)
 	DefaultTimeoutMs int
 
+	// DefaultQueryTimeout bounds builder queries that do not set WithTimeout.
+	// Zero leaves them bounded only by the caller's context.
+	// Default: 10s
+	DefaultQueryTimeout time.Duration
+
+	// StatementTimeout bounds each execution of a prepared statement, including
+	// QueryWithParams. A statement that runs longer is cancelled on the server
+	// and its connection is health-checked before reuse. Override it with
+	// Statement.SetTimeout or WithStatementTimeout. Zero disables the limit.
+	// Default: 0
+	StatementTimeout time.Duration
+
 	// DebugMode enables verbose error serialization with full cause chains.
 	// When true, errors include complete stack of wrapped errors.
 	// When false, errors are flattened to single message.
@@ -22,6 +40,11 @@ type ClientOptions struct {
 	// Default: 3
 	MaxRetries int
 
+	// RetryPolicy controls automatic retries of failed commands.
+	// Override per call with WithRetryPolicy; see DefaultRetryPolicy.
+	// Default: nil (no command retries)
+	RetryPolicy *RetryPolicy
+
 	// PoolMinSize is the minimum number of idle connections to maintain.
 	// Default: 1 (single connection mode)
 	PoolMinSize int
@@ -38,10 +61,120 @@ type ClientOptions struct {
 	// Default: 30s
 	HealthCheckInterval time.Duration
 
+	// PoolRefillJitter is the upper bound of the random delay before the pool
+	// replaces a dropped connection, so that many clients losing connections
+	// at once do not reconnect together.
+	// Default: 250ms
+	PoolRefillJitter time.Duration
+
+	// PoolMaxConnLifetime recycles pooled connections once they have been open
+	// this long, so load balancers can rebalance and leaked server state is
+	// bounded. A connection in use is closed when its command completes.
+	// Zero keeps connections open indefinitely.
+	// Default: 0
+	PoolMaxConnLifetime time.Duration
+
+	// PoolMaxConnUses recycles pooled connections after they have been checked
+	// out this many times. Zero disables the limit.
+	// Default: 0
+	PoolMaxConnUses int
+
+	// IdleProbeInterval sends a lightweight ping on a connection that has been
+	// idle this long, on pooled and single connections alike, so connections
+	// silently dropped by a NAT or load balancer are found and replaced before
+	// a command is sent on them. Zero disables probing.
+	// Default: 0
+	IdleProbeInterval time.Duration
+
+	// MaxInFlightCommands caps the commands executing at once across the client,
+	// so a batch job cannot monopolize the pool. Zero disables the limit.
+	// Default: 0
+	MaxInFlightCommands int
+
+	// CommandsPerSecond caps the rate at which commands are started, protecting
+	// small server instances. Zero disables the limit.
+	// Default: 0
+	CommandsPerSecond float64
+
+	// CommandBurst is how many commands may start at once under CommandsPerSecond.
+	// Default: 1
+	CommandBurst int
+
+	// LimiterMaxWait is how long a command waits for the limiter before failing
+	// with E_RATE_LIMITED. Zero fails immediately when the limiter is saturated.
+	// Default: 0
+	LimiterMaxWait time.Duration
+
+	// LoadSheddingThreshold enables load shedding: while the p95 latency of
+	// commands completed within LoadSheddingWindow exceeds it, commands with a
+	// priority below PriorityNormal fail with E_OVERLOADED so that interactive
+	// traffic keeps the capacity. Zero disables shedding.
+	// Default: 0
+	LoadSheddingThreshold time.Duration
+
+	// LoadSheddingWindow is how far back latencies count towards the p95.
+	// Default: 10s
+	LoadSheddingWindow time.Duration
+
+	// HedgeDelay enables hedged reads in pooled mode: a SELECT or SHOW not
+	// answered within it is sent again on another connection, which may be on
+	// another cluster member, and the first response wins. Override it per
+	// call with WithHedgeDelay. Zero disables hedging.
+	// Default: 0
+	HedgeDelay time.Duration
+
+	// HedgeBudget caps hedges to a fraction of eligible reads, so that a
+	// slow server is not sent twice the load. Nil means unlimited.
+	// Default: nil
+	HedgeBudget *RetryBudget
+
+	// StateHistorySize is how many recent state transitions are kept for
+	// GetStateHistory and QueryStateHistory. Negative disables the history.
+	// Default: 32
+	StateHistorySize int
+
+	// ReadYourWritesWindow is how long a Session from NewSession keeps the
+	// connection of its last write, so that its reads see the write.
+	// Default: 5s
+	ReadYourWritesWindow time.Duration
+
 	// MaxReconnectAttempts is the maximum number of automatic reconnection attempts.
 	// Default: 10
 	MaxReconnectAttempts int
 
+	// Dialer opens the network connection to the server, e.g. to route through a
+	// proxy or to connect to an in-memory server in tests (see package clienttest).
+	// If nil, a TCP connection is dialed with DefaultTimeoutMs. For a
+	// syndrdb+unix:// connection string, network is "unix" and address the
+	// socket path.
+	Dialer func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// Discoverer finds the cluster members to connect to, e.g. an SRVDiscoverer
+	// for the _syndrdb._tcp records of a domain. Connections are spread across
+	// the members and skip unreachable ones; the connection string's host is
+	// then not dialed. If nil, the connection string's host is used.
+	Discoverer Discoverer
+
+	// DiscoveryRefreshInterval is how often Discoverer is consulted again while
+	// connected, so new connections follow the cluster as it changes.
+	// Zero disables refreshing.
+	// Default: 30s
+	DiscoveryRefreshInterval time.Duration
+
+	// DialAddressTimeout bounds each connection attempt when the server's
+	// hostname resolves to several addresses, so an unreachable address does
+	// not use up the whole connect timeout. Zero gives each address an even
+	// share of the time remaining.
+	// Default: 0
+	DialAddressTimeout time.Duration
+
+	// TCPKeepAlive is the period between TCP keepalive probes on the client's
+	// connections, including those opened by Dialer. Zero keeps the dialer's
+	// setting (Go's default dialer probes every 15s) and a negative value
+	// disables keepalives.
+	// Default: 0
+	TCPKeepAlive time.Duration
+
 	// TLSConfig provides custom TLS configuration.
 	// If nil, TLS is disabled unless TLSEnabled is true.
 	TLSConfig *tls.Config
@@ -67,6 +200,52 @@ type ClientOptions struct {
 	// If nil, a default logger is used.
 	Logger Logger
 
+	// Redaction masks sensitive values in hook contexts, log fields and error
+	// Details. See DefaultRedactionPolicy.
+	// Default: nil (no redaction beyond the logger's sensitive keys)
+	Redaction *RedactionPolicy
+
+	// AccessPolicy restricts the operations and fields commands may use per
+	// bundle, rejecting violations locally with E_POLICY_VIOLATION. See
+	// NewAccessPolicy.
+	// Default: nil (no restrictions)
+	AccessPolicy *AccessPolicy
+
+	// WireCapture records the raw frames exchanged with the server, for
+	// diagnosing protocol problems. See NewWireCapture and NewRotatingFile.
+	// Default: nil
+	WireCapture *WireCapture
+
+	// DateTimeLayout is the time.Format layout of DATETIME values: time.Time
+	// values in builders and statement parameters are sent in it, and
+	// ParseDateTime reads it.
+	// Default: time.RFC3339Nano
+	DateTimeLayout string
+
+	// TimeZone is the session time zone. time.Time values are converted to it
+	// before formatting, and DATETIME strings without an offset are read in it.
+	// Default: UTC
+	TimeZone *time.Location
+
+	// Timestamps makes InsertBuilder and UpdateBuilder set creation and
+	// modification timestamps. See DefaultTimestampPolicy.
+	// Default: nil (no automatic timestamps)
+	Timestamps *TimestampPolicy
+
+	// IDGenerator creates IDs for inserted documents that do not set IDField,
+	// e.g. UUIDv7Generator, ULIDGenerator or a SnowflakeGenerator.
+	// Default: nil (IDs are assigned by the server)
+	IDGenerator IDGenerator
+
+	// IDField is the field IDGenerator fills on insert.
+	// Default: "id"
+	IDField string
+
+	// TraceComments prefixes commands sent with a trace ID from WithTraceID
+	// with a /* trace_id=... */ comment, so server-side logs can be correlated.
+	// Default: false
+	TraceComments bool
+
 	// LogLevel sets the minimum log level (DEBUG, INFO, WARN, ERROR).
 	// Default: "INFO"
 	LogLevel string
@@ -84,6 +263,37 @@ type ClientOptions struct {
 	// Default: 100
 	PreparedStatementCacheSize int
 
+	// QueryCacheSize is the maximum number of query results held by the query cache.
+	// Only queries built with QueryBuilder.Cached are cached.
+	// Default: 1000
+	QueryCacheSize int
+
+	// MaxResponseBytes is the largest response the client will read, counting
+	// every frame of a response sent in several, both as read and, for
+	// compressed responses, once decompressed. QueryStream, which does not
+	// hold the whole response, applies it to each frame instead.
+	// Larger responses fail with E_RESPONSE_TOO_LARGE; unless the response
+	// was read in full, the connection is discarded.
+	// Zero disables the limit.
+	// Default: 64 MiB
+	MaxResponseBytes int
+
+	// MaxRowsInMemory is the largest number of rows a single response may contain.
+	// Larger results fail with E_RESPONSE_TOO_LARGE. Zero disables the limit.
+	// Default: 0 (unlimited)
+	MaxRowsInMemory int
+
+	// ReadBufferSize is the size of each connection's read buffer. Responses
+	// that fit in it are parsed in place; larger ones are assembled in a
+	// buffer reused across reads.
+	// Default: 64 KiB
+	ReadBufferSize int
+
+	// Compression requests compressed responses during the handshake.
+	// Used only if the server advertises support; otherwise responses are uncompressed.
+	// Default: CompressionNone
+	Compression Compression
+
 	// TransactionTimeout is the maximum duration a transaction can remain active.
 	// Transactions exceeding this timeout are automatically rolled back.
 	// Default: 5 minutes
@@ -94,6 +304,20 @@ type ClientOptions struct {
 	// Default: 5 minutes
 	SchemaCacheTTL time.Duration
 
+	// SchemaCache stores the schema builders are validated against. Give
+	// several clients the same cache, e.g. a MemorySchemaCache or a
+	// StoreSchemaCache backed by Redis, so they fetch the schema once between
+	// them and share invalidations.
+	// Default: nil (a MemorySchemaCache per client)
+	SchemaCache SchemaCache
+
+	// SchemaNotifications subscribes to the server's schema change
+	// notifications on a dedicated connection, so changes made by other
+	// clients invalidate the schema cache and run OnSchemaChange handlers.
+	// Requires a server advertising the "schema_notifications" feature.
+	// Default: false
+	SchemaNotifications bool
+
 	// PreloadSchema enables eager schema loading during connection initialization.
 	// When true, schema is fetched immediately after connecting.
 	// Default: false
@@ -104,17 +328,29 @@ type ClientOptions struct {
 func DefaultOptions() ClientOptions {
 	return ClientOptions{
 		DefaultTimeoutMs:           10000,
+		DefaultQueryTimeout:        10 * time.Second,
 		DebugMode:                  false,
 		MaxRetries:                 3,
 		PoolMinSize:                1,
 		PoolMaxSize:                1,
 		PoolIdleTimeout:            30 * time.Second,
 		HealthCheckInterval:        30 * time.Second,
+		PoolRefillJitter:           250 * time.Millisecond,
+		DiscoveryRefreshInterval:   30 * time.Second,
 		MaxReconnectAttempts:       10,
+		StateHistorySize:           defaultStateHistorySize,
+		ReadYourWritesWindow:       5 * time.Second,
 		TLSEnabled:                 false,
 		TLSInsecureSkipVerify:      false,
 		LogLevel:                   "INFO",
 		PreparedStatementCacheSize: 100,
+		QueryCacheSize:             1000,
+		MaxResponseBytes:           64 << 20,
+		ReadBufferSize:             64 << 10,
+		IDField:                    "id",
+		DateTimeLayout:             time.RFC3339Nano,
+		TimeZone:                   time.UTC,
+		Compression:                CompressionNone,
 		TransactionTimeout:         5 * time.Minute,
 		SchemaCacheTTL:             5 * time.Minute,
 		PreloadSchema:              false,
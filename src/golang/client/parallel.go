@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// ParallelResult is the outcome of one query run by QueryParallel.
+type ParallelResult struct {
+	Query  string
+	Result interface{}
+	Error  error
+}
+
+// ParallelOption configures QueryParallel.
+type ParallelOption func(*parallelOptions)
+
+type parallelOptions struct {
+	concurrency int
+	failFast    bool
+}
+
+// WithConcurrency limits how many queries QueryParallel runs at once.
+// The default is PoolMaxSize. Values above it only make queries wait for a
+// pooled connection.
+func WithConcurrency(n int) ParallelOption {
+	return func(o *parallelOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithFailFast makes QueryParallel cancel the queries still running or
+// waiting to start once one fails. Their results carry the cancellation error.
+func WithFailFast() ParallelOption {
+	return func(o *parallelOptions) {
+		o.failFast = true
+	}
+}
+
+// QueryParallel runs queries concurrently across pooled connections and
+// returns one result per query, in the order given. Each query goes through
+// the normal command path, so hooks, retries and cache invalidation apply.
+// The error is the failure of the lowest-indexed query that failed.
+//
+// Example:
+//
+//	results, err := client.QueryParallel(ctx, []string{
+//		`SELECT * FROM BUNDLE "orders" WHERE "status" == "open";`,
+//		`SELECT * FROM BUNDLE "users" WHERE "active" == true;`,
+//	}, client.WithConcurrency(4))
+//
+// Without a pool (PoolMaxSize <= 1) the single connection cannot carry
+// concurrent requests, so queries run one at a time.
+func (c *Client) QueryParallel(ctx context.Context, queries []string, opts ...ParallelOption) ([]ParallelResult, error) {
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("QueryParallel", CONNECTED, c.stateMgr.GetState())
+	}
+
+	options := parallelOptions{concurrency: c.opts.PoolMaxSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if !c.poolEnabled || c.pool == nil {
+		options.concurrency = 1
+	}
+	if options.concurrency < 1 {
+		options.concurrency = 1
+	}
+	if options.concurrency > len(queries) {
+		options.concurrency = len(queries)
+	}
+
+	if len(queries) == 0 {
+		return []ParallelResult{}, nil
+	}
+
+	var onError func()
+	if options.failFast {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		onError = cancel
+	}
+
+	results := c.runParallel(ctx, queries, options.concurrency, onError)
+	for _, r := range results {
+		if r.Error != nil {
+			return results, r.Error
+		}
+	}
+	return results, nil
+}
+
+// runParallel executes queries with at most concurrency workers. When
+// onError is set it is called after the first failure.
+func (c *Client) runParallel(ctx context.Context, queries []string, concurrency int, onError func()) []ParallelResult {
+	results := make([]ParallelResult, len(queries))
+	indexes := make(chan int)
+	var failOnce sync.Once
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i].Query = queries[i]
+				if err := ctx.Err(); err != nil {
+					results[i].Error = err
+					continue
+				}
+				results[i].Result, results[i].Error = c.sendCommand(ctx, queries[i])
+				if results[i].Error != nil && onError != nil {
+					failOnce.Do(onError)
+				}
+			}
+		}()
+	}
+
+	for i := range queries {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	c.logger.Debug("parallel queries executed",
+		Int("queries", len(queries)),
+		Int("concurrency", concurrency))
+
+	return results
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newPooledParallelClient connects a client with a pool of poolSize to a
+// server that answers each command with its text after delay, recording the
+// most commands it was serving at once in peak.
+func newPooledParallelClient(t *testing.T, poolSize int, delay time.Duration, peak *atomic.Int32) *Client {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var inFlight atomic.Int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					command, err := reader.ReadString('\x04')
+					if err != nil {
+						return
+					}
+					command = strings.TrimSuffix(command, "\x04")
+					if strings.HasPrefix(command, "syndrdb://") {
+						conn.Write([]byte("S0001 Welcome to SyndrDB\n{\"status\": \"success\"}\n"))
+						continue
+					}
+					n := inFlight.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					time.Sleep(delay)
+					inFlight.Add(-1)
+					conn.Write([]byte(`{"success": true, "data": "` + command + `"}` + "\n"))
+				}
+			}()
+		}
+	}()
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.PoolMinSize = 1
+	opts.PoolMaxSize = poolSize
+	c := NewClient(&opts)
+	if err := c.Connect(context.Background(), "syndrdb://"+ln.Addr().String()+":primary:root:root;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c
+}
+
+func parallelQueries(n int) []string {
+	queries := make([]string, n)
+	for i := range queries {
+		queries[i] = "Q" + strconv.Itoa(i) + ";"
+	}
+	return queries
+}
+
+// TestQueryParallelPositionalResults verifies results line up with their queries.
+func TestQueryParallelPositionalResults(t *testing.T) {
+	var peak atomic.Int32
+	c := newPooledParallelClient(t, 4, 5*time.Millisecond, &peak)
+	queries := parallelQueries(12)
+
+	results, err := c.QueryParallel(context.Background(), queries)
+	if err != nil {
+		t.Fatalf("QueryParallel failed: %v", err)
+	}
+	if len(results) != len(queries) {
+		t.Fatalf("expected %d results, got %d", len(queries), len(results))
+	}
+	for i, r := range results {
+		if r.Query != queries[i] || r.Result != queries[i] || r.Error != nil {
+			t.Errorf("result %d: expected %q, got %+v", i, queries[i], r)
+		}
+	}
+	if peak.Load() < 2 {
+		t.Errorf("expected queries to overlap, peak in-flight was %d", peak.Load())
+	}
+}
+
+// TestQueryParallelConcurrencyLimit verifies WithConcurrency bounds in-flight queries.
+func TestQueryParallelConcurrencyLimit(t *testing.T) {
+	var peak atomic.Int32
+	c := newPooledParallelClient(t, 8, 10*time.Millisecond, &peak)
+
+	if _, err := c.QueryParallel(context.Background(), parallelQueries(10), WithConcurrency(2)); err != nil {
+		t.Fatalf("QueryParallel failed: %v", err)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("expected at most 2 queries in flight, got %d", p)
+	}
+}
+
+// TestQueryParallelErrors verifies failures are reported per query and the
+// lowest-indexed one is returned.
+func TestQueryParallelErrors(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		if strings.HasPrefix(command, "BAD") {
+			return `{"success": false, "error": "` + command + ` failed"}`
+		}
+		return `{"success": true, "data": "ok"}`
+	})
+
+	results, err := c.QueryParallel(context.Background(), []string{"GOOD", "BAD1", "GOOD", "BAD2"})
+	if err == nil || !strings.Contains(err.Error(), "BAD1") {
+		t.Fatalf("expected BAD1's error, got %v", err)
+	}
+	for i, failed := range []bool{false, true, false, true} {
+		if (results[i].Error != nil) != failed {
+			t.Errorf("result %d: unexpected error state %v", i, results[i].Error)
+		}
+		if !failed && results[i].Result != "ok" {
+			t.Errorf("result %d: expected ok, got %v", i, results[i].Result)
+		}
+	}
+	if got := len(server.received()); got != 4 {
+		t.Errorf("expected every query to be sent, got %d", got)
+	}
+}
+
+// TestQueryParallelFailFast verifies WithFailFast skips queries after a failure.
+func TestQueryParallelFailFast(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		if command == "BAD" {
+			return `{"success": false, "error": "syntax error"}`
+		}
+		return `{"success": true, "data": "ok"}`
+	})
+
+	results, err := c.QueryParallel(context.Background(), []string{"GOOD", "BAD", "GOOD", "GOOD"}, WithFailFast())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if results[0].Error != nil || results[1].Error == nil {
+		t.Errorf("expected first query to succeed and second to fail, got %+v", results[:2])
+	}
+	for _, r := range results[2:] {
+		if r.Error != context.Canceled {
+			t.Errorf("expected skipped query to report context.Canceled, got %v", r.Error)
+		}
+	}
+	if got := len(server.received()); got != 2 {
+		t.Errorf("expected 2 queries sent, got %d", got)
+	}
+}
+
+// TestQueryParallelRequiresConnection verifies the state check.
+func TestQueryParallelRequiresConnection(t *testing.T) {
+	c := NewClient(nil)
+	if _, err := c.QueryParallel(context.Background(), []string{"SHOW BUNDLES;"}); err == nil {
+		t.Error("expected error when not connected")
+	}
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// parseParamTypes reads parameter types from a PREPARE response, found under
+// "ParamTypes" either at the top level or inside "Result". Each entry is a
+// type name or an object with a "Type" member, by position. It returns nil if
+// the server sent no types or their number does not match paramCount, in
+// which case arguments are sent unchecked.
+func parseParamTypes(response interface{}, paramCount int) []schema.FieldType {
+	respMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	entries, ok := respMap["ParamTypes"].([]interface{})
+	if !ok {
+		result, _ := respMap["Result"].(map[string]interface{})
+		if entries, ok = result["ParamTypes"].([]interface{}); !ok {
+			return nil
+		}
+	}
+	if len(entries) != paramCount {
+		return nil
+	}
+
+	types := make([]schema.FieldType, len(entries))
+	for i, entry := range entries {
+		name := stringValue(entry)
+		if m, ok := entry.(map[string]interface{}); ok {
+			name = stringValue(m["Type"])
+		}
+		types[i] = schema.FieldType(strings.ToUpper(name))
+	}
+	return types
+}
+
+// checkParamTypes validates params against the statement's parameter types,
+// converting values that represent the expected type losslessly, such as
+// "42" or 42.0 for an INT. nil is accepted for every type, and types the
+// driver does not know are not checked.
+func checkParamTypes(types []schema.FieldType, params []interface{}) ([]interface{}, error) {
+	if types == nil {
+		return params, nil
+	}
+	converted := make([]interface{}, len(params))
+	for i, value := range params {
+		converted[i] = value
+		if value == nil || i >= len(types) {
+			continue
+		}
+		v, ok := convertParam(value, types[i])
+		if !ok {
+			return nil, ErrParamTypeMismatch(i+1, string(types[i]), value)
+		}
+		converted[i] = v
+	}
+	return converted, nil
+}
+
+// convertParam returns value as typ, and false if it cannot represent one.
+func convertParam(value interface{}, typ schema.FieldType) (interface{}, bool) {
+	switch typ {
+	case schema.STRING, schema.TEXT:
+		switch v := value.(type) {
+		case string:
+			return v, true
+		case []byte:
+			return string(v), true
+		}
+		return nil, false
+
+	case schema.INT:
+		switch v := value.(type) {
+		case string:
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			return n, err == nil
+		case json.Number:
+			n, err := v.Int64()
+			return n, err == nil
+		case *big.Int:
+			return v.Int64(), v.IsInt64()
+		}
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return value, true
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, false
+			}
+			return int64(f), true
+		}
+		return nil, false
+
+	case schema.FLOAT, schema.DECIMAL:
+		switch v := value.(type) {
+		case string:
+			if typ == schema.DECIMAL {
+				r, ok := new(big.Rat).SetString(strings.TrimSpace(v))
+				return r, ok
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			return f, err == nil
+		case json.Number:
+			return v, true
+		case *big.Rat, big.Rat, *big.Float, *big.Int, DecimalValue:
+			return value, true
+		}
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return value, true
+		}
+		return nil, false
+
+	case schema.BOOLEAN:
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			return b, err == nil
+		}
+		return nil, false
+
+	case schema.DATETIME:
+		switch value.(type) {
+		case time.Time, *time.Time, string:
+			return value, true
+		}
+		return nil, false
+	}
+	return value, true
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// TestParseParamTypes verifies the parameter type formats read from PREPARE.
+func TestParseParamTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		response interface{}
+		count    int
+		want     []schema.FieldType
+	}{
+		{"names", map[string]interface{}{"ParamTypes": []interface{}{"string", "INT"}}, 2, []schema.FieldType{schema.STRING, schema.INT}},
+		{"objects", map[string]interface{}{"Result": map[string]interface{}{
+			"ParamTypes": []interface{}{map[string]interface{}{"Position": 1.0, "Type": "BOOLEAN"}},
+		}}, 1, []schema.FieldType{schema.BOOLEAN}},
+		{"count mismatch", map[string]interface{}{"ParamTypes": []interface{}{"INT"}}, 2, nil},
+		{"absent", map[string]interface{}{"status": "ok"}, 1, nil},
+		{"not a map", "OK", 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseParamTypes(tt.response, tt.count); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheckParamTypes verifies lossless conversions and mismatches.
+func TestCheckParamTypes(t *testing.T) {
+	types := []schema.FieldType{schema.INT, schema.BOOLEAN, schema.STRING, schema.FLOAT, schema.JSON}
+	got, err := checkParamTypes(types, []interface{}{"42", "true", []byte("x"), 3, map[string]interface{}{"a": 1}})
+	if err != nil {
+		t.Fatalf("checkParamTypes failed: %v", err)
+	}
+	want := []interface{}{int64(42), true, "x", 3, map[string]interface{}{"a": 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+
+	if got, err := checkParamTypes([]schema.FieldType{schema.INT}, []interface{}{nil}); err != nil || got[0] != nil {
+		t.Errorf("expected nil to be accepted, got %v, %v", got, err)
+	}
+	if got, err := checkParamTypes([]schema.FieldType{schema.INT}, []interface{}{2.0}); err != nil || got[0] != int64(2) {
+		t.Errorf("expected 2.0 to convert to INT, got %v, %v", got, err)
+	}
+
+	mismatches := []struct {
+		typ   schema.FieldType
+		value interface{}
+	}{
+		{schema.INT, 2.5},
+		{schema.INT, "abc"},
+		{schema.BOOLEAN, 1},
+		{schema.STRING, 7},
+		{schema.FLOAT, true},
+		{schema.DATETIME, 5},
+	}
+	for _, m := range mismatches {
+		_, err := checkParamTypes([]schema.FieldType{m.typ}, []interface{}{m.value})
+		if ErrorCode(err) != "E_PARAM_TYPE_MISMATCH" || !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("%s %#v: expected E_PARAM_TYPE_MISMATCH, got %v", m.typ, m.value, err)
+		}
+	}
+}
+
+// TestStatementParamTypes verifies Execute validates and converts arguments
+// against the types returned by PREPARE before sending EXECUTE.
+func TestStatementParamTypes(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		if strings.HasPrefix(command, "PREPARE") {
+			return `{"success":true,"data":{"ParamTypes":["STRING","INT"]}}`
+		}
+		return `{"status":"ok"}`
+	})
+
+	stmt, err := c.Prepare(context.Background(), "find_user",
+		`SELECT * FROM BUNDLE "users" WHERE "email" == $1 AND "age" >= $2;`)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if !reflect.DeepEqual(stmt.ParamTypes(), []schema.FieldType{schema.STRING, schema.INT}) {
+		t.Errorf("unexpected parameter types %v", stmt.ParamTypes())
+	}
+
+	if _, err := stmt.Execute("a@example.com", "21"); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	_, err = stmt.Execute("a@example.com", "twenty")
+	var qe *QueryError
+	if !errors.As(err, &qe) || qe.Code != "E_PARAM_TYPE_MISMATCH" || qe.Details["expected"] != "INT" || qe.Details["position"] != 2 {
+		t.Errorf("expected E_PARAM_TYPE_MISMATCH for $2, got %v", err)
+	}
+
+	want := []string{
+		`PREPARE find_user AS SELECT * FROM BUNDLE "users" WHERE "email" == $1 AND "age" >= $2;`,
+		"EXECUTE find_user\x05a@example.com\x0521",
+	}
+	if got := server.received(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
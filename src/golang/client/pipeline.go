@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Pipeline sends several commands on one connection before reading any
+// responses, trading one round trip per command for a single round trip
+// for the whole batch. Commands are not atomic; use a transaction for that.
+//
+// Example:
+//
+//	results, err := client.Pipeline().
+//		Add(`ADD DOCUMENT TO BUNDLE "events" WITH ({"type" = "a"});`).
+//		Add(`ADD DOCUMENT TO BUNDLE "events" WITH ({"type" = "b"});`).
+//		Execute(ctx)
+type Pipeline struct {
+	client   *Client
+	commands []string
+}
+
+// PipelineResult is the outcome of one pipelined command.
+type PipelineResult struct {
+	Command string
+	Result  interface{}
+	Error   error
+}
+
+// Pipeline returns a new, empty pipeline.
+func (c *Client) Pipeline() *Pipeline {
+	return &Pipeline{client: c}
+}
+
+// Add appends commands to the pipeline.
+func (p *Pipeline) Add(commands ...string) *Pipeline {
+	p.commands = append(p.commands, commands...)
+	return p
+}
+
+// Len returns the number of queued commands.
+func (p *Pipeline) Len() int {
+	return len(p.commands)
+}
+
+// Execute writes all commands, then reads their responses in order.
+// The returned slice always has one entry per command, in the order added.
+// Hooks run for each command. The load shedder and command limiter admit the
+// pipeline as a single command. The error is the first failure encountered,
+// whether it affected the whole pipeline or a single command.
+func (p *Pipeline) Execute(ctx context.Context) ([]PipelineResult, error) {
+	c := p.client
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("Pipeline.Execute", CONNECTED, c.stateMgr.GetState())
+	}
+
+	results := make([]PipelineResult, len(p.commands))
+	if len(p.commands) == 0 {
+		return results, nil
+	}
+
+	// The batch is admitted by the load shedder and limiter as one command
+	if c.shedder != nil {
+		if err := c.shedder.admit(QueryPriorityFromContext(ctx)); err != nil {
+			c.logger.Warn("low-priority pipeline shed",
+				Int("commands", len(p.commands)),
+				Error("error", err))
+			return nil, err
+		}
+	}
+
+	if c.limiter != nil {
+		release, err := c.limiter.acquire(ctx)
+		if err != nil {
+			c.logger.Warn("pipeline rejected by limiter",
+				Int("commands", len(p.commands)),
+				Error("error", err))
+			return nil, err
+		}
+		defer release()
+	}
+
+	if c.shedder != nil {
+		admitted := time.Now()
+		defer func() { c.shedder.record(time.Since(admitted)) }()
+	}
+
+	// Acquire a single connection for the whole pipeline
+	var conn ConnectionInterface
+	var poolWait time.Duration
+	if c.poolEnabled && c.pool != nil {
+		var err error
+		waitStart := time.Now()
+		conn, err = c.pool.Get(ctx)
+		poolWait = time.Since(waitStart)
+		if err != nil {
+			return nil, err
+		}
+		defer c.pool.Put(conn)
+	} else {
+		if c.conn == nil {
+			return nil, &ConnectionError{
+				Code:    "NO_CONNECTION",
+				Type:    "CONNECTION_ERROR",
+				Message: "no active connection",
+			}
+		}
+		conn = c.conn
+	}
+
+	// Switch databases before writing, so sendOnConn never needs a round
+	// trip while responses are outstanding
+	if err := c.ensureDatabase(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	hookCtxs := make([]*HookContext, len(p.commands))
+	hooked := make([]bool, len(p.commands)) // Before hooks succeeded
+	sent := make([]bool, len(p.commands))
+	var firstErr error
+	var sendErr error
+
+	// Write phase
+	for i, command := range p.commands {
+		results[i].Command = command
+
+		hookCtx := newHookContext(ctx, command)
+		hookCtx.Metadata["pipeline_index"] = i
+		hookCtx.Metadata["pipeline_size"] = len(p.commands)
+		hookCtx.PoolWaitDuration = poolWait
+		hookCtx.setConnection(conn)
+		hookCtxs[i] = hookCtx
+
+		if err := c.executeBeforeHooks(ctx, hookCtx); err != nil {
+			results[i].Error = err
+			continue
+		}
+		hooked[i] = true
+		results[i].Command = hookCtx.Command
+
+		if err := c.opts.AccessPolicy.Check(hookCtx.Command); err != nil {
+			results[i].Error = err
+			continue
+		}
+
+		if sendErr != nil {
+			results[i].Error = pipelineAbortedError(i, sendErr)
+			continue
+		}
+
+		if err := c.sendOnConn(ctx, conn, hookCtx.Command); err != nil {
+			c.logger.Error("failed to send pipelined command",
+				Int("index", i),
+				Error("error", err))
+			sendErr = err
+			results[i].Error = err
+			continue
+		}
+		sent[i] = true
+	}
+
+	// Read phase: one response per sent command, in order
+	var readErr error
+	for i := range p.commands {
+		if !sent[i] {
+			continue
+		}
+
+		if readErr != nil {
+			results[i].Error = pipelineAbortedError(i, readErr)
+			continue
+		}
+
+		result, err := conn.ReceiveResponse(ctx)
+		result, err = checkServerStatus(result, err, results[i].Command)
+		results[i].Result = result
+		results[i].Error = err
+		hookCtxs[i].Duration = time.Since(start)
+		hookCtxs[i].ResponseBytes = responseSize(conn)
+
+		// A dead connection cannot deliver the remaining responses
+		if err != nil && !conn.IsAlive() {
+			readErr = err
+		}
+	}
+
+	// After hooks and cache invalidation
+	for i, hookCtx := range hookCtxs {
+		if hooked[i] {
+			if !sent[i] {
+				hookCtx.Duration = time.Since(start)
+			}
+			hookCtx.Result = results[i].Result
+			hookCtx.Error = results[i].Error
+			if hookErr := c.executeAfterHooks(ctx, hookCtx); hookErr != nil {
+				results[i].Error = hookErr
+			}
+		}
+
+		if results[i].Error == nil {
+			c.invalidateCaches(results[i].Command, hookCtx.TraceID)
+		} else if firstErr == nil {
+			firstErr = results[i].Error
+		}
+	}
+
+	c.logger.Debug("pipeline executed",
+		Int("commands", len(p.commands)),
+		Duration("duration", time.Since(start)),
+		Bool("success", firstErr == nil))
+
+	return results, firstErr
+}
+
+// pipelineAbortedError reports a command skipped because the connection failed earlier.
+func pipelineAbortedError(index int, cause error) error {
+	return &QueryError{
+		Code:    "E_PIPELINE_ABORTED",
+		Type:    "QueryError",
+		Message: "pipelined command not completed due to an earlier connection failure",
+		Details: map[string]interface{}{
+			"index": index,
+		},
+		Cause: cause,
+	}
+}
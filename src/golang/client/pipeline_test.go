@@ -0,0 +1,227 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// pipeServer is a loopback server for a single-connection client.
+// It records each command and replies with respond(command) as one line.
+type pipeServer struct {
+	mu       sync.Mutex
+	commands []string
+}
+
+func (s *pipeServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+// newPipeClient returns a CONNECTED client whose connection is served by respond.
+func newPipeClient(t *testing.T, respond func(command string) string) (*Client, *pipeServer) {
+	t.Helper()
+
+	// A TCP loopback rather than net.Pipe: pipelining relies on the kernel
+	// buffering writes while responses are still unread.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	clientSide, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		ln.Close()
+		t.Fatalf("dial: %v", err)
+	}
+	serverSide, err := ln.Accept()
+	if err != nil {
+		ln.Close()
+		clientSide.Close()
+		t.Fatalf("accept: %v", err)
+	}
+	server := &pipeServer{}
+
+	go func() {
+		reader := bufio.NewReader(serverSide)
+		for {
+			command, err := reader.ReadString('\x04')
+			if err != nil {
+				return
+			}
+			command = strings.TrimSuffix(command, "\x04")
+			server.mu.Lock()
+			server.commands = append(server.commands, command)
+			server.mu.Unlock()
+			if _, err := serverSide.Write([]byte(respond(command) + "\n")); err != nil {
+				return
+			}
+		}
+	}()
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	c := NewClient(&opts)
+	c.conn = newConnection(clientSide, opts)
+	c.stateMgr.TransitionTo(CONNECTING, nil, nil)
+	c.stateMgr.TransitionTo(CONNECTED, nil, nil)
+
+	t.Cleanup(func() {
+		clientSide.Close()
+		serverSide.Close()
+		ln.Close()
+	})
+
+	return c, server
+}
+
+// TestPipelineOrderedResults verifies results are returned in command order.
+func TestPipelineOrderedResults(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return "ok:" + command
+	})
+
+	results, err := c.Pipeline().
+		Add("CMD 1", "CMD 2").
+		Add("CMD 3").
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, expected := range []string{"CMD 1", "CMD 2", "CMD 3"} {
+		if results[i].Command != expected || results[i].Result != "ok:"+expected {
+			t.Errorf("result %d: got %+v", i, results[i])
+		}
+	}
+	if got := server.received(); len(got) != 3 {
+		t.Errorf("expected server to receive 3 commands, got %v", got)
+	}
+}
+
+// TestPipelineCommandError verifies a server error fails only its own command.
+func TestPipelineCommandError(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		if command == "BAD" {
+			return `{"success": false, "error": "syntax error"}`
+		}
+		return `{"success": true, "data": "done"}`
+	})
+
+	results, err := c.Pipeline().Add("GOOD", "BAD", "GOOD").Execute(context.Background())
+	if ErrorCode(err) != "SERVER_ERROR" {
+		t.Fatalf("expected SERVER_ERROR, got %v", err)
+	}
+	if results[0].Error != nil || results[2].Error != nil {
+		t.Errorf("expected surrounding commands to succeed: %+v", results)
+	}
+	if results[2].Result != "done" {
+		t.Errorf("expected third result 'done', got %v", results[2].Result)
+	}
+}
+
+// TestPipelineHooks verifies hooks run per command and Before errors skip the command.
+func TestPipelineHooks(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return "ok" })
+
+	c.RegisterHook(&pipelineRejectHook{reject: "SKIP"})
+	recorder := &txRecordingHook{}
+	c.RegisterHook(recorder)
+
+	results, err := c.Pipeline().Add("ONE", "SKIP", "TWO").Execute(context.Background())
+	if err == nil || results[1].Error == nil {
+		t.Fatal("expected rejected command to fail")
+	}
+	if got := server.received(); len(got) != 2 || got[0] != "ONE" || got[1] != "TWO" {
+		t.Errorf("expected only ONE and TWO to be sent, got %v", got)
+	}
+	if len(recorder.commands) != 2 {
+		t.Errorf("expected After hooks for 2 commands, got %v", recorder.commands)
+	}
+}
+
+// TestPipelineEmpty verifies an empty pipeline is a no-op.
+func TestPipelineEmpty(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return "ok" })
+
+	results, err := c.Pipeline().Execute(context.Background())
+	if err != nil || len(results) != 0 {
+		t.Errorf("expected no results and no error, got %v, %v", results, err)
+	}
+	if len(server.received()) != 0 {
+		t.Error("expected nothing sent")
+	}
+}
+
+// TestPipelineRequiresConnection verifies Execute fails when disconnected.
+func TestPipelineRequiresConnection(t *testing.T) {
+	opts := DefaultOptions()
+	c := NewClient(&opts)
+
+	if _, err := c.Pipeline().Add("CMD").Execute(context.Background()); ErrorCode(err) != "INVALID_STATE" {
+		t.Errorf("expected INVALID_STATE, got %v", err)
+	}
+}
+
+// pipelineRejectHook fails Before for one command.
+type pipelineRejectHook struct {
+	reject string
+}
+
+func (h *pipelineRejectHook) Name() string { return "reject" }
+func (h *pipelineRejectHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	if hookCtx.Command == h.reject {
+		return errors.New("rejected")
+	}
+	return nil
+}
+func (h *pipelineRejectHook) After(ctx context.Context, hookCtx *HookContext) error { return nil }
+
+// TestPipelineLimiter verifies the command limiter admits a pipeline as one command.
+func TestPipelineLimiter(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	c.limiter = newCommandLimiter(&ClientOptions{MaxInFlightCommands: 1})
+
+	release, err := c.limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	_, err = c.Pipeline().Add("CMD1", "CMD2").Execute(context.Background())
+	if ErrorCode(err) != "E_RATE_LIMITED" {
+		t.Fatalf("expected E_RATE_LIMITED, got %v", err)
+	}
+	if len(server.received()) != 0 {
+		t.Error("expected nothing sent while the limiter is saturated")
+	}
+	release()
+
+	if _, err := c.Pipeline().Add("CMD1", "CMD2").Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if stats := c.LimiterStats(); stats.Admitted != 2 || stats.InFlight != 0 {
+		t.Errorf("unexpected limiter stats: %+v", stats)
+	}
+}
+
+// TestPipelineTraceComments verifies pipelined commands carry the trace comment.
+func TestPipelineTraceComments(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	c.opts.TraceComments = true
+
+	ctx := WithTraceID(context.Background(), "req-7")
+	if _, err := c.Pipeline().Add("CMD1", "CMD2").Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	for _, command := range server.received() {
+		if !strings.HasPrefix(command, "/* trace_id=req-7 */ ") {
+			t.Errorf("expected a trace comment, got %s", command)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,6 +24,9 @@ type PoolStats struct {
 	Misses            atomic.Int64
 	Timeouts          atomic.Int64
 	Errors            atomic.Int64
+	Refills           atomic.Int64 // connections opened by the maintainer to keep minIdle
+	Recycled          atomic.Int64 // connections closed on reaching their lifetime or use limit
+	ProbeFailures     atomic.Int64 // idle connections discarded after failing an idle probe
 }
 
 // ConnectionPool manages a pool of database connections with automatic cleanup.
@@ -33,11 +37,24 @@ type ConnectionPool struct {
 	maxOpen             int
 	idleTimeout         time.Duration
 	healthCheckInterval time.Duration
+	refillJitter        time.Duration
+	maxLifetime         time.Duration
+	maxUses             int
+	idleProbe           time.Duration
+	refillCh            chan struct{}
 	stats               PoolStats
 	stopCh              chan struct{}
 	wg                  sync.WaitGroup
 	mu                  sync.RWMutex
 	closed              bool
+	metaMu              sync.Mutex
+	meta                map[ConnectionInterface]*connMeta
+}
+
+// connMeta is what the pool tracks about each connection it opened.
+type connMeta struct {
+	expires time.Time // Zero without a lifetime limit
+	uses    int       // Times the connection was checked out
 }
 
 // NewConnectionPool creates a new connection pool with the specified configuration.
@@ -63,13 +80,33 @@ func NewConnectionPool(
 		maxOpen:             maxOpen,
 		idleTimeout:         idleTimeout,
 		healthCheckInterval: healthCheckInterval,
+		refillCh:            make(chan struct{}, 1),
+		meta:                make(map[ConnectionInterface]*connMeta),
 		stopCh:              make(chan struct{}),
 	}
 
 	return pool
 }
 
-// Initialize starts the pool and creates minimum idle connections.
+// poolSettings holds the pool options that NewConnectionPool does not take.
+type poolSettings struct {
+	refillJitter time.Duration // Upper bound of the random delay before the maintainer opens a connection
+	maxLifetime  time.Duration // Recycle connections open this long; zero for no limit
+	maxUses      int           // Recycle connections checked out this many times; zero for no limit
+	idleProbe    time.Duration // Ping connections idle this long; zero to disable
+}
+
+// configure applies settings. It must be called before Initialize.
+func (p *ConnectionPool) configure(settings poolSettings) {
+	p.refillJitter = settings.refillJitter
+	p.maxLifetime = settings.maxLifetime
+	p.maxUses = settings.maxUses
+	p.idleProbe = settings.idleProbe
+}
+
+// Initialize starts the pool and warms it up by opening minIdle connections
+// concurrently, so the first burst of traffic does not pay connect and auth
+// latency. If any connection fails, those already opened are closed.
 func (p *ConnectionPool) Initialize(ctx context.Context) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -78,24 +115,44 @@ func (p *ConnectionPool) Initialize(ctx context.Context) error {
 		return fmt.Errorf("pool is closed")
 	}
 
-	// Create initial connections up to minIdle
+	conns := make([]ConnectionInterface, p.minIdle)
+	errs := make([]error, p.minIdle)
+	var wg sync.WaitGroup
 	for i := 0; i < p.minIdle; i++ {
-		conn, err := p.factory(ctx)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conns[i], errs[i] = p.factory(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
 		if err != nil {
-			// Close any connections created so far
-			p.closeAllConnections()
+			for _, conn := range conns {
+				if conn != nil {
+					conn.Close()
+				}
+			}
 			return fmt.Errorf("failed to create initial connection: %w", err)
 		}
-
+	}
+	for _, conn := range conns {
+		p.track(conn)
 		p.conns <- conn
 		p.stats.TotalConnections.Add(1)
 		p.stats.IdleConnections.Add(1)
 	}
 
 	// Start background workers
-	p.wg.Add(2)
+	p.wg.Add(3)
 	go p.cleanupWorker()
 	go p.healthCheckWorker()
+	go p.maintainWorker()
+	if p.idleProbe > 0 {
+		p.wg.Add(1)
+		go p.idleProbeWorker()
+	}
 
 	return nil
 }
@@ -125,15 +182,16 @@ func (p *ConnectionPool) Get(ctx context.Context) (ConnectionInterface, error) {
 		p.stats.IdleConnections.Add(-1)
 		p.stats.ActiveConnections.Add(1)
 
-		// Validate connection is still alive
-		if !conn.IsAlive() {
+		// Validate connection is still alive and within its limits
+		if !conn.IsAlive() || p.expired(conn) {
 			p.stats.TotalConnections.Add(-1)
 			p.stats.ActiveConnections.Add(-1)
-			conn.Close()
+			p.retire(conn)
 			// Try to get another connection
 			return p.Get(ctx)
 		}
 
+		p.checkout(conn)
 		return conn, nil
 
 	default:
@@ -152,6 +210,8 @@ func (p *ConnectionPool) Get(ctx context.Context) (ConnectionInterface, error) {
 			p.stats.TotalConnections.Add(1)
 			p.stats.ActiveConnections.Add(1)
 
+			p.track(conn)
+			p.checkout(conn)
 			return conn, nil
 		}
 
@@ -168,15 +228,16 @@ func (p *ConnectionPool) Get(ctx context.Context) (ConnectionInterface, error) {
 			p.stats.IdleConnections.Add(-1)
 			p.stats.ActiveConnections.Add(1)
 
-			// Validate connection is still alive
-			if !conn.IsAlive() {
+			// Validate connection is still alive and within its limits
+			if !conn.IsAlive() || p.expired(conn) {
 				p.stats.TotalConnections.Add(-1)
 				p.stats.ActiveConnections.Add(-1)
-				conn.Close()
+				p.retire(conn)
 				// Try to get another connection
 				return p.Get(ctx)
 			}
 
+			p.checkout(conn)
 			return conn, nil
 		}
 	}
@@ -193,16 +254,17 @@ func (p *ConnectionPool) Put(conn ConnectionInterface) {
 	p.mu.RUnlock()
 
 	if closed {
-		conn.Close()
+		p.discard(conn)
 		return
 	}
 
 	p.stats.ActiveConnections.Add(-1)
 
-	// Validate connection health before returning to pool
-	if !conn.IsAlive() {
+	// Validate connection health and limits before returning to pool. The
+	// caller has finished its command, so an expired connection is drained.
+	if !conn.IsAlive() || p.expired(conn) || !p.checkFlagged(conn) {
 		p.stats.TotalConnections.Add(-1)
-		conn.Close()
+		p.retire(conn)
 		return
 	}
 
@@ -213,7 +275,7 @@ func (p *ConnectionPool) Put(conn ConnectionInterface) {
 	default:
 		// Pool is full, close the connection
 		p.stats.TotalConnections.Add(-1)
-		conn.Close()
+		p.discard(conn)
 	}
 }
 
@@ -229,6 +291,9 @@ func (p *ConnectionPool) Stats() PoolStats {
 	stats.Misses.Store(p.stats.Misses.Load())
 	stats.Timeouts.Store(p.stats.Timeouts.Load())
 	stats.Errors.Store(p.stats.Errors.Load())
+	stats.Refills.Store(p.stats.Refills.Load())
+	stats.Recycled.Store(p.stats.Recycled.Load())
+	stats.ProbeFailures.Store(p.stats.ProbeFailures.Load())
 	return stats
 }
 
@@ -273,30 +338,43 @@ func (p *ConnectionPool) cleanupWorker() {
 	}
 }
 
-// cleanupIdleConnections removes stale idle connections while maintaining minIdle.
+// cleanupIdleConnections closes idle connections that exceed idleTimeout or
+// their lifetime. Connections closed below minIdle are replaced by the
+// maintainer.
 func (p *ConnectionPool) cleanupIdleConnections() {
 	now := time.Now()
-	currentIdle := int(p.stats.IdleConnections.Load())
+	idleCount := int(p.stats.IdleConnections.Load())
+	removed := false
 
-	for currentIdle > p.minIdle {
+	for i := 0; i < idleCount; i++ {
 		select {
 		case conn := <-p.conns:
-			// Check if connection has been idle too long
-			if now.Sub(conn.LastActivity()) > p.idleTimeout {
+			switch {
+			case p.expired(conn):
 				p.stats.IdleConnections.Add(-1)
 				p.stats.TotalConnections.Add(-1)
-				conn.Close()
-				currentIdle--
-			} else {
+				p.retire(conn)
+
+			case now.Sub(conn.LastActivity()) > p.idleTimeout:
+				// Idle too long
+				p.stats.IdleConnections.Add(-1)
+				p.stats.TotalConnections.Add(-1)
+				p.discard(conn)
+				removed = true
+
+			default:
 				// Connection is still fresh, return it
 				p.conns <- conn
-				return
 			}
 
 		default:
-			return
+			i = idleCount
 		}
 	}
+
+	if removed {
+		p.requestRefill()
+	}
 }
 
 // healthCheckWorker periodically pings idle connections.
@@ -332,7 +410,8 @@ func (p *ConnectionPool) healthCheckIdleConnections() {
 				// Connection is dead, don't return it
 				p.stats.IdleConnections.Add(-1)
 				p.stats.TotalConnections.Add(-1)
-				conn.Close()
+				p.discard(conn)
+				p.requestRefill()
 			} else {
 				// Connection is healthy, return it
 				p.conns <- conn
@@ -344,12 +423,192 @@ func (p *ConnectionPool) healthCheckIdleConnections() {
 	}
 }
 
+// idleProbeWorker pings connections that have been idle for idleProbe, so
+// connections dropped by the network are replaced before they are handed out.
+func (p *ConnectionPool) idleProbeWorker() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.idleProbe / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+
+		case <-ticker.C:
+			p.probeIdleConnections()
+		}
+	}
+}
+
+// probeIdleConnections pings the idle connections with no activity for
+// idleProbe and removes those that fail.
+func (p *ConnectionPool) probeIdleConnections() {
+	idleCount := int(p.stats.IdleConnections.Load())
+	for i := 0; i < idleCount; i++ {
+		select {
+		case conn := <-p.conns:
+			if time.Since(conn.LastActivity()) >= p.idleProbe && probeConnection(conn) != nil {
+				p.stats.IdleConnections.Add(-1)
+				p.stats.TotalConnections.Add(-1)
+				p.stats.ProbeFailures.Add(1)
+				p.discard(conn)
+				p.requestRefill()
+				continue
+			}
+			p.conns <- conn
+
+		default:
+			return
+		}
+	}
+}
+
+// maintainWorker keeps minIdle connections open, replacing those closed as
+// idle-expired or unhealthy. It refills when a connection is dropped and on
+// every health check interval, which also retries failed refills.
+func (p *ConnectionPool) maintainWorker() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+
+		case <-ticker.C:
+		case <-p.refillCh:
+		}
+		p.refill()
+	}
+}
+
+// requestRefill wakes the maintainer without blocking.
+func (p *ConnectionPool) requestRefill() {
+	select {
+	case p.refillCh <- struct{}{}:
+	default:
+	}
+}
+
+// refill opens connections until minIdle are idle, without exceeding maxOpen.
+// Each one waits a random delay of up to refillJitter first, so that clients
+// which lost their connections together do not reconnect in lockstep.
+func (p *ConnectionPool) refill() {
+	for int(p.stats.IdleConnections.Load()) < p.minIdle && int(p.stats.TotalConnections.Load()) < p.maxOpen {
+		if p.refillJitter > 0 {
+			select {
+			case <-p.stopCh:
+				return
+			case <-time.After(time.Duration(rand.Int63n(int64(p.refillJitter)))):
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		conn, err := p.factory(ctx)
+		cancel()
+		if err != nil {
+			// Retried on the next tick
+			p.stats.Errors.Add(1)
+			return
+		}
+
+		p.stats.TotalConnections.Add(1)
+		p.track(conn)
+		select {
+		case p.conns <- conn:
+			p.stats.IdleConnections.Add(1)
+			p.stats.Refills.Add(1)
+		default:
+			p.stats.TotalConnections.Add(-1)
+			p.discard(conn)
+			return
+		}
+	}
+}
+
+// checkFlagged pings conn if a cancelled command flagged it for a health
+// check, and reports whether it is usable.
+func (p *ConnectionPool) checkFlagged(conn ConnectionInterface) bool {
+	c, ok := conn.(*Connection)
+	if !ok || !c.takeHealthCheckFlag() {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := conn.Ping(ctx); err != nil {
+		c.markDead()
+		return false
+	}
+	return true
+}
+
+// track starts tracking a connection the pool opened. With a lifetime limit,
+// the connection expires up to 10% early so that connections opened together
+// are not recycled together.
+func (p *ConnectionPool) track(conn ConnectionInterface) {
+	meta := &connMeta{}
+	if p.maxLifetime > 0 {
+		jitter := time.Duration(rand.Int63n(int64(p.maxLifetime)/10 + 1))
+		meta.expires = time.Now().Add(p.maxLifetime - jitter)
+	}
+
+	p.metaMu.Lock()
+	p.meta[conn] = meta
+	p.metaMu.Unlock()
+}
+
+// checkout counts a use of conn.
+func (p *ConnectionPool) checkout(conn ConnectionInterface) {
+	p.metaMu.Lock()
+	if meta := p.meta[conn]; meta != nil {
+		meta.uses++
+	}
+	p.metaMu.Unlock()
+}
+
+// expired reports whether conn has outlived its lifetime or been checked out
+// maxUses times.
+func (p *ConnectionPool) expired(conn ConnectionInterface) bool {
+	p.metaMu.Lock()
+	defer p.metaMu.Unlock()
+
+	meta := p.meta[conn]
+	if meta == nil {
+		return false
+	}
+	return (!meta.expires.IsZero() && time.Now().After(meta.expires)) ||
+		(p.maxUses > 0 && meta.uses >= p.maxUses)
+}
+
+// retire closes a connection the pool stopped using because it died or
+// expired, counting it as recycled if it was still alive, and asks the
+// maintainer to replace it.
+func (p *ConnectionPool) retire(conn ConnectionInterface) {
+	if conn.IsAlive() {
+		p.stats.Recycled.Add(1)
+	}
+	p.discard(conn)
+	p.requestRefill()
+}
+
+// discard closes conn and stops tracking it.
+func (p *ConnectionPool) discard(conn ConnectionInterface) {
+	p.metaMu.Lock()
+	delete(p.meta, conn)
+	p.metaMu.Unlock()
+	conn.Close()
+}
+
 // closeAllConnections closes all connections in the pool.
 func (p *ConnectionPool) closeAllConnections() {
 	for {
 		select {
 		case conn := <-p.conns:
-			conn.Close()
+			p.discard(conn)
 		default:
 			return
 		}
@@ -404,3 +404,279 @@ func TestPoolFactoryError(t *testing.T) {
 		t.Error("Expected error to be recorded in stats")
 	}
 }
+
+// TestPoolWarmUp verifies Initialize opens minIdle connections concurrently.
+func TestPoolWarmUp(t *testing.T) {
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		time.Sleep(100 * time.Millisecond)
+		return newMockConnection(int(connID.Add(1))), nil
+	}
+
+	pool := NewConnectionPool(factory, 4, 8, 30*time.Second, 10*time.Second)
+	ctx := context.Background()
+	start := time.Now()
+	if err := pool.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(ctx)
+
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("Expected concurrent warm-up, took %v", elapsed)
+	}
+	stats := pool.Stats()
+	if stats.IdleConnections.Load() != 4 || stats.TotalConnections.Load() != 4 {
+		t.Errorf("Expected 4 idle connections, got %d idle of %d", stats.IdleConnections.Load(), stats.TotalConnections.Load())
+	}
+}
+
+// TestPoolWarmUpFailure verifies a failed warm-up closes the connections it opened.
+func TestPoolWarmUpFailure(t *testing.T) {
+	var opened []*mockConnection
+	var mu sync.Mutex
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		id := int(connID.Add(1))
+		if id == 2 {
+			return nil, errors.New("auth failed")
+		}
+		conn := newMockConnection(id)
+		mu.Lock()
+		opened = append(opened, conn)
+		mu.Unlock()
+		return conn, nil
+	}
+
+	pool := NewConnectionPool(factory, 3, 5, 30*time.Second, 10*time.Second)
+	if err := pool.Initialize(context.Background()); err == nil {
+		t.Fatal("Expected warm-up error")
+	}
+	for _, conn := range opened {
+		if conn.IsAlive() {
+			t.Errorf("Expected connection %d to be closed", conn.id)
+		}
+	}
+	stats := pool.Stats()
+	if total := stats.TotalConnections.Load(); total != 0 {
+		t.Errorf("Expected no connections, got %d", total)
+	}
+}
+
+// TestPoolMaintainerReplacesDeadConnections verifies the maintainer restores
+// minIdle after connections die.
+func TestPoolMaintainerReplacesDeadConnections(t *testing.T) {
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		return newMockConnection(int(connID.Add(1))), nil
+	}
+
+	pool := NewConnectionPool(factory, 2, 5, 30*time.Second, 10*time.Second)
+	pool.configure(poolSettings{refillJitter: 20 * time.Millisecond})
+	ctx := context.Background()
+	if err := pool.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(ctx)
+
+	// Both idle connections die while checked out
+	conn1, _ := pool.Get(ctx)
+	conn2, _ := pool.Get(ctx)
+	conn1.Close()
+	conn2.Close()
+	pool.Put(conn1)
+	pool.Put(conn2)
+
+	var stats PoolStats
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if stats = pool.Stats(); stats.IdleConnections.Load() == 2 {
+			break
+		}
+	}
+	if stats.IdleConnections.Load() != 2 || stats.TotalConnections.Load() != 2 {
+		t.Errorf("Expected 2 idle connections, got %d idle of %d", stats.IdleConnections.Load(), stats.TotalConnections.Load())
+	}
+	if stats.Refills.Load() != 2 {
+		t.Errorf("Expected 2 refills, got %d", stats.Refills.Load())
+	}
+}
+
+// TestPoolMaintainerReplacesExpiredConnections verifies idle-expired
+// connections below minIdle are replaced rather than kept.
+func TestPoolMaintainerReplacesExpiredConnections(t *testing.T) {
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		return newMockConnection(int(connID.Add(1))), nil
+	}
+
+	pool := NewConnectionPool(factory, 1, 3, 100*time.Millisecond, 10*time.Second)
+	ctx := context.Background()
+	if err := pool.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(ctx)
+
+	var stats PoolStats
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if stats = pool.Stats(); stats.Refills.Load() > 0 {
+			break
+		}
+	}
+	if stats.Refills.Load() == 0 {
+		t.Fatal("Expected the expired connection to be replaced")
+	}
+	conn, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if conn.(*mockConnection).id == 1 {
+		t.Error("Expected a fresh connection")
+	}
+	pool.Put(conn)
+}
+
+// TestPoolMaxConnUses verifies connections are recycled after maxUses checkouts.
+func TestPoolMaxConnUses(t *testing.T) {
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		return newMockConnection(int(connID.Add(1))), nil
+	}
+
+	pool := NewConnectionPool(factory, 0, 1, 30*time.Second, 10*time.Second)
+	pool.configure(poolSettings{maxUses: 2})
+	ctx := context.Background()
+	if err := pool.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(ctx)
+
+	var ids []int
+	for i := 0; i < 3; i++ {
+		conn, err := pool.Get(ctx)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		ids = append(ids, conn.(*mockConnection).id)
+		pool.Put(conn)
+	}
+
+	if ids[0] != ids[1] || ids[1] == ids[2] {
+		t.Errorf("Expected a new connection on the third use, got ids %v", ids)
+	}
+	stats := pool.Stats()
+	if stats.Recycled.Load() != 1 {
+		t.Errorf("Expected 1 recycled connection, got %d", stats.Recycled.Load())
+	}
+}
+
+// TestPoolMaxConnLifetimeDrains verifies an expired connection in use stays
+// open until it is returned.
+func TestPoolMaxConnLifetimeDrains(t *testing.T) {
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		return newMockConnection(int(connID.Add(1))), nil
+	}
+
+	pool := NewConnectionPool(factory, 0, 2, 30*time.Second, 10*time.Second)
+	pool.configure(poolSettings{maxLifetime: 50 * time.Millisecond})
+	ctx := context.Background()
+	if err := pool.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(ctx)
+
+	conn, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if !conn.IsAlive() {
+		t.Fatal("Expected the in-use connection to stay open past its lifetime")
+	}
+	pool.Put(conn)
+	if conn.IsAlive() {
+		t.Error("Expected the expired connection to be closed when returned")
+	}
+
+	stats := pool.Stats()
+	if stats.Recycled.Load() != 1 || stats.TotalConnections.Load() != 0 {
+		t.Errorf("Expected 1 recycled and no open connections, got %d recycled, %d open",
+			stats.Recycled.Load(), stats.TotalConnections.Load())
+	}
+}
+
+// TestPoolMaxConnLifetimeIdle verifies expired idle connections are replaced.
+func TestPoolMaxConnLifetimeIdle(t *testing.T) {
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		return newMockConnection(int(connID.Add(1))), nil
+	}
+
+	pool := NewConnectionPool(factory, 1, 2, 30*time.Second, 10*time.Second)
+	pool.configure(poolSettings{maxLifetime: 50 * time.Millisecond})
+	ctx := context.Background()
+	if err := pool.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(ctx)
+
+	time.Sleep(100 * time.Millisecond)
+	conn, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if conn.(*mockConnection).id == 1 {
+		t.Error("Expected the expired idle connection to be replaced")
+	}
+	pool.Put(conn)
+
+	stats := pool.Stats()
+	if stats.Recycled.Load() == 0 {
+		t.Error("Expected the expired connection to count as recycled")
+	}
+}
+
+// TestPoolIdleProbe verifies only connections idle past the probe interval are
+// pinged, and those failing the probe are replaced.
+func TestPoolIdleProbe(t *testing.T) {
+	conns := []*mockConnection{newMockConnection(1), newMockConnection(2)}
+	connID := atomic.Int32{}
+	factory := func(ctx context.Context) (ConnectionInterface, error) {
+		id := int(connID.Add(1))
+		if id <= len(conns) {
+			return conns[id-1], nil
+		}
+		return newMockConnection(id), nil
+	}
+
+	pool := NewConnectionPool(factory, 2, 2, 30*time.Second, 10*time.Second)
+	pool.configure(poolSettings{idleProbe: 50 * time.Millisecond})
+	ctx := context.Background()
+	if err := pool.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(ctx)
+
+	// Connection 1 stops answering; connection 2 stays healthy
+	conns[0].mu.Lock()
+	conns[0].pingErr = fmt.Errorf("connection reset by peer")
+	conns[0].mu.Unlock()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.stats.ProbeFailures.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if conns[0].IsAlive() {
+		t.Fatal("Expected the connection failing the idle probe to be discarded")
+	}
+	if !conns[1].IsAlive() {
+		t.Error("Expected the healthy connection to be kept")
+	}
+
+	for time.Now().Before(deadline) && int(pool.stats.TotalConnections.Load()) < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if total := pool.stats.TotalConnections.Load(); total != 2 {
+		t.Errorf("Expected the discarded connection to be replaced, got %d connections", total)
+	}
+}
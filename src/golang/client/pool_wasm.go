@@ -26,6 +26,9 @@ type PoolStats struct {
 	Misses            atomic.Int64
 	Timeouts          atomic.Int64
 	Errors            atomic.Int64
+	Refills           atomic.Int64 // connections opened by the maintainer to keep minIdle
+	Recycled          atomic.Int64 // connections closed on reaching their lifetime or use limit
+	ProbeFailures     atomic.Int64 // idle connections discarded after failing an idle probe
 }
 
 // NewConnectionPool returns an error in WASM builds as pooling is not supported.
@@ -38,6 +41,20 @@ func NewConnectionPool(
 	return nil
 }
 
+// poolSettings holds the pool options that NewConnectionPool does not take.
+type poolSettings struct {
+	refillJitter time.Duration
+	maxLifetime  time.Duration
+	maxUses      int
+	idleProbe    time.Duration
+}
+
+// configure is a no-op in WASM builds.
+func (p *ConnectionPool) configure(settings poolSettings) {}
+
+// startIdleProbe is a no-op in WASM builds.
+func (c *Client) startIdleProbe(done <-chan struct{}) {}
+
 // Get always returns an error in WASM builds.
 func (p *ConnectionPool) Get(ctx context.Context) (ConnectionInterface, error) {
 	return nil, errors.New("connection pooling is not supported in WASM builds")
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
 )
 
 // Statement represents a prepared statement with parameter placeholders.
@@ -15,12 +18,41 @@ type Statement struct {
 	name       string
 	query      string
 	paramCount int
+	paramNames []string           // Names of :name placeholders by position; nil for $n queries
+	paramTypes []schema.FieldType // Types reported by PREPARE, by position; nil if unknown
 	conn       ConnectionInterface
 	closed     bool
 	createdAt  time.Time
+	cache      *StatementCache // Owning cache, set when cached
+	release    func()          // Returns conn to the pool on Close (pooled mode only)
+	redaction  *RedactionPolicy
+	codec      valueCodec     // Formats time.Time and decimal parameters
+	codecs     *codecRegistry // Type codecs applied to parameters
+	timeout    time.Duration  // Bounds each execution; zero for no limit
 	mu         sync.Mutex
 }
 
+// statementCancelGrace is how long a cancelled statement has to end before
+// its connection is given up on.
+var statementCancelGrace = 2 * time.Second
+
+type statementTimeoutKey struct{}
+
+// WithStatementTimeout returns a context whose prepared statement executions,
+// including QueryWithParams, are bounded by d instead of the statement's own
+// timeout.
+func WithStatementTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, statementTimeoutKey{}, d)
+}
+
+// SetTimeout bounds each execution of the statement to d, overriding
+// ClientOptions.StatementTimeout. Zero disables the limit.
+func (s *Statement) SetTimeout(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timeout = d
+}
+
 // QueryParams is a type-safe wrapper for query parameters.
 type QueryParams []interface{}
 
@@ -31,7 +63,24 @@ func NewQueryParams(values ...interface{}) QueryParams {
 
 // Execute runs the prepared statement with the provided parameters.
 // Parameters are passed using the delimiter-based protocol: EXECUTE name\x05param1\x05param2
+// For a query with :name placeholders, pass a single NamedArgs, or the values
+// in order of each name's first appearance.
 func (s *Statement) Execute(params ...interface{}) (interface{}, error) {
+	return s.ExecuteContext(context.Background(), params...)
+}
+
+// ExecuteNamed runs a statement prepared with :name placeholders, binding
+// each to the value of the same name in args.
+func (s *Statement) ExecuteNamed(args map[string]interface{}) (interface{}, error) {
+	return s.ExecuteContext(context.Background(), NamedArgs(args))
+}
+
+// ExecuteContext runs the prepared statement under ctx. If the statement
+// timeout elapses first, the server is sent CANCEL <name>, which ends the
+// running statement so that its EXECUTE is answered, and the call fails with
+// E_STATEMENT_TIMEOUT. The connection is health-checked before it is reused,
+// and is discarded if the server has not answered within statementCancelGrace.
+func (s *Statement) ExecuteContext(ctx context.Context, params ...interface{}) (interface{}, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -39,17 +88,55 @@ func (s *Statement) Execute(params ...interface{}) (interface{}, error) {
 		return nil, fmt.Errorf("statement %s is already closed", s.name)
 	}
 
+	if named, ok := namedArgsFrom(params); ok {
+		if s.paramNames == nil {
+			return nil, &QueryError{
+				Code:    "E_INVALID_QUERY",
+				Type:    "QueryError",
+				Message: fmt.Sprintf("statement %s has no named parameters", s.name),
+				Query:   s.query,
+			}
+		}
+		bound, err := bindNamedArgs(s.paramNames, named)
+		if err != nil {
+			return nil, err
+		}
+		params = bound
+	}
+
 	if len(params) != s.paramCount {
 		return nil, ErrInvalidParameterCount(s.paramCount, len(params))
 	}
 
+	params, err := s.codecs.marshalParams(params)
+	if err != nil {
+		return nil, err
+	}
+	params, err = checkParamTypes(s.paramTypes, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		s.cache.stats.TotalExecutions.Add(1)
+	}
+
 	// Build EXECUTE command with delimiter-separated parameters
-	command := buildExecuteCommand(s.name, params)
+	command := buildExecuteCommand(s.name, s.codec.encodeParams(params))
+
+	timeout := s.timeout
+	if d, ok := ctx.Value(statementTimeoutKey{}).(time.Duration); ok {
+		timeout = d
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout+statementCancelGrace)
+		defer cancel()
+	}
 
 	// Send command and receive response
-	ctx := context.Background() // TODO: Accept context parameter in next iteration
 	if err := s.conn.SendCommand(ctx, command); err != nil {
-		return nil, &QueryError{
+		return nil, s.redaction.redactError(&QueryError{
 			Code:    "E_EXECUTE_FAILED",
 			Type:    "QueryError",
 			Message: fmt.Sprintf("failed to execute statement %s", s.name),
@@ -60,12 +147,32 @@ func (s *Statement) Execute(params ...interface{}) (interface{}, error) {
 			Query:  s.query,
 			Params: params,
 			Cause:  err,
-		}
+		})
+	}
+
+	// Started once EXECUTE is written, so CANCEL cannot overtake it
+	var timer *time.Timer
+	cancelled := make(chan struct{})
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, func() {
+			defer close(cancelled)
+			if c, ok := s.conn.(*Connection); ok {
+				c.flagHealthCheck()
+			}
+			s.conn.SendCommand(context.Background(), "CANCEL "+s.name)
+		})
 	}
 
 	result, err := s.conn.ReceiveResponse(ctx)
+	if timer != nil && !timer.Stop() {
+		// Wait for CANCEL to be written before the connection is used again
+		<-cancelled
+		if err != nil || serverStatusError(result, s.query) != nil {
+			return nil, ErrStatementTimeout(s.name, timeout)
+		}
+	}
 	if err != nil {
-		return nil, &QueryError{
+		return nil, s.redaction.redactError(&QueryError{
 			Code:    "E_EXECUTE_RESPONSE_FAILED",
 			Type:    "QueryError",
 			Message: fmt.Sprintf("failed to receive response for statement %s", s.name),
@@ -75,14 +182,21 @@ func (s *Statement) Execute(params ...interface{}) (interface{}, error) {
 			Query:  s.query,
 			Params: params,
 			Cause:  err,
-		}
+		})
+	}
+
+	if queryErr := serverStatusError(result, s.query); queryErr != nil {
+		queryErr.Params = params
+		return nil, s.redaction.redactError(queryErr)
 	}
 
 	return result, nil
 }
 
 // Close deallocates the prepared statement on the server.
-// Sends DEALLOCATE command per server protocol.
+// Sends DEALLOCATE command per server protocol on the statement's own connection,
+// removes the statement from the cache and, in pooled mode, returns the connection
+// to the pool. The statement is unusable afterwards even if deallocation fails.
 func (s *Statement) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -91,6 +205,16 @@ func (s *Statement) Close() error {
 		return nil // Already closed, no-op
 	}
 
+	s.closed = true
+	defer func() {
+		if s.cache != nil {
+			s.cache.removeStatement(s)
+		}
+		if s.release != nil {
+			s.release()
+		}
+	}()
+
 	command := fmt.Sprintf("DEALLOCATE %s", s.name)
 	ctx := context.Background()
 
@@ -109,10 +233,34 @@ func (s *Statement) Close() error {
 		}
 	}
 
-	s.closed = true
+	// Consume the acknowledgement so it isn't read as the next command's response
+	if _, err := s.conn.ReceiveResponse(ctx); err != nil {
+		return &StatementError{
+			QueryError: QueryError{
+				Code:    "E_DEALLOCATE_FAILED",
+				Type:    "StatementError",
+				Message: fmt.Sprintf("failed to receive deallocate response for %s", s.name),
+				Details: map[string]interface{}{
+					"statement_name": s.name,
+				},
+				Cause: err,
+			},
+			StatementName: s.name,
+		}
+	}
+
 	return nil
 }
 
+// supersede marks s closed without deallocating it, for a statement whose
+// name was prepared again on the same connection. The connection now belongs
+// to the new statement, so it is not released either.
+func (s *Statement) supersede() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
 // Name returns the statement name.
 func (s *Statement) Name() string {
 	return s.name
@@ -128,6 +276,19 @@ func (s *Statement) ParamCount() int {
 	return s.paramCount
 }
 
+// ParamNames returns the statement's named parameters in positional order,
+// or nil if it uses $n placeholders.
+func (s *Statement) ParamNames() []string {
+	return s.paramNames
+}
+
+// ParamTypes returns the parameter types the server reported when the
+// statement was prepared, in positional order, or nil if it reported none.
+// Execute checks arguments against them, failing with E_PARAM_TYPE_MISMATCH.
+func (s *Statement) ParamTypes() []schema.FieldType {
+	return s.paramTypes
+}
+
 // escapeParameterValue escapes special control characters in parameter values.
 // Per server protocol: \x04 (EOT) -> \x04\x04, \x05 (ENQ) -> \x05\x05
 func escapeParameterValue(value string) string {
@@ -166,9 +327,9 @@ func convertToString(value interface{}) string {
 	case int64:
 		return fmt.Sprintf("%d", v)
 	case float64:
-		return fmt.Sprintf("%f", v)
+		return strconv.FormatFloat(v, 'f', -1, 64)
 	case float32:
-		return fmt.Sprintf("%f", v)
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
 	case bool:
 		if v {
 			return "true"
@@ -0,0 +1,253 @@
+package client
+
+import (
+	"container/list"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// QueryCache caches query results with LRU eviction, per-entry TTL and
+// bundle-based invalidation. Entries are tagged with the bundles they read;
+// a mutation or DDL command on a bundle drops every entry tagged with it.
+// Queries opt in via QueryBuilder.Cached.
+type QueryCache struct {
+	entries    map[string]*list.Element       // key -> element holding *queryCacheEntry
+	lru        *list.List                     // front = most recently used
+	bundles    map[string]map[string]struct{} // bundle -> keys
+	maxSize    int
+	generation atomic.Uint64 // incremented on every invalidation
+	stats      queryCacheCounters
+	mu         sync.Mutex
+}
+
+// queryCacheEntry is a single cached result.
+type queryCacheEntry struct {
+	key       string
+	bundles   []string
+	result    interface{}
+	expiresAt time.Time
+}
+
+// queryCacheCounters holds the live counters behind QueryCacheStats.
+type queryCacheCounters struct {
+	hits          atomic.Int64
+	misses        atomic.Int64
+	evictions     atomic.Int64
+	invalidations atomic.Int64
+}
+
+// QueryCacheStats is a snapshot of query cache metrics.
+type QueryCacheStats struct {
+	Hits          int64
+	Misses        int64
+	Evictions     int64
+	Invalidations int64
+	Size          int
+}
+
+// NewQueryCache creates a query cache holding at most maxSize entries.
+func NewQueryCache(maxSize int) *QueryCache {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+	return &QueryCache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		bundles: make(map[string]map[string]struct{}),
+		maxSize: maxSize,
+	}
+}
+
+// Get returns the cached result for key if present and not expired.
+func (qc *QueryCache) Get(key string) (interface{}, bool) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	elem, ok := qc.entries[key]
+	if !ok {
+		qc.stats.misses.Add(1)
+		return nil, false
+	}
+
+	entry := elem.Value.(*queryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		qc.removeElement(elem)
+		qc.stats.misses.Add(1)
+		return nil, false
+	}
+
+	qc.lru.MoveToFront(elem)
+	qc.stats.hits.Add(1)
+	return entry.result, true
+}
+
+// Set stores result under key for ttl, tagged with the bundles it reads.
+func (qc *QueryCache) Set(key string, bundles []string, result interface{}, ttl time.Duration) {
+	qc.setIfCurrent(key, bundles, result, ttl, qc.generation.Load())
+}
+
+// setIfCurrent stores result only if no invalidation happened since generation was read.
+// This prevents a query that raced with a mutation from caching a stale result.
+func (qc *QueryCache) setIfCurrent(key string, bundles []string, result interface{}, ttl time.Duration, generation uint64) {
+	if ttl <= 0 {
+		return
+	}
+
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	if qc.generation.Load() != generation {
+		return
+	}
+
+	if elem, ok := qc.entries[key]; ok {
+		qc.removeElement(elem)
+	}
+
+	for qc.lru.Len() >= qc.maxSize {
+		qc.removeElement(qc.lru.Back())
+		qc.stats.evictions.Add(1)
+	}
+
+	entry := &queryCacheEntry{
+		key:       key,
+		bundles:   bundles,
+		result:    result,
+		expiresAt: time.Now().Add(ttl),
+	}
+	qc.entries[key] = qc.lru.PushFront(entry)
+
+	for _, bundle := range bundles {
+		bundle = strings.ToLower(bundle)
+		keys, ok := qc.bundles[bundle]
+		if !ok {
+			keys = make(map[string]struct{})
+			qc.bundles[bundle] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// InvalidateBundle removes all entries that read bundle.
+// Returns the number of entries removed.
+func (qc *QueryCache) InvalidateBundle(bundle string) int {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	qc.generation.Add(1)
+
+	keys := qc.bundles[strings.ToLower(bundle)]
+	removed := 0
+	for key := range keys {
+		if elem, ok := qc.entries[key]; ok {
+			qc.removeElement(elem)
+			removed++
+		}
+	}
+
+	qc.stats.invalidations.Add(int64(removed))
+	return removed
+}
+
+// Clear removes all entries.
+func (qc *QueryCache) Clear() {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	qc.generation.Add(1)
+	qc.stats.invalidations.Add(int64(qc.lru.Len()))
+
+	qc.entries = make(map[string]*list.Element)
+	qc.bundles = make(map[string]map[string]struct{})
+	qc.lru.Init()
+}
+
+// Len returns the number of cached entries, including expired ones not yet removed.
+func (qc *QueryCache) Len() int {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	return qc.lru.Len()
+}
+
+// Stats returns a snapshot of cache metrics.
+func (qc *QueryCache) Stats() QueryCacheStats {
+	return QueryCacheStats{
+		Hits:          qc.stats.hits.Load(),
+		Misses:        qc.stats.misses.Load(),
+		Evictions:     qc.stats.evictions.Load(),
+		Invalidations: qc.stats.invalidations.Load(),
+		Size:          qc.Len(),
+	}
+}
+
+// removeElement unlinks an entry from the LRU list, key map and bundle index.
+// Must be called with qc.mu locked.
+func (qc *QueryCache) removeElement(elem *list.Element) {
+	entry := qc.lru.Remove(elem).(*queryCacheEntry)
+	delete(qc.entries, entry.key)
+
+	for _, bundle := range entry.bundles {
+		bundle = strings.ToLower(bundle)
+		if keys, ok := qc.bundles[bundle]; ok {
+			delete(keys, entry.key)
+			if len(keys) == 0 {
+				delete(qc.bundles, bundle)
+			}
+		}
+	}
+}
+
+// bundlePattern matches the bundle a SyndrQL command targets, e.g.
+// SELECT ... FROM "users", ADD DOCUMENT TO BUNDLE "users", DELETE DOCUMENTS FROM BUNDLE "users".
+var bundlePattern = regexp.MustCompile(`(?i)\b(?:FROM|BUNDLE|INTO)\s+(?:BUNDLE\s+)?"?([A-Za-z_][A-Za-z0-9_.\-]*)"?`)
+
+// CommandBundle returns the bundle targeted by a SyndrQL command, or "" if none is found.
+func CommandBundle(command string) string {
+	match := bundlePattern.FindStringSubmatch(command)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// IsWriteCommand reports whether command modifies documents or schema
+// and should therefore invalidate cached query results.
+func IsWriteCommand(command string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(command))
+	for _, prefix := range []string{"ADD ", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "ALTER"} {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidateQueryCache drops cached results affected by a successful write command.
+// If the target bundle cannot be determined, the whole cache is cleared.
+func (c *Client) invalidateQueryCache(command string) {
+	if c.queryCache == nil || !IsWriteCommand(command) {
+		return
+	}
+
+	bundle := CommandBundle(command)
+	if bundle == "" {
+		c.queryCache.Clear()
+		c.logger.Debug("query cache cleared after write to unknown bundle",
+			String("command", command))
+		return
+	}
+
+	if removed := c.queryCache.InvalidateBundle(bundle); removed > 0 {
+		c.logger.Debug("query cache invalidated",
+			String("bundle", bundle),
+			Int("entries", removed))
+	}
+}
+
+// QueryCache returns the client's query result cache.
+func (c *Client) QueryCache() *QueryCache {
+	return c.queryCache
+}
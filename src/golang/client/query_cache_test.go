@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestQueryCacheGetSet verifies basic storage, expiry and stats.
+func TestQueryCacheGetSet(t *testing.T) {
+	cache := NewQueryCache(10)
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected miss for unknown key")
+	}
+
+	cache.Set("k1", []string{"users"}, "result", time.Minute)
+	if result, ok := cache.Get("k1"); !ok || result != "result" {
+		t.Errorf("expected cached result, got %v (ok=%v)", result, ok)
+	}
+
+	cache.Set("k2", []string{"users"}, "short", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("k2"); ok {
+		t.Error("expected expired entry to miss")
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Size != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+// TestQueryCacheLRUEviction verifies the least recently used entry is evicted.
+func TestQueryCacheLRUEviction(t *testing.T) {
+	cache := NewQueryCache(2)
+
+	cache.Set("a", []string{"users"}, 1, time.Minute)
+	cache.Set("b", []string{"users"}, 2, time.Minute)
+	cache.Get("a") // a is now most recently used
+	cache.Set("c", []string{"users"}, 3, time.Minute)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("expected a to remain cached")
+	}
+	if cache.Stats().Evictions != 1 {
+		t.Errorf("expected 1 eviction, got %d", cache.Stats().Evictions)
+	}
+}
+
+// TestQueryCacheInvalidateBundle verifies only entries reading the bundle are dropped.
+func TestQueryCacheInvalidateBundle(t *testing.T) {
+	cache := NewQueryCache(10)
+
+	cache.Set("users", []string{"users"}, 1, time.Minute)
+	cache.Set("join", []string{"orders", "users"}, 2, time.Minute)
+	cache.Set("orders", []string{"orders"}, 3, time.Minute)
+
+	if removed := cache.InvalidateBundle("Users"); removed != 2 {
+		t.Errorf("expected 2 entries removed, got %d", removed)
+	}
+	if _, ok := cache.Get("orders"); !ok {
+		t.Error("expected unrelated entry to remain cached")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", cache.Len())
+	}
+}
+
+// TestQueryCacheStaleWrite verifies results fetched before an invalidation are not cached.
+func TestQueryCacheStaleWrite(t *testing.T) {
+	cache := NewQueryCache(10)
+
+	generation := cache.generation.Load()
+	cache.InvalidateBundle("users")
+	cache.setIfCurrent("k", []string{"users"}, "stale", time.Minute, generation)
+
+	if _, ok := cache.Get("k"); ok {
+		t.Error("expected stale result to be discarded")
+	}
+}
+
+// TestQueryBuilderCached verifies cached results are served and invalidated by writes.
+func TestQueryBuilderCached(t *testing.T) {
+	opts := DefaultOptions()
+	client := NewClient(&opts)
+	ctx := context.Background()
+
+	qb := client.QueryBuilder().Select("users").Where("age", GreaterThan, 21).Cached(time.Minute)
+	query, params, err := qb.buildQuery()
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	rendered := inlineParameters(query, params)
+	client.QueryCache().Set(qb.cacheKey(rendered), qb.cacheBundles(rendered), "cached", time.Minute)
+
+	result, err := qb.Execute(ctx)
+	if err != nil || result != "cached" {
+		t.Fatalf("expected cached result, got %v (err=%v)", result, err)
+	}
+
+	other := client.QueryBuilder().Select("users").Where("age", GreaterThan, 30)
+	otherQuery, otherParams, _ := other.buildQuery()
+	if other.cacheKey(inlineParameters(otherQuery, otherParams)) == qb.cacheKey(rendered) {
+		t.Error("expected different parameters to produce different cache keys")
+	}
+
+	client.invalidateQueryCache(`UPDATE DOCUMENTS IN BUNDLE "users" ("age" = 22) WHERE "id" == 1;`)
+
+	// Not connected, so a cache miss must reach the server and fail
+	if _, err := qb.Execute(ctx); err == nil {
+		t.Error("expected cache miss after invalidation")
+	}
+}
+
+// TestCommandBundle verifies bundle extraction from SyndrQL commands.
+func TestCommandBundle(t *testing.T) {
+	tests := map[string]string{
+		`SELECT * FROM "users";`:                                "users",
+		`SELECT * FROM orders WHERE "id" == 1;`:                 "orders",
+		`ADD DOCUMENT TO BUNDLE  "items" WITH ({"a" = 1});`:     "items",
+		`DELETE DOCUMENTS FROM BUNDLE "logs" WHERE "id" == 1;`:  "logs",
+		`CREATE BUNDLE "accounts" WITH FIELDS ({"id", "int"});`: "accounts",
+		"COMMIT;": "",
+	}
+	for command, want := range tests {
+		if got := CommandBundle(command); got != want {
+			t.Errorf("CommandBundle(%q) = %q, want %q", command, got, want)
+		}
+	}
+}
+
+// TestIsWriteCommand verifies write detection.
+func TestIsWriteCommand(t *testing.T) {
+	writes := []string{
+		`ADD DOCUMENT TO BUNDLE "users" WITH ({"a" = 1});`,
+		`update documents in bundle "users" ("a" = 1) WHERE "id" == 1;`,
+		`DELETE DOCUMENTS FROM BUNDLE "users" WHERE "id" == 1;`,
+		`DROP BUNDLE "users";`,
+	}
+	for _, command := range writes {
+		if !IsWriteCommand(command) {
+			t.Errorf("expected %q to be a write command", command)
+		}
+	}
+	if IsWriteCommand(`SELECT * FROM "users";`) {
+		t.Error("expected SELECT not to be a write command")
+	}
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// QueryPriority labels how important a query is to the application.
+// The driver does not reorder commands by priority; it is carried in
+// HookContext.Metadata and debug logs so hooks and load shedding can act on it.
+type QueryPriority int
+
+const (
+	// PriorityLow marks background work such as reports and backfills.
+	PriorityLow QueryPriority = -1
+	// PriorityNormal is the default priority.
+	PriorityNormal QueryPriority = 0
+	// PriorityHigh marks latency-sensitive, user-facing queries.
+	PriorityHigh QueryPriority = 1
+)
+
+// String returns the lowercase name of the priority.
+func (p QueryPriority) String() string {
+	switch {
+	case p < PriorityNormal:
+		return "low"
+	case p > PriorityNormal:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// Metadata keys set on HookContext.Metadata for tagged commands.
+const (
+	// MetadataTags holds the command's tags as a map[string]string.
+	MetadataTags = "tags"
+	// MetadataPriority holds the command's QueryPriority.
+	MetadataPriority = "priority"
+)
+
+type queryMetadataKey struct{}
+
+// queryMetadata is the tag and priority information carried by a context.
+type queryMetadata struct {
+	tags     map[string]string
+	priority QueryPriority
+}
+
+func queryMetadataFrom(ctx context.Context) queryMetadata {
+	if md, ok := ctx.Value(queryMetadataKey{}).(queryMetadata); ok {
+		return md
+	}
+	return queryMetadata{}
+}
+
+// WithQueryTags returns a context whose commands carry tags, e.g.
+// {"feature": "checkout"}, so slow-query analysis can attribute them to
+// application features. Tags merge with any already on ctx; later values win.
+func WithQueryTags(ctx context.Context, tags map[string]string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	md := queryMetadataFrom(ctx)
+	merged := make(map[string]string, len(md.tags)+len(tags))
+	for k, v := range md.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	md.tags = merged
+	return context.WithValue(ctx, queryMetadataKey{}, md)
+}
+
+// WithQueryPriority returns a context whose commands carry priority p.
+func WithQueryPriority(ctx context.Context, p QueryPriority) context.Context {
+	md := queryMetadataFrom(ctx)
+	md.priority = p
+	return context.WithValue(ctx, queryMetadataKey{}, md)
+}
+
+// QueryTagsFromContext returns a copy of the tags set on ctx, or nil.
+func QueryTagsFromContext(ctx context.Context) map[string]string {
+	md := queryMetadataFrom(ctx)
+	if len(md.tags) == 0 {
+		return nil
+	}
+	tags := make(map[string]string, len(md.tags))
+	for k, v := range md.tags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// QueryPriorityFromContext returns the priority set on ctx, or PriorityNormal.
+func QueryPriorityFromContext(ctx context.Context) QueryPriority {
+	return queryMetadataFrom(ctx).priority
+}
+
+// applyQueryMetadata copies ctx's tags and priority into hookCtx.Metadata.
+func applyQueryMetadata(ctx context.Context, hookCtx *HookContext) {
+	md := queryMetadataFrom(ctx)
+	if len(md.tags) > 0 {
+		hookCtx.Metadata[MetadataTags] = QueryTagsFromContext(ctx)
+	}
+	hookCtx.Metadata[MetadataPriority] = md.priority
+}
+
+// queryMetadataFields returns log fields describing ctx's tags and priority.
+func queryMetadataFields(ctx context.Context) []Field {
+	md := queryMetadataFrom(ctx)
+	var fields []Field
+	if len(md.tags) > 0 {
+		fields = append(fields, String("tags", formatQueryTags(md.tags)))
+	}
+	if md.priority != PriorityNormal {
+		fields = append(fields, String("priority", md.priority.String()))
+	}
+	return fields
+}
+
+// formatQueryTags renders tags as sorted "key=value" pairs.
+func formatQueryTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// queryOptions holds the per-query settings shared by the builders.
+type queryOptions struct {
+	timeout  time.Duration
+	tags     map[string]string
+	priority *QueryPriority
+	database string
+}
+
+func (o *queryOptions) setTag(key, value string) {
+	if o.tags == nil {
+		o.tags = make(map[string]string)
+	}
+	o.tags[key] = value
+}
+
+// apply returns ctx with the builder's tags, priority, database and timeout applied.
+// Without WithTimeout the client's DefaultQueryTimeout is used; the returned
+// cancel func must always be called.
+func (o *queryOptions) apply(ctx context.Context, c *Client) (context.Context, context.CancelFunc) {
+	ctx = WithQueryTags(ctx, o.tags)
+	if o.priority != nil {
+		ctx = WithQueryPriority(ctx, *o.priority)
+	}
+	if o.database != "" {
+		ctx = WithDatabase(ctx, o.database)
+	}
+
+	timeout := o.timeout
+	if timeout <= 0 {
+		timeout = c.opts.DefaultQueryTimeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// metadataHook records the metadata and context deadline of each command.
+type metadataHook struct {
+	mu        sync.Mutex
+	metadata  []map[string]interface{}
+	deadlines []time.Duration
+}
+
+func (h *metadataHook) Name() string { return "metadata" }
+
+func (h *metadataHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.metadata = append(h.metadata, hookCtx.Metadata)
+	var remaining time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining = time.Until(deadline)
+	}
+	h.deadlines = append(h.deadlines, remaining)
+	return nil
+}
+
+func (h *metadataHook) After(ctx context.Context, hookCtx *HookContext) error { return nil }
+
+// TestBuilderTagsAndPriority verifies builder tags and priority reach hooks and debug logs.
+func TestBuilderTagsAndPriority(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	var logs bytes.Buffer
+	c.logger = NewLogger("DEBUG", &logs)
+	hook := &metadataHook{}
+	c.RegisterHook(hook)
+
+	ctx := WithQueryTags(context.Background(), map[string]string{"service": "web", "feature": "search"})
+	_, err := c.QueryBuilder().Select("users").
+		WithTag("feature", "checkout").
+		WithPriority(PriorityHigh).
+		Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	md := hook.metadata[0]
+	tags, _ := md[MetadataTags].(map[string]string)
+	if tags["feature"] != "checkout" || tags["service"] != "web" {
+		t.Errorf("unexpected tags: %v", md[MetadataTags])
+	}
+	if md[MetadataPriority] != PriorityHigh {
+		t.Errorf("expected high priority, got %v", md[MetadataPriority])
+	}
+	if !strings.Contains(logs.String(), "feature=checkout,service=web") {
+		t.Errorf("expected tags in debug log, got:\n%s", logs.String())
+	}
+
+	// Untagged commands report normal priority and no tags
+	if _, err := c.QueryBuilder().Select("users").Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if _, ok := hook.metadata[1][MetadataTags]; ok || hook.metadata[1][MetadataPriority] != PriorityNormal {
+		t.Errorf("unexpected metadata: %v", hook.metadata[1])
+	}
+}
+
+// TestBuilderTimeouts verifies WithTimeout overrides DefaultQueryTimeout.
+func TestBuilderTimeouts(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	c.opts.DefaultQueryTimeout = time.Minute
+	hook := &metadataHook{}
+	c.RegisterHook(hook)
+
+	ctx := context.Background()
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "a"}).Execute(ctx); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if _, err := c.DeleteBuilder("users").Where("id", Equals, 1).WithTimeout(time.Hour).Execute(ctx); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	c.opts.DefaultQueryTimeout = 0
+	if _, err := c.UpdateBuilder("users").Set("name", "b").Where("id", Equals, 1).Execute(ctx); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if d := hook.deadlines[0]; d <= 30*time.Second || d > time.Minute {
+		t.Errorf("expected DefaultQueryTimeout deadline, got %v", d)
+	}
+	if d := hook.deadlines[1]; d <= 59*time.Minute {
+		t.Errorf("expected WithTimeout deadline, got %v", d)
+	}
+	if d := hook.deadlines[2]; d != 0 {
+		t.Errorf("expected no deadline, got %v", d)
+	}
+}
+
+// TestBuilderTimeoutExpires verifies a slow query fails with the builder timeout.
+func TestBuilderTimeoutExpires(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		time.Sleep(200 * time.Millisecond)
+		return `{"success":true}`
+	})
+
+	start := time.Now()
+	_, err := c.QueryBuilder().Select("users").WithTimeout(20 * time.Millisecond).Execute(context.Background())
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("query was not cut short by its timeout (took %v)", elapsed)
+	}
+}
+
+// TestSlowQueryHookTags verifies slow queries record their tags.
+func TestSlowQueryHookTags(t *testing.T) {
+	hook := NewSlowQueryHook(0, nil)
+	ctx := WithQueryTags(context.Background(), map[string]string{"feature": "reports"})
+	hookCtx := newHookContext(ctx, "SELECT * FROM \"orders\";")
+	hook.After(ctx, hookCtx)
+
+	queries := hook.GetSlowQueries()
+	if len(queries) != 1 || queries[0].Tags["feature"] != "reports" {
+		t.Errorf("expected tagged slow query, got %+v", queries)
+	}
+}
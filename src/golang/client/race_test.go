@@ -0,0 +1,7 @@
+//go:build race
+
+package client
+
+// raceEnabled reports whether tests run under the race detector, which makes
+// sync.Pool drop items at random so allocation counts are not meaningful.
+const raceEnabled = true
@@ -0,0 +1,116 @@
+package client
+
+import "fmt"
+
+// rawExpr is a SyndrQL fragment written by the caller, with ? placeholders
+// bound to args.
+type rawExpr struct {
+	sql  string
+	args []interface{}
+}
+
+// WhereRaw adds a condition written in SyndrQL with an implicit AND
+// connector, for expressions the builder does not cover:
+//
+//	qb.WhereRaw("lower(name) == ?", "alice")
+//
+// Each ? is bound to the next argument like any other builder parameter, so
+// values are never spliced into the expression. The expression is wrapped in
+// parentheses and is not checked by schema validation or field encryption.
+func (qb *QueryBuilder) WhereRaw(expr string, args ...interface{}) *QueryBuilder {
+	qb.whereClauses = append(qb.whereClauses, whereClause{
+		raw:       &rawExpr{sql: expr, args: args},
+		connector: And,
+	})
+	return qb
+}
+
+// OrWhereRaw adds a raw condition with an OR connector. See WhereRaw.
+func (qb *QueryBuilder) OrWhereRaw(expr string, args ...interface{}) *QueryBuilder {
+	qb.whereClauses = append(qb.whereClauses, whereClause{
+		raw:       &rawExpr{sql: expr, args: args},
+		connector: Or,
+	})
+	return qb
+}
+
+// SelectRaw adds a raw expression to the selected fields, e.g.
+// SelectRaw("count(*) AS c"). Placeholders are bound as in WhereRaw.
+func (qb *QueryBuilder) SelectRaw(expr string, args ...interface{}) *QueryBuilder {
+	qb.rawSelects = append(qb.rawSelects, rawExpr{sql: expr, args: args})
+	return qb
+}
+
+// OrderByRaw adds a raw ORDER BY expression, including its direction if any,
+// e.g. OrderByRaw("lower(name) DESC"). Placeholders are bound as in WhereRaw.
+func (qb *QueryBuilder) OrderByRaw(expr string, args ...interface{}) *QueryBuilder {
+	qb.orderBys = append(qb.orderBys, orderByClause{
+		raw: &rawExpr{sql: expr, args: args},
+	})
+	return qb
+}
+
+// bind writes the expression to query with each ? replaced by the next
+// $n placeholder, appending its arguments to the query's params. Question
+// marks inside quoted strings are left alone. With a single NamedArgs
+// argument, :name placeholders are bound instead.
+func (r *rawExpr) bind(query *queryWriter) error {
+	if named, ok := namedArgsFrom(r.args); ok {
+		for _, value := range named {
+			if err := validateColumns(value); err != nil {
+				return err
+			}
+		}
+		return r.bindNamed(query, named)
+	}
+	if err := validateColumns(r.args...); err != nil {
+		return err
+	}
+
+	used := 0
+	var quote rune
+	for _, ch := range r.sql {
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '?':
+			if used < len(r.args) {
+				query.placeholder(r.args[used])
+			}
+			used++
+			continue
+		}
+		query.WriteRune(ch)
+	}
+
+	if used != len(r.args) {
+		return &QueryError{
+			Code:    "E_INVALID_QUERY",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("raw expression %q has %d placeholders but %d arguments", r.sql, used, len(r.args)),
+		}
+	}
+	return nil
+}
+
+// bindNamed writes the expression to query with each :name placeholder
+// replaced by a $n placeholder bound to args[name].
+func (r *rawExpr) bindNamed(query *queryWriter, args NamedArgs) error {
+	var names []string
+	scanNamedParams(r.sql, func(string) {}, func(name string) {
+		names = append(names, name)
+	})
+	// Checks every name has a value and every value a name
+	if _, err := bindNamedArgs(uniqueNames(names), args); err != nil {
+		return err
+	}
+
+	scanNamedParams(r.sql, query.WriteString, func(name string) {
+		query.placeholder(args[name])
+	})
+	return nil
+}
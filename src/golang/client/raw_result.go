@@ -0,0 +1,304 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RawResult is a query result whose rows are left as undecoded JSON, so large
+// result sets are not expanded into maps and slices up front. Rows can be
+// decoded one at a time into any type, or forwarded as-is.
+type RawResult struct {
+	// Rows holds each row's JSON. It is nil when the response has no rows.
+	Rows []json.RawMessage
+
+	// Fields holds the other members of the object the rows were found in,
+	// e.g. "ResultCount", or the whole response object when it has no rows.
+	Fields map[string]interface{}
+}
+
+// Len returns the number of rows.
+func (r *RawResult) Len() int {
+	return len(r.Rows)
+}
+
+// Decode unmarshals row i into v.
+func (r *RawResult) Decode(i int, v interface{}) error {
+	if i < 0 || i >= len(r.Rows) {
+		return fmt.Errorf("row %d out of range [0, %d)", i, len(r.Rows))
+	}
+	return json.Unmarshal(r.Rows[i], v)
+}
+
+// lazyRowsKey marks a context whose response rows should be left undecoded.
+type lazyRowsKey struct{}
+
+// lazyReceiver is implemented by connections that can decode rows lazily.
+type lazyReceiver interface {
+	receiveLazy(ctx context.Context) (interface{}, error)
+}
+
+// QueryRaw executes query like Query but returns its rows as raw JSON.
+// For large results this avoids building a map per row; decode only the rows
+// you need with RawResult.Decode:
+//
+//	result, err := c.QueryRaw(ctx, `SELECT * FROM BUNDLE "events";`)
+//	for i := 0; i < result.Len(); i++ {
+//		var event Event
+//		if err := result.Decode(i, &event); err != nil { ... }
+//	}
+//
+// Rows are found in a top-level array or under "data" or "Result", as for
+// MaxRowsInMemory, which still applies.
+func (c *Client) QueryRaw(ctx context.Context, query string) (*RawResult, error) {
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("QueryRaw", CONNECTED, c.stateMgr.GetState())
+	}
+
+	result, err := c.sendCommand(context.WithValue(ctx, lazyRowsKey{}, true), query)
+	if err != nil {
+		return nil, err
+	}
+	return toRawResult(result)
+}
+
+// receiveResponse reads the response to a command on conn, leaving rows
+// undecoded when ctx comes from QueryRaw and conn supports it.
+func receiveResponse(ctx context.Context, conn ConnectionInterface) (interface{}, error) {
+	if lazy, _ := ctx.Value(lazyRowsKey{}).(bool); lazy {
+		if receiver, ok := conn.(lazyReceiver); ok {
+			return receiver.receiveLazy(ctx)
+		}
+	}
+	return conn.ReceiveResponse(ctx)
+}
+
+// toRawResult converts a response to a RawResult. Responses already decoded,
+// e.g. by a connection without lazy decoding, are re-encoded row by row.
+func toRawResult(result interface{}) (*RawResult, error) {
+	switch v := result.(type) {
+	case *RawResult:
+		return v, nil
+	case []interface{}:
+		return encodeRawRows(v, nil)
+	case map[string]interface{}:
+		if rows, ok := v["Result"].([]interface{}); ok {
+			fields := make(map[string]interface{}, len(v))
+			for key, value := range v {
+				if key != "Result" {
+					fields[key] = value
+				}
+			}
+			return encodeRawRows(rows, fields)
+		}
+		return &RawResult{Fields: v}, nil
+	case nil:
+		return &RawResult{}, nil
+	default:
+		return &RawResult{Fields: map[string]interface{}{"result": v}}, nil
+	}
+}
+
+func encodeRawRows(rows []interface{}, fields map[string]interface{}) (*RawResult, error) {
+	raw := make([]json.RawMessage, len(rows))
+	for i, row := range rows {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		raw[i] = data
+	}
+	return &RawResult{Rows: raw, Fields: fields}, nil
+}
+
+// receiveLazy reads a response like ReceiveResponse, but streams the JSON with
+// a json.Decoder and keeps rows as raw JSON. Responses that are not a JSON
+// object or array are parsed as usual.
+func (c *Connection) receiveLazy(ctx context.Context) (interface{}, error) {
+	var rows []json.RawMessage
+	line, err := c.readResponse(ctx, func(payload []byte) error {
+		part, err := c.decodeLazyResponse(payload)
+		if err != nil {
+			return err
+		}
+		raw, err := toRawResult(part)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, raw.Rows...)
+		return c.checkRawRowLimit(len(rows))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.decodeLazyResponse(line)
+	if err != nil {
+		return nil, err
+	}
+	if rows != nil {
+		raw, err := toRawResult(result)
+		if err != nil {
+			return nil, err
+		}
+		result = &RawResult{Rows: append(rows, raw.Rows...), Fields: raw.Fields}
+	}
+	if raw, ok := result.(*RawResult); ok {
+		if err := c.checkRawRowLimit(raw.Len()); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// decodeLazyResponse decodes one frame for receiveLazy.
+func (c *Connection) decodeLazyResponse(line []byte) (interface{}, error) {
+	if len(line) == 0 || (line[0] != '{' && line[0] != '[') {
+		return c.parseResponse(line)
+	}
+
+	result, err := decodeLazy(line)
+	if err != nil {
+		// Malformed JSON: fall back to the regular parser, which returns it as a string
+		return c.parseResponse(line)
+	}
+
+	if respMap, ok := result.(map[string]interface{}); ok {
+		if err := envelopeError(respMap); err != nil {
+			return nil, err
+		}
+	}
+	c.updateActivity()
+	return result, nil
+}
+
+// checkRawRowLimit returns E_RESPONSE_TOO_LARGE if rows exceeds MaxRowsInMemory.
+func (c *Connection) checkRawRowLimit(rows int) error {
+	if c.maxRows > 0 && rows > c.maxRows {
+		tooLarge := responseTooLargeError("MaxRowsInMemory", c.maxRows)
+		tooLarge.Details["rows"] = rows
+		return tooLarge
+	}
+	return nil
+}
+
+// decodeLazy decodes a JSON object or array, keeping rows raw. It returns a
+// *RawResult when rows were found, otherwise the decoded value with the
+// "data" envelope removed, as ReceiveResponse would.
+func decodeLazy(line []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	if tok == json.Delim('[') {
+		rows, err := decodeRawRows(dec)
+		if err != nil {
+			return nil, err
+		}
+		result = &RawResult{Rows: rows}
+	} else {
+		obj, raw, err := decodeLazyObject(dec)
+		if err != nil {
+			return nil, err
+		}
+		failed := envelopeError(obj) != nil || serverStatusError(obj, "") != nil
+		data, hasData := obj["data"]
+		switch {
+		case failed:
+			result = obj
+		case raw != nil:
+			result = raw
+		case hasData:
+			result = data
+		default:
+			result = obj
+		}
+	}
+
+	// Trailing data makes the line invalid JSON, as json.Unmarshal would report
+	if _, err := dec.Token(); err == nil {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return result, nil
+}
+
+// decodeLazyObject decodes the members of an object whose '{' has been read.
+// An array under "data" or "Result" is kept raw and returned as a RawResult,
+// with the object's other members as its Fields; a "data" object is searched
+// the same way.
+func decodeLazyObject(dec *json.Decoder) (map[string]interface{}, *RawResult, error) {
+	obj := make(map[string]interface{})
+	var result *RawResult
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := tok.(string)
+
+		if key != "data" && key != "Result" {
+			var value interface{}
+			if err := dec.Decode(&value); err != nil {
+				return nil, nil, err
+			}
+			obj[key] = value
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		switch tok {
+		case json.Delim('['):
+			rows, err := decodeRawRows(dec)
+			if err != nil {
+				return nil, nil, err
+			}
+			result = &RawResult{Rows: rows}
+		case json.Delim('{'):
+			inner, raw, err := decodeLazyObject(dec)
+			if err != nil {
+				return nil, nil, err
+			}
+			if raw != nil {
+				result = raw
+			} else {
+				obj[key] = inner
+			}
+		default:
+			obj[key] = tok
+		}
+	}
+
+	// Consume the closing '}'
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	if result != nil && result.Fields == nil {
+		result.Fields = obj
+	}
+	return obj, result, nil
+}
+
+// decodeRawRows reads array elements as raw JSON up to and including the closing ']'.
+func decodeRawRows(dec *json.Decoder) ([]json.RawMessage, error) {
+	rows := []json.RawMessage{}
+	for dec.More() {
+		var row json.RawMessage
+		if err := dec.Decode(&row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestQueryRawKeepsRowsUndecoded verifies rows are returned as raw JSON from each response shape.
+func TestQueryRawKeepsRowsUndecoded(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		switch {
+		case strings.Contains(command, "nested"):
+			return `{"success": true, "data": {"Result": [{"name": "Alice"}, {"name": "Bob"}], "ResultCount": 2}}`
+		case strings.Contains(command, "array"):
+			return `[{"name": "Alice"}]`
+		default:
+			return `{"status": "ok", "message": "no rows"}`
+		}
+	})
+	ctx := context.Background()
+
+	result, err := c.QueryRaw(ctx, `SELECT * FROM BUNDLE "nested";`)
+	if err != nil {
+		t.Fatalf("QueryRaw failed: %v", err)
+	}
+	if result.Len() != 2 || string(result.Rows[1]) != `{"name": "Bob"}` {
+		t.Fatalf("unexpected rows: %s", result.Rows)
+	}
+	if result.Fields["ResultCount"] != float64(2) {
+		t.Errorf("expected ResultCount in Fields, got %v", result.Fields)
+	}
+	var row struct{ Name string }
+	if err := result.Decode(0, &row); err != nil || row.Name != "Alice" {
+		t.Errorf("expected Alice, got %+v, %v", row, err)
+	}
+	if err := result.Decode(2, &row); err == nil {
+		t.Error("expected out of range error")
+	}
+
+	if result, err = c.QueryRaw(ctx, `SELECT * FROM BUNDLE "array";`); err != nil || result.Len() != 1 {
+		t.Errorf("expected 1 row from a top-level array, got %+v, %v", result, err)
+	}
+
+	result, err = c.QueryRaw(ctx, `SHOW STATUS;`)
+	if err != nil || result.Rows != nil || result.Fields["message"] != "no rows" {
+		t.Errorf("expected fields without rows, got %+v, %v", result, err)
+	}
+}
+
+// TestQueryRawErrors verifies error responses and row limits apply as for Query.
+func TestQueryRawErrors(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		switch {
+		case strings.Contains(command, "failed"):
+			return `{"success": false, "error": "bundle not found"}`
+		case strings.Contains(command, "status"):
+			return `{"status": "error", "code": "E_DUPLICATE_KEY", "message": "duplicate key"}`
+		default:
+			return `{"success": true, "data": [1, 2, 3, 4]}`
+		}
+	})
+	ctx := context.Background()
+
+	if _, err := c.QueryRaw(ctx, "failed"); ErrorCode(err) != "SERVER_ERROR" {
+		t.Errorf("expected SERVER_ERROR, got %v", err)
+	}
+	if _, err := c.QueryRaw(ctx, "status"); ErrorCode(err) != "E_DUPLICATE_KEY" {
+		t.Errorf("expected E_DUPLICATE_KEY, got %v", err)
+	}
+
+	c.conn.setResponseLimits(0, 3)
+	if _, err := c.QueryRaw(ctx, "rows"); ErrorCode(err) != "E_RESPONSE_TOO_LARGE" {
+		t.Errorf("expected E_RESPONSE_TOO_LARGE, got %v", err)
+	}
+	if !c.conn.IsAlive() {
+		t.Error("expected connection to remain usable after a row limit error")
+	}
+}
+
+// TestReadLineLargerThanBuffer verifies responses spanning many buffer fills are
+// assembled correctly and the assembly buffer is reused.
+func TestReadLineLargerThanBuffer(t *testing.T) {
+	rows := make([]string, 200)
+	for i := range rows {
+		rows[i] = fmt.Sprintf(`{"id": %d}`, i)
+	}
+	payload := `{"success": true, "data": [` + strings.Join(rows, ",") + `]}`
+	c, _ := newPipeClient(t, func(command string) string { return payload + "\r" })
+	c.conn.reader = bufio.NewReaderSize(c.conn.conn, 64)
+
+	for i := 0; i < 3; i++ {
+		result, err := c.Query("SELECT * FROM BUNDLE \"big\";", 1000)
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if got, ok := result.([]interface{}); !ok || len(got) != 200 {
+			t.Fatalf("expected 200 rows, got %T", result)
+		}
+	}
+	if got := c.conn.LastResponseSize(); got != len(payload) {
+		t.Errorf("expected LastResponseSize %d without the line ending, got %d", len(payload), got)
+	}
+	if cap(c.conn.lineBuf) < len(payload) {
+		t.Errorf("expected the assembly buffer to be kept, cap %d", cap(c.conn.lineBuf))
+	}
+}
+
+func largeResponse(rows int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"success": true, "data": {"Result": [`)
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id": %d, "name": "user_%d", "email": "user_%d@example.com", "active": true}`, i, i, i)
+	}
+	b.WriteString(`], "ResultCount": 1000}}`)
+	return []byte(b.String())
+}
+
+// BenchmarkParseResponse_Eager measures decoding a 1000-row response into maps.
+func BenchmarkParseResponse_Eager(b *testing.B) {
+	line := largeResponse(1000)
+	conn := &Connection{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := conn.parseResponse(line); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParseResponse_Lazy measures decoding the same response with raw rows.
+func BenchmarkParseResponse_Lazy(b *testing.B) {
+	line := largeResponse(1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result, err := decodeLazy(line)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if raw, ok := result.(*RawResult); !ok || raw.Len() != 1000 {
+			b.Fatalf("unexpected result %T", result)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRawExpressions verifies raw fragments are placed in order with bound parameters.
+func TestRawExpressions(t *testing.T) {
+	c := NewClient(nil)
+	qb := c.QueryBuilder().Select("users", "name").
+		SelectRaw("count(*) AS c").
+		Where("age", GreaterThan, 30).
+		WhereRaw("lower(name) == ?", "alice").
+		OrWhereRaw("tag IN (?, ?) AND note != '?'", "a", "b").
+		OrderByRaw("length(name) DESC").
+		OrderBy("age", Ascending)
+
+	query, params, err := qb.buildQuery()
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	want := `SELECT name, count(*) AS c FROM users WHERE age > $1 AND (lower(name) == $2) OR (tag IN ($3, $4) AND note != '?') ORDER BY length(name) DESC, age ASC;`
+	if query != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, query)
+	}
+	if !reflect.DeepEqual(params, []interface{}{30, "alice", "a", "b"}) {
+		t.Errorf("unexpected params: %v", params)
+	}
+
+	// Raw-only selects replace *
+	query, _, _ = c.QueryBuilder().Select("users").SelectRaw("count(*) AS c").buildQuery()
+	if query != `SELECT count(*) AS c FROM users;` {
+		t.Errorf("unexpected query: %s", query)
+	}
+}
+
+// TestRawExpressionArgumentMismatch verifies placeholder and argument counts must agree.
+func TestRawExpressionArgumentMismatch(t *testing.T) {
+	c := NewClient(nil)
+	_, _, err := c.QueryBuilder().Select("users").WhereRaw("a == ? AND b == ?", 1).buildQuery()
+	if ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("expected E_INVALID_QUERY, got %v", err)
+	}
+
+	a := c.QueryBuilder().Select("users").WhereRaw("lower(name) == ?", "x").Fingerprint()
+	b := c.QueryBuilder().Select("users").WhereRaw("upper(name) == ?", "x").Fingerprint()
+	if a == b {
+		t.Error("expected different raw expressions to have different fingerprints")
+	}
+}
+
+// TestRawExpressionNamedArgs verifies :name placeholders bind from NamedArgs.
+func TestRawExpressionNamedArgs(t *testing.T) {
+	c := NewClient(nil)
+	query, params, err := c.QueryBuilder().Select("users").
+		Where("active", Equals, true).
+		WhereRaw("age >= :minAge AND (email == :email OR backup == :email) AND note != ':x'",
+			NamedArgs{"minAge": 21, "email": "a@example.com"}).
+		buildQuery()
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	want := `SELECT * FROM users WHERE active == $1 AND (age >= $2 AND (email == $3 OR backup == $4) AND note != ':x');`
+	if query != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, query)
+	}
+	if !reflect.DeepEqual(params, []interface{}{true, 21, "a@example.com", "a@example.com"}) {
+		t.Errorf("unexpected params: %v", params)
+	}
+
+	_, _, err = c.QueryBuilder().Select("users").WhereRaw("age >= :minAge", NamedArgs{"min": 21}).buildQuery()
+	if ErrorCode(err) != "E_PARAM_MISSING" {
+		t.Errorf("expected E_PARAM_MISSING, got %v", err)
+	}
+}
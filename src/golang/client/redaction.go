@@ -0,0 +1,250 @@
+package client
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultRedactionMask replaces redacted values.
+const defaultRedactionMask = "[REDACTED]"
+
+// RedactionPolicy masks sensitive values before commands, parameters and
+// errors leave the client through hooks, logs or error Details. Set it with
+// ClientOptions.Redaction. Commands sent to the server are not modified.
+type RedactionPolicy struct {
+	// Fields are case-insensitive field-name patterns using path.Match syntax,
+	// e.g. "password" or "*_token". Values assigned to or compared with a
+	// matching field are masked, as are Details entries with a matching key.
+	Fields []string
+
+	// Values are patterns masked wherever they appear, e.g. email addresses.
+	Values []*regexp.Regexp
+
+	// Mask replaces redacted values. Default: "[REDACTED]"
+	Mask string
+}
+
+// DefaultRedactionPolicy returns a policy masking common credential and
+// personal-data fields and email addresses.
+func DefaultRedactionPolicy() *RedactionPolicy {
+	return &RedactionPolicy{
+		Fields: []string{
+			"password", "passwd", "secret", "*_secret", "token", "*_token",
+			"api_key", "apikey", "authorization", "ssn", "credit_card", "card_number",
+		},
+		Values: []*regexp.Regexp{
+			regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		},
+	}
+}
+
+// fieldValuePattern matches a quoted field name followed by an assignment or
+// comparison and its literal value, as written by the builders.
+var fieldValuePattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"(\s*(?:==|!=|>=|<=|=|:|>|<)\s*)("(?:[^"\\]|\\.)*"|-?[\w.$]+)`)
+
+// mask returns the replacement for redacted values.
+func (p *RedactionPolicy) mask() string {
+	if p.Mask == "" {
+		return defaultRedactionMask
+	}
+	return p.Mask
+}
+
+// isSensitiveField reports whether field matches one of the field patterns.
+func (p *RedactionPolicy) isSensitiveField(field string) bool {
+	field = strings.ToLower(field)
+	for _, pattern := range p.Fields {
+		if ok, _ := path.Match(strings.ToLower(pattern), field); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactCommand returns command with the values of sensitive fields and
+// any Values matches masked.
+func (p *RedactionPolicy) RedactCommand(command string) string {
+	redacted, _ := p.redactCommand(command)
+	return redacted
+}
+
+// redactCommand masks command and reports which $n placeholders were bound
+// to sensitive fields, so the matching parameters can be masked too.
+func (p *RedactionPolicy) redactCommand(command string) (string, map[int]bool) {
+	if p == nil || command == "" {
+		return command, nil
+	}
+
+	var placeholders map[int]bool
+	if len(p.Fields) > 0 {
+		command = fieldValuePattern.ReplaceAllStringFunc(command, func(match string) string {
+			parts := fieldValuePattern.FindStringSubmatch(match)
+			if !p.isSensitiveField(parts[1]) {
+				return match
+			}
+			if n, err := strconv.Atoi(strings.TrimPrefix(parts[3], "$")); err == nil && strings.HasPrefix(parts[3], "$") {
+				if placeholders == nil {
+					placeholders = make(map[int]bool)
+				}
+				placeholders[n-1] = true
+				return match
+			}
+			return `"` + parts[1] + `"` + parts[2] + `"` + p.mask() + `"`
+		})
+	}
+	return p.redactString(command), placeholders
+}
+
+// redactString masks Values matches in s.
+func (p *RedactionPolicy) redactString(s string) string {
+	for _, re := range p.Values {
+		s = re.ReplaceAllString(s, p.mask())
+	}
+	return s
+}
+
+// redactParams returns a copy of params with values bound to sensitive
+// fields in command, and string values matching Values, masked.
+func (p *RedactionPolicy) redactParams(command string, params []interface{}) []interface{} {
+	if p == nil || len(params) == 0 {
+		return params
+	}
+
+	_, placeholders := p.redactCommand(command)
+	redacted := make([]interface{}, len(params))
+	for i, param := range params {
+		switch {
+		case placeholders[i]:
+			redacted[i] = p.mask()
+		default:
+			redacted[i] = p.redactValue(param)
+		}
+	}
+	return redacted
+}
+
+// redactValue masks a Details or parameter value, recursing into maps and slices.
+func (p *RedactionPolicy) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return p.RedactCommand(v)
+	case map[string]interface{}:
+		return p.redactDetails(v)
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = p.redactValue(item)
+		}
+		return redacted
+	}
+	return value
+}
+
+// redactDetails returns a copy of details with sensitive keys and values masked.
+func (p *RedactionPolicy) redactDetails(details map[string]interface{}) map[string]interface{} {
+	if details == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(details))
+	for key, value := range details {
+		if p.isSensitiveField(key) {
+			redacted[key] = p.mask()
+		} else {
+			redacted[key] = p.redactValue(value)
+		}
+	}
+	return redacted
+}
+
+// redactError masks the Details, Query and Params of the driver errors in
+// err's chain, in place, and returns err.
+func (p *RedactionPolicy) redactError(err error) error {
+	if p == nil {
+		return err
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch typed := e.(type) {
+		case *ConnectionError:
+			typed.Details = p.redactDetails(typed.Details)
+		case *ProtocolError:
+			typed.Details = p.redactDetails(typed.Details)
+		case *StateError:
+			typed.Details = p.redactDetails(typed.Details)
+		case *QueryError:
+			p.redactQueryError(typed)
+		case *StatementError:
+			p.redactQueryError(&typed.QueryError)
+		case *TransactionError:
+			typed.Details = p.redactDetails(typed.Details)
+		}
+	}
+	return err
+}
+
+// redactQueryError masks the query-specific fields of a QueryError.
+func (p *RedactionPolicy) redactQueryError(e *QueryError) {
+	e.Params = p.redactParams(e.Query, e.Params)
+	e.Query = p.RedactCommand(e.Query)
+	e.Details = p.redactDetails(e.Details)
+}
+
+// redactFields masks log fields with sensitive keys or string values
+// containing sensitive data.
+func (p *RedactionPolicy) redactFields(fields []Field) []Field {
+	redacted := make([]Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = field
+		if p.isSensitiveField(field.Key) {
+			redacted[i].Value = p.mask()
+		} else if s, ok := field.Value.(string); ok {
+			redacted[i].Value = p.RedactCommand(s)
+		}
+	}
+	return redacted
+}
+
+// redactingLogger applies a RedactionPolicy to every field before logging.
+type redactingLogger struct {
+	Logger
+	policy *RedactionPolicy
+}
+
+// wrapLogger returns logger wrapped so its fields are redacted, or logger
+// itself if p is nil.
+func (p *RedactionPolicy) wrapLogger(logger Logger) Logger {
+	if p == nil {
+		return logger
+	}
+	return &redactingLogger{Logger: logger, policy: p}
+}
+
+// unwrapLogger returns the logger wrapped by a redactingLogger.
+func unwrapLogger(logger Logger) Logger {
+	if rl, ok := logger.(*redactingLogger); ok {
+		return rl.Logger
+	}
+	return logger
+}
+
+func (l *redactingLogger) Debug(msg string, fields ...Field) {
+	l.Logger.Debug(msg, l.policy.redactFields(fields)...)
+}
+
+func (l *redactingLogger) Info(msg string, fields ...Field) {
+	l.Logger.Info(msg, l.policy.redactFields(fields)...)
+}
+
+func (l *redactingLogger) Warn(msg string, fields ...Field) {
+	l.Logger.Warn(msg, l.policy.redactFields(fields)...)
+}
+
+func (l *redactingLogger) Error(msg string, fields ...Field) {
+	l.Logger.Error(msg, l.policy.redactFields(fields)...)
+}
+
+func (l *redactingLogger) WithFields(fields ...Field) Logger {
+	return &redactingLogger{Logger: l.Logger.WithFields(l.policy.redactFields(fields)...), policy: l.policy}
+}
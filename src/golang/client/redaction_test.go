@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRedactCommand verifies sensitive field values and value patterns are masked.
+func TestRedactCommand(t *testing.T) {
+	p := DefaultRedactionPolicy()
+
+	got := p.RedactCommand(`UPDATE DOCUMENTS IN BUNDLE "users" (SET "password" = "hunter2", "name" = "Bob") WHERE "api_key" == "abc123";`)
+	want := `UPDATE DOCUMENTS IN BUNDLE "users" (SET "password" = "[REDACTED]", "name" = "Bob") WHERE "api_key" == "[REDACTED]";`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	got = p.RedactCommand(`SELECT * FROM "users" WHERE "contact" == "alice@example.com" AND "reset_token" == 42;`)
+	if strings.Contains(got, "alice@example.com") || strings.Contains(got, "42") {
+		t.Errorf("expected email and token to be masked, got %s", got)
+	}
+
+	custom := &RedactionPolicy{Fields: []string{"PIN"}, Mask: "***"}
+	if got := custom.RedactCommand(`{"pin": "1234", "id": 7}`); got != `{"pin": "***", "id": 7}` {
+		t.Errorf("expected case-insensitive match with custom mask, got %s", got)
+	}
+
+	var nilPolicy *RedactionPolicy
+	if got := nilPolicy.RedactCommand(`"password" = "x"`); got != `"password" = "x"` {
+		t.Errorf("expected nil policy to leave command unchanged, got %s", got)
+	}
+}
+
+// TestRedactParams verifies parameters bound to sensitive fields are masked.
+func TestRedactParams(t *testing.T) {
+	p := DefaultRedactionPolicy()
+	got := p.redactParams(`SELECT * FROM "users" WHERE "name" == $1 AND "password" == $2;`, []interface{}{"bob", "hunter2"})
+	if !reflect.DeepEqual(got, []interface{}{"bob", "[REDACTED]"}) {
+		t.Errorf("unexpected params: %v", got)
+	}
+}
+
+// TestRedactionHooksAndLogs verifies hooks and logs see masked values while the server receives the original.
+func TestRedactionHooksAndLogs(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"error","message":"duplicate email alice@example.com","code":"E_DUPLICATE","password":"hunter2"}`
+	})
+	var logs bytes.Buffer
+	c.redaction = DefaultRedactionPolicy()
+	c.logger = c.redaction.wrapLogger(NewLogger("DEBUG", &logs))
+	c.debugMode.Store(true)
+
+	var before, after string
+	c.RegisterHook(&funcHook{
+		before: func(hookCtx *HookContext) { before = hookCtx.Command },
+		after:  func(hookCtx *HookContext) { after = hookCtx.Command },
+	})
+
+	_, err := c.InsertBuilder("users").Values(map[string]interface{}{"email": "alice@example.com", "password": "hunter2"}).Execute(context.Background())
+	if err == nil {
+		t.Fatal("expected server error")
+	}
+
+	if sent := server.received()[0]; !strings.Contains(sent, "hunter2") || !strings.Contains(sent, "alice@example.com") {
+		t.Errorf("expected the server to receive the original command, got %s", sent)
+	}
+	for name, command := range map[string]string{"Before": before, "After": after, "logs": logs.String()} {
+		if strings.Contains(command, "hunter2") || strings.Contains(command, "alice@example.com") {
+			t.Errorf("expected %s to be redacted, got %s", name, command)
+		}
+	}
+
+	queryErr, ok := err.(*QueryError)
+	if !ok {
+		t.Fatalf("expected *QueryError, got %T", err)
+	}
+	if strings.Contains(queryErr.Query, "hunter2") || queryErr.Details["password"] != "[REDACTED]" {
+		t.Errorf("expected error Query and Details to be redacted, got %q %v", queryErr.Query, queryErr.Details)
+	}
+}
+
+// funcHook calls the given functions from Before and After.
+type funcHook struct {
+	before func(*HookContext)
+	after  func(*HookContext)
+}
+
+func (h *funcHook) Name() string { return "func" }
+func (h *funcHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	h.before(hookCtx)
+	return nil
+}
+func (h *funcHook) After(ctx context.Context, hookCtx *HookContext) error {
+	h.after(hookCtx)
+	return nil
+}
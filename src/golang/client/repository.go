@@ -0,0 +1,433 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// documentIDField is the server-assigned identifier present on every document.
+const documentIDField = "DocumentID"
+
+// GenericRepository binds a struct type to a bundle and provides typed CRUD
+// helpers built on the query builders. Document fields map to struct fields
+// by their json tags, as encoding/json would name them; fields tagged "-" are
+// ignored. The document ID is read from and written to the field named
+// DocumentID unless WithIDField names another:
+//
+//	type User struct {
+//		ID    string `json:"DocumentID,omitempty"`
+//		Name  string `json:"name"`
+//		Email string `json:"email"`
+//	}
+//
+//	users := client.NewGenericRepository[User](c, "users")
+//	_, err := users.Insert(ctx, &User{Name: "Ada", Email: "ada@example.com"})
+//	user, err := users.FindByID(ctx, id)
+//
+// Queries run through the client, so hooks, encryption and the transaction
+// or database bound to ctx apply as for any builder.
+type GenericRepository[T any] struct {
+	client  *Client
+	bundle  string
+	idField string
+	fields  []repositoryField
+}
+
+// repositoryField is a struct field mapped to a document field.
+type repositoryField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	typ       reflect.Type
+	dateTime  bool // time.Time or *time.Time, decoded with the client's DATETIME format
+	decimal   bool // big.Rat or *big.Rat, decoded exactly from strings or numbers
+}
+
+// NewGenericRepository returns a repository for documents of bundle mapped to T,
+// which must be a struct type.
+func NewGenericRepository[T any](c *Client, bundle string) *GenericRepository[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("client: GenericRepository requires a struct type, got %s", t))
+	}
+	return &GenericRepository[T]{
+		client:  c,
+		bundle:  bundle,
+		idField: documentIDField,
+		fields:  repositoryFields(t),
+	}
+}
+
+// WithIDField uses field as the document identifier instead of DocumentID.
+// Unlike DocumentID, a custom ID field is written on Insert.
+func (r *GenericRepository[T]) WithIDField(field string) *GenericRepository[T] {
+	r.idField = field
+	return r
+}
+
+// Bundle returns the bundle the repository reads and writes.
+func (r *GenericRepository[T]) Bundle() string {
+	return r.bundle
+}
+
+// FindByID returns the document whose ID field equals id. It returns an
+// E_DOCUMENT_NOT_FOUND error, matching ErrDocumentNotFound, if there is none.
+func (r *GenericRepository[T]) FindByID(ctx context.Context, id interface{}) (*T, error) {
+	results, err := r.Query(ctx, func(qb *QueryBuilder) {
+		qb.Where(r.idField, Equals, id).Limit(1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, r.notFound(id)
+	}
+	return &results[0], nil
+}
+
+// Find returns the documents whose fields equal all values in match.
+// An empty match returns every document in the bundle.
+func (r *GenericRepository[T]) Find(ctx context.Context, match map[string]interface{}) ([]T, error) {
+	fields := make([]string, 0, len(match))
+	for field := range match {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return r.Query(ctx, func(qb *QueryBuilder) {
+		for _, field := range fields {
+			qb.Where(field, Equals, match[field])
+		}
+	})
+}
+
+// Query runs a SELECT on the bundle, refined by build, and decodes the rows
+// into T. build may add conditions, ordering and limits; it must not call Select.
+//
+//	active, err := users.Query(ctx, func(qb *client.QueryBuilder) {
+//		qb.Where("status", client.Equals, "active").OrderBy("name", client.Ascending)
+//	})
+func (r *GenericRepository[T]) Query(ctx context.Context, build func(qb *QueryBuilder)) ([]T, error) {
+	qb := r.client.QueryBuilder().Select(r.bundle)
+	if build != nil {
+		build(qb)
+	}
+
+	result, err := qb.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.parseRows(resultRows(result))
+	if err != nil {
+		return nil, err
+	}
+	return decodeRows[T](rows)
+}
+
+// Insert adds entity as a new document. When the ID field is DocumentID, it
+// is not sent and the ID assigned by the server is stored back into entity.
+func (r *GenericRepository[T]) Insert(ctx context.Context, entity *T) (*MutationResult, error) {
+	values := r.values(entity, r.idField == documentIDField)
+	result, err := r.client.InsertBuilder(r.bundle).Values(values).Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.idField == documentIDField && len(result.InsertedIDs) == 1 {
+		r.setID(entity, result.InsertedIDs[0])
+	}
+	return result, nil
+}
+
+// Update writes all fields of entity to the document with its ID. It returns
+// an E_DOCUMENT_NOT_FOUND error if the server reports no document changed.
+func (r *GenericRepository[T]) Update(ctx context.Context, entity *T) (*MutationResult, error) {
+	id, err := r.id(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	ub := r.client.UpdateBuilder(r.bundle)
+	for field, value := range r.values(entity, true) {
+		ub.Set(field, value)
+	}
+	result, err := ub.Where(r.idField, Equals, id).Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if result.AffectedCount == 0 {
+		return result, r.notFound(id)
+	}
+	return result, nil
+}
+
+// Delete removes the document with the given ID. It returns an
+// E_DOCUMENT_NOT_FOUND error if the server reports no document removed.
+func (r *GenericRepository[T]) Delete(ctx context.Context, id interface{}) (*MutationResult, error) {
+	result, err := r.client.DeleteBuilder(r.bundle).Where(r.idField, Equals, id).Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if result.AffectedCount == 0 {
+		return result, r.notFound(id)
+	}
+	return result, nil
+}
+
+// Validate checks the mapping of T against the bundle's schema, as returned by
+// DescribeBundle. It returns an E_FIELD_NOT_FOUND error listing the struct
+// fields the bundle does not define.
+func (r *GenericRepository[T]) Validate(ctx context.Context) error {
+	bundle, err := r.client.DescribeBundle(ctx, r.bundle)
+	if err != nil {
+		return err
+	}
+
+	defined := map[string]bool{documentIDField: true}
+	for _, field := range bundle.Fields {
+		defined[field.Name] = true
+	}
+
+	var missing []string
+	for _, field := range r.fields {
+		if !defined[field.name] {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &QueryError{
+		Code:    "E_FIELD_NOT_FOUND",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("bundle %s has no fields %s", r.bundle, strings.Join(missing, ", ")),
+		Details: map[string]interface{}{
+			"bundle": r.bundle,
+			"fields": missing,
+		},
+	}
+}
+
+// parseRows returns rows with the values of fields whose type has a codec
+// unmarshaled by it, those of time fields parsed in the client's DATETIME
+// format and re-encoded as RFC 3339, and those of *big.Rat fields as exact
+// fractions, which encoding/json decodes. Rows are copied rather than
+// modified, as results may be cached.
+func (r *GenericRepository[T]) parseRows(rows []interface{}) ([]interface{}, error) {
+	fieldCodecs := r.client.codecs.bundleCodecs(r.bundle)
+	var fields []repositoryField
+	codecs := make(map[string]Codec)
+	for _, field := range r.fields {
+		if _, ok := fieldCodecs[field.name]; ok {
+			continue // already unmarshaled by QueryBuilder
+		}
+		if codec, ok := r.client.codecs.typeCodec(field.typ); ok {
+			codecs[field.name] = codec
+		} else if codec, ok := r.client.codecs.typeCodec(indirect(field.typ)); ok {
+			codecs[field.name] = codec
+		} else if !field.dateTime && !field.decimal {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return rows, nil
+	}
+
+	codec := newValueCodec(r.client.opts)
+	parsed := make([]interface{}, len(rows))
+	for i, row := range rows {
+		doc, ok := row.(map[string]interface{})
+		if !ok {
+			parsed[i] = row
+			continue
+		}
+		copied := make(map[string]interface{}, len(doc))
+		for k, v := range doc {
+			copied[k] = v
+		}
+		for _, field := range fields {
+			value, ok := copied[field.name]
+			if !ok || value == nil {
+				continue
+			}
+			if typeCodec, ok := codecs[field.name]; ok {
+				v, err := typeCodec.Unmarshal(value)
+				if err != nil {
+					return nil, errCodec("E_CODEC_UNMARSHAL_FAILED", r.bundle, field.name, err)
+				}
+				copied[field.name] = v
+				continue
+			}
+			if field.decimal {
+				d, err := r.client.ParseDecimal(value)
+				if err != nil {
+					return nil, fmt.Errorf("decode row %d: field %s: %w", i, field.name, err)
+				}
+				copied[field.name] = d.RatString()
+				continue
+			}
+			t, err := codec.parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("decode row %d: field %s: %w", i, field.name, err)
+			}
+			copied[field.name] = t.Format(time.RFC3339Nano)
+		}
+		parsed[i] = copied
+	}
+	return parsed, nil
+}
+
+// values returns the document fields of entity, omitting empty omitempty
+// fields and, if skipID is set, the ID field.
+func (r *GenericRepository[T]) values(entity *T, skipID bool) map[string]interface{} {
+	v := reflect.ValueOf(entity).Elem()
+	values := make(map[string]interface{}, len(r.fields))
+	for _, field := range r.fields {
+		if skipID && field.name == r.idField {
+			continue
+		}
+		fv, ok := fieldByIndex(v, field.index)
+		if !ok || !fv.CanInterface() || (field.omitEmpty && fv.IsZero()) {
+			continue
+		}
+		values[field.name] = fv.Interface()
+	}
+	return values
+}
+
+// id returns the ID field of entity, failing if it is unmapped or empty.
+func (r *GenericRepository[T]) id(entity *T) (interface{}, error) {
+	v := reflect.ValueOf(entity).Elem()
+	for _, field := range r.fields {
+		if field.name != r.idField {
+			continue
+		}
+		if fv, ok := fieldByIndex(v, field.index); ok && fv.CanInterface() && !fv.IsZero() {
+			return fv.Interface(), nil
+		}
+		break
+	}
+	return nil, &QueryError{
+		Code:    "E_INVALID_QUERY",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("%s has no %s value", v.Type(), r.idField),
+	}
+}
+
+// setID stores a server-assigned ID into entity's ID field if it is a string.
+func (r *GenericRepository[T]) setID(entity *T, id string) {
+	v := reflect.ValueOf(entity).Elem()
+	for _, field := range r.fields {
+		if field.name == r.idField {
+			if fv, ok := fieldByIndex(v, field.index); ok && fv.Kind() == reflect.String && fv.CanSet() {
+				fv.SetString(id)
+			}
+			return
+		}
+	}
+}
+
+func (r *GenericRepository[T]) notFound(id interface{}) *QueryError {
+	return &QueryError{
+		Code:    "E_DOCUMENT_NOT_FOUND",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("no document in %s with %s %v", r.bundle, r.idField, id),
+		Details: map[string]interface{}{
+			"bundle": r.bundle,
+			"id":     id,
+		},
+	}
+}
+
+// repositoryFields lists the exported fields of t by their JSON names,
+// including fields promoted from untagged embedded structs.
+func repositoryFields(t reflect.Type) []repositoryField {
+	var fields []repositoryField
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" && indirect(sf.Type).Kind() == reflect.Struct {
+			continue // its fields are visited as promoted fields
+		}
+		if len(sf.Index) > 1 && !promoted(t, sf.Index) {
+			continue // belongs to a tagged or non-struct embedded field
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, repositoryField{
+			name:      name,
+			index:     sf.Index,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			typ:       sf.Type,
+			dateTime:  indirect(sf.Type) == reflect.TypeOf(time.Time{}),
+			decimal:   indirect(sf.Type) == reflect.TypeOf(big.Rat{}),
+		})
+	}
+	return fields
+}
+
+// promoted reports whether the field at index is reached only through
+// untagged embedded structs, as encoding/json would flatten it.
+func promoted(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		sf := indirect(t).Field(i)
+		if !sf.Anonymous || sf.Tag.Get("json") != "" {
+			return false
+		}
+		t = sf.Type
+	}
+	return true
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex without panicking on nil
+// embedded pointers; ok is false if one is nil.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// decodeRows converts result rows to T through their JSON form.
+func decodeRows[T any](rows []interface{}) ([]T, error) {
+	results := make([]T, 0, len(rows))
+	for i, row := range rows {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		var item T
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, fmt.Errorf("decode row %d: %w", i, err)
+		}
+		results = append(results, item)
+	}
+	return results, nil
+}
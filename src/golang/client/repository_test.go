@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type repoUser struct {
+	ID    string `json:"DocumentID,omitempty"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age,omitempty"`
+}
+
+type repoAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type repoPost struct {
+	repoAudit
+	ID       string `json:"DocumentID,omitempty"`
+	Title    string
+	Internal string `json:"-"`
+	secret   string
+}
+
+func TestRepositoryFields(t *testing.T) {
+	var names []string
+	for _, field := range repositoryFields(reflect.TypeOf(repoPost{})) {
+		names = append(names, field.name)
+	}
+	want := []string{"created_by", "DocumentID", "Title"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected fields %v, got %v", want, names)
+	}
+}
+
+func TestGenericRepositoryInsert(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"DocumentID": "u_1", "name": "Ada"}}`
+	})
+	users := NewGenericRepository[repoUser](c, "users")
+
+	user := &repoUser{Name: "Ada", Email: "ada@example.com"}
+	result, err := users.Insert(context.Background(), user)
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if user.ID != "u_1" || len(result.InsertedIDs) != 1 {
+		t.Errorf("expected the assigned ID to be stored, got %q (%v)", user.ID, result.InsertedIDs)
+	}
+
+	command := server.received()[0]
+	if !strings.HasPrefix(command, `ADD DOCUMENT TO BUNDLE  "users"`) || !strings.Contains(command, `"email"`) {
+		t.Errorf("unexpected insert: %s", command)
+	}
+	if strings.Contains(command, "DocumentID") || strings.Contains(command, `"age"`) {
+		t.Errorf("expected DocumentID and empty omitempty fields to be left out: %s", command)
+	}
+}
+
+func TestGenericRepositoryFind(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, "missing") {
+			return `{"success": true, "data": {"Result": [], "ResultCount": 0}}`
+		}
+		return `{"success": true, "data": {"Result": [{"DocumentID": "u_1", "name": "Ada", "email": "ada@example.com", "age": 36}], "ResultCount": 1}}`
+	})
+	users := NewGenericRepository[repoUser](c, "users")
+	ctx := context.Background()
+
+	user, err := users.FindByID(ctx, "u_1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if *user != (repoUser{ID: "u_1", Name: "Ada", Email: "ada@example.com", Age: 36}) {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	if command := server.received()[0]; !strings.Contains(command, "WHERE DocumentID == 'u_1' LIMIT 1") {
+		t.Errorf("unexpected FindByID query: %s", command)
+	}
+
+	if _, err := users.FindByID(ctx, "missing"); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+
+	found, err := users.Find(ctx, map[string]interface{}{"name": "Ada", "age": 36})
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(found) != 1 || found[0].Email != "ada@example.com" {
+		t.Errorf("unexpected Find results: %+v", found)
+	}
+	command := server.received()[2]
+	if !strings.Contains(command, "WHERE age == 36 AND name == 'Ada'") {
+		t.Errorf("expected conditions in field order: %s", command)
+	}
+}
+
+func TestGenericRepositoryUpdateAndDelete(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		if strings.Contains(command, "u_404") {
+			return `{"success": true, "data": {"affected_count": 0}}`
+		}
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	users := NewGenericRepository[repoUser](c, "users")
+	ctx := context.Background()
+
+	if _, err := users.Update(ctx, &repoUser{ID: "u_1", Name: "Ada Lovelace", Email: "ada@example.com"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	command := server.received()[0]
+	if !strings.HasPrefix(command, "UPDATE DOCUMENTS") || !strings.Contains(command, "Ada Lovelace") || !strings.Contains(command, `"DocumentID"`) {
+		t.Errorf("unexpected update: %s", command)
+	}
+
+	if _, err := users.Update(ctx, &repoUser{Name: "no id"}); ErrorCode(err) != "E_INVALID_QUERY" {
+		t.Errorf("expected E_INVALID_QUERY for a missing ID, got %v", err)
+	}
+	if _, err := users.Update(ctx, &repoUser{ID: "u_404"}); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+
+	if _, err := users.Delete(ctx, "u_1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if command := server.received()[2]; !strings.HasPrefix(command, "DELETE DOCUMENTS") {
+		t.Errorf("unexpected delete: %s", command)
+	}
+	if _, err := users.Delete(ctx, "u_404"); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestGenericRepositoryCustomIDField(t *testing.T) {
+	type sku struct {
+		Code  string  `json:"code"`
+		Price float64 `json:"price"`
+	}
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	skus := NewGenericRepository[sku](c, "skus").WithIDField("code")
+
+	if _, err := skus.Insert(context.Background(), &sku{Code: "A-1", Price: 9.5}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if command := server.received()[0]; !strings.Contains(command, `"code"`) {
+		t.Errorf("expected a custom ID field to be written: %s", command)
+	}
+}
+
+func TestGenericRepositoryValidate(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return showBundlesResponse
+	})
+	ctx := context.Background()
+
+	if err := NewGenericRepository[repoUser](c, "users").Validate(ctx); !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected ErrFieldNotFound for age, got %v", err)
+	} else if !strings.Contains(err.Error(), "age") {
+		t.Errorf("expected age to be reported: %v", err)
+	}
+
+	type named struct {
+		ID   string `json:"DocumentID"`
+		Name string `json:"name"`
+	}
+	if err := NewGenericRepository[named](c, "users").Validate(ctx); err != nil {
+		t.Errorf("expected a valid mapping, got %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls automatic re-execution of failed commands.
+// Only errors whose code is listed in RetryableCodes are retried, and mutations
+// are never retried unless the call is marked idempotent with WithIdempotent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first.
+	// Values <= 1 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+
+	// Multiplier scales the backoff after each retry. Values < 1 are treated as 1.
+	Multiplier float64
+
+	// Jitter randomizes each delay by up to this fraction (0-1) in either direction.
+	Jitter float64
+
+	// RetryableCodes lists the error codes that trigger a retry.
+	RetryableCodes []string
+
+	// Budget limits retries across all commands sharing the policy. Nil means unlimited.
+	Budget *RetryBudget
+}
+
+// DefaultRetryPolicy returns a policy retrying transient connection failures
+// up to 3 attempts with exponential backoff from 100ms to 2s and 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableCodes: []string{
+			"SEND_FAILED",
+			"RECEIVE_FAILED",
+			"NO_RESPONSE",
+			"CONNECTION_DEAD",
+			"CONNECTION_FAILED",
+			"NETWORK_ERROR",
+		},
+		Budget: NewRetryBudget(0.1, 10),
+	}
+}
+
+// isRetryable reports whether err carries one of the policy's retryable codes.
+func (p *RetryPolicy) isRetryable(err error) bool {
+	code := ErrorCode(err)
+	if code == "" {
+		return false
+	}
+	for _, retryable := range p.RetryableCodes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry (1-based), including jitter.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
+}
+
+// RetryBudget caps retries to a fraction of overall command volume so that
+// retries cannot multiply load on a struggling server. Every command deposits
+// ratio tokens (up to burst) and every retry withdraws one.
+type RetryBudget struct {
+	ratio  float64
+	burst  float64
+	tokens float64
+	mu     sync.Mutex
+}
+
+// NewRetryBudget creates a budget allowing retries for ratio of commands,
+// with up to burst retries available immediately.
+func NewRetryBudget(ratio float64, burst int) *RetryBudget {
+	return &RetryBudget{
+		ratio:  ratio,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// deposit records a command execution.
+func (b *RetryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+b.ratio)
+}
+
+// withdraw consumes a retry token, returning false if the budget is exhausted.
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+const (
+	retryPolicyKey    contextKey = "retryPolicy"
+	idempotentKey     contextKey = "idempotent"
+	commandAttemptKey contextKey = "commandAttempt"
+)
+
+// WithRetryPolicy overrides the client's retry policy for commands executed with ctx.
+// Pass nil to disable retries for the call.
+func WithRetryPolicy(ctx context.Context, policy *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey, policy)
+}
+
+// WithIdempotent marks commands executed with ctx as safe to retry even if they
+// are mutations, e.g. an UPDATE that sets absolute values.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey, true)
+}
+
+// retryPolicyFor returns the policy that applies to ctx.
+func (c *Client) retryPolicyFor(ctx context.Context) *RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey).(*RetryPolicy); ok {
+		return policy
+	}
+	return c.opts.RetryPolicy
+}
+
+// isIdempotentCommand reports whether command may safely be executed more than once.
+// Reads are idempotent; writes only when the caller marked ctx with WithIdempotent.
+func isIdempotentCommand(ctx context.Context, command string) bool {
+	if marked, _ := ctx.Value(idempotentKey).(bool); marked {
+		return true
+	}
+	upper := strings.ToUpper(strings.TrimSpace(command))
+	return strings.HasPrefix(upper, "SELECT") || strings.HasPrefix(upper, "SHOW")
+}
+
+// ErrorCode returns the code of the first driver error in err's chain, or "".
+func ErrorCode(err error) string {
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		return connErr.Code
+	}
+	var protoErr *ProtocolError
+	if errors.As(err, &protoErr) {
+		return protoErr.Code
+	}
+	var stmtErr *StatementError
+	if errors.As(err, &stmtErr) {
+		return stmtErr.Code
+	}
+	var queryErr *QueryError
+	if errors.As(err, &queryErr) {
+		return queryErr.Code
+	}
+	var txErr *TransactionError
+	if errors.As(err, &txErr) {
+		return txErr.Code
+	}
+	var stateErr *StateError
+	if errors.As(err, &stateErr) {
+		return stateErr.Code
+	}
+	return ""
+}
+
+// sendWithRetries executes a command, retrying transient failures according
+// to the retry policy in effect for ctx.
+func (c *Client) sendWithRetries(ctx context.Context, command string) (interface{}, error) {
+	policy := c.retryPolicyFor(ctx)
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return c.sendAttempt(ctx, command)
+	}
+
+	if policy.Budget != nil {
+		policy.Budget.deposit()
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := c.sendAttempt(withCommandAttempt(ctx, attempt), command)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.isRetryable(err) {
+			return result, err
+		}
+
+		if !isIdempotentCommand(ctx, command) {
+			c.logger.Debug("not retrying non-idempotent command",
+				String("command", command),
+				Error("error", err))
+			return result, err
+		}
+
+		if policy.Budget != nil && !policy.Budget.withdraw() {
+			c.logger.Warn("retry budget exhausted",
+				String("command", command),
+				Error("error", err))
+			return result, err
+		}
+
+		delay := policy.backoff(attempt)
+		c.logger.Info("retrying command",
+			String("command", command),
+			String("error_code", ErrorCode(err)),
+			Int("attempt", attempt+1),
+			Int("max_attempts", policy.MaxAttempts),
+			Duration("backoff", delay))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// withCommandAttempt returns a context reporting attempt as HookContext.Attempt.
+func withCommandAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, commandAttemptKey, attempt)
+}
+
+// commandAttempt returns the attempt set with withCommandAttempt, or 1.
+func commandAttempt(ctx context.Context) int {
+	if attempt, ok := ctx.Value(commandAttemptKey).(int); ok {
+		return attempt
+	}
+	return 1
+}
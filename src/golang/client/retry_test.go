@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries INVALID_STATE errors, which a disconnected client returns.
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     2,
+		RetryableCodes: []string{"INVALID_STATE"},
+		Budget:         NewRetryBudget(0, 10),
+	}
+}
+
+// TestRetryPolicyRetriesQueries verifies idempotent commands are retried up to MaxAttempts.
+func TestRetryPolicyRetriesQueries(t *testing.T) {
+	policy := testRetryPolicy()
+	opts := DefaultOptions()
+	opts.RetryPolicy = policy
+	client := NewClient(&opts)
+
+	_, err := client.sendCommand(context.Background(), `SELECT * FROM "users";`)
+	if ErrorCode(err) != "INVALID_STATE" {
+		t.Fatalf("expected INVALID_STATE error, got %v", err)
+	}
+
+	// Two retries after the first attempt
+	if policy.Budget.tokens != 8 {
+		t.Errorf("expected 2 retries to consume budget, tokens left: %v", policy.Budget.tokens)
+	}
+}
+
+// TestRetryPolicySkipsMutations verifies mutations are only retried when marked idempotent.
+func TestRetryPolicySkipsMutations(t *testing.T) {
+	policy := testRetryPolicy()
+	opts := DefaultOptions()
+	opts.RetryPolicy = policy
+	client := NewClient(&opts)
+
+	mutation := `DELETE DOCUMENTS FROM BUNDLE "users" WHERE "id" == 1;`
+	client.sendCommand(context.Background(), mutation)
+	if policy.Budget.tokens != 10 {
+		t.Errorf("expected no retries for mutation, tokens left: %v", policy.Budget.tokens)
+	}
+
+	client.sendCommand(WithIdempotent(context.Background()), mutation)
+	if policy.Budget.tokens != 8 {
+		t.Errorf("expected 2 retries for idempotent mutation, tokens left: %v", policy.Budget.tokens)
+	}
+}
+
+// TestRetryPolicyOverride verifies per-call policies replace the client policy.
+func TestRetryPolicyOverride(t *testing.T) {
+	clientPolicy := testRetryPolicy()
+	opts := DefaultOptions()
+	opts.RetryPolicy = clientPolicy
+	client := NewClient(&opts)
+
+	override := testRetryPolicy()
+	override.MaxAttempts = 2
+	client.sendCommand(WithRetryPolicy(context.Background(), override), `SELECT * FROM "users";`)
+
+	if clientPolicy.Budget.tokens != 10 {
+		t.Error("expected client policy to be unused")
+	}
+	if override.Budget.tokens != 9 {
+		t.Errorf("expected 1 retry with override, tokens left: %v", override.Budget.tokens)
+	}
+
+	// nil disables retries for the call
+	client.sendCommand(WithRetryPolicy(context.Background(), nil), `SELECT * FROM "users";`)
+	if clientPolicy.Budget.tokens != 10 {
+		t.Error("expected no retries with nil override")
+	}
+}
+
+// TestRetryBudgetExhaustion verifies retries stop when the budget is empty.
+func TestRetryBudgetExhaustion(t *testing.T) {
+	budget := NewRetryBudget(0.5, 1)
+
+	if !budget.withdraw() {
+		t.Fatal("expected initial burst token")
+	}
+	if budget.withdraw() {
+		t.Error("expected budget to be exhausted")
+	}
+
+	budget.deposit()
+	budget.deposit()
+	if !budget.withdraw() {
+		t.Error("expected deposits to refill budget")
+	}
+}
+
+// TestRetryPolicyBackoff verifies exponential growth, capping and jitter bounds.
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("retry %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(1)
+		if delay < 50*time.Millisecond || delay > 150*time.Millisecond {
+			t.Fatalf("jittered delay %v outside expected range", delay)
+		}
+	}
+}
+
+// TestRetryContextCancellation verifies backoff waits stop when ctx is cancelled.
+func TestRetryContextCancellation(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.InitialBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+	opts := DefaultOptions()
+	opts.RetryPolicy = policy
+	client := NewClient(&opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.sendCommand(ctx, `SELECT * FROM "users";`); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+// TestErrorCode verifies codes are extracted through wrapped errors.
+func TestErrorCode(t *testing.T) {
+	wrapped := fmt.Errorf("wrapped: %w", &ProtocolError{Code: "SEND_FAILED"})
+	if code := ErrorCode(wrapped); code != "SEND_FAILED" {
+		t.Errorf("expected SEND_FAILED, got %q", code)
+	}
+
+	stmtErr := &StatementError{QueryError: QueryError{Code: "E_PREPARE_FAILED"}}
+	if code := ErrorCode(stmtErr); code != "E_PREPARE_FAILED" {
+		t.Errorf("expected E_PREPARE_FAILED, got %q", code)
+	}
+
+	if code := ErrorCode(fmt.Errorf("plain")); code != "" {
+		t.Errorf("expected empty code, got %q", code)
+	}
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// defaultStreamBatchSize is the batch size used by QueryStream when none is given.
+const defaultStreamBatchSize = 500
+
+// errStreamClosed ends the delivery of partial frames to a closed RowStream.
+var errStreamClosed = errors.New("row stream closed")
+
+// RowStream delivers the rows of a query result in batches, so consumers can
+// process and hand off large results incrementally instead of as one value.
+// When the server sends the result in several frames, rows are available as
+// each frame arrives, and only rows not yet delivered are held; otherwise the
+// complete response is read first, bounded by ClientOptions.MaxResponseBytes
+// and MaxRowsInMemory. A RowStream is safe for concurrent use.
+type RowStream struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	rows      []interface{} // Received rows not yet delivered
+	total     int
+	delivered int
+	batchSize int
+	streamed  bool          // Rows arrived in partial frames
+	ready     chan struct{} // Closed when the first rows arrive or the query ends
+	done      bool
+	err       error
+	closed    bool
+	cancel    context.CancelFunc
+}
+
+// QueryStream executes query and returns its rows as a RowStream of batches
+// of at most batchSize rows (default 500 when batchSize <= 0).
+// A response that is not a list of rows is delivered as a single row.
+// QueryStream returns once the first rows arrive; an error after that ends
+// the stream and is reported by Err. The command is not retried, since its
+// rows may already have been delivered.
+func (c *Client) QueryStream(ctx context.Context, query string, batchSize int) (*RowStream, error) {
+	if c.stateMgr.GetState() != CONNECTED {
+		return nil, ErrInvalidState("QueryStream", CONNECTED, c.stateMgr.GetState())
+	}
+
+	s := newRowStream(nil, batchSize)
+	s.ready = make(chan struct{})
+	ctx, s.cancel = context.WithCancel(ctx)
+	ctx = context.WithValue(WithRetryPolicy(ctx, nil), partialRowsKey{}, s.push)
+	go func() {
+		result, err := c.sendCommand(ctx, query)
+		s.finish(result, err)
+	}()
+
+	<-s.ready
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil && !s.streamed {
+		return nil, s.err
+	}
+	return s, nil
+}
+
+// NewRowStream returns a RowStream over rows, e.g. for results already in memory.
+func NewRowStream(rows []interface{}, batchSize int) *RowStream {
+	s := newRowStream(rows, batchSize)
+	s.done = true
+	return s
+}
+
+func newRowStream(rows []interface{}, batchSize int) *RowStream {
+	if batchSize <= 0 {
+		batchSize = defaultStreamBatchSize
+	}
+	s := &RowStream{rows: rows, total: len(rows), batchSize: batchSize, cancel: func() {}}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// push adds the rows of a partial frame.
+func (s *RowStream) push(rows []interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errStreamClosed
+	}
+	if !s.streamed {
+		s.streamed = true
+		close(s.ready)
+	}
+	s.rows = append(s.rows, rows...)
+	s.total += len(rows)
+	s.cond.Broadcast()
+	return nil
+}
+
+// finish adds the rows of the final frame, or records the error that ended
+// the query.
+func (s *RowStream) finish(result interface{}, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.cancel()
+
+	if err == nil && !s.closed {
+		var rows []interface{}
+		if s.streamed {
+			rows = resultRows(mergeRows([]interface{}{}, result))
+		} else {
+			rows = resultRows(result)
+		}
+		s.rows = append(s.rows, rows...)
+		s.total += len(rows)
+	}
+	if !errors.Is(err, errStreamClosed) {
+		s.err = err
+	}
+	s.done = true
+	if !s.streamed {
+		close(s.ready)
+	}
+	s.cond.Broadcast()
+}
+
+// NextBatch returns the next batch of rows, or nil once the stream is
+// exhausted or closed. While the server is still sending the result, it
+// waits for rows and may return fewer than the batch size.
+func (s *RowStream) NextBatch() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for !s.closed && !s.done && len(s.rows) == 0 {
+		s.cond.Wait()
+	}
+	if s.closed || len(s.rows) == 0 {
+		return nil
+	}
+	n := s.batchSize
+	if n > len(s.rows) {
+		n = len(s.rows)
+	}
+	batch := s.rows[:n:n]
+	s.rows = s.rows[n:]
+	s.delivered += n
+	return batch
+}
+
+// Total returns the number of rows in the result, or received so far while
+// the server is still sending it.
+func (s *RowStream) Total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.total
+}
+
+// Delivered returns the number of rows returned by NextBatch so far.
+func (s *RowStream) Delivered() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.delivered
+}
+
+// Err returns the error that ended the stream early, if any. It is set once
+// NextBatch has returned nil.
+func (s *RowStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close ends the stream and releases its rows. NextBatch returns nil
+// afterwards. The rest of a result still being sent is read and discarded.
+func (s *RowStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.rows = nil
+	s.cond.Broadcast()
+}
+
+// resultRows returns the rows of a query response: a top-level array, the
+// "Result" array of an object, or the response itself as a single row.
+func resultRows(result interface{}) []interface{} {
+	switch v := result.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		if rows, ok := v["Result"].([]interface{}); ok {
+			return rows
+		}
+	}
+	return []interface{}{result}
+}
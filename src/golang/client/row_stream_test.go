@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+// TestRowStreamBatches verifies rows are delivered in order in batches of the requested size.
+func TestRowStreamBatches(t *testing.T) {
+	rows := []interface{}{1, 2, 3, 4, 5}
+	stream := NewRowStream(rows, 2)
+
+	var sizes []int
+	var delivered []interface{}
+	for batch := stream.NextBatch(); batch != nil; batch = stream.NextBatch() {
+		sizes = append(sizes, len(batch))
+		delivered = append(delivered, batch...)
+	}
+
+	if len(sizes) != 3 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 1 {
+		t.Errorf("expected batches of 2, 2, 1, got %v", sizes)
+	}
+	for i, row := range delivered {
+		if row != rows[i] {
+			t.Fatalf("expected rows in order, got %v", delivered)
+		}
+	}
+	if stream.Total() != 5 || stream.Delivered() != 5 {
+		t.Errorf("expected 5 total and delivered, got %d and %d", stream.Total(), stream.Delivered())
+	}
+}
+
+// TestRowStreamClose verifies a closed stream delivers no more rows.
+func TestRowStreamClose(t *testing.T) {
+	stream := NewRowStream([]interface{}{1, 2, 3}, 1)
+	stream.NextBatch()
+	stream.Close()
+
+	if batch := stream.NextBatch(); batch != nil {
+		t.Errorf("expected no batch after Close, got %v", batch)
+	}
+	if stream.Total() != 3 || stream.Delivered() != 1 {
+		t.Errorf("expected 3 total and 1 delivered, got %d and %d", stream.Total(), stream.Delivered())
+	}
+}
+
+// TestQueryStream verifies QueryStream streams the "Result" rows of a response.
+func TestQueryStream(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"Result":[{"id":1},{"id":2},{"id":3}],"ResultCount":3}`
+	})
+
+	stream, err := c.QueryStream(context.Background(), `SELECT * FROM "users";`, 0)
+	if err != nil {
+		t.Fatalf("QueryStream failed: %v", err)
+	}
+	if stream.Total() != 3 {
+		t.Errorf("expected 3 rows, got %d", stream.Total())
+	}
+	if batch := stream.NextBatch(); len(batch) != 3 {
+		t.Errorf("expected one batch with the default size, got %d rows", len(batch))
+	}
+}
+
+// TestResultRows verifies rows are extracted from each response shape.
+func TestResultRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		result interface{}
+		want   int
+	}{
+		{"nil", nil, 0},
+		{"array", []interface{}{1, 2}, 2},
+		{"result object", map[string]interface{}{"Result": []interface{}{1, 2, 3}}, 3},
+		{"single object", map[string]interface{}{"status": "ok"}, 1},
+		{"message", "3 documents", 1},
+	}
+	for _, tt := range tests {
+		if got := len(resultRows(tt.result)); got != tt.want {
+			t.Errorf("%s: expected %d rows, got %d", tt.name, tt.want, got)
+		}
+	}
+}
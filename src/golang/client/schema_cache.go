@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// SchemaCache stores the schema that builders are validated against. Each
+// client has its own in-memory cache unless ClientOptions.SchemaCache is set;
+// clients given the same cache fetch the schema once between them and see
+// each other's invalidations. Clients sharing a cache must use the same
+// database.
+type SchemaCache interface {
+	// Get returns the cached schema and when it was fetched, or a nil schema
+	// if none is cached.
+	Get(ctx context.Context) (*schema.SchemaDefinition, time.Time, error)
+
+	// Set caches a schema fetched at fetchedAt.
+	Set(ctx context.Context, schemaDef *schema.SchemaDefinition, fetchedAt time.Time) error
+
+	// Invalidate drops the cached schema.
+	Invalidate(ctx context.Context) error
+}
+
+// MemorySchemaCache is a SchemaCache held in memory. Share one between the
+// clients of a process by setting it as their ClientOptions.SchemaCache.
+type MemorySchemaCache struct {
+	mu        sync.RWMutex
+	schemaDef *schema.SchemaDefinition
+	fetchedAt time.Time
+}
+
+// NewMemorySchemaCache returns an empty in-memory schema cache.
+func NewMemorySchemaCache() *MemorySchemaCache {
+	return &MemorySchemaCache{}
+}
+
+// Get implements SchemaCache.
+func (m *MemorySchemaCache) Get(ctx context.Context) (*schema.SchemaDefinition, time.Time, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.schemaDef, m.fetchedAt, nil
+}
+
+// Set implements SchemaCache.
+func (m *MemorySchemaCache) Set(ctx context.Context, schemaDef *schema.SchemaDefinition, fetchedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schemaDef, m.fetchedAt = schemaDef, fetchedAt
+	return nil
+}
+
+// Invalidate implements SchemaCache.
+func (m *MemorySchemaCache) Invalidate(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schemaDef = nil
+	return nil
+}
+
+// SchemaStore is a key-value store, such as Redis or memcached, that holds a
+// schema cache shared between processes. See NewStoreSchemaCache.
+type SchemaStore interface {
+	// Get returns the value of key, or nil if it is not set.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set sets the value of key.
+	Set(ctx context.Context, key string, value []byte) error
+
+	// Delete removes key.
+	Delete(ctx context.Context, key string) error
+}
+
+// DefaultSchemaStoreKey is the key StoreSchemaCache uses unless given another.
+const DefaultSchemaStoreKey = "syndrdb:schema"
+
+// StoreSchemaCache is a SchemaCache kept as JSON under one key of a
+// SchemaStore, so a fleet of processes fetches the schema once after a
+// deployment instead of once per client. Every validation reads the store.
+type StoreSchemaCache struct {
+	store SchemaStore
+	key   string
+}
+
+// NewStoreSchemaCache returns a cache kept in store under key, or under
+// DefaultSchemaStoreKey if key is "". Use a key per database.
+func NewStoreSchemaCache(store SchemaStore, key string) *StoreSchemaCache {
+	if key == "" {
+		key = DefaultSchemaStoreKey
+	}
+	return &StoreSchemaCache{store: store, key: key}
+}
+
+// storedSchema is the JSON form of a StoreSchemaCache entry.
+type storedSchema struct {
+	FetchedAt time.Time                `json:"fetchedAt"`
+	Schema    *schema.SchemaDefinition `json:"schema"`
+}
+
+// Get implements SchemaCache.
+func (s *StoreSchemaCache) Get(ctx context.Context) (*schema.SchemaDefinition, time.Time, error) {
+	data, err := s.store.Get(ctx, s.key)
+	if err != nil || data == nil {
+		return nil, time.Time{}, err
+	}
+	var entry storedSchema
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, time.Time{}, err
+	}
+	return entry.Schema, entry.FetchedAt, nil
+}
+
+// Set implements SchemaCache.
+func (s *StoreSchemaCache) Set(ctx context.Context, schemaDef *schema.SchemaDefinition, fetchedAt time.Time) error {
+	data, err := json.Marshal(storedSchema{FetchedAt: fetchedAt, Schema: schemaDef})
+	if err != nil {
+		return err
+	}
+	return s.store.Set(ctx, s.key, data)
+}
+
+// Invalidate implements SchemaCache.
+func (s *StoreSchemaCache) Invalidate(ctx context.Context) error {
+	return s.store.Delete(ctx, s.key)
+}
+
+// InvalidateSchemaCache drops the cached schema, so the next validated
+// builder fetches it again. With a shared SchemaCache, every client using
+// it refetches; call it after schema changes made outside this client.
+func (c *Client) InvalidateSchemaCache(ctx context.Context) error {
+	if c.schemaValidator == nil {
+		return nil
+	}
+	return c.schemaValidator.cache.Invalidate(ctx)
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// TestSharedSchemaCache verifies clients sharing a cache fetch the schema once
+// and see each other's invalidations.
+func TestSharedSchemaCache(t *testing.T) {
+	respond := func(command string) string {
+		if command == showBundlesCommand {
+			return showBundlesResponse
+		}
+		return `{"success":true,"data":{"Result":[]}}`
+	}
+	a, serverA := newPipeClient(t, respond)
+	b, serverB := newPipeClient(t, respond)
+	shared := NewMemorySchemaCache()
+	a.schemaValidator.cache = shared
+	b.schemaValidator.cache = shared
+
+	ctx := context.Background()
+	query := func(c *Client) {
+		t.Helper()
+		if _, err := c.QueryBuilder().Select("users").WithValidation(true).Where("name", Equals, "Ada").Execute(ctx); err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+	}
+	countFetches := func(server *pipeServer) int {
+		n := 0
+		for _, command := range server.received() {
+			if command == showBundlesCommand {
+				n++
+			}
+		}
+		return n
+	}
+
+	query(a)
+	query(b)
+	if fa, fb := countFetches(serverA), countFetches(serverB); fa != 1 || fb != 0 {
+		t.Errorf("expected one fetch by the first client, got %d and %d", fa, fb)
+	}
+
+	// DDL on one client invalidates the schema for both
+	if _, err := b.Mutate(`DROP BUNDLE "posts";`, 1000); err != nil {
+		t.Fatalf("DDL failed: %v", err)
+	}
+	query(a)
+	if fa := countFetches(serverA); fa != 2 {
+		t.Errorf("expected a refetch after DDL on another client, got %d fetches", fa)
+	}
+
+	if err := a.InvalidateSchemaCache(ctx); err != nil {
+		t.Fatalf("InvalidateSchemaCache failed: %v", err)
+	}
+	if _, cached := b.schemaValidator.cacheAge(); cached {
+		t.Error("expected InvalidateSchemaCache to drop the shared schema")
+	}
+}
+
+// mapStore is a SchemaStore backed by a map.
+type mapStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (s *mapStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key], nil
+}
+
+func (s *mapStore) Set(ctx context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values == nil {
+		s.values = make(map[string][]byte)
+	}
+	s.values[key] = value
+	return nil
+}
+
+func (s *mapStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+// TestStoreSchemaCache verifies the schema round-trips through a SchemaStore.
+func TestStoreSchemaCache(t *testing.T) {
+	store := &mapStore{}
+	cache := NewStoreSchemaCache(store, "")
+	ctx := context.Background()
+
+	if def, _, err := cache.Get(ctx); def != nil || err != nil {
+		t.Fatalf("expected an empty cache, got %v (%v)", def, err)
+	}
+
+	fetchedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	def := &schema.SchemaDefinition{Bundles: []schema.BundleDefinition{{
+		Name:   "users",
+		Fields: []schema.FieldDefinition{{Name: "email", Type: schema.STRING, Required: true}},
+	}}}
+	if err := cache.Set(ctx, def, fetchedAt); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, ok := store.values[DefaultSchemaStoreKey]; !ok {
+		t.Errorf("expected the schema under %s", DefaultSchemaStoreKey)
+	}
+
+	got, gotAt, err := cache.Get(ctx)
+	if err != nil || !gotAt.Equal(fetchedAt) {
+		t.Fatalf("unexpected Get result %v %v (%v)", got, gotAt, err)
+	}
+	if len(got.Bundles) != 1 || got.Bundles[0].Fields[0].Name != "email" || !got.Bundles[0].Fields[0].Required {
+		t.Errorf("schema did not round-trip: %+v", got)
+	}
+
+	if err := cache.Invalidate(ctx); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+	if def, _, _ := cache.Get(ctx); def != nil {
+		t.Error("expected Invalidate to remove the schema")
+	}
+}
+
+// TestSchemaNotifications verifies a pushed schema change invalidates the
+// cache and runs OnSchemaChange handlers.
+func TestSchemaNotifications(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	subscribed := make(chan net.Conn, 1)
+	unsubscribed := make(chan struct{})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for first := true; ; first = false {
+					command, err := reader.ReadString('\x04')
+					if err != nil {
+						return
+					}
+					response := `{"success":true,"data":{}}` + "\n"
+					if first {
+						response = "S0001::Welcome\n" + `{"status":"success"}` + "\n"
+					}
+					if _, err := conn.Write([]byte(response)); err != nil {
+						return
+					}
+					if strings.TrimSuffix(command, "\x04") == subscribeSchemaCommand {
+						subscribed <- conn
+						reader.ReadString('\x04') // Blocks until the client closes the subscription
+						close(unsubscribed)
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.SchemaNotifications = true
+	c := NewClient(&opts)
+	changes := make(chan string, 1)
+	c.OnSchemaChange(func(command string) { changes <- command })
+	if err := c.Connect(context.Background(), "syndrdb://"+ln.Addr().String()+":primary:root:root;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	c.schemaValidator.setSchema(&schema.SchemaDefinition{})
+
+	var sub net.Conn
+	select {
+	case sub = <-subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not subscribe to schema notifications")
+	}
+	ddl := `ALTER BUNDLE "users" ADD FIELD "age" INT;`
+	sub.Write([]byte(`{"success":true,"data":{"event":"schema_changed","command":"ALTER BUNDLE \"users\" ADD FIELD \"age\" INT;"}}` + "\n"))
+
+	select {
+	case command := <-changes:
+		if command != ddl {
+			t.Errorf("expected handler to receive %q, got %q", ddl, command)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnSchemaChange handler was not called")
+	}
+	if _, cached := c.schemaValidator.cacheAge(); cached {
+		t.Error("expected the notification to invalidate the schema cache")
+	}
+
+	c.Disconnect(context.Background())
+	select {
+	case <-unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Disconnect to close the subscription")
+	}
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// subscribeSchemaCommand asks the server to push schema change notifications
+// on the connection it is sent on.
+const subscribeSchemaCommand = "SUBSCRIBE SCHEMA;"
+
+const (
+	// schemaResubscribeMinBackoff and schemaResubscribeMaxBackoff bound the
+	// delay before a lost subscription is renewed.
+	schemaResubscribeMinBackoff = 100 * time.Millisecond
+	schemaResubscribeMaxBackoff = 30 * time.Second
+)
+
+// startSchemaSubscription subscribes to schema change notifications until
+// done is closed, renewing the subscription when its connection is lost.
+// Servers without the schema_notifications feature are not subscribed to.
+func (c *Client) startSchemaSubscription(done <-chan struct{}) {
+	if err := c.requireFeature(FeatureSchemaNotifications); err != nil {
+		c.logger.Warn("schema notifications unavailable", Error("error", err))
+		return
+	}
+	go func() {
+		backoff := schemaResubscribeMinBackoff
+		for resubscribe := false; ; resubscribe = true {
+			subscribed, err := c.watchSchema(done, resubscribe)
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if subscribed {
+				backoff = schemaResubscribeMinBackoff
+			}
+			c.logger.Warn("schema notification subscription lost, resubscribing",
+				Error("error", err),
+				Duration("retryIn", backoff))
+
+			select {
+			case <-done:
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, schemaResubscribeMaxBackoff)
+		}
+	}()
+}
+
+// watchSchema subscribes on a dedicated connection and handles notifications
+// until the connection fails or done is closed. subscribed reports whether
+// the subscription was established. After a resubscription the schema cache
+// is invalidated, as changes made in between were missed.
+func (c *Client) watchSchema(done <-chan struct{}, resubscribe bool) (subscribed bool, err error) {
+	conn, err := c.subscribeSchema()
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// Closing the connection ends the read below when the client disconnects
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-done:
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	c.logger.Debug("subscribed to schema notifications", String("remoteAddr", conn.RemoteAddr()))
+	if resubscribe && c.schemaValidator != nil {
+		c.schemaValidator.InvalidateCache()
+	}
+
+	for {
+		notification, err := conn.ReceiveResponse(context.Background())
+		if err != nil {
+			return true, err
+		}
+		command := schemaNotificationCommand(notification)
+		c.logger.Debug("schema change notification", String("command", command))
+		c.schemaChanged(command, "")
+		c.invalidateQueryCache(command)
+	}
+}
+
+// subscribeSchema opens a connection and sends SUBSCRIBE SCHEMA on it.
+func (c *Client) subscribeSchema() (ConnectionInterface, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.opts.DefaultTimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	conn, err := c.connFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.SendCommand(ctx, subscribeSchemaCommand); err == nil {
+		_, err = conn.ReceiveResponse(ctx)
+	}
+	if err == nil {
+		// Notifications may be hours apart
+		if d, ok := conn.(interface{ clearDeadline() error }); ok {
+			err = d.clearDeadline()
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, &ConnectionError{
+			Code:    "E_SCHEMA_SUBSCRIBE_FAILED",
+			Type:    "CONNECTION_ERROR",
+			Message: "failed to subscribe to schema notifications",
+			Cause:   err,
+		}
+	}
+	return conn, nil
+}
+
+// schemaNotificationCommand returns the DDL command a notification reports,
+// e.g. {"event":"schema_changed","command":"DROP BUNDLE \"users\";"}, or ""
+// if it does not say.
+func schemaNotificationCommand(notification interface{}) string {
+	if m, ok := notification.(map[string]interface{}); ok {
+		command, _ := m["command"].(string)
+		return command
+	}
+	return ""
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 	"sync"
 	"time"
@@ -13,9 +12,8 @@ import (
 // SchemaValidator provides schema-based validation for QueryBuilder operations.
 type SchemaValidator struct {
 	client      *Client
-	schema      *schema.SchemaDefinition
-	schemaMu    sync.RWMutex
-	lastFetch   time.Time
+	cache       SchemaCache
+	fetchMu     sync.Mutex // Serializes fetches, so concurrent misses fetch once
 	cacheTTL    time.Duration
 	autoRefresh bool
 }
@@ -24,6 +22,7 @@ type SchemaValidator struct {
 func NewSchemaValidator(client *Client, cacheTTL time.Duration, autoRefresh bool) *SchemaValidator {
 	return &SchemaValidator{
 		client:      client,
+		cache:       NewMemorySchemaCache(),
 		cacheTTL:    cacheTTL,
 		autoRefresh: autoRefresh,
 	}
@@ -31,79 +30,60 @@ func NewSchemaValidator(client *Client, cacheTTL time.Duration, autoRefresh bool
 
 // fetchSchema retrieves the schema from the server using SHOW BUNDLES.
 func (sv *SchemaValidator) fetchSchema(ctx context.Context) error {
-	// Query for schema
-	query := "SHOW BUNDLES;"
-	result, err := sv.client.Query(query, 0)
-	if err != nil {
-		return &QueryError{
-			Code:    "E_SCHEMA_FETCH_FAILED",
-			Type:    "QueryError",
-			Message: "failed to fetch schema",
-			Cause:   err,
-		}
-	}
+	_, err := sv.client.GetSchema(ctx)
+	return err
+}
 
-	// Parse schema from response
-	// Convert result to JSON bytes for parsing
-	var responseBytes []byte
-	switch v := result.(type) {
-	case string:
-		responseBytes = []byte(v)
-	case []byte:
-		responseBytes = v
-	default:
-		// Try JSON marshaling
-		responseBytes, err = json.Marshal(v)
-		if err != nil {
-			return &QueryError{
-				Code:    "E_SCHEMA_PARSE_FAILED",
-				Type:    "QueryError",
-				Message: "failed to marshal schema response",
-				Cause:   err,
-			}
-		}
+// setSchema caches a freshly fetched schema.
+func (sv *SchemaValidator) setSchema(parsedSchema *schema.SchemaDefinition) {
+	if err := sv.cache.Set(context.Background(), parsedSchema, time.Now()); err != nil {
+		sv.client.logger.Warn("failed to cache schema", Error("error", err))
 	}
+}
 
-	parsedSchema, err := schema.ParseServerSchema(responseBytes)
+// cached returns the cached schema, or nil if none is cached, it has expired
+// or the cache cannot be read.
+func (sv *SchemaValidator) cached(ctx context.Context) *schema.SchemaDefinition {
+	schemaDefn, fetchedAt, err := sv.cache.Get(ctx)
 	if err != nil {
-		return &QueryError{
-			Code:    "E_SCHEMA_PARSE_FAILED",
-			Type:    "QueryError",
-			Message: "failed to parse schema response",
-			Cause:   err,
-		}
+		sv.client.logger.Warn("failed to read schema cache", Error("error", err))
+		return nil
 	}
+	if schemaDefn == nil || time.Since(fetchedAt) > sv.cacheTTL {
+		return nil
+	}
+	return schemaDefn
+}
 
-	sv.schemaMu.Lock()
-	sv.schema = parsedSchema
-	sv.lastFetch = time.Now()
-	sv.schemaMu.Unlock()
-
-	return nil
+// cacheAge returns how long ago the cached schema was fetched, and false if
+// no schema is cached.
+func (sv *SchemaValidator) cacheAge() (time.Duration, bool) {
+	schemaDefn, fetchedAt, err := sv.cache.Get(context.Background())
+	if err != nil || schemaDefn == nil {
+		return 0, false
+	}
+	return time.Since(fetchedAt), true
 }
 
 // getSchema returns the cached schema, fetching it if necessary or expired.
 func (sv *SchemaValidator) getSchema(ctx context.Context) (*schema.SchemaDefinition, error) {
-	sv.schemaMu.RLock()
-	needsFetch := sv.schema == nil || time.Since(sv.lastFetch) > sv.cacheTTL
-	sv.schemaMu.RUnlock()
-
-	if needsFetch {
-		if err := sv.fetchSchema(ctx); err != nil {
-			return nil, err
-		}
+	if schemaDefn := sv.cached(ctx); schemaDefn != nil {
+		return schemaDefn, nil
 	}
 
-	sv.schemaMu.RLock()
-	defer sv.schemaMu.RUnlock()
-	return sv.schema, nil
+	sv.fetchMu.Lock()
+	defer sv.fetchMu.Unlock()
+	if schemaDefn := sv.cached(ctx); schemaDefn != nil {
+		return schemaDefn, nil
+	}
+	return sv.client.GetSchema(ctx)
 }
 
 // InvalidateCache forces a schema refresh on the next validation.
 func (sv *SchemaValidator) InvalidateCache() {
-	sv.schemaMu.Lock()
-	sv.schema = nil
-	sv.schemaMu.Unlock()
+	if err := sv.cache.Invalidate(context.Background()); err != nil {
+		sv.client.logger.Warn("failed to invalidate schema cache", Error("error", err))
+	}
 }
 
 // DetectDDL checks if a query contains DDL operations that require schema refresh.
@@ -154,6 +134,11 @@ func (sv *SchemaValidator) ValidateQuery(bundle string, fields []string, whereCl
 
 	// Validate WHERE clause fields
 	for _, clause := range whereClauses {
+		// Raw conditions are the caller's responsibility, and EXISTS has no field
+		if clause.raw != nil || clause.operator == Exists || clause.operator == NotExists {
+			continue
+		}
+
 		// Handle dot-notation for relationship traversal
 		if strings.Contains(clause.field, ".") {
 			// TODO: Validate relationship traversal
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SearchOption configures a full-text condition added by Search.
+type SearchOption func(*searchOptions)
+
+type searchOptions struct {
+	rank            bool
+	highlight       bool
+	preTag, postTag string
+}
+
+// RankByRelevance selects the match score as _score and orders the results by
+// it, best match first, ahead of any ORDER BY added after Search.
+func RankByRelevance() SearchOption {
+	return func(o *searchOptions) {
+		o.rank = true
+	}
+}
+
+// Highlight selects the field with matched terms wrapped in preTag and
+// postTag, e.g. "<em>" and "</em>", as _highlight.
+func Highlight(preTag, postTag string) SearchOption {
+	return func(o *searchOptions) {
+		o.highlight = true
+		o.preTag, o.postTag = preTag, postTag
+	}
+}
+
+// Search adds a full-text condition, with an implicit AND connector,
+// matching documents whose field matches query under the server's search
+// syntax. The field needs a fulltext index:
+//
+//	c.QueryBuilder().Select("articles").
+//		Search("body", "distributed databases", client.RankByRelevance()).
+//		Limit(10)
+//
+// renders SELECT *, SCORE(body) AS _score FROM articles WHERE body MATCH
+// 'distributed databases' ORDER BY _score DESC LIMIT 10. The _score and
+// _highlight aliases are fixed, so rank and highlight one Search per query.
+func (qb *QueryBuilder) Search(field, query string, opts ...SearchOption) *QueryBuilder {
+	var options searchOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.rank {
+		qb.searchSelects = append(qb.searchSelects, rawExpr{sql: "SCORE(" + field + ") AS _score"})
+		qb.orderBys = append(qb.orderBys, orderByClause{raw: &rawExpr{sql: "_score DESC"}})
+	}
+	if options.highlight {
+		qb.searchSelects = append(qb.searchSelects, rawExpr{
+			sql:  "HIGHLIGHT(" + field + ", ?, ?) AS _highlight",
+			args: []interface{}{options.preTag, options.postTag},
+		})
+	}
+	return qb.Where(field, Match, query)
+}
+
+// validateSearchClause checks the operand of a MATCH condition.
+func validateSearchClause(clause whereClause) error {
+	if query, ok := clause.value.(string); ok && strings.TrimSpace(query) != "" {
+		return nil
+	}
+	return &QueryError{
+		Code:    "E_INVALID_QUERY",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("invalid MATCH condition on %s: the search query must be a non-empty string", clause.field),
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestQueryBuilder_Search(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		qb       *QueryBuilder
+		expected string
+	}{
+		{
+			"plain",
+			c.QueryBuilder().Select("articles").Search("body", "it's fast"),
+			`SELECT * FROM articles WHERE body MATCH 'it''s fast';`,
+		},
+		{
+			"ranked",
+			c.QueryBuilder().Select("articles").
+				Search("body", "distributed databases", RankByRelevance()).
+				OrderBy("published", Descending).
+				Limit(10),
+			`SELECT *, SCORE(body) AS _score FROM articles WHERE body MATCH 'distributed databases' ORDER BY _score DESC, published DESC LIMIT 10;`,
+		},
+		{
+			"highlighted",
+			c.QueryBuilder().Select("articles", "title").
+				Where("status", Equals, "published").
+				Search("body", "raft", Highlight("<em>", "</em>")),
+			`SELECT title, HIGHLIGHT(body, '<em>', '</em>') AS _highlight FROM articles WHERE status == 'published' AND body MATCH 'raft';`,
+		},
+	}
+	for _, tt := range tests {
+		if _, err := tt.qb.Execute(ctx); err != nil {
+			t.Fatalf("%s: Execute failed: %v", tt.name, err)
+		}
+	}
+	for i, got := range server.received() {
+		if got != tests[i].expected {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", tests[i].name, tests[i].expected, got)
+		}
+	}
+}
+
+func TestQueryBuilder_SearchValidation(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	ctx := context.Background()
+
+	for _, qb := range []*QueryBuilder{
+		c.QueryBuilder().Select("articles").Search("body", "  "),
+		c.QueryBuilder().Select("articles").Where("body", Match, 42),
+	} {
+		if _, err := qb.Execute(ctx); ErrorCode(err) != "E_INVALID_QUERY" {
+			t.Errorf("expected E_INVALID_QUERY, got %v", err)
+		}
+	}
+	if len(server.received()) != 0 {
+		t.Error("expected nothing to be sent")
+	}
+}
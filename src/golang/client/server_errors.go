@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// serverStatusError converts a {"status": "error", ...} response into a
+// QueryError for query. The error code is the server's "code" if present,
+// otherwise the code classified from the message, otherwise E_SERVER_ERROR.
+// Returns nil if result is not an error-status response.
+func serverStatusError(result interface{}, query string) *QueryError {
+	payload, ok := result.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	status, _ := payload["status"].(string)
+	if !strings.EqualFold(status, "error") {
+		return nil
+	}
+
+	code, _ := payload["code"].(string)
+	if code == "" {
+		code = ClassifyServerError(payload)
+	}
+	if code == "" {
+		code = "E_SERVER_ERROR"
+	}
+
+	message := "server returned an error"
+	for _, key := range []string{"message", "error"} {
+		if value, ok := payload[key]; ok && value != nil {
+			message = fmt.Sprintf("%v", value)
+			break
+		}
+	}
+
+	return &QueryError{
+		Code:    code,
+		Type:    "QueryError",
+		Message: message,
+		Details: payload,
+		Query:   query,
+	}
+}
+
+// checkServerStatus returns result unchanged, or the error it carries if it
+// is an error-status response to query.
+func checkServerStatus(result interface{}, err error, query string) (interface{}, error) {
+	if err != nil {
+		return result, err
+	}
+	if queryErr := serverStatusError(result, query); queryErr != nil {
+		return nil, queryErr
+	}
+	return result, nil
+}
+
+// txStatusError wraps an error-status response received inside transaction
+// txID in a TransactionError carrying the server code and statement.
+func txStatusError(queryErr *QueryError, txID string) *TransactionError {
+	return &TransactionError{
+		Code:    queryErr.Code,
+		Type:    "TransactionError",
+		Message: queryErr.Message,
+		Details: map[string]interface{}{
+			"query":    queryErr.Query,
+			"response": queryErr.Details,
+		},
+		TransactionID: txID,
+		State:         "active",
+		Cause:         queryErr,
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestServerStatusError verifies error-status responses become QueryErrors instead of results.
+func TestServerStatusError(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		if strings.HasPrefix(command, "SELECT") {
+			return `{"status":"error","code":"E_BUNDLE_NOT_FOUND","message":"bundle not found: users"}`
+		}
+		return `{"status":"ERROR","message":"unexpected token near WHERE"}`
+	})
+
+	query := `SELECT * FROM "users";`
+	result, err := c.Query(query, 1000)
+	if result != nil {
+		t.Errorf("expected no result, got %v", result)
+	}
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) {
+		t.Fatalf("expected QueryError, got %T: %v", err, err)
+	}
+	if queryErr.Code != "E_BUNDLE_NOT_FOUND" || queryErr.Message != "bundle not found: users" || queryErr.Query != query {
+		t.Errorf("unexpected error: %+v", queryErr)
+	}
+	if !errors.Is(err, ErrBundleNotFound) {
+		t.Error("expected error to match ErrBundleNotFound")
+	}
+
+	// Without a code the message is classified
+	_, err = c.Mutate(`DELETE DOCUMENTS FROM "users" WHERE;`, 1000)
+	if ErrorCode(err) != "E_SYNTAX_ERROR" || !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("expected E_SYNTAX_ERROR, got %v", err)
+	}
+}
+
+// TestServerStatusErrorFallback verifies unrecognized errors get E_SERVER_ERROR and successes pass through.
+func TestServerStatusErrorFallback(t *testing.T) {
+	if err := serverStatusError(map[string]interface{}{"status": "success", "data": 1}, "SHOW BUNDLES;"); err != nil {
+		t.Errorf("expected no error for a success response, got %v", err)
+	}
+	if err := serverStatusError("Transaction started", "BEGIN TRANSACTION;"); err != nil {
+		t.Errorf("expected no error for a text response, got %v", err)
+	}
+
+	err := serverStatusError(map[string]interface{}{"status": "error", "error": "disk full"}, "SHOW BUNDLES;")
+	if err == nil || err.Code != "E_SERVER_ERROR" || err.Message != "disk full" {
+		t.Errorf("unexpected error: %+v", err)
+	}
+}
+
+// TestTransactionServerStatusError verifies error-status responses inside a transaction become TransactionErrors.
+func TestTransactionServerStatusError(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		if strings.HasPrefix(command, "BEGIN") {
+			return beginResponder(command)
+		}
+		return `{"status":"error","code":"E_FIELD_NOT_FOUND","message":"field not found: age"}`
+	})
+
+	tx, err := c.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE DOCUMENTS IN BUNDLE "users" (age = 3) WHERE "id" == 1;`
+	_, err = tx.Query(query, 1000)
+	var txErr *TransactionError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("expected TransactionError, got %T: %v", err, err)
+	}
+	if txErr.Code != "E_FIELD_NOT_FOUND" || txErr.TransactionID != tx.ID() || txErr.Details["query"] != query {
+		t.Errorf("unexpected error: %+v", txErr)
+	}
+	if !errors.Is(err, ErrFieldNotFound) {
+		t.Error("expected error to match ErrFieldNotFound")
+	}
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Features a server may list in the "features" capability of its
+// authentication response.
+const (
+	FeaturePreparedStatements  = "prepared_statements"
+	FeatureTransactions        = "transactions"
+	FeatureTLS                 = "tls"
+	FeatureExplain             = "explain"
+	FeatureSchemaNotifications = "schema_notifications"
+)
+
+// ServerInfo describes the server a client is connected to, as advertised
+// during authentication.
+type ServerInfo struct {
+	// Version is the server version, or "" if the server did not report one.
+	Version string
+
+	// Features lists the advertised features. Nil means the server predates
+	// feature advertisement, in which case every feature is assumed available.
+	Features []string
+
+	// IsolationLevels lists the transaction isolation levels that may be
+	// requested explicitly with BeginWithIsolation.
+	IsolationLevels []IsolationLevel
+
+	// Compression lists the advertised response compression algorithms.
+	Compression []string
+
+	// TLS reports whether the connection is encrypted.
+	TLS bool
+}
+
+// Supports reports whether the server offers feature. Servers that do not
+// advertise features are assumed to support everything.
+func (s *ServerInfo) Supports(feature string) bool {
+	if s == nil || s.Features == nil {
+		return true
+	}
+	for _, f := range s.Features {
+		if strings.EqualFold(f, feature) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseServerInfo extracts server information from an authentication response.
+func parseServerInfo(authData map[string]interface{}, tls bool) *ServerInfo {
+	info := &ServerInfo{
+		IsolationLevels: serverIsolationLevels(authData),
+		Compression:     stringList(authData["compression"]),
+		TLS:             tls,
+	}
+	if version, ok := authData["version"].(string); ok {
+		info.Version = version
+	}
+	if _, ok := authData["features"]; ok {
+		info.Features = stringList(authData["features"])
+		if info.Features == nil {
+			info.Features = []string{}
+		}
+	}
+	return info
+}
+
+// stringList converts a JSON string or array of strings to a slice.
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// ServerInfo returns information about the connected server, or nil before
+// the first successful connection.
+func (c *Client) ServerInfo() *ServerInfo {
+	c.serverInfoMu.RLock()
+	defer c.serverInfoMu.RUnlock()
+	if c.serverInfo == nil {
+		return nil
+	}
+	info := *c.serverInfo
+	// Copy the slices so callers cannot modify the cached info; Clone keeps
+	// nil and empty Features distinct
+	info.Features = slices.Clone(info.Features)
+	info.IsolationLevels = slices.Clone(info.IsolationLevels)
+	info.Compression = slices.Clone(info.Compression)
+	return &info
+}
+
+// setServerInfo records the information from the latest handshake.
+func (c *Client) setServerInfo(info *ServerInfo) {
+	c.serverInfoMu.Lock()
+	c.serverInfo = info
+	c.serverInfoMu.Unlock()
+}
+
+// requireFeature returns E_FEATURE_UNSUPPORTED if the server advertised
+// features without feature.
+func (c *Client) requireFeature(feature string) error {
+	c.serverInfoMu.RLock()
+	info := c.serverInfo
+	c.serverInfoMu.RUnlock()
+
+	if info.Supports(feature) {
+		return nil
+	}
+	return ErrFeatureUnsupported(feature, info.Version)
+}
+
+// ErrFeatureUnsupported creates an error for a feature the server does not offer.
+func ErrFeatureUnsupported(feature, serverVersion string) *ProtocolError {
+	version := serverVersion
+	if version == "" {
+		version = "unknown"
+	}
+	return &ProtocolError{
+		Code:    "E_FEATURE_UNSUPPORTED",
+		Type:    "PROTOCOL_ERROR",
+		Message: fmt.Sprintf("server (version %s) does not support %s", version, feature),
+		Details: map[string]interface{}{
+			"feature":        feature,
+			"server_version": serverVersion,
+		},
+	}
+}
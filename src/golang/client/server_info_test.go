@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+// TestParseServerInfo verifies version, features and capabilities are read from the auth response.
+func TestParseServerInfo(t *testing.T) {
+	info := parseServerInfo(map[string]interface{}{
+		"status":           "success",
+		"version":          "1.4.0",
+		"features":         []interface{}{"transactions", "TLS"},
+		"isolation_levels": []interface{}{"SERIALIZABLE"},
+		"compression":      "gzip",
+	}, true)
+
+	if info.Version != "1.4.0" || !info.TLS {
+		t.Errorf("unexpected server info: %+v", info)
+	}
+	if !info.Supports(FeatureTransactions) || !info.Supports(FeatureTLS) || info.Supports(FeaturePreparedStatements) {
+		t.Errorf("unexpected feature support: %v", info.Features)
+	}
+	if len(info.IsolationLevels) != 1 || info.IsolationLevels[0] != Serializable {
+		t.Errorf("unexpected isolation levels: %v", info.IsolationLevels)
+	}
+	if len(info.Compression) != 1 || info.Compression[0] != "gzip" {
+		t.Errorf("unexpected compression: %v", info.Compression)
+	}
+
+	// Servers that don't advertise features are assumed to support everything
+	legacy := parseServerInfo(map[string]interface{}{"status": "success"}, false)
+	if legacy.Features != nil || !legacy.Supports(FeaturePreparedStatements) {
+		t.Errorf("expected legacy server to support all features: %+v", legacy)
+	}
+
+	// An empty feature list supports nothing
+	none := parseServerInfo(map[string]interface{}{"features": []interface{}{}}, false)
+	if none.Supports(FeatureTransactions) {
+		t.Error("expected an empty feature list to support nothing")
+	}
+}
+
+// TestServerInfoFromHandshake verifies ServerInfo is captured on connect.
+func TestServerInfoFromHandshake(t *testing.T) {
+	c := NewClient(nil)
+	if c.ServerInfo() != nil {
+		t.Error("expected no server info before connecting")
+	}
+
+	server := newCompressionServer(t, []string{"gzip"}, `{"success": true}`)
+	c = connectCompressionClient(t, server, CompressionNone)
+
+	info := c.ServerInfo()
+	if info == nil || len(info.Compression) != 1 || info.Compression[0] != "gzip" || info.TLS {
+		t.Fatalf("unexpected server info: %+v", info)
+	}
+	if c.GetDebugInfo()["server"] == nil {
+		t.Error("expected server info in debug info")
+	}
+
+	info.Compression[0] = "zstd"
+	if got := c.ServerInfo().Compression[0]; got != "gzip" {
+		t.Errorf("expected the cached server info to be unaffected, got %q", got)
+	}
+}
+
+// TestFeatureGating verifies unsupported features fail with E_FEATURE_UNSUPPORTED before any command is sent.
+func TestFeatureGating(t *testing.T) {
+	c, server := newPipeClient(t, beginResponder)
+	c.setServerInfo(&ServerInfo{Version: "0.9.0", Features: []string{}})
+	ctx := context.Background()
+
+	if _, err := c.Begin(ctx); ErrorCode(err) != "E_FEATURE_UNSUPPORTED" {
+		t.Errorf("expected E_FEATURE_UNSUPPORTED from Begin, got %v", err)
+	}
+	if _, err := c.Prepare(ctx, "find_user", "SELECT * FROM \"users\" WHERE \"id\" == $1;"); ErrorCode(err) != "E_FEATURE_UNSUPPORTED" {
+		t.Errorf("expected E_FEATURE_UNSUPPORTED from Prepare, got %v", err)
+	}
+	if got := server.received(); len(got) != 0 {
+		t.Errorf("expected no commands to be sent, got %v", got)
+	}
+
+	c.setServerInfo(&ServerInfo{Version: "1.0.0", Features: []string{FeatureTransactions}})
+	if _, err := c.Begin(ctx); err != nil {
+		t.Errorf("expected Begin to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Session gives a sequence of commands read-your-writes consistency in
+// pooled mode. After a write succeeds in the session, the session keeps the
+// connection it ran on and sends its later commands there until Window has
+// passed since the last write, so reads cannot land on a connection or
+// replica that has not seen the write. Bind a session to a context with
+// WithSession:
+//
+//	session := c.NewSession()
+//	defer session.Close()
+//	ctx = client.WithSession(ctx, session)
+//	c.InsertBuilder("orders").Values(order).Execute(ctx)
+//	c.QueryBuilder().Select("orders").Where("id", client.Equals, id).Execute(ctx) // same connection
+//
+// Commands in a session run one at a time. A single-connection client is
+// always consistent, so sessions do nothing there. Transactions and prepared
+// statements use their own connections.
+type Session struct {
+	client *Client
+	window time.Duration
+
+	// turn is held while a command of the session runs
+	turn chan struct{}
+
+	mu          sync.Mutex
+	pinned      ConnectionInterface
+	pinnedUntil time.Time
+	timer       *time.Timer
+	closed      bool
+}
+
+// NewSession returns a session pinning connections for
+// ClientOptions.ReadYourWritesWindow after each write.
+func (c *Client) NewSession() *Session {
+	return c.NewSessionWithWindow(c.opts.ReadYourWritesWindow)
+}
+
+// NewSessionWithWindow returns a session pinning connections for window
+// after each write. A window <= 0 never pins.
+func (c *Client) NewSessionWithWindow(window time.Duration) *Session {
+	return &Session{
+		client: c,
+		window: window,
+		turn:   make(chan struct{}, 1),
+	}
+}
+
+type sessionKey struct{}
+
+// WithSession returns a context whose commands run in session.
+func WithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// SessionFromContext returns the session bound to ctx with WithSession, or nil.
+func SessionFromContext(ctx context.Context) *Session {
+	session, _ := ctx.Value(sessionKey{}).(*Session)
+	return session
+}
+
+// Pinned reports whether the session currently holds a connection.
+func (s *Session) Pinned() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pinned != nil && time.Now().Before(s.pinnedUntil)
+}
+
+// Close returns the pinned connection, if any, to the pool. Later commands
+// in the session run as if it had no writes.
+func (s *Session) Close() {
+	s.turn <- struct{}{}
+	defer func() { <-s.turn }()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.unpinLocked()
+}
+
+// acquire waits for the session's turn and returns the connection for its
+// next command: the pinned connection while the window lasts, otherwise one
+// from the pool. Every successful acquire must be followed by release.
+func (s *Session) acquire(ctx context.Context) (ConnectionInterface, error) {
+	select {
+	case s.turn <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	s.mu.Lock()
+	if s.pinned != nil && s.pinned.IsAlive() && time.Now().Before(s.pinnedUntil) {
+		conn := s.pinned
+		s.mu.Unlock()
+		return conn, nil
+	}
+	s.unpinLocked()
+	s.mu.Unlock()
+
+	conn, err := s.client.pool.Get(ctx)
+	if err != nil {
+		<-s.turn
+		return nil, err
+	}
+	return conn, nil
+}
+
+// release ends the session's turn. After a successful write the connection
+// is pinned for the window; otherwise a connection that is not pinned goes
+// back to the pool.
+func (s *Session) release(conn ConnectionInterface, wrote bool) {
+	defer func() { <-s.turn }()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if wrote && s.window > 0 && !s.closed && conn.IsAlive() {
+		if s.pinned != conn {
+			s.unpinLocked()
+			s.pinned = conn
+		}
+		s.pinnedUntil = time.Now().Add(s.window)
+		if s.timer == nil {
+			s.timer = time.AfterFunc(s.window, s.expire)
+		} else {
+			s.timer.Reset(s.window)
+		}
+		return
+	}
+	if conn != s.pinned {
+		s.client.pool.Put(conn)
+	} else if !conn.IsAlive() {
+		s.unpinLocked()
+	}
+}
+
+// expire returns the pinned connection to the pool once the window has
+// passed without further writes.
+func (s *Session) expire() {
+	s.turn <- struct{}{}
+	defer func() { <-s.turn }()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pinned != nil && !time.Now().Before(s.pinnedUntil) {
+		s.unpinLocked()
+	}
+}
+
+// unpinLocked returns the pinned connection to the pool. Must be called
+// with s.mu locked.
+func (s *Session) unpinLocked() {
+	if s.pinned == nil {
+		return
+	}
+	s.client.pool.Put(s.pinned)
+	s.pinned = nil
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSessionPoolClient connects a pooled client to a server whose responses
+// report which connection served the command.
+func newSessionPoolClient(t *testing.T) *Client {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var accepted atomic.Int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			id := accepted.Add(1)
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for first := true; ; first = false {
+					if _, err := reader.ReadString('\x04'); err != nil {
+						return
+					}
+					response := fmt.Sprintf(`{"success":true,"data":{"conn":%d}}`, id) + "\n"
+					if first {
+						response = "S0001::Welcome\n" + `{"status":"success"}` + "\n"
+					}
+					if _, err := conn.Write([]byte(response)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.PoolMinSize = 3
+	opts.PoolMaxSize = 3
+	c := NewClient(&opts)
+	if err := c.Connect(context.Background(), "syndrdb://"+ln.Addr().String()+":primary:root:root;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect(context.Background()) })
+	return c
+}
+
+// servedBy returns the connection that served command on ctx.
+func servedBy(t *testing.T, c *Client, ctx context.Context, command string) float64 {
+	t.Helper()
+	result, err := c.sendCommand(ctx, command)
+	if err != nil {
+		t.Fatalf("%s failed: %v", command, err)
+	}
+	return result.(map[string]interface{})["conn"].(float64)
+}
+
+// TestSessionReadYourWrites verifies reads after a write in a session use the
+// write's connection, which other commands cannot take meanwhile.
+func TestSessionReadYourWrites(t *testing.T) {
+	c := newSessionPoolClient(t)
+	session := c.NewSession()
+	defer session.Close()
+	ctx := WithSession(context.Background(), session)
+
+	servedBy(t, c, ctx, `SELECT * FROM BUNDLE "orders";`)
+	if session.Pinned() {
+		t.Error("expected a read not to pin the session")
+	}
+
+	writer := servedBy(t, c, ctx, `ADD DOCUMENT TO BUNDLE "orders" WITH ({"id" = 1});`)
+	if !session.Pinned() {
+		t.Fatal("expected a write to pin the session")
+	}
+	for i := 0; i < 5; i++ {
+		if got := servedBy(t, c, ctx, `SELECT * FROM BUNDLE "orders";`); got != writer {
+			t.Errorf("read %d: expected connection %v, got %v", i, writer, got)
+		}
+		if got := servedBy(t, c, context.Background(), `SELECT * FROM BUNDLE "orders";`); got == writer {
+			t.Errorf("read %d: expected commands outside the session to avoid the pinned connection", i)
+		}
+	}
+
+	session.Close()
+	if session.Pinned() {
+		t.Error("expected Close to unpin the session")
+	}
+	if idle := idleConnections(c); idle != 3 {
+		t.Errorf("expected the pinned connection back in the pool, %d idle", idle)
+	}
+}
+
+// TestSessionWindow verifies the pin ends once the window passes without writes.
+func TestSessionWindow(t *testing.T) {
+	c := newSessionPoolClient(t)
+	session := c.NewSessionWithWindow(50 * time.Millisecond)
+	ctx := WithSession(context.Background(), session)
+
+	servedBy(t, c, ctx, `UPDATE DOCUMENTS IN BUNDLE "orders" ("status" = "paid") WHERE ("id" == 1);`)
+	if !session.Pinned() {
+		t.Fatal("expected a write to pin the session")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for idleConnections(c) != 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the connection to return to the pool after the window")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if session.Pinned() {
+		t.Error("expected the session to be unpinned after the window")
+	}
+
+	unpinned := c.NewSessionWithWindow(0)
+	servedBy(t, c, WithSession(context.Background(), unpinned), `DELETE DOCUMENTS FROM BUNDLE "orders" WHERE ("id" == 1);`)
+	if unpinned.Pinned() {
+		t.Error("expected a zero window never to pin")
+	}
+}
+
+// idleConnections returns the number of idle connections in c's pool.
+func idleConnections(c *Client) int32 {
+	stats := c.pool.Stats()
+	return stats.IdleConnections.Load()
+}
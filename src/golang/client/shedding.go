@@ -0,0 +1,164 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// shedSampleCapacity bounds the latency samples kept for the p95.
+	shedSampleCapacity = 1024
+
+	// shedMinSamples is how many recent samples are needed before commands
+	// are shed, so a single slow command cannot trigger shedding.
+	shedMinSamples = 20
+
+	// shedRecomputeInterval is how often the p95 is recomputed.
+	shedRecomputeInterval = 100 * time.Millisecond
+)
+
+// LoadSheddingStats is a snapshot of the client's load shedder.
+type LoadSheddingStats struct {
+	Enabled   bool
+	Threshold time.Duration
+
+	// P95 is the 95th percentile latency of commands completed within the
+	// window, or zero with fewer than the minimum number of samples.
+	P95      time.Duration
+	Samples  int
+	Shedding bool // Whether low-priority commands are being rejected
+
+	Shed int64 // Commands rejected with E_OVERLOADED
+}
+
+// latencySample is the latency of one command and when it completed.
+type latencySample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// loadShedder rejects low-priority commands while the rolling p95 latency
+// of recent commands exceeds LoadSheddingThreshold.
+type loadShedder struct {
+	threshold time.Duration
+	window    time.Duration
+
+	mu         sync.Mutex
+	samples    []latencySample // Ring buffer of up to shedSampleCapacity samples
+	next       int
+	p95        time.Duration
+	count      int // Samples within the window at the last recompute
+	computedAt time.Time
+
+	shed atomic.Int64
+}
+
+// newLoadShedder returns a shedder for opts, or nil if shedding is disabled.
+func newLoadShedder(opts *ClientOptions) *loadShedder {
+	if opts.LoadSheddingThreshold <= 0 {
+		return nil
+	}
+	window := opts.LoadSheddingWindow
+	if window <= 0 {
+		window = 10 * time.Second
+	}
+	return &loadShedder{
+		threshold: opts.LoadSheddingThreshold,
+		window:    window,
+		samples:   make([]latencySample, 0, shedSampleCapacity),
+	}
+}
+
+// record adds the latency of a completed command.
+func (s *loadShedder) record(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sample := latencySample{at: time.Now(), latency: latency}
+	if len(s.samples) < shedSampleCapacity {
+		s.samples = append(s.samples, sample)
+		return
+	}
+	s.samples[s.next] = sample
+	s.next = (s.next + 1) % shedSampleCapacity
+}
+
+// admit fails with E_OVERLOADED when a command of priority p should be shed.
+// Only commands below PriorityNormal are shed.
+func (s *loadShedder) admit(p QueryPriority) error {
+	if p >= PriorityNormal {
+		return nil
+	}
+	p95, _ := s.percentile()
+	if p95 <= s.threshold {
+		return nil
+	}
+	s.shed.Add(1)
+	return overloadedError(p95, s.threshold)
+}
+
+// percentile returns the p95 latency within the window and the number of
+// samples it is based on, recomputing it at most every shedRecomputeInterval.
+func (s *loadShedder) percentile() (time.Duration, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.computedAt) < shedRecomputeInterval {
+		return s.p95, s.count
+	}
+	s.computedAt = now
+
+	cutoff := now.Add(-s.window)
+	latencies := make([]time.Duration, 0, len(s.samples))
+	for _, sample := range s.samples {
+		if sample.at.After(cutoff) {
+			latencies = append(latencies, sample.latency)
+		}
+	}
+	s.count = len(latencies)
+	s.p95 = 0
+	if len(latencies) >= shedMinSamples {
+		slices.Sort(latencies)
+		s.p95 = latencies[(len(latencies)*95+99)/100-1]
+	}
+	return s.p95, s.count
+}
+
+// stats returns a snapshot of the shedder.
+func (s *loadShedder) stats() LoadSheddingStats {
+	p95, samples := s.percentile()
+	return LoadSheddingStats{
+		Enabled:   true,
+		Threshold: s.threshold,
+		P95:       p95,
+		Samples:   samples,
+		Shedding:  p95 > s.threshold,
+		Shed:      s.shed.Load(),
+	}
+}
+
+// overloadedError reports that a low-priority command was shed.
+func overloadedError(p95, threshold time.Duration) *ConnectionError {
+	return &ConnectionError{
+		Code:    "E_OVERLOADED",
+		Type:    "CONNECTION_ERROR",
+		Message: fmt.Sprintf("low-priority command shed: p95 latency %v exceeds %v", p95, threshold),
+		Details: map[string]interface{}{
+			"p95":       p95.String(),
+			"threshold": threshold.String(),
+		},
+	}
+}
+
+// LoadSheddingStats returns a snapshot of the load shedder configured with
+// LoadSheddingThreshold. Enabled is false without a threshold.
+func (c *Client) LoadSheddingStats() LoadSheddingStats {
+	if c.shedder == nil {
+		return LoadSheddingStats{}
+	}
+	return c.shedder.stats()
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestLoadShedderPercentile verifies only low-priority commands are shed
+// while the p95 exceeds the threshold.
+func TestLoadShedderPercentile(t *testing.T) {
+	s := newLoadShedder(&ClientOptions{LoadSheddingThreshold: 100 * time.Millisecond})
+
+	for i := 0; i < 100; i++ {
+		s.record(10 * time.Millisecond)
+	}
+	if err := s.admit(PriorityLow); err != nil {
+		t.Fatalf("expected fast commands not to shed, got %v", err)
+	}
+
+	// 10 slow commands in 110 put the p95 above the threshold
+	for i := 0; i < 10; i++ {
+		s.record(time.Second)
+	}
+	s.computedAt = time.Time{}
+	err := s.admit(PriorityLow)
+	if ErrorCode(err) != "E_OVERLOADED" || !errors.Is(err, ErrOverloaded) {
+		t.Fatalf("expected E_OVERLOADED, got %v", err)
+	}
+	for _, p := range []QueryPriority{PriorityNormal, PriorityHigh} {
+		if err := s.admit(p); err != nil {
+			t.Errorf("expected %s priority to be admitted, got %v", p, err)
+		}
+	}
+
+	stats := s.stats()
+	if !stats.Shedding || stats.P95 != time.Second || stats.Samples != 110 || stats.Shed != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+// TestLoadShedderWindow verifies samples expire, so shedding stops once the
+// slow commands leave the window, and too few samples never shed.
+func TestLoadShedderWindow(t *testing.T) {
+	s := newLoadShedder(&ClientOptions{LoadSheddingThreshold: time.Millisecond, LoadSheddingWindow: time.Minute})
+
+	for i := 0; i < shedMinSamples-1; i++ {
+		s.record(time.Second)
+	}
+	if err := s.admit(PriorityLow); err != nil {
+		t.Fatalf("expected no shedding below %d samples, got %v", shedMinSamples, err)
+	}
+
+	s.record(time.Second)
+	s.computedAt = time.Time{}
+	if err := s.admit(PriorityLow); err == nil {
+		t.Fatal("expected shedding")
+	}
+
+	for i := range s.samples {
+		s.samples[i].at = s.samples[i].at.Add(-2 * time.Minute)
+	}
+	s.computedAt = time.Time{}
+	if err := s.admit(PriorityLow); err != nil {
+		t.Errorf("expected shedding to stop once samples expire, got %v", err)
+	}
+}
+
+// TestClientShedsLowPriority verifies shed commands never reach the server
+// and are counted by MetricsHook.
+func TestClientShedsLowPriority(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"ok":true}` })
+	c.shedder = newLoadShedder(&ClientOptions{LoadSheddingThreshold: 100 * time.Millisecond})
+	for i := 0; i < shedMinSamples; i++ {
+		c.shedder.record(time.Second)
+	}
+	metrics := NewMetricsHook()
+	c.RegisterHook(metrics)
+
+	ctx := context.Background()
+	if _, err := c.sendCommand(WithQueryPriority(ctx, PriorityLow), "REPORT"); ErrorCode(err) != "E_OVERLOADED" {
+		t.Fatalf("expected E_OVERLOADED, got %v", err)
+	}
+	if _, err := c.sendCommand(WithQueryPriority(ctx, PriorityHigh), "LOOKUP"); err != nil {
+		t.Fatalf("expected high priority to run, got %v", err)
+	}
+
+	if got := server.received(); len(got) != 1 || got[0] != "LOOKUP" {
+		t.Errorf("expected only the high-priority command to reach the server, got %v", got)
+	}
+	if metrics.TotalOverloaded.Load() != 1 {
+		t.Errorf("expected 1 overloaded command, got %d", metrics.TotalOverloaded.Load())
+	}
+	c.shedder.computedAt = time.Time{}
+	if stats := c.LoadSheddingStats(); stats.Samples != shedMinSamples+1 {
+		t.Errorf("expected the admitted command's latency to be recorded, got %+v", stats)
+	}
+}
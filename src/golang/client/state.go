@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 )
@@ -70,11 +71,18 @@ type StateTransition struct {
 // StateChangeHandler is called when the connection state changes.
 type StateChangeHandler func(transition StateTransition)
 
+// defaultStateHistorySize is how many recent transitions a StateManager
+// keeps unless configured with SetHistorySize.
+const defaultStateHistorySize = 32
+
 // StateManager manages connection state transitions and event handlers.
 type StateManager struct {
 	current        ConnectionState
 	lastTransition time.Time
 	handlers       []StateChangeHandler
+	history        []StateTransition // Ring buffer of recent transitions
+	historyNext    int
+	historySize    int
 	mu             sync.RWMutex
 }
 
@@ -84,6 +92,7 @@ func NewStateManager() *StateManager {
 		current:        DISCONNECTED,
 		lastTransition: time.Now(),
 		handlers:       make([]StateChangeHandler, 0),
+		historySize:    defaultStateHistorySize,
 	}
 }
 
@@ -122,6 +131,7 @@ func (sm *StateManager) TransitionTo(newState ConnectionState, err error, metada
 	// Update state
 	sm.current = newState
 	sm.lastTransition = now
+	sm.recordTransition(transition)
 
 	// Notify handlers (call without lock to prevent deadlocks)
 	handlers := make([]StateChangeHandler, len(sm.handlers))
@@ -166,6 +176,117 @@ func (sm *StateManager) GetState() ConnectionState {
 	return sm.current
 }
 
+// recordTransition adds transition to the history, replacing the oldest
+// once it is full. Must be called with sm.mu locked.
+func (sm *StateManager) recordTransition(transition StateTransition) {
+	if sm.historySize <= 0 {
+		return
+	}
+	if len(sm.history) < sm.historySize {
+		sm.history = append(sm.history, transition)
+		return
+	}
+	sm.history[sm.historyNext] = transition
+	sm.historyNext = (sm.historyNext + 1) % sm.historySize
+}
+
+// orderedHistory returns the recorded transitions, oldest first. Must be
+// called with sm.mu held.
+func (sm *StateManager) orderedHistory() []StateTransition {
+	transitions := make([]StateTransition, 0, len(sm.history))
+	transitions = append(transitions, sm.history[sm.historyNext:]...)
+	return append(transitions, sm.history[:sm.historyNext]...)
+}
+
+// SetHistorySize sets how many recent transitions are kept, keeping the most
+// recent ones already recorded. Zero or less stops recording.
+// Default: 32
+func (sm *StateManager) SetHistorySize(size int) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	history := sm.orderedHistory()
+	if size <= 0 {
+		history = nil
+	} else if len(history) > size {
+		history = history[len(history)-size:]
+	}
+	sm.history = history
+	sm.historyNext = 0
+	sm.historySize = size
+}
+
+// GetStateHistory returns up to limit of the most recent transitions, oldest
+// first. A limit of zero or less returns every recorded transition.
+func (sm *StateManager) GetStateHistory(limit int) []StateTransition {
+	return sm.QueryStateHistory(TransitionFilter{Limit: limit})
+}
+
+// QueryStateHistory returns the recorded transitions matching filter, oldest first.
+func (sm *StateManager) QueryStateHistory(filter TransitionFilter) []StateTransition {
+	sm.mu.RLock()
+	history := sm.orderedHistory()
+	sm.mu.RUnlock()
+
+	matched := history[:0]
+	for _, transition := range history {
+		if filter.Matches(transition) {
+			matched = append(matched, transition)
+		}
+	}
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[len(matched)-filter.Limit:]
+	}
+	return matched
+}
+
+// TransitionFilter selects state transitions in QueryStateHistory, e.g. to
+// see only the failures of a flapping connection. Empty fields match every
+// transition; a transition must match every non-empty field.
+type TransitionFilter struct {
+	// ErrorsOnly selects transitions caused by an error.
+	ErrorsOnly bool
+
+	// ReconnectsOnly selects transitions of automatic reconnection, whose
+	// Metadata reason is "auto_reconnect" or "reconnect_failed".
+	ReconnectsOnly bool
+
+	// States selects transitions into these states.
+	States []ConnectionState
+
+	// Since selects transitions at or after this time.
+	Since time.Time
+
+	// Limit keeps only the most recent matches. Zero keeps all.
+	Limit int
+}
+
+// Matches reports whether transition satisfies the filter, ignoring Limit.
+func (f TransitionFilter) Matches(transition StateTransition) bool {
+	if f.ErrorsOnly && transition.Error == nil {
+		return false
+	}
+	if f.ReconnectsOnly && !transition.IsReconnect() {
+		return false
+	}
+	if len(f.States) > 0 && !slices.Contains(f.States, transition.To) {
+		return false
+	}
+	if !f.Since.IsZero() && transition.Timestamp.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// IsReconnect reports whether the transition is part of an automatic reconnection.
+func (t StateTransition) IsReconnect() bool {
+	switch t.Metadata["reason"] {
+	case "auto_reconnect", "reconnect_failed":
+		return true
+	}
+	return false
+}
+
 // GetLastTransition returns the most recent state transition.
 func (sm *StateManager) GetLastTransition() StateTransition {
 	sm.mu.RLock()
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -213,3 +215,86 @@ func TestTransitionWithError(t *testing.T) {
 		t.Errorf("expected error %v, got %v", testErr, capturedError)
 	}
 }
+
+func TestStateHistory(t *testing.T) {
+	sm := NewStateManager()
+	for i := 0; i < defaultStateHistorySize; i++ {
+		sm.TransitionTo(CONNECTING, nil, nil)
+		sm.TransitionTo(DISCONNECTED, nil, nil)
+	}
+	sm.TransitionTo(CONNECTING, nil, nil)
+
+	history := sm.GetStateHistory(0)
+	if len(history) != defaultStateHistorySize {
+		t.Fatalf("expected %d transitions, got %d", defaultStateHistorySize, len(history))
+	}
+	if last := history[len(history)-1]; last.To != CONNECTING {
+		t.Errorf("expected the newest transition last, got %v", last.To)
+	}
+	for i := 1; i < len(history); i++ {
+		if history[i].Timestamp.Before(history[i-1].Timestamp) {
+			t.Fatalf("expected transitions oldest first")
+		}
+	}
+
+	recent := sm.GetStateHistory(3)
+	if len(recent) != 3 || recent[2].To != CONNECTING || recent[1].To != DISCONNECTED {
+		t.Errorf("expected the 3 most recent transitions, got %+v", recent)
+	}
+
+	sm.SetHistorySize(2)
+	if got := sm.GetStateHistory(0); len(got) != 2 || got[1].To != CONNECTING {
+		t.Errorf("expected shrinking to keep the newest transitions, got %+v", got)
+	}
+	sm.TransitionTo(DISCONNECTED, nil, nil)
+	if got := sm.GetStateHistory(0); len(got) != 2 || got[0].To != CONNECTING || got[1].To != DISCONNECTED {
+		t.Errorf("unexpected history after resizing: %+v", got)
+	}
+
+	sm.SetHistorySize(0)
+	sm.TransitionTo(CONNECTING, nil, nil)
+	if got := sm.GetStateHistory(0); len(got) != 0 {
+		t.Errorf("expected no history when disabled, got %+v", got)
+	}
+}
+
+func TestQueryStateHistory(t *testing.T) {
+	sm := NewStateManager()
+	failure := errors.New("connection refused")
+	sm.TransitionTo(CONNECTING, nil, map[string]interface{}{"reason": "user_initiated"})
+	sm.TransitionTo(CONNECTED, nil, nil)
+	sm.TransitionTo(DISCONNECTING, nil, nil)
+	sm.TransitionTo(DISCONNECTED, failure, map[string]interface{}{"reason": "error"})
+	midpoint := time.Now()
+	sm.TransitionTo(CONNECTING, nil, map[string]interface{}{"reason": "auto_reconnect"})
+	sm.TransitionTo(DISCONNECTED, failure, map[string]interface{}{"reason": "reconnect_failed"})
+
+	tests := []struct {
+		name   string
+		filter TransitionFilter
+		want   []ConnectionState
+	}{
+		{"errors", TransitionFilter{ErrorsOnly: true}, []ConnectionState{DISCONNECTED, DISCONNECTED}},
+		{"reconnects", TransitionFilter{ReconnectsOnly: true}, []ConnectionState{CONNECTING, DISCONNECTED}},
+		{"reconnect errors", TransitionFilter{ErrorsOnly: true, ReconnectsOnly: true}, []ConnectionState{DISCONNECTED}},
+		{"states", TransitionFilter{States: []ConnectionState{CONNECTED, DISCONNECTING}}, []ConnectionState{CONNECTED, DISCONNECTING}},
+		{"since", TransitionFilter{Since: midpoint}, []ConnectionState{CONNECTING, DISCONNECTED}},
+		{"limit", TransitionFilter{ErrorsOnly: true, Limit: 1}, []ConnectionState{DISCONNECTED}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sm.QueryStateHistory(tt.filter)
+			states := make([]ConnectionState, len(got))
+			for i, transition := range got {
+				states[i] = transition.To
+			}
+			if !reflect.DeepEqual(states, tt.want) {
+				t.Errorf("got %v, want %v", states, tt.want)
+			}
+		})
+	}
+
+	if last := sm.QueryStateHistory(TransitionFilter{ErrorsOnly: true, Limit: 1})[0]; last.Metadata["reason"] != "reconnect_failed" {
+		t.Errorf("expected Limit to keep the most recent match, got %+v", last)
+	}
+}
@@ -10,12 +10,21 @@ type StatementCache struct {
 	statements  sync.Map // map[string]*Statement
 	accessOrder []string
 	maxSize     int
-	stats       *CacheStats
+	stats       *statementCacheCounters
 	mu          sync.Mutex
 }
 
-// CacheStats tracks prepared statement cache performance metrics.
+// CacheStats is a snapshot of prepared statement cache performance metrics.
 type CacheStats struct {
+	Hits            int64
+	Misses          int64
+	Evictions       int64
+	TotalExecutions int64
+	CurrentSize     int64
+}
+
+// statementCacheCounters holds the live counters behind CacheStats.
+type statementCacheCounters struct {
 	Hits            atomic.Int64
 	Misses          atomic.Int64
 	Evictions       atomic.Int64
@@ -25,11 +34,14 @@ type CacheStats struct {
 
 // NewStatementCache creates a new statement cache with the specified maximum size.
 func NewStatementCache(maxSize int) *StatementCache {
+	if maxSize < 1 {
+		maxSize = 1
+	}
 	return &StatementCache{
 		statements:  sync.Map{},
 		accessOrder: make([]string, 0, maxSize),
 		maxSize:     maxSize,
-		stats:       &CacheStats{},
+		stats:       &statementCacheCounters{},
 	}
 }
 
@@ -46,27 +58,59 @@ func (c *StatementCache) Get(name string) (*Statement, bool) {
 	return value.(*Statement), true
 }
 
-// Add adds a statement to the cache, evicting LRU entry if cache is full.
-func (c *StatementCache) Add(stmt *Statement) error {
+// Statements returns the cached statements, least recently used first.
+func (c *StatementCache) Statements() []*Statement {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Check if cache is full
-	if len(c.accessOrder) >= c.maxSize {
-		// Evict least recently used
-		if err := c.evictLRU(); err != nil {
-			return err
+	statements := make([]*Statement, 0, len(c.accessOrder))
+	for _, name := range c.accessOrder {
+		if value, ok := c.statements.Load(name); ok {
+			statements = append(statements, value.(*Statement))
+		}
+	}
+	return statements
+}
+
+// Add adds a statement to the cache, evicting LRU entries if the cache is full.
+// Evicted statements are deallocated on the server via their owning connection,
+// which releases that connection back to the pool in pooled mode.
+// A cached statement with the same name is replaced and deallocated, unless
+// it shares stmt's connection, where DEALLOCATE would drop stmt instead.
+func (c *StatementCache) Add(stmt *Statement) error {
+	c.mu.Lock()
+
+	var evicted []*Statement
+	var superseded *Statement
+	if value, ok := c.statements.Load(stmt.name); ok {
+		c.statements.Delete(stmt.name)
+		c.removeFromAccessOrder(stmt.name)
+		if old := value.(*Statement); old.conn == stmt.conn {
+			superseded = old
+		} else {
+			evicted = append(evicted, old)
 		}
 	}
 
+	for len(c.accessOrder) >= c.maxSize {
+		evicted = append(evicted, c.evictLRU())
+	}
+
+	stmt.cache = c
 	c.statements.Store(stmt.name, stmt)
 	c.accessOrder = append(c.accessOrder, stmt.name)
 	c.stats.CurrentSize.Store(int64(len(c.accessOrder)))
 
-	return nil
+	c.mu.Unlock()
+
+	if superseded != nil {
+		superseded.supersede()
+	}
+	// Deallocate outside the lock; Close calls back into Remove
+	return closeStatements(evicted)
 }
 
-// Remove removes a statement from the cache.
+// Remove removes a statement from the cache without deallocating it.
 func (c *StatementCache) Remove(name string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -76,17 +120,25 @@ func (c *StatementCache) Remove(name string) {
 	c.stats.CurrentSize.Store(int64(len(c.accessOrder)))
 }
 
+// removeStatement removes stmt if it is still the cached entry for its name.
+// Used by Statement.Close so that closing a replaced statement keeps its successor.
+func (c *StatementCache) removeStatement(stmt *Statement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.statements.CompareAndDelete(stmt.name, stmt) {
+		c.removeFromAccessOrder(stmt.name)
+		c.stats.CurrentSize.Store(int64(len(c.accessOrder)))
+	}
+}
+
 // Clear removes all statements from the cache and deallocates them.
 func (c *StatementCache) Clear() error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	var lastErr error
+	var statements []*Statement
 	c.statements.Range(func(key, value interface{}) bool {
-		stmt := value.(*Statement)
-		if err := stmt.Close(); err != nil {
-			lastErr = err
-		}
+		statements = append(statements, value.(*Statement))
 		c.statements.Delete(key)
 		return true
 	})
@@ -94,45 +146,48 @@ func (c *StatementCache) Clear() error {
 	c.accessOrder = make([]string, 0, c.maxSize)
 	c.stats.CurrentSize.Store(0)
 
-	return lastErr
+	c.mu.Unlock()
+
+	return closeStatements(statements)
 }
 
-// Stats returns a copy of the cache statistics.
+// Stats returns a snapshot of the cache statistics.
 func (c *StatementCache) Stats() CacheStats {
 	return CacheStats{
-		Hits:            atomic.Int64{},
-		Misses:          atomic.Int64{},
-		Evictions:       atomic.Int64{},
-		TotalExecutions: atomic.Int64{},
-		CurrentSize:     atomic.Int64{},
+		Hits:            c.stats.Hits.Load(),
+		Misses:          c.stats.Misses.Load(),
+		Evictions:       c.stats.Evictions.Load(),
+		TotalExecutions: c.stats.TotalExecutions.Load(),
+		CurrentSize:     c.stats.CurrentSize.Load(),
 	}
 }
 
-// evictLRU evicts the least recently used statement from the cache.
-// Must be called with c.mu locked.
-func (c *StatementCache) evictLRU() error {
-	if len(c.accessOrder) == 0 {
-		return nil
-	}
-
+// evictLRU removes the least recently used statement from the cache and returns it.
+// The caller must deallocate it. Must be called with c.mu locked and a non-empty cache.
+func (c *StatementCache) evictLRU() *Statement {
 	// Get least recently used (first in order)
 	lruName := c.accessOrder[0]
+	c.accessOrder = c.accessOrder[1:]
+
+	value, _ := c.statements.LoadAndDelete(lruName)
+	c.stats.Evictions.Add(1)
 
-	// Load and deallocate
-	if value, ok := c.statements.Load(lruName); ok {
-		stmt := value.(*Statement)
+	stmt, _ := value.(*Statement)
+	return stmt
+}
+
+// closeStatements deallocates statements, returning the last error encountered.
+func closeStatements(statements []*Statement) error {
+	var lastErr error
+	for _, stmt := range statements {
+		if stmt == nil {
+			continue
+		}
 		if err := stmt.Close(); err != nil {
-			// Log but continue with eviction
-			// TODO: Use client logger when available
+			lastErr = err
 		}
 	}
-
-	// Remove from cache
-	c.statements.Delete(lruName)
-	c.accessOrder = c.accessOrder[1:]
-	c.stats.Evictions.Add(1)
-
-	return nil
+	return lastErr
 }
 
 // updateAccessOrder moves a statement to the end (most recently used).
@@ -140,19 +195,27 @@ func (c *StatementCache) updateAccessOrder(name string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.removeFromAccessOrder(name)
-	c.accessOrder = append(c.accessOrder, name)
+	// Skip statements evicted since they were loaded
+	if c.removeFromAccessOrder(name) {
+		c.accessOrder = append(c.accessOrder, name)
+	}
 }
 
 // removeFromAccessOrder removes a statement name from the access order list.
-// Must be called with c.mu locked.
-func (c *StatementCache) removeFromAccessOrder(name string) {
+// Returns false if the name was not present. Must be called with c.mu locked.
+func (c *StatementCache) removeFromAccessOrder(name string) bool {
 	for i, n := range c.accessOrder {
 		if n == name {
 			c.accessOrder = append(c.accessOrder[:i], c.accessOrder[i+1:]...)
-			break
+			return true
 		}
 	}
+	return false
+}
+
+// StatementCacheStats returns a snapshot of the client's prepared statement cache metrics.
+func (c *Client) StatementCacheStats() CacheStats {
+	return c.stmtCache.Stats()
 }
 
 // TODO: Track query fingerprints with execution counts to auto-prepare queries
@@ -0,0 +1,341 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/transport/mock"
+)
+
+// newCachedTestStatement creates a statement on its own mock connection.
+func newCachedTestStatement(name string) (*Statement, *mock.MockTransport) {
+	mockTransport := mock.NewMockTransport().WithReceiveData([]byte("OK\x04"))
+	return &Statement{
+		name: name,
+		conn: NewTransportConnection(mockTransport, "localhost:1776"),
+	}, mockTransport
+}
+
+// sentCommands returns the commands written to a mock transport.
+func sentCommands(m *mock.MockTransport) []string {
+	var commands []string
+	for _, data := range m.GetSendHistory() {
+		commands = append(commands, strings.TrimRight(string(data), "\x04"))
+	}
+	return commands
+}
+
+// TestStatementCacheEvictionDeallocates verifies evicted statements are deallocated on their own connection.
+func TestStatementCacheEvictionDeallocates(t *testing.T) {
+	cache := NewStatementCache(2)
+	released := 0
+
+	stmt1, transport1 := newCachedTestStatement("stmt_1")
+	stmt1.release = func() { released++ }
+	stmt2, transport2 := newCachedTestStatement("stmt_2")
+	stmt3, _ := newCachedTestStatement("stmt_3")
+
+	cache.Add(stmt1)
+	cache.Add(stmt2)
+	cache.Get("stmt_1") // stmt_2 becomes least recently used
+	if err := cache.Add(stmt3); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	if _, ok := cache.Get("stmt_2"); ok {
+		t.Error("expected stmt_2 to be evicted")
+	}
+	if sent := sentCommands(transport2); len(sent) != 1 || sent[0] != "DEALLOCATE stmt_2" {
+		t.Errorf("expected DEALLOCATE stmt_2 on its connection, got %v", sent)
+	}
+	if sent := sentCommands(transport1); len(sent) != 0 {
+		t.Errorf("expected no commands on stmt_1 connection, got %v", sent)
+	}
+	if !stmt2.closed || stmt1.closed {
+		t.Error("expected only the evicted statement to be closed")
+	}
+
+	// Closing a cached statement removes it and releases its pooled connection
+	stmt1.Close()
+	if _, ok := cache.Get("stmt_1"); ok {
+		t.Error("expected closed statement to be removed from cache")
+	}
+	if released != 1 {
+		t.Errorf("expected connection released once, got %d", released)
+	}
+
+	stats := cache.Stats()
+	if stats.Evictions != 1 || stats.CurrentSize != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("expected 1 hit and 2 misses, got %+v", stats)
+	}
+}
+
+// TestStatementCacheReplace verifies adding a duplicate name deallocates the old statement.
+func TestStatementCacheReplace(t *testing.T) {
+	cache := NewStatementCache(10)
+
+	old, oldTransport := newCachedTestStatement("stmt")
+	replacement, _ := newCachedTestStatement("stmt")
+
+	cache.Add(old)
+	cache.Add(replacement)
+
+	if got, _ := cache.Get("stmt"); got != replacement {
+		t.Error("expected replacement statement to be cached")
+	}
+	if !old.closed || len(sentCommands(oldTransport)) != 1 {
+		t.Error("expected replaced statement to be deallocated")
+	}
+	if cache.Stats().CurrentSize != 1 {
+		t.Errorf("expected size 1, got %d", cache.Stats().CurrentSize)
+	}
+}
+
+// TestStatementCacheReplaceSharedConnection verifies a replaced statement on
+// the replacement's connection is not deallocated, which would drop the
+// replacement.
+func TestStatementCacheReplaceSharedConnection(t *testing.T) {
+	cache := NewStatementCache(10)
+
+	old, transport := newCachedTestStatement("stmt")
+	replacement := &Statement{name: "stmt", conn: old.conn}
+
+	cache.Add(old)
+	if err := cache.Add(replacement); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	if got, _ := cache.Get("stmt"); got != replacement {
+		t.Error("expected replacement statement to be cached")
+	}
+	if !old.closed || replacement.closed {
+		t.Errorf("expected only the replaced statement closed, got old=%v replacement=%v", old.closed, replacement.closed)
+	}
+	if sent := sentCommands(transport); len(sent) != 0 {
+		t.Errorf("expected nothing sent on the shared connection, got %q", sent)
+	}
+}
+
+// TestPrepareReplacesStatement verifies preparing a cached name again
+// deallocates the old statement before the new one is prepared.
+func TestPrepareReplacesStatement(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+	ctx := context.Background()
+
+	old, err := c.Prepare(ctx, "by_id", `SELECT * FROM BUNDLE "users" WHERE "id" == $1;`)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	stmt, err := c.Prepare(ctx, "by_id", `SELECT * FROM BUNDLE "orders" WHERE "id" == $1;`)
+	if err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if _, err := stmt.Execute(1); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	got := server.received()
+	want := []string{
+		`PREPARE by_id AS SELECT * FROM BUNDLE "users" WHERE "id" == $1;`,
+		"DEALLOCATE by_id",
+		`PREPARE by_id AS SELECT * FROM BUNDLE "orders" WHERE "id" == $1;`,
+		"EXECUTE by_id\x051",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if !old.closed {
+		t.Error("expected the replaced statement to be closed")
+	}
+	if cached, _ := c.stmtCache.Get("by_id"); cached != stmt {
+		t.Error("expected the new statement to be cached")
+	}
+}
+
+// TestStatementCacheClear verifies Clear deallocates every statement.
+func TestStatementCacheClear(t *testing.T) {
+	cache := NewStatementCache(10)
+
+	var transports []*mock.MockTransport
+	for i := 0; i < 3; i++ {
+		stmt, transport := newCachedTestStatement(fmt.Sprintf("stmt_%d", i))
+		cache.Add(stmt)
+		transports = append(transports, transport)
+	}
+
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	for i, transport := range transports {
+		if len(sentCommands(transport)) != 1 {
+			t.Errorf("statement %d not deallocated", i)
+		}
+	}
+	if cache.Stats().CurrentSize != 0 {
+		t.Errorf("expected empty cache, got %d", cache.Stats().CurrentSize)
+	}
+}
+
+// TestStatementExecuteCountsExecutions verifies cached executions are counted.
+func TestStatementExecuteCountsExecutions(t *testing.T) {
+	cache := NewStatementCache(10)
+	stmt, _ := newCachedTestStatement("stmt")
+	stmt.paramCount = 1
+	cache.Add(stmt)
+
+	if _, err := stmt.Execute(1); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if cache.Stats().TotalExecutions != 1 {
+		t.Errorf("expected 1 execution, got %d", cache.Stats().TotalExecutions)
+	}
+}
+
+// TestQueryWithParamsGeneratesValidName verifies the temporary statement name passes validation.
+func TestQueryWithParamsGeneratesValidName(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"status":"ok"}`
+	})
+
+	if _, err := c.QueryWithParams(context.Background(), `SELECT * FROM BUNDLE "users" WHERE "id" == $1;`, 1); err != nil {
+		t.Fatalf("QueryWithParams failed: %v", err)
+	}
+	commands := server.received()
+	if len(commands) < 2 || !strings.HasPrefix(commands[0], "PREPARE stmt_") || !strings.HasPrefix(commands[1], "EXECUTE stmt_") {
+		t.Errorf("expected PREPARE then EXECUTE, got %v", commands)
+	}
+}
+
+// newSlowStatement creates a statement named "slow" whose server answers
+// EXECUTE only after receiving CANCEL, or never if honorCancel is false.
+func newSlowStatement(t *testing.T, honorCancel bool) (*Statement, *Connection, chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	clientSide, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		ln.Close()
+		t.Fatalf("dial: %v", err)
+	}
+	serverSide, err := ln.Accept()
+	if err != nil {
+		ln.Close()
+		clientSide.Close()
+		t.Fatalf("accept: %v", err)
+	}
+	t.Cleanup(func() {
+		clientSide.Close()
+		serverSide.Close()
+		ln.Close()
+	})
+
+	commands := make(chan string, 10)
+	go func() {
+		reader := bufio.NewReader(serverSide)
+		for {
+			command, err := reader.ReadString('\x04')
+			if err != nil {
+				return
+			}
+			command = strings.TrimSuffix(command, "\x04")
+			commands <- command
+			switch {
+			case command == "CANCEL slow" && honorCancel:
+				serverSide.Write([]byte(`{"status":"error","error":"statement cancelled"}` + "\n"))
+			case command == "STATUS":
+				serverSide.Write([]byte(`{"status":"ok"}` + "\n"))
+			}
+		}
+	}()
+
+	opts := DefaultOptions()
+	conn := newConnection(clientSide, opts)
+	return &Statement{name: "slow", conn: conn, codec: newValueCodec(opts)}, conn, commands
+}
+
+// TestStatementTimeoutCancels verifies a statement exceeding its timeout is
+// cancelled and its connection flagged for a health check.
+func TestStatementTimeoutCancels(t *testing.T) {
+	stmt, conn, commands := newSlowStatement(t, true)
+	stmt.SetTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := stmt.Execute()
+	if ErrorCode(err) != "E_STATEMENT_TIMEOUT" || !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected E_STATEMENT_TIMEOUT, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the cancel to end the statement promptly, took %v", elapsed)
+	}
+	if got := []string{<-commands, <-commands}; got[0] != "EXECUTE slow" || got[1] != "CANCEL slow" {
+		t.Errorf("expected EXECUTE then CANCEL, got %v", got)
+	}
+	if !conn.IsAlive() {
+		t.Error("expected the connection to stay usable after the cancel was honored")
+	}
+	if !conn.takeHealthCheckFlag() {
+		t.Error("expected the connection to be flagged for a health check")
+	}
+}
+
+// TestStatementTimeoutFromContext verifies WithStatementTimeout overrides the
+// statement's timeout and an unanswered cancel gives up the connection.
+func TestStatementTimeoutFromContext(t *testing.T) {
+	saved := statementCancelGrace
+	statementCancelGrace = 50 * time.Millisecond
+	defer func() { statementCancelGrace = saved }()
+
+	stmt, conn, _ := newSlowStatement(t, false)
+	ctx := WithStatementTimeout(context.Background(), 50*time.Millisecond)
+
+	if _, err := stmt.ExecuteContext(ctx); ErrorCode(err) != "E_STATEMENT_TIMEOUT" {
+		t.Fatalf("expected E_STATEMENT_TIMEOUT, got %v", err)
+	}
+	if conn.IsAlive() {
+		t.Error("expected the connection to be discarded when the cancel went unanswered")
+	}
+}
+
+// TestPoolChecksFlaggedConnections verifies the pool pings flagged connections before reuse.
+func TestPoolChecksFlaggedConnections(t *testing.T) {
+	stmt, conn, commands := newSlowStatement(t, true)
+	pool := NewConnectionPool(func(ctx context.Context) (ConnectionInterface, error) {
+		return conn, nil
+	}, 0, 1, time.Minute, time.Minute)
+	if err := pool.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer pool.Close(context.Background())
+
+	got, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	stmt.SetTimeout(20 * time.Millisecond)
+	stmt.Execute()
+	pool.Put(got)
+
+	<-commands // EXECUTE
+	<-commands // CANCEL
+	if command := <-commands; command != "STATUS" {
+		t.Errorf("expected a health check ping, got %q", command)
+	}
+	stats := pool.Stats()
+	if stats.IdleConnections.Load() != 1 {
+		t.Errorf("expected the healthy connection back in the pool, got %d idle", stats.IdleConnections.Load())
+	}
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Column is a field reference used as a condition operand, rendered as a name
+// rather than a string. It correlates a subquery with the query around it:
+//
+//	orders := c.QueryBuilder().Select("orders", "id").
+//		Where("orders.user_id", client.Equals, client.Column("users.id"))
+//	c.QueryBuilder().Select("users").WhereExists(orders)
+//
+// A Column must be an identifier or a dot-separated path of identifiers;
+// anything else fails the query with E_INVALID_QUERY. Column operands are
+// only supported by QueryBuilder conditions.
+type Column string
+
+// columnPattern matches the field references a Column may hold.
+var columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// validate rejects a Column that would not be rendered as a single name.
+func (c Column) validate() error {
+	if columnPattern.MatchString(string(c)) {
+		return nil
+	}
+	return &QueryError{
+		Code:    "E_INVALID_QUERY",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("invalid column reference %q", string(c)),
+	}
+}
+
+// validateColumns returns the error of the first invalid Column in values.
+func validateColumns(values ...interface{}) error {
+	for _, value := range values {
+		if column, ok := value.(Column); ok {
+			if err := column.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WhereIn adds a condition, with an implicit AND connector, matching
+// documents whose field is among the values selected by subquery. The
+// subquery must select exactly one field. It is rendered inline, and its
+// parameters are numbered after those of the outer query.
+func (qb *QueryBuilder) WhereIn(field string, subquery *QueryBuilder) *QueryBuilder {
+	return qb.Where(field, In, subquery)
+}
+
+// WhereNotIn adds a condition, with an implicit AND connector, matching
+// documents whose field is not among the values selected by subquery.
+func (qb *QueryBuilder) WhereNotIn(field string, subquery *QueryBuilder) *QueryBuilder {
+	return qb.Where(field, NotIn, subquery)
+}
+
+// WhereExists adds a condition, with an implicit AND connector, matching
+// documents for which subquery returns at least one document. Use Column
+// operands in the subquery to refer to the outer document.
+func (qb *QueryBuilder) WhereExists(subquery *QueryBuilder) *QueryBuilder {
+	return qb.Where("", Exists, subquery)
+}
+
+// WhereNotExists adds a condition, with an implicit AND connector, matching
+// documents for which subquery returns no documents.
+func (qb *QueryBuilder) WhereNotExists(subquery *QueryBuilder) *QueryBuilder {
+	return qb.Where("", NotExists, subquery)
+}
+
+// validateSubquery checks a condition with a subquery operand, or an EXISTS
+// condition without one.
+func validateSubquery(clause whereClause, sub *QueryBuilder) error {
+	var problem string
+	switch {
+	case sub == nil:
+		problem = fmt.Sprintf("%s needs a subquery operand", clause.operator)
+	case sub.queryType != selectQuery || sub.bundle == "":
+		problem = "the subquery must be a SELECT with a bundle"
+	case clause.operator == In || clause.operator == NotIn:
+		if len(sub.fields)+len(sub.rawSelects)+len(sub.searchSelects) != 1 {
+			problem = fmt.Sprintf("the subquery of %s must select exactly one field", clause.operator)
+		}
+	case clause.operator != Exists && clause.operator != NotExists:
+		problem = fmt.Sprintf("%s does not accept a subquery operand", clause.operator)
+	}
+	if problem == "" {
+		return nil
+	}
+
+	target := clause.field
+	if target == "" {
+		target = "subquery"
+	}
+	return &QueryError{
+		Code:    "E_INVALID_QUERY",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("invalid condition on %s: %s", target, problem),
+	}
+}
+
+// subquery writes sub in parentheses, without its terminating semicolon. Its
+// placeholders are numbered after those already written.
+func (w *queryWriter) subquery(sub *QueryBuilder) error {
+	w.WriteString("(")
+	buf, params, err := sub.AppendQuery(w.buf, w.params)
+	if err != nil {
+		return err
+	}
+	w.buf = append(buf[:len(buf)-1], ')')
+	w.params = params
+	return nil
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilder_Subqueries(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		qb       *QueryBuilder
+		expected string
+	}{
+		{
+			"in",
+			c.QueryBuilder().Select("users").
+				Where("active", Equals, true).
+				WhereIn("id", c.QueryBuilder().Select("orders", "user_id").Where("total", GreaterThan, 100)).
+				Where("country", Equals, "DE"),
+			`SELECT * FROM users WHERE active == TRUE AND id IN (SELECT user_id FROM orders WHERE total > 100) AND country == 'DE';`,
+		},
+		{
+			"correlated exists",
+			c.QueryBuilder().Select("users", "name").
+				WhereExists(c.QueryBuilder().Select("orders", "id").
+					Where("orders.user_id", Equals, Column("users.id")).
+					Where("status", Equals, "paid")),
+			`SELECT name FROM users WHERE EXISTS (SELECT id FROM orders WHERE orders.user_id == users.id AND status == 'paid');`,
+		},
+		{
+			"not exists",
+			c.QueryBuilder().Select("users").
+				WhereNotExists(c.QueryBuilder().Select("orders").Where("orders.user_id", Equals, Column("users.id"))),
+			`SELECT * FROM users WHERE NOT EXISTS (SELECT * FROM orders WHERE orders.user_id == users.id);`,
+		},
+	}
+	for _, tt := range tests {
+		if _, err := tt.qb.Execute(ctx); err != nil {
+			t.Fatalf("%s: Execute failed: %v", tt.name, err)
+		}
+	}
+	for i, got := range server.received() {
+		if got != tests[i].expected {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", tests[i].name, tests[i].expected, got)
+		}
+	}
+}
+
+func TestQueryBuilder_SubqueryParameters(t *testing.T) {
+	c := NewClient(nil)
+	sub := c.QueryBuilder().Select("orders", "user_id").
+		Where("a", Equals, 1).Where("b", Equals, 2).Where("c", Equals, 3).Where("d", Equals, 4).Where("e", Equals, 5)
+	qb := c.QueryBuilder().Select("users").
+		Where("f", Equals, 6).Where("g", Equals, 7).Where("h", Equals, 8).Where("i", Equals, 9).
+		WhereIn("id", sub).
+		Where("j", Equals, 10)
+
+	query, params, err := qb.buildQuery()
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	expected := "SELECT * FROM users WHERE f == $1 AND g == $2 AND h == $3 AND i == $4 AND id IN " +
+		"(SELECT user_id FROM orders WHERE a == $5 AND b == $6 AND c == $7 AND d == $8 AND e == $9) AND j == $10;"
+	if query != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, query)
+	}
+	if len(params) != 10 || params[4] != 1 || params[9] != 10 {
+		t.Errorf("unexpected params %v", params)
+	}
+}
+
+func TestQueryBuilder_SubqueryValidation(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		qb   *QueryBuilder
+	}{
+		{"select all", c.QueryBuilder().Select("users").WhereIn("id", c.QueryBuilder().Select("orders"))},
+		{"no bundle", c.QueryBuilder().Select("users").WhereExists(c.QueryBuilder())},
+		{"nil", c.QueryBuilder().Select("users").WhereExists(nil)},
+		{"operator", c.QueryBuilder().Select("users").Where("id", GreaterThan, c.QueryBuilder().Select("orders", "user_id"))},
+		{"inner", c.QueryBuilder().Select("users").WhereExists(c.QueryBuilder().Select("orders").Search("notes", ""))},
+	}
+	for _, tt := range tests {
+		if _, err := tt.qb.Execute(ctx); ErrorCode(err) != "E_INVALID_QUERY" {
+			t.Errorf("%s: expected E_INVALID_QUERY, got %v", tt.name, err)
+		}
+	}
+	if len(server.received()) != 0 {
+		t.Error("expected nothing to be sent")
+	}
+}
+
+// TestQueryBuilder_ColumnValidation verifies Column operands are rendered
+// only when they are plain field references.
+func TestQueryBuilder_ColumnValidation(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	ctx := context.Background()
+
+	for _, column := range []Column{"id", "users.id", "_meta.created_at2"} {
+		if _, _, err := c.QueryBuilder().Select("orders").Where("user_id", Equals, column).buildQuery(); err != nil {
+			t.Errorf("%q: unexpected error %v", column, err)
+		}
+	}
+
+	invalid := []Column{"", "users.id OR 1 == 1", `"id"`, "1id", "users..id", "users.", "id; DROP BUNDLE users"}
+	for _, column := range invalid {
+		qb := c.QueryBuilder().Select("users").WhereExists(c.QueryBuilder().Select("orders").Where("orders.user_id", Equals, column))
+		if _, err := qb.Execute(ctx); ErrorCode(err) != "E_INVALID_QUERY" {
+			t.Errorf("%q: expected E_INVALID_QUERY, got %v", column, err)
+		}
+		raw := c.QueryBuilder().Select("users").WhereRaw("id == ?", column)
+		if _, err := raw.Execute(ctx); ErrorCode(err) != "E_INVALID_QUERY" {
+			t.Errorf("%q in a raw condition: expected E_INVALID_QUERY, got %v", column, err)
+		}
+	}
+	if len(server.received()) != 0 {
+		t.Error("expected nothing to be sent")
+	}
+
+	if got := formatParameterValue(Column("id); DROP BUNDLE users; --")); got != "'id); DROP BUNDLE users; --'" {
+		t.Errorf("expected an invalid Column to be quoted, got %s", got)
+	}
+}
+
+func TestQueryBuilder_SubqueryEncryptionAndCaching(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"Result": []}}`
+	})
+	email := NewDeterministicEncryptor(testKeys, "k1")
+	c.RegisterEncryptor("orders", "email", email)
+
+	sub := c.QueryBuilder().Select("orders", "user_id").Where("email", Equals, "alice@example.com")
+	qb := c.QueryBuilder().Select("users").WhereIn("id", sub)
+	if _, err := qb.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	ciphertext, _ := email.Encrypt("alice@example.com")
+	if command := server.received()[0]; !strings.Contains(command, ciphertext) || strings.Contains(command, "alice@") {
+		t.Errorf("expected the subquery condition to be encrypted, got %s", command)
+	}
+	if sub.whereClauses[0].value != "alice@example.com" {
+		t.Error("expected the subquery builder to be left unchanged")
+	}
+	if bundles := qb.cacheBundles(""); len(bundles) != 2 || bundles[1] != "orders" {
+		t.Errorf("expected cached results to depend on orders, got %v", bundles)
+	}
+	if qb.Fingerprint() == c.QueryBuilder().Select("users").WhereIn("id", c.QueryBuilder().Select("orders", "id")).Fingerprint() {
+		t.Error("expected the fingerprint to cover the subquery shape")
+	}
+}
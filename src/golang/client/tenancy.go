@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// tenantRegistry holds the discriminator field of each tenant-scoped bundle.
+type tenantRegistry struct {
+	mu     sync.RWMutex
+	fields map[string]string // bundle -> discriminator field
+}
+
+type tenantKey struct{}
+
+// allTenants marks a context that bypasses tenant scoping.
+type allTenants struct{}
+
+// WithTenant returns a context whose builder commands on tenant-scoped
+// bundles are restricted to tenantID. See RegisterTenantBundle.
+func WithTenant(ctx context.Context, tenantID interface{}) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// WithAllTenants returns a context whose builder commands are not scoped to a
+// tenant, for administrative work across tenants. Use it sparingly: it
+// disables the protection tenant scoping provides.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantKey{}, allTenants{})
+}
+
+// TenantFromContext returns the tenant set with WithTenant, if any.
+func TenantFromContext(ctx context.Context) (interface{}, bool) {
+	tenant := ctx.Value(tenantKey{})
+	if _, all := tenant.(allTenants); all || tenant == nil {
+		return nil, false
+	}
+	return tenant, true
+}
+
+// RegisterTenantBundle scopes bundle to the tenant of each command's context,
+// identified by field. Queries, updates and deletes built with the client's
+// builders get a field == tenant condition ANDed onto their whole WHERE
+// clause, and inserts get field set to the tenant, so a forgotten condition
+// cannot read or modify another tenant's documents:
+//
+//	c.RegisterTenantBundle("orders", "tenantId")
+//	ctx = client.WithTenant(ctx, "acme")
+//	c.QueryBuilder().Select("orders").Where("status", client.Equals, "open").Execute(ctx)
+//	// SELECT * FROM orders WHERE status == 'open' AND tenantId == 'acme';
+//
+// A builder command on a scoped bundle fails with E_TENANT_REQUIRED if its
+// context carries no tenant, unless it was made with WithAllTenants, and with
+// E_TENANT_MISMATCH if it sets field to another tenant. Raw commands,
+// prepared statements and joined bundles are not scoped. An empty field
+// removes the scoping.
+func (c *Client) RegisterTenantBundle(bundle, field string) {
+	c.tenancy.mu.Lock()
+	defer c.tenancy.mu.Unlock()
+	if field == "" {
+		delete(c.tenancy.fields, bundle)
+		return
+	}
+	if c.tenancy.fields == nil {
+		c.tenancy.fields = make(map[string]string)
+	}
+	c.tenancy.fields[bundle] = field
+}
+
+// tenantScope returns the discriminator field and tenant a command on
+// bundle must be restricted to. field is "" if the bundle is not scoped or
+// ctx was made with WithAllTenants.
+func (c *Client) tenantScope(ctx context.Context, bundle string) (field string, tenant interface{}, err error) {
+	c.tenancy.mu.RLock()
+	field = c.tenancy.fields[bundle]
+	c.tenancy.mu.RUnlock()
+	if field == "" {
+		return "", nil, nil
+	}
+	if _, all := ctx.Value(tenantKey{}).(allTenants); all {
+		return "", nil, nil
+	}
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return "", nil, &QueryError{
+			Code:    "E_TENANT_REQUIRED",
+			Type:    "QueryError",
+			Message: fmt.Sprintf("bundle %s is tenant-scoped but the context has no tenant (use WithTenant)", bundle),
+			Details: map[string]interface{}{
+				"bundle": bundle,
+				"field":  field,
+			},
+		}
+	}
+	return field, tenant, nil
+}
+
+// scopeClauses returns clauses restricted to field == tenant. AND binds
+// tighter than OR, so the condition is added to every OR-separated term:
+// a OR b AND c becomes a AND t OR b AND c AND t, i.e. (a OR b AND c) AND t.
+func scopeClauses(clauses []whereClause, field string, tenant interface{}) []whereClause {
+	condition := whereClause{field: field, operator: Equals, value: tenant, connector: And}
+	scoped := make([]whereClause, 0, len(clauses)+2)
+	for i, clause := range clauses {
+		if i > 0 && clause.connector == Or {
+			scoped = append(scoped, condition)
+		}
+		scoped = append(scoped, clause)
+	}
+	return append(scoped, condition)
+}
+
+// checkTenantValue returns E_TENANT_MISMATCH if values set field to a
+// tenant other than tenant.
+func checkTenantValue(bundle, field string, tenant interface{}, values map[string]interface{}) error {
+	value, ok := values[field]
+	if !ok || reflect.DeepEqual(value, tenant) {
+		return nil
+	}
+	return &QueryError{
+		Code:    "E_TENANT_MISMATCH",
+		Type:    "QueryError",
+		Message: fmt.Sprintf("cannot set %s.%s to %v in tenant %v", bundle, field, value, tenant),
+		Details: map[string]interface{}{
+			"bundle": bundle,
+			"field":  field,
+			"tenant": tenant,
+		},
+	}
+}
+
+// withTenant returns a copy of the builder scoped to the tenant of ctx, in
+// its subqueries too.
+func (qb *QueryBuilder) withTenant(ctx context.Context) (*QueryBuilder, error) {
+	field, tenant, err := qb.client.tenantScope(ctx, qb.bundle)
+	if err != nil {
+		return nil, err
+	}
+	whereClauses := qb.whereClauses
+	copied := false
+	for i, clause := range whereClauses {
+		sub, ok := clause.value.(*QueryBuilder)
+		if !ok || sub == nil {
+			continue
+		}
+		scopedSub, err := sub.withTenant(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !copied {
+			whereClauses = append([]whereClause(nil), whereClauses...)
+			copied = true
+		}
+		whereClauses[i].value = scopedSub
+	}
+	if field != "" {
+		whereClauses = scopeClauses(whereClauses, field, tenant)
+	}
+	scoped := *qb
+	scoped.whereClauses = whereClauses
+	return &scoped, nil
+}
+
+// withTenant returns a copy of the builder with the discriminator field set
+// to the tenant of ctx.
+func (ib *InsertBuilder) withTenant(ctx context.Context) (*InsertBuilder, error) {
+	field, tenant, err := ib.client.tenantScope(ctx, ib.bundle)
+	if err != nil || field == "" {
+		return ib, err
+	}
+	if err := checkTenantValue(ib.bundle, field, tenant, ib.values); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(ib.values)+1)
+	for k, v := range ib.values {
+		values[k] = v
+	}
+	values[field] = tenant
+	scoped := *ib
+	scoped.values = values
+	return &scoped, nil
+}
+
+// withTenant returns a copy of the builder restricted to the tenant of ctx.
+func (ub *UpdateBuilder) withTenant(ctx context.Context) (*UpdateBuilder, error) {
+	field, tenant, err := ub.client.tenantScope(ctx, ub.bundle)
+	if err != nil || field == "" {
+		return ub, err
+	}
+	if err := checkTenantValue(ub.bundle, field, tenant, ub.setFields); err != nil {
+		return nil, err
+	}
+	scoped := *ub
+	scoped.whereClauses = scopeClauses(ub.whereClauses, field, tenant)
+	return &scoped, nil
+}
+
+// withTenant returns a copy of the builder restricted to the tenant of ctx.
+func (db *DeleteBuilder) withTenant(ctx context.Context) (*DeleteBuilder, error) {
+	field, tenant, err := db.client.tenantScope(ctx, db.bundle)
+	if err != nil || field == "" {
+		return db, err
+	}
+	scoped := *db
+	scoped.whereClauses = scopeClauses(db.whereClauses, field, tenant)
+	return &scoped, nil
+}
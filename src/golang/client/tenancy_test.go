@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestTenantScoping verifies builder commands on scoped bundles are
+// restricted to the context's tenant.
+func TestTenantScoping(t *testing.T) {
+	c, server := newPipeClient(t, func(string) string {
+		return `{"success":true,"data":{"Result":[],"affected":1}}`
+	})
+	c.RegisterTenantBundle("orders", "tenantId")
+	ctx := WithTenant(context.Background(), "acme")
+
+	if _, err := c.QueryBuilder().Select("orders").
+		Where("status", Equals, "open").
+		Or("status", Equals, "held").
+		Execute(ctx); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if _, err := c.QueryBuilder().Select("users").Execute(ctx); err != nil {
+		t.Fatalf("unscoped query failed: %v", err)
+	}
+	if _, err := c.UpdateBuilder("orders").Set("status", "paid").Where("id", Equals, 1).Execute(ctx); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := c.DeleteBuilder("orders").Where("id", Equals, 1).Execute(ctx); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := c.InsertBuilder("orders").Values(map[string]interface{}{"id": 2}).WithoutTimestamps().Execute(ctx); err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+
+	received := server.received()
+	if len(received) != 5 {
+		t.Fatalf("expected 5 commands, got %q", received)
+	}
+	wantQuery := `SELECT * FROM orders WHERE status == 'open' AND tenantId == 'acme' OR status == 'held' AND tenantId == 'acme';`
+	if received[0] != wantQuery {
+		t.Errorf("expected %s, got %s", wantQuery, received[0])
+	}
+	if strings.Contains(received[1], "tenantId") {
+		t.Errorf("expected an unregistered bundle not to be scoped: %s", received[1])
+	}
+	for _, command := range received[2:4] {
+		if !strings.Contains(command, `"id" == 1 AND "tenantId" == "acme"`) {
+			t.Errorf("expected the mutation to be scoped: %s", command)
+		}
+	}
+	if !strings.Contains(received[4], `"tenantId"`) || !strings.Contains(received[4], `"acme"`) {
+		t.Errorf("expected the insert to set the tenant: %s", received[4])
+	}
+}
+
+// TestTenantRequired verifies scoped bundles fail closed without a tenant
+// and reject writes to another tenant.
+func TestTenantRequired(t *testing.T) {
+	c, server := newPipeClient(t, func(string) string { return `{"success":true,"data":{"Result":[]}}` })
+	c.RegisterTenantBundle("orders", "tenantId")
+
+	_, err := c.QueryBuilder().Select("orders").Execute(context.Background())
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) || queryErr.Code != "E_TENANT_REQUIRED" {
+		t.Errorf("expected E_TENANT_REQUIRED, got %v", err)
+	}
+
+	ctx := WithTenant(context.Background(), "acme")
+	_, err = c.UpdateBuilder("orders").Set("tenantId", "globex").Where("id", Equals, 1).Execute(ctx)
+	if !errors.As(err, &queryErr) || queryErr.Code != "E_TENANT_MISMATCH" {
+		t.Errorf("expected E_TENANT_MISMATCH, got %v", err)
+	}
+	if n := len(server.received()); n != 0 {
+		t.Errorf("expected nothing sent, got %d commands", n)
+	}
+
+	if _, err := c.QueryBuilder().Select("orders").Execute(WithAllTenants(context.Background())); err != nil {
+		t.Fatalf("cross-tenant query failed: %v", err)
+	}
+	if got := server.received(); len(got) != 1 || strings.Contains(got[0], "tenantId") {
+		t.Errorf("expected WithAllTenants to skip scoping, got %q", got)
+	}
+
+	c.RegisterTenantBundle("orders", "")
+	if _, err := c.QueryBuilder().Select("orders").Execute(context.Background()); err != nil {
+		t.Errorf("expected an unregistered bundle to need no tenant, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import "time"
+
+// TimestampPolicy makes the insert and update builders maintain creation and
+// modification timestamps, so every write path records them without each call
+// site having to. Values set explicitly on a builder are left unchanged, e.g.
+// when importing documents with their original timestamps.
+type TimestampPolicy struct {
+	// CreatedField is set on insert. Empty disables it.
+	CreatedField string
+
+	// UpdatedField is set on insert and on every update. Empty disables it.
+	UpdatedField string
+
+	// Now returns the current time. Default: time.Now
+	Now func() time.Time
+
+	// Layout formats timestamps, in UTC. Default: the client's
+	// DateTimeLayout and TimeZone, as for any time.Time value
+	Layout string
+
+	// Bundles limits the policy to these bundles. Empty applies it to all.
+	Bundles []string
+}
+
+// DefaultTimestampPolicy returns a policy maintaining createdAt and updatedAt
+// on every bundle.
+func DefaultTimestampPolicy() *TimestampPolicy {
+	return &TimestampPolicy{
+		CreatedField: "createdAt",
+		UpdatedField: "updatedAt",
+	}
+}
+
+// appliesTo reports whether the policy covers bundle.
+func (p *TimestampPolicy) appliesTo(bundle string) bool {
+	if p == nil {
+		return false
+	}
+	if len(p.Bundles) == 0 {
+		return true
+	}
+	for _, b := range p.Bundles {
+		if b == bundle {
+			return true
+		}
+	}
+	return false
+}
+
+// now returns the current timestamp, formatted if the policy has a Layout.
+func (p *TimestampPolicy) now() interface{} {
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	if p.Layout == "" {
+		return now()
+	}
+	return now().UTC().Format(p.Layout)
+}
+
+// stamp returns values with the unset timestamp fields added, without
+// modifying values. created selects whether CreatedField is set.
+func (p *TimestampPolicy) stamp(values map[string]interface{}, created bool) map[string]interface{} {
+	var fields []string
+	if created && p.CreatedField != "" {
+		fields = append(fields, p.CreatedField)
+	}
+	if p.UpdatedField != "" {
+		fields = append(fields, p.UpdatedField)
+	}
+
+	var stamped map[string]interface{}
+	var now interface{}
+	for _, field := range fields {
+		if _, set := values[field]; set {
+			continue
+		}
+		if stamped == nil {
+			stamped = make(map[string]interface{}, len(values)+len(fields))
+			for k, v := range values {
+				stamped[k] = v
+			}
+			now = p.now()
+		}
+		stamped[field] = now
+	}
+	if stamped == nil {
+		return values
+	}
+	return stamped
+}
+
+// WithoutTimestamps exempts this insert from the client's TimestampPolicy.
+func (ib *InsertBuilder) WithoutTimestamps() *InsertBuilder {
+	ib.skipTimestamps = true
+	return ib
+}
+
+// WithoutTimestamps exempts this update from the client's TimestampPolicy.
+func (ub *UpdateBuilder) WithoutTimestamps() *UpdateBuilder {
+	ub.skipTimestamps = true
+	return ub
+}
+
+// withTimestamps returns a copy of the builder with the policy's timestamps set.
+func (ib *InsertBuilder) withTimestamps() *InsertBuilder {
+	policy := ib.client.opts.Timestamps
+	if ib.skipTimestamps || !policy.appliesTo(ib.bundle) {
+		return ib
+	}
+	stamped := *ib
+	stamped.values = policy.stamp(ib.values, true)
+	return &stamped
+}
+
+// withTimestamps returns a copy of the builder with the policy's update timestamp set.
+func (ub *UpdateBuilder) withTimestamps() *UpdateBuilder {
+	policy := ub.client.opts.Timestamps
+	if ub.skipTimestamps || !policy.appliesTo(ub.bundle) {
+		return ub
+	}
+	stamped := *ub
+	stamped.setFields = policy.stamp(ub.setFields, false)
+	return &stamped
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTimestampClient(t *testing.T, policy *TimestampPolicy) (*Client, *pipeServer) {
+	t.Helper()
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	c.opts.Timestamps = policy
+	return c, server
+}
+
+func fixedClock() time.Time {
+	return time.Date(2026, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+}
+
+func TestTimestampsOnInsert(t *testing.T) {
+	policy := DefaultTimestampPolicy()
+	policy.Now = fixedClock
+	c, server := newTimestampClient(t, policy)
+	ctx := context.Background()
+
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "Ada"}).Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	command := server.received()[0]
+	for _, want := range []string{`"createdAt" =  "2026-03-01T11:30:00Z"`, `"updatedAt" =  "2026-03-01T11:30:00Z"`} {
+		if !strings.Contains(command, want) {
+			t.Errorf("expected %s in %s", want, command)
+		}
+	}
+
+	// Explicit values are kept
+	values := map[string]interface{}{"name": "Ada", "createdAt": "2020-01-01T00:00:00Z"}
+	if _, err := c.InsertBuilder("users").Values(values).Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if command := server.received()[1]; !strings.Contains(command, "2020-01-01T00:00:00Z") || strings.Contains(command, `"createdAt" =  "2026`) {
+		t.Errorf("expected the explicit createdAt to be kept: %s", command)
+	}
+	if len(values) != 2 {
+		t.Errorf("expected the caller's values to be left unmodified, got %v", values)
+	}
+
+	// Per-call opt-out
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "Ada"}).WithoutTimestamps().Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if command := server.received()[2]; strings.Contains(command, "createdAt") || strings.Contains(command, "updatedAt") {
+		t.Errorf("expected no timestamps: %s", command)
+	}
+}
+
+func TestTimestampsOnUpdate(t *testing.T) {
+	policy := &TimestampPolicy{
+		CreatedField: "created",
+		UpdatedField: "modified",
+		Now:          fixedClock,
+		Layout:       time.DateOnly,
+		Bundles:      []string{"users"},
+	}
+	c, server := newTimestampClient(t, policy)
+	ctx := context.Background()
+
+	if _, err := c.UpdateBuilder("users").Set("name", "Ada").Where("DocumentID", Equals, "u_1").Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	command := server.received()[0]
+	if !strings.Contains(command, `"modified"`) || !strings.Contains(command, "2026-03-01") || strings.Contains(command, `"created"`) {
+		t.Errorf("expected only the update timestamp: %s", command)
+	}
+
+	if _, err := c.UpdateBuilder("users").Set("name", "Ada").Where("DocumentID", Equals, "u_1").WithoutTimestamps().Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if command := server.received()[1]; strings.Contains(command, "modified") {
+		t.Errorf("expected no timestamp with WithoutTimestamps: %s", command)
+	}
+
+	// Bundles outside the policy are untouched
+	if _, err := c.UpdateBuilder("logs").Set("level", "warn").Where("DocumentID", Equals, "l_1").Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if command := server.received()[2]; strings.Contains(command, "modified") {
+		t.Errorf("expected no timestamp outside the policy's bundles: %s", command)
+	}
+}
+
+func TestTimestampsDisabledByDefault(t *testing.T) {
+	c, server := newTimestampClient(t, nil)
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{"name": "Ada"}).Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if command := server.received()[0]; strings.Contains(command, "createdAt") {
+		t.Errorf("expected no timestamps without a policy: %s", command)
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"strings"
+)
+
+type traceIDKey struct{}
+
+// WithTraceID returns a context whose commands use id as their trace ID
+// instead of a generated UUID, so driver hooks and logs can be correlated
+// with an application request ID.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// TraceIDFromContext returns the trace ID set on ctx with WithTraceID, or "".
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// annotateTrace prefixes command with a /* trace_id=... */ comment carrying
+// the caller's trace ID when ClientOptions.TraceComments is enabled.
+func (c *Client) annotateTrace(ctx context.Context, command string) string {
+	if !c.opts.TraceComments {
+		return command
+	}
+	id := TraceIDFromContext(ctx)
+	if id == "" {
+		return command
+	}
+	return "/* trace_id=" + sanitizeTraceID(id) + " */ " + command
+}
+
+// sanitizeTraceID replaces characters that could end the comment or alter
+// the command with underscores.
+func sanitizeTraceID(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '-' || r == '_' || r == '.' || r == ':':
+			return r
+		}
+		return '_'
+	}, id)
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestWithTraceID verifies the caller's trace ID reaches hooks instead of a generated one.
+func TestWithTraceID(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	var traceIDs []string
+	c.RegisterHook(&funcHook{
+		before: func(hookCtx *HookContext) { traceIDs = append(traceIDs, hookCtx.TraceID) },
+		after:  func(hookCtx *HookContext) {},
+	})
+
+	ctx := WithTraceID(context.Background(), "req-42")
+	if _, err := c.QueryBuilder().Select("users").Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if _, err := c.QueryBuilder().Select("users").Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if traceIDs[0] != "req-42" {
+		t.Errorf("expected caller trace ID, got %q", traceIDs[0])
+	}
+	if traceIDs[1] == "" || traceIDs[1] == "req-42" {
+		t.Errorf("expected a generated trace ID without WithTraceID, got %q", traceIDs[1])
+	}
+	if got := server.received()[0]; strings.HasPrefix(got, "/*") {
+		t.Errorf("expected no trace comment by default, got %s", got)
+	}
+}
+
+// TestTraceComments verifies the trace ID is sent as a sanitized comment when enabled.
+func TestTraceComments(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string { return `{"success":true}` })
+	c.opts.TraceComments = true
+
+	ctx := WithTraceID(context.Background(), "req-42*/ DROP")
+	if _, err := c.Query(`SELECT * FROM "users";`, 0); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if _, err := c.executeWithTimeout(ctx, `SELECT * FROM "users";`, 0); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	got := server.received()
+	if got[0] != `SELECT * FROM "users";` {
+		t.Errorf("expected no comment without a trace ID, got %s", got[0])
+	}
+	if want := `/* trace_id=req-42___DROP */ SELECT * FROM "users";`; got[1] != want {
+		t.Errorf("expected %s, got %s", want, got[1])
+	}
+
+	if field := RequestIDField(ctx); field.Value != "req-42*/ DROP" {
+		t.Errorf("expected RequestIDField to return the trace ID, got %v", field.Value)
+	}
+}
@@ -0,0 +1,258 @@
+// Package tracing provides an OpenTelemetry hook for the SyndrDB client.
+//
+// It lives in its own package so the core client stays free of the
+// OpenTelemetry dependency; import it only when tracing is needed:
+//
+//	c.RegisterHook(tracing.NewHook(tracing.WithDatabase("primary")))
+//
+// Each command produces a client span that is a child of the span carried by
+// the command's context. Commands executed inside a transaction are children
+// of a transaction span, which in turn is a child of the context passed to
+// Client.Begin and ends on COMMIT or ROLLBACK, or when the client releases the
+// transaction for any other reason.
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// instrumentationName identifies this package as the tracer's instrumentation scope.
+const instrumentationName = "github.com/dan-strohschein/syndrdb-drivers/src/golang/client/tracing"
+
+// Metadata keys used to pass spans from Before to After.
+const (
+	metadataSpan   = "otel_span"
+	metadataTxSpan = "otel_tx_span"
+)
+
+// Attribute keys follow the OpenTelemetry database semantic conventions.
+var (
+	attrDBSystem      = attribute.Key("db.system.name")
+	attrDBNamespace   = attribute.Key("db.namespace")
+	attrDBQueryText   = attribute.Key("db.query.text")
+	attrDBOperation   = attribute.Key("db.operation.name")
+	attrDBCollection  = attribute.Key("db.collection.name")
+	attrCommandType   = attribute.Key("syndrdb.command.type")
+	attrTraceID       = attribute.Key("syndrdb.trace_id")
+	attrTransactionID = attribute.Key("syndrdb.transaction.id")
+	attrTxOutcome     = attribute.Key("syndrdb.transaction.outcome")
+)
+
+// Hook creates OpenTelemetry spans for SyndrDB commands.
+type Hook struct {
+	tracer           trace.Tracer
+	database         string
+	includeStatement bool
+
+	mu      sync.Mutex
+	txSpans map[string]trace.Span // open transaction spans by transaction ID
+}
+
+// Option configures a Hook.
+type Option func(*Hook)
+
+// WithTracerProvider sets the tracer provider. Defaults to the global provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(h *Hook) {
+		h.tracer = provider.Tracer(instrumentationName, trace.WithInstrumentationVersion(client.Version))
+	}
+}
+
+// WithDatabase records the database name as db.namespace on every span.
+func WithDatabase(name string) Option {
+	return func(h *Hook) {
+		h.database = name
+	}
+}
+
+// WithStatement controls whether the SyndrQL text is recorded as db.query.text.
+// Enabled by default; disable when statements may contain sensitive literals.
+func WithStatement(enabled bool) Option {
+	return func(h *Hook) {
+		h.includeStatement = enabled
+	}
+}
+
+// NewHook creates a tracing hook.
+func NewHook(opts ...Option) *Hook {
+	h := &Hook{
+		tracer:           otel.GetTracerProvider().Tracer(instrumentationName, trace.WithInstrumentationVersion(client.Version)),
+		includeStatement: true,
+		txSpans:          make(map[string]trace.Span),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Name returns the hook name.
+func (h *Hook) Name() string {
+	return "otel-tracing"
+}
+
+// Before starts the command span and, for BEGIN, the enclosing transaction span.
+func (h *Hook) Before(ctx context.Context, hookCtx *client.HookContext) error {
+	operation := operationName(hookCtx.Command)
+
+	// Commands inside a transaction are parented to the transaction span
+	if hookCtx.TransactionID != "" {
+		if txSpan := h.transactionSpan(hookCtx.TransactionID); txSpan != nil {
+			ctx = trace.ContextWithSpan(ctx, txSpan)
+		}
+	} else if operation == "BEGIN" {
+		var txSpan trace.Span
+		ctx, txSpan = h.tracer.Start(ctx, "syndrdb.transaction",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithTimestamp(hookCtx.StartTime),
+			trace.WithAttributes(h.baseAttributes()...))
+		hookCtx.Metadata[metadataTxSpan] = txSpan
+	}
+
+	attrs := h.baseAttributes()
+	attrs = append(attrs,
+		attrDBOperation.String(operation),
+		attrCommandType.String(hookCtx.CommandType),
+		attrTraceID.String(hookCtx.TraceID))
+
+	bundle := client.CommandBundle(hookCtx.Command)
+	if bundle != "" {
+		attrs = append(attrs, attrDBCollection.String(bundle))
+	}
+	if h.includeStatement {
+		attrs = append(attrs, attrDBQueryText.String(hookCtx.Command))
+	}
+	if hookCtx.TransactionID != "" {
+		attrs = append(attrs, attrTransactionID.String(hookCtx.TransactionID))
+	}
+
+	_, span := h.tracer.Start(ctx, spanName(operation, bundle),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(hookCtx.StartTime),
+		trace.WithAttributes(attrs...))
+	hookCtx.Metadata[metadataSpan] = span
+
+	return nil
+}
+
+// After ends the command span and opens or closes the transaction span as needed.
+func (h *Hook) After(ctx context.Context, hookCtx *client.HookContext) error {
+	if span, ok := hookCtx.Metadata[metadataSpan].(trace.Span); ok {
+		recordError(span, hookCtx.Error)
+		span.End()
+	}
+
+	// BEGIN: keep the transaction span open until COMMIT/ROLLBACK
+	if txSpan, ok := hookCtx.Metadata[metadataTxSpan].(trace.Span); ok {
+		if hookCtx.Error != nil || hookCtx.TransactionID == "" {
+			recordError(txSpan, hookCtx.Error)
+			txSpan.End()
+			return nil
+		}
+
+		txSpan.SetAttributes(attrTransactionID.String(hookCtx.TransactionID))
+		h.mu.Lock()
+		h.txSpans[hookCtx.TransactionID] = txSpan
+		h.mu.Unlock()
+		return nil
+	}
+
+	if hookCtx.TransactionID == "" {
+		return nil
+	}
+
+	operation := operationName(hookCtx.Command)
+	if operation != "COMMIT" && operation != "ROLLBACK" {
+		return nil
+	}
+
+	// A failed COMMIT leaves the transaction open for a subsequent ROLLBACK
+	if operation == "COMMIT" && hookCtx.Error != nil {
+		if txSpan := h.transactionSpan(hookCtx.TransactionID); txSpan != nil {
+			txSpan.AddEvent("commit failed")
+		}
+		return nil
+	}
+
+	h.mu.Lock()
+	txSpan, ok := h.txSpans[hookCtx.TransactionID]
+	delete(h.txSpans, hookCtx.TransactionID)
+	h.mu.Unlock()
+
+	if ok {
+		txSpan.SetAttributes(attrTxOutcome.String(strings.ToLower(operation)))
+		if operation == "ROLLBACK" {
+			txSpan.SetStatus(codes.Error, "transaction rolled back")
+		}
+		txSpan.End()
+	}
+
+	return nil
+}
+
+// TransactionEnded ends the span of a transaction released without a
+// successful COMMIT or ROLLBACK, e.g. one reclaimed by the timeout monitor
+// after its connection died. It implements client.TransactionEndHook.
+func (h *Hook) TransactionEnded(txID string) {
+	h.mu.Lock()
+	txSpan, ok := h.txSpans[txID]
+	delete(h.txSpans, txID)
+	h.mu.Unlock()
+
+	if ok {
+		txSpan.SetAttributes(attrTxOutcome.String("released"))
+		txSpan.SetStatus(codes.Error, "transaction released without commit or rollback")
+		txSpan.End()
+	}
+}
+
+// transactionSpan returns the open span for txID, or nil.
+func (h *Hook) transactionSpan(txID string) trace.Span {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.txSpans[txID]
+}
+
+// baseAttributes returns the attributes shared by all spans.
+func (h *Hook) baseAttributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attrDBSystem.String("syndrdb")}
+	if h.database != "" {
+		attrs = append(attrs, attrDBNamespace.String(h.database))
+	}
+	return attrs
+}
+
+// recordError marks span as failed when err is non-nil.
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// operationName returns the leading SyndrQL keyword of command in upper case.
+func operationName(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(strings.TrimRight(fields[0], ";"))
+}
+
+// spanName follows the "{operation} {target}" convention.
+func spanName(operation, bundle string) string {
+	if bundle == "" {
+		return operation
+	}
+	return operation + " " + bundle
+}
@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+func newTestHook(t *testing.T, opts ...Option) (*Hook, *tracetest.SpanRecorder, trace.Tracer) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	opts = append([]Option{WithTracerProvider(provider)}, opts...)
+	return NewHook(opts...), recorder, provider.Tracer("test")
+}
+
+func run(t *testing.T, h *Hook, ctx context.Context, command, txID string, err error) *client.HookContext {
+	t.Helper()
+	hookCtx := &client.HookContext{
+		Command:   command,
+		StartTime: time.Now(),
+		Metadata:  make(map[string]interface{}),
+		TraceID:   "trace-" + command,
+	}
+	if command != "BEGIN TRANSACTION;" {
+		hookCtx.TransactionID = txID
+	}
+	if err := h.Before(ctx, hookCtx); err != nil {
+		t.Fatalf("Before() failed: %v", err)
+	}
+	hookCtx.TransactionID = txID
+	hookCtx.Error = err
+	if err := h.After(ctx, hookCtx); err != nil {
+		t.Fatalf("After() failed: %v", err)
+	}
+	return hookCtx
+}
+
+func attrValue(span sdktrace.ReadOnlySpan, key string) string {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+// TestHookCreatesChildSpan verifies spans are parented to the context span and carry attributes.
+func TestHookCreatesChildSpan(t *testing.T) {
+	h, recorder, tracer := newTestHook(t, WithDatabase("primary"))
+
+	ctx, parent := tracer.Start(context.Background(), "request")
+	run(t, h, ctx, `SELECT * FROM "users" WHERE "age" > 21;`, "", nil)
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "SELECT users" {
+		t.Errorf("expected span name 'SELECT users', got %q", span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("command span is not a child of the context span")
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("expected client span kind, got %v", span.SpanKind())
+	}
+
+	expected := map[string]string{
+		"db.system.name":     "syndrdb",
+		"db.namespace":       "primary",
+		"db.operation.name":  "SELECT",
+		"db.collection.name": "users",
+		"db.query.text":      `SELECT * FROM "users" WHERE "age" > 21;`,
+	}
+	for key, want := range expected {
+		if got := attrValue(span, key); got != want {
+			t.Errorf("attribute %s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// TestHookRecordsErrors verifies failed commands set an error status.
+func TestHookRecordsErrors(t *testing.T) {
+	h, recorder, _ := newTestHook(t, WithStatement(false))
+
+	run(t, h, context.Background(), `ADD DOCUMENT TO BUNDLE "orders" WITH ({"total" = 5});`, "", errors.New("boom"))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Description != "boom" {
+		t.Errorf("expected error status 'boom', got %q", spans[0].Status().Description)
+	}
+	if attrValue(spans[0], "db.query.text") != "" {
+		t.Error("statement recorded despite WithStatement(false)")
+	}
+	if attrValue(spans[0], "db.collection.name") != "orders" {
+		t.Errorf("expected bundle 'orders', got %q", attrValue(spans[0], "db.collection.name"))
+	}
+}
+
+// TestHookTransactionSpans verifies transaction commands nest under a transaction span.
+func TestHookTransactionSpans(t *testing.T) {
+	h, recorder, tracer := newTestHook(t)
+
+	ctx, parent := tracer.Start(context.Background(), "request")
+	run(t, h, ctx, "BEGIN TRANSACTION;", "TX_1", nil)
+	run(t, h, context.Background(), `UPDATE DOCUMENTS IN BUNDLE "users" ("name" = "x") WHERE "id" == 1;`, "TX_1", nil)
+	run(t, h, context.Background(), "COMMIT;", "TX_1", nil)
+	parent.End()
+
+	byName := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		byName[span.Name()] = span
+	}
+
+	txSpan, ok := byName["syndrdb.transaction"]
+	if !ok {
+		t.Fatal("transaction span not recorded")
+	}
+	if txSpan.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("transaction span is not a child of the Begin context span")
+	}
+	if attrValue(txSpan, "syndrdb.transaction.outcome") != "commit" {
+		t.Errorf("expected outcome 'commit', got %q", attrValue(txSpan, "syndrdb.transaction.outcome"))
+	}
+
+	for _, name := range []string{"BEGIN", "UPDATE users", "COMMIT"} {
+		span, ok := byName[name]
+		if !ok {
+			t.Fatalf("span %q not recorded", name)
+		}
+		if span.Parent().SpanID() != txSpan.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of the transaction span", name)
+		}
+	}
+
+	if len(h.txSpans) != 0 {
+		t.Errorf("expected no open transaction spans, got %d", len(h.txSpans))
+	}
+}
+
+// TestHookFailedBegin verifies a failed BEGIN does not leak a transaction span.
+func TestHookFailedBegin(t *testing.T) {
+	h, recorder, _ := newTestHook(t)
+
+	run(t, h, context.Background(), "BEGIN TRANSACTION;", "", errors.New("refused"))
+
+	if len(recorder.Ended()) != 2 {
+		t.Errorf("expected command and transaction spans to end, got %d", len(recorder.Ended()))
+	}
+	if len(h.txSpans) != 0 {
+		t.Errorf("expected no open transaction spans, got %d", len(h.txSpans))
+	}
+}
+
+// TestHookTransactionEnded verifies a transaction released without COMMIT or
+// ROLLBACK, such as one reclaimed by the timeout monitor, ends its span.
+func TestHookTransactionEnded(t *testing.T) {
+	h, recorder, _ := newTestHook(t)
+
+	run(t, h, context.Background(), "BEGIN TRANSACTION;", "TX_1", nil)
+	h.TransactionEnded("TX_1")
+	h.TransactionEnded("TX_1")
+
+	var txSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "syndrdb.transaction" {
+			txSpan = span
+		}
+	}
+	if txSpan == nil {
+		t.Fatal("transaction span not ended")
+	}
+	if attrValue(txSpan, "syndrdb.transaction.outcome") != "released" {
+		t.Errorf("expected outcome 'released', got %q", attrValue(txSpan, "syndrdb.transaction.outcome"))
+	}
+	if len(h.txSpans) != 0 {
+		t.Errorf("expected no open transaction spans, got %d", len(h.txSpans))
+	}
+	var _ client.TransactionEndHook = h
+}
@@ -2,8 +2,11 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -38,6 +41,46 @@ func (l IsolationLevel) String() string {
 	}
 }
 
+// parseIsolationLevel parses an isolation level name such as "SERIALIZABLE".
+func parseIsolationLevel(name string) (IsolationLevel, bool) {
+	normalized := strings.Join(strings.Fields(strings.ToUpper(strings.ReplaceAll(name, "_", " "))), " ")
+	for _, level := range []IsolationLevel{ReadUncommitted, ReadCommitted, RepeatableRead, Serializable} {
+		if level.String() == normalized {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// serverIsolationLevels returns the isolation levels listed in the
+// authentication response's "isolation_levels" capability.
+func serverIsolationLevels(authData map[string]interface{}) []IsolationLevel {
+	names, _ := authData["isolation_levels"].([]interface{})
+	var levels []IsolationLevel
+	for _, name := range names {
+		if s, ok := name.(string); ok {
+			if level, ok := parseIsolationLevel(s); ok {
+				levels = append(levels, level)
+			}
+		}
+	}
+	return levels
+}
+
+// connSupportsIsolation reports whether conn's server advertised level.
+func connSupportsIsolation(conn ConnectionInterface, level IsolationLevel) bool {
+	c, ok := conn.(*Connection)
+	if !ok || c.serverInfo == nil {
+		return false
+	}
+	for _, supported := range c.serverInfo.IsolationLevels {
+		if supported == level {
+			return true
+		}
+	}
+	return false
+}
+
 // Transaction represents a database transaction with ACID properties.
 // Binds to a specific connection for the transaction lifetime.
 type Transaction struct {
@@ -49,57 +92,150 @@ type Transaction struct {
 	committed  bool
 	rolledBack bool
 	startedAt  time.Time
+	attempt    int      // InTransaction attempt number, 0 outside InTransaction
+	writes     []string // Write commands whose cache invalidation is deferred to commit
+	traceID    string   // Trace ID of the Begin call
+	beginStack string   // Begin call stack, captured in debug mode
+	ctx        context.Context
+	stopWatch  func() bool // Stops the rollback on ctx cancellation
+	ctxErr     error       // Why ctx ended, once it has
 	mu         sync.Mutex
+	cmdMu      sync.Mutex // Serializes round trips on conn
 }
 
-// Query executes a query within the transaction context.
-func (tx *Transaction) Query(query string, timeoutMs int) (interface{}, error) {
+// txContextRollbackTimeout bounds the rollback sent when a transaction's
+// context ends.
+const txContextRollbackTimeout = 5 * time.Second
+
+// watch binds the transaction to ctx: when ctx is cancelled or times out, the
+// transaction is rolled back and its connection released. Commands already
+// in flight finish first.
+func (tx *Transaction) watch(ctx context.Context) {
+	tx.ctx = ctx
+	if ctx.Done() == nil {
+		return
+	}
+	// The callback takes tx.mu, so it cannot run before stopWatch is set
 	tx.mu.Lock()
-	if tx.committed {
-		tx.mu.Unlock()
-		return nil, ErrTransactionAlreadyCommitted(tx.id)
+	defer tx.mu.Unlock()
+	tx.stopWatch = context.AfterFunc(ctx, func() {
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), txContextRollbackTimeout)
+		defer cancel()
+		if err := tx.rollback(rollbackCtx, context.Cause(ctx)); err != nil && tx.client != nil {
+			tx.client.logger.Warn("failed to roll back transaction after its context ended",
+				String("tx_id", tx.id),
+				Error("error", err))
+		}
+	})
+}
+
+// context returns the context commands in the transaction run under.
+func (tx *Transaction) context() context.Context {
+	if tx.ctx == nil {
+		return context.Background()
 	}
-	if tx.rolledBack {
-		tx.mu.Unlock()
-		return nil, ErrTransactionAlreadyRolledBack(tx.id)
+	return tx.ctx
+}
+
+// checkActive returns the error for operations on a transaction that has
+// ended. The caller must hold tx.mu.
+func (tx *Transaction) checkActive() error {
+	if tx.ctxErr == nil && tx.ctx != nil && tx.ctx.Err() != nil {
+		// Cancelled, but the rollback has not run yet
+		tx.ctxErr = context.Cause(tx.ctx)
+	}
+	switch {
+	case tx.ctxErr != nil && !tx.committed:
+		return ErrTransactionContextCancelled(tx.id, tx.ctxErr)
+	case tx.committed:
+		return ErrTransactionAlreadyCommitted(tx.id)
+	case tx.rolledBack:
+		return ErrTransactionAlreadyRolledBack(tx.id)
 	}
+	return nil
+}
+
+// Isolation returns the transaction's isolation level.
+func (tx *Transaction) Isolation() IsolationLevel {
+	return tx.isolation
+}
+
+// Query executes a query within the transaction context.
+func (tx *Transaction) Query(query string, timeoutMs int) (interface{}, error) {
+	tx.mu.Lock()
+	err := tx.checkActive()
 	tx.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
-	ctx := context.Background()
+	ctx := tx.context()
 	if timeoutMs > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
 		defer cancel()
 	}
 
-	if err := tx.conn.SendCommand(ctx, query); err != nil {
-		return nil, &QueryError{
-			Code:    "E_TX_QUERY_FAILED",
-			Type:    "QueryError",
-			Message: "failed to execute query in transaction",
-			Details: map[string]interface{}{
-				"transaction_id": tx.id,
-			},
-			Query: query,
-			Cause: err,
+	var executed string
+	result, err := tx.runCommand(ctx, query, func(command string) (interface{}, error) {
+		tx.cmdMu.Lock()
+		defer tx.cmdMu.Unlock()
+
+		executed = command
+		if err := tx.conn.SendCommand(ctx, command); err != nil {
+			return nil, &QueryError{
+				Code:    "E_TX_QUERY_FAILED",
+				Type:    "QueryError",
+				Message: "failed to execute query in transaction",
+				Details: map[string]interface{}{
+					"transaction_id": tx.id,
+				},
+				Query: command,
+				Cause: err,
+			}
 		}
+
+		result, err := tx.conn.ReceiveResponse(ctx)
+		if queryErr := serverStatusError(result, command); err == nil && queryErr != nil {
+			return nil, txStatusError(queryErr, tx.id)
+		}
+		return result, err
+	})
+
+	// Writes become visible to other sessions on commit, so invalidate caches then
+	if err == nil && IsWriteCommand(executed) {
+		tx.mu.Lock()
+		tx.writes = append(tx.writes, executed)
+		tx.mu.Unlock()
 	}
 
-	return tx.conn.ReceiveResponse(ctx)
+	return result, err
+}
+
+// runCommand executes fn for command through the client's hook chain,
+// tagging the hook context with the transaction ID.
+func (tx *Transaction) runCommand(ctx context.Context, command string, fn func(command string) (interface{}, error)) (interface{}, error) {
+	if tx.client == nil {
+		return fn(command)
+	}
+
+	hookCtx := newHookContext(ctx, command)
+	hookCtx.TransactionID = tx.id
+	hookCtx.setConnection(tx.conn)
+	if tx.attempt > 0 {
+		hookCtx.Metadata["tx_attempt"] = tx.attempt
+	}
+	return tx.client.runWithHooks(ctx, hookCtx, fn)
 }
 
 // QueryWithParams executes a parameterized query within the transaction.
 func (tx *Transaction) QueryWithParams(query string, params ...interface{}) (interface{}, error) {
 	tx.mu.Lock()
-	if tx.committed {
-		tx.mu.Unlock()
-		return nil, ErrTransactionAlreadyCommitted(tx.id)
-	}
-	if tx.rolledBack {
-		tx.mu.Unlock()
-		return nil, ErrTransactionAlreadyRolledBack(tx.id)
-	}
+	err := tx.checkActive()
 	tx.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
 	// Prepare statement within transaction
 	stmt, err := tx.prepareInternal(query)
@@ -108,21 +244,17 @@ func (tx *Transaction) QueryWithParams(query string, params ...interface{}) (int
 	}
 	defer stmt.Close()
 
-	return stmt.Execute(params...)
+	return stmt.ExecuteContext(tx.context(), params...)
 }
 
 // Prepare creates a prepared statement within the transaction context.
 func (tx *Transaction) Prepare(query string) (*Statement, error) {
 	tx.mu.Lock()
-	if tx.committed {
-		tx.mu.Unlock()
-		return nil, ErrTransactionAlreadyCommitted(tx.id)
-	}
-	if tx.rolledBack {
-		tx.mu.Unlock()
-		return nil, ErrTransactionAlreadyRolledBack(tx.id)
-	}
+	err := tx.checkActive()
 	tx.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
 	return tx.prepareInternal(query)
 }
@@ -136,41 +268,60 @@ func (tx *Transaction) prepareInternal(query string) (*Statement, error) {
 		return nil, err
 	}
 
-	command := fmt.Sprintf("PREPARE %s AS %s", stmtName, query)
-	ctx := context.Background()
+	// Named placeholders are sent to the server as $n
+	prepared, paramNames, err := rewriteNamedParams(query)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := tx.conn.SendCommand(ctx, command); err != nil {
-		return nil, &StatementError{
-			QueryError: QueryError{
-				Code:    "E_PREPARE_FAILED",
-				Type:    "StatementError",
-				Message: "failed to prepare statement in transaction",
-				Details: map[string]interface{}{
-					"transaction_id": tx.id,
+	command := fmt.Sprintf("PREPARE %s AS %s", stmtName, prepared)
+	ctx := tx.context()
+
+	response, err := tx.runCommand(ctx, command, func(command string) (interface{}, error) {
+		tx.cmdMu.Lock()
+		defer tx.cmdMu.Unlock()
+
+		if err := tx.conn.SendCommand(ctx, command); err != nil {
+			return nil, &StatementError{
+				QueryError: QueryError{
+					Code:    "E_PREPARE_FAILED",
+					Type:    "StatementError",
+					Message: "failed to prepare statement in transaction",
+					Details: map[string]interface{}{
+						"transaction_id": tx.id,
+					},
+					Query: query,
+					Cause: err,
 				},
-				Query: query,
-				Cause: err,
-			},
-			StatementName: stmtName,
+				StatementName: stmtName,
+			}
 		}
-	}
 
-	response, err := tx.conn.ReceiveResponse(ctx)
+		return tx.conn.ReceiveResponse(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse parameter count from response
-	paramCount := countPlaceholders(query)
+	paramCount := countPlaceholders(prepared)
 
 	stmt := &Statement{
 		name:       stmtName,
 		query:      query,
 		paramCount: paramCount,
+		paramNames: paramNames,
+		paramTypes: parseParamTypes(response, paramCount),
 		conn:       tx.conn,
 		closed:     false,
 		createdAt:  time.Now(),
 	}
+	if tx.client != nil {
+		stmt.redaction = tx.client.redaction
+		stmt.codec = newValueCodec(tx.client.opts)
+		stmt.codecs = &tx.client.codecs
+		stmt.timeout = tx.client.opts.StatementTimeout
+	}
 
 	// Log success for debugging
 	if tx.client != nil && tx.client.logger != nil {
@@ -180,8 +331,6 @@ func (tx *Transaction) prepareInternal(query string) (*Statement, error) {
 			Int("param_count", paramCount))
 	}
 
-	_ = response // TODO: Parse server response for validation
-
 	return stmt, nil
 }
 
@@ -190,40 +339,56 @@ func (tx *Transaction) Commit() error {
 	tx.mu.Lock()
 	defer tx.mu.Unlock()
 
-	if tx.committed {
-		return ErrTransactionAlreadyCommitted(tx.id)
-	}
-	if tx.rolledBack {
-		return ErrTransactionAlreadyRolledBack(tx.id)
+	if err := tx.checkActive(); err != nil {
+		return err
 	}
 
 	ctx := context.Background()
-	if err := tx.conn.SendCommand(ctx, "COMMIT;"); err != nil {
-		return &TransactionError{
-			Code:          "E_COMMIT_FAILED",
-			Type:          "TransactionError",
-			Message:       "failed to commit transaction",
-			TransactionID: tx.id,
-			State:         "active",
-			Cause:         err,
+	_, err := tx.runCommand(ctx, "COMMIT;", func(command string) (interface{}, error) {
+		tx.cmdMu.Lock()
+		defer tx.cmdMu.Unlock()
+
+		if err := tx.conn.SendCommand(ctx, command); err != nil {
+			return nil, &TransactionError{
+				Code:          "E_COMMIT_FAILED",
+				Type:          "TransactionError",
+				Message:       "failed to commit transaction",
+				TransactionID: tx.id,
+				State:         "active",
+				Cause:         err,
+			}
 		}
-	}
 
-	if _, err := tx.conn.ReceiveResponse(ctx); err != nil {
-		return &TransactionError{
-			Code:          "E_COMMIT_RESPONSE_FAILED",
-			Type:          "TransactionError",
-			Message:       "failed to receive commit response",
-			TransactionID: tx.id,
-			Cause:         err,
+		response, err := tx.conn.ReceiveResponse(ctx)
+		if err != nil {
+			return nil, &TransactionError{
+				Code:          "E_COMMIT_RESPONSE_FAILED",
+				Type:          "TransactionError",
+				Message:       "failed to receive commit response",
+				TransactionID: tx.id,
+				Cause:         err,
+			}
+		}
+		if queryErr := serverStatusError(response, command); queryErr != nil {
+			return nil, txStatusError(queryErr, tx.id)
 		}
+		return response, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	tx.committed = true
+	if tx.stopWatch != nil {
+		tx.stopWatch()
+	}
 
 	// Remove from active transactions and return connection to pool
 	if tx.client != nil {
-		tx.client.activeTransactions.Delete(tx.id)
+		for _, command := range tx.writes {
+			tx.client.invalidateCaches(command, tx.id)
+		}
+		tx.client.releaseTransaction(tx.id)
 		if tx.client.poolEnabled && tx.client.pool != nil {
 			tx.client.pool.Put(tx.conn)
 		}
@@ -232,44 +397,70 @@ func (tx *Transaction) Commit() error {
 	return nil
 }
 
-// Rollback rolls back the transaction and releases the connection.
+// Rollback rolls back the transaction and releases the connection. It is a
+// no-op if the transaction was already rolled back, including automatically
+// when its context ended.
 func (tx *Transaction) Rollback() error {
+	return tx.rollback(context.Background(), nil)
+}
+
+// rollback rolls back the transaction under ctx. cause is the error that
+// ended the transaction's context, or nil for an explicit rollback.
+func (tx *Transaction) rollback(ctx context.Context, cause error) error {
 	tx.mu.Lock()
 	defer tx.mu.Unlock()
 
 	if tx.committed {
+		if cause != nil {
+			return nil // Committed before the context ended
+		}
 		return ErrTransactionAlreadyCommitted(tx.id)
 	}
 	if tx.rolledBack {
 		return nil // Already rolled back, no-op
 	}
+	if cause != nil {
+		tx.ctxErr = cause
+	}
 
-	ctx := context.Background()
-	if err := tx.conn.SendCommand(ctx, "ROLLBACK;"); err != nil {
-		return &TransactionError{
-			Code:          "E_ROLLBACK_FAILED",
-			Type:          "TransactionError",
-			Message:       "failed to rollback transaction",
-			TransactionID: tx.id,
-			State:         "active",
-			Cause:         err,
+	_, err := tx.runCommand(ctx, "ROLLBACK;", func(command string) (interface{}, error) {
+		tx.cmdMu.Lock()
+		defer tx.cmdMu.Unlock()
+
+		if err := tx.conn.SendCommand(ctx, command); err != nil {
+			return nil, &TransactionError{
+				Code:          "E_ROLLBACK_FAILED",
+				Type:          "TransactionError",
+				Message:       "failed to rollback transaction",
+				TransactionID: tx.id,
+				State:         "active",
+				Cause:         err,
+			}
 		}
-	}
 
-	if _, err := tx.conn.ReceiveResponse(ctx); err != nil {
-		// Log but don't fail - rollback intent is clear
-		if tx.client != nil && tx.client.logger != nil {
-			tx.client.logger.Warn("failed to receive rollback response",
-				String("tx_id", tx.id),
-				Error("error", err))
+		response, err := tx.conn.ReceiveResponse(ctx)
+		if err != nil {
+			// Log but don't fail - rollback intent is clear
+			if tx.client != nil && tx.client.logger != nil {
+				tx.client.logger.Warn("failed to receive rollback response",
+					String("tx_id", tx.id),
+					Error("error", err))
+			}
 		}
+		return response, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	tx.rolledBack = true
+	if tx.stopWatch != nil {
+		tx.stopWatch()
+	}
 
 	// Remove from active transactions and return connection to pool
 	if tx.client != nil {
-		tx.client.activeTransactions.Delete(tx.id)
+		tx.client.releaseTransaction(tx.id)
 		if tx.client.poolEnabled && tx.client.pool != nil {
 			tx.client.pool.Put(tx.conn)
 		}
@@ -278,11 +469,38 @@ func (tx *Transaction) Rollback() error {
 	return nil
 }
 
+// releaseTransaction removes txID from the active transactions and notifies
+// hooks implementing TransactionEndHook. Unknown IDs are ignored.
+func (c *Client) releaseTransaction(txID string) {
+	if _, loaded := c.activeTransactions.LoadAndDelete(txID); !loaded {
+		return
+	}
+
+	c.hooksMu.RLock()
+	var hooks []TransactionEndHook
+	for _, entry := range c.hooks {
+		if hook, ok := entry.hook.(TransactionEndHook); ok {
+			hooks = append(hooks, hook)
+		}
+	}
+	c.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook.TransactionEnded(txID)
+	}
+}
+
 // ID returns the transaction ID.
 func (tx *Transaction) ID() string {
 	return tx.id
 }
 
+// Attempt returns the InTransaction attempt this transaction belongs to,
+// starting at 1, or 0 for transactions started with Begin.
+func (tx *Transaction) Attempt() int {
+	return tx.attempt
+}
+
 // ConnectionID returns the connection ID this transaction is bound to
 func (tx *Transaction) ConnectionID() string {
 	return tx.connID
@@ -309,13 +527,102 @@ type transactionContext struct {
 	startedAt time.Time
 }
 
+// TransactionInfo describes an open transaction, as listed by ActiveTransactions.
+type TransactionInfo struct {
+	ID         string
+	TraceID    string // Trace ID of the Begin call
+	Isolation  IsolationLevel
+	Connection string // Remote address of the reserved connection
+	StartedAt  time.Time
+	Age        time.Duration
+	BeginStack string // Begin call stack; empty unless debug mode was on at Begin
+}
+
+// ActiveTransactions lists the transactions that have not been committed or
+// rolled back, oldest first. Each holds a connection until it ends or
+// TransactionTimeout rolls it back, so long-lived entries usually point to a
+// missing Commit or Rollback; enable debug mode to record where they began.
+func (c *Client) ActiveTransactions() []TransactionInfo {
+	now := time.Now()
+	var infos []TransactionInfo
+	c.activeTransactions.Range(func(key, value interface{}) bool {
+		txCtx := value.(*transactionContext)
+		infos = append(infos, TransactionInfo{
+			ID:         txCtx.tx.id,
+			TraceID:    txCtx.tx.traceID,
+			Isolation:  txCtx.tx.isolation,
+			Connection: txCtx.tx.connID,
+			StartedAt:  txCtx.startedAt,
+			Age:        now.Sub(txCtx.startedAt),
+			BeginStack: txCtx.tx.beginStack,
+		})
+		return true
+	})
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].StartedAt.Before(infos[j].StartedAt)
+	})
+	return infos
+}
+
+// TxOption configures InTransaction.
+type TxOption func(*txOptions)
+
+type txOptions struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// WithTxRetry re-runs the InTransaction callback on a fresh transaction when
+// it fails with a serialization failure, deadlock or lock conflict
+// (ErrTxConflict), up to maxAttempts attempts in total. The delay before each
+// retry starts at backoff and doubles. The callback must be safe to re-run;
+// tx.Attempt reports the current attempt and hooks see it as "tx_attempt"
+// in HookContext.Metadata.
+func WithTxRetry(maxAttempts int, backoff time.Duration) TxOption {
+	return func(o *txOptions) {
+		o.maxAttempts = maxAttempts
+		o.backoff = backoff
+	}
+}
+
 // InTransaction executes a function within a transaction with automatic commit/rollback.
 // Commits on success, rolls back on error or panic.
-func (c *Client) InTransaction(ctx context.Context, fn func(*Transaction) error) error {
+func (c *Client) InTransaction(ctx context.Context, fn func(*Transaction) error, opts ...TxOption) error {
+	options := txOptions{maxAttempts: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.runTransaction(ctx, fn, attempt)
+		if err == nil || attempt >= options.maxAttempts || !errors.Is(err, ErrTxConflict) {
+			return err
+		}
+
+		delay := options.backoff << (attempt - 1)
+		c.logger.Warn("retrying transaction after conflict",
+			Int("attempt", attempt),
+			Int("max_attempts", options.maxAttempts),
+			Duration("backoff", delay),
+			Error("error", err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// runTransaction runs one InTransaction attempt.
+func (c *Client) runTransaction(ctx context.Context, fn func(*Transaction) error, attempt int) error {
 	tx, err := c.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	tx.attempt = attempt
 
 	// Set up panic recovery with rollback
 	defer func() {
@@ -357,7 +664,3 @@ func (c *Client) InTransaction(ctx context.Context, fn func(*Transaction) error)
 // server supports nested transactions. Design: tx.Savepoint(name), tx.RollbackTo(name),
 // tx.ReleaseSavepoint(name). Track savepoint stack per transaction for proper nesting.
 // NOTE: Server currently doesn't support savepoints (see limitations.md)
-
-// TODO: Add transaction isolation level configuration when server protocol is extended.
-// Currently server provides READ COMMITTED isolation only.
-// Monitor server roadmap for configurable isolation levels.
@@ -0,0 +1,394 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func beginResponder(command string) string {
+	return "Transaction started with ID: TX_1_abc"
+}
+
+// TestBeginWithIsolationNegotiated verifies the isolation clause is sent to servers advertising it.
+func TestBeginWithIsolationNegotiated(t *testing.T) {
+	c, server := newPipeClient(t, beginResponder)
+	c.conn.serverInfo = parseServerInfo(map[string]interface{}{
+		"isolation_levels": []interface{}{"READ COMMITTED", "serializable", "snapshot"},
+	}, false)
+
+	tx, err := c.BeginWithIsolation(context.Background(), Serializable)
+	if err != nil {
+		t.Fatalf("BeginWithIsolation failed: %v", err)
+	}
+	if tx.Isolation() != Serializable {
+		t.Errorf("expected SERIALIZABLE, got %s", tx.Isolation())
+	}
+	if got := server.received(); len(got) != 1 || got[0] != "BEGIN TRANSACTION ISOLATION LEVEL SERIALIZABLE;" {
+		t.Errorf("unexpected commands: %v", got)
+	}
+}
+
+// TestBeginWithIsolationUnsupported verifies unadvertised levels fail instead of downgrading.
+func TestBeginWithIsolationUnsupported(t *testing.T) {
+	c, server := newPipeClient(t, beginResponder)
+
+	_, err := c.BeginWithIsolation(context.Background(), RepeatableRead)
+	if ErrorCode(err) != "E_ISOLATION_UNSUPPORTED" {
+		t.Fatalf("expected E_ISOLATION_UNSUPPORTED, got %v", err)
+	}
+	if got := server.received(); len(got) != 0 {
+		t.Errorf("expected no commands, got %v", got)
+	}
+
+	// READ COMMITTED is the server default and needs no clause
+	tx, err := c.BeginWithIsolation(context.Background(), ReadCommitted)
+	if err != nil {
+		t.Fatalf("BeginWithIsolation failed: %v", err)
+	}
+	if tx.Isolation() != ReadCommitted {
+		t.Errorf("expected READ COMMITTED, got %s", tx.Isolation())
+	}
+	if got := server.received(); len(got) != 1 || got[0] != "BEGIN TRANSACTION;" {
+		t.Errorf("unexpected commands: %v", got)
+	}
+}
+
+// TestParseIsolationLevel verifies level names are matched case- and separator-insensitively.
+func TestParseIsolationLevel(t *testing.T) {
+	for name, want := range map[string]IsolationLevel{
+		"READ COMMITTED":   ReadCommitted,
+		"read_uncommitted": ReadUncommitted,
+		"Repeatable  Read": RepeatableRead,
+		"serializable":     Serializable,
+	} {
+		if got, ok := parseIsolationLevel(name); !ok || got != want {
+			t.Errorf("parseIsolationLevel(%q) = %v, %v", name, got, ok)
+		}
+	}
+	if _, ok := parseIsolationLevel("snapshot"); ok {
+		t.Error("expected unknown level to be rejected")
+	}
+}
+
+// attemptHook records the transaction attempt of each UPDATE.
+type attemptHook struct {
+	attempts []interface{}
+}
+
+func (h *attemptHook) Name() string { return "attempts" }
+
+func (h *attemptHook) Before(ctx context.Context, hookCtx *HookContext) error {
+	if strings.HasPrefix(hookCtx.Command, "UPDATE") {
+		h.attempts = append(h.attempts, hookCtx.Metadata["tx_attempt"])
+	}
+	return nil
+}
+
+func (h *attemptHook) After(ctx context.Context, hookCtx *HookContext) error { return nil }
+
+// TestInTransactionRetriesConflicts verifies conflicting transactions are re-run on a fresh transaction.
+func TestInTransactionRetriesConflicts(t *testing.T) {
+	var conflicts atomic.Int32
+	conflicts.Store(2)
+	c, server := newPipeClient(t, func(command string) string {
+		switch {
+		case strings.HasPrefix(command, "BEGIN"):
+			return beginResponder(command)
+		case strings.HasPrefix(command, "UPDATE") && conflicts.Add(-1) >= 0:
+			return `{"status":"error","message":"could not serialize access due to concurrent update"}`
+		}
+		return `{"success":true}`
+	})
+
+	var attempts []int
+	hook := &attemptHook{}
+	c.RegisterHook(hook)
+
+	err := c.InTransaction(context.Background(), func(tx *Transaction) error {
+		attempts = append(attempts, tx.Attempt())
+		_, err := tx.Query(`UPDATE DOCUMENTS IN BUNDLE "accounts" ("balance" = 10) WHERE "id" == 1;`, 1000)
+		return err
+	}, WithTxRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("InTransaction failed: %v", err)
+	}
+	if len(attempts) != 3 || attempts[2] != 3 {
+		t.Errorf("expected 3 attempts, got %v", attempts)
+	}
+	if len(hook.attempts) != 3 || hook.attempts[0] != 1 || hook.attempts[2] != 3 {
+		t.Errorf("expected attempt metadata, got %v", hook.attempts)
+	}
+
+	var rollbacks, commits int
+	for _, command := range server.received() {
+		switch command {
+		case "ROLLBACK;":
+			rollbacks++
+		case "COMMIT;":
+			commits++
+		}
+	}
+	if rollbacks != 2 || commits != 1 {
+		t.Errorf("expected 2 rollbacks and 1 commit, got %d and %d", rollbacks, commits)
+	}
+}
+
+// TestInTransactionRetryLimits verifies only conflicts are retried, up to maxAttempts.
+func TestInTransactionRetryLimits(t *testing.T) {
+	c, _ := newPipeClient(t, beginResponder)
+	ctx := context.Background()
+
+	calls := 0
+	conflict := &TransactionError{Code: "E_DEADLOCK", Type: "TransactionError", Message: "deadlock detected"}
+	err := c.InTransaction(ctx, func(tx *Transaction) error {
+		calls++
+		return conflict
+	}, WithTxRetry(2, 0))
+	if !errors.Is(err, ErrTxConflict) || calls != 2 {
+		t.Errorf("expected 2 attempts ending in a conflict, got %d: %v", calls, err)
+	}
+
+	calls = 0
+	err = c.InTransaction(ctx, func(tx *Transaction) error {
+		calls++
+		return errors.New("validation failed")
+	}, WithTxRetry(5, 0))
+	if err == nil || calls != 1 {
+		t.Errorf("expected other errors not to be retried, got %d calls", calls)
+	}
+
+	calls = 0
+	c.InTransaction(ctx, func(tx *Transaction) error {
+		calls++
+		if tx.Attempt() != 1 {
+			t.Errorf("expected attempt 1, got %d", tx.Attempt())
+		}
+		return conflict
+	})
+	if calls != 1 {
+		t.Errorf("expected no retries without WithTxRetry, got %d calls", calls)
+	}
+}
+
+// countingBeginResponder answers each BEGIN with a new transaction ID.
+func countingBeginResponder() func(command string) string {
+	var n atomic.Int32
+	return func(command string) string {
+		if strings.HasPrefix(command, "BEGIN") {
+			return fmt.Sprintf("Transaction started with ID: TX_%d_abc", n.Add(1))
+		}
+		return `{"status":"success"}`
+	}
+}
+
+// TestActiveTransactions verifies open transactions are listed oldest first with their trace IDs.
+func TestActiveTransactions(t *testing.T) {
+	c, _ := newPipeClient(t, countingBeginResponder())
+	c.EnableDebugMode()
+
+	first, err := c.Begin(WithTraceID(context.Background(), "trace-first"))
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	c.DisableDebugMode()
+	second, err := c.Begin(WithTraceID(context.Background(), "trace-second"))
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	infos := c.ActiveTransactions()
+	if len(infos) != 2 || infos[0].ID != first.ID() || infos[1].ID != second.ID() {
+		t.Fatalf("unexpected transactions: %+v", infos)
+	}
+	if infos[0].TraceID != "trace-first" || infos[1].TraceID != "trace-second" {
+		t.Errorf("unexpected trace IDs: %q, %q", infos[0].TraceID, infos[1].TraceID)
+	}
+	if !strings.Contains(infos[0].BeginStack, "TestActiveTransactions") {
+		t.Errorf("expected the Begin stack in debug mode, got %q", infos[0].BeginStack)
+	}
+	if infos[1].BeginStack != "" {
+		t.Errorf("expected no stack outside debug mode, got %q", infos[1].BeginStack)
+	}
+	if infos[0].Age <= 0 {
+		t.Errorf("expected a positive age, got %v", infos[0].Age)
+	}
+
+	if err := first.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if infos := c.ActiveTransactions(); len(infos) != 1 || infos[0].ID != second.ID() {
+		t.Errorf("expected only the open transaction, got %+v", infos)
+	}
+}
+
+// TestAbandonedTransactionWarning verifies timed-out transactions are rolled
+// back with a warning carrying the Begin stack.
+func TestAbandonedTransactionWarning(t *testing.T) {
+	c, server := newPipeClient(t, countingBeginResponder())
+	var logs bytes.Buffer
+	c.logger = NewLogger("WARN", &logs)
+	c.opts.TransactionTimeout = time.Millisecond
+	c.EnableDebugMode()
+
+	if _, err := c.Begin(WithTraceID(context.Background(), "trace-leak")); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	c.checkAbandonedTransactions()
+
+	if got := server.received(); len(got) != 2 || got[1] != "ROLLBACK;" {
+		t.Errorf("expected a forced rollback, got %v", got)
+	}
+	if len(c.ActiveTransactions()) != 0 {
+		t.Error("expected no active transactions")
+	}
+	for _, want := range []string{"transaction leaked", "trace-leak", "TestAbandonedTransactionWarning"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected %q in the warning, got %s", want, logs.String())
+		}
+	}
+}
+
+// txEndRecorder records released transactions and rejects ROLLBACK.
+type txEndRecorder struct {
+	mu    sync.Mutex
+	ended []string
+}
+
+func (h *txEndRecorder) Name() string { return "tx-end" }
+func (h *txEndRecorder) Before(ctx context.Context, hookCtx *HookContext) error {
+	if hookCtx.Command == "ROLLBACK;" {
+		return errors.New("rollback rejected")
+	}
+	return nil
+}
+func (h *txEndRecorder) After(ctx context.Context, hookCtx *HookContext) error { return nil }
+func (h *txEndRecorder) TransactionEnded(txID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ended = append(h.ended, txID)
+}
+
+// TestTransactionEndHook verifies hooks learn of every released transaction,
+// including one the timeout monitor reclaims after its rollback fails.
+func TestTransactionEndHook(t *testing.T) {
+	c, _ := newPipeClient(t, countingBeginResponder())
+	c.opts.TransactionTimeout = time.Millisecond
+	hook := &txEndRecorder{}
+	c.RegisterHook(hook)
+
+	committed, err := c.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := committed.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	abandoned, err := c.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	c.checkAbandonedTransactions()
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.ended) != 2 || hook.ended[0] != committed.ID() || hook.ended[1] != abandoned.ID() {
+		t.Errorf("expected both transactions to be released once, got %v", hook.ended)
+	}
+}
+
+// waitForCommands waits until the server has received n commands.
+func waitForCommands(t *testing.T, server *pipeServer, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got := server.received()
+		if len(got) >= n || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestTransactionContextCancelRollsBack verifies cancelling the Begin context
+// rolls the transaction back and fails later operations.
+func TestTransactionContextCancelRollsBack(t *testing.T) {
+	c, server := newPipeClient(t, countingBeginResponder())
+	ctx, cancel := context.WithCancel(context.Background())
+	tx, err := c.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	cancel()
+	if got := waitForCommands(t, server, 2); len(got) != 2 || got[1] != "ROLLBACK;" {
+		t.Fatalf("expected an automatic rollback, got %v", got)
+	}
+	if len(c.ActiveTransactions()) != 0 {
+		t.Error("expected the transaction to be released")
+	}
+
+	_, err = tx.Query("SELECT * FROM BUNDLE \"users\";", 0)
+	if ErrorCode(err) != "E_TX_CONTEXT_CANCELLED" {
+		t.Fatalf("expected E_TX_CONTEXT_CANCELLED, got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, ErrTransactionClosed) {
+		t.Errorf("expected the error to match context.Canceled and ErrTransactionClosed, got %v", err)
+	}
+	if err := tx.Commit(); ErrorCode(err) != "E_TX_CONTEXT_CANCELLED" {
+		t.Errorf("expected Commit to fail with E_TX_CONTEXT_CANCELLED, got %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Errorf("expected Rollback to be a no-op, got %v", err)
+	}
+	if got := server.received(); len(got) != 2 {
+		t.Errorf("expected no further commands, got %v", got)
+	}
+}
+
+// TestTransactionContextDeadline verifies a context that has timed out fails
+// operations even before the automatic rollback runs.
+func TestTransactionContextDeadline(t *testing.T) {
+	c, _ := newPipeClient(t, countingBeginResponder())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	tx, err := c.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	<-ctx.Done()
+	err = tx.Commit()
+	if ErrorCode(err) != "E_TX_CONTEXT_CANCELLED" || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected E_TX_CONTEXT_CANCELLED caused by the deadline, got %v", err)
+	}
+}
+
+// TestTransactionCommitStopsContextWatch verifies a committed transaction is
+// not rolled back when its context is cancelled afterwards.
+func TestTransactionCommitStopsContextWatch(t *testing.T) {
+	c, server := newPipeClient(t, countingBeginResponder())
+	ctx, cancel := context.WithCancel(context.Background())
+	tx, err := c.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	if got := server.received(); len(got) != 2 || got[1] != "COMMIT;" {
+		t.Errorf("expected no rollback after commit, got %v", got)
+	}
+}
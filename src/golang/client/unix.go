@@ -0,0 +1,49 @@
+package client
+
+import (
+	"strings"
+)
+
+// unixScheme is the scheme of connection strings for a server listening on
+// a Unix domain socket.
+// Format: syndrdb+unix:///PATH/TO/SOCKET:DATABASE:USERNAME:PASSWORD;
+const unixScheme = "syndrdb+unix://"
+
+// unixAddressPrefix marks an address passed to NewConnection as a Unix
+// domain socket path rather than a host:port.
+const unixAddressPrefix = "unix:"
+
+// unixHandshakeHost is the host:port sent in the handshake of a Unix socket
+// connection, which has neither.
+const unixHandshakeHost = "localhost:0"
+
+// UnixAddress returns the NewConnection address of the Unix domain socket at path.
+func UnixAddress(path string) string {
+	return unixAddressPrefix + path
+}
+
+// unixSocketPath returns the socket path of an address made by UnixAddress.
+func unixSocketPath(address string) (string, bool) {
+	return strings.CutPrefix(address, unixAddressPrefix)
+}
+
+// parseUnixConnString splits a syndrdb+unix:// connection string into the
+// socket path and the syndrdb:// handshake sent to the server, which carries
+// the database, credentials and options unchanged. The path runs to the
+// first ':'.
+func parseUnixConnString(connStr string) (path, handshake string, err error) {
+	withoutScheme := strings.TrimPrefix(connStr, unixScheme)
+	path, rest, found := strings.Cut(withoutScheme, ":")
+	if !found || path == "" || rest == "" {
+		return "", "", &ConnectionError{
+			Code:    "INVALID_CONNECTION_STRING",
+			Type:    "CONNECTION_ERROR",
+			Message: "invalid connection string format",
+			Details: map[string]interface{}{
+				"connectionString": connStr,
+				"expected":         unixScheme + "/PATH/TO/SOCKET:DATABASE:USERNAME:PASSWORD;",
+			},
+		}
+	}
+	return path, "syndrdb://" + unixHandshakeHost + ":" + rest, nil
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// TestParseUnixConnString verifies the socket path is split from the handshake.
+func TestParseUnixConnString(t *testing.T) {
+	path, handshake, err := parseUnixConnString("syndrdb+unix:///var/run/syndrdb.sock:primary:root:secret;?tls=false")
+	if err != nil {
+		t.Fatalf("parseUnixConnString failed: %v", err)
+	}
+	if path != "/var/run/syndrdb.sock" {
+		t.Errorf("unexpected path %q", path)
+	}
+	if want := "syndrdb://localhost:0:primary:root:secret;?tls=false"; handshake != want {
+		t.Errorf("got handshake %q, want %q", handshake, want)
+	}
+
+	for _, connStr := range []string{"syndrdb+unix://", "syndrdb+unix:///var/run/syndrdb.sock", "syndrdb+unix://:primary:root:secret;"} {
+		if _, _, err := parseUnixConnString(connStr); ErrorCode(err) != "INVALID_CONNECTION_STRING" {
+			t.Errorf("%q: expected INVALID_CONNECTION_STRING, got %v", connStr, err)
+		}
+	}
+}
+
+// TestUnixSocketPool verifies a pooled client connects over a Unix domain socket.
+func TestUnixSocketPool(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "syndrdb.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("cannot listen on a Unix socket: %v", err)
+	}
+	defer ln.Close()
+
+	var mu sync.Mutex
+	var handshakes []string
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for first := true; ; first = false {
+					command, err := reader.ReadString('\x04')
+					if err != nil {
+						return
+					}
+					response := `{"ok":true}` + "\n"
+					if first {
+						mu.Lock()
+						handshakes = append(handshakes, command[:len(command)-1])
+						mu.Unlock()
+						response = "S0001::Welcome\n" + `{"status":"success"}` + "\n"
+					}
+					if _, err := conn.Write([]byte(response)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	opts := DefaultOptions()
+	opts.Logger = NewNoopLogger()
+	opts.PoolMinSize = 2
+	opts.PoolMaxSize = 4
+	c := NewClient(&opts)
+	if err := c.Connect(context.Background(), "syndrdb+unix://"+socket+":primary:root:root;"); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer c.Disconnect(context.Background())
+
+	result, err := c.Query(`SELECT * FROM BUNDLE "users";`, 1000)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, map[string]interface{}{"ok": true}) {
+		t.Errorf("unexpected result %v", result)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handshakes) < 2 {
+		t.Fatalf("expected the pool to open at least 2 connections, got %d", len(handshakes))
+	}
+	for _, handshake := range handshakes {
+		if handshake != "syndrdb://localhost:0:primary:root:root;" {
+			t.Errorf("unexpected handshake %q", handshake)
+		}
+	}
+}
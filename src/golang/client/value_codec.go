@@ -0,0 +1,195 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// valueCodec converts Go values the server has no literal for to their wire
+// form before a query is rendered: time.Time values to DATETIME strings,
+// arbitrary-precision numbers to exact decimal literals, geospatial values to
+// GeoJSON, and arrays and nested objects to JSON literals. The zero value
+// formats times as RFC 3339 with nanoseconds in UTC.
+type valueCodec struct {
+	layout string
+	zone   *time.Location
+}
+
+// wireLiteral is a value already in SyndrQL literal form, such as an exact
+// decimal or a GeoJSON object. It is rendered unquoted, unlike strings.
+type wireLiteral string
+
+func newValueCodec(opts ClientOptions) valueCodec {
+	return valueCodec{layout: opts.DateTimeLayout, zone: opts.TimeZone}
+}
+
+// encode returns value in its wire form; ok is false if value needs no
+// conversion. Slices, arrays and maps become JSON array and object literals,
+// with their elements encoded the same way, and other structs are encoded
+// with encoding/json.
+func (vc valueCodec) encode(value interface{}) (interface{}, bool) {
+	if encoded, ok := vc.encodeScalar(value); ok {
+		return encoded, true
+	}
+	v := reflect.ValueOf(value)
+	if isContainer(v) {
+		return wireLiteral(vc.appendLiteral(nil, value)), true
+	}
+	if v.Kind() == reflect.Struct {
+		return wireLiteral(appendJSON(nil, value)), true
+	}
+	return value, false
+}
+
+// encodeScalar converts times, decimals and geospatial values.
+func (vc valueCodec) encodeScalar(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return vc.format(v), true
+	case *time.Time:
+		if v == nil {
+			return nil, true
+		}
+		return vc.format(*v), true
+	case *big.Rat, big.Rat, *big.Float, *big.Int, DecimalValue:
+		return encodeDecimal(v), true
+	case GeoPoint, *GeoPoint, GeoPolygon, geoCircle:
+		return encodeGeo(v), true
+	}
+	return value, false
+}
+
+// isContainer reports whether v is a slice, array or map rendered as a JSON
+// literal. Byte slices are left to the caller, as they were before.
+func isContainer(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return true
+	}
+	return false
+}
+
+// appendLiteral appends the JSON literal of value to dst. Map keys are
+// sorted so the same document always renders the same query.
+func (vc valueCodec) appendLiteral(dst []byte, value interface{}) []byte {
+	if encoded, ok := vc.encodeScalar(value); ok {
+		value = encoded
+	}
+	if literal, ok := value.(wireLiteral); ok {
+		return append(dst, literal...)
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case !isContainer(v):
+		return appendJSON(dst, value)
+	case v.Kind() != reflect.Array && v.IsNil():
+		return append(dst, "null"...)
+	case v.Kind() == reflect.Map:
+		keys := make([]string, 0, v.Len())
+		members := make(map[string]interface{}, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key := fmt.Sprint(iter.Key().Interface())
+			keys = append(keys, key)
+			members[key] = iter.Value().Interface()
+		}
+		sort.Strings(keys)
+		dst = append(dst, '{')
+		for i, key := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSON(dst, key)
+			dst = append(dst, ':')
+			dst = vc.appendLiteral(dst, members[key])
+		}
+		return append(dst, '}')
+	default:
+		dst = append(dst, '[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = vc.appendLiteral(dst, v.Index(i).Interface())
+		}
+		return append(dst, ']')
+	}
+}
+
+// appendJSON appends the JSON encoding of a scalar value, or of its string
+// form if it has none.
+func appendJSON(dst []byte, value interface{}) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(value))
+	}
+	return append(dst, data...)
+}
+
+// encodeValues returns values in wire form, copying only if needed.
+func (vc valueCodec) encodeValues(values map[string]interface{}) map[string]interface{} {
+	var encoded map[string]interface{}
+	for field, value := range values {
+		formatted, ok := vc.encode(value)
+		if !ok {
+			continue
+		}
+		if encoded == nil {
+			encoded = make(map[string]interface{}, len(values))
+			for k, v := range values {
+				encoded[k] = v
+			}
+		}
+		encoded[field] = formatted
+	}
+	if encoded == nil {
+		return values
+	}
+	return encoded
+}
+
+// encodeWhere returns clauses with operands in wire form, copying only if needed.
+func (vc valueCodec) encodeWhere(clauses []whereClause) []whereClause {
+	var encoded []whereClause
+	for i, clause := range clauses {
+		formatted, ok := vc.encode(clause.value)
+		if !ok {
+			continue
+		}
+		if encoded == nil {
+			encoded = make([]whereClause, len(clauses))
+			copy(encoded, clauses)
+		}
+		encoded[i].value = formatted
+	}
+	if encoded == nil {
+		return clauses
+	}
+	return encoded
+}
+
+// encodeParams returns params in wire form, copying only if needed.
+func (vc valueCodec) encodeParams(params []interface{}) []interface{} {
+	var encoded []interface{}
+	for i, param := range params {
+		formatted, ok := vc.encode(param)
+		if !ok {
+			continue
+		}
+		if encoded == nil {
+			encoded = make([]interface{}, len(params))
+			copy(encoded, params)
+		}
+		encoded[i] = formatted
+	}
+	if encoded == nil {
+		return params
+	}
+	return encoded
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValueCodecLiterals(t *testing.T) {
+	codec := valueCodec{layout: time.DateTime}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	type dimensions struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{"strings", []string{"a", `say "hi"`}, wireLiteral(`["a","say \"hi\""]`)},
+		{"nested", map[string]interface{}{"tags": []interface{}{"x", 1}, "city": "Berlin", "zip": nil}, wireLiteral(`{"city":"Berlin","tags":["x",1],"zip":null}`)},
+		{"elements encoded", []interface{}{at, big.NewRat(1, 4), ferryBuilding}, wireLiteral(`["2026-03-01 12:00:00",0.25,{"type":"Point","coordinates":[-122.3937,37.7955]}]`)},
+		{"struct", dimensions{Width: 2, Height: 3}, wireLiteral(`{"width":2,"height":3}`)},
+		{"empty", []int{}, wireLiteral(`[]`)},
+		{"nil slice", []string(nil), wireLiteral(`null`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := codec.encode(tt.value)
+			if !ok || got != tt.want {
+				t.Errorf("expected %v, got %v (%v)", tt.want, got, ok)
+			}
+		})
+	}
+
+	for _, scalar := range []interface{}{"text", 42, 1.5, true, nil, []byte("raw")} {
+		if _, ok := codec.encode(scalar); ok {
+			t.Errorf("expected %v to be left unchanged", scalar)
+		}
+	}
+}
+
+func TestBuildersEncodeNestedValues(t *testing.T) {
+	c, server := newPipeClient(t, func(command string) string {
+		return `{"success": true, "data": {"affected_count": 1}}`
+	})
+	ctx := context.Background()
+
+	if _, err := c.InsertBuilder("users").Values(map[string]interface{}{
+		"address": map[string]interface{}{"city": "Berlin", "zip": "10115"},
+	}).Execute(ctx); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if _, err := c.UpdateBuilder("users").Set("tags", []string{"admin"}).Where("tags", Contains, "staff").Execute(ctx); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	want := []string{
+		`"address" =  {"city":"Berlin","zip":"10115"}`,
+		`"tags" = ["admin"]) WHERE "tags" CONTAINS "staff"`,
+	}
+	for i, command := range server.received() {
+		if !strings.Contains(command, want[i]) {
+			t.Errorf("command %d: expected %s in %s", i, want[i], command)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WireCapture writes the raw frames exchanged with the server, one line per
+// frame, so protocol problems between the driver and the server can be
+// diagnosed without a packet capture. Set it with ClientOptions.WireCapture.
+//
+// Each line holds a UTC timestamp, the connection ID, the trace ID of the
+// command, the direction (">" sent, "<" received) and the frame as a quoted
+// Go string, e.g.
+//
+//	2026-01-02T15:04:05.000000Z conn=3 trace=4f1c... > "SELECT * FROM BUNDLE \"users\";"
+//
+// Frames are written before decompression. Handshake passwords are always
+// masked.
+type WireCapture struct {
+	w         io.Writer
+	redaction *RedactionPolicy
+	mu        sync.Mutex
+}
+
+// NewWireCapture returns a capture writing to w. A non-nil redaction masks
+// sensitive values in the captured frames; see RedactionPolicy.
+func NewWireCapture(w io.Writer, redaction *RedactionPolicy) *WireCapture {
+	return &WireCapture{w: w, redaction: redaction}
+}
+
+// wireCaptureTimeLayout formats capture timestamps.
+const wireCaptureTimeLayout = "2006-01-02T15:04:05.000000Z"
+
+// wireTraceKey carries the trace ID of the command being sent to the
+// connection, so its frames can be attributed to it.
+type wireTraceKey struct{}
+
+// wireTraceID returns the trace ID for frames sent or received with ctx.
+func wireTraceID(ctx context.Context) string {
+	if id, ok := ctx.Value(wireTraceKey{}).(string); ok {
+		return id
+	}
+	if id := TraceIDFromContext(ctx); id != "" {
+		return id
+	}
+	return "-"
+}
+
+// sent records a command written to connection connID.
+func (w *WireCapture) sent(ctx context.Context, connID uint64, command string) {
+	w.write(ctx, connID, ">", w.redaction.RedactCommand(redactHandshake(command)))
+}
+
+// received records a frame read from connection connID.
+func (w *WireCapture) received(ctx context.Context, connID uint64, frame []byte) {
+	w.write(ctx, connID, "<", w.redaction.RedactCommand(string(frame)))
+}
+
+func (w *WireCapture) write(ctx context.Context, connID uint64, direction, frame string) {
+	line := fmt.Sprintf("%s conn=%d trace=%s %s %s\n",
+		time.Now().UTC().Format(wireCaptureTimeLayout), connID, wireTraceID(ctx), direction, strconv.Quote(frame))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// A failing capture must not fail the command
+	io.WriteString(w.w, line)
+}
+
+// RotatingFile is an io.WriteCloser that starts a new file once the current
+// one reaches MaxBytes, keeping MaxBackups old files as path.1 (newest)
+// through path.N. It is safe for concurrent use.
+type RotatingFile struct {
+	path       string
+	maxBytes   int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFile opens path for appending, rotating it after maxBytes and
+// keeping maxBackups old files. maxBytes <= 0 never rotates.
+func NewRotatingFile(path string, maxBytes int64, maxBackups int) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+// Write appends p, first rotating the file if p would take it past MaxBytes.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.maxBytes > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the backups along, dropping the oldest, and starts a new
+// file. Must be called with r.mu locked.
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	if r.maxBackups > 0 {
+		for i := r.maxBackups - 1; i >= 1; i-- {
+			os.Rename(r.backupPath(i), r.backupPath(i+1))
+		}
+		if err := os.Rename(r.path, r.backupPath(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) backupPath(n int) string {
+	return r.path + "." + strconv.Itoa(n)
+}
+
+// Close closes the current file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// wireLinePattern matches one captured frame.
+var wireLinePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[\d:.]+Z conn=(\d+) trace=(\S+) ([<>]) (".*")$`)
+
+// TestWireCapture verifies both directions of a command are captured with
+// the command's trace ID and sensitive values masked.
+func TestWireCapture(t *testing.T) {
+	c, _ := newPipeClient(t, func(command string) string {
+		return `{"token":"abc123","name":"alice"}`
+	})
+	var buf bytes.Buffer
+	capture := NewWireCapture(&buf, DefaultRedactionPolicy())
+	c.opts.WireCapture = capture
+	c.conn.capture = capture
+
+	ctx := WithTraceID(context.Background(), "trace-1")
+	if _, err := c.sendCommand(ctx, `UPDATE DOCUMENTS IN BUNDLE "users" ("password" = "hunter2") CONFIRMED;`); err != nil {
+		t.Fatalf("sendCommand failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 captured frames, got %q", lines)
+	}
+	for i, want := range []string{">", "<"} {
+		match := wireLinePattern.FindStringSubmatch(lines[i])
+		if match == nil {
+			t.Fatalf("unexpected capture line %q", lines[i])
+		}
+		if match[2] != "trace-1" || match[3] != want {
+			t.Errorf("line %d: expected trace-1 %s, got %q", i, want, lines[i])
+		}
+	}
+	if strings.Contains(buf.String(), "hunter2") || strings.Contains(buf.String(), "abc123") {
+		t.Errorf("expected sensitive values to be masked, got %q", buf.String())
+	}
+	if !strings.Contains(lines[1], `alice`) {
+		t.Errorf("expected the response frame, got %q", lines[1])
+	}
+}
+
+// TestWireCaptureHandshake verifies handshake passwords are masked without a policy.
+func TestWireCaptureHandshake(t *testing.T) {
+	var buf bytes.Buffer
+	NewWireCapture(&buf, nil).sent(context.Background(), 7, "syndrdb://localhost:1776:primary:root:secret;")
+
+	line := buf.String()
+	if strings.Contains(line, "secret") || !strings.Contains(line, "conn=7 trace=- >") {
+		t.Errorf("unexpected capture line %q", line)
+	}
+}
+
+// TestRotatingFile verifies files rotate at MaxBytes and old backups are dropped.
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wire.log")
+	f, err := NewRotatingFile(path, 50, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"1", "2", "3", "4"} {
+		if _, err := f.Write([]byte(strings.Repeat(line, 29) + "\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	for file, want := range map[string]string{path: "4", path + ".1": "3", path + ".2": "2"} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("reading %s: %v", file, err)
+		}
+		if string(data) != strings.Repeat(want, 29)+"\n" {
+			t.Errorf("%s: unexpected contents %q", file, data)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only 2 backups, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+func printDumpUsage() {
+	printHeader("Dump Bundle")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb dump --bundle " + colorYellow("<name>") + " [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn         Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --bundle       Bundle to dump (required)")
+	fmt.Println("  --out          Output file (default: stdout)")
+	fmt.Println("  --schema       Include the bundle definition, so restore --create can recreate it")
+	fmt.Println("  --batch-size   Documents fetched per query (default: 1000)")
+	fmt.Println("\nThe dump is NDJSON: a header line, one line per document, and a trailer")
+	fmt.Println("with the document count and a SHA-256 checksum.")
+	fmt.Println("\nExamples:")
+	fmt.Println("  syndrdb dump --bundle users --schema --out users.dump")
+	fmt.Println("  syndrdb dump --bundle users | gzip > users.dump.gz")
+}
+
+func printRestoreUsage() {
+	printHeader("Restore Bundle")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb restore --file " + colorYellow("<path>") + " [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn          Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --file          Dump file, or - for stdin (required)")
+	fmt.Println("  --bundle        Target bundle (default: the bundle the dump was taken from)")
+	fmt.Println("  --create        Create the bundle and its indexes from the dump's schema")
+	fmt.Println("  --batch-size    Documents sent per round trip (default: 500)")
+	fmt.Println("  --verify-only   Check the dump's checksum without connecting")
+	fmt.Println("\nDump files are verified before anything is restored. Dumps read from stdin")
+	fmt.Println("are verified as they are restored, so a corrupt one is partially restored.")
+	fmt.Println("\nExamples:")
+	fmt.Println("  syndrdb restore --file users.dump")
+	fmt.Println("  syndrdb restore --file users.dump --bundle users_copy --create")
+	fmt.Println("  gunzip -c users.dump.gz | syndrdb restore --file -")
+}
+
+// backupResult is the structured output of dump and restore.
+type backupResult struct {
+	Bundle     string  `json:"bundle"`
+	File       string  `json:"file"`
+	Documents  int     `json:"documents"`
+	Failed     int     `json:"failed"`
+	Checksum   string  `json:"checksum"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// handleDump writes a bundle to a dump file with client.DumpBundle
+func handleDump(args []string) {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	bundle := fs.String("bundle", "", "Bundle to dump (required)")
+	out := fs.String("out", "", "Output file (default: stdout)")
+	withSchema := fs.Bool("schema", false, "Include the bundle definition")
+	batchSize := fs.Int("batch-size", 1000, "Documents fetched per query")
+	fs.Usage = printDumpUsage
+	fs.Parse(args)
+
+	if *bundle == "" {
+		printError("Bundle is required")
+		printDumpUsage()
+		os.Exit(exitValidation)
+	}
+	if *batchSize < 1 {
+		printError("--batch-size must be at least 1")
+		os.Exit(exitValidation)
+	}
+
+	c := connectDataClient(*connStr)
+	defer c.Disconnect(context.Background())
+
+	// Write to stdout unless --out is given; progress goes to stderr either way
+	output := resultWriter
+	var file *os.File
+	if *out != "" {
+		if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+			printError(fmt.Sprintf("Failed to create directory: %v", err))
+			os.Exit(1)
+		}
+		var err error
+		file, err = os.Create(*out)
+		if err != nil {
+			printError(fmt.Sprintf("Failed to create output file: %v", err))
+			os.Exit(1)
+		}
+		output = file
+	}
+
+	start := time.Now()
+	opts := []client.BackupOption{
+		client.WithBackupBatchSize(*batchSize),
+		client.WithBackupProgress(func(n int) { printProgress("Dumped", n, start) }),
+	}
+	if *withSchema {
+		opts = append(opts, client.WithSchemaDDL())
+	}
+	manifest, err := c.DumpBundle(context.Background(), *bundle, output, opts...)
+	if err == nil && file != nil {
+		err = file.Close()
+	}
+	clearProgress()
+	if err != nil {
+		printError(fmt.Sprintf("Dump failed: %v", err))
+		os.Exit(1)
+	}
+
+	message := fmt.Sprintf("Dumped %d document(s) from %s in %s", manifest.Documents, colorCyan(*bundle), time.Since(start).Round(time.Millisecond))
+	fmt.Fprintln(os.Stderr, colorGreen("✓")+" "+message)
+	fmt.Fprintln(os.Stderr, colorDim("  checksum "+manifest.Checksum))
+
+	// Without --out, the dump itself is the result
+	if structuredOutput() && *out != "" {
+		emit(backupResult{
+			Bundle:     *bundle,
+			File:       *out,
+			Documents:  manifest.Documents,
+			Checksum:   manifest.Checksum,
+			DurationMs: milliseconds(time.Since(start)),
+		})
+	}
+}
+
+// handleRestore loads a dump file into a bundle with client.RestoreBundle
+func handleRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	file := fs.String("file", "", "Dump file, or - for stdin (required)")
+	bundle := fs.String("bundle", "", "Target bundle (default: the dumped bundle)")
+	create := fs.Bool("create", false, "Create the bundle from the dump's schema")
+	batchSize := fs.Int("batch-size", 500, "Documents sent per round trip")
+	verifyOnly := fs.Bool("verify-only", false, "Verify the dump without restoring it")
+	fs.Usage = printRestoreUsage
+	fs.Parse(args)
+
+	if *file == "" {
+		printError("File is required")
+		printRestoreUsage()
+		os.Exit(exitValidation)
+	}
+	if *batchSize < 1 {
+		printError("--batch-size must be at least 1")
+		os.Exit(exitValidation)
+	}
+
+	input := io.Reader(os.Stdin)
+	var f *os.File
+	if *file != "-" {
+		var err error
+		f, err = os.Open(*file)
+		if err != nil {
+			printError(fmt.Sprintf("Failed to open dump file: %v", err))
+			os.Exit(1)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	start := time.Now()
+
+	// Verify files before restoring, so a corrupt dump restores nothing
+	if f != nil || *verifyOnly {
+		manifest, err := client.VerifyDump(input)
+		if err != nil {
+			printError(fmt.Sprintf("Dump verification failed: %v", err))
+			os.Exit(exitValidation)
+		}
+		if *verifyOnly {
+			if structuredOutput() {
+				emit(backupResult{
+					Bundle:     manifest.Bundle,
+					File:       *file,
+					Documents:  manifest.Documents,
+					Checksum:   manifest.Checksum,
+					DurationMs: milliseconds(time.Since(start)),
+				})
+				return
+			}
+			printSuccess(fmt.Sprintf("Dump of %s is intact: %d document(s), %s", colorCyan(manifest.Bundle), manifest.Documents, manifest.Checksum))
+			return
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			printError(fmt.Sprintf("Failed to read dump file: %v", err))
+			os.Exit(1)
+		}
+	}
+
+	c := connectDataClient(*connStr)
+	defer c.Disconnect(context.Background())
+
+	opts := []client.BackupOption{
+		client.WithBackupBatchSize(*batchSize),
+		client.WithBackupProgress(func(n int) { printProgress("Restored", n, start) }),
+	}
+	if *create {
+		opts = append(opts, client.WithCreateBundle())
+	}
+	result, err := c.RestoreBundle(context.Background(), *bundle, input, opts...)
+	clearProgress()
+	if result == nil || err != nil && !isRestoreIncomplete(err) {
+		printError(fmt.Sprintf("Restore failed: %v", err))
+		if errors.Is(err, client.ErrDumpCorrupt) {
+			os.Exit(exitValidation)
+		}
+		os.Exit(1)
+	}
+
+	target := *bundle
+	if target == "" {
+		target = result.Manifest.Bundle
+	}
+	if structuredOutput() {
+		emit(backupResult{
+			Bundle:     target,
+			File:       *file,
+			Documents:  result.Restored,
+			Failed:     result.Failed,
+			Checksum:   result.Manifest.Checksum,
+			DurationMs: milliseconds(time.Since(start)),
+		})
+	}
+	message := fmt.Sprintf("Restored %d document(s) into %s in %s", result.Restored, colorCyan(target), time.Since(start).Round(time.Millisecond))
+	if err != nil {
+		printError(fmt.Sprintf("%s; %d failed: %v", message, result.Failed, errors.Unwrap(err)))
+		os.Exit(1)
+	}
+	printSuccess(message)
+}
+
+// isRestoreIncomplete reports whether err is RestoreBundle's report of
+// documents the server rejected, rather than a failure of the restore.
+func isRestoreIncomplete(err error) bool {
+	var queryErr *client.QueryError
+	return errors.As(err, &queryErr) && queryErr.Code == "E_RESTORE_INCOMPLETE"
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+func TestIsRestoreIncomplete(t *testing.T) {
+	incomplete := &client.QueryError{
+		Code:  "E_RESTORE_INCOMPLETE",
+		Type:  "QueryError",
+		Cause: &client.ProtocolError{Code: "SERVER_ERROR", Type: "PROTOCOL_ERROR", Message: "duplicate key"},
+	}
+	if !isRestoreIncomplete(incomplete) {
+		t.Error("expected rejected documents to be reported as an incomplete restore")
+	}
+	if !isRestoreIncomplete(fmt.Errorf("restore: %w", incomplete)) {
+		t.Error("expected a wrapped incomplete restore to be recognized")
+	}
+	if isRestoreIncomplete(&client.QueryError{Code: "E_DUMP_CHECKSUM_MISMATCH"}) || isRestoreIncomplete(errors.New("EOF")) {
+		t.Error("expected other errors to be restore failures")
+	}
+}
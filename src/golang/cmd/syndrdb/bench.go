@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// Benchmark workloads.
+const (
+	workloadRead  = "read"
+	workloadWrite = "write"
+	workloadMixed = "mixed"
+)
+
+// benchReadRatio is the share of reads in the mixed workload.
+const benchReadRatio = 0.8
+
+func printBenchUsage() {
+	printHeader("Benchmark")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb bench [options]")
+	fmt.Println()
+	fmt.Println("Drives load through the client's connection pool against a scratch bundle")
+	fmt.Println("and reports throughput, latency percentiles and error rates.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn          Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --concurrency   Concurrent workers and pooled connections (default: 8)")
+	fmt.Println("  --duration      How long to measure (default: 30s)")
+	fmt.Println("  --workload      read, write or mixed (80% reads) (default: mixed)")
+	fmt.Println("  --seed          Documents inserted before measuring reads (default: 1000)")
+	fmt.Println("  --keep          Keep the scratch bundle afterwards")
+	fmt.Println("  --json          Print the report as JSON")
+	fmt.Println("\nExamples:")
+	fmt.Println("  syndrdb bench --concurrency 32 --duration 1m --workload read")
+	fmt.Println("  syndrdb bench --workload write --json > bench.json")
+}
+
+// benchConfig configures runBench.
+type benchConfig struct {
+	bundle      string
+	concurrency int
+	duration    time.Duration
+	workload    string
+	seed        int
+}
+
+// benchStats summarizes the operations of one kind, or of all kinds.
+type benchStats struct {
+	Ops        int     `json:"ops"`
+	Errors     int     `json:"errors"`
+	ErrorRate  float64 `json:"error_rate"`
+	Throughput float64 `json:"ops_per_sec"`
+	P50Ms      float64 `json:"p50_ms"`
+	P95Ms      float64 `json:"p95_ms"`
+	P99Ms      float64 `json:"p99_ms"`
+	MaxMs      float64 `json:"max_ms"`
+}
+
+// benchReport is the result of `syndrdb bench`, printed with --output json or yaml.
+type benchReport struct {
+	Workload    string                `json:"workload"`
+	Concurrency int                   `json:"concurrency"`
+	DurationSec float64               `json:"duration_sec"`
+	Total       benchStats            `json:"total"`
+	Operations  map[string]benchStats `json:"operations"`
+	FirstError  string                `json:"first_error,omitempty"`
+}
+
+// benchSample is the outcome of one operation.
+type benchSample struct {
+	op      string
+	latency float64 // milliseconds
+	err     error
+}
+
+// handleBench runs a load test and prints the report.
+func handleBench(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	concurrency := fs.Int("concurrency", 8, "Concurrent workers and pooled connections")
+	duration := fs.Duration("duration", 30*time.Second, "How long to measure")
+	workload := fs.String("workload", workloadMixed, "read, write or mixed")
+	seed := fs.Int("seed", 1000, "Documents inserted before measuring reads")
+	keep := fs.Bool("keep", false, "Keep the scratch bundle afterwards")
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON (same as --output json)")
+	fs.Usage = printBenchUsage
+	fs.Parse(args)
+	if *jsonOutput {
+		setOutputFormat(outputJSON)
+	}
+
+	switch {
+	case *connStr == "":
+		printError("Connection string is required")
+		fmt.Fprintln(os.Stderr, "\nProvide via --conn flag or SYNDRDB_CONN environment variable")
+		os.Exit(exitValidation)
+	case *workload != workloadRead && *workload != workloadWrite && *workload != workloadMixed:
+		printError(fmt.Sprintf("Unknown workload %q (want read, write or mixed)", *workload))
+		os.Exit(exitValidation)
+	case *concurrency < 1 || *duration <= 0:
+		printError("--concurrency and --duration must be positive")
+		os.Exit(exitValidation)
+	}
+
+	// One pooled connection per worker, opened up front so connects are not measured
+	opts := cliOptions()
+	opts.Logger = client.NewNoopLogger()
+	opts.PoolMinSize = *concurrency
+	opts.PoolMaxSize = *concurrency
+	c := client.NewClient(opts)
+	ctx := context.Background()
+	if err := c.Connect(ctx, *connStr); err != nil {
+		printError(fmt.Sprintf("Failed to connect: %v", err))
+		os.Exit(exitConnection)
+	}
+	defer c.Disconnect(ctx)
+
+	cfg := benchConfig{
+		bundle:      fmt.Sprintf("syndrdb_bench_%d", time.Now().UnixNano()),
+		concurrency: *concurrency,
+		duration:    *duration,
+		workload:    *workload,
+		seed:        *seed,
+	}
+	if !structuredOutput() {
+		printInfo(fmt.Sprintf("Preparing %s with %d documents...", colorCyan(cfg.bundle), cfg.seed))
+	}
+	if err := setupBench(ctx, c, cfg); err != nil {
+		printError(fmt.Sprintf("Setup failed: %v", err))
+		os.Exit(1)
+	}
+	if !*keep {
+		defer c.Query(schema.SerializeDeleteBundle(cfg.bundle), 0)
+	}
+
+	if !structuredOutput() {
+		printInfo(fmt.Sprintf("Running %s workload with %d workers for %s...", cfg.workload, cfg.concurrency, cfg.duration))
+	}
+	report := runBench(ctx, c, cfg)
+
+	if structuredOutput() {
+		emit(report)
+		return
+	}
+	printHeader("Benchmark Results")
+	writeBenchReport(os.Stdout, report)
+}
+
+// setupBench creates the scratch bundle and, unless the workload only
+// writes, seeds it with cfg.seed documents for the readers to find.
+func setupBench(ctx context.Context, c *client.Client, cfg benchConfig) error {
+	create := schema.SerializeCreateBundle(&schema.BundleDefinition{
+		Name: cfg.bundle,
+		Fields: []schema.FieldDefinition{
+			{Name: "key", Type: schema.STRING, Required: true, Unique: true},
+			{Name: "value", Type: schema.INT},
+			{Name: "payload", Type: schema.STRING},
+		},
+	})
+	if _, err := c.Query(create, 0); err != nil {
+		return err
+	}
+	if cfg.workload == workloadWrite {
+		return nil
+	}
+
+	batch := make([]string, 0, 100)
+	for i := 0; i < cfg.seed; i++ {
+		command, err := client.AddDocumentCommand(cfg.bundle, benchDocument(fmt.Sprintf("k-%d", i), i))
+		if err != nil {
+			return err
+		}
+		batch = append(batch, command)
+		if len(batch) == cap(batch) || i == cfg.seed-1 {
+			results, err := c.Pipeline().Add(batch...).Execute(ctx)
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				if result.Error != nil {
+					return result.Error
+				}
+			}
+			batch = batch[:0]
+		}
+	}
+	return nil
+}
+
+// benchDocument returns the document written for key.
+func benchDocument(key string, value int) map[string]interface{} {
+	return map[string]interface{}{
+		"key":     key,
+		"value":   value,
+		"payload": strings.Repeat("x", 64),
+	}
+}
+
+// runBench runs cfg.concurrency workers against the scratch bundle for
+// cfg.duration. Each worker records its own samples, so measuring needs no
+// locking; operations still in flight at the deadline are counted.
+func runBench(ctx context.Context, c *client.Client, cfg benchConfig) *benchReport {
+	deadline := time.Now().Add(cfg.duration)
+	samples := make([][]benchSample, cfg.concurrency)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.concurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(worker) + 1))
+			for n := 0; time.Now().Before(deadline); n++ {
+				op := cfg.workload
+				if op == workloadMixed {
+					op = workloadWrite
+					if rng.Float64() < benchReadRatio {
+						op = workloadRead
+					}
+				}
+				if op == workloadRead && cfg.seed == 0 {
+					op = workloadWrite
+				}
+
+				opStart := time.Now()
+				var err error
+				if op == workloadRead {
+					key := fmt.Sprintf("k-%d", rng.Intn(cfg.seed))
+					_, err = c.QueryBuilder().Select(cfg.bundle).Where("key", client.Equals, key).Limit(1).Execute(ctx)
+				} else {
+					key := fmt.Sprintf("w-%d-%d", worker, n)
+					_, err = c.InsertBuilder(cfg.bundle).Values(benchDocument(key, n)).Execute(ctx)
+				}
+				samples[worker] = append(samples[worker], benchSample{op: op, latency: milliseconds(time.Since(opStart)), err: err})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	return summarizeBench(cfg, time.Since(start), samples)
+}
+
+// summarizeBench computes the report from every worker's samples.
+func summarizeBench(cfg benchConfig, elapsed time.Duration, samples [][]benchSample) *benchReport {
+	report := &benchReport{
+		Workload:    cfg.workload,
+		Concurrency: cfg.concurrency,
+		DurationSec: elapsed.Seconds(),
+		Operations:  make(map[string]benchStats),
+	}
+
+	var all []float64
+	latencies := make(map[string][]float64)
+	errors := make(map[string]int)
+	totalErrors := 0
+	for _, worker := range samples {
+		for _, sample := range worker {
+			all = append(all, sample.latency)
+			latencies[sample.op] = append(latencies[sample.op], sample.latency)
+			if sample.err != nil {
+				errors[sample.op]++
+				totalErrors++
+				if report.FirstError == "" {
+					report.FirstError = sample.err.Error()
+				}
+			}
+		}
+	}
+
+	report.Total = newBenchStats(all, totalErrors, elapsed)
+	for op, values := range latencies {
+		report.Operations[op] = newBenchStats(values, errors[op], elapsed)
+	}
+	return report
+}
+
+// newBenchStats computes the stats of latencies in milliseconds.
+func newBenchStats(latencies []float64, errors int, elapsed time.Duration) benchStats {
+	stats := benchStats{Ops: len(latencies), Errors: errors}
+	if len(latencies) == 0 {
+		return stats
+	}
+
+	sort.Float64s(latencies)
+	percentile := func(p float64) float64 {
+		return latencies[int(p*float64(len(latencies)-1)+0.5)]
+	}
+	stats.ErrorRate = float64(errors) / float64(len(latencies))
+	if elapsed > 0 {
+		stats.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	}
+	stats.P50Ms = percentile(0.50)
+	stats.P95Ms = percentile(0.95)
+	stats.P99Ms = percentile(0.99)
+	stats.MaxMs = latencies[len(latencies)-1]
+	return stats
+}
+
+// writeBenchReport prints the report as a table with a row per operation.
+func writeBenchReport(w io.Writer, report *benchReport) {
+	fmt.Fprintf(w, "  Workload %s, %d workers, %.1fs\n\n", report.Workload, report.Concurrency, report.DurationSec)
+
+	ops := make([]string, 0, len(report.Operations))
+	for op := range report.Operations {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	row := func(name string, stats benchStats) {
+		fmt.Fprintf(w, "  %-6s %8d %10.1f %7.2f%% %8.2f %8.2f %8.2f %8.2f\n",
+			name, stats.Ops, stats.Throughput, stats.ErrorRate*100, stats.P50Ms, stats.P95Ms, stats.P99Ms, stats.MaxMs)
+	}
+	fmt.Fprintln(w, colorBold(fmt.Sprintf("  %-6s %8s %10s %8s %8s %8s %8s %8s", "op", "ops", "ops/s", "errors", "p50 ms", "p95 ms", "p99 ms", "max ms")))
+	for _, op := range ops {
+		row(op, report.Operations[op])
+	}
+	if len(ops) > 1 {
+		row("total", report.Total)
+	}
+
+	if report.FirstError != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, colorYellow("⚠")+fmt.Sprintf(" %d operation(s) failed; first error: %s", report.Total.Errors, report.FirstError))
+	}
+}
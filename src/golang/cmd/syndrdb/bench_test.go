@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client/clienttest"
+)
+
+func TestRunBench(t *testing.T) {
+	server := clienttest.NewServer()
+	server.On(`CREATE BUNDLE "bench"*`).Return("created")
+	server.On(`ADD DOCUMENT TO BUNDLE*`).Return(map[string]interface{}{"affected_count": 1})
+	server.On(`SELECT * FROM bench WHERE key == 'k-0' LIMIT 1`).ReturnError("timeout")
+	server.On(`SELECT * FROM bench WHERE key ==*`).Return([]map[string]interface{}{{"key": "k-1"}})
+
+	opts := client.DefaultOptions()
+	opts.Logger = client.NewNoopLogger()
+	opts.PoolMinSize = 4
+	opts.PoolMaxSize = 4
+	c := clienttest.NewClient(t, server, &opts)
+
+	cfg := benchConfig{bundle: "bench", concurrency: 4, duration: 50 * time.Millisecond, workload: workloadMixed, seed: 3}
+	if err := setupBench(context.Background(), c, cfg); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	if got := server.CallCount(`ADD DOCUMENT TO BUNDLE*`); got != 3 {
+		t.Fatalf("expected 3 seeded documents, got %d", got)
+	}
+
+	report := runBench(context.Background(), c, cfg)
+	reads, writes := report.Operations[workloadRead], report.Operations[workloadWrite]
+	if reads.Ops == 0 || writes.Ops == 0 || reads.Ops+writes.Ops != report.Total.Ops {
+		t.Fatalf("expected reads and writes adding up to the total, got %+v", report)
+	}
+	if reads.Errors == 0 || writes.Errors != 0 || !strings.Contains(report.FirstError, "timeout") {
+		t.Errorf("expected only reads of k-0 to fail, got %+v", report)
+	}
+	if report.Total.Throughput <= 0 || report.Total.P50Ms > report.Total.P99Ms {
+		t.Errorf("unexpected total stats %+v", report.Total)
+	}
+}
+
+func TestSummarizeBench(t *testing.T) {
+	var samples [][]benchSample
+	for w := 0; w < 2; w++ {
+		var worker []benchSample
+		for i := 1; i <= 50; i++ {
+			worker = append(worker, benchSample{op: workloadRead, latency: float64(w*50 + i)})
+		}
+		samples = append(samples, worker)
+	}
+	samples[1] = append(samples[1], benchSample{op: workloadWrite, latency: 500, err: errors.New("disk full")})
+
+	report := summarizeBench(benchConfig{workload: workloadMixed, concurrency: 2}, 2*time.Second, samples)
+	reads := report.Operations[workloadRead]
+	if reads.Ops != 100 || reads.P50Ms != 51 || reads.P95Ms != 95 || reads.P99Ms != 99 || reads.MaxMs != 100 {
+		t.Errorf("unexpected read stats %+v", reads)
+	}
+	if report.Total.Ops != 101 || report.Total.Throughput != 50.5 || report.Total.MaxMs != 500 {
+		t.Errorf("unexpected total stats %+v", report.Total)
+	}
+	if writes := report.Operations[workloadWrite]; writes.Errors != 1 || writes.ErrorRate != 1 || report.FirstError != "disk full" {
+		t.Errorf("unexpected write stats %+v (%s)", writes, report.FirstError)
+	}
+
+	colorsEnabled = false
+	defer func() { colorsEnabled = true }()
+	var buf bytes.Buffer
+	writeBenchReport(&buf, report)
+	for _, want := range []string{
+		"Workload mixed, 2 workers, 2.0s",
+		"  read        100       50.0    0.00%    51.00    95.00    99.00   100.00",
+		"  total       101       50.5    0.99%",
+		"⚠ 1 operation(s) failed; first error: disk full",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, buf.String())
+		}
+	}
+}
@@ -17,7 +17,7 @@ import (
 func handleCodegen(args []string) {
 	if len(args) == 0 {
 		printCodegenUsage()
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	subcommand := args[0]
@@ -27,12 +27,18 @@ func handleCodegen(args []string) {
 		handleCodegenFetch(args[1:])
 	case "generate":
 		handleCodegenGenerate(args[1:])
+	case "openapi":
+		handleCodegenOpenAPI(args[1:])
+	case "proto":
+		handleCodegenProto(args[1:])
+	case "watch", "--watch":
+		handleCodegenWatch(args[1:])
 	case "help", "-h", "--help":
 		printCodegenUsage()
 	default:
 		printError(fmt.Sprintf("Unknown codegen subcommand: %s", subcommand))
 		printCodegenUsage()
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 }
 
@@ -43,6 +49,9 @@ func printCodegenUsage() {
 	fmt.Println("Commands:")
 	fmt.Println("  " + colorGreen("fetch-schema") + "  Fetch schema from database server")
 	fmt.Println("  " + colorGreen("generate") + "     Generate code from schema")
+	fmt.Println("  " + colorGreen("openapi") + "      Generate an OpenAPI 3.1 specification")
+	fmt.Println("  " + colorGreen("proto") + "        Generate Protobuf messages and gRPC services")
+	fmt.Println("  " + colorGreen("--watch") + "      Regenerate code whenever the schema changes")
 	fmt.Println("\nExamples:")
 	fmt.Println("  " + colorDim("# Fetch schema from server"))
 	fmt.Println("  syndrdb codegen fetch-schema --output ./schema.json")
@@ -55,12 +64,24 @@ func printCodegenUsage() {
 	fmt.Println()
 	fmt.Println("  " + colorDim("# Generate GraphQL Schema"))
 	fmt.Println("  syndrdb codegen generate --format graphql")
+	fmt.Println()
+	fmt.Println("  " + colorDim("# Generate GraphQL resolvers and SDL in one file"))
+	fmt.Println("  syndrdb codegen generate --format graphql-resolvers --language go --package api")
+	fmt.Println()
+	fmt.Println("  " + colorDim("# Generate an OpenAPI specification"))
+	fmt.Println("  syndrdb codegen openapi --output ./openapi.json --server https://api.example.com")
+	fmt.Println()
+	fmt.Println("  " + colorDim("# Generate Protobuf messages and CRUD services"))
+	fmt.Println("  syndrdb codegen proto --output ./proto/syndrdb.proto --services")
+	fmt.Println()
+	fmt.Println("  " + colorDim("# Regenerate TypeScript, JSON Schema and GraphQL on every schema change"))
+	fmt.Println("  syndrdb codegen --watch --out-dir ./generated")
 }
 
 // handleCodegenFetch fetches schema from the server
 func handleCodegenFetch(args []string) {
 	fs := flag.NewFlagSet("codegen fetch-schema", flag.ExitOnError)
-	connStr := fs.String("conn", os.Getenv("SYNDRDB_CONN"), "Connection string")
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
 	output := fs.String("output", getDefaultSchemaFile(), "Output file path")
 	format := fs.String("format", "json", "Output format (json, yaml)")
 	fs.Parse(args)
@@ -68,38 +89,30 @@ func handleCodegenFetch(args []string) {
 	if *connStr == "" {
 		printError("Connection string is required")
 		fmt.Println("\nProvide via --conn flag or SYNDRDB_CONN environment variable")
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	printHeader("Fetch Schema from Server")
 
 	// Connect to database
 	printStep(1, 3, "Connecting to database...")
-	opts := &client.ClientOptions{}
+	opts := cliOptions()
 	c := client.NewClient(opts)
 	ctx := context.Background()
 	if err := c.Connect(ctx, *connStr); err != nil {
 		printError(fmt.Sprintf("Failed to connect: %v", err))
-		os.Exit(1)
+		os.Exit(exitConnection)
 	}
 	defer c.Disconnect(ctx)
 	printSuccess("Connected")
 
 	// Fetch schema
 	printStep(2, 3, "Fetching schema...")
-	result, err := c.Query("SHOW BUNDLES;", 0)
+	schemaDef, err := c.GetSchema(ctx)
 	if err != nil {
 		printError(fmt.Sprintf("Failed to fetch schema: %v", err))
 		os.Exit(1)
 	}
-
-	// Parse response
-	resultJSON, _ := json.Marshal(result)
-	schemaDef, err := schema.ParseServerSchema(resultJSON)
-	if err != nil {
-		printError(fmt.Sprintf("Failed to parse schema: %v", err))
-		os.Exit(1)
-	}
 	printSuccess(fmt.Sprintf("Found %d bundle(s)", len(schemaDef.Bundles)))
 
 	// Write to file
@@ -118,11 +131,10 @@ func handleCodegenFetch(args []string) {
 	case "json":
 		data, err = json.MarshalIndent(schemaDef, "", "  ")
 	case "yaml":
-		printWarning("YAML format not yet implemented, using JSON")
-		data, err = json.MarshalIndent(schemaDef, "", "  ")
+		data, err = marshalYAML(schemaDef)
 	default:
 		printError(fmt.Sprintf("Unknown format: %s", *format))
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	if err != nil {
@@ -136,6 +148,10 @@ func handleCodegenFetch(args []string) {
 	}
 
 	printSuccess(fmt.Sprintf("Schema saved to: %s", colorCyan(*output)))
+	if structuredOutput() {
+		emitCodegenResult(*output, len(schemaDef.Bundles))
+		return
+	}
 
 	// Show bundle summary
 	fmt.Println()
@@ -153,8 +169,8 @@ func handleCodegenGenerate(args []string) {
 	fs := flag.NewFlagSet("codegen generate", flag.ExitOnError)
 	schemaFile := fs.String("schema", getDefaultSchemaFile(), "Schema file path")
 	output := fs.String("output", "", "Output file path (default: stdout)")
-	formatType := fs.String("format", "types", "Output format: types, json-schema, graphql")
-	language := fs.String("language", "go", "Language for types: go, typescript")
+	formatType := fs.String("format", "types", "Output format: types, json-schema, graphql, graphql-resolvers")
+	language := fs.String("language", "go", "Language for types and resolvers: go, typescript")
 	packageName := fs.String("package", "models", "Package name for generated code")
 	fs.Parse(args)
 
@@ -172,7 +188,11 @@ func handleCodegenGenerate(args []string) {
 	var schemaDef schema.SchemaDefinition
 	if err := json.Unmarshal(data, &schemaDef); err != nil {
 		printError(fmt.Sprintf("Failed to parse schema: %v", err))
-		os.Exit(1)
+		os.Exit(exitValidation)
+	}
+	if err := schemaDef.ValidateConstraints(); err != nil {
+		printError(fmt.Sprintf("Invalid schema: %v", err))
+		os.Exit(exitValidation)
 	}
 	printSuccess(fmt.Sprintf("Loaded schema with %d bundle(s)", len(schemaDef.Bundles)))
 
@@ -197,9 +217,11 @@ func handleCodegenGenerate(args []string) {
 		outputData, err = generateJSONSchema(registry)
 	case "graphql":
 		outputData, err = generateGraphQLSchema(registry)
+	case "graphql-resolvers":
+		outputData, err = generateGraphQLResolvers(registry, *language, *packageName)
 	default:
 		printError(fmt.Sprintf("Unknown format: %s", *formatType))
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	if err != nil {
@@ -210,7 +232,7 @@ func handleCodegenGenerate(args []string) {
 	// Write output
 	if *output == "" {
 		// Print to stdout
-		fmt.Println(outputData)
+		fmt.Fprintln(resultWriter, outputData)
 	} else {
 		// Create directory if needed
 		dir := filepath.Dir(*output)
@@ -225,6 +247,136 @@ func handleCodegenGenerate(args []string) {
 		}
 
 		printSuccess(fmt.Sprintf("Code generated: %s", colorCyan(*output)))
+		emitCodegenResult(*output, len(schemaDef.Bundles))
+	}
+}
+
+// emitCodegenResult reports a file written by codegen in structured output
+// mode. Code printed to stdout is itself the result and is not wrapped.
+func emitCodegenResult(file string, bundles int) {
+	if structuredOutput() {
+		emit(map[string]interface{}{"file": file, "bundles": bundles})
+	}
+}
+
+// handleCodegenOpenAPI generates an OpenAPI specification from schema
+func handleCodegenOpenAPI(args []string) {
+	fs := flag.NewFlagSet("codegen openapi", flag.ExitOnError)
+	schemaFile := fs.String("schema", getDefaultSchemaFile(), "Schema file path")
+	output := fs.String("output", "", "Output file path (default: stdout)")
+	title := fs.String("title", "SyndrDB API", "API title")
+	apiVersion := fs.String("api-version", "1.0.0", "API version")
+	serverURL := fs.String("server", "", "Server URL to list in the specification")
+	fs.Parse(args)
+
+	data, err := os.ReadFile(*schemaFile)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to read schema file: %v", err))
+		printInfo("Run " + colorCyan("syndrdb codegen fetch-schema") + " to fetch schema")
+		os.Exit(1)
+	}
+
+	var schemaDef schema.SchemaDefinition
+	if err := json.Unmarshal(data, &schemaDef); err != nil {
+		printError(fmt.Sprintf("Failed to parse schema: %v", err))
+		os.Exit(exitValidation)
+	}
+
+	gen := codegen.NewOpenAPIGenerator()
+	gen.Title = *title
+	gen.Version = *apiVersion
+	gen.ServerURL = *serverURL
+	spec, err := gen.Generate(&schemaDef)
+	if err != nil {
+		printError(fmt.Sprintf("Code generation failed: %v", err))
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		fmt.Fprintln(resultWriter, spec)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*output), 0755); err != nil {
+		printError(fmt.Sprintf("Failed to create directory: %v", err))
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, []byte(spec+"\n"), 0644); err != nil {
+		printError(fmt.Sprintf("Failed to write file: %v", err))
+		os.Exit(1)
+	}
+	printSuccess(fmt.Sprintf("OpenAPI specification for %d bundle(s) written to: %s", len(schemaDef.Bundles), colorCyan(*output)))
+	emitCodegenResult(*output, len(schemaDef.Bundles))
+}
+
+// handleCodegenProto generates Protobuf definitions from schema
+func handleCodegenProto(args []string) {
+	fs := flag.NewFlagSet("codegen proto", flag.ExitOnError)
+	schemaFile := fs.String("schema", getDefaultSchemaFile(), "Schema file path")
+	output := fs.String("output", "", "Output file path (default: stdout)")
+	packageName := fs.String("package", "syndrdb", "Proto package name")
+	goPackage := fs.String("go-package", "", "Value for option go_package")
+	services := fs.Bool("services", false, "Generate CRUD service definitions")
+	mappingFile := fs.String("mapping", "", "Field number mapping file (default: <output>.fields.json, or ./proto_fields.json)")
+	fs.Parse(args)
+
+	data, err := os.ReadFile(*schemaFile)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to read schema file: %v", err))
+		printInfo("Run " + colorCyan("syndrdb codegen fetch-schema") + " to fetch schema")
+		os.Exit(1)
+	}
+
+	var schemaDef schema.SchemaDefinition
+	if err := json.Unmarshal(data, &schemaDef); err != nil {
+		printError(fmt.Sprintf("Failed to parse schema: %v", err))
+		os.Exit(exitValidation)
+	}
+
+	if *mappingFile == "" {
+		*mappingFile = "proto_fields.json"
+		if *output != "" {
+			*mappingFile = strings.TrimSuffix(*output, ".proto") + ".fields.json"
+		}
+	}
+	mapping, err := codegen.LoadProtoFieldMapping(*mappingFile)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to load field mapping: %v", err))
+		os.Exit(1)
+	}
+
+	gen := codegen.NewProtoGenerator()
+	gen.Package = *packageName
+	gen.GoPackage = *goPackage
+	gen.Services = *services
+	gen.Mapping = mapping
+	proto, err := gen.Generate(&schemaDef)
+	if err != nil {
+		printError(fmt.Sprintf("Code generation failed: %v", err))
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		fmt.Fprint(resultWriter, proto)
+	} else {
+		if err := os.MkdirAll(filepath.Dir(*output), 0755); err != nil {
+			printError(fmt.Sprintf("Failed to create directory: %v", err))
+			os.Exit(1)
+		}
+		if err := os.WriteFile(*output, []byte(proto), 0644); err != nil {
+			printError(fmt.Sprintf("Failed to write file: %v", err))
+			os.Exit(1)
+		}
+		printSuccess(fmt.Sprintf("Protobuf definitions written to: %s", colorCyan(*output)))
+	}
+
+	if err := mapping.Save(*mappingFile); err != nil {
+		printError(fmt.Sprintf("Failed to save field mapping: %v", err))
+		os.Exit(1)
+	}
+	if *output != "" {
+		printInfo(fmt.Sprintf("Field numbers saved to %s - commit it to keep numbers stable", colorCyan(*mappingFile)))
+		emitCodegenResult(*output, len(schemaDef.Bundles))
 	}
 }
 
@@ -238,7 +390,11 @@ func generateGoTypes(registry *codegen.TypeRegistry, packageName string) (string
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("package %s\n\n", packageName))
-	sb.WriteString("import \"time\"\n\n")
+	if hasFieldType(bundles, schema.DECIMAL, schema.GEOPOINT, schema.GEOSHAPE) {
+		sb.WriteString("import (\n\t\"encoding/json\"\n\t\"time\"\n)\n\n")
+	} else {
+		sb.WriteString("import \"time\"\n\n")
+	}
 	sb.WriteString("// Generated by syndrdb codegen - DO NOT EDIT\n\n")
 
 	for _, bundle := range bundles {
@@ -248,10 +404,10 @@ func generateGoTypes(registry *codegen.TypeRegistry, packageName string) (string
 
 		for _, field := range bundle.Fields {
 			fieldName := toPascalCase(field.Name)
-			goType := syndrdbToGoType(field.Type)
+			goType := goFieldType(&field)
 
-			// Add pointer for optional fields
-			if !field.Required {
+			// Add pointer for optional fields; nil slices and maps already mean unset
+			if !field.Required && field.Type != schema.ARRAY && field.Type != schema.OBJECT {
 				goType = "*" + goType
 			}
 
@@ -284,12 +440,13 @@ func generateTypeScriptTypes(registry *codegen.TypeRegistry, moduleName string)
 		sb.WriteString(fmt.Sprintf("export interface %s {\n", interfaceName))
 
 		for _, field := range bundle.Fields {
-			tsType := syndrdbToTypeScriptType(field.Type)
+			tsType := typeScriptFieldType(&field)
 			optional := ""
 			if !field.Required {
 				optional = "?"
 			}
 
+			writeTypeScriptFieldDoc(&sb, &field)
 			sb.WriteString(fmt.Sprintf("  %s%s: %s;\n", field.Name, optional, tsType))
 		}
 
@@ -327,19 +484,79 @@ func generateGraphQLSchema(registry *codegen.TypeRegistry) (string, error) {
 	return gen.Generate(&singleSchema)
 }
 
+func generateGraphQLResolvers(registry *codegen.TypeRegistry, language, packageName string) (string, error) {
+	bundles := registry.GetAll()
+	if len(bundles) == 0 {
+		return "", fmt.Errorf("no bundles found in registry")
+	}
+
+	gen := codegen.NewGraphQLResolverGenerator()
+	singleSchema := schema.SchemaDefinition{Bundles: make([]schema.BundleDefinition, 0)}
+	for _, b := range bundles {
+		singleSchema.Bundles = append(singleSchema.Bundles, *b)
+	}
+	if language == "typescript" {
+		return gen.GenerateTypeScript(&singleSchema)
+	}
+	return gen.GenerateGo(&singleSchema, packageName)
+}
+
+// writeTypeScriptFieldDoc writes a JSDoc comment with the field's constraints and default.
+func writeTypeScriptFieldDoc(sb *strings.Builder, field *schema.FieldDefinition) {
+	var lines []string
+	if description := codegen.DescribeConstraints(field); description != "" {
+		lines = append(lines, description)
+	}
+	if field.DefaultValue != nil {
+		if data, err := json.Marshal(field.DefaultValue); err == nil {
+			lines = append(lines, "@default "+string(data))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	sb.WriteString("  /**\n")
+	for _, line := range lines {
+		sb.WriteString("   * " + strings.ReplaceAll(line, "*/", "*\\/") + "\n")
+	}
+	sb.WriteString("   */\n")
+}
+
+// typeScriptLiteralUnion converts enum values to a literal union type such as 'a' | 'b'.
+func typeScriptLiteralUnion(values []interface{}) string {
+	literals := make([]string, 0, len(values))
+	for _, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "any"
+		}
+		literals = append(literals, string(data))
+	}
+	return strings.Join(literals, " | ")
+}
+
 // Type conversion helpers
 
+// syndrdbToGoType maps a field type, by its schema name or its legacy
+// lowercase name, to a Go type.
 func syndrdbToGoType(fieldType schema.FieldType) string {
 	switch fieldType {
-	case "int":
+	case "int", schema.INT:
 		return "int64"
-	case "float":
+	case "float", schema.FLOAT:
 		return "float64"
-	case "string":
+	case schema.DECIMAL:
+		// json.Number keeps every digit of a decimal sent as a number or a string
+		return "json.Number"
+	case schema.GEOPOINT, schema.GEOSHAPE:
+		// GeoJSON; a GEOPOINT also decodes into client.GeoPoint
+		return "json.RawMessage"
+	case "string", schema.STRING, schema.TEXT:
 		return "string"
-	case "bool":
+	case "bool", schema.BOOLEAN:
 		return "bool"
-	case "timestamp":
+	case "timestamp", schema.DATETIME:
 		return "time.Time"
 	case "json":
 		return "interface{}"
@@ -348,15 +565,25 @@ func syndrdbToGoType(fieldType schema.FieldType) string {
 	}
 }
 
+// syndrdbToTypeScriptType maps a field type, by its schema name or its legacy
+// lowercase name, to a TypeScript type.
 func syndrdbToTypeScriptType(fieldType schema.FieldType) string {
 	switch fieldType {
-	case "int", "float":
+	case "int", "float", schema.INT, schema.FLOAT:
 		return "number"
-	case "string":
+	case schema.DECIMAL:
+		// A JavaScript number cannot hold every decimal exactly; use a string
+		// with a decimal library such as big.js
 		return "string"
-	case "bool":
+	case schema.GEOPOINT:
+		return "{ type: \"Point\"; coordinates: [number, number] }"
+	case schema.GEOSHAPE:
+		return "{ type: string; coordinates: unknown[] }"
+	case "string", schema.STRING, schema.TEXT:
+		return "string"
+	case "bool", schema.BOOLEAN:
 		return "boolean"
-	case "timestamp":
+	case "timestamp", schema.DATETIME:
 		return "Date"
 	case "json":
 		return "any"
@@ -365,6 +592,86 @@ func syndrdbToTypeScriptType(fieldType schema.FieldType) string {
 	}
 }
 
+// hasFieldType reports whether any bundle has a field, array item or object
+// member of one of fieldTypes.
+func hasFieldType(bundles []*schema.BundleDefinition, fieldTypes ...schema.FieldType) bool {
+	for _, bundle := range bundles {
+		if fieldsHaveType(bundle.Fields, fieldTypes) {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldsHaveType(fields []schema.FieldDefinition, fieldTypes []schema.FieldType) bool {
+	for _, field := range fields {
+		for _, fieldType := range fieldTypes {
+			if field.Type == fieldType {
+				return true
+			}
+		}
+		if field.Items != nil && fieldsHaveType([]schema.FieldDefinition{*field.Items}, fieldTypes) {
+			return true
+		}
+		if fieldsHaveType(field.Fields, fieldTypes) {
+			return true
+		}
+	}
+	return false
+}
+
+// goFieldType returns the Go type of a field: a slice of the item type for
+// arrays and a map for objects.
+func goFieldType(field *schema.FieldDefinition) string {
+	switch field.Type {
+	case schema.ARRAY:
+		if field.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + goFieldType(field.Items)
+	case schema.OBJECT:
+		return "map[string]interface{}"
+	default:
+		return syndrdbToGoType(field.Type)
+	}
+}
+
+// typeScriptFieldType returns the TypeScript type of a field: a literal union
+// for enums, an array of the item type for arrays and an object type with the
+// members of objects.
+func typeScriptFieldType(field *schema.FieldDefinition) string {
+	if field.Constraints != nil && len(field.Constraints.Enum) > 0 {
+		return typeScriptLiteralUnion(field.Constraints.Enum)
+	}
+	switch field.Type {
+	case schema.ARRAY:
+		if field.Items == nil {
+			return "any[]"
+		}
+		item := typeScriptFieldType(field.Items)
+		if strings.Contains(item, " | ") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case schema.OBJECT:
+		if len(field.Fields) == 0 {
+			return "Record<string, any>"
+		}
+		members := make([]string, len(field.Fields))
+		for i := range field.Fields {
+			member := &field.Fields[i]
+			optional := ""
+			if !member.Required {
+				optional = "?"
+			}
+			members[i] = member.Name + optional + ": " + typeScriptFieldType(member)
+		}
+		return "{ " + strings.Join(members, "; ") + " }"
+	default:
+		return syndrdbToTypeScriptType(field.Type)
+	}
+}
+
 func toPascalCase(s string) string {
 	parts := strings.Split(s, "_")
 	for i, part := range parts {
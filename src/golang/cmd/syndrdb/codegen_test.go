@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/codegen"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+func TestGenerateTypeScriptTypes_Constraints(t *testing.T) {
+	maxLength := 40
+	registry := codegen.NewTypeRegistry()
+	registry.LoadFromSchema(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{
+			{Name: "role", Type: schema.STRING, DefaultValue: "member", Constraints: &schema.FieldConstraints{
+				Enum: []interface{}{"member", "admin"},
+			}},
+			{Name: "bio", Type: schema.STRING, Constraints: &schema.FieldConstraints{
+				MaxLength: &maxLength, Pattern: `^[^*/]*$`,
+			}},
+		}},
+	}})
+
+	result, err := generateTypeScriptTypes(registry, "models")
+	if err != nil {
+		t.Fatalf("generateTypeScriptTypes failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"  /**\n   * one of member, admin\n   * @default \"member\"\n   */\n  role?: \"member\" | \"admin\";\n",
+		"   * max length 40, pattern ^[^*\\/]*$\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in:\n%s", want, result)
+		}
+	}
+}
+
+func TestGenerateTypes_Decimal(t *testing.T) {
+	registry := codegen.NewTypeRegistry()
+	registry.LoadFromSchema(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "orders", Fields: []schema.FieldDefinition{
+			{Name: "total", Type: schema.DECIMAL, Required: true},
+		}},
+	}})
+
+	goTypes, err := generateGoTypes(registry, "models")
+	if err != nil {
+		t.Fatalf("generateGoTypes failed: %v", err)
+	}
+	for _, want := range []string{"\t\"encoding/json\"\n", "\tTotal json.Number `json:\"total\"`\n"} {
+		if !strings.Contains(goTypes, want) {
+			t.Errorf("expected %q in:\n%s", want, goTypes)
+		}
+	}
+
+	tsTypes, err := generateTypeScriptTypes(registry, "models")
+	if err != nil {
+		t.Fatalf("generateTypeScriptTypes failed: %v", err)
+	}
+	if !strings.Contains(tsTypes, "total: string;") {
+		t.Errorf("expected a string total in:\n%s", tsTypes)
+	}
+}
+
+func TestGenerateTypes_Geo(t *testing.T) {
+	registry := codegen.NewTypeRegistry()
+	registry.LoadFromSchema(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "stores", Fields: []schema.FieldDefinition{
+			{Name: "location", Type: schema.GEOPOINT, Required: true},
+			{Name: "area", Type: schema.GEOSHAPE},
+		}},
+	}})
+
+	goTypes, err := generateGoTypes(registry, "models")
+	if err != nil {
+		t.Fatalf("generateGoTypes failed: %v", err)
+	}
+	for _, want := range []string{"\t\"encoding/json\"\n", "\tLocation json.RawMessage `json:\"location\"`\n"} {
+		if !strings.Contains(goTypes, want) {
+			t.Errorf("expected %q in:\n%s", want, goTypes)
+		}
+	}
+
+	tsTypes, err := generateTypeScriptTypes(registry, "models")
+	if err != nil {
+		t.Fatalf("generateTypeScriptTypes failed: %v", err)
+	}
+	for _, want := range []string{
+		`location: { type: "Point"; coordinates: [number, number] };`,
+		"area?: { type: string; coordinates: unknown[] };",
+	} {
+		if !strings.Contains(tsTypes, want) {
+			t.Errorf("expected %q in:\n%s", want, tsTypes)
+		}
+	}
+}
+
+func TestGenerateTypes_Nested(t *testing.T) {
+	registry := codegen.NewTypeRegistry()
+	registry.LoadFromSchema(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{
+			{Name: "roles", Type: schema.ARRAY, Required: true, Items: &schema.FieldDefinition{
+				Type: schema.STRING, Constraints: &schema.FieldConstraints{Enum: []interface{}{"admin", "member"}},
+			}},
+			{Name: "balances", Type: schema.ARRAY, Items: &schema.FieldDefinition{Type: schema.DECIMAL}},
+			{Name: "address", Type: schema.OBJECT, Fields: []schema.FieldDefinition{
+				{Name: "city", Type: schema.STRING, Required: true},
+				{Name: "tags", Type: schema.ARRAY},
+			}},
+		}},
+	}})
+
+	goTypes, err := generateGoTypes(registry, "models")
+	if err != nil {
+		t.Fatalf("generateGoTypes failed: %v", err)
+	}
+	for _, want := range []string{
+		"\t\"encoding/json\"\n",
+		"\tBalances []json.Number `json:\"balances,omitempty\"`\n",
+		"\tAddress map[string]interface{} `json:\"address,omitempty\"`\n",
+	} {
+		if !strings.Contains(goTypes, want) {
+			t.Errorf("expected %q in:\n%s", want, goTypes)
+		}
+	}
+
+	tsTypes, err := generateTypeScriptTypes(registry, "models")
+	if err != nil {
+		t.Fatalf("generateTypeScriptTypes failed: %v", err)
+	}
+	for _, want := range []string{
+		`roles: ("admin" | "member")[];`,
+		"balances?: string[];",
+		"address?: { city: string; tags?: any[] };",
+	} {
+		if !strings.Contains(tsTypes, want) {
+			t.Errorf("expected %q in:\n%s", want, tsTypes)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/codegen"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// watchOutputFiles maps the formats supported by "codegen --watch" to the file
+// written in the output directory.
+var watchOutputFiles = map[string]string{
+	"typescript":  "types.ts",
+	"go":          "types.go",
+	"json-schema": "schema.json",
+	"graphql":     "schema.graphql",
+}
+
+// handleCodegenWatch regenerates code whenever the schema file or the server schema changes
+func handleCodegenWatch(args []string) {
+	fs := flag.NewFlagSet("codegen watch", flag.ExitOnError)
+	schemaFile := fs.String("schema", getDefaultSchemaFile(), "Schema file to watch")
+	connStr := fs.String("conn", "", "Poll the server schema instead of a file")
+	outDir := fs.String("out-dir", "./generated", "Output directory")
+	formatList := fs.String("formats", "typescript,json-schema,graphql", "Comma-separated formats: typescript, go, json-schema, graphql")
+	packageName := fs.String("package", "models", "Package name for generated Go code")
+	interval := fs.Duration("interval", 2*time.Second, "How often to check for changes")
+	debounce := fs.Duration("debounce", 300*time.Millisecond, "How long the schema must be stable before regenerating")
+	fs.Parse(args)
+
+	formats, err := parseWatchFormats(*formatList)
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+
+	printHeader("Watch Schema")
+
+	source := codegen.FileSchemaSource(*schemaFile)
+	watching := *schemaFile
+	if *connStr != "" {
+		c := connectDataClient(*connStr)
+		defer c.Disconnect(context.Background())
+		source = c.GetSchema
+		watching = maskConnectionString(*connStr)
+	}
+
+	fmt.Printf("%s %s every %s → %s\n", colorDim("Watching"), colorCyan(watching), interval, colorCyan(*outDir))
+	printInfo("Press Ctrl+C to stop")
+
+	watcher := codegen.NewWatcher(source, codegen.WatchOptions{
+		Interval: *interval,
+		Debounce: *debounce,
+		OnChange: func(schemaDef *schema.SchemaDefinition) error {
+			written, err := writeWatchOutputs(*outDir, schemaDef, formats, *packageName)
+			if err != nil {
+				return err
+			}
+			printSuccess(fmt.Sprintf("[%s] Regenerated %s (%d bundle(s))",
+				time.Now().Format("15:04:05"), strings.Join(written, ", "), len(schemaDef.Bundles)))
+			return nil
+		},
+		OnError: func(err error) {
+			printError(err.Error())
+		},
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	watcher.Run(ctx)
+	fmt.Println()
+}
+
+// parseWatchFormats validates a comma-separated list of watch formats.
+func parseWatchFormats(list string) ([]string, error) {
+	var formats []string
+	for _, format := range strings.Split(list, ",") {
+		format = strings.TrimSpace(format)
+		if format == "" {
+			continue
+		}
+		if _, ok := watchOutputFiles[format]; !ok {
+			return nil, fmt.Errorf("Unknown format: %s (use typescript, go, json-schema or graphql)", format)
+		}
+		formats = append(formats, format)
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("No formats given")
+	}
+	return formats, nil
+}
+
+// writeWatchOutputs generates every format into dir and returns the files written.
+func writeWatchOutputs(dir string, schemaDef *schema.SchemaDefinition, formats []string, packageName string) ([]string, error) {
+	registry := codegen.NewTypeRegistry()
+	registry.LoadFromSchema(schemaDef)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("Failed to create directory: %v", err)
+	}
+
+	written := make([]string, 0, len(formats))
+	for _, format := range formats {
+		var output string
+		var err error
+		switch format {
+		case "typescript":
+			output, err = generateTypeScriptTypes(registry, packageName)
+		case "go":
+			output, err = generateGoTypes(registry, packageName)
+		case "json-schema":
+			output, err = generateJSONSchema(registry)
+		case "graphql":
+			output, err = generateGraphQLSchema(registry)
+		}
+		if err != nil {
+			return written, fmt.Errorf("Code generation failed for %s: %v", format, err)
+		}
+
+		path := filepath.Join(dir, watchOutputFiles[format])
+		if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+			return written, fmt.Errorf("Failed to write file: %v", err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
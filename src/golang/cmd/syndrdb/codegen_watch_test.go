@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+func TestParseWatchFormats(t *testing.T) {
+	formats, err := parseWatchFormats("typescript, graphql,,json-schema")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"typescript", "graphql", "json-schema"}; !reflect.DeepEqual(formats, want) {
+		t.Errorf("expected %v, got %v", want, formats)
+	}
+
+	if _, err := parseWatchFormats("typescript,yaml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, err := parseWatchFormats(" , "); err == nil {
+		t.Error("expected an error for an empty format list")
+	}
+}
+
+func TestWriteWatchOutputs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "generated")
+	schemaDef := &schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{
+			{Name: "name", Type: schema.STRING, Required: true},
+		}},
+	}}
+
+	written, err := writeWatchOutputs(dir, schemaDef, []string{"typescript", "json-schema", "graphql", "go"}, "models")
+	if err != nil {
+		t.Fatalf("writeWatchOutputs failed: %v", err)
+	}
+	if len(written) != 4 {
+		t.Fatalf("expected 4 files, got %v", written)
+	}
+
+	expected := map[string]string{
+		"types.ts":       "export interface Users",
+		"schema.json":    `"users"`,
+		"schema.graphql": "type users",
+		"types.go":       "package models",
+	}
+	for file, snippet := range expected {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("expected %s to be written: %v", file, err)
+		}
+		if !strings.Contains(string(data), snippet) {
+			t.Errorf("expected %s to contain %q, got:\n%s", file, snippet, data)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+var (
+	configOnce    sync.Once
+	configOptions *client.ClientOptions
+)
+
+// cliOptions returns client options loaded from the config file (SYNDRDB_CONFIG,
+// or syndrdb.yaml/.syndrdbrc) and SYNDRDB_* environment variables, exiting if
+// the configuration is invalid. Each call returns a copy.
+func cliOptions() *client.ClientOptions {
+	configOnce.Do(func() {
+		opts, err := client.LoadOptions(os.Getenv("SYNDRDB_CONFIG"))
+		if err != nil {
+			printError(fmt.Sprintf("Invalid configuration: %v", err))
+			os.Exit(exitValidation)
+		}
+		configOptions = opts
+	})
+	opts := *configOptions
+	return &opts
+}
+
+// defaultConnString returns the configured connection string, used as the --conn default.
+func defaultConnString() string {
+	return cliOptions().ConnString
+}
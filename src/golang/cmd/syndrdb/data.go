@@ -0,0 +1,601 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// identifierPattern matches the bundle names the client accepts in the
+// commands it renders, so an export cannot be steered by its --bundle.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// exportKeyField orders export pages; every document has one.
+const exportKeyField = "DocumentID"
+
+// Supported data file formats
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+)
+
+func printExportUsage() {
+	printHeader("Export Documents")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb export --bundle " + colorYellow("<name>") + " [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn         Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --bundle       Bundle to export (required)")
+	fmt.Println("  --format       json, ndjson or csv (default: from --out extension, else json)")
+	fmt.Println("  --out          Output file (default: stdout)")
+	fmt.Println("  --where        SyndrQL condition selecting the documents to export")
+	fmt.Println("  --batch-size   Documents fetched per query (default: 1000)")
+	fmt.Println("\nExamples:")
+	fmt.Println("  syndrdb export --bundle users --out users.ndjson")
+	fmt.Println(`  syndrdb export --bundle users --format csv --where '"status" == "active"' > active.csv`)
+}
+
+func printImportUsage() {
+	printHeader("Import Documents")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb import --bundle " + colorYellow("<name>") + " --file " + colorYellow("<path>") + " [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn         Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --bundle       Target bundle (required)")
+	fmt.Println("  --file         Input file, or - for stdin (required)")
+	fmt.Println("  --format       json, ndjson or csv (default: from --file extension, else json)")
+	fmt.Println("  --batch-size   Documents sent per round trip (default: 500)")
+	fmt.Println("\nCSV values that look like integers, decimals or booleans are imported as")
+	fmt.Println("those types; empty cells are omitted.")
+	fmt.Println("\nExamples:")
+	fmt.Println("  syndrdb import --bundle users --file users.ndjson")
+	fmt.Println("  syndrdb import --bundle users --file users.csv --batch-size 1000")
+}
+
+// dataTransferResult is the structured output of export and import.
+type dataTransferResult struct {
+	Bundle     string   `json:"bundle"`
+	Format     string   `json:"format"`
+	File       string   `json:"file"`
+	Documents  int      `json:"documents"`
+	Failed     int      `json:"failed"`
+	DurationMs float64  `json:"duration_ms"`
+	Skipped    []string `json:"skipped_fields,omitempty"`
+}
+
+// handleExport writes a bundle's documents to a file, one page at a time
+func handleExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	bundle := fs.String("bundle", "", "Bundle to export (required)")
+	format := fs.String("format", "", "Output format: json, ndjson or csv")
+	out := fs.String("out", "", "Output file (default: stdout)")
+	where := fs.String("where", "", "SyndrQL condition for a partial export")
+	batchSize := fs.Int("batch-size", 1000, "Documents fetched per query")
+	fs.Usage = printExportUsage
+	fs.Parse(args)
+
+	if *bundle == "" {
+		printError("Bundle is required")
+		printExportUsage()
+		os.Exit(exitValidation)
+	}
+	if !identifierPattern.MatchString(*bundle) {
+		printError(fmt.Sprintf("Invalid bundle name %q", *bundle))
+		os.Exit(exitValidation)
+	}
+	if *batchSize < 1 {
+		printError("--batch-size must be at least 1")
+		os.Exit(exitValidation)
+	}
+	dataFormat, err := resolveDataFormat(*format, *out)
+	if err != nil {
+		printError(err.Error())
+		os.Exit(exitValidation)
+	}
+
+	c := connectDataClient(*connStr)
+	defer c.Disconnect(context.Background())
+
+	// Write to stdout unless --out is given; progress goes to stderr either way
+	output := resultWriter
+	if *out != "" {
+		if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+			printError(fmt.Sprintf("Failed to create directory: %v", err))
+			os.Exit(1)
+		}
+		file, err := os.Create(*out)
+		if err != nil {
+			printError(fmt.Sprintf("Failed to create output file: %v", err))
+			os.Exit(1)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	buffered := bufio.NewWriter(output)
+	writer := newDocumentWriter(dataFormat, buffered)
+	start := time.Now()
+	exported := 0
+
+	// Each page starts after the last DocumentID of the one before, so pages
+	// never overlap or skip documents, whatever order the server keeps
+	lastID := ""
+	for {
+		query := buildExportQuery(*bundle, *where, *batchSize, lastID)
+		result, err := c.Query(query, 60000)
+		if err != nil {
+			clearProgress()
+			printError(fmt.Sprintf("Export failed after %d documents: %v", exported, err))
+			os.Exit(1)
+		}
+
+		documents, ok := resultDocuments(result)
+		if !ok {
+			clearProgress()
+			printError(fmt.Sprintf("Unexpected query result type %T", result))
+			os.Exit(1)
+		}
+
+		for _, doc := range documents {
+			if err := writer.Write(doc); err != nil {
+				clearProgress()
+				printError(fmt.Sprintf("Failed to write document: %v", err))
+				os.Exit(1)
+			}
+		}
+		exported += len(documents)
+		printProgress("Exported", exported, start)
+
+		if len(documents) > 0 {
+			if lastID, _ = documents[len(documents)-1][exportKeyField].(string); lastID == "" {
+				clearProgress()
+				printError(fmt.Sprintf("Export failed after %d documents: a document has no %s to page by", exported, exportKeyField))
+				os.Exit(1)
+			}
+		}
+
+		if len(documents) < *batchSize {
+			break
+		}
+	}
+
+	err = writer.Close()
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil {
+		clearProgress()
+		printError(fmt.Sprintf("Failed to write output: %v", err))
+		os.Exit(1)
+	}
+
+	clearProgress()
+	message := fmt.Sprintf("Exported %d document(s) from %s in %s", exported, colorCyan(*bundle), time.Since(start).Round(time.Millisecond))
+	fmt.Fprintln(os.Stderr, colorGreen("✓")+" "+message)
+	if skipped := writer.SkippedFields(); len(skipped) > 0 {
+		fmt.Fprintln(os.Stderr, colorYellow("⚠")+" Fields missing from the CSV header were skipped: "+strings.Join(skipped, ", "))
+	}
+
+	// Without --out, the documents themselves are the result
+	if structuredOutput() && *out != "" {
+		emit(dataTransferResult{
+			Bundle:     *bundle,
+			Format:     dataFormat,
+			File:       *out,
+			Documents:  exported,
+			DurationMs: milliseconds(time.Since(start)),
+			Skipped:    writer.SkippedFields(),
+		})
+	}
+}
+
+// handleImport loads documents from a file into a bundle in pipelined batches
+func handleImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	bundle := fs.String("bundle", "", "Target bundle (required)")
+	file := fs.String("file", "", "Input file, or - for stdin (required)")
+	format := fs.String("format", "", "Input format: json, ndjson or csv")
+	batchSize := fs.Int("batch-size", 500, "Documents sent per round trip")
+	fs.Usage = printImportUsage
+	fs.Parse(args)
+
+	if *bundle == "" || *file == "" {
+		printError("Bundle and file are required")
+		printImportUsage()
+		os.Exit(exitValidation)
+	}
+	if *batchSize < 1 {
+		printError("--batch-size must be at least 1")
+		os.Exit(exitValidation)
+	}
+	dataFormat, err := resolveDataFormat(*format, *file)
+	if err != nil {
+		printError(err.Error())
+		os.Exit(exitValidation)
+	}
+
+	input := io.Reader(os.Stdin)
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			printError(fmt.Sprintf("Failed to open input file: %v", err))
+			os.Exit(1)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	reader, err := newDocumentReader(dataFormat, bufio.NewReader(input))
+	if err != nil {
+		printError(fmt.Sprintf("Failed to read input: %v", err))
+		os.Exit(1)
+	}
+
+	c := connectDataClient(*connStr)
+	defer c.Disconnect(context.Background())
+
+	ctx := context.Background()
+	start := time.Now()
+	imported, failed := 0, 0
+	batch := make([]string, 0, *batchSize)
+
+	// Each batch is sent as one pipeline; failures are reported per document
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		first := imported + failed + 1
+		results, err := c.Pipeline().Add(batch...).Execute(ctx)
+		if results == nil {
+			clearProgress()
+			printError(fmt.Sprintf("Import failed after %d documents: %v", imported, err))
+			os.Exit(1)
+		}
+		for i, result := range results {
+			if result.Error == nil {
+				imported++
+				continue
+			}
+			failed++
+			if failed <= 10 {
+				clearProgress()
+				printError(fmt.Sprintf("Document %d failed: %v", first+i, result.Error))
+			}
+		}
+		batch = batch[:0]
+		printProgress("Imported", imported, start)
+	}
+
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			flush()
+			clearProgress()
+			printError(fmt.Sprintf("Failed to read document %d: %v", imported+failed+1, err))
+			os.Exit(1)
+		}
+
+		command, err := client.AddDocumentCommand(*bundle, doc)
+		if err != nil {
+			flush()
+			clearProgress()
+			printError(fmt.Sprintf("Document %d cannot be imported: %v", imported+failed+1, err))
+			os.Exit(exitValidation)
+		}
+		batch = append(batch, command)
+		if len(batch) == *batchSize {
+			flush()
+		}
+	}
+	flush()
+
+	clearProgress()
+	if structuredOutput() {
+		emit(dataTransferResult{
+			Bundle:     *bundle,
+			Format:     dataFormat,
+			File:       *file,
+			Documents:  imported,
+			Failed:     failed,
+			DurationMs: milliseconds(time.Since(start)),
+		})
+	}
+	message := fmt.Sprintf("Imported %d document(s) into %s in %s", imported, colorCyan(*bundle), time.Since(start).Round(time.Millisecond))
+	if failed > 0 {
+		printError(fmt.Sprintf("%s; %d failed", message, failed))
+		os.Exit(1)
+	}
+	printSuccess(message)
+}
+
+// connectDataClient connects for import/export or exits.
+func connectDataClient(connStr string) *client.Client {
+	if connStr == "" {
+		printError("Connection string is required")
+		fmt.Fprintln(os.Stderr, "\nProvide via --conn flag or SYNDRDB_CONN environment variable")
+		os.Exit(exitValidation)
+	}
+
+	opts := cliOptions()
+	opts.Logger = client.NewNoopLogger()
+	c := client.NewClient(opts)
+	if err := c.Connect(context.Background(), connStr); err != nil {
+		printError(fmt.Sprintf("Failed to connect: %v", err))
+		os.Exit(exitConnection)
+	}
+	return c
+}
+
+// resolveDataFormat returns the explicit format, or infers it from the file extension.
+func resolveDataFormat(format, path string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".ndjson", ".jsonl":
+			return formatNDJSON, nil
+		case ".csv":
+			return formatCSV, nil
+		default:
+			return formatJSON, nil
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case formatJSON, formatNDJSON, formatCSV:
+		return strings.ToLower(format), nil
+	default:
+		return "", fmt.Errorf("unknown format %q (expected json, ndjson or csv)", format)
+	}
+}
+
+// buildExportQuery returns the query for the page of an export after the
+// document afterID, or the first page if afterID is "".
+func buildExportQuery(bundle, where string, limit int, afterID string) string {
+	var conditions []string
+	if where = strings.TrimSuffix(strings.TrimSpace(where), ";"); where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	if afterID != "" {
+		conditions = append(conditions, fmt.Sprintf(`"%s" > '%s'`, exportKeyField, strings.ReplaceAll(afterID, "'", "''")))
+	}
+
+	var query strings.Builder
+	query.WriteString(`SELECT * FROM "` + bundle + `"`)
+	if len(conditions) > 0 {
+		query.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	}
+	fmt.Fprintf(&query, ` ORDER BY "%s" ASC LIMIT %d;`, exportKeyField, limit)
+	return query.String()
+}
+
+// printProgress overwrites a progress line on stderr.
+func printProgress(verb string, count int, start time.Time) {
+	elapsed := time.Since(start).Seconds()
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(count) / elapsed
+	}
+	fmt.Fprintf(os.Stderr, "\r%s %s documents %s", colorCyan("…"), verb+" "+strconv.Itoa(count), colorDim(fmt.Sprintf("(%.0f/s)", rate)))
+}
+
+// clearProgress erases the progress line.
+func clearProgress() {
+	fmt.Fprint(os.Stderr, "\r\033[K")
+}
+
+// ============================================================================
+// Document writers
+// ============================================================================
+
+// documentWriter streams documents in one of the supported formats.
+type documentWriter interface {
+	Write(doc map[string]interface{}) error
+	Close() error
+	// SkippedFields lists fields that could not be written (CSV only).
+	SkippedFields() []string
+}
+
+func newDocumentWriter(format string, w io.Writer) documentWriter {
+	switch format {
+	case formatNDJSON:
+		return &rowEncoderWriter{encoder: client.NewNDJSONEncoder(w)}
+	case formatCSV:
+		writer := &rowEncoderWriter{}
+		writer.encoder = client.NewCSVEncoder(w, client.OnSkippedField(func(field string) {
+			writer.skipped = append(writer.skipped, field)
+		}))
+		return writer
+	default:
+		return &jsonArrayWriter{w: w}
+	}
+}
+
+// jsonArrayWriter writes documents as an indented JSON array.
+type jsonArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (jw *jsonArrayWriter) Write(doc map[string]interface{}) error {
+	data, err := json.MarshalIndent(doc, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	prefix := ",\n  "
+	if jw.count == 0 {
+		prefix = "[\n  "
+	}
+	jw.count++
+	_, err = io.WriteString(jw.w, prefix+string(data))
+	return err
+}
+
+func (jw *jsonArrayWriter) Close() error {
+	if jw.count == 0 {
+		_, err := io.WriteString(jw.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(jw.w, "\n]\n")
+	return err
+}
+
+func (jw *jsonArrayWriter) SkippedFields() []string { return nil }
+
+// rowEncoderWriter writes documents with a client CSV or NDJSON encoder.
+// For CSV, the header is taken from the sorted fields of the first document;
+// fields first seen in later documents are skipped.
+type rowEncoderWriter struct {
+	encoder interface {
+		Encode(row interface{}) error
+		Flush() error
+	}
+	skipped []string
+}
+
+func (rw *rowEncoderWriter) Write(doc map[string]interface{}) error {
+	return rw.encoder.Encode(doc)
+}
+
+func (rw *rowEncoderWriter) Close() error {
+	return rw.encoder.Flush()
+}
+
+func (rw *rowEncoderWriter) SkippedFields() []string {
+	fields := append([]string(nil), rw.skipped...)
+	sort.Strings(fields)
+	return fields
+}
+
+// ============================================================================
+// Document readers
+// ============================================================================
+
+// documentReader streams documents from an input file.
+// Read returns io.EOF after the last document.
+type documentReader interface {
+	Read() (map[string]interface{}, error)
+}
+
+func newDocumentReader(format string, r io.Reader) (documentReader, error) {
+	switch format {
+	case formatCSV:
+		reader := csv.NewReader(r)
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		return &csvReader{reader: reader, header: header}, nil
+	default:
+		// JSON arrays and NDJSON/concatenated documents are both accepted
+		return newJSONReader(r)
+	}
+}
+
+// jsonReader reads documents from a JSON array or a stream of JSON objects.
+type jsonReader struct {
+	decoder *json.Decoder
+	inArray bool
+}
+
+func newJSONReader(r io.Reader) (*jsonReader, error) {
+	buffered := bufio.NewReader(r)
+
+	// Peek at the first non-space byte to detect an array
+	for {
+		b, err := buffered.Peek(1)
+		if err == io.EOF {
+			return &jsonReader{decoder: json.NewDecoder(buffered)}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.ContainsRune(" \t\r\n", rune(b[0])) {
+			buffered.ReadByte()
+			continue
+		}
+		break
+	}
+
+	decoder := json.NewDecoder(buffered)
+	decoder.UseNumber()
+
+	reader := &jsonReader{decoder: decoder}
+	if b, _ := buffered.Peek(1); len(b) == 1 && b[0] == '[' {
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		reader.inArray = true
+	}
+	return reader, nil
+}
+
+func (jr *jsonReader) Read() (map[string]interface{}, error) {
+	if jr.inArray && !jr.decoder.More() {
+		return nil, io.EOF
+	}
+
+	var doc map[string]interface{}
+	if err := jr.decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// csvReader reads CSV rows as documents keyed by the header row.
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (cr *csvReader) Read() (map[string]interface{}, error) {
+	record, err := cr.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := make(map[string]interface{}, len(cr.header))
+	for i, column := range cr.header {
+		if i < len(record) && record[i] != "" {
+			doc[column] = inferCSVValue(record[i])
+		}
+	}
+	return doc, nil
+}
+
+// inferCSVValue converts CSV text to an integer, decimal or boolean where possible.
+func inferCSVValue(text string) interface{} {
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f
+	}
+	switch text {
+	case "true", "TRUE":
+		return true
+	case "false", "FALSE":
+		return false
+	}
+	return text
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBuildExportQuery(t *testing.T) {
+	got := buildExportQuery("users", `"status" == "active" OR "age" > 30;`, 100, "")
+	want := `SELECT * FROM "users" WHERE ("status" == "active" OR "age" > 30) ORDER BY "DocumentID" ASC LIMIT 100;`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	got = buildExportQuery("users", `"status" == "active"`, 100, "d'42")
+	want = `SELECT * FROM "users" WHERE ("status" == "active") AND "DocumentID" > 'd''42' ORDER BY "DocumentID" ASC LIMIT 100;`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if got := buildExportQuery("users", "", 10, "d7"); got != `SELECT * FROM "users" WHERE "DocumentID" > 'd7' ORDER BY "DocumentID" ASC LIMIT 10;` {
+		t.Errorf("unexpected query without filter: %s", got)
+	}
+}
+
+func TestDocumentRoundTrip(t *testing.T) {
+	docs := []map[string]interface{}{
+		{"id": float64(1), "name": "a"},
+		{"id": float64(2), "name": "b", "extra": true},
+	}
+
+	for _, format := range []string{formatJSON, formatNDJSON, formatCSV} {
+		var buf bytes.Buffer
+		writer := newDocumentWriter(format, &buf)
+		for _, doc := range docs {
+			if err := writer.Write(doc); err != nil {
+				t.Fatalf("%s: Write failed: %v", format, err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("%s: Close failed: %v", format, err)
+		}
+
+		reader, err := newDocumentReader(format, strings.NewReader(buf.String()))
+		if err != nil {
+			t.Fatalf("%s: newDocumentReader failed: %v", format, err)
+		}
+		count := 0
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: Read failed: %v", format, err)
+			}
+			if doc["name"] != docs[count]["name"] {
+				t.Errorf("%s: document %d: expected name %v, got %v", format, count, docs[count]["name"], doc["name"])
+			}
+			count++
+		}
+		if count != len(docs) {
+			t.Errorf("%s: expected %d documents, got %d", format, len(docs), count)
+		}
+
+		if format == formatCSV {
+			if skipped := writer.SkippedFields(); len(skipped) != 1 || skipped[0] != "extra" {
+				t.Errorf("expected 'extra' to be skipped, got %v", skipped)
+			}
+		}
+	}
+}
+
+func TestInferCSVValue(t *testing.T) {
+	tests := map[string]interface{}{
+		"42":    int64(42),
+		"4.5":   4.5,
+		"true":  true,
+		"FALSE": false,
+		"NaN":   "NaN",
+		"abc":   "abc",
+	}
+	for input, want := range tests {
+		if got := inferCSVValue(input); got != want {
+			t.Errorf("inferCSVValue(%q) = %v (%T), want %v (%T)", input, got, got, want, want)
+		}
+	}
+}
+
+func TestResolveDataFormat(t *testing.T) {
+	if f, _ := resolveDataFormat("", "out.jsonl"); f != formatNDJSON {
+		t.Errorf("expected ndjson, got %s", f)
+	}
+	if f, _ := resolveDataFormat("", ""); f != formatJSON {
+		t.Errorf("expected json default, got %s", f)
+	}
+	if _, err := resolveDataFormat("xml", ""); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+func printExplainUsage() {
+	printHeader("Explain Query")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb explain [options] " + colorYellow("\"<query>\""))
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn   Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --json   Print the plan as JSON")
+	fmt.Println("\nExamples:")
+	fmt.Println(`  syndrdb explain 'SELECT * FROM "users" WHERE "email" == "a@example.com";'`)
+	fmt.Println(`  syndrdb explain --json 'SELECT * FROM "orders";'`)
+}
+
+// handleExplain prints the server's execution plan for a query
+func handleExplain(args []string) {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	jsonOutput := fs.Bool("json", false, "Print the plan as JSON (same as --output json)")
+	fs.Usage = printExplainUsage
+	fs.Parse(args)
+	if *jsonOutput {
+		setOutputFormat(outputJSON)
+	}
+
+	query := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if query == "" {
+		printError("Query is required")
+		printExplainUsage()
+		os.Exit(exitValidation)
+	}
+
+	c := connectDataClient(*connStr)
+	plan, err := c.Explain(context.Background(), query)
+	c.Disconnect(context.Background())
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+
+	if structuredOutput() {
+		emit(plan)
+		return
+	}
+
+	printHeader("Query Plan")
+	fmt.Println(colorDim(plan.Query))
+	fmt.Println()
+	writePlan(os.Stdout, plan.Root, 0)
+}
+
+// writePlan prints a plan node and its children as an indented tree,
+// flagging full scans since they usually mean a missing index.
+func writePlan(w io.Writer, node *client.PlanNode, depth int) {
+	if node == nil {
+		return
+	}
+
+	line := strings.Repeat("  ", depth) + colorGreen(node.Type)
+	if node.Bundle != "" {
+		line += " on " + colorCyan(node.Bundle)
+	}
+	if node.Index != "" {
+		line += " using " + colorCyan(node.Index)
+	}
+	if node.Detail != "" {
+		line += " (" + node.Detail + ")"
+	}
+	if node.EstimatedRows >= 0 {
+		line += colorDim(fmt.Sprintf(" rows=%d", node.EstimatedRows))
+	}
+	if node.Index == "" && isFullScan(node.Type) {
+		line += " " + colorYellow("[no index]")
+	}
+	fmt.Fprintln(w, line)
+
+	for _, child := range node.Children {
+		writePlan(w, child, depth+1)
+	}
+}
+
+// isFullScan reports whether a plan node type reads every document, e.g.
+// "FullScan", "full_scan" or "SeqScan".
+func isFullScan(nodeType string) bool {
+	normalized := strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(nodeType))
+	return strings.Contains(normalized, "fullscan") || strings.Contains(normalized, "seqscan")
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+func TestWritePlan(t *testing.T) {
+	colorsEnabled = false
+	defer func() { colorsEnabled = true }()
+
+	root := &client.PlanNode{Type: "Filter", Detail: `"age" > 30`, EstimatedRows: 120, Children: []*client.PlanNode{
+		{Type: "IndexScan", Bundle: "users", Index: "idx_age", EstimatedRows: 480},
+		{Type: "Full Scan", Bundle: "orders", EstimatedRows: -1},
+	}}
+
+	var buf bytes.Buffer
+	writePlan(&buf, root, 0)
+	want := strings.Join([]string{
+		`Filter ("age" > 30) rows=120`,
+		`  IndexScan on users using idx_age rows=480`,
+		`  Full Scan on orders [no index]`,
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -7,45 +7,101 @@ import (
 
 const version = "1.0.0"
 
+// Exit codes. Scripts and CI pipelines rely on them, so they are part of the
+// CLI's interface: see "Exit Codes" in README.md.
+const (
+	exitOK         = 0
+	exitError      = 1 // Any other failure
+	exitValidation = 2 // Invalid usage, schema, migrations or plan, or schema drift
+	exitConnection = 3 // The server could not be reached or refused the credentials
+	exitPending    = 4 // migrate status --check found pending migrations
+)
+
 func main() {
-	if len(os.Args) < 2 {
+	format, args, err := extractOutputFlag(os.Args[1:])
+	if err != nil {
+		printError(err.Error())
+		os.Exit(exitValidation)
+	}
+	setOutputFormat(format)
+
+	if len(args) < 1 {
 		printUsage()
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
-	command := os.Args[1]
+	command := args[0]
 
 	switch command {
 	case "migrate":
-		handleMigrate(os.Args[2:])
+		handleMigrate(args[1:])
 	case "codegen":
-		handleCodegen(os.Args[2:])
+		handleCodegen(args[1:])
 	case "test":
-		handleTest(os.Args[2:])
+		handleTest(args[1:])
+	case "shell":
+		handleShell(args[1:])
+	case "schema":
+		handleSchema(args[1:])
+	case "export":
+		handleExport(args[1:])
+	case "import":
+		handleImport(args[1:])
+	case "dump":
+		handleDump(args[1:])
+	case "restore":
+		handleRestore(args[1:])
+	case "explain":
+		handleExplain(args[1:])
+	case "bench":
+		handleBench(args[1:])
 	case "version", "-v", "--version":
+		if structuredOutput() {
+			emit(map[string]string{"version": version})
+			return
+		}
 		fmt.Printf("syndrdb v%s\n", version)
 	case "help", "-h", "--help":
 		printUsage()
 	default:
 		printError(fmt.Sprintf("Unknown command: %s", command))
 		printUsage()
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 }
 
 func printUsage() {
 	fmt.Println(colorBold(colorCyan("SyndrDB CLI")) + " - Database migrations, codegen, and testing\n")
 	fmt.Println("Usage:")
-	fmt.Println("  syndrdb " + colorYellow("<command>") + " [options]\n")
+	fmt.Println("  syndrdb [--output table|json|yaml] " + colorYellow("<command>") + " [options]\n")
 	fmt.Println("Commands:")
 	fmt.Println("  " + colorGreen("migrate") + "   Manage database migrations")
 	fmt.Println("  " + colorGreen("codegen") + "   Generate code from schema")
 	fmt.Println("  " + colorGreen("test") + "      Test database connection and schema")
+	fmt.Println("  " + colorGreen("shell") + "     Interactive SyndrQL shell")
+	fmt.Println("  " + colorGreen("schema") + "    Compare the live schema with a schema file")
+	fmt.Println("  " + colorGreen("export") + "    Export bundle documents to JSON, NDJSON or CSV")
+	fmt.Println("  " + colorGreen("import") + "    Import documents from JSON, NDJSON or CSV")
+	fmt.Println("  " + colorGreen("dump") + "      Back up a bundle to a checksummed dump file")
+	fmt.Println("  " + colorGreen("restore") + "   Restore a bundle from a dump file")
+	fmt.Println("  " + colorGreen("explain") + "   Show the execution plan for a query")
+	fmt.Println("  " + colorGreen("bench") + "     Load test the server and report throughput and latency")
 	fmt.Println("  " + colorGreen("version") + "   Show version information")
 	fmt.Println("  " + colorGreen("help") + "      Show this help message\n")
+	fmt.Println("Global Options:")
+	fmt.Println("  -o, --output   Result format: table, json or yaml (default: table). With json")
+	fmt.Println("                 or yaml, stdout holds only the result; messages go to stderr.")
+	fmt.Println()
 	fmt.Println("Run '" + colorCyan("syndrdb <command> --help") + "' for more information on a command.\n")
+	fmt.Println("Exit Codes:")
+	fmt.Println("  0 success, 1 error, 2 validation failure, 3 connection failure,")
+	fmt.Println("  4 pending migrations (migrate status --check)")
+	fmt.Println()
 	fmt.Println("Environment Variables:")
 	fmt.Println("  SYNDRDB_CONN             Database connection string")
+	fmt.Println("  SYNDRDB_CONFIG           Config file (default: syndrdb.yaml or .syndrdbrc in . or ~)")
 	fmt.Println("  SYNDRDB_MIGRATIONS_DIR   Directory for migration files (default: ./migrations)")
 	fmt.Println("  SYNDRDB_SCHEMA_FILE      Path to schema file (default: ./schema.json)")
+	fmt.Println("  SYNDRDB_OUTPUT           Default for --output")
+	fmt.Println("  SYNDRDB_NON_INTERACTIVE  Never prompt for confirmation; use --yes instead")
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,7 +20,7 @@ import (
 func handleMigrate(args []string) {
 	if len(args) == 0 {
 		printMigrateUsage()
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	subcommand := args[0]
@@ -31,6 +32,10 @@ func handleMigrate(args []string) {
 		handleMigrateGenerate(args[1:])
 	case "up":
 		handleMigrateUp(args[1:])
+	case "plan":
+		handleMigratePlan(args[1:])
+	case "apply":
+		handleMigrateApply(args[1:])
 	case "down":
 		handleMigrateDown(args[1:])
 	case "status":
@@ -42,7 +47,7 @@ func handleMigrate(args []string) {
 	default:
 		printError(fmt.Sprintf("Unknown migrate subcommand: %s", subcommand))
 		printMigrateUsage()
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 }
 
@@ -54,6 +59,8 @@ func printMigrateUsage() {
 	fmt.Println("  " + colorGreen("init") + "       Initialize migration directory and sample schema")
 	fmt.Println("  " + colorGreen("generate") + "   Generate a new migration from schema changes")
 	fmt.Println("  " + colorGreen("up") + "         Apply pending migrations")
+	fmt.Println("  " + colorGreen("plan") + "       Export pending migrations as a signed plan")
+	fmt.Println("  " + colorGreen("apply") + "      Apply a plan exported by plan")
 	fmt.Println("  " + colorGreen("down") + "       Rollback the last migration")
 	fmt.Println("  " + colorGreen("status") + "     Show migration status")
 	fmt.Println("  " + colorGreen("validate") + "   Validate migration files")
@@ -64,14 +71,64 @@ func printMigrateUsage() {
 	fmt.Println("  " + colorDim("# Create a new migration"))
 	fmt.Println("  syndrdb migrate generate --name add_users_table")
 	fmt.Println()
+	fmt.Println("  " + colorDim("# Create a migration from the changes to the live schema"))
+	fmt.Println("  syndrdb migrate generate --name add_age --diff --conn $SYNDRDB_CONN")
+	fmt.Println()
 	fmt.Println("  " + colorDim("# Apply migrations (with preview)"))
 	fmt.Println("  syndrdb migrate up --dry-run")
 	fmt.Println("  syndrdb migrate up")
 	fmt.Println()
+	fmt.Println("  " + colorDim("# Promote a plan from staging to production"))
+	fmt.Println("  syndrdb migrate plan --conn $STAGING_CONN --out plan.json")
+	fmt.Println("  syndrdb migrate apply --conn $PRODUCTION_CONN --plan plan.json")
+	fmt.Println()
 	fmt.Println("  " + colorDim("# Check status"))
 	fmt.Println("  syndrdb migrate status")
 }
 
+// migrationSummary describes a migration in structured output.
+type migrationSummary struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Status       string   `json:"status,omitempty"`
+	Created      string   `json:"created"`
+	UpCommands   int      `json:"up_commands"`
+	DownCommands int      `json:"down_commands"`
+	Destructive  []string `json:"destructive,omitempty"`
+}
+
+// summarizeMigration describes mig with the given status.
+func summarizeMigration(mig *migration.Migration, status string) migrationSummary {
+	summary := migrationSummary{
+		ID:           mig.ID,
+		Name:         mig.Name,
+		Status:       status,
+		Created:      mig.Timestamp.Format(time.RFC3339),
+		UpCommands:   len(mig.Up),
+		DownCommands: len(mig.Down),
+	}
+	for _, change := range migration.DetectDestructive(mig) {
+		summary.Destructive = append(summary.Destructive, change.Reason)
+	}
+	return summary
+}
+
+// migrationRunResult is the structured output of "migrate up", "down" and
+// "apply". Each migration's status says whether it was applied, rolled back,
+// or is still pending because of --dry-run or a declined prompt.
+type migrationRunResult struct {
+	DryRun     bool               `json:"dry_run"`
+	Cancelled  bool               `json:"cancelled,omitempty"`
+	Migrations []migrationSummary `json:"migrations"`
+}
+
+// setStatus sets the status of every migration in the result.
+func (r *migrationRunResult) setStatus(status string) {
+	for i := range r.Migrations {
+		r.Migrations[i].Status = status
+	}
+}
+
 // handleMigrateInit initializes a new migration project
 func handleMigrateInit(args []string) {
 	fs := flag.NewFlagSet("migrate init", flag.ExitOnError)
@@ -85,7 +142,7 @@ func handleMigrateInit(args []string) {
 	// Check if directory already exists
 	if _, err := os.Stat(*dir); err == nil && !*force {
 		printError(fmt.Sprintf("Directory %s already exists. Use --force to overwrite.", *dir))
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	// Create migration directory
@@ -150,10 +207,16 @@ This directory contains database migrations for your SyndrDB project.
 `
 	if err := os.WriteFile(readmePath, []byte(readme), 0644); err != nil {
 		printWarning(fmt.Sprintf("Failed to create README: %v", err))
+		readmePath = ""
 	} else {
 		printSuccess(fmt.Sprintf("Created README: %s", colorCyan(readmePath)))
 	}
 
+	if structuredOutput() {
+		emit(map[string]string{"dir": *dir, "schema_file": *schemaFile, "readme": readmePath})
+		return
+	}
+
 	fmt.Println()
 	printInfo("Next steps:")
 	fmt.Println("  1. Edit " + colorCyan(*schemaFile) + " to define your schema")
@@ -167,12 +230,16 @@ func handleMigrateGenerate(args []string) {
 	name := fs.String("name", "", "Migration name (required)")
 	schemaFile := fs.String("schema", getDefaultSchemaFile(), "Schema file path")
 	dir := fs.String("dir", getDefaultMigrationsDir(), "Migration directory")
+	lint := fs.Bool("lint", false, "Lint the schema first and stop on lint errors")
+	fromLive := fs.Bool("diff", false, "Generate only the changes from the live schema (requires --conn)")
+	connStr := fs.String("conn", defaultConnString(), "Connection string, used with --diff")
+	allowDestructive := fs.Bool("allow-destructive", false, "Allow --diff to drop bundles or fields, or change field types")
 	fs.Parse(args)
 
 	if *name == "" {
 		printError("Migration name is required")
 		fmt.Println("\nUsage: syndrdb migrate generate --name <name>")
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	printHeader(fmt.Sprintf("Generate Migration: %s", *name))
@@ -188,21 +255,64 @@ func handleMigrateGenerate(args []string) {
 	var newSchema schema.SchemaDefinition
 	if err := json.Unmarshal(data, &newSchema); err != nil {
 		printError(fmt.Sprintf("Failed to parse schema: %v", err))
-		os.Exit(1)
+		os.Exit(exitValidation)
+	}
+
+	if err := newSchema.ValidateConstraints(); err != nil {
+		printError(fmt.Sprintf("Invalid schema: %v", err))
+		os.Exit(exitValidation)
 	}
 
 	printInfo(fmt.Sprintf("Found %d bundle(s) in schema", len(newSchema.Bundles)))
 
-	// Generate UP commands from schema
-	upCommands := generateUpCommands(&newSchema)
+	if *lint {
+		result := schema.Lint(&newSchema)
+		if len(result.Issues) > 0 {
+			writeLintResult(os.Stdout, result)
+		}
+		if result.HasErrors() {
+			printError("Schema has lint errors - fix them or run without --lint")
+			os.Exit(exitValidation)
+		}
+	}
 
-	// Generate DOWN commands (drop bundles in reverse order)
-	rollbackGen := migration.NewRollbackGenerator()
-	downCommands, err := rollbackGen.GenerateDown(upCommands)
-	if err != nil {
-		printWarning(fmt.Sprintf("Could not auto-generate down commands: %v", err))
-		downCommands = []string{} // Empty down commands if auto-generation fails
-	} // Create migration
+	var upCommands, downCommands []string
+	if *fromLive {
+		// Generate UP and DOWN commands from the changes to the live schema
+		c := connectDataClient(*connStr)
+		live, err := fetchServerSchema(c, 0)
+		c.Disconnect(context.Background())
+		if err != nil {
+			printError(err.Error())
+			os.Exit(1)
+		}
+
+		upCommands, downCommands, err = generateDiffCommands(&newSchema, live, *allowDestructive)
+		if err != nil {
+			printError(err.Error())
+			os.Exit(exitValidation)
+		}
+		if len(upCommands) == 0 {
+			printSuccess("No changes: the live schema matches the schema file")
+			if structuredOutput() {
+				emit(map[string]interface{}{"created": false})
+			}
+			return
+		}
+	} else {
+		// Generate UP commands from schema
+		upCommands = generateUpCommands(&newSchema)
+
+		// Generate DOWN commands (drop bundles in reverse order)
+		rollbackGen := migration.NewRollbackGenerator()
+		downCommands, err = rollbackGen.GenerateDown(upCommands)
+		if err != nil {
+			printWarning(fmt.Sprintf("Could not auto-generate down commands: %v", err))
+			downCommands = []string{} // Empty down commands if auto-generation fails
+		}
+	}
+
+	// Create migration
 	mig := &migration.Migration{
 		ID:           generateMigrationID(*name),
 		Name:         *name,
@@ -220,6 +330,10 @@ func handleMigrateGenerate(args []string) {
 	}
 
 	printSuccess(fmt.Sprintf("Created migration: %s", colorCyan(filepath.Base(filePath))))
+	if structuredOutput() {
+		emit(map[string]interface{}{"created": true, "file": filePath, "migration": summarizeMigration(mig, "pending")})
+		return
+	}
 	fmt.Println()
 	printInfo("Migration preview:")
 	fmt.Println(colorDim("  UP commands:   " + fmt.Sprintf("%d", len(upCommands))))
@@ -234,17 +348,18 @@ func handleMigrateGenerate(args []string) {
 // handleMigrateUp applies pending migrations
 func handleMigrateUp(args []string) {
 	fs := flag.NewFlagSet("migrate up", flag.ExitOnError)
-	connStr := fs.String("conn", os.Getenv("SYNDRDB_CONN"), "Connection string")
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
 	dir := fs.String("dir", getDefaultMigrationsDir(), "Migration directory")
 	dryRun := fs.Bool("dry-run", false, "Show what would be applied without executing")
 	steps := fs.Int("steps", 0, "Number of migrations to apply (0 = all)")
-	force := fs.Bool("force", false, "Skip confirmation prompt")
+	confirm := addConfirmationFlags(fs)
+	allowDestructive := fs.Bool("allow-destructive", false, "Apply migrations that drop bundles or fields, or change field types")
 	fs.Parse(args)
 
 	if *connStr == "" {
 		printError("Connection string is required")
 		fmt.Println("\nProvide via --conn flag or SYNDRDB_CONN environment variable")
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	printHeader("Apply Migrations")
@@ -256,21 +371,23 @@ func handleMigrateUp(args []string) {
 		os.Exit(1)
 	}
 
+	result := &migrationRunResult{DryRun: *dryRun, Migrations: []migrationSummary{}}
 	if len(migrations) == 0 {
 		printWarning("No migration files found in " + *dir)
 		printInfo("Run " + colorCyan("syndrdb migrate generate") + " to create a migration")
+		emitRunResult(result)
 		return
 	}
 
 	printInfo(fmt.Sprintf("Found %d migration(s)", len(migrations)))
 
 	// Connect to database
-	opts := &client.ClientOptions{}
+	opts := cliOptions()
 	c := client.NewClient(opts)
 	ctx := context.Background()
 	if err := c.Connect(ctx, *connStr); err != nil {
 		printError(fmt.Sprintf("Failed to connect: %v", err))
-		os.Exit(1)
+		os.Exit(exitConnection)
 	}
 	defer c.Disconnect(ctx)
 
@@ -289,6 +406,7 @@ func handleMigrateUp(args []string) {
 
 	if len(plan.Migrations) == 0 {
 		printSuccess("All migrations are up to date!")
+		emitRunResult(result)
 		return
 	}
 
@@ -301,25 +419,46 @@ func handleMigrateUp(args []string) {
 	// Show plan
 	fmt.Println()
 	printInfo(fmt.Sprintf("Pending migrations: %d", plan.TotalCount))
+	plan.Destructive = nil
 	for i, mig := range plan.Migrations {
 		status := colorYellow("pending")
-		fmt.Printf("  %d. %s [%s]\n", i+1, colorBold(mig.Name), status)
+		marker := ""
+		destructive := migration.DetectDestructive(mig)
+		plan.Destructive = append(plan.Destructive, destructive...)
+		if len(destructive) > 0 {
+			marker = " " + colorRed("DESTRUCTIVE")
+		}
+		result.Migrations = append(result.Migrations, summarizeMigration(mig, "pending"))
+		fmt.Printf("  %d. %s [%s]%s\n", i+1, colorBold(mig.Name), status, marker)
 		fmt.Printf("     %s (%d up, %d down)\n", colorDim(mig.ID), len(mig.Up), len(mig.Down))
+		for _, change := range destructive {
+			fmt.Printf("     %s %s\n", colorRed("!"), change.Reason)
+		}
 	}
 
 	if *dryRun {
 		fmt.Println()
 		printInfo(colorYellow("DRY RUN") + " - no changes will be applied")
+		emitRunResult(result)
 		return
 	}
 
-	// Confirm before applying
-	if !*force {
-		fmt.Println()
-		if !promptConfirm(fmt.Sprintf("Apply %d migration(s)?", plan.TotalCount)) {
-			printInfo("Cancelled")
-			return
+	if len(plan.Destructive) > 0 {
+		if !*allowDestructive {
+			fmt.Println()
+			printError(fmt.Sprintf("%d destructive change(s) would lose data", len(plan.Destructive)))
+			fmt.Println("\nReview them and rerun with --allow-destructive to apply")
+			os.Exit(exitValidation)
 		}
+		plan.AllowDestructive = true
+	}
+
+	// Confirm before applying
+	if !confirm.confirmed(fmt.Sprintf("Apply %d migration(s)?", plan.TotalCount)) {
+		printInfo("Cancelled")
+		result.Cancelled = true
+		emitRunResult(result)
+		return
 	}
 
 	// Apply migrations
@@ -327,27 +466,32 @@ func handleMigrateUp(args []string) {
 	printHeader("Applying Migrations")
 
 	plan.DryRun = false
+	migrationClient.OnProgress(func(event migration.ProgressEvent) {
+		writeMigrationProgress(os.Stderr, event)
+	})
 	if err := migrationClient.Apply(plan); err != nil {
 		printError(fmt.Sprintf("Migration failed: %v", err))
 		os.Exit(1)
 	}
 
 	printSuccess("All migrations applied successfully!")
+	result.setStatus("applied")
+	emitRunResult(result)
 }
 
 // handleMigrateDown rolls back the last migration
 func handleMigrateDown(args []string) {
 	fs := flag.NewFlagSet("migrate down", flag.ExitOnError)
-	connStr := fs.String("conn", os.Getenv("SYNDRDB_CONN"), "Connection string")
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
 	dir := fs.String("dir", getDefaultMigrationsDir(), "Migration directory")
 	dryRun := fs.Bool("dry-run", false, "Show what would be rolled back without executing")
-	force := fs.Bool("force", false, "Skip confirmation prompt")
+	confirm := addConfirmationFlags(fs)
 	fs.Parse(args)
 
 	if *connStr == "" {
 		printError("Connection string is required")
 		fmt.Println("\nProvide via --conn flag or SYNDRDB_CONN environment variable")
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 
 	printHeader("Rollback Migration")
@@ -359,13 +503,16 @@ func handleMigrateDown(args []string) {
 		os.Exit(1)
 	}
 
+	result := &migrationRunResult{DryRun: *dryRun, Migrations: []migrationSummary{}}
 	if len(migrations) == 0 {
 		printWarning("No migrations found")
+		emitRunResult(result)
 		return
 	}
 
 	// Get last migration (TODO: track which are applied)
 	lastMigration := migrations[len(migrations)-1]
+	result.Migrations = append(result.Migrations, summarizeMigration(lastMigration, "applied"))
 
 	printInfo(fmt.Sprintf("Rolling back: %s", colorBold(lastMigration.Name)))
 	fmt.Println(colorDim("  ID: " + lastMigration.ID))
@@ -374,25 +521,25 @@ func handleMigrateDown(args []string) {
 	if *dryRun {
 		fmt.Println()
 		printInfo(colorYellow("DRY RUN") + " - no changes will be applied")
+		emitRunResult(result)
 		return
 	}
 
 	// Confirm
-	if !*force {
-		fmt.Println()
-		if !promptConfirm("Rollback this migration?") {
-			printInfo("Cancelled")
-			return
-		}
+	if !confirm.confirmed("Rollback this migration?") {
+		printInfo("Cancelled")
+		result.Cancelled = true
+		emitRunResult(result)
+		return
 	}
 
 	// Connect and rollback
-	opts := &client.ClientOptions{}
+	opts := cliOptions()
 	c := client.NewClient(opts)
 	ctx := context.Background()
 	if err := c.Connect(ctx, *connStr); err != nil {
 		printError(fmt.Sprintf("Failed to connect: %v", err))
-		os.Exit(1)
+		os.Exit(exitConnection)
 	}
 	defer c.Disconnect(ctx)
 
@@ -407,13 +554,24 @@ func handleMigrateDown(args []string) {
 	}
 
 	printSuccess("Migration rolled back successfully!")
+	result.setStatus("rolled_back")
+	emitRunResult(result)
+}
+
+// emitRunResult prints the result of a migration run in structured output
+// mode; in table mode the progress already printed is the result.
+func emitRunResult(result *migrationRunResult) {
+	if structuredOutput() {
+		emit(result)
+	}
 }
 
 // handleMigrateStatus shows the status of migrations
 func handleMigrateStatus(args []string) {
 	fs := flag.NewFlagSet("migrate status", flag.ExitOnError)
-	connStr := fs.String("conn", os.Getenv("SYNDRDB_CONN"), "Connection string (optional)")
+	connStr := fs.String("conn", defaultConnString(), "Connection string (optional)")
 	dir := fs.String("dir", getDefaultMigrationsDir(), "Migration directory")
+	check := fs.Bool("check", false, fmt.Sprintf("Exit with code %d if migrations are pending", exitPending))
 	fs.Parse(args)
 
 	printHeader("Migration Status")
@@ -425,22 +583,47 @@ func handleMigrateStatus(args []string) {
 		os.Exit(1)
 	}
 
-	if len(migrations) == 0 {
+	summaries := make([]migrationSummary, 0, len(migrations))
+	pending := 0
+	for _, mig := range migrations {
+		summary := summarizeMigration(mig, "pending") // TODO: check if applied
+		if summary.Status == "pending" {
+			pending++
+		}
+		summaries = append(summaries, summary)
+	}
+
+	switch {
+	case structuredOutput():
+		emit(map[string]interface{}{"dir": *dir, "total": len(migrations), "pending": pending, "migrations": summaries})
+	case len(migrations) == 0:
 		printWarning("No migration files found in " + *dir)
 		printInfo("Run " + colorCyan("syndrdb migrate generate") + " to create a migration")
-		return
+	default:
+		writeMigrationStatus(summaries, *connStr != "")
 	}
 
-	// Show all migrations
+	if *check && pending > 0 {
+		printWarning(fmt.Sprintf("%d pending migration(s)", pending))
+		os.Exit(exitPending)
+	}
+}
+
+// writeMigrationStatus prints the status table of "migrate status".
+func writeMigrationStatus(summaries []migrationSummary, connected bool) {
 	fmt.Println()
-	rows := make([][]string, 0, len(migrations))
-	for _, mig := range migrations {
-		status := colorYellow("pending") // TODO: check if applied
+	rows := make([][]string, 0, len(summaries))
+	for _, summary := range summaries {
+		status := summary.Status
+		if status == "pending" {
+			status = colorYellow(status)
+		}
+		created, _ := time.Parse(time.RFC3339, summary.Created)
 		rows = append(rows, []string{
-			mig.ID,
-			mig.Name,
+			summary.ID,
+			summary.Name,
 			status,
-			mig.Timestamp.Format("2006-01-02 15:04"),
+			created.Format("2006-01-02 15:04"),
 		})
 	}
 
@@ -450,9 +633,9 @@ func handleMigrateStatus(args []string) {
 	)
 
 	fmt.Println()
-	printInfo(fmt.Sprintf("Total migrations: %d", len(migrations)))
+	printInfo(fmt.Sprintf("Total migrations: %d", len(summaries)))
 
-	if *connStr != "" {
+	if connected {
 		printInfo("Connected to database - showing actual status")
 	} else {
 		printInfo("Not connected - showing file status only")
@@ -477,6 +660,9 @@ func handleMigrateValidate(args []string) {
 
 	if len(migrations) == 0 {
 		printWarning("No migration files found")
+		if structuredOutput() {
+			emit(&migration.ValidationResult{Valid: true, Conflicts: []migration.MigrationConflict{}})
+		}
 		return
 	}
 
@@ -487,6 +673,14 @@ func handleMigrateValidate(args []string) {
 	validator := migration.NewMigrationValidator(migration.NewMigrationHistory())
 	validation := validator.Validate(migrations)
 
+	if structuredOutput() {
+		emit(validation)
+		if !validation.Valid {
+			os.Exit(exitValidation)
+		}
+		return
+	}
+
 	if validation.Valid {
 		printSuccess("All migrations are valid!")
 		return
@@ -499,7 +693,7 @@ func handleMigrateValidate(args []string) {
 		fmt.Println(colorRed("✗") + " " + conflict.Message)
 	}
 
-	os.Exit(1)
+	os.Exit(exitValidation)
 }
 
 // Helper functions
@@ -518,6 +712,42 @@ func getDefaultSchemaFile() string {
 	return "./schema.json"
 }
 
+// nonInteractiveEnv names the environment variable that turns on
+// --non-interactive, e.g. for a whole CI job.
+const nonInteractiveEnv = "SYNDRDB_NON_INTERACTIVE"
+
+// confirmation holds the flags of a command that asks before changing data.
+type confirmation struct {
+	yes            bool
+	nonInteractive bool
+}
+
+// addConfirmationFlags registers --yes (also -y, and --force as before) and
+// --non-interactive on fs.
+func addConfirmationFlags(fs *flag.FlagSet) *confirmation {
+	c := &confirmation{}
+	fs.BoolVar(&c.yes, "yes", false, "Answer yes to the confirmation prompt")
+	fs.BoolVar(&c.yes, "y", false, "Shorthand for --yes")
+	fs.BoolVar(&c.yes, "force", false, "Same as --yes")
+	fs.BoolVar(&c.nonInteractive, "non-interactive", os.Getenv(nonInteractiveEnv) != "", "Never prompt; fail instead unless --yes is given (or set "+nonInteractiveEnv+")")
+	return c
+}
+
+// confirmed reports whether the user agreed to message. With --yes it does
+// not ask; with --non-interactive it exits with exitValidation rather than
+// wait for input that will never come.
+func (c *confirmation) confirmed(message string) bool {
+	if c.yes {
+		return true
+	}
+	if c.nonInteractive {
+		printError(fmt.Sprintf("%s Confirmation is required; rerun with --yes", message))
+		os.Exit(exitValidation)
+	}
+	fmt.Println()
+	return promptConfirm(message)
+}
+
 func promptConfirm(message string) bool {
 	fmt.Printf("%s [y/N]: ", message)
 	reader := bufio.NewReader(os.Stdin)
@@ -534,38 +764,92 @@ func generateMigrationID(name string) string {
 	return id
 }
 
-func generateUpCommands(schema *schema.SchemaDefinition) []string {
+// generateUpCommands creates the bundles, indexes and relationships of a
+// schema, including field defaults. Relationships are added after every
+// bundle exists, since they may point at bundles defined later in the file.
+func generateUpCommands(schemaDef *schema.SchemaDefinition) []string {
 	commands := make([]string, 0)
-	for _, bundle := range schema.Bundles {
-		// Generate CREATE BUNDLE command
-		fields := make([]string, 0, len(bundle.Fields))
-		for _, field := range bundle.Fields {
-			fieldDef := field.Name + " " + string(field.Type)
-			if field.Required {
-				fieldDef += " REQUIRED"
-			}
-			if field.Unique {
-				fieldDef += " UNIQUE"
+	for i := range schemaDef.Bundles {
+		bundle := &schemaDef.Bundles[i]
+		commands = append(commands, schema.SerializeCreateBundle(bundle))
+
+		for j := range bundle.Indexes {
+			if indexCmd := schema.SerializeCreateIndex(&bundle.Indexes[j], bundle.Name); indexCmd != "" {
+				commands = append(commands, indexCmd)
 			}
-			fields = append(fields, fieldDef)
 		}
-
-		cmd := fmt.Sprintf("CREATE BUNDLE %s (%s);", bundle.Name, strings.Join(fields, ", "))
-		commands = append(commands, cmd)
-
-		// Generate CREATE INDEX commands
-		for _, index := range bundle.Indexes {
-			indexCmd := fmt.Sprintf("CREATE INDEX %s ON %s USING %s (%s);",
-				index.Name,
-				bundle.Name,
-				strings.ToUpper(string(index.Type)),
-				strings.Join(index.Fields, ", "))
-			commands = append(commands, indexCmd)
+	}
+	for i := range schemaDef.Bundles {
+		bundle := &schemaDef.Bundles[i]
+		for j := range bundle.Relationships {
+			commands = append(commands, schema.SerializeAddRelationship(bundle.Name, &bundle.Relationships[j]))
 		}
 	}
 	return commands
 }
 
+// progressBarWidth is the number of cells in the migrate up progress bar.
+const progressBarWidth = 30
+
+// writeMigrationProgress renders a progress event from Apply: a line per
+// finished migration and, after each command, a progress bar with the elapsed
+// time and estimated time left, redrawn in place.
+func writeMigrationProgress(w io.Writer, event migration.ProgressEvent) {
+	switch event.Stage {
+	case migration.CommandCompleted:
+		fmt.Fprintf(w, "\r\033[K%s %3.0f%% %d/%d commands %s",
+			progressBar(event.Fraction(), progressBarWidth), event.Fraction()*100,
+			event.CompletedCommands, event.TotalCommands,
+			colorDim(fmt.Sprintf("[%d/%d %s] %s elapsed, ETA %s", event.MigrationIndex+1, event.MigrationCount,
+				event.MigrationID, formatProgressDuration(event.Elapsed), formatProgressDuration(event.Remaining()))))
+	case migration.MigrationCompleted:
+		fmt.Fprint(w, "\r\033[K")
+		if event.Err != nil {
+			fmt.Fprintf(w, "%s %s %s\n", colorRed("✗"), event.MigrationID, colorDim(formatProgressDuration(event.Duration)))
+		} else {
+			fmt.Fprintf(w, "%s %s %s\n", colorGreen("✓"), event.MigrationID, colorDim(formatProgressDuration(event.Duration)))
+		}
+	}
+}
+
+// progressBar draws fraction (0 to 1) as a bar of width cells.
+func progressBar(fraction float64, width int) string {
+	filled := int(fraction * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// formatProgressDuration rounds d for display: milliseconds under a second,
+// tenths of a second under a minute, whole seconds above.
+func formatProgressDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
+
+// generateDiffCommands returns the commands changing the live schema into
+// the schema file and the commands reverting them. Destructive changes are
+// refused unless allowDestructive is set.
+func generateDiffCommands(schemaDef, live *schema.SchemaDefinition, allowDestructive bool) ([]string, []string, error) {
+	diff := schema.CompareSchemas(schemaDef, live)
+	sortSchemaDiff(diff)
+
+	if destructive := destructiveChanges(diff); len(destructive) > 0 && !allowDestructive {
+		return nil, nil, fmt.Errorf("refusing to generate %d destructive change(s) without --allow-destructive:\n  • %s",
+			len(destructive), strings.Join(destructive, "\n  • "))
+	}
+
+	up, down := schema.SerializeSchemaDiff(diff)
+	return up, down, nil
+}
+
 // clientExecutorAdapter adapts client.Client to migration.MigrationExecutor
 type clientExecutorAdapter struct {
 	client *client.Client
@@ -577,5 +861,9 @@ func (a *clientExecutorAdapter) Execute(command string) (interface{}, error) {
 	if strings.HasPrefix(cmdUpper, "SELECT") || strings.HasPrefix(cmdUpper, "SHOW") {
 		return a.client.Query(command, 0)
 	}
-	return a.client.Mutate(command, 0)
+	result, err := a.client.Mutate(command, 0)
+	if err != nil {
+		return nil, err
+	}
+	return result.Raw, nil
 }
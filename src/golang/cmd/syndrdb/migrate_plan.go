@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/migration"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// planKeyEnv names the environment variable holding the plan signing key.
+const planKeyEnv = "SYNDRDB_PLAN_KEY"
+
+// handleMigratePlan exports the pending migrations as a signed plan artifact
+func handleMigratePlan(args []string) {
+	fs := flag.NewFlagSet("migrate plan", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	dir := fs.String("dir", getDefaultMigrationsDir(), "Migration directory")
+	schemaFile := fs.String("schema", "", "Schema file the plan produces, recorded as the target schema")
+	out := fs.String("out", "plan.json", "Plan artifact to write")
+	key := fs.String("key", os.Getenv(planKeyEnv), "Signing key (or use "+planKeyEnv+")")
+	fs.Parse(args)
+
+	if *key == "" {
+		printError("A signing key is required")
+		fmt.Println("\nProvide via --key flag or " + planKeyEnv + " environment variable")
+		os.Exit(exitValidation)
+	}
+
+	printHeader("Export Migration Plan")
+
+	migrations, err := migration.ListMigrationFiles(*dir)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to list migrations: %v", err))
+		os.Exit(1)
+	}
+
+	var target *schema.SchemaDefinition
+	if *schemaFile != "" {
+		if target, err = readSchemaFile(*schemaFile); err != nil {
+			printError(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	c := connectDataClient(*connStr)
+	base, err := fetchServerSchema(c, 0)
+	c.Disconnect(context.Background())
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+
+	plan, err := migration.NewClient(nil).Plan(migrations)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to create migration plan: %v", err))
+		os.Exit(1)
+	}
+
+	data, err := migration.ExportPlan(plan, base, target, []byte(*key))
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		printError(fmt.Sprintf("Failed to write plan: %v", err))
+		os.Exit(1)
+	}
+
+	printSuccess(fmt.Sprintf("Wrote plan with %d migration(s) to %s", plan.TotalCount, colorCyan(*out)))
+	if len(plan.Destructive) > 0 {
+		printWarning(fmt.Sprintf("Plan contains %d destructive change(s); apply it with --allow-destructive", len(plan.Destructive)))
+	}
+	printInfo("Apply exactly this plan with " + colorCyan("syndrdb migrate apply --plan "+*out))
+
+	if structuredOutput() {
+		summaries := make([]migrationSummary, 0, len(plan.Migrations))
+		for _, mig := range plan.Migrations {
+			summaries = append(summaries, summarizeMigration(mig, "pending"))
+		}
+		emit(map[string]interface{}{"file": *out, "migrations": summaries})
+	}
+}
+
+// handleMigrateApply applies a plan artifact exported by "migrate plan"
+func handleMigrateApply(args []string) {
+	fs := flag.NewFlagSet("migrate apply", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	planFile := fs.String("plan", "", "Plan artifact to apply (required)")
+	key := fs.String("key", os.Getenv(planKeyEnv), "Signing key (or use "+planKeyEnv+")")
+	dryRun := fs.Bool("dry-run", false, "Verify the plan and preconditions without executing")
+	confirm := addConfirmationFlags(fs)
+	allowDestructive := fs.Bool("allow-destructive", false, "Apply migrations that drop bundles or fields, or change field types")
+	fs.Parse(args)
+
+	if *planFile == "" {
+		printError("Plan file is required")
+		fmt.Println("\nUsage: syndrdb migrate apply --plan <plan.json>")
+		os.Exit(exitValidation)
+	}
+	if *key == "" {
+		printError("A signing key is required")
+		fmt.Println("\nProvide via --key flag or " + planKeyEnv + " environment variable")
+		os.Exit(exitValidation)
+	}
+
+	printHeader("Apply Migration Plan")
+
+	data, err := os.ReadFile(*planFile)
+	if err != nil {
+		printError(fmt.Sprintf("Failed to read plan: %v", err))
+		os.Exit(1)
+	}
+	artifact, err := migration.ImportPlan(data, []byte(*key))
+	if err != nil {
+		printError(fmt.Sprintf("Refusing to apply plan: %v", err))
+		os.Exit(exitValidation)
+	}
+	plan := artifact.Plan
+	printInfo(fmt.Sprintf("Plan from %s with %d migration(s), signature verified",
+		artifact.CreatedAt.Format("2006-01-02 15:04:05 MST"), len(plan.Migrations)))
+
+	c := connectDataClient(*connStr)
+	defer c.Disconnect(context.Background())
+
+	live, err := fetchServerSchema(c, 0)
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+	if err := artifact.CheckPreconditions(live); err != nil {
+		printError("The schema no longer matches the plan's preconditions")
+		if migErr, ok := err.(*migration.MigrationError); ok {
+			if changes, ok := migErr.Details["changes"].([]string); ok {
+				for _, change := range changes {
+					fmt.Println("  • " + change)
+				}
+			}
+		}
+		fmt.Println("\nExport a new plan against the current schema")
+		os.Exit(exitValidation)
+	}
+	printSuccess("Schema matches the plan's preconditions")
+
+	result := &migrationRunResult{DryRun: *dryRun, Migrations: []migrationSummary{}}
+	destructive := 0
+	for i, mig := range plan.Migrations {
+		result.Migrations = append(result.Migrations, summarizeMigration(mig, "pending"))
+		marker := ""
+		if changes := migration.DetectDestructive(mig); len(changes) > 0 {
+			destructive += len(changes)
+			marker = " " + colorRed("DESTRUCTIVE")
+		}
+		fmt.Printf("  %d. %s%s\n", i+1, colorBold(mig.Name), marker)
+		fmt.Printf("     %s (%d up, %d down)\n", colorDim(mig.ID), len(mig.Up), len(mig.Down))
+	}
+
+	if *dryRun {
+		fmt.Println()
+		printInfo(colorYellow("DRY RUN") + " - no changes will be applied")
+		emitRunResult(result)
+		return
+	}
+	if destructive > 0 && !*allowDestructive {
+		fmt.Println()
+		printError(fmt.Sprintf("%d destructive change(s) would lose data", destructive))
+		fmt.Println("\nReview them and rerun with --allow-destructive to apply")
+		os.Exit(exitValidation)
+	}
+	if !confirm.confirmed(fmt.Sprintf("Apply %d migration(s)?", len(plan.Migrations))) {
+		printInfo("Cancelled")
+		result.Cancelled = true
+		emitRunResult(result)
+		return
+	}
+
+	migrationClient := migration.NewClient(&clientExecutorAdapter{client: c})
+	migrationClient.OnProgress(func(event migration.ProgressEvent) {
+		writeMigrationProgress(os.Stderr, event)
+	})
+	plan.DryRun = false
+	plan.AllowDestructive = *allowDestructive
+	if err := migrationClient.Apply(plan); err != nil {
+		printError(fmt.Sprintf("Migration failed: %v", err))
+		os.Exit(1)
+	}
+	printSuccess("Plan applied successfully!")
+
+	if artifact.TargetSchema != nil {
+		checkTargetSchema(c, artifact.TargetSchema)
+	}
+	result.setStatus("applied")
+	emitRunResult(result)
+}
+
+// checkTargetSchema warns if the schema after applying a plan differs from its target.
+func checkTargetSchema(c *client.Client, target *schema.SchemaDefinition) {
+	live, err := fetchServerSchema(c, 0)
+	if err != nil {
+		printWarning(fmt.Sprintf("Could not verify the target schema: %v", err))
+		return
+	}
+	diff := schema.CompareSchemas(target, live)
+	if !diff.HasChanges {
+		printSuccess("Schema matches the plan's target schema")
+		return
+	}
+	sortSchemaDiff(diff)
+	printWarning("Schema differs from the plan's target schema:")
+	writeSchemaDiff(os.Stdout, diff)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/migration"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+func TestGenerateUpCommands(t *testing.T) {
+	up := generateUpCommands(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{
+			Name: "users",
+			Fields: []schema.FieldDefinition{
+				{Name: "email", Type: schema.STRING, Required: true, Unique: true},
+				{Name: "role", Type: schema.STRING, DefaultValue: "member"},
+			},
+			Indexes: []schema.IndexDefinition{{Name: "idx_email", Type: schema.HASH, Fields: []string{"email"}}},
+		},
+	}})
+
+	if len(up) != 2 {
+		t.Fatalf("expected CREATE BUNDLE and CREATE INDEX, got %v", up)
+	}
+	if !strings.Contains(up[0], `{"role", "STRING", FALSE, FALSE, "member"}`) {
+		t.Errorf("expected default value in CREATE BUNDLE, got: %s", up[0])
+	}
+	if want := `CREATE HASH INDEX "idx_email" ON BUNDLE "users" WITH FIELDS ("email");`; up[1] != want {
+		t.Errorf("expected %q, got %q", want, up[1])
+	}
+
+	down, err := migration.NewRollbackGenerator().GenerateDown(up)
+	if err != nil {
+		t.Fatalf("expected reversible UP commands: %v", err)
+	}
+	if len(down) != 2 || down[1] != `DROP BUNDLE "users";` {
+		t.Errorf("unexpected DOWN commands: %v", down)
+	}
+}
+
+func TestGenerateUpCommands_Relationships(t *testing.T) {
+	up := generateUpCommands(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{
+			Name:   "users",
+			Fields: []schema.FieldDefinition{{Name: "id", Type: schema.STRING}},
+			Relationships: []schema.RelationshipDefinition{
+				{Name: "posts", Type: "1toMany", SourceBundle: "users", SourceField: "id", DestBundle: "posts", DestField: "user_id"},
+			},
+		},
+		{
+			Name:   "posts",
+			Fields: []schema.FieldDefinition{{Name: "user_id", Type: schema.STRING}},
+		},
+	}})
+
+	// The relationship is added once both bundles exist
+	if len(up) != 3 || !strings.HasPrefix(up[1], `CREATE BUNDLE "posts"`) {
+		t.Fatalf("expected both bundles before the relationship, got %v", up)
+	}
+	if want := `UPDATE BUNDLE "users" ADD RELATIONSHIP ("posts" {"1toMany", "users", "id", "posts", "user_id"});`; up[2] != want {
+		t.Errorf("expected %q, got %q", want, up[2])
+	}
+
+	down, err := migration.NewRollbackGenerator().GenerateDown(up)
+	if err != nil {
+		t.Fatalf("expected reversible UP commands: %v", err)
+	}
+	want := []string{`UPDATE BUNDLE "users" REMOVE RELATIONSHIP "posts";`, `DROP BUNDLE "posts";`, `DROP BUNDLE "users";`}
+	if strings.Join(down, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected DOWN %q, got %q", want, down)
+	}
+}
+
+func TestGenerateDiffCommands(t *testing.T) {
+	live := &schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{
+			{Name: "email", Type: schema.STRING},
+			{Name: "nickname", Type: schema.STRING},
+		}},
+	}}
+	local := &schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{
+			{Name: "email", Type: schema.STRING},
+			{Name: "age", Type: schema.INT},
+		}},
+	}}
+
+	// Removing nickname drops data
+	if _, _, err := generateDiffCommands(local, live, false); err == nil || !strings.Contains(err.Error(), `field "nickname" would be removed`) {
+		t.Fatalf("expected destructive change to be refused, got %v", err)
+	}
+
+	up, down, err := generateDiffCommands(local, live, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(up) != 1 || !strings.Contains(up[0], `{ADD "age" = "age", "INT"`) || !strings.Contains(up[0], `{REMOVE "nickname"`) {
+		t.Errorf("unexpected UP commands: %q", up)
+	}
+	if len(down) != 1 || !strings.Contains(down[0], `{REMOVE "age"`) || !strings.Contains(down[0], `{ADD "nickname" = "nickname", "STRING"`) {
+		t.Errorf("unexpected DOWN commands: %q", down)
+	}
+
+	// Adding a field alone is safe
+	local.Bundles[0].Fields = append(local.Bundles[0].Fields, live.Bundles[0].Fields[1])
+	if up, _, err := generateDiffCommands(local, live, false); err != nil || len(up) != 1 {
+		t.Errorf("expected a safe migration, got %q, %v", up, err)
+	}
+}
+
+func TestWriteMigrationProgress(t *testing.T) {
+	var buf strings.Builder
+	writeMigrationProgress(&buf, migration.ProgressEvent{
+		Stage: migration.CommandCompleted, MigrationID: "002_backfill", MigrationIndex: 1, MigrationCount: 3,
+		CompletedCommands: 5, TotalCommands: 10, Elapsed: 10 * time.Second,
+	})
+	out := buf.String()
+	for _, want := range []string{progressBar(0.5, progressBarWidth), " 50% 5/10 commands", "[2/3 002_backfill] 10s elapsed, ETA 10s"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+
+	buf.Reset()
+	writeMigrationProgress(&buf, migration.ProgressEvent{Stage: migration.MigrationCompleted, MigrationID: "002_backfill", Duration: 1500 * time.Millisecond})
+	if out := buf.String(); !strings.Contains(out, "002_backfill") || !strings.Contains(out, "1.5s") {
+		t.Errorf("unexpected completion line %q", buf.String())
+	}
+
+	if bar := progressBar(1.2, 4); bar != "[████]" {
+		t.Errorf("expected a full bar, got %q", bar)
+	}
+}
+
+func TestConfirmationFlags(t *testing.T) {
+	t.Setenv(nonInteractiveEnv, "")
+
+	for _, args := range [][]string{{"--yes"}, {"-y"}, {"--force"}} {
+		fs := flag.NewFlagSet("migrate up", flag.ContinueOnError)
+		confirm := addConfirmationFlags(fs)
+		if err := fs.Parse(append(args, "--non-interactive")); err != nil {
+			t.Fatal(err)
+		}
+		if !confirm.yes || !confirm.nonInteractive || !confirm.confirmed("Apply?") {
+			t.Errorf("%v: expected the prompt to be answered without asking", args)
+		}
+	}
+
+	t.Setenv(nonInteractiveEnv, "1")
+	confirm := addConfirmationFlags(flag.NewFlagSet("migrate down", flag.ContinueOnError))
+	if confirm.yes || !confirm.nonInteractive {
+		t.Errorf("expected %s to turn on --non-interactive, got %+v", nonInteractiveEnv, confirm)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Output formats selected with the global --output flag.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// outputEnv names the environment variable holding the default output format.
+const outputEnv = "SYNDRDB_OUTPUT"
+
+// outputFormat is the format command results are printed in.
+var outputFormat = outputTable
+
+// resultWriter receives command results. It stays the process's stdout after
+// setOutputFormat moves progress and messages to stderr.
+var resultWriter io.Writer = os.Stdout
+
+// extractOutputFlag removes the global --output option from the command line
+// (os.Args[1:]) and returns the format and the remaining arguments. The
+// option may come before the command, or anywhere after it except for
+// codegen, whose subcommands use --output for a file path.
+func extractOutputFlag(args []string) (string, []string, error) {
+	format := os.Getenv(outputEnv)
+	rest := make([]string, 0, len(args))
+	command := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || command == "codegen" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		if name != "--output" && name != "-o" {
+			if command == "" && !strings.HasPrefix(arg, "-") {
+				command = arg
+			}
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return "", nil, fmt.Errorf("--output requires a value (table, json or yaml)")
+			}
+			i++
+			value = args[i]
+		}
+		format = value
+	}
+
+	switch format {
+	case "":
+		return outputTable, rest, nil
+	case outputTable, outputJSON, outputYAML:
+		return format, rest, nil
+	default:
+		return "", nil, fmt.Errorf("unknown output format %q (want table, json or yaml)", format)
+	}
+}
+
+// setOutputFormat selects the output format. For json and yaml, everything
+// written to os.Stdout goes to stderr instead, so that stdout carries only
+// the result document emitted by the command.
+func setOutputFormat(format string) {
+	outputFormat = format
+	if structuredOutput() && resultWriter == io.Writer(os.Stdout) {
+		os.Stdout = os.Stderr
+	}
+}
+
+// structuredOutput reports whether results are printed as json or yaml.
+func structuredOutput() bool {
+	return outputFormat == outputJSON || outputFormat == outputYAML
+}
+
+// emit writes a command result to resultWriter in the selected structured
+// format. Field names follow the json tags of v.
+func emit(v interface{}) {
+	var data []byte
+	var err error
+	if outputFormat == outputYAML {
+		data, err = marshalYAML(v)
+	} else {
+		data, err = json.MarshalIndent(v, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		printError(fmt.Sprintf("Failed to encode output: %v", err))
+		os.Exit(1)
+	}
+	resultWriter.Write(data)
+}
+
+// yamlNode is a decoded JSON value that keeps the order of object keys.
+type yamlNode struct {
+	keys   []string    // Object keys, in order; nil for arrays and scalars
+	items  []*yamlNode // Object values or array items
+	array  bool
+	object bool
+	scalar interface{} // string, json.Number, bool or nil
+}
+
+// marshalYAML encodes v as block-style YAML. v is marshaled to JSON first so
+// that json tags, omitempty and custom marshalers apply as they do for json.
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := decodeYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	if !node.block() {
+		b.WriteString(node.inline())
+		b.WriteString("\n")
+	} else {
+		node.write(&b, 0)
+	}
+	return []byte(b.String()), nil
+}
+
+// decodeYAMLNode reads the next JSON value from dec.
+func decodeYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return &yamlNode{scalar: tok}, nil
+	}
+
+	node := &yamlNode{object: delim == '{', array: delim == '['}
+	for dec.More() {
+		if node.object {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			node.keys = append(node.keys, key.(string))
+		}
+		item, err := decodeYAMLNode(dec)
+		if err != nil {
+			return nil, err
+		}
+		node.items = append(node.items, item)
+	}
+	// Consume the closing delimiter
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// block reports whether the node is written on lines of its own.
+func (n *yamlNode) block() bool {
+	return (n.object || n.array) && len(n.items) > 0
+}
+
+// inline renders a scalar or an empty collection.
+func (n *yamlNode) inline() string {
+	switch {
+	case n.object:
+		return "{}"
+	case n.array:
+		return "[]"
+	}
+	switch v := n.scalar.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	default:
+		return yamlString(fmt.Sprint(v))
+	}
+}
+
+// write writes a non-empty object or array, every line indented by indent.
+func (n *yamlNode) write(b *strings.Builder, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for i, item := range n.items {
+		if n.object {
+			b.WriteString(pad + yamlString(n.keys[i]) + ":")
+			if item.block() {
+				b.WriteString("\n")
+				item.write(b, indent+2)
+			} else {
+				b.WriteString(" " + item.inline() + "\n")
+			}
+			continue
+		}
+
+		if !item.block() {
+			b.WriteString(pad + "- " + item.inline() + "\n")
+			continue
+		}
+		// Write the item indented, then put the dash in front of its first line
+		var nested strings.Builder
+		item.write(&nested, indent+2)
+		b.WriteString(pad + "- " + nested.String()[indent+2:])
+	}
+}
+
+// yamlString returns s, quoted if YAML would otherwise read it as something
+// other than the same string.
+func yamlString(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, "\n\r\t\"\\") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") ||
+		strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'%@`") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil || strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0o") {
+		return strconv.Quote(s)
+	}
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExtractOutputFlag(t *testing.T) {
+	t.Setenv(outputEnv, "")
+
+	tests := []struct {
+		args   []string
+		format string
+		rest   string
+	}{
+		{[]string{"migrate", "status"}, outputTable, "migrate status"},
+		{[]string{"--output", "json", "migrate", "status"}, outputJSON, "migrate status"},
+		{[]string{"-o=yaml", "test", "connection"}, outputYAML, "test connection"},
+		{[]string{"migrate", "status", "--dir", "m", "--output=json"}, outputJSON, "migrate status --dir m"},
+		{[]string{"explain", "-o", "yaml", "--", "--output"}, outputYAML, "explain -- --output"},
+		// codegen subcommands take --output as a file path
+		{[]string{"codegen", "fetch-schema", "--output", "schema.json"}, outputTable, "codegen fetch-schema --output schema.json"},
+		{[]string{"-o", "json", "codegen", "openapi", "--output", "api.json"}, outputJSON, "codegen openapi --output api.json"},
+	}
+	for _, tt := range tests {
+		format, rest, err := extractOutputFlag(tt.args)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if format != tt.format || strings.Join(rest, " ") != tt.rest {
+			t.Errorf("%v: got %q %q, want %q %q", tt.args, format, rest, tt.format, tt.rest)
+		}
+	}
+
+	for _, args := range [][]string{{"--output", "xml", "version"}, {"version", "--output"}} {
+		if _, _, err := extractOutputFlag(args); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+
+	t.Setenv(outputEnv, "yaml")
+	if format, _, _ := extractOutputFlag([]string{"version"}); format != outputYAML {
+		t.Errorf("expected %s to set the default format, got %s", outputEnv, format)
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	report := &diagnosticsReport{
+		Passed: false,
+		Checks: []diagnosticCheck{
+			{Name: "connect", Status: checkPass, DurationMs: 1.5},
+			{Name: "auth", Status: checkFail, Error: "bad password: try again"},
+		},
+		Warnings: []string{"true", "", "- dash", "plain text"},
+	}
+	data, err := marshalYAML(map[string]interface{}{
+		"report": report,
+		"empty":  []string{},
+		"nested": [][]int{{1, 2}, {}},
+	})
+	if err != nil {
+		t.Fatalf("marshalYAML failed: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"empty: []",
+		"nested:",
+		"  - - 1",
+		"    - 2",
+		"  - []",
+		"report:",
+		"  passed: false",
+		"  checks:",
+		"    - name: connect",
+		"      status: pass",
+		"      duration_ms: 1.5",
+		"    - name: auth",
+		"      status: fail",
+		"      duration_ms: 0",
+		`      error: "bad password: try again"`,
+		"  warnings:",
+		`    - "true"`,
+		`    - ""`,
+		`    - "- dash"`,
+		"    - plain text",
+		"",
+	}, "\n")
+	if string(data) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", data, want)
+	}
+
+	if data, _ := marshalYAML("42"); string(data) != "\"42\"\n" {
+		t.Errorf("expected a quoted scalar, got %q", data)
+	}
+}
+
+func TestEmit(t *testing.T) {
+	var buf bytes.Buffer
+	savedWriter, savedFormat := resultWriter, outputFormat
+	defer func() { resultWriter, outputFormat = savedWriter, savedFormat }()
+	resultWriter = &buf
+
+	outputFormat = outputJSON
+	emit(map[string]int{"total": 2})
+	outputFormat = outputYAML
+	emit(map[string]int{"total": 2})
+
+	if want := "{\n  \"total\": 2\n}\ntotal: 2\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// exitSchemaDrift is the exit code of "schema diff" when the live schema differs
+// from the schema file. Errors exit with 1 so CI can tell the two apart.
+const exitSchemaDrift = 2
+
+func handleSchema(args []string) {
+	if len(args) == 0 {
+		printSchemaUsage()
+		os.Exit(exitValidation)
+	}
+
+	subcommand := args[0]
+
+	switch subcommand {
+	case "diff":
+		handleSchemaDiff(args[1:])
+	case "lint":
+		handleSchemaLint(args[1:])
+	case "help", "-h", "--help":
+		printSchemaUsage()
+	default:
+		printError(fmt.Sprintf("Unknown schema subcommand: %s", subcommand))
+		printSchemaUsage()
+		os.Exit(exitValidation)
+	}
+}
+
+func printSchemaUsage() {
+	printHeader("Schema Commands")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb schema " + colorYellow("<command>") + " [options]\n")
+	fmt.Println("Commands:")
+	fmt.Println("  " + colorGreen("diff") + "  Compare the live schema with a schema file")
+	fmt.Println("  " + colorGreen("lint") + "  Check a schema file for design problems")
+	fmt.Println("\nExamples:")
+	fmt.Println("  " + colorDim("# Show drift between the server and schema.json"))
+	fmt.Println("  syndrdb schema diff --conn $SYNDRDB_CONN --schema ./schema.json")
+	fmt.Println()
+	fmt.Println("  " + colorDim("# Machine-readable output for CI"))
+	fmt.Println("  syndrdb schema diff --json")
+	fmt.Println()
+	fmt.Println("  " + colorDim("# Lint the schema, failing on warnings too"))
+	fmt.Println("  syndrdb schema lint --strict")
+	fmt.Println()
+	fmt.Printf("diff exits with %d when drift exists and 1 on errors.\n", exitSchemaDrift)
+}
+
+// schemaDiffReport is the structured output of "schema diff".
+type schemaDiffReport struct {
+	*schema.SchemaDiff
+	DestructiveChanges []string `json:"destructiveChanges"`
+}
+
+// handleSchemaDiff compares the live schema with a schema file
+func handleSchemaDiff(args []string) {
+	fs := flag.NewFlagSet("schema diff", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	schemaFile := fs.String("schema", getDefaultSchemaFile(), "Schema file path")
+	jsonOutput := fs.Bool("json", false, "Print the diff as JSON (same as --output json)")
+	fs.Parse(args)
+	if *jsonOutput {
+		setOutputFormat(outputJSON)
+	}
+
+	local, err := readSchemaFile(*schemaFile)
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+
+	c := connectDataClient(*connStr)
+	live, err := fetchServerSchema(c, 0)
+	c.Disconnect(context.Background())
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+
+	diff := schema.CompareSchemas(local, live)
+	sortSchemaDiff(diff)
+	destructive := destructiveChanges(diff)
+
+	if structuredOutput() {
+		if destructive == nil {
+			destructive = []string{}
+		}
+		emit(schemaDiffReport{SchemaDiff: diff, DestructiveChanges: destructive})
+	} else {
+		printHeader("Schema Diff")
+		fmt.Printf("%s %s → %s\n\n", colorDim("Comparing"), colorCyan(*schemaFile), colorCyan(maskConnectionString(*connStr)))
+		writeSchemaDiff(os.Stdout, diff)
+
+		if len(destructive) > 0 {
+			fmt.Println()
+			printWarning(fmt.Sprintf("%d destructive change(s) - applying the schema file would lose data:", len(destructive)))
+			for _, change := range destructive {
+				fmt.Println("  • " + change)
+			}
+		}
+	}
+
+	if diff.HasChanges {
+		os.Exit(exitSchemaDrift)
+	}
+}
+
+// handleSchemaLint checks a schema file with schema.Lint
+func handleSchemaLint(args []string) {
+	fs := flag.NewFlagSet("schema lint", flag.ExitOnError)
+	schemaFile := fs.String("schema", getDefaultSchemaFile(), "Schema file path")
+	jsonOutput := fs.Bool("json", false, "Print issues as JSON (same as --output json)")
+	strict := fs.Bool("strict", false, "Fail on warnings as well as errors")
+	disable := fs.String("disable", "", "Comma-separated rule codes to skip")
+	maxFields := fs.Int("max-fields", schema.DefaultMaxBundleFields, "Field count above which a bundle is too wide")
+	fs.Parse(args)
+	if *jsonOutput {
+		setOutputFormat(outputJSON)
+	}
+
+	schemaDef, err := readSchemaFile(*schemaFile)
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
+	}
+
+	linter := schema.NewLinter()
+	linter.MaxBundleFields = *maxFields
+	for _, code := range strings.Split(*disable, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			linter.Disable(code)
+		}
+	}
+	result := linter.Lint(schemaDef)
+
+	if structuredOutput() {
+		emit(result)
+	} else {
+		printHeader("Schema Lint")
+		fmt.Printf("%s %s\n\n", colorDim("Checking"), colorCyan(*schemaFile))
+		writeLintResult(os.Stdout, result)
+	}
+
+	if result.HasErrors() || (*strict && len(result.Issues) > 0) {
+		os.Exit(exitValidation)
+	}
+}
+
+// writeLintResult prints lint issues followed by a summary line.
+func writeLintResult(w io.Writer, result *schema.LintResult) {
+	if len(result.Issues) == 0 {
+		fmt.Fprintln(w, colorGreen("✓")+" No issues found")
+		return
+	}
+
+	for _, issue := range result.Issues {
+		marker := colorYellow("⚠")
+		if issue.Severity == schema.LintError {
+			marker = colorRed("✗")
+		}
+		location := issue.Bundle
+		if issue.Field != "" {
+			location += "." + issue.Field
+		}
+		fmt.Fprintf(w, "%s %s %s %s\n", marker, colorBold(location), issue.Message, colorDim("["+issue.Code+"]"))
+	}
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", len(result.Errors()), len(result.Warnings()))
+}
+
+// readSchemaFile loads a schema definition from a JSON file.
+func readSchemaFile(path string) (*schema.SchemaDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read schema file: %v", err)
+	}
+
+	var schemaDef schema.SchemaDefinition
+	if err := json.Unmarshal(data, &schemaDef); err != nil {
+		return nil, fmt.Errorf("Failed to parse schema file: %v", err)
+	}
+	return &schemaDef, nil
+}
+
+// fetchServerSchema loads the live schema, bounded by timeoutMs when positive.
+func fetchServerSchema(c *client.Client, timeoutMs int) (*schema.SchemaDefinition, error) {
+	ctx := context.Background()
+	if timeoutMs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+		defer cancel()
+	}
+
+	schemaDef, err := c.GetSchema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch schema: %v", err)
+	}
+	return schemaDef, nil
+}
+
+// sortSchemaDiff orders changes by name; CompareSchemas builds them from maps.
+func sortSchemaDiff(diff *schema.SchemaDiff) {
+	sort.Slice(diff.BundleChanges, func(i, j int) bool {
+		return diff.BundleChanges[i].BundleName < diff.BundleChanges[j].BundleName
+	})
+	for _, change := range diff.BundleChanges {
+		sort.Slice(change.FieldChanges, func(i, j int) bool {
+			return change.FieldChanges[i].FieldName < change.FieldChanges[j].FieldName
+		})
+		sort.Slice(change.IndexChanges, func(i, j int) bool {
+			return indexChangeName(change.IndexChanges[i]) < indexChangeName(change.IndexChanges[j])
+		})
+	}
+	sort.Slice(diff.RelationshipChanges, func(i, j int) bool {
+		a, b := diff.RelationshipChanges[i], diff.RelationshipChanges[j]
+		if a.BundleName != b.BundleName {
+			return a.BundleName < b.BundleName
+		}
+		return relationshipChangeName(a) < relationshipChangeName(b)
+	})
+}
+
+// destructiveChanges lists changes that drop data when the schema file is applied:
+// deleted bundles, removed fields and field type changes.
+func destructiveChanges(diff *schema.SchemaDiff) []string {
+	var changes []string
+	for _, change := range diff.BundleChanges {
+		switch change.Type {
+		case "delete":
+			changes = append(changes, fmt.Sprintf("bundle %q would be dropped", change.BundleName))
+		case "modify":
+			for _, field := range change.FieldChanges {
+				switch {
+				case field.Type == "remove":
+					changes = append(changes, fmt.Sprintf("field %q would be removed from %q", field.FieldName, change.BundleName))
+				case field.Type == "modify" && field.OldField.Type != field.NewField.Type:
+					changes = append(changes, fmt.Sprintf("field %q in %q would change type from %s to %s",
+						field.FieldName, change.BundleName, field.OldField.Type, field.NewField.Type))
+				}
+			}
+		}
+	}
+	return changes
+}
+
+// writeSchemaDiff prints a colorized diff. "+" marks what the schema file adds
+// to the server, "-" what it removes and "~" what it modifies.
+func writeSchemaDiff(w io.Writer, diff *schema.SchemaDiff) {
+	if !diff.HasChanges {
+		fmt.Fprintln(w, colorGreen("✓")+" No drift: the live schema matches the schema file")
+		return
+	}
+
+	for _, change := range diff.BundleChanges {
+		switch change.Type {
+		case "create":
+			fmt.Fprintf(w, "%s bundle %s %s\n", colorGreen("+"), colorBold(change.BundleName),
+				colorDim(fmt.Sprintf("(%d fields)", len(change.NewDefinition.Fields))))
+		case "delete":
+			fmt.Fprintf(w, "%s bundle %s %s\n", colorRed("-"), colorBold(change.BundleName), colorRed("[destructive]"))
+		case "modify":
+			fmt.Fprintf(w, "%s bundle %s\n", colorYellow("~"), colorBold(change.BundleName))
+			for _, field := range change.FieldChanges {
+				writeFieldChange(w, field)
+			}
+			for _, index := range change.IndexChanges {
+				writeIndexChange(w, index)
+			}
+		}
+	}
+
+	for _, change := range diff.RelationshipChanges {
+		switch change.Type {
+		case "add":
+			fmt.Fprintf(w, "%s relationship %s\n", colorGreen("+"), describeRelationship(change.NewRelationship))
+		case "remove":
+			fmt.Fprintf(w, "%s relationship %s\n", colorRed("-"), describeRelationship(change.OldRelationship))
+		case "modify":
+			fmt.Fprintf(w, "%s relationship %s → %s\n", colorYellow("~"),
+				describeRelationship(change.OldRelationship), describeRelationship(change.NewRelationship))
+		}
+	}
+}
+
+func writeFieldChange(w io.Writer, change schema.FieldChange) {
+	switch change.Type {
+	case "add":
+		fmt.Fprintf(w, "    %s field %s %s\n", colorGreen("+"), change.FieldName, describeField(change.NewField))
+	case "remove":
+		fmt.Fprintf(w, "    %s field %s %s %s\n", colorRed("-"), change.FieldName, describeField(change.OldField), colorRed("[destructive]"))
+	case "modify":
+		line := fmt.Sprintf("    %s field %s %s → %s", colorYellow("~"), change.FieldName,
+			describeField(change.OldField), describeField(change.NewField))
+		if change.OldField.Type != change.NewField.Type {
+			line += " " + colorRed("[destructive]")
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func writeIndexChange(w io.Writer, change schema.IndexChange) {
+	switch change.Type {
+	case "add":
+		fmt.Fprintf(w, "    %s index %s\n", colorGreen("+"), describeIndex(change.NewIndex))
+	case "remove":
+		fmt.Fprintf(w, "    %s index %s\n", colorRed("-"), describeIndex(change.OldIndex))
+	case "modify":
+		fmt.Fprintf(w, "    %s index %s → %s\n", colorYellow("~"), describeIndex(change.OldIndex), describeIndex(change.NewIndex))
+	}
+}
+
+func describeField(field *schema.FieldDefinition) string {
+	parts := []string{string(field.Type)}
+	if field.Required {
+		parts = append(parts, "required")
+	}
+	if field.Unique {
+		parts = append(parts, "unique")
+	}
+	if field.DefaultValue != nil {
+		parts = append(parts, fmt.Sprintf("default=%v", field.DefaultValue))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func describeIndex(index *schema.IndexDefinition) string {
+	kind := string(index.Type)
+	if index.Unique {
+		kind = "unique " + kind
+	}
+	description := fmt.Sprintf("%s (%s: %s)", index.Name, kind, strings.Join(index.Fields, ", "))
+	if index.Filter != "" {
+		description += " where " + index.Filter
+	}
+	return description
+}
+
+func describeRelationship(rel *schema.RelationshipDefinition) string {
+	return fmt.Sprintf("%s (%s.%s → %s.%s, %s)", rel.Name, rel.SourceBundle, rel.SourceField, rel.DestBundle, rel.DestField, rel.Type)
+}
+
+func indexChangeName(change schema.IndexChange) string {
+	if change.NewIndex != nil {
+		return change.NewIndex.Name
+	}
+	return change.OldIndex.Name
+}
+
+func relationshipChangeName(change schema.RelationshipChange) string {
+	if change.NewRelationship != nil {
+		return change.NewRelationship.Name
+	}
+	return change.OldRelationship.Name
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+func schemaDiffFixture() *schema.SchemaDiff {
+	local := &schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{
+			{Name: "name", Type: schema.STRING, Required: true},
+			{Name: "age", Type: schema.STRING},
+			{Name: "email", Type: schema.STRING},
+		}},
+		{Name: "orders", Fields: []schema.FieldDefinition{{Name: "total", Type: schema.FLOAT}}},
+	}}
+	live := &schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{
+			{Name: "name", Type: schema.STRING, Required: true},
+			{Name: "age", Type: schema.INT},
+			{Name: "nickname", Type: schema.STRING},
+		}},
+		{Name: "legacy", Fields: []schema.FieldDefinition{}},
+	}}
+
+	diff := schema.CompareSchemas(local, live)
+	sortSchemaDiff(diff)
+	return diff
+}
+
+func TestDestructiveChanges(t *testing.T) {
+	got := destructiveChanges(schemaDiffFixture())
+	want := []string{
+		`bundle "legacy" would be dropped`,
+		`field "age" in "users" would change type from INT to STRING`,
+		`field "nickname" would be removed from "users"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWriteSchemaDiff(t *testing.T) {
+	colorsEnabled = false
+	defer func() { colorsEnabled = true }()
+
+	var buf bytes.Buffer
+	writeSchemaDiff(&buf, schemaDiffFixture())
+	want := strings.Join([]string{
+		"- bundle legacy [destructive]",
+		"+ bundle orders (1 fields)",
+		"~ bundle users",
+		"    ~ field age (INT) → (STRING) [destructive]",
+		"    + field email (STRING)",
+		"    - field nickname (STRING) [destructive]",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	writeSchemaDiff(&buf, schema.CompareSchemas(&schema.SchemaDefinition{}, &schema.SchemaDefinition{}))
+	if !strings.Contains(buf.String(), "No drift") {
+		t.Errorf("expected no drift message, got %q", buf.String())
+	}
+}
+
+func TestWriteLintResult(t *testing.T) {
+	colorsEnabled = false
+	defer func() { colorsEnabled = true }()
+
+	var buf bytes.Buffer
+	writeLintResult(&buf, &schema.LintResult{Issues: []schema.LintIssue{
+		{Code: schema.LintReservedBundleName, Severity: schema.LintError, Bundle: "select", Message: `"select" is a SyndrQL keyword`},
+		{Code: schema.LintUnindexedForeignKey, Severity: schema.LintWarning, Bundle: "posts", Field: "author_id", Message: "references users"},
+	}})
+	want := strings.Join([]string{
+		`✗ select "select" is a SyndrQL keyword [RESERVED_BUNDLE_NAME]`,
+		"⚠ posts.author_id references users [UNINDEXED_FOREIGN_KEY]",
+		"",
+		"1 error(s), 1 warning(s)",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	writeLintResult(&buf, &schema.LintResult{})
+	if got := buf.String(); got != "✓ No issues found\n" {
+		t.Errorf("unexpected output for a clean schema: %q", got)
+	}
+}
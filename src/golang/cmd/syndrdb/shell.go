@@ -0,0 +1,511 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+const (
+	shellPrompt             = "syndrdb> "
+	shellContinuationPrompt = "     ->  "
+	shellMaxHistory         = 1000
+)
+
+func printShellUsage() {
+	printHeader("Interactive Shell")
+	fmt.Println("Usage:")
+	fmt.Println("  syndrdb shell [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn      Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --history   History file (default: ~/.syndrdb_history)")
+	fmt.Println("  --timeout   Statement timeout in milliseconds (default: 30000)")
+	fmt.Println()
+	printShellHelp()
+}
+
+func printShellHelp() {
+	fmt.Println("Statements end with ';' and may span multiple lines.")
+	fmt.Println()
+	fmt.Println("Meta commands:")
+	fmt.Println("  " + colorGreen(`\d`) + "            List bundles")
+	fmt.Println("  " + colorGreen(`\d NAME`) + "       Describe a bundle")
+	fmt.Println("  " + colorGreen(`\di [NAME]`) + "    Show indexes, optionally for one bundle")
+	fmt.Println("  " + colorGreen(`\s`) + "            Show command history")
+	fmt.Println("  " + colorGreen(`\timing`) + "       Toggle statement timing")
+	fmt.Println("  " + colorGreen(`\r`) + "            Clear the statement buffer")
+	fmt.Println("  " + colorGreen(`\?`) + "            Show this help")
+	fmt.Println("  " + colorGreen(`\q`) + "            Quit")
+}
+
+// replShell holds interactive shell state.
+type replShell struct {
+	client      *client.Client
+	timeoutMs   int
+	timing      bool
+	history     []string
+	historyFile string
+	buffer      strings.Builder
+}
+
+// handleShell starts an interactive SyndrQL shell
+func handleShell(args []string) {
+	fs := flag.NewFlagSet("shell", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	historyFile := fs.String("history", defaultHistoryFile(), "History file")
+	timeoutMs := fs.Int("timeout", 30000, "Statement timeout in milliseconds")
+	fs.Usage = printShellUsage
+	fs.Parse(args)
+
+	if *connStr == "" {
+		printError("Connection string is required")
+		fmt.Println("\nProvide via --conn flag or SYNDRDB_CONN environment variable")
+		os.Exit(exitValidation)
+	}
+
+	opts := cliOptions()
+	opts.Logger = client.NewNoopLogger()
+	c := client.NewClient(opts)
+	ctx := context.Background()
+	if err := c.Connect(ctx, *connStr); err != nil {
+		printError(fmt.Sprintf("Failed to connect: %v", err))
+		os.Exit(exitConnection)
+	}
+	defer c.Disconnect(ctx)
+
+	sh := &replShell{
+		client:      c,
+		timeoutMs:   *timeoutMs,
+		timing:      true,
+		historyFile: *historyFile,
+	}
+	sh.loadHistory()
+
+	fmt.Println(colorBold(colorCyan("SyndrDB shell")) + " " + colorDim("v"+version))
+	fmt.Println("Connected to " + colorCyan(maskConnectionString(*connStr)))
+	fmt.Println(`Type \? for help, \q to quit.`)
+	fmt.Println()
+
+	sh.run()
+}
+
+// run reads lines until EOF or \q.
+func (sh *replShell) run() {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for {
+		if sh.buffer.Len() == 0 {
+			fmt.Print(colorCyan(shellPrompt))
+		} else {
+			fmt.Print(colorDim(shellContinuationPrompt))
+		}
+
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		// Meta commands are only recognized at the start of a statement
+		if sh.buffer.Len() == 0 && strings.HasPrefix(trimmed, `\`) {
+			sh.addHistory(trimmed)
+			if quit := sh.runMeta(trimmed); quit {
+				return
+			}
+			continue
+		}
+
+		if trimmed == "" && sh.buffer.Len() == 0 {
+			continue
+		}
+
+		if sh.buffer.Len() > 0 {
+			sh.buffer.WriteString("\n")
+		}
+		sh.buffer.WriteString(line)
+
+		statements, rest := splitStatements(sh.buffer.String())
+		sh.buffer.Reset()
+		sh.buffer.WriteString(rest)
+
+		for _, statement := range statements {
+			sh.addHistory(statement)
+			sh.execute(statement)
+		}
+	}
+}
+
+// runMeta executes a backslash command. Returns true to quit.
+func (sh *replShell) runMeta(command string) bool {
+	fields := strings.Fields(command)
+	arg := ""
+	if len(fields) > 1 {
+		arg = strings.Trim(fields[1], `"`)
+	}
+
+	switch fields[0] {
+	case `\q`:
+		return true
+	case `\?`, `\h`:
+		printShellHelp()
+	case `\d`:
+		if arg == "" {
+			sh.listBundles()
+		} else {
+			sh.describeBundle(arg)
+		}
+	case `\di`:
+		sh.showIndexes(arg)
+	case `\s`:
+		for i, entry := range sh.history {
+			fmt.Printf("%s  %s\n", colorDim(fmt.Sprintf("%4d", i+1)), entry)
+		}
+	case `\timing`:
+		sh.timing = !sh.timing
+		if sh.timing {
+			printInfo("Timing is on")
+		} else {
+			printInfo("Timing is off")
+		}
+	case `\r`:
+		sh.buffer.Reset()
+		printInfo("Statement buffer cleared")
+	default:
+		printError(fmt.Sprintf(`Unknown meta command: %s (try \?)`, fields[0]))
+	}
+	return false
+}
+
+// execute runs a statement and prints its result.
+func (sh *replShell) execute(statement string) {
+	start := time.Now()
+	result, err := sh.client.Query(statement, sh.timeoutMs)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		printError(err.Error())
+	} else {
+		printResult(result)
+	}
+
+	if sh.timing {
+		fmt.Println(colorDim(fmt.Sprintf("Time: %.3f ms", float64(elapsed.Microseconds())/1000)))
+	}
+	fmt.Println()
+}
+
+// fetchSchema loads the server schema for meta commands.
+func (sh *replShell) fetchSchema() (*schema.SchemaDefinition, bool) {
+	schemaDef, err := fetchServerSchema(sh.client, sh.timeoutMs)
+	if err != nil {
+		printError(err.Error())
+		return nil, false
+	}
+	return schemaDef, true
+}
+
+func (sh *replShell) listBundles() {
+	schemaDef, ok := sh.fetchSchema()
+	if !ok {
+		return
+	}
+
+	rows := make([][]string, 0, len(schemaDef.Bundles))
+	for _, bundle := range schemaDef.Bundles {
+		rows = append(rows, []string{
+			bundle.Name,
+			fmt.Sprintf("%d", len(bundle.Fields)),
+			fmt.Sprintf("%d", len(bundle.Indexes)),
+			fmt.Sprintf("%d", len(bundle.Relationships)),
+		})
+	}
+	printTable([]string{"Bundle", "Fields", "Indexes", "Relationships"}, rows)
+	fmt.Printf("(%d bundles)\n\n", len(rows))
+}
+
+func (sh *replShell) describeBundle(name string) {
+	schemaDef, ok := sh.fetchSchema()
+	if !ok {
+		return
+	}
+
+	bundle := findBundle(schemaDef, name)
+	if bundle == nil {
+		printError(fmt.Sprintf("Bundle %q not found", name))
+		return
+	}
+
+	fmt.Println(colorBold("Bundle " + bundle.Name))
+	rows := make([][]string, 0, len(bundle.Fields))
+	for _, field := range bundle.Fields {
+		def := ""
+		if field.DefaultValue != nil {
+			def = fmt.Sprintf("%v", field.DefaultValue)
+		}
+		rows = append(rows, []string{
+			field.Name,
+			string(field.Type),
+			yesNo(field.Required),
+			yesNo(field.Unique),
+			def,
+		})
+	}
+	printTable([]string{"Field", "Type", "Required", "Unique", "Default"}, rows)
+
+	if len(bundle.Relationships) > 0 {
+		fmt.Println()
+		rows = rows[:0]
+		for _, rel := range bundle.Relationships {
+			rows = append(rows, []string{
+				rel.Name,
+				rel.Type,
+				rel.SourceField,
+				rel.DestBundle + "." + rel.DestField,
+			})
+		}
+		printTable([]string{"Relationship", "Type", "Field", "References"}, rows)
+	}
+	fmt.Println()
+}
+
+func (sh *replShell) showIndexes(name string) {
+	schemaDef, ok := sh.fetchSchema()
+	if !ok {
+		return
+	}
+
+	bundles := schemaDef.Bundles
+	if name != "" {
+		bundle := findBundle(schemaDef, name)
+		if bundle == nil {
+			printError(fmt.Sprintf("Bundle %q not found", name))
+			return
+		}
+		bundles = []schema.BundleDefinition{*bundle}
+	}
+
+	rows := make([][]string, 0)
+	for _, bundle := range bundles {
+		for _, index := range bundle.Indexes {
+			rows = append(rows, []string{
+				bundle.Name,
+				index.Name,
+				string(index.Type),
+				strings.Join(index.Fields, ", "),
+			})
+		}
+	}
+	printTable([]string{"Bundle", "Index", "Type", "Fields"}, rows)
+	fmt.Printf("(%d indexes)\n\n", len(rows))
+}
+
+// findBundle looks up a bundle by name, case-insensitively.
+func findBundle(schemaDef *schema.SchemaDefinition, name string) *schema.BundleDefinition {
+	for i := range schemaDef.Bundles {
+		if strings.EqualFold(schemaDef.Bundles[i].Name, name) {
+			return &schemaDef.Bundles[i]
+		}
+	}
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// printResult prints documents as a table and other results as JSON. With
+// --output json or yaml, every result is emitted in that format instead.
+func printResult(result interface{}) {
+	rows, ok := resultDocuments(result)
+	if structuredOutput() {
+		if ok {
+			emit(rows)
+		} else {
+			emit(result)
+		}
+		return
+	}
+	if !ok {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Printf("%v\n", result)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	// Columns in order of first appearance
+	columns := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		keys := make([]string, 0, len(row))
+		for key := range row {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	cells := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		line := make([]string, len(columns))
+		for i, column := range columns {
+			if value, ok := row[column]; ok {
+				line[i] = formatCell(value)
+			}
+		}
+		cells = append(cells, line)
+	}
+
+	printTable(columns, cells)
+	if len(rows) == 1 {
+		fmt.Println("(1 row)")
+	} else {
+		fmt.Printf("(%d rows)\n", len(rows))
+	}
+}
+
+// resultDocuments extracts documents from a result that is an array of objects,
+// either directly or under a "Result" key.
+func resultDocuments(result interface{}) ([]map[string]interface{}, bool) {
+	if respMap, ok := result.(map[string]interface{}); ok {
+		result = respMap["Result"]
+	}
+
+	items, ok := result.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	rows := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		row, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		rows = append(rows, row)
+	}
+	return rows, true
+}
+
+// formatCell renders a value for a table cell.
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return colorDim("NULL")
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// splitStatements returns the complete ';'-terminated statements in input and the
+// unterminated remainder. Semicolons inside quoted strings don't end a statement.
+func splitStatements(input string) ([]string, string) {
+	statements := make([]string, 0)
+	var quote rune
+	escaped := false
+	start := 0
+
+	for i, ch := range input {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\' && quote != 0:
+			escaped = true
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == ';':
+			if statement := strings.TrimSpace(input[start : i+1]); statement != ";" {
+				statements = append(statements, statement)
+			}
+			start = i + 1
+		}
+	}
+
+	rest := input[start:]
+	if strings.TrimSpace(rest) == "" {
+		rest = ""
+	}
+	return statements, rest
+}
+
+// defaultHistoryFile returns ~/.syndrdb_history, or "" if the home directory is unknown.
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".syndrdb_history")
+}
+
+// loadHistory reads previous sessions' history. Entries are stored one per line,
+// with newlines in multi-line statements escaped.
+func (sh *replShell) loadHistory() {
+	if sh.historyFile == "" {
+		return
+	}
+	data, err := os.ReadFile(sh.historyFile)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			sh.history = append(sh.history, strings.ReplaceAll(line, `\n`, "\n"))
+		}
+	}
+	if len(sh.history) > shellMaxHistory {
+		sh.history = sh.history[len(sh.history)-shellMaxHistory:]
+	}
+}
+
+// addHistory records an entry and appends it to the history file.
+func (sh *replShell) addHistory(entry string) {
+	if entry == "" || (len(sh.history) > 0 && sh.history[len(sh.history)-1] == entry) {
+		return
+	}
+	sh.history = append(sh.history, entry)
+	if len(sh.history) > shellMaxHistory {
+		sh.history = sh.history[1:]
+	}
+
+	if sh.historyFile == "" {
+		return
+	}
+	f, err := os.OpenFile(sh.historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, strings.ReplaceAll(entry, "\n", `\n`))
+}
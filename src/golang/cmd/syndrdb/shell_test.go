@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		input      string
+		statements []string
+		rest       string
+	}{
+		{"SHOW BUNDLES;", []string{"SHOW BUNDLES;"}, ""},
+		{"SELECT *\nFROM \"users\"", []string{}, "SELECT *\nFROM \"users\""},
+		{"SHOW BUNDLES; SHOW DATABASES;  ", []string{"SHOW BUNDLES;", "SHOW DATABASES;"}, ""},
+		{`SELECT * FROM "a" WHERE "x" == "semi;colon"; SELECT`, []string{`SELECT * FROM "a" WHERE "x" == "semi;colon";`}, " SELECT"},
+		{`SELECT * FROM "a" WHERE "x" == "esc\";aped";`, []string{`SELECT * FROM "a" WHERE "x" == "esc\";aped";`}, ""},
+		{";;", []string{}, ""},
+	}
+
+	for _, tt := range tests {
+		statements, rest := splitStatements(tt.input)
+		if !reflect.DeepEqual(statements, tt.statements) || rest != tt.rest {
+			t.Errorf("splitStatements(%q) = %q, %q; want %q, %q", tt.input, statements, rest, tt.statements, tt.rest)
+		}
+	}
+}
+
+func TestResultDocuments(t *testing.T) {
+	docs := []interface{}{map[string]interface{}{"name": "a"}}
+
+	if rows, ok := resultDocuments(docs); !ok || len(rows) != 1 {
+		t.Errorf("expected 1 document from array, got %v", rows)
+	}
+	if rows, ok := resultDocuments(map[string]interface{}{"Result": docs}); !ok || len(rows) != 1 {
+		t.Errorf("expected 1 document from Result, got %v", rows)
+	}
+	if _, ok := resultDocuments([]interface{}{1, 2}); ok {
+		t.Error("expected scalar arrays to be rejected")
+	}
+	if _, ok := resultDocuments("OK"); ok {
+		t.Error("expected strings to be rejected")
+	}
+}
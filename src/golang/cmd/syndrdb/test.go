@@ -4,17 +4,22 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
 	"github.com/dan-strohschein/syndrdb-drivers/src/golang/migration"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
 )
 
 func handleTest(args []string) {
-	if len(args) == 0 {
-		printTestUsage()
-		os.Exit(1)
+	// Without a subcommand, or with only flags, run the diagnostics battery
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "--help" {
+		handleTestDiagnostics(args)
+		return
 	}
 
 	subcommand := args[0]
@@ -31,19 +36,32 @@ func handleTest(args []string) {
 	default:
 		printError(fmt.Sprintf("Unknown test subcommand: %s", subcommand))
 		printTestUsage()
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 }
 
 func printTestUsage() {
 	printHeader("Test Commands")
 	fmt.Println("Usage:")
+	fmt.Println("  syndrdb test [options]")
 	fmt.Println("  syndrdb test " + colorYellow("<command>") + " [options]\n")
+	fmt.Println("Without a command, runs diagnostics against the server: connect, auth,")
+	fmt.Println("ping, SHOW BUNDLES, a scratch bundle round trip and a latency report.")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --conn        Connection string (default: $SYNDRDB_CONN)")
+	fmt.Println("  --json        Print the report as JSON, e.g. for CI")
+	fmt.Println("  --samples     Pings for the latency report (default: 10)")
+	fmt.Println("  --read-only   Skip the scratch bundle checks")
+	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  " + colorGreen("connection") + "   Test database connection and health")
 	fmt.Println("  " + colorGreen("migrations") + "  Validate migration files")
 	fmt.Println("  " + colorGreen("all") + "         Run all tests")
 	fmt.Println("\nExamples:")
+	fmt.Println("  " + colorDim("# Run diagnostics in CI"))
+	fmt.Println("  syndrdb test --conn \"$SYNDRDB_CONN\" --json")
+	fmt.Println()
 	fmt.Println("  " + colorDim("# Test connection"))
 	fmt.Println("  syndrdb test connection")
 	fmt.Println()
@@ -54,95 +72,35 @@ func printTestUsage() {
 	fmt.Println("  syndrdb test all")
 }
 
+// testAllReport is the result of `syndrdb test all`.
+type testAllReport struct {
+	Passed     bool               `json:"passed"`
+	Connection *diagnosticsReport `json:"connection"`
+	Migrations *diagnosticsReport `json:"migrations"`
+}
+
 // handleTestConnection tests database connection
 func handleTestConnection(args []string) {
 	fs := flag.NewFlagSet("test connection", flag.ExitOnError)
-	connStr := fs.String("conn", os.Getenv("SYNDRDB_CONN"), "Connection string")
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
 	verbose := fs.Bool("verbose", false, "Show detailed connection info")
 	fs.Parse(args)
 
 	if *connStr == "" {
 		printError("Connection string is required")
 		fmt.Println("\nProvide via --conn flag or SYNDRDB_CONN environment variable")
-		os.Exit(1)
-	}
-
-	printHeader("Test Database Connection")
-
-	// Test 1: Parse connection string
-	testsPassed := 0
-	testsTotal := 4
-
-	fmt.Println()
-	fmt.Print("  1. Parse connection string... ")
-	if *verbose {
-		fmt.Println()
-		fmt.Println(colorDim("     Connection: " + maskConnectionString(*connStr)))
-	}
-	// Basic validation
-	if len(*connStr) < 10 || !containsString(*connStr, "syndrdb://") {
-		printError("Invalid connection string format")
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
-	printSuccess("OK")
-	testsPassed++
 
-	// Test 2: Connect
-	fmt.Print("  2. Connect to database... ")
-	opts := &client.ClientOptions{}
-	c := client.NewClient(opts)
-	ctx := context.Background()
-	err := c.Connect(ctx, *connStr)
-	if err != nil {
-		fmt.Println(colorRed("FAIL"))
-		printError(fmt.Sprintf("Connection failed: %v", err))
-		os.Exit(1)
-	}
-	defer c.Disconnect(ctx)
-	printSuccess("OK")
-	testsPassed++
-
-	// Test 3: Ping
-	fmt.Print("  3. Ping server... ")
-	pingStart := time.Now()
-	if err := c.Ping(ctx); err != nil {
-		fmt.Println(colorRed("FAIL"))
-		printError(fmt.Sprintf("Ping failed: %v", err))
-		os.Exit(1)
-	}
-	pingDuration := time.Since(pingStart)
-	if *verbose {
-		fmt.Printf("%s (%s)\n", colorGreen("OK"), colorDim(fmt.Sprintf("%dms", pingDuration.Milliseconds())))
+	report := runConnectionTests(context.Background(), cliOptions(), *connStr, *verbose)
+	if structuredOutput() {
+		emit(report)
 	} else {
-		printSuccess(fmt.Sprintf("OK (%dms)", pingDuration.Milliseconds()))
+		printHeader("Test Database Connection")
+		writeDiagnostics(os.Stdout, report)
 	}
-	testsPassed++
-
-	// Test 4: Check state
-	fmt.Print("  4. Check connection state... ")
-	state := c.GetState()
-	if state != client.CONNECTED {
-		fmt.Println(colorRed("FAIL"))
-		printError(fmt.Sprintf("Expected state CONNECTED, got %s", state))
-		os.Exit(1)
-	}
-	printSuccess("OK")
-	testsPassed++
-
-	// Summary
-	fmt.Println()
-	if testsPassed == testsTotal {
-		printSuccess(fmt.Sprintf("All tests passed! (%d/%d)", testsPassed, testsTotal))
-	} else {
-		printError(fmt.Sprintf("Some tests failed (%d/%d passed)", testsPassed, testsTotal))
-		os.Exit(1)
-	}
-
-	if *verbose {
-		fmt.Println()
-		printInfo("Connection Details:")
-		fmt.Println(colorDim(fmt.Sprintf("  State: %s", state)))
-		fmt.Println(colorDim(fmt.Sprintf("  Ping Latency: %dms", pingDuration.Milliseconds())))
+	if !report.Passed {
+		os.Exit(report.exitCode())
 	}
 }
 
@@ -153,175 +111,166 @@ func handleTestMigrations(args []string) {
 	verbose := fs.Bool("verbose", false, "Show detailed validation info")
 	fs.Parse(args)
 
-	printHeader("Test Migration Files")
-
-	// Test 1: Check directory exists
-	fmt.Println()
-	fmt.Print("  1. Check migrations directory... ")
-	if _, err := os.Stat(*dir); os.IsNotExist(err) {
-		fmt.Println(colorRed("FAIL"))
-		printError(fmt.Sprintf("Directory not found: %s", *dir))
-		printInfo("Run " + colorCyan("syndrdb migrate init") + " to initialize")
-		os.Exit(1)
-	}
-	printSuccess("OK")
-
-	// Test 2: Load migrations
-	fmt.Print("  2. Load migration files... ")
-	migrations, err := migration.ListMigrationFiles(*dir)
-	if err != nil {
-		fmt.Println(colorRed("FAIL"))
-		printError(fmt.Sprintf("Failed to load migrations: %v", err))
-		os.Exit(1)
-	}
-	printSuccess(fmt.Sprintf("OK (%d found)", len(migrations)))
-
-	if len(migrations) == 0 {
-		printWarning("No migration files found")
-		fmt.Println()
-		printInfo("Run " + colorCyan("syndrdb migrate generate") + " to create migrations")
-		return
-	}
-
-	// Test 3: Validate migrations
-	fmt.Print("  3. Validate migration structure... ")
-	validator := migration.NewMigrationValidator(migration.NewMigrationHistory())
-	validation := validator.Validate(migrations)
-
-	if !validation.Valid {
-		fmt.Println(colorRed("FAIL"))
-		printError("Validation errors found:")
-		for _, conflict := range validation.Conflicts {
-			fmt.Println("  " + colorRed("•") + " " + conflict.Message)
-		}
-		os.Exit(1)
-	}
-	printSuccess("OK")
-
-	// Test 4: Check for issues
-	fmt.Print("  4. Check for common issues... ")
-	issues := checkMigrationIssues(migrations)
-	if len(issues) > 0 {
-		fmt.Println(colorYellow("WARN"))
-		for _, issue := range issues {
-			printWarning(issue)
-		}
+	report := runMigrationTests(*dir)
+	if structuredOutput() {
+		emit(report)
 	} else {
-		printSuccess("OK")
-	}
-
-	// Summary
-	fmt.Println()
-	printSuccess("All migration tests passed!")
-
-	if *verbose {
-		fmt.Println()
-		printInfo("Migration Summary:")
-		for i, mig := range migrations {
-			fmt.Printf("  %d. %s\n", i+1, colorBold(mig.Name))
-			fmt.Println(colorDim(fmt.Sprintf("     ID: %s", mig.ID)))
-			fmt.Println(colorDim(fmt.Sprintf("     Up commands: %d", len(mig.Up))))
-			fmt.Println(colorDim(fmt.Sprintf("     Down commands: %d", len(mig.Down))))
-			fmt.Println(colorDim(fmt.Sprintf("     Created: %s", mig.Timestamp.Format("2006-01-02 15:04"))))
+		printHeader("Test Migration Files")
+		writeDiagnostics(os.Stdout, report)
+		if *verbose && report.Passed {
+			writeMigrationSummary(*dir)
 		}
 	}
+	if !report.Passed {
+		os.Exit(report.exitCode())
+	}
 }
 
 // handleTestAll runs all tests
 func handleTestAll(args []string) {
 	fs := flag.NewFlagSet("test all", flag.ExitOnError)
-	connStr := fs.String("conn", os.Getenv("SYNDRDB_CONN"), "Connection string")
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
 	dir := fs.String("dir", getDefaultMigrationsDir(), "Migration directory")
 	verbose := fs.Bool("verbose", false, "Show detailed test info")
 	fs.Parse(args)
 
-	printHeader("Run All Tests")
-
-	testsRun := 0
-	testsPassed := 0
-
-	// Test connection
-	fmt.Println()
-	fmt.Println(colorBold("Connection Tests"))
-	fmt.Println(colorDim("────────────────────────────────────────"))
-	testsRun++
-	if runConnectionTests(*connStr, *verbose) {
-		testsPassed++
+	report := &testAllReport{Migrations: runMigrationTests(*dir)}
+	if *connStr == "" {
+		report.Connection = &diagnosticsReport{}
+		report.Connection.skip("no connection string", "connection")
+		report.Connection.finish()
+	} else {
+		report.Connection = runConnectionTests(context.Background(), cliOptions(), *connStr, *verbose)
 	}
+	report.Passed = report.Connection.Passed && report.Migrations.Passed
 
-	// Test migrations
-	fmt.Println()
-	fmt.Println(colorBold("Migration Tests"))
-	fmt.Println(colorDim("────────────────────────────────────────"))
-	testsRun++
-	if runMigrationTests(*dir, *verbose) {
-		testsPassed++
+	if structuredOutput() {
+		emit(report)
+	} else {
+		printHeader("Run All Tests")
+		for _, suite := range []struct {
+			title  string
+			report *diagnosticsReport
+		}{{"Connection Tests", report.Connection}, {"Migration Tests", report.Migrations}} {
+			fmt.Println(colorBold(suite.title))
+			fmt.Println(colorDim("────────────────────────────────────────"))
+			writeDiagnostics(os.Stdout, suite.report)
+			fmt.Println()
+		}
+	}
+	switch {
+	case !report.Connection.Passed:
+		os.Exit(report.Connection.exitCode())
+	case !report.Migrations.Passed:
+		os.Exit(report.Migrations.exitCode())
 	}
+}
 
-	// Summary
+// Helper functions
+
+// writeMigrationSummary lists the migrations in dir for --verbose.
+func writeMigrationSummary(dir string) {
+	migrations, err := migration.ListMigrationFiles(dir)
+	if err != nil || len(migrations) == 0 {
+		return
+	}
 	fmt.Println()
-	fmt.Println(colorBold("Test Summary"))
-	fmt.Println(colorDim("────────────────────────────────────────"))
-	if testsPassed == testsRun {
-		printSuccess(fmt.Sprintf("All test suites passed! (%d/%d)", testsPassed, testsRun))
-	} else {
-		printError(fmt.Sprintf("Some test suites failed (%d/%d passed)", testsPassed, testsRun))
-		os.Exit(1)
+	printInfo("Migration Summary:")
+	for i, mig := range migrations {
+		fmt.Printf("  %d. %s\n", i+1, colorBold(mig.Name))
+		fmt.Println(colorDim(fmt.Sprintf("     ID: %s", mig.ID)))
+		fmt.Println(colorDim(fmt.Sprintf("     Up commands: %d", len(mig.Up))))
+		fmt.Println(colorDim(fmt.Sprintf("     Down commands: %d", len(mig.Down))))
+		fmt.Println(colorDim(fmt.Sprintf("     Created: %s", mig.Timestamp.Format("2006-01-02 15:04"))))
 	}
 }
 
-// Helper functions
+// runConnectionTests checks that connStr is well formed and that a client
+// connects with it, pings the server and reaches the CONNECTED state.
+func runConnectionTests(ctx context.Context, opts *client.ClientOptions, connStr string, verbose bool) *diagnosticsReport {
+	report := &diagnosticsReport{}
 
-func runConnectionTests(connStr string, verbose bool) bool {
-	if connStr == "" {
-		printWarning("Skipping connection tests (no connection string)")
-		return true
+	start := time.Now()
+	var err error
+	if len(connStr) < 10 || !containsString(connStr, "syndrdb://") {
+		err = fmt.Errorf("invalid connection string format")
+	}
+	detail := ""
+	if verbose {
+		detail = maskConnectionString(connStr)
+	}
+	if !report.record("parse", start, detail, err) {
+		report.skip("invalid connection string", "connect", "ping", "state")
+		return report.finish()
 	}
 
-	opts := &client.ClientOptions{}
 	c := client.NewClient(opts)
-	ctx := context.Background()
-	err := c.Connect(ctx, connStr)
-	if err != nil {
-		printError(fmt.Sprintf("Connection failed: %v", err))
-		return false
+	start = time.Now()
+	if !report.record("connect", start, "", c.Connect(ctx, connStr)) {
+		report.skip("not connected", "ping", "state")
+		return report.finish()
 	}
 	defer c.Disconnect(ctx)
 
-	if err := c.Ping(ctx); err != nil {
-		printError(fmt.Sprintf("Ping failed: %v", err))
-		return false
-	}
+	start = time.Now()
+	report.record("ping", start, "", c.Ping(ctx))
 
-	printSuccess("Connection tests passed")
-	return true
+	start = time.Now()
+	err = nil
+	if state := c.GetState(); state != client.CONNECTED {
+		err = fmt.Errorf("expected state CONNECTED, got %s", state)
+	}
+	report.record("state", start, "", err)
+	return report.finish()
 }
 
-func runMigrationTests(dir string, verbose bool) bool {
-	migrations, err := migration.ListMigrationFiles(dir)
-	if err != nil {
-		printError(fmt.Sprintf("Failed to load migrations: %v", err))
-		return false
+// runMigrationTests loads and validates the migration files in dir. Common
+// issues, like migrations without DOWN commands, are reported as warnings.
+func runMigrationTests(dir string) *diagnosticsReport {
+	report := &diagnosticsReport{}
+
+	start := time.Now()
+	var err error
+	if _, statErr := os.Stat(dir); os.IsNotExist(statErr) {
+		err = fmt.Errorf("directory not found: %s (run syndrdb migrate init to initialize)", dir)
+	}
+	if !report.record("directory", start, dir, err) {
+		report.skip("no migrations directory", "load", "validate", "issues")
+		return report.finish()
 	}
 
+	start = time.Now()
+	migrations, err := migration.ListMigrationFiles(dir)
+	if !report.record("load", start, fmt.Sprintf("%d found", len(migrations)), err) {
+		report.skip("migrations not loaded", "validate", "issues")
+		return report.finish()
+	}
 	if len(migrations) == 0 {
-		printWarning("No migration files found")
-		return true
+		report.skip("no migration files", "validate", "issues")
+		return report.finish()
 	}
 
+	start = time.Now()
 	validator := migration.NewMigrationValidator(migration.NewMigrationHistory())
 	validation := validator.Validate(migrations)
-
+	err = nil
 	if !validation.Valid {
-		printError("Migration validation failed")
-		for _, conflict := range validation.Conflicts {
-			fmt.Println("  " + colorRed("•") + " " + conflict.Message)
+		messages := make([]string, len(validation.Conflicts))
+		for i, conflict := range validation.Conflicts {
+			messages[i] = conflict.Message
 		}
-		return false
+		err = fmt.Errorf("%s", strings.Join(messages, "; "))
 	}
+	report.record("validate", start, "", err)
 
-	printSuccess(fmt.Sprintf("Migration tests passed (%d files)", len(migrations)))
-	return true
+	start = time.Now()
+	report.Warnings = checkMigrationIssues(migrations)
+	detail := ""
+	if len(report.Warnings) > 0 {
+		detail = fmt.Sprintf("%d warning(s)", len(report.Warnings))
+	}
+	report.record("issues", start, detail, nil)
+	return report.finish()
 }
 
 func maskConnectionString(connStr string) string {
@@ -371,3 +320,317 @@ func checkMigrationIssues(migrations []*migration.Migration) []string {
 
 	return issues
 }
+
+// Diagnostic check statuses.
+const (
+	checkPass = "pass"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// diagnosticCheck is the outcome of one step of the diagnostics battery.
+type diagnosticCheck struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Detail     string  `json:"detail,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// latencyReport summarizes ping round trips in milliseconds.
+type latencyReport struct {
+	Samples int     `json:"samples"`
+	MinMs   float64 `json:"min_ms"`
+	P50Ms   float64 `json:"p50_ms"`
+	P95Ms   float64 `json:"p95_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+// diagnosticsReport is the result of `syndrdb test` and its connection and
+// migrations suites, printed with --output json or yaml.
+type diagnosticsReport struct {
+	Passed   bool              `json:"passed"`
+	Checks   []diagnosticCheck `json:"checks"`
+	Latency  *latencyReport    `json:"latency,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// record adds a check that started at start, failed if err is set, and
+// reports whether it passed.
+func (r *diagnosticsReport) record(name string, start time.Time, detail string, err error) bool {
+	check := diagnosticCheck{
+		Name:       name,
+		Status:     checkPass,
+		DurationMs: milliseconds(time.Since(start)),
+		Detail:     detail,
+	}
+	if err != nil {
+		check.Status = checkFail
+		check.Detail = ""
+		check.Error = err.Error()
+	}
+	r.Checks = append(r.Checks, check)
+	return err == nil
+}
+
+// skip adds checks that did not run, with the reason as their detail.
+func (r *diagnosticsReport) skip(reason string, names ...string) {
+	for _, name := range names {
+		r.Checks = append(r.Checks, diagnosticCheck{Name: name, Status: checkSkip, Detail: reason})
+	}
+}
+
+// checkExitCodes maps the checks whose failure has an exit code of its own.
+var checkExitCodes = map[string]int{
+	"parse":     exitValidation,
+	"connect":   exitConnection,
+	"auth":      exitConnection,
+	"directory": exitValidation,
+	"load":      exitValidation,
+	"validate":  exitValidation,
+}
+
+// exitCode returns the exit code for the first failed check, or exitOK.
+func (r *diagnosticsReport) exitCode() int {
+	for _, check := range r.Checks {
+		if check.Status != checkFail {
+			continue
+		}
+		if code, ok := checkExitCodes[check.Name]; ok {
+			return code
+		}
+		return exitError
+	}
+	return exitOK
+}
+
+// finish sets Passed, which holds unless a check failed, and returns r.
+func (r *diagnosticsReport) finish() *diagnosticsReport {
+	r.Passed = true
+	for _, check := range r.Checks {
+		if check.Status == checkFail {
+			r.Passed = false
+		}
+	}
+	return r
+}
+
+// diagnosticsConfig configures runDiagnostics.
+type diagnosticsConfig struct {
+	connStr  string
+	samples  int
+	readOnly bool
+	bundle   string // Scratch bundle, created and dropped by the write checks
+}
+
+// handleTestDiagnostics runs the diagnostics battery and exits non-zero if a check fails.
+func handleTestDiagnostics(args []string) {
+	fs := flag.NewFlagSet("test", flag.ExitOnError)
+	connStr := fs.String("conn", defaultConnString(), "Connection string")
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON (same as --output json)")
+	samples := fs.Int("samples", 10, "Pings for the latency report")
+	readOnly := fs.Bool("read-only", false, "Skip the scratch bundle checks")
+	fs.Usage = printTestUsage
+	fs.Parse(args)
+	if *jsonOutput {
+		setOutputFormat(outputJSON)
+	}
+
+	if *connStr == "" {
+		printError("Connection string is required")
+		fmt.Fprintln(os.Stderr, "\nProvide via --conn flag or SYNDRDB_CONN environment variable")
+		os.Exit(exitValidation)
+	}
+
+	opts := cliOptions()
+	opts.Logger = client.NewNoopLogger()
+	report := runDiagnostics(context.Background(), opts, diagnosticsConfig{
+		connStr:  *connStr,
+		samples:  *samples,
+		readOnly: *readOnly,
+		bundle:   fmt.Sprintf("syndrdb_test_%d", time.Now().UnixNano()),
+	})
+
+	if structuredOutput() {
+		emit(report)
+	} else {
+		printHeader("SyndrDB Diagnostics")
+		fmt.Println(colorDim("  Connection: " + maskConnectionString(*connStr)))
+		fmt.Println()
+		writeDiagnostics(os.Stdout, report)
+	}
+	if !report.Passed {
+		os.Exit(report.exitCode())
+	}
+}
+
+// runDiagnostics connects with opts and runs every check, skipping those that
+// depend on a failed one. The scratch bundle is dropped whenever it was created.
+func runDiagnostics(ctx context.Context, opts *client.ClientOptions, cfg diagnosticsConfig) *diagnosticsReport {
+	report := &diagnosticsReport{}
+	record, skip, finish := report.record, report.skip, report.finish
+	writeChecks := []string{"create bundle", "insert", "query", "drop bundle"}
+
+	// Connect and authenticate; an auth failure means the handshake itself worked
+	c := client.NewClient(opts)
+	start := time.Now()
+	err := c.Connect(ctx, cfg.connStr)
+	if err != nil && client.ErrorCode(err) == "AUTH_FAILED" {
+		record("connect", start, "handshake completed", nil)
+		record("auth", start, "", err)
+		skip("not authenticated", append([]string{"ping", "show bundles"}, writeChecks...)...)
+		return finish()
+	}
+	if !record("connect", start, "", err) {
+		skip("not connected", append([]string{"auth", "ping", "show bundles"}, writeChecks...)...)
+		return finish()
+	}
+	defer c.Disconnect(ctx)
+
+	authDetail := ""
+	if info := c.ServerInfo(); info != nil && info.Version != "" {
+		authDetail = "server " + info.Version
+	}
+	record("auth", start, authDetail, nil)
+
+	start = time.Now()
+	record("ping", start, "", c.Ping(ctx))
+
+	start = time.Now()
+	schemaDef, err := c.GetSchema(ctx)
+	detail := ""
+	if err == nil {
+		detail = fmt.Sprintf("%d bundles", len(schemaDef.Bundles))
+	}
+	record("show bundles", start, detail, err)
+
+	if cfg.readOnly {
+		skip("read-only", writeChecks...)
+	} else {
+		runWriteChecks(ctx, c, cfg.bundle, record, skip)
+	}
+
+	report.Latency = measureLatency(ctx, c, cfg.samples)
+	return finish()
+}
+
+// runWriteChecks creates the scratch bundle, round-trips a document through
+// it and drops it again.
+func runWriteChecks(ctx context.Context, c *client.Client, bundle string, record func(string, time.Time, string, error) bool, skip func(string, ...string)) {
+	create := schema.SerializeCreateBundle(&schema.BundleDefinition{
+		Name: bundle,
+		Fields: []schema.FieldDefinition{
+			{Name: "name", Type: schema.STRING, Required: true},
+			{Name: "n", Type: schema.INT},
+		},
+	})
+	start := time.Now()
+	_, err := c.Query(create, 0)
+	if !record("create bundle", start, bundle, err) {
+		skip("scratch bundle not created", "insert", "query", "drop bundle")
+		return
+	}
+
+	start = time.Now()
+	_, err = c.InsertBuilder(bundle).Values(map[string]interface{}{"name": "syndrdb-test", "n": 1}).Execute(ctx)
+	if record("insert", start, "", err) {
+		start = time.Now()
+		result, err := c.QueryBuilder().Select(bundle).Where("name", client.Equals, "syndrdb-test").Execute(ctx)
+		detail := ""
+		if err == nil {
+			documents, ok := resultDocuments(result)
+			switch {
+			case !ok:
+				err = fmt.Errorf("unexpected query result type %T", result)
+			case len(documents) == 0:
+				err = fmt.Errorf("the inserted document was not returned")
+			default:
+				detail = fmt.Sprintf("%d document(s)", len(documents))
+			}
+		}
+		record("query", start, detail, err)
+	} else {
+		skip("insert failed", "query")
+	}
+
+	start = time.Now()
+	_, err = c.Query(schema.SerializeDeleteBundle(bundle), 0)
+	record("drop bundle", start, "", err)
+}
+
+// measureLatency pings the server samples times, returning nil if none succeed.
+func measureLatency(ctx context.Context, c *client.Client, samples int) *latencyReport {
+	durations := make([]float64, 0, samples)
+	for i := 0; i < samples; i++ {
+		start := time.Now()
+		if err := c.Ping(ctx); err != nil {
+			continue
+		}
+		durations = append(durations, milliseconds(time.Since(start)))
+	}
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sort.Float64s(durations)
+	percentile := func(p float64) float64 {
+		return durations[int(p*float64(len(durations)-1)+0.5)]
+	}
+	return &latencyReport{
+		Samples: len(durations),
+		MinMs:   durations[0],
+		P50Ms:   percentile(0.50),
+		P95Ms:   percentile(0.95),
+		MaxMs:   durations[len(durations)-1],
+	}
+}
+
+// writeDiagnostics prints the checks, latency report and a pass/fail summary.
+func writeDiagnostics(w io.Writer, report *diagnosticsReport) {
+	passed, failed, skipped := 0, 0, 0
+	for i, check := range report.Checks {
+		var status string
+		switch check.Status {
+		case checkPass:
+			passed++
+			status = colorGreen("OK") + colorDim(fmt.Sprintf(" (%.1fms)", check.DurationMs))
+		case checkFail:
+			failed++
+			status = colorRed("FAIL")
+		default:
+			skipped++
+			status = colorYellow("SKIP")
+		}
+		line := fmt.Sprintf("  %d. %-14s %s", i+1, check.Name, status)
+		if check.Detail != "" {
+			line += " " + colorDim(check.Detail)
+		}
+		fmt.Fprintln(w, line)
+		if check.Error != "" {
+			fmt.Fprintln(w, "     "+colorRed(check.Error))
+		}
+	}
+
+	for _, warning := range report.Warnings {
+		fmt.Fprintln(w, "     "+colorYellow("⚠")+" "+warning)
+	}
+
+	if report.Latency != nil {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "  Latency over %d pings: min %.1fms, p50 %.1fms, p95 %.1fms, max %.1fms\n",
+			report.Latency.Samples, report.Latency.MinMs, report.Latency.P50Ms, report.Latency.P95Ms, report.Latency.MaxMs)
+	}
+
+	fmt.Fprintln(w)
+	summary := fmt.Sprintf("%d passed, %d failed, %d skipped", passed, failed, skipped)
+	if report.Passed {
+		fmt.Fprintln(w, colorGreen("✓")+" "+summary)
+	} else {
+		fmt.Fprintln(w, colorRed("✗")+" "+summary)
+	}
+}
+
+// milliseconds converts d to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
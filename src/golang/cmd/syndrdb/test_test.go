@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client/clienttest"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/migration"
+)
+
+func diagnosticsOptions(dialer func(ctx context.Context, network, address string) (net.Conn, error)) *client.ClientOptions {
+	opts := client.DefaultOptions()
+	opts.Logger = client.NewNoopLogger()
+	opts.MaxRetries = 1
+	opts.Dialer = dialer
+	return &opts
+}
+
+func checkStatuses(report *diagnosticsReport) string {
+	statuses := make([]string, len(report.Checks))
+	for i, check := range report.Checks {
+		statuses[i] = check.Name + "=" + check.Status
+	}
+	return strings.Join(statuses, ",")
+}
+
+func TestRunDiagnostics(t *testing.T) {
+	server := clienttest.NewServer()
+	server.On(`SHOW BUNDLES`).Return(map[string]interface{}{"bundles": []interface{}{}})
+	server.On(`CREATE BUNDLE "scratch"*`).Return("created")
+	server.On(`ADD DOCUMENT TO BUNDLE*`).Return(map[string]interface{}{"affected_count": 1})
+	server.On(`SELECT * FROM scratch WHERE name == 'syndrdb-test'`).Return([]map[string]interface{}{{"name": "syndrdb-test", "n": 1}})
+	server.On(`DROP BUNDLE "scratch"`).Return("dropped")
+
+	report := runDiagnostics(context.Background(), diagnosticsOptions(server.Dial), diagnosticsConfig{
+		connStr: clienttest.ConnectionString,
+		samples: 5,
+		bundle:  "scratch",
+	})
+
+	want := "connect=pass,auth=pass,ping=pass,show bundles=pass,create bundle=pass,insert=pass,query=pass,drop bundle=pass"
+	if got := checkStatuses(report); got != want {
+		t.Fatalf("got %s, want %s (%+v)", got, want, report.Checks)
+	}
+	if !report.Passed || report.Latency == nil || report.Latency.Samples != 5 {
+		t.Errorf("expected a passing report with 5 latency samples, got %+v", report)
+	}
+	if report.Latency.MinMs > report.Latency.P50Ms || report.Latency.P95Ms > report.Latency.MaxMs {
+		t.Errorf("latency percentiles out of order: %+v", report.Latency)
+	}
+}
+
+func TestRunDiagnostics_Failures(t *testing.T) {
+	server := clienttest.NewServer()
+	server.On(`SHOW BUNDLES`).Return(map[string]interface{}{"bundles": []interface{}{}})
+	server.On(`CREATE BUNDLE*`).Return("created")
+	server.On(`ADD DOCUMENT TO BUNDLE*`).ReturnError("permission denied")
+	server.On(`DROP BUNDLE*`).Return("dropped")
+
+	report := runDiagnostics(context.Background(), diagnosticsOptions(server.Dial), diagnosticsConfig{
+		connStr: clienttest.ConnectionString,
+		samples: 1,
+		bundle:  "scratch",
+	})
+	want := "connect=pass,auth=pass,ping=pass,show bundles=pass,create bundle=pass,insert=fail,query=skip,drop bundle=pass"
+	if got := checkStatuses(report); got != want || report.Passed {
+		t.Errorf("got %s (passed %v), want %s", got, report.Passed, want)
+	}
+
+	readOnly := runDiagnostics(context.Background(), diagnosticsOptions(server.Dial), diagnosticsConfig{
+		connStr:  clienttest.ConnectionString,
+		readOnly: true,
+	})
+	if !readOnly.Passed || readOnly.Checks[4].Status != checkSkip || readOnly.Latency != nil {
+		t.Errorf("expected write checks to be skipped, got %+v", readOnly)
+	}
+
+	refused := func(ctx context.Context, network, address string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	offline := runDiagnostics(context.Background(), diagnosticsOptions(refused), diagnosticsConfig{
+		connStr: clienttest.ConnectionString,
+	})
+	want = "connect=fail,auth=skip,ping=skip,show bundles=skip,create bundle=skip,insert=skip,query=skip,drop bundle=skip"
+	if got := checkStatuses(offline); got != want || offline.Passed {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestWriteDiagnostics(t *testing.T) {
+	colorsEnabled = false
+	defer func() { colorsEnabled = true }()
+
+	report := &diagnosticsReport{
+		Checks: []diagnosticCheck{
+			{Name: "connect", Status: checkPass, DurationMs: 1.5},
+			{Name: "show bundles", Status: checkFail, Error: "timeout"},
+			{Name: "insert", Status: checkSkip, Detail: "read-only"},
+		},
+		Latency: &latencyReport{Samples: 3, MinMs: 0.5, P50Ms: 1, P95Ms: 2, MaxMs: 2},
+	}
+
+	var buf bytes.Buffer
+	writeDiagnostics(&buf, report)
+	want := strings.Join([]string{
+		"  1. connect        OK (1.5ms)",
+		"  2. show bundles   FAIL",
+		"     timeout",
+		"  3. insert         SKIP read-only",
+		"",
+		"  Latency over 3 pings: min 0.5ms, p50 1.0ms, p95 2.0ms, max 2.0ms",
+		"",
+		"✗ 1 passed, 1 failed, 1 skipped",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRunConnectionTests(t *testing.T) {
+	server := clienttest.NewServer()
+	report := runConnectionTests(context.Background(), diagnosticsOptions(server.Dial), clienttest.ConnectionString, true)
+	if got := checkStatuses(report); got != "parse=pass,connect=pass,ping=pass,state=pass" || !report.Passed {
+		t.Errorf("got %s (passed %v)", got, report.Passed)
+	}
+	if !strings.HasSuffix(report.Checks[0].Detail, ":****;") {
+		t.Errorf("expected the password to be masked, got %s", report.Checks[0].Detail)
+	}
+
+	invalid := runConnectionTests(context.Background(), diagnosticsOptions(server.Dial), "localhost", false)
+	if got := checkStatuses(invalid); got != "parse=fail,connect=skip,ping=skip,state=skip" || invalid.Passed {
+		t.Errorf("got %s (passed %v)", got, invalid.Passed)
+	}
+}
+
+func TestRunMigrationTests(t *testing.T) {
+	dir := t.TempDir()
+	for _, mig := range []*migration.Migration{
+		{ID: "001_users", Name: "users", Up: []string{`CREATE BUNDLE "users";`}, Down: []string{`DROP BUNDLE "users";`}, Timestamp: time.Now()},
+		{ID: "002_orders", Name: "orders", Up: []string{`CREATE BUNDLE "orders";`}, Timestamp: time.Now()},
+	} {
+		if _, err := migration.WriteMigrationFile(mig, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report := runMigrationTests(dir)
+	if got := checkStatuses(report); got != "directory=pass,load=pass,validate=pass,issues=pass" || !report.Passed {
+		t.Errorf("got %s (passed %v)", got, report.Passed)
+	}
+	if report.Checks[1].Detail != "2 found" || len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "002_orders") {
+		t.Errorf("expected a warning about the missing DOWN commands, got %+v", report)
+	}
+
+	missing := runMigrationTests(filepath.Join(dir, "missing"))
+	if got := checkStatuses(missing); got != "directory=fail,load=skip,validate=skip,issues=skip" || missing.Passed {
+		t.Errorf("got %s (passed %v)", got, missing.Passed)
+	}
+}
+
+func TestDiagnosticsReportExitCode(t *testing.T) {
+	tests := []struct {
+		checks []diagnosticCheck
+		want   int
+	}{
+		{[]diagnosticCheck{{Name: "connect", Status: checkPass}, {Name: "ping", Status: checkPass}}, exitOK},
+		{[]diagnosticCheck{{Name: "connect", Status: checkFail}, {Name: "ping", Status: checkSkip}}, exitConnection},
+		{[]diagnosticCheck{{Name: "connect", Status: checkPass}, {Name: "auth", Status: checkFail}}, exitConnection},
+		{[]diagnosticCheck{{Name: "parse", Status: checkFail}}, exitValidation},
+		{[]diagnosticCheck{{Name: "directory", Status: checkPass}, {Name: "validate", Status: checkFail}}, exitValidation},
+		{[]diagnosticCheck{{Name: "connect", Status: checkPass}, {Name: "insert", Status: checkFail}}, exitError},
+	}
+	for _, tt := range tests {
+		report := (&diagnosticsReport{Checks: tt.checks}).finish()
+		if got := report.exitCode(); got != tt.want {
+			t.Errorf("%s: got exit code %d, want %d", checkStatuses(report), got, tt.want)
+		}
+	}
+}
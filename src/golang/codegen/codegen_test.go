@@ -2,6 +2,10 @@ package codegen
 
 import (
 	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -121,3 +125,369 @@ func TestGraphQLSchemaGenerator_Generate(t *testing.T) {
 		t.Error("expected Mutation type")
 	}
 }
+
+func constraintTestSchema() *schema.SchemaDefinition {
+	minLength, maxLength := 3, 20
+	minAge := 0.0
+	return &schema.SchemaDefinition{
+		Bundles: []schema.BundleDefinition{
+			{
+				Name: "users",
+				Fields: []schema.FieldDefinition{
+					{Name: "username", Type: schema.STRING, Required: true, Constraints: &schema.FieldConstraints{
+						MinLength: &minLength, MaxLength: &maxLength, Pattern: "^[a-z]+$",
+					}},
+					{Name: "age", Type: schema.INT, Constraints: &schema.FieldConstraints{Min: &minAge}},
+					{Name: "role", Type: schema.STRING, Required: true, DefaultValue: "member", Constraints: &schema.FieldConstraints{
+						Enum: []interface{}{"member", "admin"},
+					}},
+					{Name: "label", Type: schema.STRING, Constraints: &schema.FieldConstraints{
+						Enum: []interface{}{"needs review", "done"},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestJSONSchemaGenerator_Constraints(t *testing.T) {
+	result, err := NewJSONSchemaGenerator().GenerateSingle(constraintTestSchema())
+	if err != nil {
+		t.Fatalf("GenerateSingle failed: %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(result), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	properties := parsed["definitions"].(map[string]interface{})["users"].(map[string]interface{})["properties"].(map[string]interface{})
+
+	username := properties["username"].(map[string]interface{})
+	if username["minLength"] != float64(3) || username["maxLength"] != float64(20) || username["pattern"] != "^[a-z]+$" {
+		t.Errorf("unexpected username schema: %v", username)
+	}
+	if age := properties["age"].(map[string]interface{}); age["minimum"] != float64(0) {
+		t.Errorf("unexpected age schema: %v", age)
+	}
+	role := properties["role"].(map[string]interface{})
+	if role["default"] != "member" || len(role["enum"].([]interface{})) != 2 {
+		t.Errorf("unexpected role schema: %v", role)
+	}
+}
+
+func TestGraphQLSchemaGenerator_Constraints(t *testing.T) {
+	result, err := NewGraphQLSchemaGenerator().Generate(constraintTestSchema())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"enum UsersRole {\n  member\n  admin\n}\n",
+		"  role: UsersRole!\n",
+		"  role: UsersRole = member\n",
+		`  """length 3-20, pattern ^[a-z]+$"""` + "\n  username: String!\n",
+		// Values that are not GraphQL names keep the scalar type
+		"  label: String\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "enum UsersLabel") {
+		t.Error("expected no enum type for values that are not GraphQL names")
+	}
+}
+
+func resolverTestSchema() *schema.SchemaDefinition {
+	return &schema.SchemaDefinition{
+		Bundles: []schema.BundleDefinition{
+			{
+				Name: "users",
+				Fields: []schema.FieldDefinition{
+					{Name: "name", Type: schema.STRING, Required: true},
+					{Name: "manager", Type: schema.RELATIONSHIP, RelatedBundle: "users"},
+				},
+				Relationships: []schema.RelationshipDefinition{
+					{Name: "posts", Type: "1toMany", SourceBundle: "users", SourceField: "id", DestBundle: "posts", DestField: "user_id"},
+				},
+			},
+			{
+				Name:   "posts",
+				Fields: []schema.FieldDefinition{{Name: "title", Type: schema.STRING}},
+			},
+		},
+	}
+}
+
+func TestGraphQLSchemaGenerator_OneToManyRelationships(t *testing.T) {
+	result, err := NewGraphQLSchemaGenerator().Generate(resolverTestSchema())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(result, "  posts(limit: Int, offset: Int): [posts!]!\n") {
+		t.Errorf("expected paginated relationship field, got:\n%s", result)
+	}
+}
+
+func TestGraphQLResolverGenerator_GenerateGo(t *testing.T) {
+	result, err := NewGraphQLResolverGenerator().GenerateGo(resolverTestSchema(), "api")
+	if err != nil {
+		t.Fatalf("GenerateGo failed: %v", err)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "resolvers.go", result, 0)
+	if err != nil {
+		t.Fatalf("generated Go does not parse: %v", err)
+	}
+	if file.Name.Name != "api" {
+		t.Errorf("expected package api, got %s", file.Name.Name)
+	}
+
+	methods := make(map[string]bool)
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+			methods[fn.Name.Name] = true
+		}
+	}
+	for _, name := range []string{"GetUsers", "ListUsers", "CreateUsers", "UpdateUsers", "DeleteUsers", "UsersManager", "UsersPosts", "GetPosts"} {
+		if !methods[name] {
+			t.Errorf("expected resolver method %s", name)
+		}
+	}
+
+	if !strings.Contains(result, `Select("posts").Where("user_id", client.Equals, parent["id"])`) {
+		t.Error("expected relationship resolver to filter by the destination field")
+	}
+}
+
+func TestGraphQLResolverGenerator_GenerateTypeScript(t *testing.T) {
+	result, err := NewGraphQLResolverGenerator().GenerateTypeScript(resolverTestSchema())
+	if err != nil {
+		t.Fatalf("GenerateTypeScript failed: %v", err)
+	}
+
+	expected := []string{
+		"export const typeDefs = ",
+		"export function createResolvers(client: SyndrDBClient)",
+		`users: (_parent: unknown, args: { id: string }) => byId("users", args.id),`,
+		`createposts: (_parent: unknown, args: { input: Document }) => insert("posts", args.input),`,
+		`manager: (parent: Document) => byId("users", parent["manager"]),`,
+		`posts: (parent: Document, args: PageArgs) => select("posts", eq("user_id", parent["id"]), args),`,
+	}
+	for _, snippet := range expected {
+		if !strings.Contains(result, snippet) {
+			t.Errorf("expected generated TypeScript to contain %q", snippet)
+		}
+	}
+}
+
+func TestOpenAPIGenerator_Generate(t *testing.T) {
+	gen := NewOpenAPIGenerator()
+	gen.ServerURL = "https://api.example.com"
+
+	result, err := gen.Generate(resolverTestSchema())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var doc struct {
+		OpenAPI    string                            `json:"openapi"`
+		Servers    []map[string]string               `json:"servers"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal([]byte(result), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("expected openapi 3.1.0, got %s", doc.OpenAPI)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0]["url"] != "https://api.example.com" {
+		t.Errorf("unexpected servers: %v", doc.Servers)
+	}
+
+	for path, methods := range map[string][]string{
+		"/users":      {"get", "post"},
+		"/users/{id}": {"get", "patch", "delete"},
+		"/posts":      {"get", "post"},
+	} {
+		for _, method := range methods {
+			if _, ok := doc.Paths[path][method]; !ok {
+				t.Errorf("expected %s %s", method, path)
+			}
+		}
+	}
+
+	for _, name := range []string{"Users", "UsersInput", "UsersUpdate", "Posts", "Error"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("expected component schema %s", name)
+		}
+	}
+
+	input := doc.Components.Schemas["UsersInput"]
+	if required, _ := input["required"].([]interface{}); len(required) != 1 || required[0] != "name" {
+		t.Errorf("expected name to be required on create, got %v", input["required"])
+	}
+	if _, ok := doc.Components.Schemas["UsersUpdate"]["required"]; ok {
+		t.Error("expected update schema to have no required fields")
+	}
+	manager := doc.Components.Schemas["Users"]["properties"].(map[string]interface{})["manager"].(map[string]interface{})
+	if manager["type"] != "string" {
+		t.Errorf("expected relationship field to be a DocumentID string, got %v", manager)
+	}
+}
+
+func TestProtoGenerator_Generate(t *testing.T) {
+	gen := NewProtoGenerator()
+	gen.Package = "shop.v1"
+	gen.Services = true
+
+	result, err := gen.Generate(&schema.SchemaDefinition{
+		Bundles: []schema.BundleDefinition{{
+			Name: "order_items",
+			Fields: []schema.FieldDefinition{
+				{Name: "productName", Type: schema.STRING, Required: true},
+				{Name: "quantity", Type: schema.INT},
+				{Name: "createdAt", Type: schema.DATETIME},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	expected := []string{
+		"package shop.v1;",
+		`import "google/protobuf/timestamp.proto";`,
+		"message OrderItems {",
+		`  string document_id = 1 [json_name = "DocumentID"];`,
+		`  string product_name = 2 [json_name = "productName"];`,
+		"  optional int64 quantity = 3;",
+		`  google.protobuf.Timestamp created_at = 4 [json_name = "createdAt"];`,
+		"service OrderItemsService {",
+		"  rpc DeleteOrderItems(DeleteOrderItemsRequest) returns (google.protobuf.Empty);",
+	}
+	for _, snippet := range expected {
+		if !strings.Contains(result, snippet) {
+			t.Errorf("expected %q in:\n%s", snippet, result)
+		}
+	}
+}
+
+func TestProtoGenerator_StableFieldNumbers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fields.json")
+
+	bundle := schema.BundleDefinition{
+		Name: "users",
+		Fields: []schema.FieldDefinition{
+			{Name: "name", Type: schema.STRING},
+			{Name: "age", Type: schema.INT},
+		},
+	}
+	gen := NewProtoGenerator()
+	if _, err := gen.Generate(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{bundle}}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := gen.Mapping.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Remove "name" and add "email" before regenerating
+	bundle.Fields = []schema.FieldDefinition{
+		{Name: "email", Type: schema.STRING},
+		{Name: "age", Type: schema.INT},
+	}
+	mapping, err := LoadProtoFieldMapping(path)
+	if err != nil {
+		t.Fatalf("LoadProtoFieldMapping failed: %v", err)
+	}
+	gen = NewProtoGenerator()
+	gen.Mapping = mapping
+	result, err := gen.Generate(&schema.SchemaDefinition{Bundles: []schema.BundleDefinition{bundle}})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, snippet := range []string{
+		"  reserved 2;",
+		`  reserved "name";`,
+		"  optional int64 age = 3;",
+		"  optional string email = 4;",
+	} {
+		if !strings.Contains(result, snippet) {
+			t.Errorf("expected %q in:\n%s", snippet, result)
+		}
+	}
+}
+
+func TestProtoFieldName(t *testing.T) {
+	tests := map[string]string{
+		"name":        "name",
+		"createdAt":   "created_at",
+		"DocumentID":  "document_id",
+		"HTTPStatus":  "http_status",
+		"user-id":     "user_id",
+		"2fa_enabled": "f_2fa_enabled",
+	}
+	for input, want := range tests {
+		if got := protoFieldName(input); got != want {
+			t.Errorf("protoFieldName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestDecimalFieldMappings(t *testing.T) {
+	field := &schema.FieldDefinition{Name: "price", Type: schema.DECIMAL}
+
+	fieldSchema := NewJSONSchemaGenerator().generateFieldSchema(field)
+	if fieldSchema["type"] != "string" || fieldSchema["format"] != "decimal" {
+		t.Errorf("unexpected JSON Schema for a decimal: %v", fieldSchema)
+	}
+	if got := NewGraphQLSchemaGenerator().mapToGraphQLType(schema.DECIMAL); got != "String" {
+		t.Errorf("expected GraphQL String, got %s", got)
+	}
+	if got, _ := NewProtoGenerator().mapToProtoType(schema.DECIMAL); got != "string" {
+		t.Errorf("expected proto string, got %s", got)
+	}
+}
+
+func TestGeoFieldMappings(t *testing.T) {
+	point := NewJSONSchemaGenerator().generateFieldSchema(&schema.FieldDefinition{Name: "location", Type: schema.GEOPOINT})
+	properties, _ := point["properties"].(map[string]interface{})
+	if point["type"] != "object" || properties["type"] == nil || properties["coordinates"] == nil {
+		t.Errorf("unexpected JSON Schema for a geopoint: %v", point)
+	}
+	if got := NewGraphQLSchemaGenerator().mapToGraphQLType(schema.GEOSHAPE); got != "JSON" {
+		t.Errorf("expected GraphQL JSON, got %s", got)
+	}
+	if got, imp := NewProtoGenerator().mapToProtoType(schema.GEOPOINT); got != "google.protobuf.Struct" || imp == "" {
+		t.Errorf("expected google.protobuf.Struct, got %s (%s)", got, imp)
+	}
+}
+
+func TestJSONSchemaGenerator_Nested(t *testing.T) {
+	field := &schema.FieldDefinition{Name: "addresses", Type: schema.ARRAY, Items: &schema.FieldDefinition{
+		Type: schema.OBJECT, Fields: []schema.FieldDefinition{
+			{Name: "city", Type: schema.STRING, Required: true},
+			{Name: "tags", Type: schema.ARRAY, Items: &schema.FieldDefinition{Type: schema.STRING}},
+		},
+	}}
+
+	data, err := json.Marshal(NewJSONSchemaGenerator().generateFieldSchema(field))
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	want := `{"items":{"properties":{"city":{"type":"string"},"tags":{"items":{"type":"string"},"type":"array"}},"required":["city"],"type":"object"},"type":"array"}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	tags := &field.Items.Fields[1]
+	if got := NewGraphQLSchemaGenerator().fieldType(&schema.BundleDefinition{Name: "users"}, tags); got != "[String]" {
+		t.Errorf("expected a GraphQL list of strings, got %s", got)
+	}
+}
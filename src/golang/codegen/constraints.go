@@ -0,0 +1,82 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+var graphqlNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// graphqlEnum returns the GraphQL enum type name and values for a string field
+// with an enum constraint. Enums whose values are not valid GraphQL names keep
+// their scalar type.
+func graphqlEnum(bundle *schema.BundleDefinition, field *schema.FieldDefinition) (string, []string, bool) {
+	if field.Constraints == nil || len(field.Constraints.Enum) == 0 ||
+		(field.Type != schema.STRING && field.Type != schema.TEXT) {
+		return "", nil, false
+	}
+
+	values := make([]string, 0, len(field.Constraints.Enum))
+	for _, value := range field.Constraints.Enum {
+		s, ok := value.(string)
+		if !ok || !graphqlNamePattern.MatchString(s) || s == "true" || s == "false" || s == "null" {
+			return "", nil, false
+		}
+		values = append(values, s)
+	}
+	return pascalCase(bundle.Name) + pascalCase(field.Name), values, true
+}
+
+// graphqlLiteral formats a default value as a GraphQL input literal.
+func graphqlLiteral(value interface{}, enum bool) string {
+	if s, ok := value.(string); ok && enum {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
+
+// DescribeConstraints summarizes a field's constraints for doc comments,
+// e.g. "length 3-50, pattern ^[a-z]+$". Returns "" without constraints.
+func DescribeConstraints(field *schema.FieldDefinition) string {
+	c := field.Constraints
+	if c == nil {
+		return ""
+	}
+
+	var parts []string
+	switch {
+	case c.MinLength != nil && c.MaxLength != nil:
+		parts = append(parts, fmt.Sprintf("length %d-%d", *c.MinLength, *c.MaxLength))
+	case c.MinLength != nil:
+		parts = append(parts, fmt.Sprintf("min length %d", *c.MinLength))
+	case c.MaxLength != nil:
+		parts = append(parts, fmt.Sprintf("max length %d", *c.MaxLength))
+	}
+	switch {
+	case c.Min != nil && c.Max != nil:
+		parts = append(parts, fmt.Sprintf("between %v and %v", *c.Min, *c.Max))
+	case c.Min != nil:
+		parts = append(parts, fmt.Sprintf("at least %v", *c.Min))
+	case c.Max != nil:
+		parts = append(parts, fmt.Sprintf("at most %v", *c.Max))
+	}
+	if len(c.Enum) > 0 {
+		values := make([]string, len(c.Enum))
+		for i, value := range c.Enum {
+			values[i] = fmt.Sprintf("%v", value)
+		}
+		parts = append(parts, "one of "+strings.Join(values, ", "))
+	}
+	if c.Pattern != "" {
+		parts = append(parts, "pattern "+c.Pattern)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,379 @@
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// clientImportPath is the Go client package imported by generated resolvers.
+const clientImportPath = "github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+
+// tsClientPackage is the TypeScript client package imported by generated resolvers.
+const tsClientPackage = "@syndrdb/node-wasm"
+
+// GraphQLResolverGenerator generates resolver stubs for the schema produced by
+// GraphQLSchemaGenerator. Queries, mutations and relationship fields are mapped
+// to SyndrDB queries per bundle, so the output together with the embedded SDL
+// is enough to serve a GraphQL API over SyndrDB.
+type GraphQLResolverGenerator struct {
+	registry *TypeRegistry
+	sdl      *GraphQLSchemaGenerator
+}
+
+// NewGraphQLResolverGenerator creates a new GraphQL resolver generator.
+func NewGraphQLResolverGenerator() *GraphQLResolverGenerator {
+	return &GraphQLResolverGenerator{
+		registry: NewTypeRegistry(),
+		sdl:      NewGraphQLSchemaGenerator(),
+	}
+}
+
+// GenerateGo creates Go resolvers built on the client's QueryBuilder.
+// The output is a gofmt-formatted file in the given package.
+func (g *GraphQLResolverGenerator) GenerateGo(schemaDef *schema.SchemaDefinition, packageName string) (string, error) {
+	sdl, err := g.sdl.Generate(schemaDef)
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(sdl, "`") {
+		return "", fmt.Errorf("schema contains a backtick and cannot be embedded in Go source")
+	}
+	g.registry.LoadFromSchema(schemaDef)
+
+	var builder strings.Builder
+	builder.WriteString("// Code generated by syndrdb codegen. DO NOT EDIT.\n\n")
+	builder.WriteString(fmt.Sprintf("package %s\n\n", packageName))
+	builder.WriteString(fmt.Sprintf("import (\n\t\"context\"\n\n\t%q\n)\n\n", clientImportPath))
+	builder.WriteString("// Schema is the GraphQL SDL implemented by Resolver.\n")
+	builder.WriteString("const Schema = `" + sdl + "`\n")
+	builder.WriteString(goResolverPrelude)
+
+	for i := range schemaDef.Bundles {
+		g.generateGoBundle(&builder, &schemaDef.Bundles[i])
+	}
+
+	source, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated Go resolvers: %w", err)
+	}
+	return string(source), nil
+}
+
+// generateGoBundle writes the query, mutation and relationship resolvers of a bundle.
+func (g *GraphQLResolverGenerator) generateGoBundle(builder *strings.Builder, bundle *schema.BundleDefinition) {
+	name := pascalCase(bundle.Name)
+	queryField := g.sdl.toLowerFirst(bundle.Name)
+
+	builder.WriteString(fmt.Sprintf("\n// Get%s resolves Query.%s(id).\n", name, queryField))
+	builder.WriteString(fmt.Sprintf("func (r *Resolver) Get%s(ctx context.Context, id string) (Document, error) {\n", name))
+	builder.WriteString(fmt.Sprintf("\treturn r.get(ctx, %q, id)\n}\n", bundle.Name))
+
+	builder.WriteString(fmt.Sprintf("\n// List%s resolves Query.%s(limit, offset).\n", name, g.sdl.toPlural(queryField)))
+	builder.WriteString(fmt.Sprintf("func (r *Resolver) List%s(ctx context.Context, page PageArgs) ([]Document, error) {\n", name))
+	builder.WriteString(fmt.Sprintf("\treturn r.list(ctx, r.Client.QueryBuilder().Select(%q), page)\n}\n", bundle.Name))
+
+	builder.WriteString(fmt.Sprintf("\n// Create%s resolves Mutation.create%s(input).\n", name, bundle.Name))
+	builder.WriteString(fmt.Sprintf("func (r *Resolver) Create%s(ctx context.Context, input Document) (Document, error) {\n", name))
+	builder.WriteString(fmt.Sprintf("\treturn r.create(ctx, %q, input)\n}\n", bundle.Name))
+
+	builder.WriteString(fmt.Sprintf("\n// Update%s resolves Mutation.update%s(id, input).\n", name, bundle.Name))
+	builder.WriteString(fmt.Sprintf("func (r *Resolver) Update%s(ctx context.Context, id string, input Document) (Document, error) {\n", name))
+	builder.WriteString(fmt.Sprintf("\treturn r.update(ctx, %q, id, input)\n}\n", bundle.Name))
+
+	builder.WriteString(fmt.Sprintf("\n// Delete%s resolves Mutation.delete%s(id).\n", name, bundle.Name))
+	builder.WriteString(fmt.Sprintf("func (r *Resolver) Delete%s(ctx context.Context, id string) (bool, error) {\n", name))
+	builder.WriteString(fmt.Sprintf("\treturn r.delete(ctx, %q, id)\n}\n", bundle.Name))
+
+	for _, field := range bundle.Fields {
+		if field.Type != schema.RELATIONSHIP || field.RelatedBundle == "" {
+			continue
+		}
+		method := name + pascalCase(field.Name)
+		builder.WriteString(fmt.Sprintf("\n// %s resolves %s.%s.\n", method, bundle.Name, field.Name))
+		builder.WriteString(fmt.Sprintf("func (r *Resolver) %s(ctx context.Context, parent Document) (Document, error) {\n", method))
+		builder.WriteString(fmt.Sprintf("\treturn r.reference(ctx, %q, parent[%q])\n}\n", field.RelatedBundle, field.Name))
+	}
+
+	for _, rel := range oneToManyRelationships(bundle) {
+		method := name + pascalCase(rel.Name)
+		builder.WriteString(fmt.Sprintf("\n// %s resolves %s.%s(limit, offset).\n", method, bundle.Name, rel.Name))
+		builder.WriteString(fmt.Sprintf("func (r *Resolver) %s(ctx context.Context, parent Document, page PageArgs) ([]Document, error) {\n", method))
+		builder.WriteString(fmt.Sprintf("\tqb := r.Client.QueryBuilder().Select(%q).Where(%q, client.Equals, parent[%q])\n", rel.DestBundle, rel.DestField, rel.SourceField))
+		builder.WriteString("\treturn r.list(ctx, qb, page)\n}\n")
+	}
+}
+
+// GenerateTypeScript creates resolvers for the TypeScript client as a resolver map
+// suitable for Apollo Server, GraphQL Yoga and similar servers.
+func (g *GraphQLResolverGenerator) GenerateTypeScript(schemaDef *schema.SchemaDefinition) (string, error) {
+	sdl, err := g.sdl.Generate(schemaDef)
+	if err != nil {
+		return "", err
+	}
+	g.registry.LoadFromSchema(schemaDef)
+
+	var builder strings.Builder
+	builder.WriteString("// Code generated by syndrdb codegen. DO NOT EDIT.\n\n")
+	builder.WriteString(fmt.Sprintf("import type { SyndrDBClient } from '%s';\n\n", tsClientPackage))
+	builder.WriteString("/** GraphQL SDL implemented by createResolvers. */\n")
+	builder.WriteString("export const typeDefs = " + strconv.Quote(sdl) + ";\n")
+	builder.WriteString(tsResolverPrelude)
+
+	builder.WriteString("\n  return {\n    Query: {\n")
+	for _, bundle := range schemaDef.Bundles {
+		queryField := g.sdl.toLowerFirst(bundle.Name)
+		builder.WriteString(fmt.Sprintf("      %s: (_parent: unknown, args: { id: string }) => byId(%s, args.id),\n",
+			g.tsKey(queryField), strconv.Quote(bundle.Name)))
+		builder.WriteString(fmt.Sprintf("      %s: (_parent: unknown, args: PageArgs) => select(%s, '', args),\n",
+			g.tsKey(g.sdl.toPlural(queryField)), strconv.Quote(bundle.Name)))
+	}
+	builder.WriteString("    },\n    Mutation: {\n")
+	for _, bundle := range schemaDef.Bundles {
+		quoted := strconv.Quote(bundle.Name)
+		builder.WriteString(fmt.Sprintf("      %s: (_parent: unknown, args: { input: Document }) => insert(%s, args.input),\n",
+			g.tsKey("create"+bundle.Name), quoted))
+		builder.WriteString(fmt.Sprintf("      %s: (_parent: unknown, args: { id: string; input: Document }) => update(%s, args.id, args.input),\n",
+			g.tsKey("update"+bundle.Name), quoted))
+		builder.WriteString(fmt.Sprintf("      %s: (_parent: unknown, args: { id: string }) => remove(%s, args.id),\n",
+			g.tsKey("delete"+bundle.Name), quoted))
+	}
+	builder.WriteString("    },\n")
+
+	for i := range schemaDef.Bundles {
+		g.generateTypeScriptBundle(&builder, &schemaDef.Bundles[i])
+	}
+	builder.WriteString("  };\n}\n")
+
+	return builder.String(), nil
+}
+
+// generateTypeScriptBundle writes the relationship field resolvers of a bundle, if any.
+func (g *GraphQLResolverGenerator) generateTypeScriptBundle(builder *strings.Builder, bundle *schema.BundleDefinition) {
+	var fields []string
+	for _, field := range bundle.Fields {
+		if field.Type != schema.RELATIONSHIP || field.RelatedBundle == "" {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("      %s: (parent: Document) => byId(%s, parent[%s]),\n",
+			g.tsKey(field.Name), strconv.Quote(field.RelatedBundle), strconv.Quote(field.Name)))
+	}
+	for _, rel := range oneToManyRelationships(bundle) {
+		fields = append(fields, fmt.Sprintf("      %s: (parent: Document, args: PageArgs) => select(%s, eq(%s, parent[%s]), args),\n",
+			g.tsKey(rel.Name), strconv.Quote(rel.DestBundle), strconv.Quote(rel.DestField), strconv.Quote(rel.SourceField)))
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	builder.WriteString(fmt.Sprintf("    %s: {\n", g.tsKey(bundle.Name)))
+	for _, field := range fields {
+		builder.WriteString(field)
+	}
+	builder.WriteString("    },\n")
+}
+
+// pascalCase converts a bundle or field name to an exported identifier.
+func pascalCase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, part := range parts {
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+	name := strings.Join(parts, "")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsKey returns name as a TypeScript object key, quoting it when needed.
+func (g *GraphQLResolverGenerator) tsKey(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// GetTypeRegistry returns the type registry used by this generator.
+func (g *GraphQLResolverGenerator) GetTypeRegistry() *TypeRegistry {
+	return g.registry
+}
+
+// goResolverPrelude declares the Resolver type and the helpers shared by all bundles.
+const goResolverPrelude = `
+// Document is a SyndrDB document. Its "id" field holds the DocumentID.
+type Document = map[string]interface{}
+
+// PageArgs are the pagination arguments of list fields.
+type PageArgs struct {
+	Limit  *int
+	Offset *int
+}
+
+// Resolver resolves the queries, mutations and relationship fields of Schema
+// with QueryBuilder calls. Wire its methods into your GraphQL server.
+type Resolver struct {
+	Client *client.Client
+}
+
+func (r *Resolver) get(ctx context.Context, bundle, id string) (Document, error) {
+	result, err := r.Client.QueryBuilder().Select(bundle).Where("DocumentID", client.Equals, id).Limit(1).Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return firstDocument(result), nil
+}
+
+func (r *Resolver) list(ctx context.Context, qb *client.QueryBuilder, page PageArgs) ([]Document, error) {
+	if page.Limit != nil {
+		qb.Limit(*page.Limit)
+	}
+	if page.Offset != nil {
+		qb.Offset(*page.Offset)
+	}
+	result, err := qb.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return documents(result), nil
+}
+
+func (r *Resolver) create(ctx context.Context, bundle string, input Document) (Document, error) {
+	result, err := r.Client.InsertBuilder(bundle).Values(input).Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if doc := firstDocument(result.Raw); doc != nil {
+		return doc, nil
+	}
+	return input, nil
+}
+
+func (r *Resolver) update(ctx context.Context, bundle, id string, input Document) (Document, error) {
+	ub := r.Client.UpdateBuilder(bundle)
+	for field, value := range input {
+		ub.Set(field, value)
+	}
+	if _, err := ub.Where("DocumentID", client.Equals, id).Execute(ctx); err != nil {
+		return nil, err
+	}
+	return r.get(ctx, bundle, id)
+}
+
+func (r *Resolver) delete(ctx context.Context, bundle, id string) (bool, error) {
+	if _, err := r.Client.DeleteBuilder(bundle).Where("DocumentID", client.Equals, id).Execute(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *Resolver) reference(ctx context.Context, bundle string, id interface{}) (Document, error) {
+	ref, ok := id.(string)
+	if !ok || ref == "" {
+		return nil, nil
+	}
+	return r.get(ctx, bundle, ref)
+}
+
+// documents extracts documents from a query result, exposing DocumentID as "id".
+func documents(result interface{}) []Document {
+	if wrapped, ok := result.(map[string]interface{}); ok {
+		result = wrapped["Result"]
+	}
+	rows, _ := result.([]interface{})
+	docs := make([]Document, 0, len(rows))
+	for _, row := range rows {
+		doc, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, exists := doc["id"]; !exists {
+			doc["id"] = doc["DocumentID"]
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func firstDocument(result interface{}) Document {
+	if docs := documents(result); len(docs) > 0 {
+		return docs[0]
+	}
+	return nil
+}
+`
+
+// tsResolverPrelude declares the resolver helpers shared by all bundles. It ends
+// inside createResolvers, just before the resolver map is returned.
+const tsResolverPrelude = `
+/** A SyndrDB document. Its "id" field holds the DocumentID. */
+export type Document = Record<string, unknown>;
+
+/** Pagination arguments of list fields. */
+export interface PageArgs {
+  limit?: number | null;
+  offset?: number | null;
+}
+
+const quote = (name: string): string => JSON.stringify(name);
+
+/** Formats a value as a SyndrQL literal. */
+function literal(value: unknown): string {
+  if (value === null || value === undefined) return 'NULL';
+  if (typeof value === 'boolean') return value ? 'TRUE' : 'FALSE';
+  if (typeof value === 'number') return String(value);
+  return JSON.stringify(typeof value === 'string' ? value : JSON.stringify(value));
+}
+
+const eq = (field: string, value: unknown): string => quote(field) + ' == ' + literal(value);
+
+/** Extracts documents from a query result, exposing DocumentID as "id". */
+function documents(result: unknown): Document[] {
+  const rows = Array.isArray(result) ? result : (result as { Result?: unknown } | null)?.Result;
+  if (!Array.isArray(rows)) return [];
+  return rows
+    .filter((row): row is Document => typeof row === 'object' && row !== null)
+    .map((doc) => ('id' in doc ? doc : { ...doc, id: doc.DocumentID }));
+}
+
+/** Creates resolvers that run each GraphQL operation as a SyndrQL command. */
+export function createResolvers(client: SyndrDBClient) {
+  const select = async (bundle: string, where: string, args: PageArgs = {}): Promise<Document[]> => {
+    let command = 'SELECT * FROM ' + quote(bundle);
+    if (where) command += ' WHERE ' + where;
+    if (args.limit != null) command += ' LIMIT ' + Math.trunc(args.limit);
+    if (args.offset != null) command += ' OFFSET ' + Math.trunc(args.offset);
+    return documents(await client.query(command + ';'));
+  };
+
+  const byId = async (bundle: string, id: unknown): Promise<Document | null> => {
+    if (typeof id !== 'string' || id === '') return null;
+    return (await select(bundle, eq('DocumentID', id), { limit: 1 }))[0] ?? null;
+  };
+
+  const insert = async (bundle: string, input: Document): Promise<Document> => {
+    const values = Object.entries(input).map(([field, value]) => '{' + quote(field) + ' = ' + literal(value) + '}');
+    const result = await client.mutate('ADD DOCUMENT TO BUNDLE ' + quote(bundle) + ' WITH (' + values.join(', ') + ');');
+    return documents(result)[0] ?? input;
+  };
+
+  const update = async (bundle: string, id: string, input: Document): Promise<Document | null> => {
+    const values = Object.entries(input).map(([field, value]) => quote(field) + ' = ' + literal(value));
+    await client.mutate('UPDATE DOCUMENTS IN BUNDLE ' + quote(bundle) + ' (' + values.join(', ') + ') WHERE ' + eq('DocumentID', id) + ';');
+    return byId(bundle, id);
+  };
+
+  const remove = async (bundle: string, id: string): Promise<boolean> => {
+    await client.mutate('DELETE DOCUMENTS FROM ' + quote(bundle) + ' WHERE ' + eq('DocumentID', id) + ';');
+    return true;
+  };
+`
@@ -26,6 +26,11 @@ func (g *GraphQLSchemaGenerator) Generate(schemaDef *schema.SchemaDefinition) (s
 	// Write schema header
 	builder.WriteString("# Generated GraphQL Schema for SyndrDB\n\n")
 
+	// Generate enum types for enum-constrained fields
+	for _, bundle := range schemaDef.Bundles {
+		g.generateEnumTypes(&builder, &bundle)
+	}
+
 	// Generate type definitions for each bundle
 	for _, bundle := range schemaDef.Bundles {
 		g.generateType(&builder, &bundle)
@@ -66,18 +71,43 @@ func (g *GraphQLSchemaGenerator) generateType(builder *strings.Builder, bundle *
 			g.generateRelationshipField(builder, &field)
 		} else {
 			// Regular fields
-			graphqlType := g.mapToGraphQLType(field.Type)
+			graphqlType := g.fieldType(bundle, &field)
 			required := ""
 			if field.Required {
 				required = "!"
 			}
+			if description := DescribeConstraints(&field); description != "" {
+				builder.WriteString(fmt.Sprintf(`  """%s"""`+"\n", strings.ReplaceAll(description, `"""`, `\"""`)))
+			}
 			builder.WriteString(fmt.Sprintf("  %s: %s%s\n", field.Name, graphqlType, required))
 		}
 	}
 
+	// Add paginated list fields for one-to-many relationships
+	for _, rel := range oneToManyRelationships(bundle) {
+		builder.WriteString(fmt.Sprintf("  %s(limit: Int, offset: Int): [%s!]!\n", rel.Name, rel.DestBundle))
+	}
+
 	builder.WriteString("}\n")
 }
 
+// oneToManyRelationships returns the bundle's outgoing one-to-many relationships
+// whose names do not collide with a field.
+func oneToManyRelationships(bundle *schema.BundleDefinition) []schema.RelationshipDefinition {
+	fields := make(map[string]bool, len(bundle.Fields))
+	for _, field := range bundle.Fields {
+		fields[field.Name] = true
+	}
+
+	var rels []schema.RelationshipDefinition
+	for _, rel := range bundle.Relationships {
+		if rel.SourceBundle == bundle.Name && rel.Type == "1toMany" && !fields[rel.Name] {
+			rels = append(rels, rel)
+		}
+	}
+	return rels
+}
+
 // generateRelationshipField creates a field definition for relationships.
 func (g *GraphQLSchemaGenerator) generateRelationshipField(builder *strings.Builder, field *schema.FieldDefinition) {
 	if field.RelatedBundle == "" {
@@ -100,12 +130,17 @@ func (g *GraphQLSchemaGenerator) generateInputType(builder *strings.Builder, bun
 			continue
 		}
 
-		graphqlType := g.mapToGraphQLType(field.Type)
+		graphqlType := g.fieldType(bundle, &field)
 		required := ""
 		if field.Required && field.DefaultValue == nil {
 			required = "!"
 		}
-		builder.WriteString(fmt.Sprintf("  %s: %s%s\n", field.Name, graphqlType, required))
+		defaultValue := ""
+		if field.DefaultValue != nil {
+			_, _, enum := graphqlEnum(bundle, &field)
+			defaultValue = " = " + graphqlLiteral(field.DefaultValue, enum)
+		}
+		builder.WriteString(fmt.Sprintf("  %s: %s%s%s\n", field.Name, graphqlType, required, defaultValue))
 	}
 
 	builder.WriteString("}\n")
@@ -119,7 +154,7 @@ func (g *GraphQLSchemaGenerator) generateInputType(builder *strings.Builder, bun
 			continue
 		}
 
-		graphqlType := g.mapToGraphQLType(field.Type)
+		graphqlType := g.fieldType(bundle, &field)
 		builder.WriteString(fmt.Sprintf("  %s: %s\n", field.Name, graphqlType))
 	}
 
@@ -177,10 +212,37 @@ func (g *GraphQLSchemaGenerator) generateSubscriptionType(builder *strings.Build
 	builder.WriteString("}\n")
 }
 
+// generateEnumTypes creates enum types for a bundle's enum-constrained string fields.
+func (g *GraphQLSchemaGenerator) generateEnumTypes(builder *strings.Builder, bundle *schema.BundleDefinition) {
+	for i := range bundle.Fields {
+		name, values, ok := graphqlEnum(bundle, &bundle.Fields[i])
+		if !ok {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("enum %s {\n", name))
+		for _, value := range values {
+			builder.WriteString(fmt.Sprintf("  %s\n", value))
+		}
+		builder.WriteString("}\n\n")
+	}
+}
+
+// fieldType returns the GraphQL type of a field, using its enum type if it has
+// one and a list type for arrays with known items.
+func (g *GraphQLSchemaGenerator) fieldType(bundle *schema.BundleDefinition, field *schema.FieldDefinition) string {
+	if name, _, ok := graphqlEnum(bundle, field); ok {
+		return name
+	}
+	if field.Type == schema.ARRAY && field.Items != nil {
+		return "[" + g.mapToGraphQLType(field.Items.Type) + "]"
+	}
+	return g.mapToGraphQLType(field.Type)
+}
+
 // mapToGraphQLType maps SyndrDB types to GraphQL types.
 func (g *GraphQLSchemaGenerator) mapToGraphQLType(fieldType schema.FieldType) string {
 	switch fieldType {
-	case schema.STRING, schema.TEXT, schema.DATETIME:
+	case schema.STRING, schema.TEXT, schema.DATETIME, schema.DECIMAL:
 		return "String"
 	case schema.INT:
 		return "Int"
@@ -188,8 +250,8 @@ func (g *GraphQLSchemaGenerator) mapToGraphQLType(fieldType schema.FieldType) st
 		return "Float"
 	case schema.BOOLEAN:
 		return "Boolean"
-	case schema.JSON:
-		return "JSON" // Assumes JSON scalar is defined
+	case schema.JSON, schema.GEOPOINT, schema.GEOSHAPE, schema.ARRAY, schema.OBJECT:
+		return "JSON" // Assumes JSON scalar is defined; geo values are GeoJSON
 	default:
 		return "String"
 	}
@@ -73,10 +73,15 @@ func (g *JSONSchemaGenerator) GenerateMulti(schemaDef *schema.SchemaDefinition)
 
 // generateBundleSchema creates a JSON Schema object for a single bundle.
 func (g *JSONSchemaGenerator) generateBundleSchema(bundle *schema.BundleDefinition) map[string]interface{} {
+	return g.generateObjectSchema(bundle.Fields)
+}
+
+// generateObjectSchema creates a JSON Schema object with fields as its properties.
+func (g *JSONSchemaGenerator) generateObjectSchema(fields []schema.FieldDefinition) map[string]interface{} {
 	properties := make(map[string]interface{})
 	required := make([]string, 0)
 
-	for _, field := range bundle.Fields {
+	for _, field := range fields {
 		properties[field.Name] = g.generateFieldSchema(&field)
 
 		if field.Required {
@@ -108,6 +113,10 @@ func (g *JSONSchemaGenerator) generateFieldSchema(field *schema.FieldDefinition)
 		fieldSchema["type"] = "integer"
 	case schema.FLOAT:
 		fieldSchema["type"] = "number"
+	case schema.DECIMAL:
+		// A string keeps every digit, which JSON numbers do not guarantee
+		fieldSchema["type"] = "string"
+		fieldSchema["format"] = "decimal"
 	case schema.BOOLEAN:
 		fieldSchema["type"] = "boolean"
 	case schema.DATETIME:
@@ -115,6 +124,25 @@ func (g *JSONSchemaGenerator) generateFieldSchema(field *schema.FieldDefinition)
 		fieldSchema["format"] = "date-time"
 	case schema.JSON:
 		fieldSchema["type"] = "object"
+	case schema.GEOPOINT:
+		fieldSchema["type"] = "object"
+		fieldSchema["required"] = []string{"type", "coordinates"}
+		fieldSchema["properties"] = map[string]interface{}{
+			"type":        map[string]interface{}{"const": "Point"},
+			"coordinates": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}, "minItems": 2, "maxItems": 3},
+		}
+	case schema.GEOSHAPE:
+		fieldSchema["type"] = "object"
+		fieldSchema["required"] = []string{"type", "coordinates"}
+	case schema.ARRAY:
+		fieldSchema["type"] = "array"
+		if field.Items != nil {
+			fieldSchema["items"] = g.generateFieldSchema(field.Items)
+		}
+	case schema.OBJECT:
+		for k, v := range g.generateObjectSchema(field.Fields) {
+			fieldSchema[k] = v
+		}
 	case schema.RELATIONSHIP:
 		// For relationships, reference the related bundle
 		if field.RelatedBundle != "" {
@@ -131,6 +159,27 @@ func (g *JSONSchemaGenerator) generateFieldSchema(field *schema.FieldDefinition)
 		fieldSchema["default"] = field.DefaultValue
 	}
 
+	if c := field.Constraints; c != nil {
+		if c.MinLength != nil {
+			fieldSchema["minLength"] = *c.MinLength
+		}
+		if c.MaxLength != nil {
+			fieldSchema["maxLength"] = *c.MaxLength
+		}
+		if c.Min != nil {
+			fieldSchema["minimum"] = *c.Min
+		}
+		if c.Max != nil {
+			fieldSchema["maximum"] = *c.Max
+		}
+		if len(c.Enum) > 0 {
+			fieldSchema["enum"] = c.Enum
+		}
+		if c.Pattern != "" {
+			fieldSchema["pattern"] = c.Pattern
+		}
+	}
+
 	return fieldSchema
 }
 
@@ -0,0 +1,278 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// OpenAPIGenerator generates an OpenAPI 3.1 document describing a REST API over
+// SyndrDB bundles. Each bundle gets CRUD paths and component schemas for its
+// documents, create inputs and partial updates.
+type OpenAPIGenerator struct {
+	registry *TypeRegistry
+	fields   *JSONSchemaGenerator
+
+	// Title is the API title (default: "SyndrDB API").
+	Title string
+
+	// Version is the API version (default: "1.0.0").
+	Version string
+
+	// ServerURL is the base URL listed under servers. Omitted when empty.
+	ServerURL string
+}
+
+// NewOpenAPIGenerator creates a new OpenAPI generator.
+func NewOpenAPIGenerator() *OpenAPIGenerator {
+	return &OpenAPIGenerator{
+		registry: NewTypeRegistry(),
+		fields:   NewJSONSchemaGenerator(),
+		Title:    "SyndrDB API",
+		Version:  "1.0.0",
+	}
+}
+
+// Generate creates the OpenAPI document as indented JSON.
+func (g *OpenAPIGenerator) Generate(schemaDef *schema.SchemaDefinition) (string, error) {
+	g.registry.LoadFromSchema(schemaDef)
+
+	paths := make(map[string]interface{})
+	schemas := map[string]interface{}{
+		"Error": map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"error": map[string]interface{}{"type": "string"}},
+			"required":   []string{"error"},
+		},
+	}
+	tags := make([]map[string]interface{}, 0, len(schemaDef.Bundles))
+
+	for i := range schemaDef.Bundles {
+		bundle := &schemaDef.Bundles[i]
+		name := pascalCase(bundle.Name)
+
+		schemas[name] = g.generateDocumentSchema(bundle)
+		schemas[name+"Input"] = g.generateInputSchema(bundle, true)
+		schemas[name+"Update"] = g.generateInputSchema(bundle, false)
+
+		collection := "/" + url.PathEscape(bundle.Name)
+		paths[collection] = g.generateCollectionPath(bundle.Name, name)
+		paths[collection+"/{id}"] = g.generateItemPath(bundle.Name, name)
+
+		tags = append(tags, map[string]interface{}{
+			"name":        bundle.Name,
+			"description": fmt.Sprintf("Documents in the %s bundle", bundle.Name),
+		})
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   g.Title,
+			"version": g.Version,
+		},
+		"tags":  tags,
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"responses": map[string]interface{}{
+				"Error": map[string]interface{}{
+					"description": "The request failed",
+					"content":     jsonContent(schemaRef("Error")),
+				},
+			},
+		},
+	}
+	if g.ServerURL != "" {
+		document["servers"] = []map[string]interface{}{{"url": g.ServerURL}}
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// generateCollectionPath creates the list and create operations of a bundle.
+func (g *OpenAPIGenerator) generateCollectionPath(bundleName, name string) map[string]interface{} {
+	return map[string]interface{}{
+		"get": map[string]interface{}{
+			"operationId": "list" + name,
+			"summary":     fmt.Sprintf("List %s documents", bundleName),
+			"tags":        []string{bundleName},
+			"parameters": []map[string]interface{}{
+				queryParameter("limit", "Maximum number of documents to return"),
+				queryParameter("offset", "Number of documents to skip"),
+			},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "The documents",
+					"content":     jsonContent(map[string]interface{}{"type": "array", "items": schemaRef(name)}),
+				},
+				"default": errorResponse(),
+			},
+		},
+		"post": map[string]interface{}{
+			"operationId": "create" + name,
+			"summary":     fmt.Sprintf("Add a document to %s", bundleName),
+			"tags":        []string{bundleName},
+			"requestBody": map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(schemaRef(name + "Input")),
+			},
+			"responses": map[string]interface{}{
+				"201": map[string]interface{}{
+					"description": "The created document",
+					"content":     jsonContent(schemaRef(name)),
+				},
+				"default": errorResponse(),
+			},
+		},
+	}
+}
+
+// generateItemPath creates the get, update and delete operations of a bundle.
+func (g *OpenAPIGenerator) generateItemPath(bundleName, name string) map[string]interface{} {
+	return map[string]interface{}{
+		"parameters": []map[string]interface{}{{
+			"name":        "id",
+			"in":          "path",
+			"required":    true,
+			"description": "DocumentID",
+			"schema":      map[string]interface{}{"type": "string"},
+		}},
+		"get": map[string]interface{}{
+			"operationId": "get" + name,
+			"summary":     fmt.Sprintf("Get a %s document", bundleName),
+			"tags":        []string{bundleName},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "The document",
+					"content":     jsonContent(schemaRef(name)),
+				},
+				"404":     map[string]interface{}{"description": "No document has this ID"},
+				"default": errorResponse(),
+			},
+		},
+		"patch": map[string]interface{}{
+			"operationId": "update" + name,
+			"summary":     fmt.Sprintf("Update a %s document", bundleName),
+			"tags":        []string{bundleName},
+			"requestBody": map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(schemaRef(name + "Update")),
+			},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "The updated document",
+					"content":     jsonContent(schemaRef(name)),
+				},
+				"404":     map[string]interface{}{"description": "No document has this ID"},
+				"default": errorResponse(),
+			},
+		},
+		"delete": map[string]interface{}{
+			"operationId": "delete" + name,
+			"summary":     fmt.Sprintf("Delete a %s document", bundleName),
+			"tags":        []string{bundleName},
+			"responses": map[string]interface{}{
+				"204":     map[string]interface{}{"description": "The document was deleted"},
+				"404":     map[string]interface{}{"description": "No document has this ID"},
+				"default": errorResponse(),
+			},
+		},
+	}
+}
+
+// generateDocumentSchema creates the schema of a stored document, including its ID.
+func (g *OpenAPIGenerator) generateDocumentSchema(bundle *schema.BundleDefinition) map[string]interface{} {
+	properties := map[string]interface{}{
+		"DocumentID": map[string]interface{}{"type": "string", "readOnly": true},
+	}
+	required := []string{"DocumentID"}
+
+	for i := range bundle.Fields {
+		field := &bundle.Fields[i]
+		properties[field.Name] = g.generateFieldSchema(field)
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// generateInputSchema creates the request body schema for creates (required fields
+// without defaults are required) or partial updates (nothing is required).
+func (g *OpenAPIGenerator) generateInputSchema(bundle *schema.BundleDefinition, create bool) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+
+	for i := range bundle.Fields {
+		field := &bundle.Fields[i]
+		properties[field.Name] = g.generateFieldSchema(field)
+		if create && field.Required && field.DefaultValue == nil {
+			required = append(required, field.Name)
+		}
+	}
+
+	inputSchema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		inputSchema["required"] = required
+	}
+	return inputSchema
+}
+
+// generateFieldSchema maps a field to a schema. Relationship fields hold the
+// DocumentID of the related document.
+func (g *OpenAPIGenerator) generateFieldSchema(field *schema.FieldDefinition) map[string]interface{} {
+	if field.Type != schema.RELATIONSHIP {
+		return g.fields.generateFieldSchema(field)
+	}
+
+	fieldSchema := map[string]interface{}{"type": "string"}
+	if field.RelatedBundle != "" {
+		fieldSchema["description"] = fmt.Sprintf("DocumentID of the related %s document", field.RelatedBundle)
+	}
+	return fieldSchema
+}
+
+// GetTypeRegistry returns the type registry used by this generator.
+func (g *OpenAPIGenerator) GetTypeRegistry() *TypeRegistry {
+	return g.registry
+}
+
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(schemaDef map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schemaDef},
+	}
+}
+
+func errorResponse() map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/responses/Error"}
+}
+
+func queryParameter(name, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"in":          "query",
+		"description": description,
+		"schema":      map[string]interface{}{"type": "integer", "minimum": 0},
+	}
+}
@@ -0,0 +1,323 @@
+package codegen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// protoDocumentIDField is the mapping key of the document_id field every message starts with.
+const protoDocumentIDField = "DocumentID"
+
+// protoMappingVersion is the current field mapping file format version.
+const protoMappingVersion = 1
+
+// ProtoFieldMapping records the field numbers assigned to each bundle's message.
+// Persisting it between runs keeps numbers stable: existing fields keep their
+// number, new fields get unused numbers and numbers of removed fields are
+// reserved rather than reused, so regenerated messages stay wire compatible.
+type ProtoFieldMapping struct {
+	Version  int                       `json:"version"`
+	Messages map[string]map[string]int `json:"messages"`
+}
+
+// NewProtoFieldMapping creates an empty mapping.
+func NewProtoFieldMapping() *ProtoFieldMapping {
+	return &ProtoFieldMapping{
+		Version:  protoMappingVersion,
+		Messages: make(map[string]map[string]int),
+	}
+}
+
+// LoadProtoFieldMapping reads a mapping file written by Save.
+// A missing file yields an empty mapping.
+func LoadProtoFieldMapping(path string) (*ProtoFieldMapping, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewProtoFieldMapping(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var mapping ProtoFieldMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("invalid field mapping %s: %w", path, err)
+	}
+	if mapping.Version != protoMappingVersion {
+		return nil, fmt.Errorf("unsupported field mapping version %d in %s", mapping.Version, path)
+	}
+	if mapping.Messages == nil {
+		mapping.Messages = make(map[string]map[string]int)
+	}
+	return &mapping, nil
+}
+
+// Save writes the mapping to path, creating parent directories.
+func (m *ProtoFieldMapping) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// assign returns the number of a field, allocating the next free number for new fields.
+func (m *ProtoFieldMapping) assign(message, field string) int {
+	numbers, ok := m.Messages[message]
+	if !ok {
+		numbers = make(map[string]int)
+		m.Messages[message] = numbers
+	}
+	if number, ok := numbers[field]; ok {
+		return number
+	}
+
+	next := 1
+	for _, number := range numbers {
+		if number >= next {
+			next = number + 1
+		}
+	}
+	// 19000-19999 are reserved by the protobuf implementation
+	if next >= 19000 && next <= 19999 {
+		next = 20000
+	}
+	numbers[field] = next
+	return next
+}
+
+// ProtoGenerator generates proto3 definitions from SyndrDB schema definitions.
+// Each bundle becomes a message and, optionally, a CRUD service.
+type ProtoGenerator struct {
+	registry *TypeRegistry
+
+	// Package is the proto package name (default: "syndrdb").
+	Package string
+
+	// GoPackage sets option go_package when non-empty.
+	GoPackage string
+
+	// Services adds a CRUD service with request/response messages per bundle.
+	Services bool
+
+	// Mapping holds field numbers and is updated by Generate. Save it after
+	// generating to keep numbers stable across runs.
+	Mapping *ProtoFieldMapping
+}
+
+// NewProtoGenerator creates a new Protobuf generator with an empty field mapping.
+func NewProtoGenerator() *ProtoGenerator {
+	return &ProtoGenerator{
+		registry: NewTypeRegistry(),
+		Package:  "syndrdb",
+		Mapping:  NewProtoFieldMapping(),
+	}
+}
+
+// protoField is a message field ready to be written.
+type protoField struct {
+	name     string
+	jsonName string
+	typ      string
+	optional bool
+	number   int
+}
+
+// Generate creates a .proto file for the schema.
+func (g *ProtoGenerator) Generate(schemaDef *schema.SchemaDefinition) (string, error) {
+	g.registry.LoadFromSchema(schemaDef)
+
+	imports := make(map[string]bool)
+	var body strings.Builder
+
+	for i := range schemaDef.Bundles {
+		bundle := &schemaDef.Bundles[i]
+		if err := g.generateMessage(&body, bundle, imports); err != nil {
+			return "", err
+		}
+		if g.Services {
+			g.generateService(&body, bundle, imports)
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// Generated by syndrdb codegen - DO NOT EDIT\n\n")
+	builder.WriteString("syntax = \"proto3\";\n\n")
+	builder.WriteString(fmt.Sprintf("package %s;\n", g.Package))
+
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		builder.WriteString("\n")
+		for _, path := range paths {
+			builder.WriteString(fmt.Sprintf("import %q;\n", path))
+		}
+	}
+
+	if g.GoPackage != "" {
+		builder.WriteString(fmt.Sprintf("\noption go_package = %q;\n", g.GoPackage))
+	}
+
+	builder.WriteString(body.String())
+	return builder.String(), nil
+}
+
+// generateMessage writes the message for a bundle, reserving numbers of removed fields.
+func (g *ProtoGenerator) generateMessage(builder *strings.Builder, bundle *schema.BundleDefinition, imports map[string]bool) error {
+	messageName := pascalCase(bundle.Name)
+
+	fields := []protoField{{
+		name:     "document_id",
+		jsonName: protoDocumentIDField,
+		typ:      "string",
+		number:   g.Mapping.assign(bundle.Name, protoDocumentIDField),
+	}}
+	present := map[string]bool{protoDocumentIDField: true}
+	seen := map[string]string{"document_id": protoDocumentIDField}
+
+	for _, field := range bundle.Fields {
+		name := protoFieldName(field.Name)
+		if other, exists := seen[name]; exists {
+			return fmt.Errorf("bundle %s: fields %q and %q both map to proto field %q", bundle.Name, other, field.Name, name)
+		}
+		seen[name] = field.Name
+		present[field.Name] = true
+
+		typ, importPath := g.mapToProtoType(field.Type)
+		if importPath != "" {
+			imports[importPath] = true
+		}
+		fields = append(fields, protoField{
+			name:     name,
+			jsonName: field.Name,
+			typ:      typ,
+			// Message types already track presence
+			optional: !field.Required && importPath == "",
+			number:   g.Mapping.assign(bundle.Name, field.Name),
+		})
+	}
+
+	builder.WriteString(fmt.Sprintf("\n// %s is a document in the %s bundle.\n", messageName, bundle.Name))
+	builder.WriteString(fmt.Sprintf("message %s {\n", messageName))
+
+	var removed []string
+	for field := range g.Mapping.Messages[bundle.Name] {
+		if !present[field] {
+			removed = append(removed, field)
+		}
+	}
+	sort.Strings(removed)
+	if len(removed) > 0 {
+		numbers := make([]string, len(removed))
+		names := make([]string, len(removed))
+		for i, field := range removed {
+			numbers[i] = fmt.Sprintf("%d", g.Mapping.Messages[bundle.Name][field])
+			names[i] = fmt.Sprintf("%q", protoFieldName(field))
+		}
+		builder.WriteString(fmt.Sprintf("  reserved %s;\n", strings.Join(numbers, ", ")))
+		builder.WriteString(fmt.Sprintf("  reserved %s;\n", strings.Join(names, ", ")))
+	}
+
+	for _, field := range fields {
+		optional := ""
+		if field.optional {
+			optional = "optional "
+		}
+		jsonOption := ""
+		if field.jsonName != field.name {
+			jsonOption = fmt.Sprintf(" [json_name = %q]", field.jsonName)
+		}
+		builder.WriteString(fmt.Sprintf("  %s%s %s = %d%s;\n", optional, field.typ, field.name, field.number, jsonOption))
+	}
+
+	builder.WriteString("}\n")
+	return nil
+}
+
+// generateService writes a CRUD service for a bundle and its request messages.
+func (g *ProtoGenerator) generateService(builder *strings.Builder, bundle *schema.BundleDefinition, imports map[string]bool) {
+	name := pascalCase(bundle.Name)
+	imports["google/protobuf/empty.proto"] = true
+	imports["google/protobuf/field_mask.proto"] = true
+
+	builder.WriteString(fmt.Sprintf("\nservice %sService {\n", name))
+	builder.WriteString(fmt.Sprintf("  rpc Get%s(Get%sRequest) returns (%s);\n", name, name, name))
+	builder.WriteString(fmt.Sprintf("  rpc List%s(List%sRequest) returns (List%sResponse);\n", name, name, name))
+	builder.WriteString(fmt.Sprintf("  rpc Create%s(%s) returns (%s);\n", name, name, name))
+	builder.WriteString(fmt.Sprintf("  rpc Update%s(Update%sRequest) returns (%s);\n", name, name, name))
+	builder.WriteString(fmt.Sprintf("  rpc Delete%s(Delete%sRequest) returns (google.protobuf.Empty);\n", name, name))
+	builder.WriteString("}\n")
+
+	builder.WriteString(fmt.Sprintf("\nmessage Get%sRequest {\n  string document_id = 1;\n}\n", name))
+	builder.WriteString(fmt.Sprintf("\nmessage List%sRequest {\n  int32 limit = 1;\n  int32 offset = 2;\n}\n", name))
+	builder.WriteString(fmt.Sprintf("\nmessage List%sResponse {\n  repeated %s documents = 1;\n}\n", name, name))
+	builder.WriteString(fmt.Sprintf("\nmessage Update%sRequest {\n  string document_id = 1;\n  %s document = 2;\n  google.protobuf.FieldMask update_mask = 3;\n}\n", name, name))
+	builder.WriteString(fmt.Sprintf("\nmessage Delete%sRequest {\n  string document_id = 1;\n}\n", name))
+}
+
+// mapToProtoType maps SyndrDB types to proto types, returning the import a
+// well-known type needs. Relationship fields hold the related DocumentID.
+func (g *ProtoGenerator) mapToProtoType(fieldType schema.FieldType) (string, string) {
+	switch fieldType {
+	case schema.INT:
+		return "int64", ""
+	case schema.FLOAT:
+		return "double", ""
+	case schema.BOOLEAN:
+		return "bool", ""
+	case schema.DATETIME:
+		return "google.protobuf.Timestamp", "google/protobuf/timestamp.proto"
+	case schema.JSON, schema.GEOPOINT, schema.GEOSHAPE, schema.OBJECT:
+		return "google.protobuf.Struct", "google/protobuf/struct.proto"
+	case schema.ARRAY:
+		return "google.protobuf.ListValue", "google/protobuf/struct.proto"
+	default:
+		return "string", ""
+	}
+}
+
+// GetTypeRegistry returns the type registry used by this generator.
+func (g *ProtoGenerator) GetTypeRegistry() *TypeRegistry {
+	return g.registry
+}
+
+// protoFieldName converts a field name to the lower_snake_case proto style.
+func protoFieldName(name string) string {
+	var builder strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) && runes[i-1] != '_' {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte('_')
+		}
+	}
+
+	result := builder.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "f_" + result
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package codegen
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// SchemaSource loads the current schema, e.g. from a file or a live server.
+type SchemaSource func(ctx context.Context) (*schema.SchemaDefinition, error)
+
+// FileSchemaSource returns a source that reads a schema JSON file.
+func FileSchemaSource(path string) SchemaSource {
+	return func(ctx context.Context) (*schema.SchemaDefinition, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var schemaDef schema.SchemaDefinition
+		if err := json.Unmarshal(data, &schemaDef); err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %w", path, err)
+		}
+		return &schemaDef, nil
+	}
+}
+
+// WatchOptions configures a Watcher.
+type WatchOptions struct {
+	// Interval is how often the source is polled (default: 2s).
+	Interval time.Duration
+
+	// Debounce is how long a changed schema must stay unchanged before
+	// OnChange runs, so bursts of edits regenerate once (default: 300ms).
+	Debounce time.Duration
+
+	// OnChange is called with the initial schema and after every change.
+	OnChange func(*schema.SchemaDefinition) error
+
+	// OnError receives load and OnChange errors. Watching continues after errors.
+	OnError func(error)
+}
+
+// Watcher polls a schema source and calls OnChange when the schema changes.
+// Schemas are compared by content, so touching a file without editing it
+// does not trigger regeneration.
+type Watcher struct {
+	source  SchemaSource
+	opts    WatchOptions
+	trigger chan struct{}
+}
+
+// NewWatcher creates a watcher for source.
+func NewWatcher(source SchemaSource, opts WatchOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = 300 * time.Millisecond
+	}
+	return &Watcher{
+		source:  source,
+		opts:    opts,
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+// Trigger makes the watcher check the source now instead of waiting for the
+// next poll. It never blocks, so it is safe to call from client.OnSchemaChange.
+func (w *Watcher) Trigger() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run generates for the initial schema and then watches for changes until ctx
+// is done. It returns ctx.Err().
+func (w *Watcher) Run(ctx context.Context) error {
+	var (
+		current string
+		pending string
+		latest  *schema.SchemaDefinition
+	)
+
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	if schemaDef, fingerprint, ok := w.load(ctx); ok {
+		if w.change(schemaDef) {
+			current = fingerprint
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-w.trigger:
+		case <-debounce.C:
+			if w.change(latest) {
+				current = pending
+			}
+			pending = ""
+			continue
+		}
+
+		schemaDef, fingerprint, ok := w.load(ctx)
+		if !ok {
+			continue
+		}
+		switch fingerprint {
+		case current:
+			// Reverted before the debounce elapsed
+			if pending != "" {
+				debounce.Stop()
+				pending = ""
+			}
+		case pending:
+			// Unchanged since the last poll; keep waiting for the timer
+		default:
+			pending = fingerprint
+			latest = schemaDef
+			debounce.Reset(w.opts.Debounce)
+		}
+	}
+}
+
+// load fetches the schema and its fingerprint, reporting failures to OnError.
+func (w *Watcher) load(ctx context.Context) (*schema.SchemaDefinition, string, bool) {
+	schemaDef, err := w.source(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.reportError(fmt.Errorf("failed to load schema: %w", err))
+		}
+		return nil, "", false
+	}
+
+	data, err := json.Marshal(schemaDef)
+	if err != nil {
+		w.reportError(fmt.Errorf("failed to fingerprint schema: %w", err))
+		return nil, "", false
+	}
+	return schemaDef, fmt.Sprintf("%x", sha256.Sum256(data)), true
+}
+
+// change runs OnChange and reports whether it succeeded. A failed change is
+// retried when the schema next changes.
+func (w *Watcher) change(schemaDef *schema.SchemaDefinition) bool {
+	if w.opts.OnChange == nil {
+		return true
+	}
+	if err := w.opts.OnChange(schemaDef); err != nil {
+		w.reportError(err)
+		return false
+	}
+	return true
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
@@ -0,0 +1,143 @@
+package codegen
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// memorySource is a SchemaSource whose schema can be replaced by tests.
+type memorySource struct {
+	mu     sync.Mutex
+	bundle string
+}
+
+func (s *memorySource) set(bundle string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bundle = bundle
+}
+
+func (s *memorySource) load(ctx context.Context) (*schema.SchemaDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &schema.SchemaDefinition{
+		Bundles: []schema.BundleDefinition{{Name: s.bundle}},
+	}, nil
+}
+
+func startWatcher(t *testing.T, source SchemaSource, opts WatchOptions) (*Watcher, chan string) {
+	t.Helper()
+	changes := make(chan string, 10)
+	opts.OnChange = func(schemaDef *schema.SchemaDefinition) error {
+		changes <- schemaDef.Bundles[0].Name
+		return nil
+	}
+
+	w := NewWatcher(source, opts)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return w, changes
+}
+
+func expectChange(t *testing.T, changes chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-changes:
+		if got != want {
+			t.Fatalf("expected regeneration for %q, got %q", want, got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for regeneration for %q", want)
+	}
+}
+
+func expectNoChange(t *testing.T, changes chan string, wait time.Duration) {
+	t.Helper()
+	select {
+	case got := <-changes:
+		t.Fatalf("unexpected regeneration for %q", got)
+	case <-time.After(wait):
+	}
+}
+
+func TestWatcher_DebouncesChanges(t *testing.T) {
+	source := &memorySource{bundle: "users"}
+	_, changes := startWatcher(t, source.load, WatchOptions{
+		Interval: 5 * time.Millisecond,
+		Debounce: 50 * time.Millisecond,
+	})
+
+	expectChange(t, changes, "users")
+
+	// A burst of edits regenerates once, for the final schema
+	source.set("posts")
+	time.Sleep(15 * time.Millisecond)
+	source.set("comments")
+	expectChange(t, changes, "comments")
+	expectNoChange(t, changes, 100*time.Millisecond)
+}
+
+func TestWatcher_IgnoresRevertedChange(t *testing.T) {
+	source := &memorySource{bundle: "users"}
+	_, changes := startWatcher(t, source.load, WatchOptions{
+		Interval: 5 * time.Millisecond,
+		Debounce: 100 * time.Millisecond,
+	})
+
+	expectChange(t, changes, "users")
+
+	source.set("posts")
+	time.Sleep(20 * time.Millisecond)
+	source.set("users")
+	expectNoChange(t, changes, 200*time.Millisecond)
+}
+
+func TestWatcher_Trigger(t *testing.T) {
+	source := &memorySource{bundle: "users"}
+	w, changes := startWatcher(t, source.load, WatchOptions{
+		Interval: time.Hour,
+		Debounce: 10 * time.Millisecond,
+	})
+
+	expectChange(t, changes, "users")
+
+	source.set("posts")
+	w.Trigger()
+	expectChange(t, changes, "posts")
+}
+
+func TestFileSchemaSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(`{"bundles":[{"name":"users","fields":[]}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	schemaDef, err := FileSchemaSource(path)(context.Background())
+	if err != nil {
+		t.Fatalf("FileSchemaSource failed: %v", err)
+	}
+	if len(schemaDef.Bundles) != 1 || schemaDef.Bundles[0].Name != "users" {
+		t.Errorf("unexpected schema: %+v", schemaDef)
+	}
+
+	if err := os.WriteFile(path, []byte(`{`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FileSchemaSource(path)(context.Background()); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
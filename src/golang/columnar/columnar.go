@@ -0,0 +1,552 @@
+// Package columnar converts query results into record batches in the Apache
+// Arrow columnar format, for analytical consumers. Instead of converting
+// documents to DataFrame rows one map at a time, results are written as an
+// Arrow IPC stream that pandas, Polars, DuckDB and other Arrow-aware tools
+// read directly into their own columns.
+//
+// The package has no dependency on the Arrow libraries: it implements the
+// subset of the format needed for SyndrDB results, namely nullable Bool,
+// Int64, Float64, String, Timestamp and Null columns.
+package columnar
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// Type is the type of a column.
+type Type int
+
+const (
+	Null      Type = iota // Every value is null
+	Bool                  // Bit-packed booleans
+	Int64                 // 64-bit signed integers
+	Float64               // 64-bit floating point numbers
+	String                // UTF-8 strings
+	Timestamp             // Nanoseconds since the Unix epoch, UTC
+)
+
+func (t Type) String() string {
+	switch t {
+	case Null:
+		return "null"
+	case Bool:
+		return "bool"
+	case Int64:
+		return "int64"
+	case Float64:
+		return "float64"
+	case String:
+		return "string"
+	case Timestamp:
+		return "timestamp"
+	default:
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+}
+
+// Field is a named column of a schema. Every field is nullable.
+type Field struct {
+	Name string
+	Type Type
+}
+
+// Schema is the ordered fields of record batches.
+type Schema struct {
+	Fields []Field
+}
+
+// FieldIndex returns the index of the field called name, or -1.
+func (s *Schema) FieldIndex(name string) int {
+	for i, field := range s.Fields {
+		if field.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// equal reports whether s and other have the same fields.
+func (s *Schema) equal(other *Schema) bool {
+	if len(s.Fields) != len(other.Fields) {
+		return false
+	}
+	for i, field := range s.Fields {
+		if field != other.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Column holds the values of one field of a record batch in the Arrow
+// memory layout, so its buffers can be handed to Arrow implementations
+// without conversion.
+type Column struct {
+	Type      Type
+	Len       int
+	NullCount int
+
+	// Validity has bit i (least significant bit first) set if value i is
+	// not null. It is nil if NullCount is 0.
+	Validity []byte
+
+	// Offsets delimit the String values in Data: value i is
+	// Data[Offsets[i]:Offsets[i+1]]. It is nil for other types.
+	Offsets []int32
+
+	// Data holds the values: bit-packed for Bool, concatenated for String,
+	// little-endian for the other types, and nil for Null.
+	Data []byte
+}
+
+// IsNull reports whether value i is null.
+func (c *Column) IsNull(i int) bool {
+	if c.Type == Null {
+		return true
+	}
+	return c.Validity != nil && c.Validity[i/8]&(1<<(i%8)) == 0
+}
+
+// Bool returns value i of a Bool column.
+func (c *Column) Bool(i int) bool {
+	return c.Data[i/8]&(1<<(i%8)) != 0
+}
+
+// Int64 returns value i of an Int64 column, or the nanoseconds of a
+// Timestamp column.
+func (c *Column) Int64(i int) int64 {
+	return int64(binary.LittleEndian.Uint64(c.Data[8*i:]))
+}
+
+// Float64 returns value i of a Float64 column.
+func (c *Column) Float64(i int) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(c.Data[8*i:]))
+}
+
+// String returns value i of a String column.
+func (c *Column) String(i int) string {
+	return string(c.Data[c.Offsets[i]:c.Offsets[i+1]])
+}
+
+// Time returns value i of a Timestamp column.
+func (c *Column) Time(i int) time.Time {
+	return time.Unix(0, c.Int64(i)).UTC()
+}
+
+// Value returns value i as a Go value: nil, bool, int64, float64, string or
+// time.Time.
+func (c *Column) Value(i int) interface{} {
+	if c.IsNull(i) {
+		return nil
+	}
+	switch c.Type {
+	case Bool:
+		return c.Bool(i)
+	case Int64:
+		return c.Int64(i)
+	case Float64:
+		return c.Float64(i)
+	case String:
+		return c.String(i)
+	case Timestamp:
+		return c.Time(i)
+	default:
+		return nil
+	}
+}
+
+// RecordBatch is a set of rows stored column by column.
+type RecordBatch struct {
+	Schema  *Schema
+	NumRows int
+	Columns []*Column // One per schema field
+}
+
+// Column returns the column of the field called name, or nil.
+func (b *RecordBatch) Column(name string) *Column {
+	if i := b.Schema.FieldIndex(name); i >= 0 {
+		return b.Columns[i]
+	}
+	return nil
+}
+
+// rowDocument returns row as a document; rows that are not documents are
+// converted as a document with a single "value" field, as the client's
+// row encoders do.
+func rowDocument(row interface{}) map[string]interface{} {
+	if doc, ok := row.(map[string]interface{}); ok {
+		return doc
+	}
+	return map[string]interface{}{"value": row}
+}
+
+// InferSchema returns a schema with a field for every field of rows, in
+// sorted order. Each field's type is the narrowest that holds all of its
+// values: numbers decoded from JSON are Float64, Go integers and integral
+// json.Numbers are Int64 unless mixed with fractional numbers, time.Time
+// values are Timestamp, and strings, nested values and fields of mixed
+// types are String. Fields that are null in every row are Null.
+func InferSchema(rows []interface{}) *Schema {
+	types := make(map[string]Type)
+	for _, row := range rows {
+		for name, value := range rowDocument(row) {
+			types[name] = mergeTypes(types[name], valueType(value))
+		}
+	}
+	schema := &Schema{Fields: make([]Field, 0, len(types))}
+	for name, t := range types {
+		schema.Fields = append(schema.Fields, Field{Name: name, Type: t})
+	}
+	sort.Slice(schema.Fields, func(i, j int) bool {
+		return schema.Fields[i].Name < schema.Fields[j].Name
+	})
+	return schema
+}
+
+// valueType returns the column type inferred from a single value.
+func valueType(value interface{}) Type {
+	switch v := value.(type) {
+	case nil:
+		return Null
+	case bool:
+		return Bool
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return Int64
+	case float32, float64:
+		return Float64
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return Int64
+		}
+		return Float64
+	case time.Time:
+		return Timestamp
+	case *time.Time:
+		if v == nil {
+			return Null
+		}
+		return Timestamp
+	default:
+		return String
+	}
+}
+
+// mergeTypes returns the type of a column holding values of types a and b.
+func mergeTypes(a, b Type) Type {
+	switch {
+	case a == b || b == Null:
+		return a
+	case a == Null:
+		return b
+	case a == Int64 && b == Float64, a == Float64 && b == Int64:
+		return Float64
+	default:
+		return String
+	}
+}
+
+// BatchBuilder accumulates rows into a record batch.
+type BatchBuilder struct {
+	schema  *Schema
+	columns []*columnBuilder
+	rows    int
+}
+
+// NewBatchBuilder returns a builder for record batches with schema.
+func NewBatchBuilder(schema *Schema) *BatchBuilder {
+	b := &BatchBuilder{schema: schema}
+	b.reset()
+	return b
+}
+
+func (b *BatchBuilder) reset() {
+	b.columns = make([]*columnBuilder, len(b.schema.Fields))
+	for i, field := range b.schema.Fields {
+		b.columns[i] = &columnBuilder{field: field}
+	}
+	b.rows = 0
+}
+
+// Len returns the number of rows appended since the last Build.
+func (b *BatchBuilder) Len() int {
+	return b.rows
+}
+
+// Append adds a row, a document or a single value converted as a document
+// with a "value" field. Missing fields are null and fields outside the
+// schema are ignored. Values are converted to their column's type where
+// that loses nothing: integral floats to Int64, integers to Float64,
+// RFC 3339 strings to Timestamp, and anything to String, as JSON if it is
+// not a string. Other values fail, leaving the builder unchanged.
+func (b *BatchBuilder) Append(row interface{}) error {
+	doc := rowDocument(row)
+	values := make([]interface{}, len(b.columns))
+	for i, column := range b.columns {
+		value, err := column.convert(doc[column.field.Name])
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+	for i, column := range b.columns {
+		column.append(values[i])
+	}
+	b.rows++
+	return nil
+}
+
+// Build returns the rows appended since the last Build as a record batch
+// and resets the builder.
+func (b *BatchBuilder) Build() *RecordBatch {
+	batch := &RecordBatch{
+		Schema:  b.schema,
+		NumRows: b.rows,
+		Columns: make([]*Column, len(b.columns)),
+	}
+	for i, column := range b.columns {
+		batch.Columns[i] = column.finish(b.rows)
+	}
+	b.reset()
+	return batch
+}
+
+// columnBuilder accumulates the values of one field.
+type columnBuilder struct {
+	field     Field
+	validity  []byte
+	nullCount int
+	offsets   []int32
+	data      []byte
+	len       int
+}
+
+// convert returns value converted for the column: a bool, int64, float64
+// or string, nil for null, or an error if value does not fit the column.
+func (c *columnBuilder) convert(value interface{}) (interface{}, error) {
+	if p, ok := value.(*time.Time); ok {
+		if p == nil {
+			return nil, nil
+		}
+		value = *p
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch c.field.Type {
+	case Bool:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case Int64:
+		if v, ok := toInt64(value); ok {
+			return v, nil
+		}
+	case Float64:
+		if v, ok := toFloat64(value); ok {
+			return v, nil
+		}
+	case String:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("columnar: field %q: %w", c.field.Name, err)
+		}
+		return string(data), nil
+	case Timestamp:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UnixNano(), nil
+		case string:
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return t.UnixNano(), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("columnar: field %q: cannot store %T value %v in a %s column",
+		c.field.Name, value, value, c.field.Type)
+}
+
+// append adds a value returned by convert.
+func (c *columnBuilder) append(v interface{}) {
+	i := c.len
+	c.len++
+	if i%8 == 0 {
+		c.validity = append(c.validity, 0)
+		if c.field.Type == Bool {
+			c.data = append(c.data, 0)
+		}
+	}
+	if c.field.Type == String && c.offsets == nil {
+		c.offsets = []int32{0}
+	}
+
+	if v == nil {
+		c.nullCount++
+	} else {
+		c.validity[i/8] |= 1 << (i % 8)
+	}
+	switch c.field.Type {
+	case Bool:
+		if b, _ := v.(bool); b {
+			c.data[i/8] |= 1 << (i % 8)
+		}
+	case Int64, Timestamp:
+		n, _ := v.(int64)
+		c.data = binary.LittleEndian.AppendUint64(c.data, uint64(n))
+	case Float64:
+		f, _ := v.(float64)
+		c.data = binary.LittleEndian.AppendUint64(c.data, math.Float64bits(f))
+	case String:
+		s, _ := v.(string)
+		c.data = append(c.data, s...)
+		c.offsets = append(c.offsets, int32(len(c.data)))
+	}
+}
+
+// finish returns the column of rows values.
+func (c *columnBuilder) finish(rows int) *Column {
+	column := &Column{
+		Type:      c.field.Type,
+		Len:       rows,
+		NullCount: c.nullCount,
+		Validity:  c.validity,
+		Offsets:   c.offsets,
+		Data:      c.data,
+	}
+	switch {
+	case c.field.Type == Null:
+		column.NullCount = rows
+		column.Validity = nil
+		column.Data = nil
+	case c.nullCount == 0:
+		column.Validity = nil
+	}
+	if c.field.Type == String && column.Offsets == nil {
+		column.Offsets = []int32{0}
+	}
+	return column
+}
+
+// toInt64 converts integers and integral numbers to int64.
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<63 {
+			return int64(v), true
+		}
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// toFloat64 converts numbers to float64.
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	if n, ok := toInt64(value); ok {
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// Option configures RecordBatches and EncodeIPC.
+type Option func(*options)
+
+type options struct {
+	schema *Schema
+}
+
+// WithSchema sets the schema of the record batches. By default it is
+// inferred from the first batch of rows, so a field that is null or absent
+// throughout the first batch is a Null column and a later value for it
+// fails; pass a schema when the first rows are not representative.
+func WithSchema(schema *Schema) Option {
+	return func(o *options) {
+		o.schema = schema
+	}
+}
+
+// RecordBatches reads rs to the end and returns one record batch for each
+// of its batches of rows:
+//
+//	stream, err := c.QueryStream(ctx, `SELECT * FROM BUNDLE "orders";`, 10000)
+//	...
+//	batches, err := columnar.RecordBatches(stream)
+//
+// Results already in memory are wrapped with client.NewResultSet.
+func RecordBatches(rs client.ResultSet, opts ...Option) ([]*RecordBatch, error) {
+	var batches []*RecordBatch
+	_, err := readBatches(rs, opts, func(batch *RecordBatch) error {
+		batches = append(batches, batch)
+		return nil
+	})
+	return batches, err
+}
+
+// readBatches converts each batch of rows of rs to a record batch and passes
+// it to fn. It returns the schema, which is nil if rs had no rows and no
+// schema was given.
+func readBatches(rs client.ResultSet, opts []Option, fn func(*RecordBatch) error) (*Schema, error) {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	schema := options.schema
+	var builder *BatchBuilder
+	for rows := rs.NextBatch(); rows != nil; rows = rs.NextBatch() {
+		if len(rows) == 0 {
+			continue
+		}
+		if builder == nil {
+			if schema == nil {
+				schema = InferSchema(rows)
+			}
+			builder = NewBatchBuilder(schema)
+		}
+		for _, row := range rows {
+			if err := builder.Append(row); err != nil {
+				return schema, err
+			}
+		}
+		if err := fn(builder.Build()); err != nil {
+			return schema, err
+		}
+	}
+	return schema, rs.Err()
+}
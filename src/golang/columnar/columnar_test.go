@@ -0,0 +1,167 @@
+package columnar
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+func TestInferSchema(t *testing.T) {
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []interface{}{
+		map[string]interface{}{"id": 1, "price": 9.5, "name": "a", "active": true, "at": when, "tags": []interface{}{"x"}, "gone": nil, "mixed": 1},
+		map[string]interface{}{"id": int64(2), "price": 3, "name": nil, "active": false, "mixed": "two", "count": json.Number("7")},
+	}
+
+	schema := InferSchema(rows)
+	want := []Field{
+		{Name: "active", Type: Bool},
+		{Name: "at", Type: Timestamp},
+		{Name: "count", Type: Int64},
+		{Name: "gone", Type: Null},
+		{Name: "id", Type: Int64},
+		{Name: "mixed", Type: String},
+		{Name: "name", Type: String},
+		{Name: "price", Type: Float64},
+		{Name: "tags", Type: String},
+	}
+	if !reflect.DeepEqual(schema.Fields, want) {
+		t.Errorf("fields = %v, want %v", schema.Fields, want)
+	}
+}
+
+func TestBatchBuilder(t *testing.T) {
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	schema := &Schema{Fields: []Field{
+		{Name: "active", Type: Bool},
+		{Name: "at", Type: Timestamp},
+		{Name: "id", Type: Int64},
+		{Name: "name", Type: String},
+		{Name: "none", Type: Null},
+		{Name: "price", Type: Float64},
+	}}
+	b := NewBatchBuilder(schema)
+	rows := []map[string]interface{}{
+		{"active": true, "at": when, "id": float64(1), "name": "ann", "price": 9.5},
+		{"active": nil, "at": "2024-03-02T00:00:00Z", "id": 2, "name": map[string]interface{}{"first": "bo"}, "price": 3},
+		{"active": false, "id": json.Number("3"), "extra": "ignored"},
+	}
+	for _, row := range rows {
+		if err := b.Append(row); err != nil {
+			t.Fatalf("Append(%v): %v", row, err)
+		}
+	}
+	if b.Len() != 3 {
+		t.Errorf("Len = %d, want 3", b.Len())
+	}
+
+	batch := b.Build()
+	if batch.NumRows != 3 || len(batch.Columns) != len(schema.Fields) {
+		t.Fatalf("batch has %d rows and %d columns", batch.NumRows, len(batch.Columns))
+	}
+	want := map[string][]interface{}{
+		"active": {true, nil, false},
+		"at":     {when, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), nil},
+		"id":     {int64(1), int64(2), int64(3)},
+		"name":   {"ann", `{"first":"bo"}`, nil},
+		"none":   {nil, nil, nil},
+		"price":  {9.5, 3.0, nil},
+	}
+	for name, values := range want {
+		column := batch.Column(name)
+		for i, value := range values {
+			if got := column.Value(i); !reflect.DeepEqual(got, value) {
+				t.Errorf("%s[%d] = %#v, want %#v", name, i, got, value)
+			}
+		}
+	}
+
+	if id := batch.Column("id"); id.NullCount != 0 || id.Validity != nil {
+		t.Errorf("id has %d nulls and validity %v, want no bitmap", id.NullCount, id.Validity)
+	}
+	if active := batch.Column("active"); active.NullCount != 1 || active.Validity[0] != 0b101 || active.Data[0] != 0b001 {
+		t.Errorf("active: %d nulls, validity %08b, data %08b", active.NullCount, active.Validity[0], active.Data[0])
+	}
+	if name := batch.Column("name"); !reflect.DeepEqual(name.Offsets, []int32{0, 3, 17, 17}) {
+		t.Errorf("name offsets = %v", name.Offsets)
+	}
+	if none := batch.Column("none"); none.NullCount != 3 {
+		t.Errorf("none has %d nulls, want 3", none.NullCount)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Len after Build = %d, want 0", b.Len())
+	}
+}
+
+func TestBatchBuilderTypeMismatch(t *testing.T) {
+	b := NewBatchBuilder(&Schema{Fields: []Field{
+		{Name: "id", Type: Int64},
+		{Name: "name", Type: String},
+	}})
+	tests := []map[string]interface{}{
+		{"id": 1.5},
+		{"id": "1"},
+		{"id": true},
+	}
+	for _, row := range tests {
+		err := b.Append(map[string]interface{}{"id": row["id"], "name": "x"})
+		if err == nil || !strings.Contains(err.Error(), `field "id"`) {
+			t.Errorf("Append(%v) error = %v, want a type mismatch on id", row, err)
+		}
+	}
+	if b.Len() != 0 {
+		t.Errorf("Len = %d after failed appends, want 0", b.Len())
+	}
+	if batch := b.Build(); len(batch.Column("name").Data) != 0 {
+		t.Errorf("failed appends left name data %q", batch.Column("name").Data)
+	}
+}
+
+func TestRecordBatches(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{"id": float64(1), "total": 10.0},
+		map[string]interface{}{"id": float64(2), "total": 20.5},
+		map[string]interface{}{"id": float64(3), "total": 7.25},
+	}
+	batches, err := RecordBatches(client.NewRowStream(rows, 2))
+	if err != nil {
+		t.Fatalf("RecordBatches: %v", err)
+	}
+	if len(batches) != 2 || batches[0].NumRows != 2 || batches[1].NumRows != 1 {
+		t.Fatalf("got %d batches", len(batches))
+	}
+	if batches[0].Schema != batches[1].Schema {
+		t.Error("batches do not share the schema inferred from the first")
+	}
+	if got := batches[1].Column("total").Float64(0); got != 7.25 {
+		t.Errorf("total = %v, want 7.25", got)
+	}
+
+	// JSON numbers are floats; a schema makes them integers
+	schema := &Schema{Fields: []Field{{Name: "id", Type: Int64}}}
+	batches, err = RecordBatches(client.NewResultSet(rows), WithSchema(schema))
+	if err != nil {
+		t.Fatalf("RecordBatches with schema: %v", err)
+	}
+	if got := batches[0].Column("id").Int64(2); got != 3 {
+		t.Errorf("id = %v, want 3", got)
+	}
+}
+
+func TestRecordBatchesNullFirstBatch(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{"id": float64(1), "note": nil},
+		map[string]interface{}{"id": float64(2), "note": "late"},
+	}
+	if _, err := RecordBatches(client.NewRowStream(rows, 1)); err == nil {
+		t.Fatal("expected a value in a column inferred as null to fail")
+	}
+	schema := &Schema{Fields: []Field{{Name: "id", Type: Float64}, {Name: "note", Type: String}}}
+	if _, err := RecordBatches(client.NewRowStream(rows, 1), WithSchema(schema)); err != nil {
+		t.Fatalf("RecordBatches with schema: %v", err)
+	}
+}
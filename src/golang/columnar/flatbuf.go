@@ -0,0 +1,169 @@
+package columnar
+
+import "encoding/binary"
+
+// The Arrow IPC metadata is encoded as FlatBuffers. The handful of tables
+// it needs are written by this small builder rather than generated code,
+// keeping the package free of dependencies.
+//
+// The builder lays a buffer out front to back: each object is written
+// before the objects it references, and the references are patched once
+// their targets are placed. FlatBuffers offsets are unsigned and must point
+// forward, which this order satisfies.
+
+// fbObject is a table, vector or string that can be written to a buffer.
+type fbObject interface {
+	// write appends the object and everything it references to w and
+	// returns the object's position.
+	write(w *fbWriter) int
+}
+
+type fbWriter struct {
+	buf []byte
+}
+
+// align pads the buffer to a multiple of n.
+func (w *fbWriter) align(n int) {
+	for len(w.buf)%n != 0 {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// reserve appends n zero bytes and returns their position.
+func (w *fbWriter) reserve(n int) int {
+	pos := len(w.buf)
+	w.buf = append(w.buf, make([]byte, n)...)
+	return pos
+}
+
+// patch stores at slot the forward offset to target.
+func (w *fbWriter) patch(slot, target int) {
+	binary.LittleEndian.PutUint32(w.buf[slot:], uint32(target-slot))
+}
+
+// fbFinish returns the buffer with root as its root table.
+func fbFinish(root fbObject) []byte {
+	w := &fbWriter{buf: make([]byte, 4)}
+	w.patch(0, root.write(w))
+	return w.buf
+}
+
+// fbField is a table field: a little-endian scalar of size bytes, or a
+// reference to another object.
+type fbField struct {
+	size  int
+	value uint64
+	ref   fbObject
+}
+
+func fbBool(v bool) *fbField {
+	if v {
+		return &fbField{size: 1, value: 1}
+	}
+	return &fbField{size: 1}
+}
+
+func fbUint8(v uint8) *fbField  { return &fbField{size: 1, value: uint64(v)} }
+func fbInt16(v int16) *fbField  { return &fbField{size: 2, value: uint64(uint16(v))} }
+func fbInt32(v int32) *fbField  { return &fbField{size: 4, value: uint64(uint32(v))} }
+func fbInt64(v int64) *fbField  { return &fbField{size: 8, value: uint64(v)} }
+func fbRef(o fbObject) *fbField { return &fbField{size: 4, ref: o} }
+
+// fbTable is a table whose fields are indexed by field id; nil fields are
+// absent and read as their default.
+type fbTable []*fbField
+
+func (t fbTable) write(w *fbWriter) int {
+	// The table starts with the offset to its vtable, followed by the
+	// fields, each aligned to its size.
+	offsets := make([]int, len(t))
+	size := 4
+	for i, f := range t {
+		if f == nil {
+			continue
+		}
+		size = (size + f.size - 1) / f.size * f.size
+		offsets[i] = size
+		size += f.size
+	}
+
+	w.align(2)
+	vtable := len(w.buf)
+	w.buf = binary.LittleEndian.AppendUint16(w.buf, uint16(4+2*len(t)))
+	w.buf = binary.LittleEndian.AppendUint16(w.buf, uint16(size))
+	for _, offset := range offsets {
+		w.buf = binary.LittleEndian.AppendUint16(w.buf, uint16(offset))
+	}
+
+	// Tables are 8-aligned, so field alignment within the table holds in
+	// the buffer
+	w.align(8)
+	pos := w.reserve(size)
+	binary.LittleEndian.PutUint32(w.buf[pos:], uint32(pos-vtable))
+	for i, f := range t {
+		if f == nil || f.ref != nil {
+			continue
+		}
+		slot := w.buf[pos+offsets[i]:]
+		switch f.size {
+		case 1:
+			slot[0] = byte(f.value)
+		case 2:
+			binary.LittleEndian.PutUint16(slot, uint16(f.value))
+		case 4:
+			binary.LittleEndian.PutUint32(slot, uint32(f.value))
+		case 8:
+			binary.LittleEndian.PutUint64(slot, f.value)
+		}
+	}
+	for i, f := range t {
+		if f != nil && f.ref != nil {
+			w.patch(pos+offsets[i], f.ref.write(w))
+		}
+	}
+	return pos
+}
+
+// fbVector is a vector of tables or strings.
+type fbVector []fbObject
+
+func (v fbVector) write(w *fbWriter) int {
+	w.align(4)
+	pos := w.reserve(4 + 4*len(v))
+	binary.LittleEndian.PutUint32(w.buf[pos:], uint32(len(v)))
+	for i, o := range v {
+		w.patch(pos+4+4*i, o.write(w))
+	}
+	return pos
+}
+
+// fbStructs is a vector of count structs whose fields are all 8 bytes wide,
+// encoded in data.
+type fbStructs struct {
+	count int
+	data  []byte
+}
+
+func (v fbStructs) write(w *fbWriter) int {
+	// The elements follow the 4-byte count and must be 8-aligned
+	w.align(4)
+	if len(w.buf)%8 == 0 {
+		w.reserve(4)
+	}
+	pos := w.reserve(4)
+	binary.LittleEndian.PutUint32(w.buf[pos:], uint32(v.count))
+	w.buf = append(w.buf, v.data...)
+	return pos
+}
+
+// fbString is a null-terminated UTF-8 string.
+type fbString string
+
+func (s fbString) write(w *fbWriter) int {
+	w.align(4)
+	pos := w.reserve(4)
+	binary.LittleEndian.PutUint32(w.buf[pos:], uint32(len(s)))
+	w.buf = append(w.buf, s...)
+	w.buf = append(w.buf, 0)
+	return pos
+}
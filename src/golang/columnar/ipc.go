@@ -0,0 +1,229 @@
+package columnar
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// Values of the Arrow format's FlatBuffers enums and unions (Schema.fbs and
+// Message.fbs).
+const (
+	metadataV5 = 4
+
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	typeNull          = 1
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeUtf8          = 5
+	typeBool          = 6
+	typeTimestamp     = 10
+
+	precisionDouble = 2
+	unitNanosecond  = 3
+)
+
+// continuation marks the start of each message of an IPC stream.
+const continuation = 0xFFFFFFFF
+
+// IPCWriter writes record batches in the Arrow IPC streaming format, read
+// by pyarrow.ipc.open_stream, polars.read_ipc_stream and other Arrow
+// implementations.
+type IPCWriter struct {
+	w       io.Writer
+	schema  *Schema
+	started bool
+	closed  bool
+}
+
+// NewIPCWriter returns a writer of record batches with schema to w.
+func NewIPCWriter(w io.Writer, schema *Schema) *IPCWriter {
+	return &IPCWriter{w: w, schema: schema}
+}
+
+// Write writes batch, preceded by the schema if it is the first.
+func (w *IPCWriter) Write(batch *RecordBatch) error {
+	if w.closed {
+		return errors.New("columnar: write to closed IPCWriter")
+	}
+	if !batch.Schema.equal(w.schema) {
+		return errors.New("columnar: record batch schema does not match the stream's")
+	}
+	if err := w.start(); err != nil {
+		return err
+	}
+	return w.writeBatch(batch)
+}
+
+// Close ends the stream, writing the schema first if no batch was written.
+// It does not close the underlying writer.
+func (w *IPCWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.start(); err != nil {
+		return err
+	}
+	w.closed = true
+	var eos [8]byte
+	binary.LittleEndian.PutUint32(eos[:], continuation)
+	_, err := w.w.Write(eos[:])
+	return err
+}
+
+// start writes the schema message once.
+func (w *IPCWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	fields := make(fbVector, len(w.schema.Fields))
+	for i, field := range w.schema.Fields {
+		fields[i] = fieldTable(field)
+	}
+	schema := fbTable{
+		nil, // endianness: little
+		fbRef(fields),
+	}
+	return w.writeMessage(headerSchema, schema, nil)
+}
+
+// fieldTable returns the Field table describing field.
+func fieldTable(field Field) fbTable {
+	var typeID uint8
+	var typ fbTable
+	switch field.Type {
+	case Null:
+		typeID, typ = typeNull, fbTable{}
+	case Bool:
+		typeID, typ = typeBool, fbTable{}
+	case Int64:
+		typeID, typ = typeInt, fbTable{fbInt32(64), fbBool(true)}
+	case Float64:
+		typeID, typ = typeFloatingPoint, fbTable{fbInt16(precisionDouble)}
+	case String:
+		typeID, typ = typeUtf8, fbTable{}
+	case Timestamp:
+		typeID, typ = typeTimestamp, fbTable{fbInt16(unitNanosecond), fbRef(fbString("UTC"))}
+	}
+	return fbTable{
+		fbRef(fbString(field.Name)),
+		fbBool(true), // nullable
+		fbUint8(typeID),
+		fbRef(typ),
+		nil,               // dictionary
+		fbRef(fbVector{}), // children
+	}
+}
+
+// writeBatch writes the RecordBatch message of batch. Its body holds each
+// column's buffers in schema order, 8-aligned: the validity bitmap, the
+// offsets of String columns, and the values. Null columns have none.
+func (w *IPCWriter) writeBatch(batch *RecordBatch) error {
+	var body, nodes, buffers []byte
+	addBuffer := func(data []byte) {
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(body)))
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(data)))
+		body = append(body, data...)
+		body = pad(body, 8)
+	}
+	for i, column := range batch.Columns {
+		if column.Len != batch.NumRows {
+			return fmt.Errorf("columnar: column %q has %d values, want %d",
+				batch.Schema.Fields[i].Name, column.Len, batch.NumRows)
+		}
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(column.Len))
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(column.NullCount))
+		if column.Type == Null {
+			continue
+		}
+		addBuffer(column.Validity)
+		if column.Type == String {
+			offsets := make([]byte, 0, 4*len(column.Offsets))
+			for _, offset := range column.Offsets {
+				offsets = binary.LittleEndian.AppendUint32(offsets, uint32(offset))
+			}
+			addBuffer(offsets)
+		}
+		addBuffer(column.Data)
+	}
+
+	header := fbTable{
+		fbInt64(int64(batch.NumRows)),
+		fbRef(fbStructs{count: len(batch.Columns), data: nodes}),
+		fbRef(fbStructs{count: len(buffers) / 16, data: buffers}),
+	}
+	return w.writeMessage(headerRecordBatch, header, body)
+}
+
+// writeMessage writes an encapsulated message: the continuation marker, the
+// length of the Message metadata, the metadata padded so the body starts
+// 8-aligned, and the body.
+func (w *IPCWriter) writeMessage(headerType uint8, header fbTable, body []byte) error {
+	metadata := fbFinish(fbTable{
+		fbInt16(metadataV5),
+		fbUint8(headerType),
+		fbRef(header),
+		fbInt64(int64(len(body))),
+	})
+	metadata = pad(metadata, 8)
+
+	prefix := make([]byte, 8, 8+len(metadata))
+	binary.LittleEndian.PutUint32(prefix, continuation)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(metadata)))
+	if _, err := w.w.Write(append(prefix, metadata...)); err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(body)
+	return err
+}
+
+// pad appends zero bytes to b up to a multiple of n.
+func pad(b []byte, n int) []byte {
+	for len(b)%n != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// EncodeIPC writes the rows of rs to w as an Arrow IPC stream, one record
+// batch per batch of rows, and returns the number of rows written:
+//
+//	stream, err := c.QueryStream(ctx, `SELECT * FROM BUNDLE "orders";`, 10000)
+//	...
+//	n, err := columnar.EncodeIPC(w, stream)
+//
+// The stream is complete even if rs had no rows. If the schema is neither
+// given with WithSchema nor inferable from rows, it has no fields.
+func EncodeIPC(w io.Writer, rs client.ResultSet, opts ...Option) (int, error) {
+	var writer *IPCWriter
+	written := 0
+	schema, err := readBatches(rs, opts, func(batch *RecordBatch) error {
+		if writer == nil {
+			writer = NewIPCWriter(w, batch.Schema)
+		}
+		if err := writer.Write(batch); err != nil {
+			return err
+		}
+		written += batch.NumRows
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+	if writer == nil {
+		if schema == nil {
+			schema = &Schema{}
+		}
+		writer = NewIPCWriter(w, schema)
+	}
+	return written, writer.Close()
+}
@@ -0,0 +1,361 @@
+package columnar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// fbReader reads a table of a FlatBuffer, resolving fields through its vtable.
+type fbReader struct {
+	t   *testing.T
+	buf []byte
+	pos int
+}
+
+func fbRoot(t *testing.T, buf []byte) fbReader {
+	return fbReader{t: t, buf: buf, pos: int(binary.LittleEndian.Uint32(buf))}
+}
+
+// field returns the position of field id, or 0 if it is absent.
+func (r fbReader) field(id int) int {
+	if r.pos%8 != 0 {
+		r.t.Errorf("table at %d is not 8-aligned", r.pos)
+	}
+	vtable := r.pos - int(int32(binary.LittleEndian.Uint32(r.buf[r.pos:])))
+	if 4+2*id >= int(binary.LittleEndian.Uint16(r.buf[vtable:])) {
+		return 0
+	}
+	offset := int(binary.LittleEndian.Uint16(r.buf[vtable+4+2*id:]))
+	if offset == 0 {
+		return 0
+	}
+	return r.pos + offset
+}
+
+func (r fbReader) scalar(id, size int) uint64 {
+	pos := r.field(id)
+	if pos == 0 {
+		return 0
+	}
+	if pos%size != 0 {
+		r.t.Errorf("field %d at %d is not %d-aligned", id, pos, size)
+	}
+	switch size {
+	case 1:
+		return uint64(r.buf[pos])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(r.buf[pos:]))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(r.buf[pos:]))
+	default:
+		return binary.LittleEndian.Uint64(r.buf[pos:])
+	}
+}
+
+// deref follows the offset of field id.
+func (r fbReader) deref(id int) int {
+	pos := r.field(id)
+	if pos == 0 {
+		r.t.Fatalf("field %d is absent", id)
+	}
+	return pos + int(binary.LittleEndian.Uint32(r.buf[pos:]))
+}
+
+func (r fbReader) table(id int) fbReader {
+	return fbReader{t: r.t, buf: r.buf, pos: r.deref(id)}
+}
+
+func (r fbReader) string(id int) string {
+	pos := r.deref(id)
+	n := int(binary.LittleEndian.Uint32(r.buf[pos:]))
+	if r.buf[pos+4+n] != 0 {
+		r.t.Errorf("string at %d is not null-terminated", pos)
+	}
+	return string(r.buf[pos+4 : pos+4+n])
+}
+
+// vector returns the position of the elements of vector field id and their count.
+func (r fbReader) vector(id int) (int, int) {
+	pos := r.deref(id)
+	return pos + 4, int(binary.LittleEndian.Uint32(r.buf[pos:]))
+}
+
+func (r fbReader) tables(id int) []fbReader {
+	start, n := r.vector(id)
+	tables := make([]fbReader, n)
+	for i := range tables {
+		slot := start + 4*i
+		tables[i] = fbReader{t: r.t, buf: r.buf, pos: slot + int(binary.LittleEndian.Uint32(r.buf[slot:]))}
+	}
+	return tables
+}
+
+// structs returns vector field id of structs of two int64s.
+func (r fbReader) structs(id int) [][2]int64 {
+	start, n := r.vector(id)
+	if start%8 != 0 {
+		r.t.Errorf("struct vector at %d is not 8-aligned", start)
+	}
+	values := make([][2]int64, n)
+	for i := range values {
+		values[i][0] = int64(binary.LittleEndian.Uint64(r.buf[start+16*i:]))
+		values[i][1] = int64(binary.LittleEndian.Uint64(r.buf[start+16*i+8:]))
+	}
+	return values
+}
+
+// ipcMessage is a message of an IPC stream.
+type ipcMessage struct {
+	header     fbReader
+	headerType uint8
+	body       []byte
+}
+
+// readIPCStream splits an IPC stream into its messages, checking the framing.
+func readIPCStream(t *testing.T, stream []byte) []ipcMessage {
+	t.Helper()
+	var messages []ipcMessage
+	for pos := 0; ; {
+		if pos%8 != 0 {
+			t.Fatalf("message at %d is not 8-aligned", pos)
+		}
+		if binary.LittleEndian.Uint32(stream[pos:]) != continuation {
+			t.Fatalf("no continuation marker at %d", pos)
+		}
+		length := int(binary.LittleEndian.Uint32(stream[pos+4:]))
+		pos += 8
+		if length == 0 {
+			if pos != len(stream) {
+				t.Fatalf("%d bytes after the end of stream", len(stream)-pos)
+			}
+			return messages
+		}
+		if length%8 != 0 {
+			t.Fatalf("metadata length %d is not a multiple of 8", length)
+		}
+		message := fbRoot(t, stream[pos:pos+length])
+		if version := message.scalar(0, 2); version != metadataV5 {
+			t.Errorf("version = %d, want V5", version)
+		}
+		pos += length
+		bodyLength := int(message.scalar(3, 8))
+		messages = append(messages, ipcMessage{
+			header:     message.table(2),
+			headerType: uint8(message.scalar(1, 1)),
+			body:       stream[pos : pos+bodyLength],
+		})
+		pos += bodyLength
+	}
+}
+
+// decodeBatch rebuilds the columns of a RecordBatch message from its buffers.
+func decodeBatch(t *testing.T, schema *Schema, message ipcMessage) *RecordBatch {
+	t.Helper()
+	if message.headerType != headerRecordBatch {
+		t.Fatalf("header type = %d, want RecordBatch", message.headerType)
+	}
+	nodes := message.header.structs(1)
+	buffers := message.header.structs(2)
+	batch := &RecordBatch{Schema: schema, NumRows: int(message.header.scalar(0, 8))}
+	next := func() []byte {
+		buffer := buffers[0]
+		buffers = buffers[1:]
+		if buffer[0]%8 != 0 {
+			t.Errorf("buffer at %d is not 8-aligned", buffer[0])
+		}
+		if buffer[1] == 0 {
+			return nil
+		}
+		return message.body[buffer[0] : buffer[0]+buffer[1]]
+	}
+	for i, field := range schema.Fields {
+		column := &Column{Type: field.Type, Len: int(nodes[i][0]), NullCount: int(nodes[i][1])}
+		if field.Type != Null {
+			column.Validity = next()
+			if field.Type == String {
+				raw := next()
+				for j := 0; j < len(raw); j += 4 {
+					column.Offsets = append(column.Offsets, int32(binary.LittleEndian.Uint32(raw[j:])))
+				}
+			}
+			column.Data = next()
+		}
+		batch.Columns = append(batch.Columns, column)
+	}
+	if len(buffers) != 0 {
+		t.Errorf("%d unread buffers", len(buffers))
+	}
+	return batch
+}
+
+func TestEncodeIPC(t *testing.T) {
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []interface{}{
+		map[string]interface{}{"active": true, "at": when, "id": 1, "name": "ann", "price": 9.5, "none": nil},
+		map[string]interface{}{"active": false, "id": 2, "name": "bo", "price": nil},
+		map[string]interface{}{"active": nil, "at": when.Add(time.Hour), "id": 3, "name": "", "price": 1.25},
+	}
+	var buf bytes.Buffer
+	n, err := EncodeIPC(&buf, client.NewRowStream(rows, 2))
+	if err != nil {
+		t.Fatalf("EncodeIPC: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("wrote %d rows, want 3", n)
+	}
+
+	messages := readIPCStream(t, buf.Bytes())
+	if len(messages) != 3 {
+		t.Fatalf("got %d messages, want a schema and 2 batches", len(messages))
+	}
+	if messages[0].headerType != headerSchema || len(messages[0].body) != 0 {
+		t.Fatalf("first message has header type %d and a %d-byte body", messages[0].headerType, len(messages[0].body))
+	}
+
+	type fieldType struct {
+		name     string
+		typeID   uint8
+		nullable bool
+	}
+	var fields []fieldType
+	schema := &Schema{}
+	for _, field := range messages[0].header.tables(1) {
+		name := field.string(0)
+		typeID := uint8(field.scalar(2, 1))
+		fields = append(fields, fieldType{name, typeID, field.scalar(1, 1) == 1})
+		if _, n := field.vector(5); n != 0 {
+			t.Errorf("%s has %d children", name, n)
+		}
+
+		typ := field.table(3)
+		var columnType Type
+		switch typeID {
+		case typeInt:
+			if typ.scalar(0, 4) != 64 || typ.scalar(1, 1) != 1 {
+				t.Errorf("%s is not a signed 64-bit int", name)
+			}
+			columnType = Int64
+		case typeFloatingPoint:
+			if typ.scalar(0, 2) != precisionDouble {
+				t.Errorf("%s is not a double", name)
+			}
+			columnType = Float64
+		case typeTimestamp:
+			if typ.scalar(0, 2) != unitNanosecond || typ.string(1) != "UTC" {
+				t.Errorf("%s is not a UTC nanosecond timestamp", name)
+			}
+			columnType = Timestamp
+		case typeBool:
+			columnType = Bool
+		case typeUtf8:
+			columnType = String
+		case typeNull:
+			columnType = Null
+		}
+		schema.Fields = append(schema.Fields, Field{Name: name, Type: columnType})
+	}
+	wantFields := []fieldType{
+		{"active", typeBool, true},
+		{"at", typeTimestamp, true},
+		{"id", typeInt, true},
+		{"name", typeUtf8, true},
+		{"none", typeNull, true},
+		{"price", typeFloatingPoint, true},
+	}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Fatalf("fields = %v, want %v", fields, wantFields)
+	}
+
+	var got []map[string]interface{}
+	for _, message := range messages[1:] {
+		batch := decodeBatch(t, schema, message)
+		for i := 0; i < batch.NumRows; i++ {
+			row := make(map[string]interface{})
+			for j, field := range schema.Fields {
+				if batch.Columns[j].Len != batch.NumRows {
+					t.Fatalf("%s has %d values in a batch of %d", field.Name, batch.Columns[j].Len, batch.NumRows)
+				}
+				row[field.Name] = batch.Columns[j].Value(i)
+			}
+			got = append(got, row)
+		}
+	}
+	want := []map[string]interface{}{
+		{"active": true, "at": when, "id": int64(1), "name": "ann", "none": nil, "price": 9.5},
+		{"active": false, "at": nil, "id": int64(2), "name": "bo", "none": nil, "price": nil},
+		{"active": nil, "at": when.Add(time.Hour), "id": int64(3), "name": "", "none": nil, "price": 1.25},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeIPCEmpty(t *testing.T) {
+	schema := &Schema{Fields: []Field{{Name: "id", Type: Int64}}}
+	var buf bytes.Buffer
+	n, err := EncodeIPC(&buf, client.NewRowStream(nil, 10), WithSchema(schema))
+	if err != nil || n != 0 {
+		t.Fatalf("EncodeIPC = %d, %v", n, err)
+	}
+	messages := readIPCStream(t, buf.Bytes())
+	if len(messages) != 1 || messages[0].headerType != headerSchema {
+		t.Fatalf("got %d messages, want only the schema", len(messages))
+	}
+	if fields := messages[0].header.tables(1); len(fields) != 1 || fields[0].string(0) != "id" {
+		t.Errorf("schema fields = %d", len(fields))
+	}
+
+	// Without a schema the stream is still complete
+	buf.Reset()
+	if _, err := EncodeIPC(&buf, client.NewRowStream(nil, 10)); err != nil {
+		t.Fatalf("EncodeIPC: %v", err)
+	}
+	if messages := readIPCStream(t, buf.Bytes()); len(messages) != 1 {
+		t.Errorf("got %d messages, want only the schema", len(messages))
+	}
+}
+
+func TestIPCWriterSchemaMismatch(t *testing.T) {
+	w := NewIPCWriter(&bytes.Buffer{}, &Schema{Fields: []Field{{Name: "id", Type: Int64}}})
+	batch := NewBatchBuilder(&Schema{Fields: []Field{{Name: "id", Type: Float64}}}).Build()
+	if err := w.Write(batch); err == nil {
+		t.Error("expected a batch with another schema to be rejected")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := w.Write(batch); err == nil {
+		t.Error("expected a write after Close to fail")
+	}
+}
+
+type failingResultSet struct {
+	rows []interface{}
+	err  error
+}
+
+func (rs *failingResultSet) NextBatch() []interface{} {
+	rows := rs.rows
+	rs.rows = nil
+	return rows
+}
+
+func (rs *failingResultSet) Err() error { return rs.err }
+
+func TestEncodeIPCResultSetError(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	rs := &failingResultSet{rows: []interface{}{map[string]interface{}{"id": 1}}, err: streamErr}
+	n, err := EncodeIPC(&bytes.Buffer{}, rs)
+	if !errors.Is(err, streamErr) {
+		t.Errorf("err = %v, want %v", err, streamErr)
+	}
+	if n != 1 {
+		t.Errorf("wrote %d rows, want 1", n)
+	}
+}
@@ -434,11 +434,11 @@ func generateUpCommands(schemaDef *schema.SchemaDefinition) []string {
 		cmd += ");"
 		commands = append(commands, cmd)
 
-		// CREATE INDEX commands
-		for _, idx := range bundle.Indexes {
-			idxCmd := fmt.Sprintf(`CREATE INDEX "%s" ON "%s" (%s) TYPE %s;`,
-				idx.Name, bundle.Name, idx.Fields[0], idx.Type)
-			commands = append(commands, idxCmd)
+		// CREATE INDEX commands (composite, unique and partial indexes included)
+		for i := range bundle.Indexes {
+			if idxCmd := schema.SerializeCreateIndex(&bundle.Indexes[i], bundle.Name); idxCmd != "" {
+				commands = append(commands, idxCmd)
+			}
 		}
 	}
 
@@ -24,7 +24,11 @@ type clientExecutorAdapter struct {
 func (a *clientExecutorAdapter) Execute(command string) (interface{}, error) {
 	// Determine if this is a query or mutation based on command type
 	// For simplicity, treat everything as a mutation since migrations typically modify schema
-	return a.client.Mutate(command, testTimeout)
+	result, err := a.client.Mutate(command, testTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return result.Raw, nil
 }
 
 // responseToJSON converts a response interface{} to JSON bytes for parsing
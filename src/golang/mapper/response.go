@@ -1,14 +1,25 @@
 package mapper
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // ResponseMapper handles type coercion for database responses.
 // Matches the Node.js implementation in ResponseMapper.ts.
-type ResponseMapper struct{}
+type ResponseMapper struct {
+	// Layouts are tried before the built-in datetime formats by ToDateTime.
+	Layouts []string
+
+	// Location is the time zone of datetime strings without an offset, and
+	// of the values ToDateTime returns. Default: UTC
+	Location *time.Location
+}
 
 // NewResponseMapper creates a new response mapper.
 func NewResponseMapper() *ResponseMapper {
@@ -28,6 +39,8 @@ func (m *ResponseMapper) MapResponse(response interface{}, targetType string) (i
 		return m.ToInt(response)
 	case "float":
 		return m.ToFloat(response)
+	case "decimal":
+		return m.ToDecimal(response)
 	case "boolean":
 		return m.ToBool(response)
 	case "datetime":
@@ -128,6 +141,46 @@ func (m *ResponseMapper) ToFloat(value interface{}) (float64, error) {
 	}
 }
 
+// ToDecimal converts a value to an exact *big.Rat. Strings and json.Number
+// values keep every digit; float64 values are read by their shortest
+// representation, so 0.1 becomes 1/10 rather than its binary approximation.
+func (m *ResponseMapper) ToDecimal(value interface{}) (*big.Rat, error) {
+	if value == nil {
+		return nil, fmt.Errorf("cannot convert nil to decimal")
+	}
+
+	var text string
+	switch v := value.(type) {
+	case *big.Rat:
+		return new(big.Rat).Set(v), nil
+	case string:
+		text = strings.TrimSpace(v)
+	case json.Number:
+		text = v.String()
+	case float32:
+		return m.ToDecimal(float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("cannot convert %v to decimal", v)
+		}
+		text = strconv.FormatFloat(v, 'g', -1, 64)
+	case int:
+		return new(big.Rat).SetInt64(int64(v)), nil
+	case int32:
+		return new(big.Rat).SetInt64(int64(v)), nil
+	case int64:
+		return new(big.Rat).SetInt64(v), nil
+	default:
+		return nil, fmt.Errorf("cannot convert %T to decimal", value)
+	}
+
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert '%s' to decimal", text)
+	}
+	return r, nil
+}
+
 // ToBool converts a value to a boolean.
 func (m *ResponseMapper) ToBool(value interface{}) (bool, error) {
 	if value == nil {
@@ -156,33 +209,38 @@ func (m *ResponseMapper) ToBool(value interface{}) (bool, error) {
 	}
 }
 
-// ToDateTime converts a value to a time.Time.
+// ToDateTime converts a value to a time.Time. Numbers are Unix timestamps in seconds.
 func (m *ResponseMapper) ToDateTime(value interface{}) (time.Time, error) {
 	if value == nil {
 		return time.Time{}, fmt.Errorf("cannot convert nil to datetime")
 	}
 
+	loc := m.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
 	switch v := value.(type) {
 	case time.Time:
 		return v, nil
 	case string:
-		// Try multiple datetime formats
-		formats := []string{
+		// Try configured layouts, then multiple datetime formats
+		formats := append(m.Layouts[:len(m.Layouts):len(m.Layouts)],
 			time.RFC3339,
 			time.RFC3339Nano,
 			"2006-01-02 15:04:05",
 			"2006-01-02T15:04:05",
 			"2006-01-02",
-		}
+		)
 
 		for _, format := range formats {
-			if t, err := time.Parse(format, v); err == nil {
-				return t, nil
+			if t, err := time.ParseInLocation(format, v, loc); err == nil {
+				return t.In(loc), nil
 			}
 		}
 
 		return time.Time{}, fmt.Errorf("cannot parse '%s' as datetime", v)
-	case int, int32, int64:
+	case int, int32, int64, float64:
 		// Assume Unix timestamp
 		var timestamp int64
 		switch tv := v.(type) {
@@ -192,8 +250,12 @@ func (m *ResponseMapper) ToDateTime(value interface{}) (time.Time, error) {
 			timestamp = int64(tv)
 		case int64:
 			timestamp = tv
+		case float64:
+			// JSON numbers decode as float64; keep sub-second precision
+			sec := int64(tv)
+			return time.Unix(sec, int64((tv-float64(sec))*1e9)).In(loc), nil
 		}
-		return time.Unix(timestamp, 0), nil
+		return time.Unix(timestamp, 0).In(loc), nil
 	default:
 		return time.Time{}, fmt.Errorf("cannot convert %T to datetime", value)
 	}
@@ -1,7 +1,9 @@
 package mapper
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestResponseMapper_ToString(t *testing.T) {
@@ -92,3 +94,70 @@ func TestResponseMapper_ToBool(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseMapper_ToDateTime(t *testing.T) {
+	loc := time.FixedZone("EST", -5*3600)
+	mapper := &ResponseMapper{Layouts: []string{"02.01.2006 15:04"}, Location: loc}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected time.Time
+		wantErr  bool
+	}{
+		{"rfc3339", "2026-03-01T12:00:00Z", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), false},
+		{"no offset in location", "2026-03-01 07:00:00", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), false},
+		{"custom layout", "01.03.2026 07:00", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), false},
+		{"unix int", int64(1772366400), time.Unix(1772366400, 0), false},
+		{"unix float", 1772366400.25, time.Unix(1772366400, 25e7), false},
+		{"invalid", "soon", time.Time{}, true},
+		{"nil", nil, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mapper.ToDateTime(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToDateTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("ToDateTime() = %v, want %v", got, tt.expected)
+			}
+			if err == nil && got.Location() != loc {
+				t.Errorf("ToDateTime() location = %v, want %v", got.Location(), loc)
+			}
+		})
+	}
+}
+
+func TestResponseMapper_ToDecimal(t *testing.T) {
+	mapper := NewResponseMapper()
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+		wantErr  bool
+	}{
+		{"string", "1234567890.123456789012345678", "617283945061728394506172839/500000000000000000", false},
+		{"json number", json.Number("19.99"), "1999/100", false},
+		{"float shortest form", 0.1, "1/10", false},
+		{"int", int64(42), "42/1", false},
+		{"invalid", "ten", "", true},
+		{"nil", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mapper.ToDecimal(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToDecimal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.expected {
+				t.Errorf("ToDecimal() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
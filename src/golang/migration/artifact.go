@@ -0,0 +1,143 @@
+package migration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// PlanArtifactVersion is the format version written by ExportPlan.
+const PlanArtifactVersion = 1
+
+// PlanArtifact is a resolved MigrationPlan exported from one environment,
+// such as staging, to be applied unchanged in another, such as production.
+type PlanArtifact struct {
+	// Version is the artifact format version.
+	Version int `json:"version"`
+
+	// CreatedAt is when the plan was exported.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Plan holds the migrations to apply, in order.
+	Plan *MigrationPlan `json:"plan"`
+
+	// Checksums maps each migration ID to its CalculateChecksum at export.
+	Checksums map[string]string `json:"checksums"`
+
+	// BaseSchema is the schema the plan was resolved against. The target
+	// environment must match it before the plan is applied.
+	BaseSchema *schema.SchemaDefinition `json:"baseSchema"`
+
+	// TargetSchema is the schema expected once the plan is applied, if known.
+	TargetSchema *schema.SchemaDefinition `json:"targetSchema,omitempty"`
+
+	// Signature is the hex HMAC-SHA256 of the artifact without its signature.
+	Signature string `json:"signature"`
+}
+
+// ExportPlan encodes plan as a signed PlanArtifact. base is the schema the
+// plan was resolved against and target, which may be nil, the schema it
+// produces. key signs the artifact and must be shared with ImportPlan.
+func ExportPlan(plan *MigrationPlan, base, target *schema.SchemaDefinition, key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("a signing key is required to export a plan")
+	}
+	if base == nil {
+		return nil, fmt.Errorf("a base schema is required to export a plan")
+	}
+
+	artifact := &PlanArtifact{
+		Version:      PlanArtifactVersion,
+		CreatedAt:    time.Now().UTC(),
+		Plan:         plan,
+		Checksums:    make(map[string]string, len(plan.Migrations)),
+		BaseSchema:   base,
+		TargetSchema: target,
+	}
+	for _, migration := range plan.Migrations {
+		artifact.Checksums[migration.ID] = CalculateChecksum(migration)
+	}
+
+	signature, err := artifact.sign(key)
+	if err != nil {
+		return nil, err
+	}
+	artifact.Signature = signature
+	return json.MarshalIndent(artifact, "", "  ")
+}
+
+// ImportPlan decodes a PlanArtifact written by ExportPlan, verifying its
+// signature with key and the checksum of every migration in it.
+func ImportPlan(data []byte, key []byte) (*PlanArtifact, error) {
+	var artifact PlanArtifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to decode plan artifact: %w", err)
+	}
+	if artifact.Version != PlanArtifactVersion {
+		return nil, fmt.Errorf("unsupported plan artifact version %d", artifact.Version)
+	}
+	if artifact.Plan == nil || artifact.BaseSchema == nil {
+		return nil, fmt.Errorf("plan artifact is missing its plan or base schema")
+	}
+
+	expected, err := artifact.sign(key)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(expected), []byte(artifact.Signature)) {
+		return nil, ErrInvalidPlanSignature()
+	}
+
+	for _, migration := range artifact.Plan.Migrations {
+		if actual := CalculateChecksum(migration); actual != artifact.Checksums[migration.ID] {
+			return nil, ErrChecksumMismatch(migration.ID, artifact.Checksums[migration.ID], actual)
+		}
+	}
+	return &artifact, nil
+}
+
+// CheckPreconditions returns an error describing the differences if live
+// no longer matches the schema the plan was resolved against.
+func (a *PlanArtifact) CheckPreconditions(live *schema.SchemaDefinition) error {
+	diff := schema.CompareSchemas(a.BaseSchema, live)
+	if !diff.HasChanges {
+		return nil
+	}
+
+	var changes []string
+	for _, change := range diff.BundleChanges {
+		switch change.Type {
+		case "create":
+			changes = append(changes, fmt.Sprintf("bundle %q is missing", change.BundleName))
+		case "delete":
+			changes = append(changes, fmt.Sprintf("bundle %q was added", change.BundleName))
+		default:
+			changes = append(changes, fmt.Sprintf("bundle %q was modified", change.BundleName))
+		}
+	}
+	for _, change := range diff.RelationshipChanges {
+		changes = append(changes, fmt.Sprintf("relationship changed on %q", change.BundleName))
+	}
+	return ErrPlanPreconditionFailed(changes)
+}
+
+// sign returns the hex HMAC-SHA256 of the artifact with its signature cleared.
+func (a *PlanArtifact) sign(key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", fmt.Errorf("a signing key is required")
+	}
+	unsigned := *a
+	unsigned.Signature = ""
+	payload, err := json.Marshal(&unsigned)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode plan artifact: %w", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
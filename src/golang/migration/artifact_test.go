@@ -0,0 +1,105 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+func testPlanSchema() *schema.SchemaDefinition {
+	return &schema.SchemaDefinition{Bundles: []schema.BundleDefinition{
+		{Name: "users", Fields: []schema.FieldDefinition{{Name: "email", Type: schema.STRING, Required: true}}},
+	}}
+}
+
+func testPlan(t *testing.T) *MigrationPlan {
+	t.Helper()
+	plan, err := NewClient(nil).Plan([]*Migration{{
+		ID:        "002_add_age",
+		Name:      "Add age",
+		Up:        []string{`UPDATE BUNDLE "users" SET ({ADD "age" = "age", "INT", FALSE, FALSE, 0});`},
+		Down:      []string{`UPDATE BUNDLE "users" SET ({REMOVE "age" = "", "", FALSE, FALSE, NULL});`},
+		Timestamp: time.Date(2025, 6, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600)),
+	}})
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	return plan
+}
+
+func TestExportImportPlan(t *testing.T) {
+	key := []byte("staging-to-production")
+	data, err := ExportPlan(testPlan(t), testPlanSchema(), nil, key)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	artifact, err := ImportPlan(data, key)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if len(artifact.Plan.Migrations) != 1 || artifact.Plan.Migrations[0].ID != "002_add_age" {
+		t.Errorf("unexpected plan: %+v", artifact.Plan)
+	}
+	if artifact.Checksums["002_add_age"] != CalculateChecksum(artifact.Plan.Migrations[0]) {
+		t.Errorf("expected the migration checksum to be recorded, got %v", artifact.Checksums)
+	}
+
+	if _, err := ImportPlan(data, []byte("other-key")); !hasMigrationErrorCode(err, "INVALID_PLAN_SIGNATURE") {
+		t.Errorf("expected INVALID_PLAN_SIGNATURE for the wrong key, got %v", err)
+	}
+	tampered := bytes.Replace(data, []byte(`\"INT\"`), []byte(`\"STRING\"`), 1)
+	if _, err := ImportPlan(tampered, key); !hasMigrationErrorCode(err, "INVALID_PLAN_SIGNATURE") {
+		t.Errorf("expected INVALID_PLAN_SIGNATURE for a modified plan, got %v", err)
+	}
+
+	if _, err := ExportPlan(testPlan(t), testPlanSchema(), nil, nil); err == nil {
+		t.Error("expected export without a key to fail")
+	}
+}
+
+func TestImportPlan_ChecksumMismatch(t *testing.T) {
+	key := []byte("k")
+	plan := testPlan(t)
+	artifact := &PlanArtifact{
+		Version:    PlanArtifactVersion,
+		Plan:       plan,
+		Checksums:  map[string]string{"002_add_age": "stale"},
+		BaseSchema: testPlanSchema(),
+	}
+	artifact.Signature, _ = artifact.sign(key)
+	data, _ := json.Marshal(artifact)
+
+	if _, err := ImportPlan(data, key); !hasMigrationErrorCode(err, "CHECKSUM_MISMATCH") {
+		t.Errorf("expected CHECKSUM_MISMATCH, got %v", err)
+	}
+}
+
+func TestPlanArtifact_CheckPreconditions(t *testing.T) {
+	artifact := &PlanArtifact{BaseSchema: testPlanSchema()}
+	if err := artifact.CheckPreconditions(testPlanSchema()); err != nil {
+		t.Fatalf("expected matching schema to pass, got %v", err)
+	}
+
+	live := testPlanSchema()
+	live.Bundles[0].Fields = append(live.Bundles[0].Fields, schema.FieldDefinition{Name: "age", Type: schema.INT})
+	live.Bundles = append(live.Bundles, schema.BundleDefinition{Name: "audit"})
+
+	err := artifact.CheckPreconditions(live)
+	if !hasMigrationErrorCode(err, "PLAN_PRECONDITION_FAILED") {
+		t.Fatalf("expected PLAN_PRECONDITION_FAILED, got %v", err)
+	}
+	changes := strings.Join(err.(*MigrationError).Details["changes"].([]string), "\n")
+	if !strings.Contains(changes, `bundle "users" was modified`) || !strings.Contains(changes, `bundle "audit" was added`) {
+		t.Errorf("unexpected changes: %s", changes)
+	}
+}
+
+func hasMigrationErrorCode(err error, code string) bool {
+	migErr, ok := err.(*MigrationError)
+	return ok && migErr.Code == code
+}
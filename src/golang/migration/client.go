@@ -14,6 +14,8 @@ type Client struct {
 	executor  MigrationExecutor
 	generator *RollbackGenerator
 	lock      *MigrationLock
+	scripts   map[string]ScriptFunc
+	progress  ProgressFunc
 }
 
 // MigrationExecutor defines the interface for executing migration commands.
@@ -62,9 +64,10 @@ func (c *Client) Plan(migrations []*Migration) (*MigrationPlan, error) {
 	}
 
 	return &MigrationPlan{
-		Migrations: pending,
-		Direction:  Up,
-		TotalCount: len(pending),
+		Migrations:  pending,
+		Direction:   Up,
+		TotalCount:  len(pending),
+		Destructive: validation.Destructive,
 	}, nil
 }
 
@@ -81,6 +84,24 @@ func (c *Client) Apply(plan *MigrationPlan) error {
 		return nil
 	}
 
+	// Re-check the migrations, which may have been trimmed since planning
+	if !plan.AllowDestructive {
+		var destructive []DestructiveChange
+		for _, migration := range plan.Migrations {
+			destructive = append(destructive, DetectDestructive(migration)...)
+		}
+		if len(destructive) > 0 {
+			return ErrDestructiveMigration(destructive)
+		}
+	}
+
+	// Check every script is registered before anything runs
+	for _, migration := range plan.Migrations {
+		if err := c.checkScripts(migration.ID, migration.Up); err != nil {
+			return err
+		}
+	}
+
 	// Acquire lock if configured
 	if c.lock != nil {
 		if err := c.lock.AcquireLock(); err != nil {
@@ -93,8 +114,9 @@ func (c *Client) Apply(plan *MigrationPlan) error {
 		}()
 	}
 
-	for _, migration := range plan.Migrations {
-		if err := c.applyMigration(migration); err != nil {
+	progress := c.newProgressTracker(plan)
+	for i, migration := range plan.Migrations {
+		if err := c.applyMigration(migration, i, progress); err != nil {
 			return err
 		}
 	}
@@ -102,17 +124,23 @@ func (c *Client) Apply(plan *MigrationPlan) error {
 	return nil
 }
 
-// applyMigration executes a single migration's "up" commands.
-func (c *Client) applyMigration(migration *Migration) error {
+// applyMigration executes a single migration's "up" commands, reporting
+// progress for the migration at index in the plan.
+func (c *Client) applyMigration(migration *Migration, index int, progress *progressTracker) error {
 	startTime := time.Now()
 	checksum := CalculateChecksum(migration)
+	progress.migrationStarted(index, migration)
 
 	// Execute each command in sequence
 	for i, command := range migration.Up {
-		if _, err := c.executor.Execute(command); err != nil {
+		commandStart := time.Now()
+		err := c.execute(command)
+		progress.commandCompleted(index, migration, i, time.Since(commandStart), err)
+		if err != nil {
 			// Record failure
 			executionTime := time.Since(startTime).Milliseconds()
 			c.history.RecordMigration(migration.ID, Failed, executionTime, checksum, err)
+			progress.migrationCompleted(index, migration, time.Since(startTime), err)
 			return ErrMigrationFailed(migration.ID, fmt.Errorf("command %d failed: %w", i+1, err))
 		}
 	}
@@ -120,6 +148,7 @@ func (c *Client) applyMigration(migration *Migration) error {
 	// Record success
 	executionTime := time.Since(startTime).Milliseconds()
 	c.history.RecordMigration(migration.ID, Applied, executionTime, checksum, nil)
+	progress.migrationCompleted(index, migration, time.Since(startTime), nil)
 
 	return nil
 }
@@ -158,8 +187,11 @@ func (c *Client) Rollback(migrationID string, allMigrations []*Migration) error
 	}
 
 	// Execute rollback commands
+	if err := c.checkScripts(migrationID, migration.Down); err != nil {
+		return err
+	}
 	for i, command := range migration.Down {
-		if _, err := c.executor.Execute(command); err != nil {
+		if err := c.execute(command); err != nil {
 			return ErrMigrationFailed(migrationID, fmt.Errorf("rollback command %d failed: %w", i+1, err))
 		}
 	}
@@ -301,6 +333,11 @@ func FormatPreview(plan *MigrationPlan) string {
 		sb.WriteString("\n  Up Commands:\n")
 		for j, cmd := range migration.Up {
 			sb.WriteString(fmt.Sprintf("    %d. %s\n", j+1, cmd))
+			for _, change := range plan.Destructive {
+				if change.MigrationID == migration.ID && change.CommandIndex == j {
+					sb.WriteString(fmt.Sprintf("       DESTRUCTIVE: %s\n", change.Reason))
+				}
+			}
 		}
 
 		if len(migration.Down) > 0 {
@@ -0,0 +1,124 @@
+package migration
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DestructiveChange describes an Up command that can lose data when applied.
+type DestructiveChange struct {
+	// MigrationID is the migration containing the command.
+	MigrationID string `json:"migrationId"`
+
+	// CommandIndex is the zero-based index of the command in Up.
+	CommandIndex int `json:"commandIndex"`
+
+	// Command is the destructive command.
+	Command string `json:"command"`
+
+	// Reason describes the data that would be lost.
+	Reason string `json:"reason"`
+}
+
+var (
+	dropBundlePattern   = regexp.MustCompile(`(?i)^DROP\s+BUNDLE\s+["'` + "`" + `]?([^"'` + "`" + `;\s]+)`)
+	dropFieldPattern    = regexp.MustCompile(`(?i)DROP\s+FIELD\s+["'` + "`" + `]?([^"'` + "`" + `;\s]+)`)
+	updateBundlePattern = regexp.MustCompile(`(?i)^UPDATE\s+BUNDLE\s+["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `]`)
+	fieldOpPattern      = regexp.MustCompile(`(?i)\{\s*(REMOVE|MODIFY)\s+"([^"]+)"\s*=\s*"[^"]*"\s*,\s*"([^"]*)"`)
+)
+
+// safeTypeChanges lists field type changes that keep every existing value.
+var safeTypeChanges = map[string][]string{
+	"INT":     {"FLOAT", "DECIMAL", "STRING", "TEXT"},
+	"FLOAT":   {"STRING", "TEXT"},
+	"DECIMAL": {"STRING", "TEXT"},
+	"BOOLEAN": {"STRING", "TEXT"},
+	"STRING":  {"TEXT"},
+}
+
+// DetectDestructive returns the Up commands of migration that drop bundles
+// or fields, or change a field's type in a way that can truncate values.
+// The old type of a modified field is taken from the matching MODIFY in
+// Down; a MODIFY whose old type is unknown is reported as destructive.
+func DetectDestructive(migration *Migration) []DestructiveChange {
+	oldTypes := modifiedFieldTypes(migration.Down)
+
+	var changes []DestructiveChange
+	for i, command := range migration.Up {
+		for _, reason := range destructiveReasons(command, oldTypes) {
+			changes = append(changes, DestructiveChange{
+				MigrationID:  migration.ID,
+				CommandIndex: i,
+				Command:      command,
+				Reason:       reason,
+			})
+		}
+	}
+	return changes
+}
+
+// destructiveReasons describes each way command can lose data.
+func destructiveReasons(command string, oldTypes map[string]string) []string {
+	normalized := strings.TrimSpace(command)
+
+	if m := dropBundlePattern.FindStringSubmatch(normalized); m != nil {
+		return []string{fmt.Sprintf("drops bundle %q and its documents", m[1])}
+	}
+
+	var reasons []string
+	bundle := ""
+	if m := updateBundlePattern.FindStringSubmatch(normalized); m != nil {
+		bundle = m[1]
+	}
+	for _, m := range dropFieldPattern.FindAllStringSubmatch(normalized, -1) {
+		reasons = append(reasons, fmt.Sprintf("drops field %q", m[1]))
+	}
+	if bundle == "" {
+		return reasons
+	}
+
+	for _, m := range fieldOpPattern.FindAllStringSubmatch(normalized, -1) {
+		op, field, newType := strings.ToUpper(m[1]), m[2], strings.ToUpper(m[3])
+		if op == "REMOVE" {
+			reasons = append(reasons, fmt.Sprintf("removes field %q from %q", field, bundle))
+			continue
+		}
+
+		oldType, known := oldTypes[bundle+"."+field]
+		switch {
+		case !known:
+			reasons = append(reasons, fmt.Sprintf("modifies field %q in %q and may change its type to %s", field, bundle, newType))
+		case oldType != newType && !isSafeTypeChange(oldType, newType):
+			reasons = append(reasons, fmt.Sprintf("changes field %q in %q from %s to %s", field, bundle, oldType, newType))
+		}
+	}
+	return reasons
+}
+
+// modifiedFieldTypes maps "bundle.field" to the type set by each MODIFY in commands.
+func modifiedFieldTypes(commands []string) map[string]string {
+	types := make(map[string]string)
+	for _, command := range commands {
+		m := updateBundlePattern.FindStringSubmatch(strings.TrimSpace(command))
+		if m == nil {
+			continue
+		}
+		for _, op := range fieldOpPattern.FindAllStringSubmatch(command, -1) {
+			if strings.EqualFold(op[1], "MODIFY") {
+				types[m[1]+"."+op[2]] = strings.ToUpper(op[3])
+			}
+		}
+	}
+	return types
+}
+
+// isSafeTypeChange reports whether converting from one field type to another keeps every value.
+func isSafeTypeChange(from, to string) bool {
+	for _, safe := range safeTypeChanges[from] {
+		if safe == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectDestructive(t *testing.T) {
+	migration := &Migration{
+		ID: "002_cleanup",
+		Up: []string{
+			`CREATE BUNDLE "logs" WITH FIELDS ({"msg", "STRING", FALSE, FALSE, NULL});`,
+			`DROP BUNDLE "sessions";`,
+			"UPDATE BUNDLE \"users\"\nSET (\n" +
+				`    {REMOVE "nickname" = "", "", FALSE, FALSE, NULL},` + "\n" +
+				`    {MODIFY "age" = "age", "FLOAT", FALSE, FALSE, NULL},` + "\n" +
+				`    {MODIFY "bio" = "bio", "INT", FALSE, FALSE, NULL}` + "\n);",
+		},
+		Down: []string{
+			"UPDATE BUNDLE \"users\"\nSET (\n" +
+				`    {MODIFY "age" = "age", "INT", FALSE, FALSE, NULL},` + "\n" +
+				`    {MODIFY "bio" = "bio", "STRING", FALSE, FALSE, NULL}` + "\n);",
+		},
+	}
+
+	changes := DetectDestructive(migration)
+	var reasons []string
+	for _, change := range changes {
+		if change.MigrationID != "002_cleanup" || change.CommandIndex == 0 {
+			t.Errorf("unexpected change location: %+v", change)
+		}
+		reasons = append(reasons, change.Reason)
+	}
+
+	// INT to FLOAT keeps every value, STRING to INT does not
+	expected := []string{
+		`drops bundle "sessions" and its documents`,
+		`removes field "nickname" from "users"`,
+		`changes field "bio" in "users" from STRING to INT`,
+	}
+	if strings.Join(reasons, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected %q, got %q", expected, reasons)
+	}
+
+	// Without Down the old type is unknown
+	migration.Down = nil
+	if changes := DetectDestructive(migration); len(changes) != 4 {
+		t.Errorf("expected unknown type changes to be destructive, got %+v", changes)
+	}
+}
+
+func TestApply_RequiresAllowDestructive(t *testing.T) {
+	executor := &recordingExecutor{}
+	c := NewClient(executor)
+	migrations := []*Migration{
+		{ID: "001_drop", Up: []string{`DROP BUNDLE "sessions";`}},
+	}
+
+	validation := c.Validate(migrations)
+	if !validation.Valid || len(validation.Destructive) != 1 {
+		t.Fatalf("expected a valid result with 1 destructive change, got %+v", validation)
+	}
+
+	plan, err := c.Plan(migrations)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if len(plan.Destructive) != 1 {
+		t.Fatalf("expected plan to list the destructive change, got %+v", plan.Destructive)
+	}
+	if preview := FormatPreview(plan); !strings.Contains(preview, `DESTRUCTIVE: drops bundle "sessions"`) {
+		t.Errorf("expected DESTRUCTIVE marker in preview:\n%s", preview)
+	}
+
+	err = c.Apply(plan)
+	if migErr, ok := err.(*MigrationError); !ok || migErr.Code != "DESTRUCTIVE_MIGRATION" {
+		t.Fatalf("expected DESTRUCTIVE_MIGRATION, got %v", err)
+	}
+	if len(executor.commands) != 0 {
+		t.Fatalf("expected nothing to run, got %v", executor.commands)
+	}
+
+	plan.AllowDestructive = true
+	if err := c.Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if len(executor.commands) != 1 {
+		t.Errorf("expected DROP BUNDLE to run, got %v", executor.commands)
+	}
+}
+
+type recordingExecutor struct {
+	commands []string
+}
+
+func (e *recordingExecutor) Execute(command string) (interface{}, error) {
+	e.commands = append(e.commands, command)
+	return nil, nil
+}
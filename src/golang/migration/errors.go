@@ -118,6 +118,41 @@ func ErrRollbackNotSupported(migrationID string) error {
 	}
 }
 
+// ErrScriptNotFound creates an error for a script step whose script is not registered.
+func ErrScriptNotFound(migrationID, script string) error {
+	return &MigrationError{
+		Code:    "SCRIPT_NOT_FOUND",
+		Type:    "MIGRATION_ERROR",
+		Message: fmt.Sprintf("migration '%s' runs script '%s', which is not registered", migrationID, script),
+		Details: map[string]interface{}{
+			"migrationId": migrationID,
+			"script":      script,
+		},
+	}
+}
+
+// ErrInvalidPlanSignature creates an error for a plan artifact whose signature does not match.
+func ErrInvalidPlanSignature() error {
+	return &MigrationError{
+		Code:    "INVALID_PLAN_SIGNATURE",
+		Type:    "MIGRATION_ERROR",
+		Message: "plan artifact signature is invalid: it was modified or signed with a different key",
+		Details: map[string]interface{}{},
+	}
+}
+
+// ErrPlanPreconditionFailed creates an error for a schema that no longer matches a plan's base schema.
+func ErrPlanPreconditionFailed(changes []string) error {
+	return &MigrationError{
+		Code:    "PLAN_PRECONDITION_FAILED",
+		Type:    "MIGRATION_ERROR",
+		Message: fmt.Sprintf("schema differs from the plan's base schema in %d place(s)", len(changes)),
+		Details: map[string]interface{}{
+			"changes": changes,
+		},
+	}
+}
+
 // ErrMigrationConflict creates an error for when validation detects conflicts.
 func ErrMigrationConflict(conflicts []MigrationConflict) error {
 	conflictDetails := make([]map[string]interface{}, len(conflicts))
@@ -141,3 +176,26 @@ func ErrMigrationConflict(conflicts []MigrationConflict) error {
 		},
 	}
 }
+
+// ErrDestructiveMigration creates an error for applying destructive commands
+// without MigrationPlan.AllowDestructive.
+func ErrDestructiveMigration(changes []DestructiveChange) error {
+	changeDetails := make([]map[string]interface{}, len(changes))
+	for i, c := range changes {
+		changeDetails[i] = map[string]interface{}{
+			"migrationId":  c.MigrationID,
+			"commandIndex": c.CommandIndex,
+			"reason":       c.Reason,
+		}
+	}
+
+	return &MigrationError{
+		Code:    "DESTRUCTIVE_MIGRATION",
+		Type:    "MIGRATION_ERROR",
+		Message: fmt.Sprintf("plan contains %d destructive change(s); set AllowDestructive to apply it", len(changes)),
+		Details: map[string]interface{}{
+			"changes": changeDetails,
+			"count":   len(changes),
+		},
+	}
+}
@@ -22,7 +22,7 @@ func WriteMigrationFile(migration *Migration, dir string) (string, error) {
 	if migration == nil {
 		return "", fmt.Errorf("migration cannot be nil")
 	}
-	
+
 	if dir == "" {
 		return "", fmt.Errorf("directory path cannot be empty")
 	}
@@ -32,23 +32,11 @@ func WriteMigrationFile(migration *Migration, dir string) (string, error) {
 		return "", fmt.Errorf("failed to initialize directory: %w", err)
 	}
 
-	// Generate filename from timestamp and migration ID
-	timestamp := migration.Timestamp.Format("20060102150405")
-	// Sanitize migration ID for filename (replace non-alphanumeric with underscore)
-	sanitized := regexp.MustCompile(`[^a-zA-Z0-9_]+`).ReplaceAllString(migration.ID, "_")
-	filename := fmt.Sprintf("%s_%s.json", timestamp, sanitized)
-	filePath := filepath.Join(dir, filename)
-
-	// Create file structure with format version
-	fileData := MigrationFile{
-		FormatVersion: "1.0",
-		Migration:     migration,
-	}
+	filePath := filepath.Join(dir, MigrationFileName(migration))
 
-	// Marshal to JSON with indentation for readability
-	data, err := json.MarshalIndent(fileData, "", "  ")
+	data, err := EncodeMigrationFile(migration)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal migration: %w", err)
+		return "", err
 	}
 
 	// Write file with 0644 permissions
@@ -71,6 +59,42 @@ func ReadMigrationFile(path string) (*Migration, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return DecodeMigrationFile(data)
+}
+
+// MigrationFileName returns the timestamped file name used when writing a migration.
+// Format: YYYYMMDDHHMMSS_<sanitized id>.json
+func MigrationFileName(migration *Migration) string {
+	timestamp := migration.Timestamp.Format("20060102150405")
+	// Sanitize migration ID for filename (replace non-alphanumeric with underscore)
+	sanitized := regexp.MustCompile(`[^a-zA-Z0-9_]+`).ReplaceAllString(migration.ID, "_")
+	return fmt.Sprintf("%s_%s.json", timestamp, sanitized)
+}
+
+// EncodeMigrationFile serializes a migration into the on-disk file format.
+// Used by WriteMigrationFile and by environments without direct filesystem access (WASM).
+func EncodeMigrationFile(migration *Migration) ([]byte, error) {
+	if migration == nil {
+		return nil, fmt.Errorf("migration cannot be nil")
+	}
+
+	// Create file structure with format version
+	fileData := MigrationFile{
+		FormatVersion: "1.0",
+		Migration:     migration,
+	}
+
+	// Marshal to JSON with indentation for readability
+	data, err := json.MarshalIndent(fileData, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal migration: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodeMigrationFile parses and validates the contents of a migration file.
+func DecodeMigrationFile(data []byte) (*Migration, error) {
 	// Try to unmarshal as MigrationFile (with FormatVersion)
 	var fileData MigrationFile
 	if err := json.Unmarshal(data, &fileData); err != nil {
@@ -140,3 +140,36 @@ func TestInitMigrationDirectory(t *testing.T) {
 		t.Errorf("Expected permissions %s, got %s", expectedMode, info.Mode().Perm())
 	}
 }
+
+// TestEncodeDecodeMigrationFile tests the in-memory file format used without filesystem access
+func TestEncodeDecodeMigrationFile(t *testing.T) {
+	migration := &Migration{
+		ID:        "add-orders.v2",
+		Name:      "Add orders",
+		Timestamp: time.Date(2024, 2, 1, 8, 15, 30, 0, time.UTC),
+		Up:        []string{`CREATE BUNDLE "orders" WITH FIELDS ({"id", "int", TRUE, TRUE, 0})`},
+		Down:      []string{`DROP BUNDLE "orders";`},
+	}
+
+	if name := MigrationFileName(migration); name != "20240201081530_add_orders_v2.json" {
+		t.Errorf("Unexpected file name: %s", name)
+	}
+
+	data, err := EncodeMigrationFile(migration)
+	if err != nil {
+		t.Fatalf("EncodeMigrationFile failed: %v", err)
+	}
+
+	decoded, err := DecodeMigrationFile(data)
+	if err != nil {
+		t.Fatalf("DecodeMigrationFile failed: %v", err)
+	}
+
+	if decoded.ID != migration.ID || len(decoded.Down) != 1 {
+		t.Errorf("Round trip mismatch: got %+v", decoded)
+	}
+
+	if _, err := DecodeMigrationFile([]byte(`{"formatVersion": "2.0", "migration": {}}`)); err == nil {
+		t.Error("Expected error for unsupported format version")
+	}
+}
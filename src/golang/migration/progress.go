@@ -0,0 +1,138 @@
+package migration
+
+import "time"
+
+// ProgressStage identifies the point in Apply a ProgressEvent reports.
+type ProgressStage string
+
+const (
+	// MigrationStarted is reported before a migration's first command.
+	MigrationStarted ProgressStage = "migration_started"
+	// CommandCompleted is reported after each command, successful or not.
+	CommandCompleted ProgressStage = "command_completed"
+	// MigrationCompleted is reported after a migration's last command, or
+	// after the command that failed it.
+	MigrationCompleted ProgressStage = "migration_completed"
+)
+
+// ProgressEvent reports the progress of Apply.
+type ProgressEvent struct {
+	Stage ProgressStage
+
+	// MigrationID is the migration being applied; MigrationIndex is its
+	// zero-based position among MigrationCount migrations in the plan.
+	MigrationID    string
+	MigrationIndex int
+	MigrationCount int
+
+	// Command and CommandIndex identify the command for CommandCompleted,
+	// among CommandCount commands in the migration.
+	Command      string
+	CommandIndex int
+	CommandCount int
+
+	// CompletedCommands and TotalCommands count commands across the plan.
+	CompletedCommands int
+	TotalCommands     int
+
+	// Duration is how long the command (for CommandCompleted) or migration
+	// (for MigrationCompleted) took; Elapsed is the time since Apply started.
+	Duration time.Duration
+	Elapsed  time.Duration
+
+	// Err is the error that failed the command or migration, if any.
+	Err error
+}
+
+// ProgressFunc receives progress events. It is called synchronously from
+// Apply, so it should return quickly.
+type ProgressFunc func(event ProgressEvent)
+
+// OnProgress registers fn to receive progress events from Apply, replacing
+// any previous callback. Pass nil to stop reporting.
+func (c *Client) OnProgress(fn ProgressFunc) {
+	c.progress = fn
+}
+
+// Fraction returns the share of the plan's commands completed, from 0 to 1.
+func (e ProgressEvent) Fraction() float64 {
+	if e.TotalCommands == 0 {
+		return 1
+	}
+	return float64(e.CompletedCommands) / float64(e.TotalCommands)
+}
+
+// Remaining estimates the time left from the average command duration so
+// far. It returns 0 before the first command completes.
+func (e ProgressEvent) Remaining() time.Duration {
+	if e.CompletedCommands == 0 {
+		return 0
+	}
+	perCommand := e.Elapsed / time.Duration(e.CompletedCommands)
+	return perCommand * time.Duration(e.TotalCommands-e.CompletedCommands)
+}
+
+// progressTracker builds the progress events of one Apply call.
+type progressTracker struct {
+	report         ProgressFunc
+	start          time.Time
+	migrationCount int
+	total          int
+	completed      int
+}
+
+// newProgressTracker returns a tracker for plan, or nil if no callback is registered.
+func (c *Client) newProgressTracker(plan *MigrationPlan) *progressTracker {
+	if c.progress == nil {
+		return nil
+	}
+	t := &progressTracker{report: c.progress, start: time.Now(), migrationCount: len(plan.Migrations)}
+	for _, migration := range plan.Migrations {
+		t.total += len(migration.Up)
+	}
+	return t
+}
+
+// event returns an event for migration filled with the plan-wide counters.
+func (t *progressTracker) event(stage ProgressStage, index int, migration *Migration) ProgressEvent {
+	return ProgressEvent{
+		Stage:             stage,
+		MigrationID:       migration.ID,
+		MigrationIndex:    index,
+		MigrationCount:    t.migrationCount,
+		CommandCount:      len(migration.Up),
+		CompletedCommands: t.completed,
+		TotalCommands:     t.total,
+		Elapsed:           time.Since(t.start),
+	}
+}
+
+func (t *progressTracker) migrationStarted(index int, migration *Migration) {
+	if t == nil {
+		return
+	}
+	t.report(t.event(MigrationStarted, index, migration))
+}
+
+func (t *progressTracker) commandCompleted(index int, migration *Migration, commandIndex int, duration time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	t.completed++
+	event := t.event(CommandCompleted, index, migration)
+	event.Command = migration.Up[commandIndex]
+	event.CommandIndex = commandIndex
+	event.Duration = duration
+	event.Err = err
+	t.report(event)
+}
+
+func (t *progressTracker) migrationCompleted(index int, migration *Migration, duration time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	event := t.event(MigrationCompleted, index, migration)
+	event.Duration = duration
+	event.Err = err
+	t.report(event)
+}
@@ -0,0 +1,74 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOnProgress(t *testing.T) {
+	c := NewClient(&recordingExecutor{})
+	var events []ProgressEvent
+	c.OnProgress(func(event ProgressEvent) {
+		events = append(events, event)
+	})
+
+	plan, _ := c.Plan([]*Migration{
+		{ID: "001", Up: []string{`CREATE BUNDLE "a" WITH FIELDS ();`, `CREATE BUNDLE "b" WITH FIELDS ();`}},
+		{ID: "002", Up: []string{`CREATE BUNDLE "c" WITH FIELDS ();`}},
+	})
+	if err := c.Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	stages := []ProgressStage{
+		MigrationStarted, CommandCompleted, CommandCompleted, MigrationCompleted,
+		MigrationStarted, CommandCompleted, MigrationCompleted,
+	}
+	if len(events) != len(stages) {
+		t.Fatalf("expected %d events, got %+v", len(stages), events)
+	}
+	for i, stage := range stages {
+		if events[i].Stage != stage || events[i].TotalCommands != 3 || events[i].MigrationCount != 2 {
+			t.Errorf("event %d: unexpected %+v", i, events[i])
+		}
+	}
+
+	second := events[2]
+	if second.MigrationID != "001" || second.CommandIndex != 1 || second.CommandCount != 2 ||
+		second.CompletedCommands != 2 || second.Command != `CREATE BUNDLE "b" WITH FIELDS ();` {
+		t.Errorf("unexpected command event: %+v", second)
+	}
+	if last := events[len(events)-1]; last.MigrationIndex != 1 || last.Fraction() != 1 || last.Remaining() != 0 {
+		t.Errorf("expected the plan to be complete, got %+v", last)
+	}
+}
+
+func TestOnProgress_Failure(t *testing.T) {
+	c := NewClient(&recordingExecutor{})
+	cause := errors.New("boom")
+	c.RegisterScript("fail", func(exec MigrationExecutor) error { return cause })
+	var events []ProgressEvent
+	c.OnProgress(func(event ProgressEvent) { events = append(events, event) })
+
+	plan, _ := c.Plan([]*Migration{{ID: "001", Up: []string{ScriptStep("fail"), `CREATE BUNDLE "a" WITH FIELDS ();`}}})
+	if err := c.Apply(plan); err == nil {
+		t.Fatal("expected apply to fail")
+	}
+	if len(events) != 3 || !errors.Is(events[1].Err, cause) || events[2].Stage != MigrationCompleted || events[2].Err == nil {
+		t.Errorf("expected the failure to be reported, got %+v", events)
+	}
+}
+
+func TestProgressEvent_Remaining(t *testing.T) {
+	event := ProgressEvent{CompletedCommands: 2, TotalCommands: 6, Elapsed: 4 * time.Second}
+	if event.Remaining() != 8*time.Second {
+		t.Errorf("expected 8s remaining, got %s", event.Remaining())
+	}
+	if event.Fraction() < 0.33 || event.Fraction() > 0.34 {
+		t.Errorf("expected a third done, got %f", event.Fraction())
+	}
+	if (ProgressEvent{TotalCommands: 6}).Remaining() != 0 {
+		t.Error("expected no estimate before the first command")
+	}
+}
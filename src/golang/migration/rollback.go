@@ -44,6 +44,17 @@ func (g *RollbackGenerator) generateSingleDown(upCommand string) (string, error)
 		return g.reversCreateBundle(normalized)
 	}
 
+	// ADD RELATIONSHIP → REMOVE RELATIONSHIP. Checked before UPDATE BUNDLE SET,
+	// which would otherwise match bundle or relationship names containing "SET".
+	if strings.Contains(normalizedUpper, "ADD RELATIONSHIP") {
+		return g.reverseAddRelationship(normalized)
+	}
+
+	// REMOVE RELATIONSHIP → ADD RELATIONSHIP (not reversible without definition)
+	if strings.Contains(normalizedUpper, "REMOVE RELATIONSHIP") {
+		return "", fmt.Errorf("REMOVE RELATIONSHIP cannot be automatically reversed (relationship definition required)")
+	}
+
 	// UPDATE BUNDLE SET → UPDATE BUNDLE SET (reverse operations)
 	if strings.HasPrefix(normalizedUpper, "UPDATE BUNDLE") && strings.Contains(normalizedUpper, "SET") {
 		return g.reverseUpdateBundle(normalized)
@@ -64,14 +75,9 @@ func (g *RollbackGenerator) generateSingleDown(upCommand string) (string, error)
 		return "", fmt.Errorf("DROP INDEX cannot be automatically reversed (index definition required)")
 	}
 
-	// ADD RELATIONSHIP → REMOVE RELATIONSHIP
-	if strings.Contains(normalizedUpper, "ADD RELATIONSHIP") {
-		return g.reverseAddRelationship(normalized)
-	}
-
-	// REMOVE RELATIONSHIP → ADD RELATIONSHIP (not reversible without definition)
-	if strings.Contains(normalizedUpper, "REMOVE RELATIONSHIP") {
-		return "", fmt.Errorf("REMOVE RELATIONSHIP cannot be automatically reversed (relationship definition required)")
+	// SCRIPT → Not reversible (a script's effect is unknown)
+	if _, ok := ParseScriptStep(normalized); ok {
+		return "", fmt.Errorf("SCRIPT steps cannot be automatically reversed (add a Down script step)")
 	}
 
 	// INSERT → DELETE (if we support these)
@@ -148,8 +154,8 @@ func (g *RollbackGenerator) reverseUpdateBundle(updateCmd string) (string, error
 
 // reverseCreateIndex generates DROP INDEX from CREATE INDEX
 func (g *RollbackGenerator) reverseCreateIndex(createCmd string) (string, error) {
-	// Pattern: CREATE [HASH|B-]INDEX "indexName" ON BUNDLE "bundleName"
-	re := regexp.MustCompile(`(?i)CREATE\s+(?:HASH\s+|B-)?INDEX\s+["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `]`)
+	// Pattern: CREATE [UNIQUE] [HASH |FULLTEXT |B-]INDEX "indexName" ON BUNDLE "bundleName"
+	re := regexp.MustCompile(`(?i)CREATE\s+(?:UNIQUE\s+)?(?:HASH\s+|FULLTEXT\s+|B-)?INDEX\s+["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `]`)
 	matches := re.FindStringSubmatch(createCmd)
 
 	if len(matches) < 2 {
@@ -195,6 +201,7 @@ func (g *RollbackGenerator) CanGenerateDown(upCommand string) bool {
 		"CREATE BUNDLE",
 		"CREATE HASH INDEX",
 		"CREATE B-INDEX",
+		"CREATE FULLTEXT INDEX",
 		"CREATE INDEX",
 	}
 
@@ -55,6 +55,41 @@ func TestGenerateDown_CreateBTreeIndex(t *testing.T) {
 	}
 }
 
+func TestGenerateDown_CreateFulltextIndex(t *testing.T) {
+	gen := NewRollbackGenerator()
+
+	upCmd := `CREATE FULLTEXT INDEX "idx_posts_body" ON BUNDLE "posts" WITH FIELDS ("body");`
+
+	downCmd, err := gen.generateSingleDown(upCmd)
+	if err != nil {
+		t.Fatalf("failed to generate down: %v", err)
+	}
+
+	expected := `DROP INDEX "idx_posts_body";`
+	if downCmd != expected {
+		t.Errorf("expected %q, got %q", expected, downCmd)
+	}
+	if !gen.CanGenerateDown(upCmd) {
+		t.Error("expected CREATE FULLTEXT INDEX to be reversible")
+	}
+}
+
+func TestGenerateDown_CreateUniquePartialIndex(t *testing.T) {
+	gen := NewRollbackGenerator()
+
+	upCmd := `CREATE UNIQUE HASH INDEX "idx_active_email" ON BUNDLE "users" WITH FIELDS ("tenant_id", "email") WHERE "status" == "active";`
+
+	downCmd, err := gen.generateSingleDown(upCmd)
+	if err != nil {
+		t.Fatalf("failed to generate down: %v", err)
+	}
+
+	expected := `DROP INDEX "idx_active_email";`
+	if downCmd != expected {
+		t.Errorf("expected %q, got %q", expected, downCmd)
+	}
+}
+
 func TestGenerateDown_UpdateBundleAdd(t *testing.T) {
 	gen := NewRollbackGenerator()
 
@@ -89,6 +124,23 @@ func TestGenerateDown_AddRelationship(t *testing.T) {
 	}
 }
 
+func TestGenerateDown_AddRelationshipOnSettingsBundle(t *testing.T) {
+	gen := NewRollbackGenerator()
+
+	// "SETTINGS" must not be mistaken for UPDATE BUNDLE SET
+	upCmd := `UPDATE BUNDLE "settings" ADD RELATIONSHIP ("owner" {"1toMany", "settings", "id", "users", "settings_id"});`
+
+	downCmd, err := gen.generateSingleDown(upCmd)
+	if err != nil {
+		t.Fatalf("failed to generate down: %v", err)
+	}
+
+	expected := `UPDATE BUNDLE "settings" REMOVE RELATIONSHIP "owner";`
+	if downCmd != expected {
+		t.Errorf("expected %q, got %q", expected, downCmd)
+	}
+}
+
 func TestGenerateDown_NonReversible_DropBundle(t *testing.T) {
 	gen := NewRollbackGenerator()
 
@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ScriptFunc is a migration step written in Go, for data migrations that
+// need loops or conditionals. It runs its commands through exec, the same
+// executor as the migration's SyndrQL commands.
+type ScriptFunc func(exec MigrationExecutor) error
+
+// scriptStepPattern matches a script step and captures the script name.
+var scriptStepPattern = regexp.MustCompile(`(?i)^\s*SCRIPT\s+"([^"]+)"\s*;?\s*$`)
+
+// ScriptStep returns the Up or Down command that runs the script registered
+// as name with Client.RegisterScript, e.g. SCRIPT "backfill_full_names";.
+// Script steps can be mixed with SyndrQL commands and are recorded in history
+// like any other step. Only the name is part of the migration checksum, so
+// changing a script's code does not mark applied migrations as modified.
+func ScriptStep(name string) string {
+	return fmt.Sprintf("SCRIPT %q;", name)
+}
+
+// ParseScriptStep returns the script name of a script step command.
+func ParseScriptStep(command string) (string, bool) {
+	m := scriptStepPattern.FindStringSubmatch(command)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// RegisterScript registers fn under name for ScriptStep commands. Scripts must
+// be registered before Apply or Rollback runs a migration referencing them.
+func (c *Client) RegisterScript(name string, fn ScriptFunc) {
+	if c.scripts == nil {
+		c.scripts = make(map[string]ScriptFunc)
+	}
+	c.scripts[name] = fn
+}
+
+// execute runs a migration step: a registered script or a SyndrQL command.
+func (c *Client) execute(command string) error {
+	name, ok := ParseScriptStep(command)
+	if !ok {
+		_, err := c.executor.Execute(command)
+		return err
+	}
+	fn, ok := c.scripts[name]
+	if !ok {
+		return fmt.Errorf("script %q is not registered", name)
+	}
+	if err := fn(c.executor); err != nil {
+		return fmt.Errorf("script %q failed: %w", name, err)
+	}
+	return nil
+}
+
+// checkScripts returns an error for the first script step in commands whose
+// script is not registered, so a migration fails before any step runs.
+func (c *Client) checkScripts(migrationID string, commands []string) error {
+	for _, command := range commands {
+		if name, ok := ParseScriptStep(command); ok {
+			if _, registered := c.scripts[name]; !registered {
+				return ErrScriptNotFound(migrationID, name)
+			}
+		}
+	}
+	return nil
+}
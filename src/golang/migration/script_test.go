@@ -0,0 +1,90 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScriptStep_ApplyAndRollback(t *testing.T) {
+	executor := &recordingExecutor{}
+	c := NewClient(executor)
+	c.RegisterScript("backfill", func(exec MigrationExecutor) error {
+		for i := 1; i <= 2; i++ {
+			if _, err := exec.Execute(fmt.Sprintf(`UPDATE DOCUMENTS IN BUNDLE "users" (score = %d) WHERE "id" == %d;`, i*10, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	c.RegisterScript("reset", func(exec MigrationExecutor) error {
+		_, err := exec.Execute(`UPDATE DOCUMENTS IN BUNDLE "users" (score = 0);`)
+		return err
+	})
+
+	migrations := []*Migration{{
+		ID:   "001_scores",
+		Up:   []string{`UPDATE BUNDLE "users" SET ({ADD "score" = "score", "INT", FALSE, FALSE, 0});`, ScriptStep("backfill")},
+		Down: []string{ScriptStep("reset")},
+	}}
+
+	plan, err := c.Plan(migrations)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if err := c.Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if len(executor.commands) != 3 || !strings.Contains(executor.commands[2], "score = 20") {
+		t.Fatalf("expected the command and both script updates, got %v", executor.commands)
+	}
+	if record, _ := c.GetMigrationRecord("001_scores"); record.Status != Applied {
+		t.Errorf("expected migration to be recorded as applied, got %s", record.Status)
+	}
+
+	if err := c.Rollback("001_scores", migrations); err != nil {
+		t.Fatalf("rollback failed: %v", err)
+	}
+	if last := executor.commands[len(executor.commands)-1]; last != `UPDATE DOCUMENTS IN BUNDLE "users" (score = 0);` {
+		t.Errorf("expected the Down script to run, got %q", last)
+	}
+}
+
+func TestScriptStep_NotRegistered(t *testing.T) {
+	executor := &recordingExecutor{}
+	c := NewClient(executor)
+	plan, _ := c.Plan([]*Migration{{ID: "001", Up: []string{`CREATE BUNDLE "a" WITH FIELDS ();`, ScriptStep("missing")}}})
+
+	err := c.Apply(plan)
+	if migErr, ok := err.(*MigrationError); !ok || migErr.Code != "SCRIPT_NOT_FOUND" {
+		t.Fatalf("expected SCRIPT_NOT_FOUND, got %v", err)
+	}
+	if len(executor.commands) != 0 {
+		t.Errorf("expected no step to run, got %v", executor.commands)
+	}
+}
+
+func TestScriptStep_Failure(t *testing.T) {
+	c := NewClient(&recordingExecutor{})
+	cause := errors.New("bad row")
+	c.RegisterScript("backfill", func(exec MigrationExecutor) error { return cause })
+
+	plan, _ := c.Plan([]*Migration{{ID: "001", Up: []string{ScriptStep("backfill")}}})
+	err := c.Apply(plan)
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected the script error, got %v", err)
+	}
+	if record, _ := c.GetMigrationRecord("001"); record.Status != Failed {
+		t.Errorf("expected migration to be recorded as failed, got %s", record.Status)
+	}
+}
+
+func TestScriptStep_NotAutoReversible(t *testing.T) {
+	if name, ok := ParseScriptStep(`SCRIPT "backfill";`); !ok || name != "backfill" {
+		t.Fatalf("expected script step, got %q %v", name, ok)
+	}
+	if _, err := NewRollbackGenerator().GenerateDown([]string{ScriptStep("backfill")}); err == nil {
+		t.Error("expected script steps not to be reversible")
+	}
+}
@@ -84,6 +84,12 @@ type MigrationPlan struct {
 
 	// DryRun indicates this is a preview without execution.
 	DryRun bool `json:"dryRun,omitempty"`
+
+	// Destructive lists the commands in Migrations that can lose data.
+	Destructive []DestructiveChange `json:"destructive,omitempty"`
+
+	// AllowDestructive must be set for Apply to run a plan with destructive commands.
+	AllowDestructive bool `json:"allowDestructive,omitempty"`
 }
 
 // ConflictType represents the type of migration conflict.
@@ -129,4 +135,8 @@ type ValidationResult struct {
 
 	// AppliedMigrations lists migrations already applied.
 	AppliedMigrations []string `json:"appliedMigrations"`
+
+	// Destructive lists commands in pending migrations that can lose data.
+	// They do not make the result invalid but must be allowed to be applied.
+	Destructive []DestructiveChange `json:"destructive,omitempty"`
 }
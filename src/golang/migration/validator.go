@@ -51,6 +51,7 @@ func (v *MigrationValidator) Validate(migrations []*Migration) *ValidationResult
 		} else {
 			// Not applied yet - it's pending
 			result.PendingMigrations = append(result.PendingMigrations, migration.ID)
+			result.Destructive = append(result.Destructive, DetectDestructive(migration)...)
 
 			// Check dependencies
 			conflicts := v.validateDependencies(migration, migrationMap)
@@ -0,0 +1,216 @@
+package schema
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+)
+
+// ValidateConstraints checks the constraints and defaults of every field.
+func (s *SchemaDefinition) ValidateConstraints() error {
+	for _, bundle := range s.Bundles {
+		for i := range bundle.Fields {
+			if err := bundle.Fields[i].ValidateConstraints(); err != nil {
+				return fmt.Errorf("bundle %s: %w", bundle.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateConstraints checks that the field's constraints fit its type and are
+// consistent, and that its default value satisfies them.
+func (f *FieldDefinition) ValidateConstraints() error {
+	if f.Items != nil {
+		if err := f.Items.ValidateConstraints(); err != nil {
+			return fmt.Errorf("field %s: items: %w", f.Name, err)
+		}
+	}
+	for i := range f.Fields {
+		if err := f.Fields[i].ValidateConstraints(); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+
+	c := f.Constraints
+	if c == nil {
+		return nil
+	}
+
+	isString := f.Type == STRING || f.Type == TEXT
+	isNumber := f.Type == INT || f.Type == FLOAT || f.Type == DECIMAL
+
+	if (c.MinLength != nil || c.MaxLength != nil || c.Pattern != "") && !isString {
+		return fmt.Errorf("field %s: length and pattern constraints require a STRING or TEXT field, not %s", f.Name, f.Type)
+	}
+	if (c.Min != nil || c.Max != nil) && !isNumber {
+		return fmt.Errorf("field %s: min and max require an INT, FLOAT or DECIMAL field, not %s", f.Name, f.Type)
+	}
+	if len(c.Enum) > 0 && !isString && !isNumber {
+		return fmt.Errorf("field %s: enum requires a STRING, TEXT, INT, FLOAT or DECIMAL field, not %s", f.Name, f.Type)
+	}
+
+	if (c.MinLength != nil && *c.MinLength < 0) || (c.MaxLength != nil && *c.MaxLength < 0) {
+		return fmt.Errorf("field %s: length constraints must not be negative", f.Name)
+	}
+	if c.MinLength != nil && c.MaxLength != nil && *c.MinLength > *c.MaxLength {
+		return fmt.Errorf("field %s: minLength %d is greater than maxLength %d", f.Name, *c.MinLength, *c.MaxLength)
+	}
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return fmt.Errorf("field %s: min %v is greater than max %v", f.Name, *c.Min, *c.Max)
+	}
+	if c.Pattern != "" {
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("field %s: invalid pattern: %w", f.Name, err)
+		}
+	}
+
+	for _, value := range c.Enum {
+		if _, ok := value.(string); ok != isString {
+			return fmt.Errorf("field %s: enum value %v does not match type %s", f.Name, value, f.Type)
+		}
+	}
+
+	if f.DefaultValue != nil {
+		if err := f.ValidateValue(f.DefaultValue); err != nil {
+			return fmt.Errorf("default value: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks a value against the field's constraints, and the
+// elements or members of ARRAY and OBJECT values against Items and Fields.
+// Nil values pass; whether a field must be present is up to the caller, but
+// required members of an OBJECT must be present.
+func (f *FieldDefinition) ValidateValue(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	if err := f.validateNested(value); err != nil {
+		return err
+	}
+	c := f.Constraints
+	if c == nil {
+		return nil
+	}
+
+	if len(c.Enum) > 0 {
+		allowed := false
+		for _, option := range c.Enum {
+			if valuesEqual(option, value) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("field %s: %v is not one of %v", f.Name, value, c.Enum)
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		length := utf8.RuneCountInString(s)
+		if c.MinLength != nil && length < *c.MinLength {
+			return fmt.Errorf("field %s: length %d is shorter than %d", f.Name, length, *c.MinLength)
+		}
+		if c.MaxLength != nil && length > *c.MaxLength {
+			return fmt.Errorf("field %s: length %d is longer than %d", f.Name, length, *c.MaxLength)
+		}
+		if c.Pattern != "" {
+			re, err := regexp.Compile(c.Pattern)
+			if err != nil {
+				return fmt.Errorf("field %s: invalid pattern: %w", f.Name, err)
+			}
+			if !re.MatchString(s) {
+				return fmt.Errorf("field %s: %q does not match pattern %s", f.Name, s, c.Pattern)
+			}
+		}
+	}
+
+	n, ok := toFloat(value)
+	if s, isString := value.(string); isString && f.Type == DECIMAL {
+		n, ok = decimalToFloat(s)
+	}
+	if ok {
+		if c.Min != nil && n < *c.Min {
+			return fmt.Errorf("field %s: %v is less than %v", f.Name, value, *c.Min)
+		}
+		if c.Max != nil && n > *c.Max {
+			return fmt.Errorf("field %s: %v is greater than %v", f.Name, value, *c.Max)
+		}
+	}
+
+	return nil
+}
+
+// validateNested checks the elements of an array against Items and the
+// members of an object against Fields.
+func (f *FieldDefinition) validateNested(value interface{}) error {
+	if f.Items != nil {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			for i := 0; i < v.Len(); i++ {
+				if err := f.Items.ValidateValue(v.Index(i).Interface()); err != nil {
+					return fmt.Errorf("field %s: item %d: %w", f.Name, i, err)
+				}
+			}
+		}
+	}
+	if members, ok := value.(map[string]interface{}); ok {
+		for i := range f.Fields {
+			member := &f.Fields[i]
+			v, present := members[member.Name]
+			if member.Required && (!present || v == nil) {
+				return fmt.Errorf("field %s: missing required member %s", f.Name, member.Name)
+			}
+			if err := member.ValidateValue(v); err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// valuesEqual compares values, treating numbers of different types as equal
+// when their values are, since decoded JSON numbers are always float64.
+func valuesEqual(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case *big.Rat:
+		if v == nil {
+			return 0, false
+		}
+		f, _ := v.Float64()
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+// decimalToFloat reads a DECIMAL value sent as a string, for range checks.
+func decimalToFloat(s string) (float64, bool) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, false
+	}
+	f, _ := r.Float64()
+	return f, true
+}
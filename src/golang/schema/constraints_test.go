@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func intPtr(v int) *int           { return &v }
+func floatPtr(v float64) *float64 { return &v }
+
+func TestFieldDefinition_ValidateValue(t *testing.T) {
+	username := FieldDefinition{Name: "username", Type: STRING, Constraints: &FieldConstraints{
+		MinLength: intPtr(3),
+		MaxLength: intPtr(8),
+		Pattern:   "^[a-z]+$",
+	}}
+	age := FieldDefinition{Name: "age", Type: INT, Constraints: &FieldConstraints{
+		Min: floatPtr(0),
+		Max: floatPtr(150),
+	}}
+	status := FieldDefinition{Name: "status", Type: STRING, Constraints: &FieldConstraints{
+		Enum: []interface{}{"active", "banned"},
+	}}
+	priority := FieldDefinition{Name: "priority", Type: INT, Constraints: &FieldConstraints{
+		Enum: []interface{}{float64(1), float64(2)},
+	}}
+	price := FieldDefinition{Name: "price", Type: DECIMAL, Constraints: &FieldConstraints{
+		Min: floatPtr(0),
+	}}
+
+	tests := []struct {
+		field FieldDefinition
+		value interface{}
+		valid bool
+	}{
+		{username, "alice", true},
+		{username, "al", false},
+		{username, "alexandria", false},
+		{username, "Alice", false},
+		{username, nil, true},
+		{age, 30, true},
+		{age, float64(150), true},
+		{age, -1, false},
+		{age, int64(151), false},
+		{status, "active", true},
+		{status, "deleted", false},
+		{priority, 2, true},
+		{priority, 3, false},
+		{price, "19.99", true},
+		{price, "-0.01", false},
+		{price, big.NewRat(-1, 100), false},
+	}
+
+	for _, tt := range tests {
+		err := tt.field.ValidateValue(tt.value)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s.ValidateValue(%v): expected valid=%v, got %v", tt.field.Name, tt.value, tt.valid, err)
+		}
+	}
+}
+
+func TestFieldDefinition_ValidateConstraints(t *testing.T) {
+	tests := []struct {
+		name  string
+		field FieldDefinition
+		err   string
+	}{
+		{"valid", FieldDefinition{Name: "f", Type: STRING, DefaultValue: "abc",
+			Constraints: &FieldConstraints{MinLength: intPtr(1), Pattern: "^a"}}, ""},
+		{"length on int", FieldDefinition{Name: "f", Type: INT,
+			Constraints: &FieldConstraints{MaxLength: intPtr(1)}}, "require a STRING or TEXT field"},
+		{"range on string", FieldDefinition{Name: "f", Type: STRING,
+			Constraints: &FieldConstraints{Min: floatPtr(1)}}, "require an INT, FLOAT or DECIMAL field"},
+		{"inverted length", FieldDefinition{Name: "f", Type: STRING,
+			Constraints: &FieldConstraints{MinLength: intPtr(5), MaxLength: intPtr(2)}}, "greater than maxLength"},
+		{"inverted range", FieldDefinition{Name: "f", Type: FLOAT,
+			Constraints: &FieldConstraints{Min: floatPtr(5), Max: floatPtr(2)}}, "greater than max"},
+		{"bad pattern", FieldDefinition{Name: "f", Type: STRING,
+			Constraints: &FieldConstraints{Pattern: "("}}, "invalid pattern"},
+		{"enum type", FieldDefinition{Name: "f", Type: INT,
+			Constraints: &FieldConstraints{Enum: []interface{}{"a"}}}, "does not match type"},
+		{"default violates", FieldDefinition{Name: "f", Type: STRING, DefaultValue: "x",
+			Constraints: &FieldConstraints{Enum: []interface{}{"a", "b"}}}, "default value"},
+	}
+
+	for _, tt := range tests {
+		err := tt.field.ValidateConstraints()
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestSchemaDefinition_ValidateConstraints(t *testing.T) {
+	var schemaDef SchemaDefinition
+	data := `{"bundles": [{"name": "users", "fields": [
+		{"name": "role", "type": "STRING", "defaultValue": "admin", "constraints": {"enum": ["user", "editor"]}}
+	]}]}`
+	if err := json.Unmarshal([]byte(data), &schemaDef); err != nil {
+		t.Fatal(err)
+	}
+
+	err := schemaDef.ValidateConstraints()
+	if err == nil || !strings.Contains(err.Error(), "bundle users: default value: field role") {
+		t.Errorf("expected default value error for users.role, got %v", err)
+	}
+}
+
+func TestFieldDefinition_ValidateNested(t *testing.T) {
+	tags := FieldDefinition{Name: "tags", Type: ARRAY, Items: &FieldDefinition{Type: STRING, Constraints: &FieldConstraints{
+		MaxLength: intPtr(5),
+	}}}
+	address := FieldDefinition{Name: "address", Type: OBJECT, Fields: []FieldDefinition{
+		{Name: "city", Type: STRING, Required: true},
+		{Name: "zip", Type: STRING, Constraints: &FieldConstraints{Pattern: "^[0-9]{5}$"}},
+	}}
+
+	tests := []struct {
+		field FieldDefinition
+		value interface{}
+		valid bool
+	}{
+		{tags, []interface{}{"go", "db"}, true},
+		{tags, []string{"go", "databases"}, false},
+		{address, map[string]interface{}{"city": "Berlin", "zip": "10115"}, true},
+		{address, map[string]interface{}{"zip": "10115"}, false},
+		{address, map[string]interface{}{"city": "Berlin", "zip": "1O115"}, false},
+	}
+	for _, tt := range tests {
+		err := tt.field.ValidateValue(tt.value)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s.ValidateValue(%v): expected valid=%v, got %v", tt.field.Name, tt.value, tt.valid, err)
+		}
+	}
+
+	bad := FieldDefinition{Name: "tags", Type: ARRAY, Items: &FieldDefinition{Type: INT, Constraints: &FieldConstraints{Pattern: "x"}}}
+	if err := bad.ValidateConstraints(); err == nil || !strings.Contains(err.Error(), "field tags: items:") {
+		t.Errorf("expected an error for the item constraints, got %v", err)
+	}
+}
@@ -0,0 +1,432 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LintSeverity is the severity of a lint issue.
+type LintSeverity string
+
+const (
+	LintWarning LintSeverity = "warning"
+	LintError   LintSeverity = "error"
+)
+
+// Lint rule codes reported by the default rules.
+const (
+	LintMissingPrimaryKey        = "MISSING_PRIMARY_KEY"
+	LintUnindexedForeignKey      = "UNINDEXED_FOREIGN_KEY"
+	LintReservedBundleName       = "RESERVED_BUNDLE_NAME"
+	LintIncompatibleRelationship = "INCOMPATIBLE_RELATIONSHIP"
+	LintWideBundle               = "WIDE_BUNDLE"
+	LintUnknownIndexField        = "UNKNOWN_INDEX_FIELD"
+	LintInvalidConstraint        = "INVALID_CONSTRAINT"
+)
+
+// DefaultMaxBundleFields is the default Linter.MaxBundleFields.
+const DefaultMaxBundleFields = 50
+
+// LintIssue is a problem found by a lint rule.
+type LintIssue struct {
+	Code     string       `json:"code"`
+	Severity LintSeverity `json:"severity"`
+	Bundle   string       `json:"bundle,omitempty"`
+	Field    string       `json:"field,omitempty"`
+	Message  string       `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	location := i.Bundle
+	if i.Field != "" {
+		location += "." + i.Field
+	}
+	if location != "" {
+		location += ": "
+	}
+	return fmt.Sprintf("%s [%s] %s%s", i.Severity, i.Code, location, i.Message)
+}
+
+// LintResult holds the issues found in a schema, ordered by bundle.
+type LintResult struct {
+	Issues []LintIssue `json:"issues"`
+}
+
+// HasErrors reports whether any issue has error severity.
+func (r *LintResult) HasErrors() bool {
+	return len(r.Errors()) > 0
+}
+
+// Errors returns the issues with error severity.
+func (r *LintResult) Errors() []LintIssue {
+	return r.filter(LintError)
+}
+
+// Warnings returns the issues with warning severity.
+func (r *LintResult) Warnings() []LintIssue {
+	return r.filter(LintWarning)
+}
+
+func (r *LintResult) filter(severity LintSeverity) []LintIssue {
+	var issues []LintIssue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// LintRule checks a schema. Issues that leave Code or Severity empty get the
+// rule's values.
+type LintRule struct {
+	Code        string
+	Severity    LintSeverity
+	Description string
+	Check       func(def *SchemaDefinition) []LintIssue
+}
+
+// Linter runs lint rules over schema definitions.
+type Linter struct {
+	// MaxBundleFields is the field count above which WIDE_BUNDLE is reported.
+	MaxBundleFields int
+
+	rules    []LintRule
+	disabled map[string]bool
+}
+
+// NewLinter creates a linter with the default rules.
+func NewLinter() *Linter {
+	l := &Linter{
+		MaxBundleFields: DefaultMaxBundleFields,
+		disabled:        make(map[string]bool),
+	}
+	l.rules = l.defaultRules()
+	return l
+}
+
+// AddRule adds a custom rule.
+func (l *Linter) AddRule(rule LintRule) {
+	l.rules = append(l.rules, rule)
+}
+
+// Disable turns off the rules with the given codes.
+func (l *Linter) Disable(codes ...string) {
+	for _, code := range codes {
+		l.disabled[code] = true
+	}
+}
+
+// Rules returns the enabled rules.
+func (l *Linter) Rules() []LintRule {
+	rules := make([]LintRule, 0, len(l.rules))
+	for _, rule := range l.rules {
+		if !l.disabled[rule.Code] {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// Lint runs the enabled rules.
+func (l *Linter) Lint(def *SchemaDefinition) *LintResult {
+	result := &LintResult{Issues: make([]LintIssue, 0)}
+	for _, rule := range l.Rules() {
+		for _, issue := range rule.Check(def) {
+			if issue.Code == "" {
+				issue.Code = rule.Code
+			}
+			if issue.Severity == "" {
+				issue.Severity = rule.Severity
+			}
+			if !l.disabled[issue.Code] {
+				result.Issues = append(result.Issues, issue)
+			}
+		}
+	}
+
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		return result.Issues[i].Bundle < result.Issues[j].Bundle
+	})
+	return result
+}
+
+// Lint checks a schema with the default rules.
+func Lint(def *SchemaDefinition) *LintResult {
+	return NewLinter().Lint(def)
+}
+
+// defaultRules returns the built-in rules.
+func (l *Linter) defaultRules() []LintRule {
+	return []LintRule{
+		{
+			Code:        LintMissingPrimaryKey,
+			Severity:    LintWarning,
+			Description: "Bundles should have a required, unique field identifying documents",
+			Check:       lintMissingPrimaryKey,
+		},
+		{
+			Code:        LintUnindexedForeignKey,
+			Severity:    LintWarning,
+			Description: "Fields referencing other bundles should lead an index",
+			Check:       lintUnindexedForeignKeys,
+		},
+		{
+			Code:        LintReservedBundleName,
+			Severity:    LintError,
+			Description: "Bundle names must not be SyndrQL keywords",
+			Check:       lintReservedBundleNames,
+		},
+		{
+			Code:        LintIncompatibleRelationship,
+			Severity:    LintError,
+			Description: "Relationships must reference existing bundles and fields of the same type",
+			Check:       lintRelationships,
+		},
+		{
+			Code:        LintWideBundle,
+			Severity:    LintWarning,
+			Description: "Bundles should not have more than MaxBundleFields fields",
+			Check: func(def *SchemaDefinition) []LintIssue {
+				return lintWideBundles(def, l.MaxBundleFields)
+			},
+		},
+		{
+			Code:        LintUnknownIndexField,
+			Severity:    LintError,
+			Description: "Indexes must only reference fields of their bundle, and fulltext indexes only STRING or TEXT fields",
+			Check:       lintIndexFields,
+		},
+		{
+			Code:        LintInvalidConstraint,
+			Severity:    LintError,
+			Description: "Field constraints must fit the field type and defaults must satisfy them",
+			Check:       lintConstraints,
+		},
+	}
+}
+
+// reservedWords are SyndrQL keywords that cannot be used as bundle names.
+var reservedWords = map[string]bool{
+	"ADD": true, "ALTER": true, "AND": true, "AS": true, "BEGIN": true, "BUNDLE": true,
+	"BUNDLES": true, "BY": true, "COMMIT": true, "CREATE": true, "DATABASE": true,
+	"DELETE": true, "DOCUMENT": true, "DOCUMENTS": true, "DROP": true, "EXPLAIN": true,
+	"FALSE": true, "FIELDS": true, "FROM": true, "IN": true, "INDEX": true, "INTO": true,
+	"JOIN": true, "LIKE": true, "LIMIT": true, "NOT": true, "NULL": true, "OFFSET": true,
+	"ON": true, "OR": true, "ORDER": true, "RELATIONSHIP": true, "ROLLBACK": true,
+	"SELECT": true, "SET": true, "SHOW": true, "TO": true, "TRANSACTION": true,
+	"TRUE": true, "UPDATE": true, "USE": true, "VALUES": true, "WHERE": true, "WITH": true,
+}
+
+// relationshipTypes are the relationship types the server supports, lowercased.
+var relationshipTypes = map[string]bool{
+	"1to1": true, "0tomany": true, "1tomany": true, "manytomany": true,
+}
+
+func lintMissingPrimaryKey(def *SchemaDefinition) []LintIssue {
+	var issues []LintIssue
+	for _, bundle := range def.Bundles {
+		hasKey := false
+		for _, field := range bundle.Fields {
+			if field.Required && field.Unique {
+				hasKey = true
+				break
+			}
+		}
+		if !hasKey {
+			issues = append(issues, LintIssue{
+				Bundle:  bundle.Name,
+				Message: "no required unique field; documents can only be identified by DocumentID",
+			})
+		}
+	}
+	return issues
+}
+
+func lintUnindexedForeignKeys(def *SchemaDefinition) []LintIssue {
+	bundles := bundlesByName(def)
+	reported := make(map[string]bool)
+	var issues []LintIssue
+
+	check := func(bundleName, fieldName, reason string) {
+		bundle := bundles[bundleName]
+		key := bundleName + "." + fieldName
+		if bundle == nil || fieldName == "" || fieldName == "DocumentID" || reported[key] {
+			return
+		}
+		for _, index := range bundle.Indexes {
+			if len(index.Fields) > 0 && index.Fields[0] == fieldName {
+				return
+			}
+		}
+		reported[key] = true
+		issues = append(issues, LintIssue{
+			Bundle:  bundleName,
+			Field:   fieldName,
+			Message: reason + " but no index starts with it; lookups will scan the bundle",
+		})
+	}
+
+	for _, bundle := range def.Bundles {
+		for _, field := range bundle.Fields {
+			if field.Type == RELATIONSHIP {
+				check(bundle.Name, field.Name, "references "+field.RelatedBundle)
+			}
+		}
+		for _, rel := range bundle.Relationships {
+			switch strings.ToLower(rel.Type) {
+			case "0tomany", "1tomany":
+				check(rel.DestBundle, rel.DestField, fmt.Sprintf("references %s via relationship %s", rel.SourceBundle, rel.Name))
+			case "1to1":
+				check(rel.SourceBundle, rel.SourceField, fmt.Sprintf("references %s via relationship %s", rel.DestBundle, rel.Name))
+			}
+		}
+	}
+	return issues
+}
+
+func lintReservedBundleNames(def *SchemaDefinition) []LintIssue {
+	var issues []LintIssue
+	for _, bundle := range def.Bundles {
+		if reservedWords[strings.ToUpper(bundle.Name)] {
+			issues = append(issues, LintIssue{
+				Bundle:  bundle.Name,
+				Message: fmt.Sprintf("%q is a SyndrQL keyword", bundle.Name),
+			})
+		}
+	}
+	return issues
+}
+
+func lintRelationships(def *SchemaDefinition) []LintIssue {
+	bundles := bundlesByName(def)
+	var issues []LintIssue
+
+	for _, bundle := range def.Bundles {
+		for _, rel := range bundle.Relationships {
+			report := func(format string, args ...interface{}) {
+				issues = append(issues, LintIssue{
+					Bundle:  bundle.Name,
+					Message: fmt.Sprintf("relationship %s: ", rel.Name) + fmt.Sprintf(format, args...),
+				})
+			}
+
+			if !relationshipTypes[strings.ToLower(rel.Type)] {
+				report("unknown type %q (use 1to1, 0toMany, 1toMany or ManyToMany)", rel.Type)
+			}
+
+			source, dest := bundles[rel.SourceBundle], bundles[rel.DestBundle]
+			if source == nil {
+				report("source bundle %q does not exist", rel.SourceBundle)
+			}
+			if dest == nil {
+				report("destination bundle %q does not exist", rel.DestBundle)
+			}
+			if source == nil || dest == nil {
+				continue
+			}
+
+			sourceType, sourceOK := relationshipFieldType(source, rel.SourceField)
+			destType, destOK := relationshipFieldType(dest, rel.DestField)
+			if !sourceOK {
+				report("source field %s.%s does not exist", rel.SourceBundle, rel.SourceField)
+			}
+			if !destOK {
+				report("destination field %s.%s does not exist", rel.DestBundle, rel.DestField)
+			}
+			if sourceOK && destOK && sourceType != destType {
+				report("%s.%s is %s but %s.%s is %s", rel.SourceBundle, rel.SourceField, sourceType,
+					rel.DestBundle, rel.DestField, destType)
+			}
+		}
+	}
+	return issues
+}
+
+// relationshipFieldType returns the type of a field a relationship joins on.
+// DocumentID is implicit on every bundle.
+func relationshipFieldType(bundle *BundleDefinition, name string) (FieldType, bool) {
+	if name == "DocumentID" {
+		return STRING, true
+	}
+	for _, field := range bundle.Fields {
+		if field.Name == name {
+			return field.Type, true
+		}
+	}
+	return "", false
+}
+
+func lintWideBundles(def *SchemaDefinition, maxFields int) []LintIssue {
+	var issues []LintIssue
+	for _, bundle := range def.Bundles {
+		if len(bundle.Fields) > maxFields {
+			issues = append(issues, LintIssue{
+				Bundle:  bundle.Name,
+				Message: fmt.Sprintf("%d fields exceeds %d; consider splitting the bundle", len(bundle.Fields), maxFields),
+			})
+		}
+	}
+	return issues
+}
+
+func lintIndexFields(def *SchemaDefinition) []LintIssue {
+	var issues []LintIssue
+	for _, bundle := range def.Bundles {
+		fields := make(map[string]FieldType, len(bundle.Fields))
+		for _, field := range bundle.Fields {
+			fields[field.Name] = field.Type
+		}
+		for _, index := range bundle.Indexes {
+			if len(index.Fields) == 0 {
+				issues = append(issues, LintIssue{
+					Bundle:  bundle.Name,
+					Message: fmt.Sprintf("index %s has no fields", index.Name),
+				})
+			}
+			for _, field := range index.Fields {
+				fieldType, ok := fields[field]
+				switch {
+				case !ok:
+					issues = append(issues, LintIssue{
+						Bundle:  bundle.Name,
+						Field:   field,
+						Message: fmt.Sprintf("index %s references unknown field %s", index.Name, field),
+					})
+				case index.Type == FULLTEXT && fieldType != STRING && fieldType != TEXT:
+					issues = append(issues, LintIssue{
+						Bundle:  bundle.Name,
+						Field:   field,
+						Message: fmt.Sprintf("fulltext index %s references %s field %s", index.Name, fieldType, field),
+					})
+				}
+			}
+		}
+	}
+	return issues
+}
+
+func lintConstraints(def *SchemaDefinition) []LintIssue {
+	var issues []LintIssue
+	for _, bundle := range def.Bundles {
+		for i := range bundle.Fields {
+			if err := bundle.Fields[i].ValidateConstraints(); err != nil {
+				issues = append(issues, LintIssue{
+					Bundle:  bundle.Name,
+					Field:   bundle.Fields[i].Name,
+					Message: err.Error(),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func bundlesByName(def *SchemaDefinition) map[string]*BundleDefinition {
+	bundles := make(map[string]*BundleDefinition, len(def.Bundles))
+	for i := range def.Bundles {
+		bundles[def.Bundles[i].Name] = &def.Bundles[i]
+	}
+	return bundles
+}
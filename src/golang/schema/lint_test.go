@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func lintCodes(result *LintResult) []string {
+	codes := make([]string, len(result.Issues))
+	for i, issue := range result.Issues {
+		codes[i] = issue.Bundle + ":" + issue.Code
+	}
+	return codes
+}
+
+func TestLint_CleanSchema(t *testing.T) {
+	def := &SchemaDefinition{Bundles: []BundleDefinition{
+		{
+			Name:   "users",
+			Fields: []FieldDefinition{{Name: "email", Type: STRING, Required: true, Unique: true}},
+			Relationships: []RelationshipDefinition{
+				{Name: "posts", Type: "1toMany", SourceBundle: "users", SourceField: "DocumentID", DestBundle: "posts", DestField: "author_id"},
+			},
+		},
+		{
+			Name: "posts",
+			Fields: []FieldDefinition{
+				{Name: "slug", Type: STRING, Required: true, Unique: true},
+				{Name: "author_id", Type: STRING, Required: true},
+			},
+			Indexes: []IndexDefinition{{Name: "idx_author", Type: HASH, Fields: []string{"author_id"}}},
+		},
+	}}
+
+	if result := Lint(def); len(result.Issues) != 0 {
+		t.Errorf("expected no issues, got %v", result.Issues)
+	}
+}
+
+func TestLint_Rules(t *testing.T) {
+	wide := make([]FieldDefinition, 0, DefaultMaxBundleFields+1)
+	wide = append(wide, FieldDefinition{Name: "id", Type: INT, Required: true, Unique: true})
+	for i := 0; i < DefaultMaxBundleFields; i++ {
+		wide = append(wide, FieldDefinition{Name: fmt.Sprintf("f%d", i), Type: STRING})
+	}
+
+	def := &SchemaDefinition{Bundles: []BundleDefinition{
+		{
+			Name:   "authors",
+			Fields: []FieldDefinition{{Name: "id", Type: INT, Required: true, Unique: true}},
+			Relationships: []RelationshipDefinition{
+				{Name: "books", Type: "1toMany", SourceBundle: "authors", SourceField: "id", DestBundle: "books", DestField: "author_id"},
+				{Name: "awards", Type: "someToSome", SourceBundle: "authors", SourceField: "id", DestBundle: "awards", DestField: "id"},
+			},
+		},
+		{
+			Name: "books",
+			Fields: []FieldDefinition{
+				{Name: "isbn", Type: STRING, Required: true, Unique: true},
+				{Name: "author_id", Type: STRING},
+				{Name: "price", Type: FLOAT, Constraints: &FieldConstraints{MaxLength: new(int)}},
+			},
+			Indexes: []IndexDefinition{{Name: "idx_missing", Type: BTREE, Fields: []string{"title"}}},
+		},
+		{Name: "select", Fields: []FieldDefinition{{Name: "id", Type: INT, Required: true, Unique: true}}},
+		{Name: "notes", Fields: []FieldDefinition{{Name: "body", Type: TEXT}}},
+		{Name: "wide", Fields: wide},
+	}}
+
+	result := Lint(def)
+	got := strings.Join(lintCodes(result), "\n")
+	want := strings.Join([]string{
+		"authors:" + LintIncompatibleRelationship, // unknown type
+		"authors:" + LintIncompatibleRelationship, // awards bundle missing
+		"authors:" + LintIncompatibleRelationship, // INT id vs STRING author_id
+		"books:" + LintUnindexedForeignKey,
+		"books:" + LintUnknownIndexField,
+		"books:" + LintInvalidConstraint,
+		"notes:" + LintMissingPrimaryKey,
+		"select:" + LintReservedBundleName,
+		"wide:" + LintWideBundle,
+	}, "\n")
+	if got != want {
+		t.Errorf("unexpected issues:\n%s\n\nwant:\n%s\n\nissues: %v", got, want, result.Issues)
+	}
+
+	if !result.HasErrors() || len(result.Warnings()) != 3 || len(result.Errors()) != 6 {
+		t.Errorf("expected 6 errors and 3 warnings, got %d and %d", len(result.Errors()), len(result.Warnings()))
+	}
+}
+
+func TestLint_FulltextIndexFields(t *testing.T) {
+	def := &SchemaDefinition{Bundles: []BundleDefinition{{
+		Name: "posts",
+		Fields: []FieldDefinition{
+			{Name: "id", Type: STRING, Required: true, Unique: true},
+			{Name: "title", Type: STRING},
+			{Name: "body", Type: TEXT},
+			{Name: "views", Type: INT},
+		},
+		Indexes: []IndexDefinition{
+			{Name: "idx_text", Type: FULLTEXT, Fields: []string{"title", "body"}},
+			{Name: "idx_views", Type: FULLTEXT, Fields: []string{"views"}},
+		},
+	}}}
+
+	result := Lint(def)
+	if len(result.Issues) != 1 || result.Issues[0].Code != LintUnknownIndexField || result.Issues[0].Field != "views" {
+		t.Errorf("expected one issue for the INT field, got %v", result.Issues)
+	}
+}
+
+func TestLinter_Configuration(t *testing.T) {
+	def := &SchemaDefinition{Bundles: []BundleDefinition{
+		{Name: "logs", Fields: []FieldDefinition{{Name: "a", Type: STRING}, {Name: "b", Type: STRING}}},
+	}}
+
+	linter := NewLinter()
+	linter.MaxBundleFields = 1
+	linter.Disable(LintMissingPrimaryKey)
+	linter.AddRule(LintRule{
+		Code:     "NO_LOGS",
+		Severity: LintError,
+		Check: func(def *SchemaDefinition) []LintIssue {
+			return []LintIssue{{Bundle: "logs", Message: "logs belong elsewhere"}}
+		},
+	})
+
+	result := linter.Lint(def)
+	if got := strings.Join(lintCodes(result), ","); got != "logs:"+LintWideBundle+",logs:NO_LOGS" {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+	if got := result.Issues[1].String(); got != "error [NO_LOGS] logs: logs belong elsewhere" {
+		t.Errorf("unexpected issue string: %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ParseServerSchema parses the response from SHOW BUNDLES command.
@@ -22,10 +23,14 @@ func ParseServerSchema(response []byte) (*SchemaDefinition, error) {
 				Hash []struct {
 					Name   string   `json:"name"`
 					Fields []string `json:"fields"`
+					Unique bool     `json:"unique"`
+					Filter string   `json:"filter"`
 				} `json:"hash"`
 				Btree []struct {
 					Name   string   `json:"name"`
 					Fields []string `json:"fields"`
+					Unique bool     `json:"unique"`
+					Filter string   `json:"filter"`
 				} `json:"btree"`
 			} `json:"indexes"`
 			Relationships []struct {
@@ -73,6 +78,8 @@ func ParseServerSchema(response []byte) (*SchemaDefinition, error) {
 				Name:   rawIndex.Name,
 				Type:   HASH,
 				Fields: rawIndex.Fields,
+				Unique: rawIndex.Unique,
+				Filter: rawIndex.Filter,
 			}
 			bundle.Indexes = append(bundle.Indexes, index)
 		}
@@ -83,6 +90,8 @@ func ParseServerSchema(response []byte) (*SchemaDefinition, error) {
 				Name:   rawIndex.Name,
 				Type:   BTREE,
 				Fields: rawIndex.Fields,
+				Unique: rawIndex.Unique,
+				Filter: rawIndex.Filter,
 			}
 			bundle.Indexes = append(bundle.Indexes, index)
 		}
@@ -225,7 +234,9 @@ func compareFields(localFields, serverFields []FieldDefinition) []FieldChange {
 	return changes
 }
 
-// fieldsEqual compares two fields for equality.
+// fieldsEqual compares two fields for equality. Constraints and nested shapes
+// are ignored because the server does not store them, so they would always
+// show up as drift.
 func fieldsEqual(a, b *FieldDefinition) bool {
 	return a.Type == b.Type &&
 		a.Required == b.Required &&
@@ -278,9 +289,14 @@ func compareIndexes(localIndexes, serverIndexes []IndexDefinition) []IndexChange
 	return changes
 }
 
-// indexesEqual compares two indexes for equality.
+// indexesEqual compares two indexes for equality. Field order matters for
+// composite indexes.
 func indexesEqual(a, b *IndexDefinition) bool {
-	if a.Type != b.Type || len(a.Fields) != len(b.Fields) {
+	if a.Type != b.Type || a.Unique != b.Unique || len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	if strings.Join(strings.Fields(normalizeIndexFilter(a.Filter)), " ") !=
+		strings.Join(strings.Fields(normalizeIndexFilter(b.Filter)), " ") {
 		return false
 	}
 	for i := range a.Fields {
@@ -326,14 +342,22 @@ func compareRelationships(local, server *SchemaDefinition, localBundles, serverB
 		}
 	}
 
-	// Find added relationships
+	// Find added and modified relationships
 	for key, localRel := range localRels {
-		if _, exists := serverRels[key]; !exists {
+		serverRel, exists := serverRels[key]
+		if !exists {
 			changes = append(changes, RelationshipChange{
 				Type:            "add",
 				BundleName:      localRel.SourceBundle,
 				NewRelationship: localRel,
 			})
+		} else if !relationshipsEqual(localRel, serverRel) {
+			changes = append(changes, RelationshipChange{
+				Type:            "modify",
+				BundleName:      localRel.SourceBundle,
+				OldRelationship: serverRel,
+				NewRelationship: localRel,
+			})
 		}
 	}
 
@@ -350,3 +374,10 @@ func compareRelationships(local, server *SchemaDefinition, localBundles, serverB
 
 	return changes
 }
+
+// relationshipsEqual compares two relationships with the same name for equality.
+func relationshipsEqual(a, b *RelationshipDefinition) bool {
+	return strings.EqualFold(a.Type, b.Type) &&
+		a.SourceBundle == b.SourceBundle && a.SourceField == b.SourceField &&
+		a.DestBundle == b.DestBundle && a.DestField == b.DestField
+}
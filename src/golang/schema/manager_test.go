@@ -221,3 +221,70 @@ func TestCompareSchemas_ModifiedFields(t *testing.T) {
 		t.Error("expected bundle changes to be detected")
 	}
 }
+
+func TestCompareSchemas_ModifiedIndexes(t *testing.T) {
+	withIndex := func(index IndexDefinition) *SchemaDefinition {
+		return &SchemaDefinition{
+			Bundles: []BundleDefinition{
+				{
+					Name:    "users",
+					Fields:  []FieldDefinition{{Name: "email", Type: STRING}, {Name: "status", Type: STRING}},
+					Indexes: []IndexDefinition{index},
+				},
+			},
+		}
+	}
+	base := IndexDefinition{Name: "idx_email", Type: HASH, Fields: []string{"email", "status"}, Filter: `"status" == "active"`}
+
+	// Whitespace and a trailing semicolon in the filter are not changes
+	same := base
+	same.Filter = `  "status"   ==  "active";`
+	if diff := CompareSchemas(withIndex(base), withIndex(same)); diff.HasChanges {
+		t.Errorf("expected equivalent filters to match, got %+v", diff.BundleChanges)
+	}
+
+	changed := map[string]IndexDefinition{
+		"unique": {Name: "idx_email", Type: HASH, Fields: base.Fields, Unique: true, Filter: base.Filter},
+		"filter": {Name: "idx_email", Type: HASH, Fields: base.Fields},
+		"order":  {Name: "idx_email", Type: HASH, Fields: []string{"status", "email"}, Filter: base.Filter},
+	}
+	for name, index := range changed {
+		diff := CompareSchemas(withIndex(index), withIndex(base))
+		if len(diff.BundleChanges) != 1 || len(diff.BundleChanges[0].IndexChanges) != 1 ||
+			diff.BundleChanges[0].IndexChanges[0].Type != "modify" {
+			t.Errorf("%s: expected a modified index, got %+v", name, diff.BundleChanges)
+		}
+	}
+}
+
+func TestCompareSchemas_ModifiedRelationships(t *testing.T) {
+	bundles := func(rels ...RelationshipDefinition) *SchemaDefinition {
+		return &SchemaDefinition{Bundles: []BundleDefinition{
+			{Name: "users", Relationships: rels},
+			{Name: "posts"},
+		}}
+	}
+	author := RelationshipDefinition{Name: "author", Type: "1toMany", SourceBundle: "users", SourceField: "id", DestBundle: "posts", DestField: "user_id"}
+	renamed := author
+	renamed.DestField = "author_id"
+	tags := RelationshipDefinition{Name: "tags", Type: "ManytoMany", SourceBundle: "users", SourceField: "id", DestBundle: "posts", DestField: "id"}
+
+	diff := CompareSchemas(bundles(renamed, tags), bundles(author))
+	if !diff.HasChanges || len(diff.RelationshipChanges) != 2 {
+		t.Fatalf("expected 2 relationship changes, got %+v", diff.RelationshipChanges)
+	}
+	byType := make(map[string]RelationshipChange)
+	for _, change := range diff.RelationshipChanges {
+		byType[change.Type] = change
+	}
+	if change, ok := byType["modify"]; !ok || change.OldRelationship.DestField != "user_id" || change.NewRelationship.DestField != "author_id" {
+		t.Errorf("expected author to be modified, got %+v", byType)
+	}
+	if change, ok := byType["add"]; !ok || change.NewRelationship.Name != "tags" {
+		t.Errorf("expected tags to be added, got %+v", byType)
+	}
+
+	if diff := CompareSchemas(bundles(author), bundles(author)); diff.HasChanges {
+		t.Errorf("expected no changes for identical relationships, got %+v", diff.RelationshipChanges)
+	}
+}
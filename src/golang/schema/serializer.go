@@ -7,6 +7,7 @@ import (
 
 // SerializeCreateBundle generates a CREATE BUNDLE command.
 // Format matches SchemaSerializer.ts lines 15-40.
+// Field constraints have no server syntax and are not included.
 func SerializeCreateBundle(bundle *BundleDefinition) string {
 	var fields []string
 	for _, field := range bundle.Fields {
@@ -79,35 +80,44 @@ func SerializeUpdateBundle(bundleName string, changes *BundleChange) string {
 }
 
 // SerializeCreateIndex generates a CREATE INDEX command.
-// Format matches SchemaSerializer.ts lines 82-95.
-// TODO: Support multi-field composite indexes (see SchemaSerializer.ts line 58).
+// Format matches SchemaSerializer.ts lines 82-95, extended with UNIQUE and a
+// WHERE clause for partial indexes:
+//
+//	CREATE UNIQUE HASH INDEX "name" ON BUNDLE "bundle" WITH FIELDS ("a", "b") WHERE "status" == "active";
 func SerializeCreateIndex(index *IndexDefinition, bundleName string) string {
-	fieldsStr := ""
-	if len(index.Fields) > 0 {
-		quotedFields := make([]string, len(index.Fields))
-		for i, field := range index.Fields {
-			quotedFields[i] = fmt.Sprintf(`"%s"`, field)
-		}
-		fieldsStr = strings.Join(quotedFields, ", ")
+	var kind string
+	switch index.Type {
+	case HASH:
+		kind = "HASH INDEX"
+	case BTREE:
+		kind = "B-INDEX"
+	case FULLTEXT:
+		kind = "FULLTEXT INDEX"
+	default:
+		return ""
+	}
+	if index.Unique {
+		kind = "UNIQUE " + kind
+	}
+
+	quotedFields := make([]string, len(index.Fields))
+	for i, field := range index.Fields {
+		quotedFields[i] = fmt.Sprintf(`"%s"`, field)
 	}
 
-	if index.Type == HASH {
-		return fmt.Sprintf(
-			`CREATE HASH INDEX "%s" ON BUNDLE "%s" WITH FIELDS (%s);`,
-			index.Name,
-			bundleName,
-			fieldsStr,
-		)
-	} else if index.Type == BTREE {
-		return fmt.Sprintf(
-			`CREATE B-INDEX "%s" ON BUNDLE "%s" WITH FIELDS (%s);`,
-			index.Name,
-			bundleName,
-			fieldsStr,
-		)
+	where := ""
+	if filter := normalizeIndexFilter(index.Filter); filter != "" {
+		where = " WHERE " + filter
 	}
 
-	return ""
+	return fmt.Sprintf(
+		`CREATE %s "%s" ON BUNDLE "%s" WITH FIELDS (%s)%s;`,
+		kind,
+		index.Name,
+		bundleName,
+		strings.Join(quotedFields, ", "),
+		where,
+	)
 }
 
 // SerializeDropIndex generates a DROP INDEX command.
@@ -115,6 +125,24 @@ func SerializeDropIndex(indexName string) string {
 	return fmt.Sprintf(`DROP INDEX "%s";`, indexName)
 }
 
+// SerializeIndexChange generates the commands applying an index change and
+// the commands reverting it. Indexes cannot be altered, so a modified index
+// is dropped and recreated.
+func SerializeIndexChange(bundleName string, change *IndexChange) (up []string, down []string) {
+	switch change.Type {
+	case "add":
+		up = []string{SerializeCreateIndex(change.NewIndex, bundleName)}
+		down = []string{SerializeDropIndex(change.NewIndex.Name)}
+	case "remove":
+		up = []string{SerializeDropIndex(change.OldIndex.Name)}
+		down = []string{SerializeCreateIndex(change.OldIndex, bundleName)}
+	case "modify":
+		up = []string{SerializeDropIndex(change.OldIndex.Name), SerializeCreateIndex(change.NewIndex, bundleName)}
+		down = []string{SerializeDropIndex(change.NewIndex.Name), SerializeCreateIndex(change.OldIndex, bundleName)}
+	}
+	return up, down
+}
+
 // SerializeAddRelationship generates an UPDATE BUNDLE ADD RELATIONSHIP command.
 // Format matches SchemaSerializer.ts lines 100-120.
 func SerializeAddRelationship(bundleName string, rel *RelationshipDefinition) string {
@@ -139,6 +167,116 @@ func SerializeRemoveRelationship(bundleName string, relName string) string {
 	)
 }
 
+// SerializeRelationshipChange generates the commands applying a relationship
+// change and the commands reverting it. Relationships cannot be altered, so a
+// modified relationship is removed and added again.
+func SerializeRelationshipChange(change *RelationshipChange) (up []string, down []string) {
+	switch change.Type {
+	case "add":
+		up = []string{SerializeAddRelationship(change.BundleName, change.NewRelationship)}
+		down = []string{SerializeRemoveRelationship(change.BundleName, change.NewRelationship.Name)}
+	case "remove":
+		up = []string{SerializeRemoveRelationship(change.BundleName, change.OldRelationship.Name)}
+		down = []string{SerializeAddRelationship(change.BundleName, change.OldRelationship)}
+	case "modify":
+		up = []string{
+			SerializeRemoveRelationship(change.BundleName, change.OldRelationship.Name),
+			SerializeAddRelationship(change.BundleName, change.NewRelationship),
+		}
+		down = []string{
+			SerializeRemoveRelationship(change.BundleName, change.NewRelationship.Name),
+			SerializeAddRelationship(change.BundleName, change.OldRelationship),
+		}
+	}
+	return up, down
+}
+
+// SerializeBundleChange generates the commands applying a bundle change and
+// the commands reverting it. Field changes of a modified bundle become one
+// UPDATE BUNDLE command, reverted by the inverse field changes; index changes
+// follow so they can use added fields.
+func SerializeBundleChange(change *BundleChange) (up []string, down []string) {
+	switch change.Type {
+	case "create":
+		up, down = serializeBundleWithIndexes(change.NewDefinition)
+	case "delete":
+		down, up = serializeBundleWithIndexes(change.OldDefinition)
+	case "modify":
+		if cmd := SerializeUpdateBundle(change.BundleName, change); cmd != "" {
+			up = append(up, cmd)
+			inverse := &BundleChange{FieldChanges: make([]FieldChange, len(change.FieldChanges))}
+			for i := range change.FieldChanges {
+				inverse.FieldChanges[i] = invertFieldChange(change.FieldChanges[i])
+			}
+			down = append(down, SerializeUpdateBundle(change.BundleName, inverse))
+		}
+		for i := range change.IndexChanges {
+			indexUp, indexDown := SerializeIndexChange(change.BundleName, &change.IndexChanges[i])
+			up = append(up, indexUp...)
+			down = append(indexDown, down...)
+		}
+	}
+	return up, down
+}
+
+// SerializeSchemaDiff generates the commands applying a diff from
+// CompareSchemas and the commands reverting it, in the order they must run.
+// Removed relationships are dropped before bundle changes and added ones are
+// created after, so relationships never point at missing bundles.
+func SerializeSchemaDiff(diff *SchemaDiff) (up []string, down []string) {
+	var steps [][2][]string
+	for i := range diff.RelationshipChanges {
+		if diff.RelationshipChanges[i].Type == "remove" {
+			stepUp, stepDown := SerializeRelationshipChange(&diff.RelationshipChanges[i])
+			steps = append(steps, [2][]string{stepUp, stepDown})
+		}
+	}
+	for i := range diff.BundleChanges {
+		stepUp, stepDown := SerializeBundleChange(&diff.BundleChanges[i])
+		steps = append(steps, [2][]string{stepUp, stepDown})
+	}
+	for i := range diff.RelationshipChanges {
+		if diff.RelationshipChanges[i].Type != "remove" {
+			stepUp, stepDown := SerializeRelationshipChange(&diff.RelationshipChanges[i])
+			steps = append(steps, [2][]string{stepUp, stepDown})
+		}
+	}
+
+	up = make([]string, 0)
+	down = make([]string, 0)
+	for i := range steps {
+		up = append(up, steps[i][0]...)
+		down = append(down, steps[len(steps)-1-i][1]...)
+	}
+	return up, down
+}
+
+// serializeBundleWithIndexes generates the commands creating a bundle and its
+// indexes, and the commands dropping them again.
+func serializeBundleWithIndexes(bundle *BundleDefinition) (create []string, drop []string) {
+	create = []string{SerializeCreateBundle(bundle)}
+	drop = []string{SerializeDeleteBundle(bundle.Name)}
+	for i := range bundle.Indexes {
+		if cmd := SerializeCreateIndex(&bundle.Indexes[i], bundle.Name); cmd != "" {
+			create = append(create, cmd)
+			drop = append([]string{SerializeDropIndex(bundle.Indexes[i].Name)}, drop...)
+		}
+	}
+	return create, drop
+}
+
+// invertFieldChange returns the field change that undoes change.
+func invertFieldChange(change FieldChange) FieldChange {
+	switch change.Type {
+	case "add":
+		return FieldChange{Type: "remove", FieldName: change.FieldName, OldField: change.NewField}
+	case "remove":
+		return FieldChange{Type: "add", FieldName: change.FieldName, NewField: change.OldField}
+	default:
+		return FieldChange{Type: change.Type, FieldName: change.FieldName, OldField: change.NewField, NewField: change.OldField}
+	}
+}
+
 // SerializeDeleteBundle generates a DROP BUNDLE command.
 func SerializeDeleteBundle(bundleName string) string {
 	return fmt.Sprintf(`DROP BUNDLE "%s";`, bundleName)
@@ -169,3 +307,8 @@ func serializeDefaultValue(val interface{}) string {
 		return "NULL"
 	}
 }
+
+// normalizeIndexFilter trims whitespace and a trailing semicolon from a filter.
+func normalizeIndexFilter(filter string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(filter), ";"))
+}
@@ -31,6 +31,28 @@ func TestSerializeCreateBundle(t *testing.T) {
 	}
 }
 
+func TestSerializeCreateBundle_Defaults(t *testing.T) {
+	bundle := &BundleDefinition{
+		Name: "users",
+		Fields: []FieldDefinition{
+			{Name: "role", Type: STRING, DefaultValue: "user",
+				Constraints: &FieldConstraints{Enum: []interface{}{"user", "admin"}}},
+			{Name: "credits", Type: INT, Required: true, DefaultValue: 10},
+		},
+	}
+
+	cmd := SerializeCreateBundle(bundle)
+
+	for _, want := range []string{
+		`{"role", "STRING", FALSE, FALSE, "user"}`,
+		`{"credits", "INT", TRUE, FALSE, 10}`,
+	} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("expected %s in command, got: %s", want, cmd)
+		}
+	}
+}
+
 func TestSerializeDeleteBundle(t *testing.T) {
 	cmd := SerializeDeleteBundle("users")
 
@@ -72,6 +94,170 @@ func TestSerializeCreateIndex_BTree(t *testing.T) {
 	}
 }
 
+func TestSerializeCreateIndex_Fulltext(t *testing.T) {
+	index := &IndexDefinition{
+		Name:   "idx_body",
+		Type:   FULLTEXT,
+		Fields: []string{"title", "body"},
+	}
+
+	cmd := SerializeCreateIndex(index, "posts")
+
+	expected := `CREATE FULLTEXT INDEX "idx_body" ON BUNDLE "posts" WITH FIELDS ("title", "body");`
+	if cmd != expected {
+		t.Errorf("expected %q, got %q", expected, cmd)
+	}
+}
+
+func TestSerializeCreateIndex_CompositeUniquePartial(t *testing.T) {
+	index := &IndexDefinition{
+		Name:   "idx_active_email",
+		Type:   HASH,
+		Fields: []string{"tenant_id", "email"},
+		Unique: true,
+		Filter: ` "status" == "active"; `,
+	}
+
+	cmd := SerializeCreateIndex(index, "users")
+
+	expected := `CREATE UNIQUE HASH INDEX "idx_active_email" ON BUNDLE "users" WITH FIELDS ("tenant_id", "email") WHERE "status" == "active";`
+	if cmd != expected {
+		t.Errorf("expected %q, got %q", expected, cmd)
+	}
+}
+
+func TestSerializeIndexChange(t *testing.T) {
+	oldIndex := &IndexDefinition{Name: "idx_name", Type: BTREE, Fields: []string{"name"}}
+	newIndex := &IndexDefinition{Name: "idx_name", Type: BTREE, Fields: []string{"name", "age"}}
+
+	up, down := SerializeIndexChange("users", &IndexChange{Type: "modify", OldIndex: oldIndex, NewIndex: newIndex})
+	expectedUp := []string{
+		`DROP INDEX "idx_name";`,
+		`CREATE B-INDEX "idx_name" ON BUNDLE "users" WITH FIELDS ("name", "age");`,
+	}
+	expectedDown := []string{
+		`DROP INDEX "idx_name";`,
+		`CREATE B-INDEX "idx_name" ON BUNDLE "users" WITH FIELDS ("name");`,
+	}
+	if strings.Join(up, "\n") != strings.Join(expectedUp, "\n") {
+		t.Errorf("expected up %q, got %q", expectedUp, up)
+	}
+	if strings.Join(down, "\n") != strings.Join(expectedDown, "\n") {
+		t.Errorf("expected down %q, got %q", expectedDown, down)
+	}
+
+	// A removed index can be recreated on rollback
+	up, down = SerializeIndexChange("users", &IndexChange{Type: "remove", OldIndex: oldIndex})
+	if len(up) != 1 || up[0] != `DROP INDEX "idx_name";` || len(down) != 1 || down[0] != expectedDown[1] {
+		t.Errorf("unexpected remove commands: up=%q down=%q", up, down)
+	}
+}
+
+func TestSerializeRelationshipChange(t *testing.T) {
+	oldRel := &RelationshipDefinition{Name: "author", Type: "1toMany", SourceBundle: "users", SourceField: "id", DestBundle: "posts", DestField: "user_id"}
+	newRel := &RelationshipDefinition{Name: "author", Type: "1toMany", SourceBundle: "users", SourceField: "id", DestBundle: "posts", DestField: "author_id"}
+
+	up, down := SerializeRelationshipChange(&RelationshipChange{Type: "modify", BundleName: "users", OldRelationship: oldRel, NewRelationship: newRel})
+	expectedUp := []string{
+		`UPDATE BUNDLE "users" REMOVE RELATIONSHIP "author";`,
+		`UPDATE BUNDLE "users" ADD RELATIONSHIP ("author" {"1toMany", "users", "id", "posts", "author_id"});`,
+	}
+	expectedDown := []string{
+		`UPDATE BUNDLE "users" REMOVE RELATIONSHIP "author";`,
+		`UPDATE BUNDLE "users" ADD RELATIONSHIP ("author" {"1toMany", "users", "id", "posts", "user_id"});`,
+	}
+	if strings.Join(up, "\n") != strings.Join(expectedUp, "\n") {
+		t.Errorf("expected up %q, got %q", expectedUp, up)
+	}
+	if strings.Join(down, "\n") != strings.Join(expectedDown, "\n") {
+		t.Errorf("expected down %q, got %q", expectedDown, down)
+	}
+
+	// A removed relationship can be added back on rollback
+	up, down = SerializeRelationshipChange(&RelationshipChange{Type: "remove", BundleName: "users", OldRelationship: oldRel})
+	if len(up) != 1 || up[0] != expectedUp[0] || len(down) != 1 || down[0] != expectedDown[1] {
+		t.Errorf("unexpected remove commands: up=%q down=%q", up, down)
+	}
+}
+
+func TestSerializeBundleChange_Modify(t *testing.T) {
+	change := &BundleChange{
+		Type:       "modify",
+		BundleName: "users",
+		FieldChanges: []FieldChange{
+			{Type: "add", FieldName: "age", NewField: &FieldDefinition{Name: "age", Type: INT}},
+			{Type: "modify", FieldName: "name",
+				OldField: &FieldDefinition{Name: "name", Type: STRING},
+				NewField: &FieldDefinition{Name: "name", Type: STRING, Required: true}},
+		},
+		IndexChanges: []IndexChange{
+			{Type: "add", NewIndex: &IndexDefinition{Name: "idx_age", Type: BTREE, Fields: []string{"age"}}},
+		},
+	}
+
+	up, down := SerializeBundleChange(change)
+	expectedUp := []string{
+		"UPDATE BUNDLE \"users\"\nSET (\n" +
+			`    {ADD "age" = "age", "INT", FALSE, FALSE, NULL},` + "\n" +
+			`    {MODIFY "name" = "name", "STRING", TRUE, FALSE, NULL}` + "\n);",
+		`CREATE B-INDEX "idx_age" ON BUNDLE "users" WITH FIELDS ("age");`,
+	}
+	expectedDown := []string{
+		`DROP INDEX "idx_age";`,
+		"UPDATE BUNDLE \"users\"\nSET (\n" +
+			`    {REMOVE "age" = "", "", FALSE, FALSE, NULL},` + "\n" +
+			`    {MODIFY "name" = "name", "STRING", FALSE, FALSE, NULL}` + "\n);",
+	}
+	if strings.Join(up, "\n") != strings.Join(expectedUp, "\n") {
+		t.Errorf("expected up %q, got %q", expectedUp, up)
+	}
+	if strings.Join(down, "\n") != strings.Join(expectedDown, "\n") {
+		t.Errorf("expected down %q, got %q", expectedDown, down)
+	}
+}
+
+func TestSerializeSchemaDiff_Order(t *testing.T) {
+	posts := &BundleDefinition{
+		Name:    "posts",
+		Fields:  []FieldDefinition{{Name: "user_id", Type: STRING}},
+		Indexes: []IndexDefinition{{Name: "idx_user", Type: HASH, Fields: []string{"user_id"}}},
+	}
+	diff := &SchemaDiff{
+		BundleChanges: []BundleChange{
+			{Type: "create", BundleName: "posts", NewDefinition: posts},
+			{Type: "delete", BundleName: "legacy", OldDefinition: &BundleDefinition{Name: "legacy"}},
+		},
+		RelationshipChanges: []RelationshipChange{
+			{Type: "add", BundleName: "users", NewRelationship: &RelationshipDefinition{
+				Name: "posts", Type: "1toMany", SourceBundle: "users", SourceField: "id", DestBundle: "posts", DestField: "user_id"}},
+			{Type: "remove", BundleName: "users", OldRelationship: &RelationshipDefinition{
+				Name: "legacy", Type: "1toMany", SourceBundle: "users", SourceField: "id", DestBundle: "legacy", DestField: "user_id"}},
+		},
+	}
+
+	up, down := SerializeSchemaDiff(diff)
+	expectedUp := []string{
+		`UPDATE BUNDLE "users" REMOVE RELATIONSHIP "legacy";`,
+		SerializeCreateBundle(posts),
+		`CREATE HASH INDEX "idx_user" ON BUNDLE "posts" WITH FIELDS ("user_id");`,
+		`DROP BUNDLE "legacy";`,
+		`UPDATE BUNDLE "users" ADD RELATIONSHIP ("posts" {"1toMany", "users", "id", "posts", "user_id"});`,
+	}
+	expectedDown := []string{
+		`UPDATE BUNDLE "users" REMOVE RELATIONSHIP "posts";`,
+		SerializeCreateBundle(&BundleDefinition{Name: "legacy"}),
+		`DROP INDEX "idx_user";`,
+		`DROP BUNDLE "posts";`,
+		`UPDATE BUNDLE "users" ADD RELATIONSHIP ("legacy" {"1toMany", "users", "id", "legacy", "user_id"});`,
+	}
+	if strings.Join(up, "\n") != strings.Join(expectedUp, "\n") {
+		t.Errorf("expected up %q, got %q", expectedUp, up)
+	}
+	if strings.Join(down, "\n") != strings.Join(expectedDown, "\n") {
+		t.Errorf("expected down %q, got %q", expectedDown, down)
+	}
+}
+
 func TestSerializeDropIndex(t *testing.T) {
 	cmd := SerializeDropIndex("idx_email")
 
@@ -7,10 +7,15 @@ const (
 	STRING       FieldType = "STRING"
 	INT          FieldType = "INT"
 	FLOAT        FieldType = "FLOAT"
+	DECIMAL      FieldType = "DECIMAL" // Exact base-10 number, e.g. for monetary amounts
 	BOOLEAN      FieldType = "BOOLEAN"
 	DATETIME     FieldType = "DATETIME"
 	JSON         FieldType = "JSON"
 	TEXT         FieldType = "TEXT"
+	GEOPOINT     FieldType = "GEOPOINT" // GeoJSON Point
+	GEOSHAPE     FieldType = "GEOSHAPE" // GeoJSON geometry, e.g. a Polygon
+	ARRAY        FieldType = "ARRAY"    // List of values described by Items
+	OBJECT       FieldType = "OBJECT"   // Nested document described by Fields
 	RELATIONSHIP FieldType = "relationship"
 )
 
@@ -18,8 +23,9 @@ const (
 type IndexType string
 
 const (
-	HASH  IndexType = "hash"
-	BTREE IndexType = "btree"
+	HASH     IndexType = "hash"
+	BTREE    IndexType = "btree"
+	FULLTEXT IndexType = "fulltext" // Tokenized text for MATCH conditions
 )
 
 // FieldDefinition defines a single field within a bundle.
@@ -30,13 +36,40 @@ type FieldDefinition struct {
 	Unique        bool        `json:"unique"`
 	DefaultValue  interface{} `json:"defaultValue,omitempty"`
 	RelatedBundle string      `json:"relatedBundle,omitempty"` // For relationship fields
+
+	// Items describes the elements of an ARRAY field, and Fields the members
+	// of an OBJECT field. Like constraints, nested shapes are not stored by
+	// the server; they drive code generation and ValidateValue.
+	Items  *FieldDefinition  `json:"items,omitempty"`
+	Fields []FieldDefinition `json:"fields,omitempty"`
+
+	// Constraints restrict the values the field accepts. The server does not
+	// store constraints; they are enforced by generated code and ValidateValue.
+	Constraints *FieldConstraints `json:"constraints,omitempty"`
 }
 
-// IndexDefinition defines an index on a bundle.
+// FieldConstraints restricts the values of a field. Length and pattern
+// constraints apply to STRING and TEXT fields, ranges to INT, FLOAT and DECIMAL fields.
+type FieldConstraints struct {
+	MinLength *int          `json:"minLength,omitempty"`
+	MaxLength *int          `json:"maxLength,omitempty"`
+	Min       *float64      `json:"min,omitempty"`
+	Max       *float64      `json:"max,omitempty"`
+	Enum      []interface{} `json:"enum,omitempty"`
+	Pattern   string        `json:"pattern,omitempty"` // RE2 syntax
+}
+
+// IndexDefinition defines an index on a bundle. Indexes with several fields
+// are composite indexes over the fields in order.
 type IndexDefinition struct {
 	Name   string    `json:"name"`
 	Type   IndexType `json:"type"`
 	Fields []string  `json:"fields"`
+	Unique bool      `json:"unique,omitempty"`
+
+	// Filter makes a partial index over the documents matching a SyndrQL
+	// WHERE condition, e.g. `"status" == "active"`.
+	Filter string `json:"filter,omitempty"`
 }
 
 // RelationshipDefinition defines a relationship between bundles.
@@ -89,7 +122,7 @@ type BundleChange struct {
 
 // RelationshipChange represents a change to a relationship.
 type RelationshipChange struct {
-	Type            string                  `json:"type"` // "add", "remove"
+	Type            string                  `json:"type"` // "add", "remove", "modify"
 	BundleName      string                  `json:"bundleName"`
 	OldRelationship *RelationshipDefinition `json:"oldRelationship,omitempty"`
 	NewRelationship *RelationshipDefinition `json:"newRelationship,omitempty"`
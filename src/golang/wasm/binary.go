@@ -0,0 +1,69 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+// ============================================================================
+// Binary Results
+// ============================================================================
+
+// bytesToJS copies b into a new Uint8Array. The array owns its ArrayBuffer,
+// so it can be transferred between workers with postMessage(bytes, [bytes.buffer]).
+func bytesToJS(b []byte) js.Value {
+	array := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(array, b)
+	return array
+}
+
+// resultToJS converts a promise result to JS. Byte slices become a Uint8Array
+// in a single copy; everything else goes through js.ValueOf.
+func resultToJS(result interface{}) js.Value {
+	if b, ok := result.([]byte); ok {
+		return bytesToJS(b)
+	}
+	return js.ValueOf(result)
+}
+
+// encodeResult returns result as UTF-8 JSON for delivery as a Uint8Array.
+func encodeResult(result interface{}) ([]byte, error) {
+	if raw, ok := result.(json.RawMessage); ok {
+		return raw, nil
+	}
+	return json.Marshal(result)
+}
+
+// queryBinary executes a query and resolves with the JSON-encoded result as a
+// Uint8Array instead of a converted object tree.
+//
+//	const bytes = await SyndrDB.queryBinary(query, 5000);
+//	const rows = JSON.parse(new TextDecoder().decode(bytes));
+//
+// Crossing into JavaScript costs one copy regardless of how deeply nested
+// the result is, and decoding uses the engine's native JSON.parse.
+func queryBinary(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "queryBinary", Type: js.TypeNull}
+		}
+		if len(args) < 1 {
+			return nil, &js.ValueError{Method: "queryBinary", Type: js.TypeUndefined}
+		}
+
+		queryStr := args[0].String()
+		timeout := 0
+		if len(args) > 1 && args[1].Type() == js.TypeNumber {
+			timeout = args[1].Int()
+		}
+
+		result, err := globalClient.Query(queryStr, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return encodeResult(result)
+	})
+}
@@ -0,0 +1,413 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"syscall/js"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// ============================================================================
+// Query Builders
+// ============================================================================
+
+// parseOperator converts a JavaScript operator string to a client.Operator.
+// Accepts the SyndrQL spelling returned by Operator.String() plus "=" as an alias for "==".
+func parseOperator(op string) (client.Operator, error) {
+	switch strings.ToUpper(strings.TrimSpace(op)) {
+	case "==", "=":
+		return client.Equals, nil
+	case "!=", "<>":
+		return client.NotEquals, nil
+	case ">":
+		return client.GreaterThan, nil
+	case "<":
+		return client.LessThan, nil
+	case ">=":
+		return client.GreaterThanOrEqual, nil
+	case "<=":
+		return client.LessThanOrEqual, nil
+	case "LIKE":
+		return client.Like, nil
+	case "ILIKE":
+		return client.ILike, nil
+	case "NOT LIKE":
+		return client.NotLike, nil
+	case "NOT ILIKE":
+		return client.NotILike, nil
+	case "IN":
+		return client.In, nil
+	case "NOT IN":
+		return client.NotIn, nil
+	case "IS NULL":
+		return client.IsNull, nil
+	case "IS NOT NULL":
+		return client.IsNotNull, nil
+	default:
+		return client.Equals, fmt.Errorf("unsupported operator: %s", op)
+	}
+}
+
+// parseDirection converts a JavaScript sort direction ("ASC"/"DESC") to a client.Direction.
+func parseDirection(args []js.Value, index int) client.Direction {
+	if len(args) > index && args[index].Type() == js.TypeString &&
+		strings.EqualFold(args[index].String(), "DESC") {
+		return client.Descending
+	}
+	return client.Ascending
+}
+
+// whereArgs extracts (field, operator, value) from builder method arguments.
+// The value is optional for IS NULL / IS NOT NULL conditions.
+func whereArgs(method string, args []js.Value) (string, client.Operator, interface{}, error) {
+	if len(args) < 2 {
+		return "", client.Equals, nil, fmt.Errorf("%s requires field and operator arguments", method)
+	}
+
+	op, err := parseOperator(args[1].String())
+	if err != nil {
+		return "", client.Equals, nil, err
+	}
+
+	var value interface{}
+	if len(args) > 2 {
+		value = jsValueToGo(args[2])
+	}
+
+	return args[0].String(), op, value, nil
+}
+
+// stringArgs collects string arguments starting at index, flattening a single array argument.
+func stringArgs(args []js.Value, start int) []string {
+	var values []string
+	for i := start; i < len(args); i++ {
+		arg := args[i]
+		if arg.Type() == js.TypeObject && arg.Get("length").Type() == js.TypeNumber {
+			for j := 0; j < arg.Length(); j++ {
+				values = append(values, arg.Index(j).String())
+			}
+			continue
+		}
+		if arg.Type() == js.TypeString {
+			values = append(values, arg.String())
+		}
+	}
+	return values
+}
+
+// builderObject creates a chainable JavaScript object.
+// Each entry in methods is exposed as a function that returns the object itself;
+// an error from the method is recorded and reported by execute(). Each entry in
+// accessors is exposed as a plain, non-chainable function.
+//
+// A builder is single-use: once the promise returned by execute() settles, its
+// callbacks are released and removed from the object.
+func builderObject(methods map[string]func(args []js.Value) error, accessors map[string]func(args []js.Value) interface{}, execute func() (interface{}, error)) js.Value {
+	obj := js.Global().Get("Object").New()
+	var buildErr error
+	var funcs []js.Func
+	names := make([]string, 0, len(methods)+len(accessors)+1)
+
+	set := func(name string, fn func(this js.Value, args []js.Value) interface{}) {
+		f := js.FuncOf(fn)
+		funcs = append(funcs, f)
+		names = append(names, name)
+		obj.Set(name, f)
+	}
+
+	var releaseOnce sync.Once
+	release := func() {
+		releaseOnce.Do(func() {
+			for _, name := range names {
+				obj.Delete(name)
+			}
+			for _, f := range funcs {
+				f.Release()
+			}
+		})
+	}
+
+	for name, method := range methods {
+		method := method
+		set(name, func(this js.Value, args []js.Value) interface{} {
+			if buildErr == nil {
+				buildErr = method(args)
+			}
+			return obj
+		})
+	}
+
+	for name, accessor := range accessors {
+		accessor := accessor
+		set(name, func(this js.Value, args []js.Value) interface{} {
+			return accessor(args)
+		})
+	}
+
+	set("execute", func(this js.Value, args []js.Value) interface{} {
+		return promiseWrapper(func() (interface{}, error) {
+			defer release()
+			if buildErr != nil {
+				return nil, buildErr
+			}
+			return execute()
+		})
+	})
+
+	return obj
+}
+
+// selectBuilder creates a SELECT query builder.
+// Args: bundle (string), fields (...string | string[])
+// Returns: builder with where/and/or/orderBy/limit/offset/include/joins/withValidation/fingerprint/execute
+func selectBuilder(this js.Value, args []js.Value) interface{} {
+	if globalClient == nil {
+		return js.ValueOf(map[string]interface{}{"error": "client not initialized"})
+	}
+	if len(args) < 1 {
+		return js.ValueOf(map[string]interface{}{"error": "bundle name is required"})
+	}
+
+	qb := globalClient.QueryBuilder().Select(args[0].String(), stringArgs(args, 1)...)
+
+	return builderObject(map[string]func(args []js.Value) error{
+		"where": func(args []js.Value) error {
+			field, op, value, err := whereArgs("where", args)
+			if err == nil {
+				qb.Where(field, op, value)
+			}
+			return err
+		},
+		"and": func(args []js.Value) error {
+			field, op, value, err := whereArgs("and", args)
+			if err == nil {
+				qb.And(field, op, value)
+			}
+			return err
+		},
+		"or": func(args []js.Value) error {
+			field, op, value, err := whereArgs("or", args)
+			if err == nil {
+				qb.Or(field, op, value)
+			}
+			return err
+		},
+		"orderBy": func(args []js.Value) error {
+			if len(args) < 1 {
+				return fmt.Errorf("orderBy requires a field argument")
+			}
+			qb.OrderBy(args[0].String(), parseDirection(args, 1))
+			return nil
+		},
+		"limit": func(args []js.Value) error {
+			if len(args) < 1 || args[0].Type() != js.TypeNumber {
+				return fmt.Errorf("limit requires a numeric argument")
+			}
+			qb.Limit(args[0].Int())
+			return nil
+		},
+		"offset": func(args []js.Value) error {
+			if len(args) < 1 || args[0].Type() != js.TypeNumber {
+				return fmt.Errorf("offset requires a numeric argument")
+			}
+			qb.Offset(args[0].Int())
+			return nil
+		},
+		"include": func(args []js.Value) error {
+			for _, relationship := range stringArgs(args, 0) {
+				qb.Include(relationship)
+			}
+			return nil
+		},
+		"leftJoin": func(args []js.Value) error {
+			if len(args) < 3 {
+				return fmt.Errorf("leftJoin requires bundle, source field and target field")
+			}
+			qb.LeftJoin(args[0].String(), args[1].String(), args[2].String())
+			return nil
+		},
+		"innerJoin": func(args []js.Value) error {
+			if len(args) < 3 {
+				return fmt.Errorf("innerJoin requires bundle, source field and target field")
+			}
+			qb.InnerJoin(args[0].String(), args[1].String(), args[2].String())
+			return nil
+		},
+		"rightJoin": func(args []js.Value) error {
+			if len(args) < 3 {
+				return fmt.Errorf("rightJoin requires bundle, source field and target field")
+			}
+			qb.RightJoin(args[0].String(), args[1].String(), args[2].String())
+			return nil
+		},
+		"withValidation": func(args []js.Value) error {
+			qb.WithValidation(len(args) == 0 || args[0].Truthy())
+			return nil
+		},
+	}, map[string]func(args []js.Value) interface{}{
+		// fingerprint is synchronous and not chainable
+		"fingerprint": func(args []js.Value) interface{} {
+			return js.ValueOf(qb.Fingerprint())
+		},
+	}, func() (interface{}, error) {
+		return qb.Execute(context.Background())
+	})
+}
+
+// insertBuilder creates an INSERT builder.
+// Args: bundle (string)
+// Returns: builder with values/withValidation/execute
+func insertBuilder(this js.Value, args []js.Value) interface{} {
+	if globalClient == nil {
+		return js.ValueOf(map[string]interface{}{"error": "client not initialized"})
+	}
+	if len(args) < 1 {
+		return js.ValueOf(map[string]interface{}{"error": "bundle name is required"})
+	}
+
+	ib := globalClient.InsertBuilder(args[0].String())
+
+	return builderObject(map[string]func(args []js.Value) error{
+		"values": func(args []js.Value) error {
+			if len(args) < 1 || args[0].Type() != js.TypeObject {
+				return fmt.Errorf("values requires an object argument")
+			}
+			data, ok := jsValueToGo(args[0]).(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("values requires an object argument")
+			}
+			ib.Values(data)
+			return nil
+		},
+		"withValidation": func(args []js.Value) error {
+			ib.WithValidation(len(args) == 0 || args[0].Truthy())
+			return nil
+		},
+	}, nil, func() (interface{}, error) {
+		result, err := ib.Execute(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return mutationResultToJS(result), nil
+	})
+}
+
+// updateBuilder creates an UPDATE builder.
+// Args: bundle (string)
+// Returns: builder with set/where/and/or/withValidation/execute
+func updateBuilder(this js.Value, args []js.Value) interface{} {
+	if globalClient == nil {
+		return js.ValueOf(map[string]interface{}{"error": "client not initialized"})
+	}
+	if len(args) < 1 {
+		return js.ValueOf(map[string]interface{}{"error": "bundle name is required"})
+	}
+
+	ub := globalClient.UpdateBuilder(args[0].String())
+
+	return builderObject(map[string]func(args []js.Value) error{
+		// set accepts either (field, value) or an object of field/value pairs
+		"set": func(args []js.Value) error {
+			if len(args) == 1 && args[0].Type() == js.TypeObject {
+				data, ok := jsValueToGo(args[0]).(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("set requires (field, value) or an object argument")
+				}
+				for field, value := range data {
+					ub.Set(field, value)
+				}
+				return nil
+			}
+			if len(args) < 2 {
+				return fmt.Errorf("set requires (field, value) or an object argument")
+			}
+			ub.Set(args[0].String(), jsValueToGo(args[1]))
+			return nil
+		},
+		"where": func(args []js.Value) error {
+			field, op, value, err := whereArgs("where", args)
+			if err == nil {
+				ub.Where(field, op, value)
+			}
+			return err
+		},
+		"and": func(args []js.Value) error {
+			field, op, value, err := whereArgs("and", args)
+			if err == nil {
+				ub.And(field, op, value)
+			}
+			return err
+		},
+		"or": func(args []js.Value) error {
+			field, op, value, err := whereArgs("or", args)
+			if err == nil {
+				ub.Or(field, op, value)
+			}
+			return err
+		},
+		"withValidation": func(args []js.Value) error {
+			ub.WithValidation(len(args) == 0 || args[0].Truthy())
+			return nil
+		},
+	}, nil, func() (interface{}, error) {
+		result, err := ub.Execute(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return mutationResultToJS(result), nil
+	})
+}
+
+// deleteBuilder creates a DELETE builder.
+// Args: bundle (string)
+// Returns: builder with where/and/or/withValidation/execute
+func deleteBuilder(this js.Value, args []js.Value) interface{} {
+	if globalClient == nil {
+		return js.ValueOf(map[string]interface{}{"error": "client not initialized"})
+	}
+	if len(args) < 1 {
+		return js.ValueOf(map[string]interface{}{"error": "bundle name is required"})
+	}
+
+	db := globalClient.DeleteBuilder(args[0].String())
+
+	return builderObject(map[string]func(args []js.Value) error{
+		"where": func(args []js.Value) error {
+			field, op, value, err := whereArgs("where", args)
+			if err == nil {
+				db.Where(field, op, value)
+			}
+			return err
+		},
+		"and": func(args []js.Value) error {
+			field, op, value, err := whereArgs("and", args)
+			if err == nil {
+				db.And(field, op, value)
+			}
+			return err
+		},
+		"or": func(args []js.Value) error {
+			field, op, value, err := whereArgs("or", args)
+			if err == nil {
+				db.Or(field, op, value)
+			}
+			return err
+		},
+		"withValidation": func(args []js.Value) error {
+			db.WithValidation(len(args) == 0 || args[0].Truthy())
+			return nil
+		},
+	}, nil, func() (interface{}, error) {
+		result, err := db.Execute(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return mutationResultToJS(result), nil
+	})
+}
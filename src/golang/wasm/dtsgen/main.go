@@ -0,0 +1,244 @@
+// Command dtsgen generates syndrdb.d.ts, the TypeScript definitions of the
+// SyndrDB global installed by the WASM module, from the export table in
+// wasm/main.go. Every export must have a signature in signatures.go and every
+// signature an export, so the definitions cannot drift from the module.
+//
+// Usage, from this directory:
+//
+//	go generate
+package main
+
+//go:generate go run . -wasm .. -out ../syndrdb.d.ts
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// milestoneSuffix matches the "(Milestone N)" notes on section comments,
+// which mean nothing to TypeScript users.
+var milestoneSuffix = regexp.MustCompile(`\s*\(Milestone \d+\)`)
+
+// export is one entry of the makeExports table.
+type export struct {
+	name     string // JavaScript name
+	funcName string // Go function implementing it
+	section  string // Comment heading the group of exports
+	doc      string // Go doc comment of funcName
+}
+
+func main() {
+	wasmDir := flag.String("wasm", "..", "Directory containing the WASM module sources")
+	out := flag.String("out", "../syndrdb.d.ts", "TypeScript definitions file to write")
+	flag.Parse()
+
+	data, err := generate(*wasmDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dtsgen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "dtsgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the TypeScript definitions for the module in wasmDir.
+func generate(wasmDir string) ([]byte, error) {
+	exports, err := parseExports(wasmDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSignatures(exports); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by dtsgen from the export table in wasm/main.go. DO NOT EDIT.\n")
+	buf.WriteString("// Run `go generate ./wasm/dtsgen` after changing the exports.\n\n")
+	buf.WriteString(strings.TrimSpace(declarations))
+	buf.WriteString("\n\n/** The SyndrDB global installed by syndrdb.wasm. */\n")
+	buf.WriteString("export interface SyndrDBModule {\n")
+
+	section := ""
+	for i, e := range exports {
+		if e.section != section {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			fmt.Fprintf(&buf, "    // %s\n\n", e.section)
+			section = e.section
+		}
+		if summary := docSummary(e.funcName, e.doc); summary != "" {
+			fmt.Fprintf(&buf, "    /** %s */\n", summary)
+		}
+		fmt.Fprintf(&buf, "    %s;\n", signatures[e.name])
+	}
+
+	buf.WriteString("}\n\ndeclare global {\n    var SyndrDB: SyndrDBModule;\n")
+	buf.WriteString("    /** Defined by syndrdb-worker-client.js. */\n")
+	buf.WriteString("    function createSyndrDBWorker(options?: SyndrDBWorkerOptions): SyndrDBWorker;\n}\n")
+	return buf.Bytes(), nil
+}
+
+// checkSignatures reports exports without a signature and signatures without an export.
+func checkSignatures(exports []export) error {
+	exported := make(map[string]bool, len(exports))
+	var problems []string
+	for _, e := range exports {
+		exported[e.name] = true
+		if _, ok := signatures[e.name]; !ok {
+			problems = append(problems, fmt.Sprintf("export %q has no signature in signatures.go", e.name))
+		}
+	}
+	for name := range signatures {
+		if !exported[name] {
+			problems = append(problems, fmt.Sprintf("signature %q has no export in makeExports", name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// parseExports returns the entries of the makeExports table in wasmDir, in order.
+func parseExports(wasmDir string) ([]export, error) {
+	paths, err := filepath.Glob(filepath.Join(wasmDir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	docs := make(map[string]string)
+	var table *ast.FuncDecl
+	var tableFile *ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			docs[fn.Name.Name] = fn.Doc.Text()
+			if fn.Name.Name == "makeExports" {
+				table, tableFile = fn, file
+			}
+		}
+	}
+	if table == nil {
+		return nil, fmt.Errorf("makeExports not found in %s", wasmDir)
+	}
+
+	var exports []export
+	section := ""
+	prevEnd := table.Body.Lbrace
+	for _, stmt := range table.Body.List {
+		for _, group := range tableFile.Comments {
+			if group.Pos() > prevEnd && group.End() < stmt.Pos() {
+				section = strings.TrimSpace(milestoneSuffix.ReplaceAllString(group.Text(), ""))
+			}
+		}
+		prevEnd = stmt.End()
+
+		name, funcName, ok := exportAssignment(stmt)
+		if !ok {
+			continue
+		}
+		exports = append(exports, export{name: name, funcName: funcName, section: section, doc: docs[funcName]})
+	}
+	if len(exports) == 0 {
+		return nil, fmt.Errorf("no exports found in makeExports")
+	}
+	return exports, nil
+}
+
+// exportAssignment matches exports["name"] = js.FuncOf(fn), including
+// js.FuncOf(nodeOnlyExport("name", fn)).
+func exportAssignment(stmt ast.Stmt) (name, funcName string, ok bool) {
+	assign, isAssign := stmt.(*ast.AssignStmt)
+	if !isAssign || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return "", "", false
+	}
+	index, isIndex := assign.Lhs[0].(*ast.IndexExpr)
+	if !isIndex {
+		return "", "", false
+	}
+	if ident, isIdent := index.X.(*ast.Ident); !isIdent || ident.Name != "exports" {
+		return "", "", false
+	}
+	lit, isLit := index.Index.(*ast.BasicLit)
+	if !isLit || lit.Kind != token.STRING {
+		return "", "", false
+	}
+	name, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", "", false
+	}
+
+	call, isCall := assign.Rhs[0].(*ast.CallExpr)
+	if !isCall || len(call.Args) != 1 {
+		return name, "", true
+	}
+	switch arg := call.Args[0].(type) {
+	case *ast.Ident:
+		funcName = arg.Name
+	case *ast.CallExpr:
+		if len(arg.Args) > 0 {
+			if ident, isIdent := arg.Args[len(arg.Args)-1].(*ast.Ident); isIdent {
+				funcName = ident.Name
+			}
+		}
+	}
+	return name, funcName, true
+}
+
+// docSummary turns the first paragraph of a Go doc comment into a JSDoc
+// sentence, dropping the leading function name and Args/Returns lines.
+func docSummary(funcName, doc string) string {
+	var lines []string
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "Args:") || strings.HasPrefix(line, "Returns:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	summary := strings.Join(lines, " ")
+	summary = strings.TrimPrefix(summary, funcName+" ")
+	if summary == "" {
+		return ""
+	}
+
+	runes := []rune(summary)
+	runes[0] = unicode.ToUpper(runes[0])
+	summary = strings.ReplaceAll(string(runes), "*/", "* /")
+	if !strings.HasSuffix(summary, ".") {
+		summary += "."
+	}
+	return summary
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedFileUpToDate fails when syndrdb.d.ts no longer matches the export table.
+func TestGeneratedFileUpToDate(t *testing.T) {
+	want, err := generate("..")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	got, err := os.ReadFile("../syndrdb.d.ts")
+	if err != nil {
+		t.Fatalf("failed to read syndrdb.d.ts: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Error("syndrdb.d.ts is out of date; run go generate ./wasm/dtsgen")
+	}
+}
+
+// TestParseExports verifies exports are read in table order with their sections and Go functions.
+func TestParseExports(t *testing.T) {
+	exports, err := parseExports("..")
+	if err != nil {
+		t.Fatalf("parseExports failed: %v", err)
+	}
+	if exports[0].name != "createClient" || exports[0].section != "Client methods" {
+		t.Errorf("unexpected first export: %+v", exports[0])
+	}
+
+	byName := make(map[string]export)
+	for _, e := range exports {
+		byName[e.name] = e
+	}
+	if e := byName["select"]; e.funcName != "selectBuilder" {
+		t.Errorf("expected select to map to selectBuilder, got %q", e.funcName)
+	}
+	if e := byName["saveMigrationFile"]; e.funcName != "saveMigrationFile" || e.section != "Migration file operations (Node.js only)" {
+		t.Errorf("unexpected node-only export: %+v", e)
+	}
+	if e := byName["ping"]; e.section != "Health and monitoring" {
+		t.Errorf("expected milestone note stripped from section, got %q", e.section)
+	}
+}
+
+// TestCheckSignatures verifies missing and stale signatures are both reported.
+func TestCheckSignatures(t *testing.T) {
+	exports := []export{{name: "query"}, {name: "notInSignatures"}}
+	err := checkSignatures(exports)
+	if err == nil {
+		t.Fatal("expected error for mismatched signatures")
+	}
+	if !strings.Contains(err.Error(), `export "notInSignatures" has no signature`) {
+		t.Errorf("expected missing signature reported, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `signature "connect" has no export`) {
+		t.Errorf("expected stale signature reported, got %v", err)
+	}
+}
+
+// TestDocSummary verifies Go doc comments are reduced to a JSDoc sentence.
+func TestDocSummary(t *testing.T) {
+	tests := []struct {
+		funcName string
+		doc      string
+		want     string
+	}{
+		{"ping", "ping checks connection health.\nArgs: timeoutMs\n\nMore detail.\n", "Checks connection health."},
+		{"query", "query executes a query\n", "Executes a query."},
+		{"cleanup", "", ""},
+		{"x", "x closes a /* comment */ block.\n", "Closes a /* comment * / block."},
+	}
+	for _, tt := range tests {
+		if got := docSummary(tt.funcName, tt.doc); got != tt.want {
+			t.Errorf("docSummary(%q) = %q, want %q", tt.funcName, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,416 @@
+package main
+
+// declarations are the types shared by the export signatures.
+const declarations = `
+/** Connection states reported by getState and onStateChange. */
+export type ConnectionState = "DISCONNECTED" | "CONNECTING" | "CONNECTED" | "DISCONNECTING";
+
+/** Log levels accepted by setLogLevel. */
+export type LogLevel = "DEBUG" | "INFO" | "WARN" | "ERROR";
+
+/** A raw server response: rows, a document, or a status object. */
+export type ServerResponse = any;
+
+/** Result of exports that only report success. */
+export interface SuccessResult {
+    success: boolean;
+    message?: string;
+}
+
+/** Returned instead of throwing by synchronous exports called in the wrong state. */
+export interface ErrorResult {
+    error: string;
+}
+
+/** Resolved by Node.js-only exports when called in a browser. */
+export interface NodeOnlyResult {
+    error: string;
+    feature: string;
+}
+
+/** Rejection value of every Promise-returning export. */
+export interface SyndrDBError {
+    message: string;
+    error: string;
+}
+
+export interface StateTransition {
+    from: ConnectionState;
+    to: ConnectionState;
+    /** Unix timestamp in milliseconds. */
+    timestamp: number;
+    /** Time spent in the previous state, in milliseconds. */
+    duration: number;
+    error?: string;
+    metadata?: Record<string, any>;
+}
+
+export interface MutationResult {
+    /** -1 when the server does not report a count. */
+    affectedCount: number;
+    insertedIds: string[];
+    warnings: string[];
+    result: ServerResponse;
+    /** True when the write was added to the offline queue instead of sent. */
+    queued?: boolean;
+    queueId?: number;
+}
+
+export interface QueryStreamBatchInfo {
+    index: number;
+    offset: number;
+    total: number;
+}
+
+export interface QueryStreamOptions {
+    /** Rows per batch. Default: 500. */
+    batchSize?: number;
+    /** Query timeout in milliseconds. */
+    timeout?: number;
+    /** Called for each batch; a returned Promise delays the next batch. */
+    onBatch?: (rows: any[] | Uint8Array, info: QueryStreamBatchInfo) => void | Promise<void>;
+    /** Pass onBatch a Uint8Array of the batch's JSON instead of converted rows. */
+    binary?: boolean;
+    /** Called for each row; a returned Promise delays the next row. */
+    onRow?: (row: any, index: number) => void | Promise<void>;
+}
+
+export interface QueryStreamSummary {
+    delivered: number;
+    total: number;
+    cancelled: boolean;
+}
+
+export interface QueryStreamController {
+    pause(): void;
+    resume(): void;
+    cancel(): void;
+    done: Promise<QueryStreamSummary>;
+}
+
+export interface ConnectionHealth {
+    connected: boolean;
+    state: ConnectionState;
+}
+
+export interface EnvironmentInfo {
+    runtime: "nodejs" | "browser";
+    fileSystemSupport: boolean;
+    lockingSupport: boolean;
+}
+
+export type StorageBackend = "indexeddb" | "localstorage" | "memory";
+
+export interface StorageOptions {
+    /** Default: "auto" (IndexedDB, then localStorage, then memory). */
+    backend?: StorageBackend | "auto";
+    /** IndexedDB database name or localStorage key prefix. Default: "syndrdb". */
+    name?: string;
+}
+
+export interface SchemaSnapshot {
+    schema: { bundles: any[] };
+    /** True when served from storage because the server could not be reached. */
+    cached: boolean;
+    /** Unix timestamp in milliseconds. */
+    savedAt: number;
+}
+
+export interface OfflineEntry {
+    id: number;
+    command: string;
+    /** Unix timestamp in milliseconds. */
+    queuedAt: number;
+    attempts: number;
+    lastError?: string;
+}
+
+export interface OfflineQueueOptions {
+    /** Default: 1000. */
+    maxEntries?: number;
+    /** Replay when the client reconnects. Default: true. */
+    autoReplay?: boolean;
+    /** Return "stop" to keep a rejected entry and stop replaying; anything else skips it. */
+    onConflict?: (entry: OfflineEntry, error: string) => "skip" | "stop" | void | Promise<"skip" | "stop" | void>;
+}
+
+export interface ReplayResult {
+    replayed: number;
+    skipped: number;
+    remaining: number;
+    error?: string;
+}
+
+export interface PreparedStatement {
+    statementId: string;
+    paramCount: number;
+    query: string;
+}
+
+export interface TransactionHandle {
+    transactionId: string;
+}
+
+export type Operator = "==" | "=" | "!=" | "<>" | ">" | "<" | ">=" | "<=" | "LIKE" | "ILIKE" | "NOT LIKE" | (string & {});
+
+export type Direction = "ASC" | "DESC";
+
+interface Executable<T> {
+    /** Builds and runs the command; validation and argument errors reject here. The builder cannot be used once the promise settles. */
+    execute(): Promise<T>;
+}
+
+interface Filterable<Self> {
+    where(field: string, op: Operator, value?: unknown): Self;
+    and(field: string, op: Operator, value?: unknown): Self;
+    or(field: string, op: Operator, value?: unknown): Self;
+    withValidation(enabled?: boolean): Self;
+}
+
+export interface SelectBuilder extends Filterable<SelectBuilder>, Executable<ServerResponse> {
+    orderBy(field: string, direction?: Direction): SelectBuilder;
+    limit(n: number): SelectBuilder;
+    offset(n: number): SelectBuilder;
+    include(...relationships: Array<string | string[]>): SelectBuilder;
+    leftJoin(bundle: string, sourceField: string, targetField: string): SelectBuilder;
+    innerJoin(bundle: string, sourceField: string, targetField: string): SelectBuilder;
+    rightJoin(bundle: string, sourceField: string, targetField: string): SelectBuilder;
+    /** The query's fingerprint, with literal values normalized. */
+    fingerprint(): string;
+}
+
+export interface InsertBuilder extends Executable<MutationResult> {
+    values(data: Record<string, unknown>): InsertBuilder;
+    withValidation(enabled?: boolean): InsertBuilder;
+}
+
+export interface UpdateBuilder extends Filterable<UpdateBuilder>, Executable<MutationResult> {
+    set(field: string, value: unknown): UpdateBuilder;
+    set(values: Record<string, unknown>): UpdateBuilder;
+}
+
+export interface DeleteBuilder extends Filterable<DeleteBuilder>, Executable<MutationResult> {}
+
+export interface Migration {
+    id: string;
+    name: string;
+    up: string[];
+    down: string[];
+    dependencies?: string[];
+    /** RFC 3339 timestamp. */
+    timestamp: string;
+}
+
+export interface DestructiveChange {
+    migrationId: string;
+    commandIndex: number;
+    command: string;
+    reason: string;
+}
+
+export interface MigrationPlan {
+    migrations: Migration[];
+    direction: "up" | "down";
+    totalCount: number;
+    dryRun?: boolean;
+    destructive?: DestructiveChange[];
+    allowDestructive?: boolean;
+}
+
+export interface MigrationConflict {
+    type: "checksum_mismatch" | "dependency_conflict" | "order_conflict";
+    migrationId: string;
+    message: string;
+    expected?: string;
+    actual?: string;
+}
+
+export interface ValidationResult {
+    valid: boolean;
+    conflicts: MigrationConflict[];
+    pendingMigrations: string[];
+    appliedMigrations: string[];
+    destructive?: DestructiveChange[];
+}
+
+export interface MigrationRecord {
+    migrationId: string;
+    appliedAt: string;
+    rolledBackAt?: string;
+    status: "pending" | "applied" | "failed" | "rolled_back";
+    executionTimeMs: number;
+    error?: string;
+    checksum: string;
+}
+
+/** Runs the commands of a SCRIPT "name"; migration step. */
+export type MigrationScript = (exec: { execute(command: string): Promise<ServerResponse> }) => void | Promise<void>;
+
+export interface HookContext {
+    command: string;
+    commandType: string;
+    traceId: string;
+    /** Unix timestamp in milliseconds. */
+    startTime: number;
+    params?: any[];
+    metadata?: Record<string, any>;
+    attempt: number;
+    /** Available in after hooks. */
+    result?: string;
+    error?: string;
+    durationMs?: number;
+    connectionId?: number;
+    remoteAddr?: string;
+    poolWaitMs?: number;
+}
+
+export interface HookConfig {
+    name: string;
+    /** Higher priorities run first. Default: 0. */
+    priority?: number;
+    /** May return a modified context; a rejection aborts the command. */
+    before?: (ctx: HookContext) => HookContext | void | Promise<HookContext | void>;
+    after?: (ctx: HookContext) => void | Promise<void>;
+}
+
+export interface LoggingHookOptions {
+    logCommands?: boolean;
+    logResults?: boolean;
+    logDurations?: boolean;
+}
+
+export interface BuiltinHookResult extends SuccessResult {
+    name: string;
+}
+
+/** Options for createSyndrDBWorker in syndrdb-worker-client.js. */
+export interface SyndrDBWorkerOptions {
+    /** Default: "syndrdb.worker.js". */
+    workerUrl?: string;
+    /** Resolved relative to the worker script. Default: "syndrdb.wasm". */
+    wasmUrl?: string;
+    /** Resolved relative to the worker script. Default: "wasm_exec.js". */
+    wasmExecUrl?: string;
+    /** An already constructed worker running syndrdb.worker.js. */
+    worker?: Worker;
+}
+
+type WorkerBuilder<B> = {
+    [K in keyof B]: B[K] extends (...args: infer A) => infer R
+        ? (...args: A) => R extends Promise<any> ? R : R extends string ? Promise<R> : WorkerBuilder<B>
+        : never;
+};
+
+type WorkerMethod<F> = F extends (...args: infer A) => infer R
+    ? (...args: A) => R extends SelectBuilder | InsertBuilder | UpdateBuilder | DeleteBuilder | ErrorResult
+        ? WorkerBuilder<Exclude<R, ErrorResult>>
+        : Promise<Awaited<R>>
+    : never;
+
+/** Exports that take functions, which cannot be posted to the worker. */
+type WorkerExcluded = "queryStream" | "onStateChange" | "registerHook" | "registerMigrationScript" | "inTransaction";
+
+/** The main-thread proxy returned by createSyndrDBWorker. Every export returns a Promise. */
+export type SyndrDBWorker = {
+    [K in Exclude<keyof SyndrDBModule, WorkerExcluded>]: WorkerMethod<SyndrDBModule[K]>;
+} & {
+    /** Resolves once the module has loaded in the worker. */
+    ready: Promise<{ version: string; methods: string[] }>;
+    worker: Worker;
+    call(method: keyof SyndrDBModule, ...args: unknown[]): Promise<any>;
+    queryStream(query: string, options?: QueryStreamOptions): QueryStreamController;
+    on(event: "stateChange", listener: (transition: StateTransition) => void): void;
+    on(event: "error", listener: (error: Error) => void): void;
+    off(event: "stateChange" | "error", listener: (...args: any[]) => void): void;
+    /** Runs cleanup() in the worker and terminates it. */
+    terminate(): Promise<void>;
+};
+`
+
+// signatures maps each export to its TypeScript method signature.
+var signatures = map[string]string{
+	// Client methods
+	"createClient":  "createClient(optionsJSON?: string | null): Promise<SuccessResult>",
+	"connect":       "connect(connectionString: string): Promise<SuccessResult>",
+	"disconnect":    "disconnect(): Promise<SuccessResult>",
+	"query":         "query(query: string, timeoutMs?: number): Promise<ServerResponse>",
+	"mutate":        "mutate(mutation: string, timeoutMs?: number): Promise<MutationResult>",
+	"queryStream":   "queryStream(query: string, options?: QueryStreamOptions): QueryStreamController",
+	"queryBinary":   "queryBinary(query: string, timeoutMs?: number): Promise<Uint8Array>",
+	"getState":      "getState(): ConnectionState",
+	"onStateChange": "onStateChange(callback: (transition: StateTransition) => void): SuccessResult | ErrorResult",
+	"getVersion":    "getVersion(): string",
+
+	// Health, logging and debugging
+	"ping":                "ping(timeoutMs?: number): Promise<SuccessResult>",
+	"getConnectionHealth": "getConnectionHealth(): ConnectionHealth",
+	"setLogLevel":         "setLogLevel(level: LogLevel): { success: boolean; level: string } | ErrorResult",
+	"enableDebugMode":     "enableDebugMode(): { success: boolean; debugEnabled: boolean } | ErrorResult",
+	"disableDebugMode":    "disableDebugMode(): { success: boolean; debugEnabled: boolean } | ErrorResult",
+	"getDebugInfo":        "getDebugInfo(): Promise<Record<string, any>>",
+
+	// Schema
+	"generateJSONSchema":    `generateJSONSchema(schemaJSON: string, mode?: "single" | "multi"): Promise<string | Record<string, string>>`,
+	"generateGraphQLSchema": "generateGraphQLSchema(schemaJSON: string): Promise<string>",
+
+	// Migrations
+	"createMigrationClient":   "createMigrationClient(): Promise<SuccessResult>",
+	"planMigration":           "planMigration(migrations: Migration[]): Promise<MigrationPlan>",
+	"applyMigration":          "applyMigration(plan: MigrationPlan): Promise<SuccessResult>",
+	"getMigrationHistory":     "getMigrationHistory(): Promise<MigrationRecord[]>",
+	"validateMigration":       "validateMigration(migrations: Migration[]): Promise<ValidationResult>",
+	"rollbackMigration":       "rollbackMigration(migrationId: string, migrations: Migration[]): Promise<SuccessResult>",
+	"previewMigration":        "previewMigration(migrations: Migration[]): Promise<{ preview: string; plan: MigrationPlan }>",
+	"registerMigrationScript": "registerMigrationScript(name: string, fn: MigrationScript): Promise<SuccessResult>",
+	"saveMigrationFile":       "saveMigrationFile(migration: Migration, directory: string): Promise<{ success: boolean; path: string } | NodeOnlyResult>",
+	"loadMigrationFile":       "loadMigrationFile(path: string): Promise<Migration | NodeOnlyResult>",
+	"listMigrations":          "listMigrations(directory: string): Promise<Migration[] | NodeOnlyResult>",
+	"acquireMigrationLock":    "acquireMigrationLock(directory: string): Promise<SuccessResult | NodeOnlyResult>",
+	"releaseMigrationLock":    "releaseMigrationLock(): Promise<SuccessResult | NodeOnlyResult>",
+	"getEnvironmentInfo":      "getEnvironmentInfo(): Promise<EnvironmentInfo>",
+
+	// Browser persistence
+	"configureStorage":  "configureStorage(options?: StorageOptions): Promise<{ backend: StorageBackend; name: string }>",
+	"getSchema":         "getSchema(): Promise<SchemaSnapshot>",
+	"restoreStatements": "restoreStatements(): Promise<{ restored: string[]; failed: Array<{ statementId: string; error: string }> }>",
+	"clearStorage":      "clearStorage(prefix?: string): Promise<{ removed: number }>",
+
+	// Offline write queue
+	"enableOfflineQueue":  "enableOfflineQueue(options?: OfflineQueueOptions): Promise<{ success: boolean; pending: number }>",
+	"disableOfflineQueue": "disableOfflineQueue(): Promise<SuccessResult>",
+	"getOfflineQueue":     "getOfflineQueue(): Promise<{ entries: OfflineEntry[]; size: number }>",
+	"removeOfflineEntry":  "removeOfflineEntry(id: number): Promise<SuccessResult>",
+	"clearOfflineQueue":   "clearOfflineQueue(): Promise<SuccessResult>",
+	"replayOfflineQueue":  "replayOfflineQueue(): Promise<ReplayResult>",
+
+	// Parameterized queries and transactions
+	"prepare":             "prepare(statementName: string, query: string): Promise<PreparedStatement>",
+	"executeStatement":    "executeStatement(statementId: string, params?: unknown[]): Promise<ServerResponse>",
+	"deallocateStatement": "deallocateStatement(statementId: string): Promise<SuccessResult>",
+	"queryWithParams":     "queryWithParams(query: string, params?: unknown[]): Promise<ServerResponse>",
+	"beginTransaction":    "beginTransaction(): Promise<TransactionHandle>",
+	"commitTransaction":   "commitTransaction(transactionId: string): Promise<SuccessResult>",
+	"rollbackTransaction": "rollbackTransaction(transactionId: string): Promise<SuccessResult>",
+	"inTransaction":       "inTransaction<T>(callback: (tx: TransactionHandle) => T | Promise<T>): Promise<T>",
+
+	// Query builders
+	"select": "select(bundle: string, ...fields: Array<string | string[]>): SelectBuilder | ErrorResult",
+	"insert": "insert(bundle: string): InsertBuilder | ErrorResult",
+	"update": "update(bundle: string): UpdateBuilder | ErrorResult",
+	"delete": "delete(bundle: string): DeleteBuilder | ErrorResult",
+
+	// Hooks
+	"registerHook":      "registerHook(hook: HookConfig): Promise<SuccessResult>",
+	"unregisterHook":    "unregisterHook(name: string): Promise<SuccessResult>",
+	"getHooks":          "getHooks(): Promise<{ hooks: string[]; count: number }>",
+	"enableHook":        "enableHook(name: string): Promise<SuccessResult>",
+	"disableHook":       "disableHook(name: string): Promise<SuccessResult>",
+	"createLoggingHook": "createLoggingHook(options?: LoggingHookOptions): Promise<BuiltinHookResult>",
+	"createMetricsHook": "createMetricsHook(): Promise<BuiltinHookResult>",
+	"getMetricsStats":   "getMetricsStats(): Promise<Record<string, any>>",
+	"resetMetrics":      "resetMetrics(): Promise<SuccessResult>",
+	"createTracingHook": "createTracingHook(serviceName?: string): Promise<BuiltinHookResult>",
+
+	// Cleanup
+	"cleanup": "cleanup(): SuccessResult",
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"syscall/js"
 	"time"
 
@@ -37,6 +39,9 @@ var (
 
 	// Built-in hooks instances (Milestone 5)
 	builtinHooks = make(map[string]client.Hook)
+
+	// JS migration scripts, registered again on each new migration client
+	jsMigrationScripts = make(map[string]migration.ScriptFunc)
 )
 
 // clientExecutorAdapter adapts client.Client to migration.MigrationExecutor interface
@@ -48,7 +53,11 @@ type clientExecutorAdapter struct {
 func (a *clientExecutorAdapter) Execute(command string) (interface{}, error) {
 	// Use Query for SELECT, SHOW, etc. and Mutate for DDL/DML
 	// For migrations, most commands will be mutations (CREATE TABLE, ALTER, etc.)
-	return a.client.Mutate(command, 0)
+	result, err := a.client.Mutate(command, 0)
+	if err != nil {
+		return nil, err
+	}
+	return result.Raw, nil
 }
 
 // convertJSValueToInterface converts a JavaScript value to a Go interface{}
@@ -102,6 +111,8 @@ func makeExports() js.Value {
 	exports["disconnect"] = js.FuncOf(disconnect)
 	exports["query"] = js.FuncOf(query)
 	exports["mutate"] = js.FuncOf(mutate)
+	exports["queryStream"] = js.FuncOf(queryStream)
+	exports["queryBinary"] = js.FuncOf(queryBinary)
 	exports["getState"] = js.FuncOf(getState)
 	exports["onStateChange"] = js.FuncOf(onStateChange)
 	exports["getVersion"] = js.FuncOf(getVersion)
@@ -130,6 +141,7 @@ func makeExports() js.Value {
 	exports["validateMigration"] = js.FuncOf(validateMigration)
 	exports["rollbackMigration"] = js.FuncOf(rollbackMigration)
 	exports["previewMigration"] = js.FuncOf(previewMigration)
+	exports["registerMigrationScript"] = js.FuncOf(registerMigrationScript)
 
 	// Migration file operations (Node.js only)
 	exports["saveMigrationFile"] = js.FuncOf(nodeOnlyExport("saveMigrationFile", saveMigrationFile))
@@ -141,6 +153,20 @@ func makeExports() js.Value {
 	// Environment info
 	exports["getEnvironmentInfo"] = js.FuncOf(getEnvironmentInfo)
 
+	// Browser persistence
+	exports["configureStorage"] = js.FuncOf(configureStorage)
+	exports["getSchema"] = js.FuncOf(getSchema)
+	exports["restoreStatements"] = js.FuncOf(restoreStatements)
+	exports["clearStorage"] = js.FuncOf(clearStorage)
+
+	// Offline write queue
+	exports["enableOfflineQueue"] = js.FuncOf(enableOfflineQueue)
+	exports["disableOfflineQueue"] = js.FuncOf(disableOfflineQueue)
+	exports["getOfflineQueue"] = js.FuncOf(getOfflineQueue)
+	exports["removeOfflineEntry"] = js.FuncOf(removeOfflineEntry)
+	exports["clearOfflineQueue"] = js.FuncOf(clearOfflineQueue)
+	exports["replayOfflineQueue"] = js.FuncOf(replayOfflineQueue)
+
 	// Parameterized queries (Milestone 2)
 	exports["prepare"] = js.FuncOf(prepare)
 	exports["executeStatement"] = js.FuncOf(executeStatement)
@@ -153,10 +179,18 @@ func makeExports() js.Value {
 	exports["rollbackTransaction"] = js.FuncOf(rollbackTransaction)
 	exports["inTransaction"] = js.FuncOf(inTransaction)
 
+	// Query builders
+	exports["select"] = js.FuncOf(selectBuilder)
+	exports["insert"] = js.FuncOf(insertBuilder)
+	exports["update"] = js.FuncOf(updateBuilder)
+	exports["delete"] = js.FuncOf(deleteBuilder)
+
 	// Hooks System (Milestone 5)
 	exports["registerHook"] = js.FuncOf(registerHook)
 	exports["unregisterHook"] = js.FuncOf(unregisterHook)
 	exports["getHooks"] = js.FuncOf(getHooks)
+	exports["enableHook"] = js.FuncOf(enableHook)
+	exports["disableHook"] = js.FuncOf(disableHook)
 	exports["createLoggingHook"] = js.FuncOf(createLoggingHook)
 	exports["createMetricsHook"] = js.FuncOf(createMetricsHook)
 	exports["getMetricsStats"] = js.FuncOf(getMetricsStats)
@@ -287,7 +321,7 @@ func mutate(this js.Value, args []js.Value) interface{} {
 			return nil, err
 		}
 
-		return result, nil
+		return mutationResultToJS(result), nil
 	})
 }
 
@@ -466,6 +500,9 @@ func createMigrationClient(this js.Value, args []js.Value) interface{} {
 
 		adapter := &clientExecutorAdapter{client: globalClient}
 		globalMigrationClient = migration.NewClient(adapter)
+		for name, fn := range jsMigrationScripts {
+			globalMigrationClient.RegisterScript(name, fn)
+		}
 
 		return map[string]interface{}{
 			"success": true,
@@ -553,6 +590,81 @@ func applyMigration(this js.Value, args []js.Value) interface{} {
 	})
 }
 
+// registerMigrationScript registers a JavaScript function for SCRIPT "name"; migration steps.
+// Args: name (string), fn (function({execute(command): Promise}) => Promise|void)
+// Returns: Promise<{success: boolean}>
+func registerMigrationScript(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if len(args) < 2 || args[1].Type() != js.TypeFunction {
+			return nil, &js.ValueError{Method: "registerMigrationScript", Type: js.TypeUndefined}
+		}
+
+		name := args[0].String()
+		fn := jsMigrationScript(args[1])
+		jsMigrationScripts[name] = fn
+		if globalMigrationClient != nil {
+			globalMigrationClient.RegisterScript(name, fn)
+		}
+
+		return map[string]interface{}{
+			"success": true,
+			"message": "Migration script registered: " + name,
+		}, nil
+	})
+}
+
+// jsMigrationScript wraps a JavaScript function as a migration.ScriptFunc. The
+// function receives an object whose execute(command) returns a Promise, and
+// may return a Promise that the step waits for.
+func jsMigrationScript(fn js.Value) migration.ScriptFunc {
+	return func(exec migration.MigrationExecutor) error {
+		executeFunc := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) < 1 {
+				return promiseWrapper(func() (interface{}, error) {
+					return nil, &js.ValueError{Method: "execute", Type: js.TypeUndefined}
+				})
+			}
+			command := args[0].String()
+			return promiseWrapper(func() (interface{}, error) {
+				result, err := exec.Execute(command)
+				if err != nil {
+					return nil, err
+				}
+				return result, nil
+			})
+		})
+		defer executeFunc.Release()
+
+		jsExec := js.Global().Get("Object").New()
+		jsExec.Set("execute", executeFunc)
+
+		result := fn.Invoke(jsExec)
+		if result.Type() != js.TypeObject || result.Get("then").Type() != js.TypeFunction {
+			return nil
+		}
+
+		// It's a Promise - wait for it
+		resultChan := make(chan error, 1)
+		onResolve := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			resultChan <- nil
+			return nil
+		})
+		onReject := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			if len(args) > 0 {
+				resultChan <- js.Error{Value: args[0]}
+			} else {
+				resultChan <- js.Error{Value: js.ValueOf("migration script error")}
+			}
+			return nil
+		})
+		defer onResolve.Release()
+		defer onReject.Release()
+
+		result.Call("then", onResolve, onReject)
+		return <-resultChan
+	}
+}
+
 // cleanup releases resources and callbacks
 func cleanup(this js.Value, args []js.Value) interface{} {
 	if globalClient != nil {
@@ -589,6 +701,7 @@ func prepare(this js.Value, args []js.Value) interface{} {
 
 		// Store statement reference
 		preparedStatements[stmtName] = stmt
+		persistStatement(stmtName, stmt.Query())
 
 		return map[string]interface{}{
 			"statementId": stmtName,
@@ -657,6 +770,7 @@ func deallocateStatement(this js.Value, args []js.Value) interface{} {
 		}
 
 		delete(preparedStatements, stmtID)
+		forgetStatement(stmtID)
 
 		return map[string]interface{}{"success": true}, nil
 	})
@@ -888,17 +1002,44 @@ func promiseWrapper(fn func() (interface{}, error)) js.Value {
 				}
 				reject.Invoke(js.ValueOf(errorObj))
 			} else {
-				resolve.Invoke(js.ValueOf(result))
+				resolve.Invoke(resultToJS(result))
 			}
 		}()
 
 		return nil
 	})
 
+	// The executor runs synchronously inside the Promise constructor, so it
+	// can be released as soon as the promise exists.
+	defer handler.Release()
+
 	promiseConstructor := js.Global().Get("Promise")
 	return promiseConstructor.New(handler)
 }
 
+// mutationResultToJS converts a MutationResult to a plain object for JavaScript.
+func mutationResultToJS(result *client.MutationResult) map[string]interface{} {
+	ids := make([]interface{}, len(result.InsertedIDs))
+	for i, id := range result.InsertedIDs {
+		ids[i] = id
+	}
+	warnings := make([]interface{}, len(result.Warnings))
+	for i, warning := range result.Warnings {
+		warnings[i] = warning
+	}
+	obj := map[string]interface{}{
+		"affectedCount": result.AffectedCount,
+		"insertedIds":   ids,
+		"warnings":      warnings,
+		"result":        result.Raw,
+	}
+	if result.Queued {
+		obj["queued"] = true
+		obj["queueId"] = result.QueueID
+	}
+	return obj
+}
+
 // Migration helper methods
 
 // getMigrationHistory retrieves migration history
@@ -1050,27 +1191,22 @@ func isNodeJS() bool {
 // nodeOnlyExport wraps a function to check for Node.js environment
 func nodeOnlyExport(name string, fn func(js.Value, []js.Value) interface{}) func(js.Value, []js.Value) interface{} {
 	return func(this js.Value, args []js.Value) interface{} {
-		return promiseWrapper(func() (interface{}, error) {
-			if !isNodeJS() {
+		if !isNodeJS() {
+			return promiseWrapper(func() (interface{}, error) {
 				return map[string]interface{}{
 					"error":   "This feature requires Node.js environment",
 					"feature": name,
 				}, nil
-			}
-
-			// Unwrap the promise from the inner function
-			_ = fn(this, args)
+			})
+		}
 
-			// If it's already a promise, we need to handle it differently
-			// For now, return an error indicating implementation needed
-			return map[string]interface{}{
-				"error": "Node.js file operations not yet fully implemented in WASM",
-			}, nil
-		})
+		return fn(this, args)
 	}
 }
 
 // saveMigrationFile saves a migration to file (Node.js only)
+// Args: migration (object), directory (string)
+// Returns: Promise<{success: boolean, path: string}>
 func saveMigrationFile(this js.Value, args []js.Value) interface{} {
 	return promiseWrapper(func() (interface{}, error) {
 		if len(args) < 2 {
@@ -1090,9 +1226,17 @@ func saveMigrationFile(this js.Value, args []js.Value) interface{} {
 		}
 
 		dir := args[1].String()
+		if dir == "" {
+			return nil, fmt.Errorf("directory path cannot be empty")
+		}
+
+		data, err := migration.EncodeMigrationFile(&mig)
+		if err != nil {
+			return nil, err
+		}
 
-		// Write file
-		path, err := migration.WriteMigrationFile(&mig, dir)
+		// Write file through Node's fs module
+		path, err := nodeWriteFile(dir, migration.MigrationFileName(&mig), data)
 		if err != nil {
 			return nil, err
 		}
@@ -1105,6 +1249,8 @@ func saveMigrationFile(this js.Value, args []js.Value) interface{} {
 }
 
 // loadMigrationFile loads a migration from file (Node.js only)
+// Args: path (string)
+// Returns: Promise<migration>
 func loadMigrationFile(this js.Value, args []js.Value) interface{} {
 	return promiseWrapper(func() (interface{}, error) {
 		if len(args) < 1 {
@@ -1112,9 +1258,17 @@ func loadMigrationFile(this js.Value, args []js.Value) interface{} {
 		}
 
 		path := args[0].String()
+		if path == "" {
+			return nil, fmt.Errorf("file path cannot be empty")
+		}
 
-		// Read file
-		mig, err := migration.ReadMigrationFile(path)
+		// Read file through Node's fs module
+		data, err := nodeReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		mig, err := migration.DecodeMigrationFile(data)
 		if err != nil {
 			return nil, err
 		}
@@ -1135,6 +1289,8 @@ func loadMigrationFile(this js.Value, args []js.Value) interface{} {
 }
 
 // listMigrations lists migration files in directory (Node.js only)
+// Args: directory (string)
+// Returns: Promise<migration[]> sorted by timestamp
 func listMigrations(this js.Value, args []js.Value) interface{} {
 	return promiseWrapper(func() (interface{}, error) {
 		if len(args) < 1 {
@@ -1142,13 +1298,41 @@ func listMigrations(this js.Value, args []js.Value) interface{} {
 		}
 
 		dir := args[0].String()
+		if dir == "" {
+			return nil, fmt.Errorf("directory path cannot be empty")
+		}
 
-		// List files
-		migrations, err := migration.ListMigrationFiles(dir)
+		names, err := nodeListFiles(dir)
 		if err != nil {
 			return nil, err
 		}
 
+		// Same filtering rules as migration.ListMigrationFiles
+		migrations := make([]*migration.Migration, 0, len(names))
+		for _, name := range names {
+			if !strings.HasSuffix(name, ".json") || strings.HasPrefix(name, ".") {
+				continue
+			}
+
+			data, err := nodeReadFile(dir + "/" + name)
+			if err != nil {
+				return nil, err
+			}
+
+			mig, err := migration.DecodeMigrationFile(data)
+			if err != nil {
+				// Log warning but continue processing other files
+				fmt.Printf("Warning: failed to read migration file %s: %v\n", name, err)
+				continue
+			}
+
+			migrations = append(migrations, mig)
+		}
+
+		sort.Slice(migrations, func(i, j int) bool {
+			return migrations[i].Timestamp.Before(migrations[j].Timestamp)
+		})
+
 		// Serialize to JavaScript
 		migsJSON, err := json.Marshal(migrations)
 		if err != nil {
@@ -1356,6 +1540,17 @@ func convertHookContextToJS(hookCtx *client.HookContext) js.Value {
 		obj.Set("durationMs", float64(hookCtx.Duration.Milliseconds()))
 	}
 
+	obj.Set("attempt", hookCtx.Attempt)
+	if hookCtx.ConnectionID != 0 {
+		obj.Set("connectionId", float64(hookCtx.ConnectionID))
+	}
+	if hookCtx.RemoteAddr != "" {
+		obj.Set("remoteAddr", hookCtx.RemoteAddr)
+	}
+	if hookCtx.PoolWaitDuration > 0 {
+		obj.Set("poolWaitMs", float64(hookCtx.PoolWaitDuration.Microseconds())/1000)
+	}
+
 	return obj
 }
 
@@ -1408,8 +1603,12 @@ func registerHook(this js.Value, args []js.Value) interface{} {
 		// Store reference
 		jsHooks[name] = hook
 
-		// Register with client
-		globalClient.RegisterHook(hook)
+		// Register with client, at the optional priority
+		if priority := hookConfig.Get("priority"); priority.Type() == js.TypeNumber {
+			globalClient.RegisterHookWithPriority(hook, priority.Int())
+		} else {
+			globalClient.RegisterHook(hook)
+		}
 
 		return map[string]interface{}{
 			"success": true,
@@ -1445,6 +1644,41 @@ func unregisterHook(this js.Value, args []js.Value) interface{} {
 	})
 }
 
+// enableHook resumes a hook paused with disableHook
+func enableHook(this js.Value, args []js.Value) interface{} {
+	return setHookEnabled("enableHook", args, true)
+}
+
+// disableHook pauses a hook without unregistering it
+func disableHook(this js.Value, args []js.Value) interface{} {
+	return setHookEnabled("disableHook", args, false)
+}
+
+func setHookEnabled(method string, args []js.Value, enabled bool) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, js.Error{Value: js.ValueOf("client not connected")}
+		}
+
+		if len(args) < 1 {
+			return nil, &js.ValueError{Method: method, Type: js.TypeUndefined}
+		}
+
+		name := args[0].String()
+		var found bool
+		if enabled {
+			found = globalClient.EnableHook(name)
+		} else {
+			found = globalClient.DisableHook(name)
+		}
+
+		return map[string]interface{}{
+			"success": found,
+			"message": map[bool]string{true: "Hook updated", false: "Hook not found"}[found],
+		}, nil
+	})
+}
+
 // getHooks returns list of registered hooks
 func getHooks(this js.Value, args []js.Value) interface{} {
 	return promiseWrapper(func() (interface{}, error) {
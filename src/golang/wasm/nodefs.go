@@ -0,0 +1,115 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"fmt"
+	"path"
+	"syscall/js"
+)
+
+// ============================================================================
+// Node.js filesystem bridge
+// ============================================================================
+
+// The Go js/wasm runtime only reaches the real filesystem when the host installs
+// Node's fs module as globalThis.fs before starting the program. The browser
+// wasm_exec.js shim installs a stub instead, so migration file operations call
+// Node's fs module directly through syscall/js.
+
+// nodeFS returns Node's fs module.
+// Resolution order: require("fs"), process.getBuiltinModule("fs") (ESM), globalThis.fs.
+func nodeFS() (fs js.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to load Node.js fs module: %v", r)
+		}
+	}()
+
+	if require := js.Global().Get("require"); require.Type() == js.TypeFunction {
+		return require.Invoke("fs"), nil
+	}
+
+	process := js.Global().Get("process")
+	if process.Truthy() && process.Get("getBuiltinModule").Type() == js.TypeFunction {
+		return process.Call("getBuiltinModule", "fs"), nil
+	}
+
+	if fs := js.Global().Get("fs"); fs.Truthy() && fs.Get("readFileSync").Type() == js.TypeFunction {
+		return fs, nil
+	}
+
+	return js.Undefined(), fmt.Errorf("Node.js fs module is not available")
+}
+
+// fsCall invokes a synchronous fs method, converting thrown JavaScript exceptions to Go errors.
+func fsCall(method string, args ...interface{}) (result js.Value, err error) {
+	fs, err := nodeFS()
+	if err != nil {
+		return js.Undefined(), err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if jsErr, ok := r.(js.Error); ok {
+				err = fmt.Errorf("fs.%s failed: %s", method, jsErr.Get("message").String())
+				return
+			}
+			err = fmt.Errorf("fs.%s failed: %v", method, r)
+		}
+	}()
+
+	return fs.Call(method, args...), nil
+}
+
+// nodeWriteFile creates dir (recursively) and writes data to dir/name with 0644 permissions.
+func nodeWriteFile(dir, name string, data []byte) (string, error) {
+	if _, err := fsCall("mkdirSync", dir, map[string]interface{}{"recursive": true}); err != nil {
+		return "", err
+	}
+
+	filePath := path.Join(dir, name)
+	if _, err := fsCall("writeFileSync", filePath, string(data), map[string]interface{}{"mode": 0644}); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// nodeReadFile reads a UTF-8 file.
+func nodeReadFile(filePath string) ([]byte, error) {
+	contents, err := fsCall("readFileSync", filePath, "utf8")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(contents.String()), nil
+}
+
+// nodeListFiles returns the names of regular files in dir.
+// A missing directory yields an empty list, matching migration.ListMigrationFiles.
+func nodeListFiles(dir string) ([]string, error) {
+	exists, err := fsCall("existsSync", dir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists.Bool() {
+		return []string{}, nil
+	}
+
+	entries, err := fsCall("readdirSync", dir, map[string]interface{}{"withFileTypes": true})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, entries.Length())
+	for i := 0; i < entries.Length(); i++ {
+		entry := entries.Index(i)
+		if !entry.Call("isFile").Bool() {
+			continue
+		}
+		names = append(names, entry.Get("name").String())
+	}
+
+	return names, nil
+}
@@ -0,0 +1,208 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"syscall/js"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// ============================================================================
+// Offline write queue
+// ============================================================================
+
+const offlineStoragePrefix = "queue:"
+
+// storageOfflineStore persists the client's offline queue in the browser
+// storage, one key per entry, so queued writes survive a page reload.
+type storageOfflineStore struct {
+	store keyValueStore
+}
+
+// offlineKey zero-pads the ID so keys sort in queue order.
+func offlineKey(id uint64) string {
+	return fmt.Sprintf("%s%020d", offlineStoragePrefix, id)
+}
+
+func (s storageOfflineStore) Load() ([]client.OfflineEntry, error) {
+	keys, err := s.store.Keys(offlineStoragePrefix)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]client.OfflineEntry, 0, len(keys))
+	for _, key := range keys {
+		data, ok, err := s.store.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		var entry client.OfflineEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("corrupt offline queue entry %s: %w", key, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s storageOfflineStore) Save(entry client.OfflineEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.store.Put(offlineKey(entry.ID), string(data))
+}
+
+func (s storageOfflineStore) Remove(id uint64) error {
+	return s.store.Delete(offlineKey(id))
+}
+
+// enableOfflineQueue turns on offline mode: while disconnected, mutate and the
+// insert/update/delete builders resolve with { queued: true, queueId } and the
+// writes are replayed in order on reconnect.
+//
+//	await SyndrDB.enableOfflineQueue({
+//	    maxEntries: 1000,                    // default 1000
+//	    autoReplay: true,                    // replay on reconnect (default true)
+//	    onConflict: (entry, error) => 'skip' // or 'stop'; may return a Promise
+//	});
+//
+// Entries are persisted through the configured storage backend.
+func enableOfflineQueue(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "enableOfflineQueue", Type: js.TypeNull}
+		}
+
+		opts := client.OfflineQueueOptions{Store: storageOfflineStore{store: getStorage()}}
+		if len(args) > 0 && args[0].Type() == js.TypeObject {
+			config := args[0]
+			if v := config.Get("maxEntries"); v.Type() == js.TypeNumber {
+				opts.MaxEntries = v.Int()
+			}
+			if v := config.Get("autoReplay"); v.Type() == js.TypeBoolean {
+				opts.DisableAutoReplay = !v.Bool()
+			}
+			if onConflict := config.Get("onConflict"); onConflict.Type() == js.TypeFunction {
+				opts.OnConflict = jsConflictHandler(onConflict)
+			}
+		}
+
+		if err := globalClient.EnableOfflineQueue(opts); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"success": true,
+			"pending": len(globalClient.OfflineQueue()),
+		}, nil
+	})
+}
+
+// jsConflictHandler adapts a JS onConflict callback. Any result other than
+// "stop" (including a rejected promise) skips the entry.
+func jsConflictHandler(fn js.Value) client.ConflictHandler {
+	return func(entry client.OfflineEntry, err error) client.ConflictResolution {
+		result, awaitErr := awaitJSValue(fn.Invoke(js.ValueOf(offlineEntryToJS(entry)), err.Error()))
+		if awaitErr == nil && result.Type() == js.TypeString && strings.EqualFold(result.String(), "stop") {
+			return client.ConflictStop
+		}
+		return client.ConflictSkip
+	}
+}
+
+// disableOfflineQueue turns off offline mode; pending entries stay in storage.
+func disableOfflineQueue(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "disableOfflineQueue", Type: js.TypeNull}
+		}
+		globalClient.DisableOfflineQueue()
+		return map[string]interface{}{"success": true}, nil
+	})
+}
+
+// getOfflineQueue returns the pending entries in replay order.
+func getOfflineQueue(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "getOfflineQueue", Type: js.TypeNull}
+		}
+		pending := globalClient.OfflineQueue()
+		entries := make([]interface{}, len(pending))
+		for i, entry := range pending {
+			entries[i] = offlineEntryToJS(entry)
+		}
+		return map[string]interface{}{"entries": entries, "size": len(entries)}, nil
+	})
+}
+
+// removeOfflineEntry drops a pending entry by queueId.
+func removeOfflineEntry(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "removeOfflineEntry", Type: js.TypeNull}
+		}
+		if len(args) < 1 || args[0].Type() != js.TypeNumber {
+			return nil, &js.ValueError{Method: "removeOfflineEntry", Type: js.TypeUndefined}
+		}
+		if err := globalClient.RemoveOfflineEntry(uint64(args[0].Int())); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"success": true}, nil
+	})
+}
+
+// clearOfflineQueue drops every pending entry.
+func clearOfflineQueue(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "clearOfflineQueue", Type: js.TypeNull}
+		}
+		if err := globalClient.ClearOfflineQueue(); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"success": true}, nil
+	})
+}
+
+// replayOfflineQueue sends pending entries now, e.g. with autoReplay: false.
+// Resolves with { replayed, skipped, remaining, error? }.
+func replayOfflineQueue(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "replayOfflineQueue", Type: js.TypeNull}
+		}
+		result, err := globalClient.ReplayOfflineQueue(context.Background())
+		summary := map[string]interface{}{
+			"replayed":  result.Replayed,
+			"skipped":   result.Skipped,
+			"remaining": result.Remaining,
+		}
+		if err != nil {
+			summary["error"] = err.Error()
+		}
+		return summary, nil
+	})
+}
+
+// offlineEntryToJS converts a queue entry to a plain object.
+func offlineEntryToJS(entry client.OfflineEntry) map[string]interface{} {
+	obj := map[string]interface{}{
+		"id":       entry.ID,
+		"command":  entry.Command,
+		"queuedAt": entry.QueuedAt.UnixMilli(),
+		"attempts": entry.Attempts,
+	}
+	if entry.LastError != "" {
+		obj["lastError"] = entry.LastError
+	}
+	return obj
+}
@@ -0,0 +1,541 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/schema"
+)
+
+// ============================================================================
+// Browser persistence
+// ============================================================================
+
+// Browsers have no filesystem, so state the driver keeps between page loads
+// (schema snapshots, prepared statement metadata, the offline queue) goes
+// through a keyValueStore. IndexedDB is preferred; localStorage is the fallback
+// where IndexedDB is unavailable, and Node.js or locked-down contexts get an
+// in-memory store that lasts as long as the module.
+//
+// IndexedDB is asynchronous, so store methods block on JavaScript callbacks and
+// must only be called from goroutines (e.g. inside promiseWrapper), never
+// directly from an export's synchronous body.
+
+const (
+	storageIndexedDB    = "indexeddb"
+	storageLocalStorage = "localstorage"
+	storageMemory       = "memory"
+
+	defaultStorageName = "syndrdb"
+	idbObjectStore     = "kv"
+)
+
+// keyValueStore persists string values by key.
+type keyValueStore interface {
+	// Backend returns the backend name: indexeddb, localstorage or memory.
+	Backend() string
+	// Get returns the value for key and whether it exists.
+	Get(key string) (string, bool, error)
+	Put(key, value string) error
+	Delete(key string) error
+	// Keys returns the keys starting with prefix, sorted.
+	Keys(prefix string) ([]string, error)
+}
+
+var (
+	storageMu     sync.Mutex
+	globalStorage keyValueStore
+)
+
+// getStorage returns the configured store, opening the default one on first use.
+func getStorage() keyValueStore {
+	storageMu.Lock()
+	defer storageMu.Unlock()
+	if globalStorage == nil {
+		globalStorage = openStorage("auto", defaultStorageName)
+	}
+	return globalStorage
+}
+
+// openStorage opens the named backend, or the best available one for "auto".
+// An unavailable backend falls back to the next one, ending with memory.
+func openStorage(backend, name string) keyValueStore {
+	if name == "" {
+		name = defaultStorageName
+	}
+	switch backend {
+	case "auto", "", storageIndexedDB:
+		if store, err := openIndexedDB(name); err == nil {
+			return store
+		}
+		fallthrough
+	case storageLocalStorage:
+		if store, ok := openLocalStorage(name); ok {
+			return store
+		}
+	}
+	return newMemoryStore()
+}
+
+// ---------------------------------------------------------------------------
+// Memory
+// ---------------------------------------------------------------------------
+
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{values: make(map[string]string)}
+}
+
+func (m *memoryStore) Backend() string { return storageMemory }
+
+func (m *memoryStore) Get(key string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.values[key]
+	return value, ok, nil
+}
+
+func (m *memoryStore) Put(key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	return nil
+}
+
+func (m *memoryStore) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	return nil
+}
+
+func (m *memoryStore) Keys(prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for key := range m.values {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// ---------------------------------------------------------------------------
+// localStorage
+// ---------------------------------------------------------------------------
+
+// localStore namespaces keys as "<name>:<key>" in window.localStorage.
+type localStore struct {
+	storage js.Value
+	prefix  string
+}
+
+// openLocalStorage returns a localStorage store, or false when localStorage is
+// missing or throws on access (e.g. disabled storage, opaque origins).
+func openLocalStorage(name string) (store *localStore, ok bool) {
+	defer func() {
+		if recover() != nil {
+			store, ok = nil, false
+		}
+	}()
+
+	storage := js.Global().Get("localStorage")
+	if !storage.Truthy() || storage.Get("setItem").Type() != js.TypeFunction {
+		return nil, false
+	}
+	return &localStore{storage: storage, prefix: name + ":"}, true
+}
+
+func (l *localStore) Backend() string { return storageLocalStorage }
+
+func (l *localStore) Get(key string) (value string, ok bool, err error) {
+	err = jsCall("localStorage.getItem", func() {
+		item := l.storage.Call("getItem", l.prefix+key)
+		if item.Type() == js.TypeString {
+			value, ok = item.String(), true
+		}
+	})
+	return value, ok, err
+}
+
+func (l *localStore) Put(key, value string) error {
+	return jsCall("localStorage.setItem", func() {
+		l.storage.Call("setItem", l.prefix+key, value)
+	})
+}
+
+func (l *localStore) Delete(key string) error {
+	return jsCall("localStorage.removeItem", func() {
+		l.storage.Call("removeItem", l.prefix+key)
+	})
+}
+
+func (l *localStore) Keys(prefix string) (keys []string, err error) {
+	err = jsCall("localStorage.key", func() {
+		full := l.prefix + prefix
+		for i := 0; i < l.storage.Get("length").Int(); i++ {
+			key := l.storage.Call("key", i)
+			if key.Type() == js.TypeString && strings.HasPrefix(key.String(), full) {
+				keys = append(keys, strings.TrimPrefix(key.String(), l.prefix))
+			}
+		}
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+// jsCall runs fn, converting a thrown JavaScript exception (e.g. QuotaExceededError) to an error.
+func jsCall(method string, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if jsErr, ok := r.(js.Error); ok {
+				err = fmt.Errorf("%s failed: %s", method, jsErr.Get("message").String())
+				return
+			}
+			err = fmt.Errorf("%s failed: %v", method, r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// IndexedDB
+// ---------------------------------------------------------------------------
+
+// idbStore keeps values in the "kv" object store of the IndexedDB database name.
+type idbStore struct {
+	db js.Value
+}
+
+// openIndexedDB opens (creating if needed) the database name.
+func openIndexedDB(name string) (*idbStore, error) {
+	factory := js.Global().Get("indexedDB")
+	if !factory.Truthy() {
+		return nil, fmt.Errorf("indexedDB is not available")
+	}
+
+	var request js.Value
+	if err := jsCall("indexedDB.open", func() { request = factory.Call("open", name, 1) }); err != nil {
+		return nil, err
+	}
+	upgrade := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		db := request.Get("result")
+		if !db.Get("objectStoreNames").Call("contains", idbObjectStore).Bool() {
+			db.Call("createObjectStore", idbObjectStore)
+		}
+		return nil
+	})
+	defer upgrade.Release()
+	request.Set("onupgradeneeded", upgrade)
+
+	db, err := awaitIDBRequest("indexedDB.open", request)
+	if err != nil {
+		return nil, err
+	}
+	return &idbStore{db: db}, nil
+}
+
+func (s *idbStore) Backend() string { return storageIndexedDB }
+
+// request starts op on the object store in a new transaction and waits for its result.
+func (s *idbStore) request(method, mode string, op func(store js.Value) js.Value) (js.Value, error) {
+	var request js.Value
+	err := jsCall("IndexedDB "+method, func() {
+		tx := s.db.Call("transaction", idbObjectStore, mode)
+		request = op(tx.Call("objectStore", idbObjectStore))
+	})
+	if err != nil {
+		return js.Undefined(), err
+	}
+	return awaitIDBRequest("IndexedDB "+method, request)
+}
+
+func (s *idbStore) Get(key string) (string, bool, error) {
+	result, err := s.request("get", "readonly", func(store js.Value) js.Value {
+		return store.Call("get", key)
+	})
+	if err != nil || result.Type() != js.TypeString {
+		return "", false, err
+	}
+	return result.String(), true, nil
+}
+
+func (s *idbStore) Put(key, value string) error {
+	_, err := s.request("put", "readwrite", func(store js.Value) js.Value {
+		return store.Call("put", value, key)
+	})
+	return err
+}
+
+func (s *idbStore) Delete(key string) error {
+	_, err := s.request("delete", "readwrite", func(store js.Value) js.Value {
+		return store.Call("delete", key)
+	})
+	return err
+}
+
+func (s *idbStore) Keys(prefix string) ([]string, error) {
+	result, err := s.request("getAllKeys", "readonly", func(store js.Value) js.Value {
+		keyRange := js.Global().Get("IDBKeyRange").Call("bound", prefix, prefix+"\uffff")
+		return store.Call("getAllKeys", keyRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, result.Length())
+	for i := 0; i < result.Length(); i++ {
+		keys = append(keys, result.Index(i).String())
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// awaitIDBRequest waits for an IDBRequest to succeed or fail.
+func awaitIDBRequest(method string, request js.Value) (js.Value, error) {
+	type outcome struct {
+		result js.Value
+		err    error
+	}
+	done := make(chan outcome, 1)
+
+	onSuccess := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		done <- outcome{result: request.Get("result")}
+		return nil
+	})
+	onError := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		message := "request failed"
+		if reqErr := request.Get("error"); reqErr.Truthy() {
+			message = reqErr.Get("message").String()
+		}
+		done <- outcome{err: fmt.Errorf("%s failed: %s", method, message)}
+		return nil
+	})
+	defer onSuccess.Release()
+	defer onError.Release()
+
+	request.Set("onsuccess", onSuccess)
+	request.Set("onerror", onError)
+	result := <-done
+	return result.result, result.err
+}
+
+// ---------------------------------------------------------------------------
+// Exports
+// ---------------------------------------------------------------------------
+
+const (
+	schemaStorageKey       = "schema"
+	statementStoragePrefix = "statement:"
+)
+
+// storedSchema is the schema snapshot kept for offline use.
+type storedSchema struct {
+	Schema  *schema.SchemaDefinition `json:"schema"`
+	SavedAt time.Time                `json:"savedAt"`
+}
+
+// storedStatement is the metadata needed to prepare a statement again after a reload.
+type storedStatement struct {
+	StatementID string    `json:"statementId"`
+	Query       string    `json:"query"`
+	PreparedAt  time.Time `json:"preparedAt"`
+}
+
+// configureStorage selects the persistence backend.
+//
+//	await SyndrDB.configureStorage({ backend: "auto", name: "syndrdb" });
+//
+// backend is "auto" (default), "indexeddb", "localstorage" or "memory";
+// name is the IndexedDB database name or localStorage key prefix.
+// Resolves with the backend actually in use after fallback.
+func configureStorage(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		backend, name := "auto", defaultStorageName
+		if len(args) > 0 && args[0].Type() == js.TypeObject {
+			if v := args[0].Get("backend"); v.Type() == js.TypeString {
+				backend = strings.ToLower(v.String())
+			}
+			if v := args[0].Get("name"); v.Type() == js.TypeString && v.String() != "" {
+				name = v.String()
+			}
+		}
+		switch backend {
+		case "auto", storageIndexedDB, storageLocalStorage, storageMemory:
+		default:
+			return nil, fmt.Errorf("unknown storage backend %q (use auto, indexeddb, localstorage or memory)", backend)
+		}
+
+		store := openStorage(backend, name)
+		storageMu.Lock()
+		globalStorage = store
+		storageMu.Unlock()
+
+		return map[string]interface{}{"backend": store.Backend(), "name": name}, nil
+	})
+}
+
+// getSchema fetches the database schema and keeps a snapshot in storage.
+// When the client is not connected or the fetch fails, the stored snapshot is
+// returned with cached: true; it rejects only when there is no snapshot.
+func getSchema(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		store := getStorage()
+
+		var fetchErr error
+		if globalClient != nil && globalClient.GetState() == client.CONNECTED {
+			schemaDef, err := globalClient.GetSchema(context.Background())
+			if err == nil {
+				snapshot := storedSchema{Schema: schemaDef, SavedAt: time.Now()}
+				if data, err := json.Marshal(snapshot); err == nil {
+					if err := store.Put(schemaStorageKey, string(data)); err != nil {
+						consoleWarn(fmt.Sprintf("SyndrDB: failed to persist schema snapshot: %v", err))
+					}
+				}
+				return schemaSnapshotResult(snapshot, false)
+			}
+			fetchErr = err
+		}
+
+		data, ok, err := store.Get(schemaStorageKey)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if fetchErr != nil {
+				return nil, fetchErr
+			}
+			return nil, fmt.Errorf("no cached schema in %s storage and client is not connected", store.Backend())
+		}
+		var snapshot storedSchema
+		if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+			return nil, fmt.Errorf("cached schema is corrupt: %w", err)
+		}
+		return schemaSnapshotResult(snapshot, true)
+	})
+}
+
+// schemaSnapshotResult converts a snapshot to the getSchema result object.
+func schemaSnapshotResult(snapshot storedSchema, cached bool) (interface{}, error) {
+	schemaJSON, err := json.Marshal(snapshot.Schema)
+	if err != nil {
+		return nil, err
+	}
+	var schemaObj interface{}
+	if err := json.Unmarshal(schemaJSON, &schemaObj); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"schema":  schemaObj,
+		"cached":  cached,
+		"savedAt": snapshot.SavedAt.UnixMilli(),
+	}, nil
+}
+
+// persistStatement records a prepared statement so restoreStatements can
+// prepare it again after a reload. Failures are logged, not returned.
+func persistStatement(statementID, query string) {
+	data, err := json.Marshal(storedStatement{StatementID: statementID, Query: query, PreparedAt: time.Now()})
+	if err == nil {
+		err = getStorage().Put(statementStoragePrefix+statementID, string(data))
+	}
+	if err != nil {
+		consoleWarn(fmt.Sprintf("SyndrDB: failed to persist prepared statement %q: %v", statementID, err))
+	}
+}
+
+// forgetStatement removes a deallocated statement from storage.
+func forgetStatement(statementID string) {
+	if err := getStorage().Delete(statementStoragePrefix + statementID); err != nil {
+		consoleWarn(fmt.Sprintf("SyndrDB: failed to remove prepared statement %q: %v", statementID, err))
+	}
+}
+
+// consoleWarn reports a non-fatal storage problem without failing the operation.
+func consoleWarn(message string) {
+	if console := js.Global().Get("console"); console.Truthy() {
+		console.Call("warn", message)
+	}
+}
+
+// restoreStatements prepares every statement recorded in storage on the
+// current connection, e.g. after a page reload.
+// Resolves with { restored: [...ids], failed: [{ statementId, error }] }.
+func restoreStatements(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "restoreStatements", Type: js.TypeNull}
+		}
+
+		store := getStorage()
+		keys, err := store.Keys(statementStoragePrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		restored := []interface{}{}
+		failed := []interface{}{}
+		for _, key := range keys {
+			data, ok, err := store.Get(key)
+			if err != nil || !ok {
+				continue
+			}
+			var meta storedStatement
+			if err := json.Unmarshal([]byte(data), &meta); err != nil {
+				failed = append(failed, map[string]interface{}{"statementId": strings.TrimPrefix(key, statementStoragePrefix), "error": err.Error()})
+				continue
+			}
+			if _, exists := preparedStatements[meta.StatementID]; exists {
+				restored = append(restored, meta.StatementID)
+				continue
+			}
+			stmt, err := globalClient.Prepare(context.Background(), meta.StatementID, meta.Query)
+			if err != nil {
+				failed = append(failed, map[string]interface{}{"statementId": meta.StatementID, "error": err.Error()})
+				continue
+			}
+			preparedStatements[meta.StatementID] = stmt
+			restored = append(restored, meta.StatementID)
+		}
+
+		return map[string]interface{}{"restored": restored, "failed": failed}, nil
+	})
+}
+
+// clearStorage removes the stored keys starting with prefix (all keys when omitted).
+func clearStorage(this js.Value, args []js.Value) interface{} {
+	return promiseWrapper(func() (interface{}, error) {
+		prefix := ""
+		if len(args) > 0 && args[0].Type() == js.TypeString {
+			prefix = args[0].String()
+		}
+
+		store := getStorage()
+		keys, err := store.Keys(prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if err := store.Delete(key); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]interface{}{"removed": len(keys)}, nil
+	})
+}
@@ -0,0 +1,246 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"context"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/dan-strohschein/syndrdb-drivers/src/golang/client"
+)
+
+// ============================================================================
+// Streaming Queries
+// ============================================================================
+
+// streamControl is the pause/resume/cancel state shared between a JS stream
+// controller and the goroutine delivering batches.
+type streamControl struct {
+	mu        sync.Mutex
+	paused    bool
+	cancelled bool
+	wake      chan struct{}
+}
+
+func newStreamControl() *streamControl {
+	return &streamControl{wake: make(chan struct{}, 1)}
+}
+
+func (s *streamControl) set(paused, cancelled bool) {
+	s.mu.Lock()
+	s.paused = paused
+	s.cancelled = s.cancelled || cancelled
+	s.mu.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// wait blocks while the stream is paused and reports whether it was cancelled.
+func (s *streamControl) wait() bool {
+	for {
+		s.mu.Lock()
+		paused, cancelled := s.paused, s.cancelled
+		s.mu.Unlock()
+		if cancelled {
+			return true
+		}
+		if !paused {
+			return false
+		}
+		<-s.wake
+	}
+}
+
+// queryStream executes a query and delivers its rows to JavaScript in batches.
+//
+//	const stream = SyndrDB.queryStream(query, {
+//	    batchSize: 500,                   // rows per batch (default 500)
+//	    timeout: 30000,                   // query timeout in ms
+//	    onBatch: async (rows, info) => {}, // awaited before the next batch
+//	    onRow: (row, index) => {},
+//	    binary: false,                    // pass onBatch a Uint8Array of JSON
+//	});
+//	stream.pause(); stream.resume(); stream.cancel();
+//	const { delivered, total, cancelled } = await stream.done;
+//
+// Batches are converted to JS one at a time, with a yield to the event loop
+// between them, so large results do not block the page.
+func queryStream(this js.Value, args []js.Value) interface{} {
+	control := newStreamControl()
+
+	controller := js.Global().Get("Object").New()
+	controller.Set("pause", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		control.set(true, false)
+		return nil
+	}))
+	controller.Set("resume", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		control.set(false, false)
+		return nil
+	}))
+	controller.Set("cancel", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		control.set(false, true)
+		return nil
+	}))
+
+	controller.Set("done", promiseWrapper(func() (interface{}, error) {
+		if globalClient == nil {
+			return nil, &js.ValueError{Method: "queryStream", Type: js.TypeNull}
+		}
+		if len(args) < 1 {
+			return nil, &js.ValueError{Method: "queryStream", Type: js.TypeUndefined}
+		}
+
+		queryStr := args[0].String()
+		opts := js.Undefined()
+		if len(args) > 1 && args[1].Type() == js.TypeObject {
+			opts = args[1]
+		}
+		batchSize := 0
+		timeout := 0
+		binary := false
+		onBatch, onRow := js.Undefined(), js.Undefined()
+		if !opts.IsUndefined() {
+			binary = opts.Get("binary").Truthy()
+			if v := opts.Get("batchSize"); v.Type() == js.TypeNumber {
+				batchSize = v.Int()
+			}
+			if v := opts.Get("timeout"); v.Type() == js.TypeNumber {
+				timeout = v.Int()
+			}
+			onBatch, onRow = opts.Get("onBatch"), opts.Get("onRow")
+		}
+
+		ctx := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+			defer cancel()
+		}
+
+		stream, err := globalClient.QueryStream(ctx, queryStr, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		defer stream.Close()
+
+		return deliverStream(stream, control, onBatch, onRow, binary)
+	}))
+
+	return controller
+}
+
+// deliverStream passes each batch of stream to the JS callbacks, honouring
+// pause/cancel and awaiting promises they return. With binary set, onBatch
+// receives each batch as a Uint8Array of JSON instead of converted rows.
+func deliverStream(stream *client.RowStream, control *streamControl, onBatch, onRow js.Value, binary bool) (interface{}, error) {
+	cancelled := false
+	for index := 0; ; index++ {
+		if control.wait() {
+			cancelled = true
+			break
+		}
+		offset := stream.Delivered()
+		batch := stream.NextBatch()
+		if batch == nil {
+			break
+		}
+
+		if onBatch.Type() == js.TypeFunction {
+			info := map[string]interface{}{
+				"index":  index,
+				"offset": offset,
+				"total":  stream.Total(),
+			}
+			var rows js.Value
+			if binary {
+				encoded, err := encodeResult(batch)
+				if err != nil {
+					return nil, err
+				}
+				rows = bytesToJS(encoded)
+			} else {
+				rows = js.ValueOf(batch)
+			}
+			if err := awaitJS(onBatch.Invoke(rows, js.ValueOf(info))); err != nil {
+				return nil, err
+			}
+		}
+		if onRow.Type() == js.TypeFunction {
+			for i, row := range batch {
+				if err := awaitJS(onRow.Invoke(js.ValueOf(row), offset+i)); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		yieldToEventLoop()
+	}
+	if err := stream.Err(); err != nil && !cancelled {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"delivered": stream.Delivered(),
+		"total":     stream.Total(),
+		"cancelled": cancelled,
+	}, nil
+}
+
+// awaitJS waits for value to settle if it is a Promise, returning its rejection as an error.
+func awaitJS(value js.Value) error {
+	_, err := awaitJSValue(value)
+	return err
+}
+
+// awaitJSValue waits for value to settle if it is a Promise and returns the resolved value.
+func awaitJSValue(value js.Value) (js.Value, error) {
+	if value.Type() != js.TypeObject || value.Get("then").Type() != js.TypeFunction {
+		return value, nil
+	}
+
+	type outcome struct {
+		value js.Value
+		err   error
+	}
+	done := make(chan outcome, 1)
+	onResolve := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		result := js.Undefined()
+		if len(args) > 0 {
+			result = args[0]
+		}
+		done <- outcome{value: result}
+		return nil
+	})
+	onReject := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		reason := js.ValueOf("promise rejected")
+		if len(args) > 0 {
+			reason = args[0]
+		}
+		done <- outcome{err: js.Error{Value: reason}}
+		return nil
+	})
+	defer onResolve.Release()
+	defer onReject.Release()
+
+	value.Call("then", onResolve, onReject)
+	result := <-done
+	return result.value, result.err
+}
+
+// yieldToEventLoop lets the browser render and handle input before the next batch.
+func yieldToEventLoop() {
+	done := make(chan struct{})
+	callback := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		close(done)
+		return nil
+	})
+	defer callback.Release()
+
+	js.Global().Call("setTimeout", callback, 0)
+	<-done
+}
@@ -0,0 +1,211 @@
+/**
+ * Main-thread client for syndrdb.worker.js.
+ *
+ * Starts the SyndrDB WASM module in a Web Worker and returns a proxy with the
+ * same methods as the SyndrDB global, each returning a Promise:
+ *
+ *   const db = createSyndrDBWorker({ workerUrl: '/syndrdb.worker.js' });
+ *   db.on('stateChange', (t) => console.log(`${t.from} -> ${t.to}`));
+ *   await db.ready;
+ *   await db.createClient(null);
+ *   await db.connect('syndrdb://localhost:1776:primary:root:root;');
+ *   const rows = await db.select('users', 'name').where('age', '>', 21).limit(10).execute();
+ *
+ * Methods are forwarded by name, so every export of the module is available
+ * without changes here. Arguments and results must be structured-cloneable:
+ * hooks and migration scripts, which take functions, must be registered in
+ * the worker itself.
+ */
+
+(function (root) {
+    'use strict';
+
+    const BUILDERS = new Set(['select', 'insert', 'update', 'delete']);
+    const BUILDER_TERMINALS = new Set(['execute', 'fingerprint']);
+
+    function createSyndrDBWorker(options) {
+        const opts = options || {};
+        const worker = opts.worker || new Worker(opts.workerUrl || 'syndrdb.worker.js');
+        const pending = new Map();
+        const streams = new Map();
+        const listeners = { stateChange: new Set(), error: new Set() };
+        let nextId = 1;
+
+        function emit(event, payload) {
+            listeners[event].forEach((listener) => listener(payload));
+        }
+
+        function toError(error) {
+            const err = new Error(error && error.message ? error.message : String(error));
+            err.error = err.message;
+            return err;
+        }
+
+        const ready = new Promise((resolve, reject) => {
+            pending.set(0, {
+                resolve: (info) => resolve(info),
+                reject: (err) => reject(err),
+            });
+        });
+
+        worker.onmessage = (event) => {
+            const message = event.data || {};
+            switch (message.type) {
+            case 'ready':
+                settle(0, 'resolve', { version: message.version, methods: message.methods });
+                break;
+            case 'initError':
+                settle(0, 'reject', toError(message.error));
+                break;
+            case 'result':
+                settle(message.id, 'resolve', message.result);
+                break;
+            case 'error':
+                settle(message.id, 'reject', toError(message.error));
+                break;
+            case 'stateChange':
+                emit('stateChange', message.transition);
+                break;
+            case 'batch':
+                deliverBatch(message);
+                break;
+            default:
+                break;
+            }
+        };
+
+        worker.postMessage({ type: 'init', wasmUrl: opts.wasmUrl, wasmExecUrl: opts.wasmExecUrl });
+
+        worker.onerror = (event) => {
+            const err = new Error(event && event.message ? event.message : 'worker error');
+            emit('error', err);
+            pending.forEach((entry) => entry.reject(err));
+            pending.clear();
+        };
+
+        function settle(id, outcome, value) {
+            const entry = pending.get(id);
+            if (!entry) {
+                return;
+            }
+            pending.delete(id);
+            streams.delete(id);
+            entry[outcome](value);
+        }
+
+        // post sends message once the module is loaded, keeping call order.
+        function post(message) {
+            return ready.then(() => worker.postMessage(message));
+        }
+
+        function send(message) {
+            const id = nextId++;
+            const promise = new Promise((resolve, reject) => pending.set(id, { resolve, reject }));
+            post({ ...message, id }).catch((err) => settle(id, 'reject', err));
+            return { id, promise };
+        }
+
+        function call(method, args, chain) {
+            return send({ type: 'call', method, args, chain }).promise;
+        }
+
+        // builder records chained calls and sends them in one message on execute() or fingerprint().
+        function builder(method, args) {
+            const chain = [];
+            const proxy = new Proxy({}, {
+                get(target, name) {
+                    if (name === 'then') {
+                        return undefined;
+                    }
+                    return (...chainArgs) => {
+                        chain.push([name, chainArgs]);
+                        return BUILDER_TERMINALS.has(name) ? call(method, args, chain) : proxy;
+                    };
+                },
+            });
+            return proxy;
+        }
+
+        function deliverBatch(message) {
+            const stream = streams.get(message.id);
+            if (!stream) {
+                return;
+            }
+            const { onBatch, onRow } = stream.callbacks;
+            (async () => {
+                try {
+                    if (typeof onBatch === 'function') {
+                        await onBatch(message.rows, message.info);
+                    }
+                    if (typeof onRow === 'function' && Array.isArray(message.rows)) {
+                        for (let i = 0; i < message.rows.length; i++) {
+                            await onRow(message.rows[i], message.info.offset + i);
+                        }
+                    }
+                } catch (err) {
+                    emit('error', err);
+                    worker.postMessage({ type: 'streamControl', id: message.id, action: 'cancel' });
+                }
+                worker.postMessage({ type: 'ack', id: message.id, index: message.info.index });
+            })();
+        }
+
+        function queryStream(query, streamOptions) {
+            const { onBatch, onRow, ...cloneable } = streamOptions || {};
+            const { id, promise } = send({ type: 'stream', query, options: cloneable });
+            streams.set(id, { callbacks: { onBatch, onRow } });
+            const control = (action) => post({ type: 'streamControl', id, action }).catch(() => {});
+            return {
+                pause: () => control('pause'),
+                resume: () => control('resume'),
+                cancel: () => control('cancel'),
+                done: promise,
+            };
+        }
+
+        const api = {
+            ready,
+            worker,
+            call: (method, ...args) => call(method, args),
+            queryStream,
+            on(event, listener) {
+                if (!listeners[event]) {
+                    throw new Error(`unknown event: ${event}`);
+                }
+                listeners[event].add(listener);
+            },
+            off(event, listener) {
+                if (listeners[event]) {
+                    listeners[event].delete(listener);
+                }
+            },
+            async terminate() {
+                try {
+                    await call('cleanup', []);
+                } finally {
+                    worker.terminate();
+                    pending.forEach((entry) => entry.reject(new Error('worker terminated')));
+                    pending.clear();
+                }
+            },
+        };
+
+        return new Proxy(api, {
+            get(target, name) {
+                if (name in target || typeof name !== 'string' || name === 'then') {
+                    return target[name];
+                }
+                if (BUILDERS.has(name)) {
+                    return (...args) => builder(name, args);
+                }
+                return (...args) => call(name, args);
+            },
+        });
+    }
+
+    if (typeof module !== 'undefined' && module.exports) {
+        module.exports = { createSyndrDBWorker };
+    } else {
+        root.createSyndrDBWorker = createSyndrDBWorker;
+    }
+})(typeof globalThis !== 'undefined' ? globalThis : this);
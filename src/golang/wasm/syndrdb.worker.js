@@ -0,0 +1,155 @@
+/**
+ * SyndrDB Web Worker entrypoint.
+ *
+ * Runs syndrdb.wasm inside a Web Worker and exposes the SyndrDB global over
+ * postMessage, so database work never blocks the main thread. Use it through
+ * syndrdb-worker-client.js rather than posting messages by hand.
+ *
+ * Protocol (main thread -> worker):
+ *   { type: 'init', wasmUrl, wasmExecUrl }
+ *   { type: 'call', id, method, args, chain? }   chain: [[method, args], ...]
+ *   { type: 'stream', id, query, options }        options without callbacks
+ *   { type: 'ack', id, index }                    batch handled, send the next
+ *   { type: 'streamControl', id, action }         'pause' | 'resume' | 'cancel'
+ *
+ * Protocol (worker -> main thread):
+ *   { type: 'ready', version, methods }
+ *   { type: 'initError', error }
+ *   { type: 'result', id, result }
+ *   { type: 'error', id, error: { message } }
+ *   { type: 'batch', id, rows, info }
+ *   { type: 'stateChange', transition }
+ */
+
+'use strict';
+
+const streams = new Map();
+
+function post(message, transfer) {
+    self.postMessage(message, transfer || []);
+}
+
+// transferList moves Uint8Array results (queryBinary, binary streams) instead of copying them.
+function transferList(value) {
+    return value instanceof Uint8Array ? [value.buffer] : [];
+}
+
+function errorMessage(err) {
+    if (err && typeof err === 'object' && 'message' in err) {
+        return String(err.message);
+    }
+    return String(err);
+}
+
+// forwardStateChanges relays connection state transitions to the main thread.
+// cleanup() drops state callbacks, so it is registered again after each cleanup.
+function forwardStateChanges() {
+    SyndrDB.onStateChange((transition) => post({ type: 'stateChange', transition }));
+}
+
+async function init(message) {
+    try {
+        importScripts(message.wasmExecUrl || 'wasm_exec.js');
+        const go = new Go();
+        const wasmUrl = message.wasmUrl || 'syndrdb.wasm';
+        const result = await WebAssembly.instantiateStreaming(fetch(wasmUrl), go.importObject);
+        go.run(result.instance);
+
+        forwardStateChanges();
+        post({ type: 'ready', version: SyndrDB.getVersion(), methods: Object.keys(SyndrDB) });
+    } catch (err) {
+        post({ type: 'initError', error: { message: errorMessage(err) } });
+    }
+}
+
+async function call(message) {
+    const fn = SyndrDB[message.method];
+    if (typeof fn !== 'function' || message.method === 'onStateChange') {
+        throw new Error(`unknown method: ${message.method}`);
+    }
+
+    let value = fn(...(message.args || []));
+    for (const [method, args] of message.chain || []) {
+        if (value && value.error) {
+            throw new Error(value.error);
+        }
+        if (!value || typeof value[method] !== 'function') {
+            throw new Error(`${message.method}: unknown builder method ${method}`);
+        }
+        value = value[method](...(args || []));
+    }
+
+    value = await value;
+    if (message.method === 'cleanup') {
+        forwardStateChanges();
+    }
+    if (value && typeof value === 'object' && !(value instanceof Uint8Array) && typeof value.execute === 'function') {
+        throw new Error(`${message.method}: builders must end with execute() in worker mode`);
+    }
+    return value;
+}
+
+function stream(message) {
+    const pending = new Map();
+    const state = { acks: pending };
+    streams.set(message.id, state);
+
+    const controller = SyndrDB.queryStream(message.query, {
+        ...(message.options || {}),
+        onBatch: (rows, info) => new Promise((resolve) => {
+            pending.set(info.index, resolve);
+            post({ type: 'batch', id: message.id, rows, info }, transferList(rows));
+        }),
+    });
+    state.controller = controller;
+
+    controller.done
+        .then((result) => post({ type: 'result', id: message.id, result }))
+        .catch((err) => post({ type: 'error', id: message.id, error: { message: errorMessage(err) } }))
+        .finally(() => streams.delete(message.id));
+}
+
+self.onmessage = (event) => {
+    const message = event.data || {};
+    switch (message.type) {
+    case 'init':
+        init(message);
+        break;
+    case 'call':
+        call(message)
+            .then((result) => post({ type: 'result', id: message.id, result }, transferList(result)))
+            .catch((err) => post({ type: 'error', id: message.id, error: { message: errorMessage(err) } }));
+        break;
+    case 'stream':
+        try {
+            stream(message);
+        } catch (err) {
+            post({ type: 'error', id: message.id, error: { message: errorMessage(err) } });
+        }
+        break;
+    case 'ack': {
+        const state = streams.get(message.id);
+        if (state) {
+            const resolve = state.acks.get(message.index);
+            state.acks.delete(message.index);
+            if (resolve) {
+                resolve();
+            }
+        }
+        break;
+    }
+    case 'streamControl': {
+        const state = streams.get(message.id);
+        if (state && state.controller && typeof state.controller[message.action] === 'function') {
+            state.controller[message.action]();
+            if (message.action === 'cancel') {
+                state.acks.forEach((resolve) => resolve());
+                state.acks.clear();
+            }
+        }
+        break;
+    }
+    default:
+        post({ type: 'error', id: message.id, error: { message: `unknown message type: ${message.type}` } });
+    }
+};